@@ -0,0 +1,69 @@
+// Package kafkasink defines Kafka sink for Centrifuge analytics events.
+package kafkasink
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/centrifugal/centrifuge"
+
+	"github.com/Shopify/sarama"
+)
+
+// Config of Sink.
+type Config struct {
+	// Brokers is a list of Kafka brokers.
+	Brokers []string
+	// Topic to produce events to.
+	Topic string
+	// SaramaConfig allows to set custom Sarama config.
+	SaramaConfig *sarama.Config
+}
+
+// Sink produces analytics events into Kafka topic as JSON. Events keyed by
+// client ID so events of one connection keep order within partition.
+type Sink struct {
+	config   Config
+	producer sarama.SyncProducer
+}
+
+// New creates Sink.
+func New(conf Config) (*Sink, error) {
+	saramaConfig := conf.SaramaConfig
+	if saramaConfig == nil {
+		saramaConfig = sarama.NewConfig()
+		saramaConfig.Producer.RequiredAcks = sarama.WaitForLocal
+	}
+	// Required by SyncProducer.
+	saramaConfig.Producer.Return.Successes = true
+	producer, err := sarama.NewSyncProducer(conf.Brokers, saramaConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &Sink{
+		config:   conf,
+		producer: producer,
+	}, nil
+}
+
+// Export is a part of centrifuge.AnalyticsSink interface.
+func (s *Sink) Export(ctx context.Context, events []centrifuge.AnalyticsEvent) error {
+	messages := make([]*sarama.ProducerMessage, 0, len(events))
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, &sarama.ProducerMessage{
+			Topic: s.config.Topic,
+			Key:   sarama.StringEncoder(event.Client),
+			Value: sarama.ByteEncoder(data),
+		})
+	}
+	return s.producer.SendMessages(messages)
+}
+
+// Close closes Kafka producer.
+func (s *Sink) Close() error {
+	return s.producer.Close()
+}
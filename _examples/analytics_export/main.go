@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/centrifugal/centrifuge"
+	"github.com/centrifugal/centrifuge/_examples/analytics_export/kafkasink"
+)
+
+var (
+	port       = flag.Int("port", 8000, "Port to bind app to")
+	sinkName   = flag.String("sink", "clickhouse", "Sink to export events to: clickhouse or kafka")
+	clickhouse = flag.String("clickhouse", "http://localhost:8123", "ClickHouse HTTP endpoint")
+	brokers    = flag.String("brokers", "localhost:9092", "Comma separated list of Kafka brokers")
+	topic      = flag.String("topic", "centrifuge_events", "Kafka topic to produce events to")
+	sampleRate = flag.Float64("sample_rate", 1, "Part of events to export")
+)
+
+func handleLog(e centrifuge.LogEntry) {
+	log.Printf("[centrifuge] %s: %v", e.Message, e.Fields)
+}
+
+func authMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		ctx = centrifuge.SetCredentials(ctx, &centrifuge.Credentials{
+			UserID: "42",
+		})
+		r = r.WithContext(ctx)
+		h.ServeHTTP(w, r)
+	})
+}
+
+func waitExitSignal(n *centrifuge.Node) {
+	sigs := make(chan os.Signal, 1)
+	done := make(chan bool, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		n.Shutdown(context.Background())
+		done <- true
+	}()
+	<-done
+}
+
+func newSink() (centrifuge.AnalyticsSink, error) {
+	if *sinkName == "kafka" {
+		return kafkasink.New(kafkasink.Config{
+			Brokers: strings.Split(*brokers, ","),
+			Topic:   *topic,
+		})
+	}
+	return centrifuge.NewClickHouseSink(centrifuge.ClickHouseConfig{
+		Endpoint: *clickhouse,
+	}), nil
+}
+
+func main() {
+	flag.Parse()
+
+	cfg := centrifuge.DefaultConfig
+	cfg.LogLevel = centrifuge.LogLevelDebug
+	cfg.LogHandler = handleLog
+
+	node, _ := centrifuge.New(cfg)
+
+	sink, err := newSink()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := node.SetAnalytics(centrifuge.AnalyticsConfig{
+		Sink:       sink,
+		SampleRate: *sampleRate,
+	}); err != nil {
+		log.Fatal(err)
+	}
+
+	node.On().ClientConnected(func(ctx context.Context, client *centrifuge.Client) {
+		client.On().Subscribe(func(e centrifuge.SubscribeEvent) centrifuge.SubscribeReply {
+			return centrifuge.SubscribeReply{}
+		})
+	})
+
+	if err := node.Run(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Publish message into channel every second.
+	go func() {
+		for {
+			node.Publish("index", []byte(`{"time": "`+time.Now().String()+`"}`))
+			time.Sleep(time.Second)
+		}
+	}()
+
+	http.Handle("/connection/websocket", authMiddleware(centrifuge.NewWebsocketHandler(node, centrifuge.WebsocketConfig{})))
+	http.Handle("/", http.FileServer(http.Dir("./")))
+
+	go func() {
+		if err := http.ListenAndServe(":"+strconv.Itoa(*port), nil); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	waitExitSignal(node)
+	log.Println("bye!")
+}
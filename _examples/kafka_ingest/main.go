@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/centrifugal/centrifuge"
+)
+
+var (
+	port    = flag.Int("port", 8000, "Port to bind app to")
+	brokers = flag.String("brokers", "localhost:9092", "Comma separated list of Kafka brokers")
+	topic   = flag.String("topic", "centrifuge", "Kafka topic to consume")
+	group   = flag.String("group", "centrifuge-kafka-ingest-example", "Kafka consumer group")
+)
+
+func handleLog(e centrifuge.LogEntry) {
+	log.Printf("[centrifuge] %s: %v", e.Message, e.Fields)
+}
+
+func authMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		ctx = centrifuge.SetCredentials(ctx, &centrifuge.Credentials{
+			UserID: "42",
+		})
+		r = r.WithContext(ctx)
+		h.ServeHTTP(w, r)
+	})
+}
+
+func waitExitSignal(n *centrifuge.Node, ingest *centrifuge.KafkaIngest) {
+	sigs := make(chan os.Signal, 1)
+	done := make(chan bool, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		ingest.Close()
+		n.Shutdown(context.Background())
+		done <- true
+	}()
+	<-done
+}
+
+func main() {
+	flag.Parse()
+
+	cfg := centrifuge.DefaultConfig
+	cfg.LogLevel = centrifuge.LogLevelDebug
+	cfg.LogHandler = handleLog
+
+	cfg.Namespaces = []centrifuge.ChannelNamespace{
+		centrifuge.ChannelNamespace{
+			Name: "kafka",
+			ChannelOptions: centrifuge.ChannelOptions{
+				HistoryLifetime: 60,
+				HistorySize:     100,
+				HistoryRecover:  true,
+			},
+		},
+	}
+
+	node, _ := centrifuge.New(cfg)
+
+	node.On().ClientConnected(func(ctx context.Context, client *centrifuge.Client) {
+		client.On().Subscribe(func(e centrifuge.SubscribeEvent) centrifuge.SubscribeReply {
+			log.Printf("user %s subscribes on %s", client.UserID(), e.Channel)
+			return centrifuge.SubscribeReply{}
+		})
+	})
+
+	if err := node.Run(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Records with "centrifuge-channel" header or key set to "kafka:index"
+	// will be delivered to connected browsers.
+	ingest, err := centrifuge.NewKafkaIngest(node, centrifuge.KafkaIngestConfig{
+		Brokers: strings.Split(*brokers, ","),
+		Topics:  []string{*topic},
+		Group:   *group,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ingest.Run(); err != nil {
+		log.Fatal(err)
+	}
+
+	http.Handle("/connection/websocket", authMiddleware(centrifuge.NewWebsocketHandler(node, centrifuge.WebsocketConfig{})))
+	http.Handle("/", http.FileServer(http.Dir("./")))
+
+	go func() {
+		if err := http.ListenAndServe(":"+strconv.Itoa(*port), nil); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	waitExitSignal(node, ingest)
+	log.Println("bye!")
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/centrifugal/centrifuge"
+)
+
+var (
+	port    = flag.Int("port", 8000, "Port to bind app to")
+	brokers = flag.String("brokers", "tcp://localhost:1883", "Comma separated list of MQTT brokers")
+	filter  = flag.String("filter", "sensors/#", "MQTT topic filter to subscribe to")
+	qos     = flag.Int("qos", 1, "MQTT QoS level to subscribe with")
+)
+
+func handleLog(e centrifuge.LogEntry) {
+	log.Printf("[centrifuge] %s: %v", e.Message, e.Fields)
+}
+
+func authMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		ctx = centrifuge.SetCredentials(ctx, &centrifuge.Credentials{
+			UserID: "42",
+		})
+		r = r.WithContext(ctx)
+		h.ServeHTTP(w, r)
+	})
+}
+
+func waitExitSignal(n *centrifuge.Node, ingest *centrifuge.MQTTIngest) {
+	sigs := make(chan os.Signal, 1)
+	done := make(chan bool, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		ingest.Close()
+		n.Shutdown(context.Background())
+		done <- true
+	}()
+	<-done
+}
+
+func main() {
+	flag.Parse()
+
+	cfg := centrifuge.DefaultConfig
+	cfg.LogLevel = centrifuge.LogLevelDebug
+	cfg.LogHandler = handleLog
+
+	cfg.Namespaces = []centrifuge.ChannelNamespace{
+		centrifuge.ChannelNamespace{
+			Name: "sensors",
+			ChannelOptions: centrifuge.ChannelOptions{
+				HistoryLifetime: 60,
+				HistorySize:     100,
+				HistoryRecover:  true,
+			},
+		},
+	}
+
+	node, _ := centrifuge.New(cfg)
+
+	node.On().ClientConnected(func(ctx context.Context, client *centrifuge.Client) {
+		client.On().Subscribe(func(e centrifuge.SubscribeEvent) centrifuge.SubscribeReply {
+			log.Printf("user %s subscribes on %s", client.UserID(), e.Channel)
+			return centrifuge.SubscribeReply{}
+		})
+	})
+
+	if err := node.Run(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Messages from topic sensors/index will be delivered into channel
+	// sensors:index to connected browsers.
+	ingest, err := centrifuge.NewMQTTIngest(node, centrifuge.MQTTIngestConfig{
+		Brokers:  strings.Split(*brokers, ","),
+		ClientID: "centrifuge-mqtt-ingest-example",
+		Filters:  map[string]byte{*filter: byte(*qos)},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ingest.Run(); err != nil {
+		log.Fatal(err)
+	}
+
+	http.Handle("/connection/websocket", authMiddleware(centrifuge.NewWebsocketHandler(node, centrifuge.WebsocketConfig{})))
+	http.Handle("/", http.FileServer(http.Dir("./")))
+
+	go func() {
+		if err := http.ListenAndServe(":"+strconv.Itoa(*port), nil); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	waitExitSignal(node, ingest)
+	log.Println("bye!")
+}
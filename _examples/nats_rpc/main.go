@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/centrifugal/centrifuge"
+
+	"github.com/nats-io/nats.go"
+)
+
+var (
+	port    = flag.Int("port", 8000, "Port to bind app to")
+	servers = flag.String("servers", nats.DefaultURL, "Comma separated list of NATS servers")
+)
+
+func handleLog(e centrifuge.LogEntry) {
+	log.Printf("[centrifuge] %s: %v", e.Message, e.Fields)
+}
+
+func authMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		ctx = centrifuge.SetCredentials(ctx, &centrifuge.Credentials{
+			UserID: "42",
+		})
+		r = r.WithContext(ctx)
+		h.ServeHTTP(w, r)
+	})
+}
+
+func waitExitSignal(n *centrifuge.Node, proxy *centrifuge.NATSRPCProxy) {
+	sigs := make(chan os.Signal, 1)
+	done := make(chan bool, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		proxy.Close()
+		n.Shutdown(context.Background())
+		done <- true
+	}()
+	<-done
+}
+
+// runResponder emulates microservice which answers on "time" RPC method.
+func runResponder() error {
+	nc, err := nats.Connect(*servers)
+	if err != nil {
+		return err
+	}
+	_, err = nc.Subscribe("rpc.time", func(msg *nats.Msg) {
+		var req struct {
+			User   string `json:"user"`
+			Method string `json:"method"`
+		}
+		if err := json.Unmarshal(msg.Data, &req); err != nil {
+			return
+		}
+		log.Printf("user %s calls %s", req.User, req.Method)
+		data, _ := json.Marshal(map[string]interface{}{
+			"result": map[string]interface{}{
+				"data": map[string]string{"time": time.Now().String()},
+			},
+		})
+		nc.Publish(msg.Reply, data)
+	})
+	return err
+}
+
+func main() {
+	flag.Parse()
+
+	cfg := centrifuge.DefaultConfig
+	cfg.LogLevel = centrifuge.LogLevelDebug
+	cfg.LogHandler = handleLog
+
+	node, _ := centrifuge.New(cfg)
+
+	proxy, err := centrifuge.NewNATSRPCProxy(node, centrifuge.NATSRPCProxyConfig{
+		Servers: *servers,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	proxy.Bind()
+
+	if err := node.Run(); err != nil {
+		log.Fatal(err)
+	}
+	if err := runResponder(); err != nil {
+		log.Fatal(err)
+	}
+
+	http.Handle("/connection/websocket", authMiddleware(centrifuge.NewWebsocketHandler(node, centrifuge.WebsocketConfig{})))
+	http.Handle("/", http.FileServer(http.Dir("./")))
+
+	go func() {
+		if err := http.ListenAndServe(":"+strconv.Itoa(*port), nil); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	waitExitSignal(node, proxy)
+	log.Println("bye!")
+}
@@ -0,0 +1,73 @@
+package centrifuge
+
+import (
+	"testing"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAcknowledger struct {
+	acks    []uint64
+	nacks   []uint64
+	rejects []uint64
+	requeue bool
+}
+
+func (a *testAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acks = append(a.acks, tag)
+	return nil
+}
+
+func (a *testAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.nacks = append(a.nacks, tag)
+	a.requeue = requeue
+	return nil
+}
+
+func (a *testAcknowledger) Reject(tag uint64, requeue bool) error {
+	a.rejects = append(a.rejects, tag)
+	a.requeue = requeue
+	return nil
+}
+
+func TestAMQPIngestDefaultChannel(t *testing.T) {
+	ch, err := defaultAMQPChannel(amqp.Delivery{RoutingKey: "chat.index", Headers: amqp.Table{AMQPChannelHeader: "header"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "header", ch)
+	ch, err = defaultAMQPChannel(amqp.Delivery{RoutingKey: "chat.index"})
+	assert.NoError(t, err)
+	assert.Equal(t, "chat:index", ch)
+	_, err = defaultAMQPChannel(amqp.Delivery{})
+	assert.Equal(t, ErrAMQPNoChannel, err)
+}
+
+func TestAMQPIngestAckOnPublish(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	ingest, err := NewAMQPIngest(node, AMQPIngestConfig{Queue: "test"})
+	assert.NoError(t, err)
+
+	acknowledger := &testAcknowledger{}
+	deliveries := make(chan amqp.Delivery, 3)
+	deliveries <- amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: 1, RoutingKey: "test", Body: []byte(`{}`)}
+	deliveries <- amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: 2}
+	close(deliveries)
+	assert.Error(t, ingest.processDeliveries(deliveries))
+	assert.Equal(t, []uint64{1}, acknowledger.acks)
+	// Message without channel rejected without requeue.
+	assert.Equal(t, []uint64{2}, acknowledger.rejects)
+	assert.False(t, acknowledger.requeue)
+}
+
+func TestAMQPIngestRequeueOnPublishError(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	node.SetBroker(&failingPublishBroker{testMemoryEngine()})
+	ingest, err := NewAMQPIngest(node, AMQPIngestConfig{Queue: "test"})
+	assert.NoError(t, err)
+
+	acknowledger := &testAcknowledger{}
+	assert.NoError(t, ingest.process(amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: 1, RoutingKey: "test"}))
+	assert.Len(t, acknowledger.acks, 0)
+	assert.Equal(t, []uint64{1}, acknowledger.nacks)
+	assert.True(t, acknowledger.requeue)
+}
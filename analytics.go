@@ -0,0 +1,223 @@
+package centrifuge
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"time"
+)
+
+// Analytics event types.
+const (
+	// AnalyticsEventConnect emitted when client connection authenticated.
+	AnalyticsEventConnect = "connect"
+	// AnalyticsEventDisconnect emitted when authenticated client connection closed.
+	AnalyticsEventDisconnect = "disconnect"
+	// AnalyticsEventSubscribe emitted when client subscribed on channel.
+	AnalyticsEventSubscribe = "subscribe"
+	// AnalyticsEventUnsubscribe emitted when client unsubscribed from channel.
+	AnalyticsEventUnsubscribe = "unsubscribe"
+	// AnalyticsEventDelivery emitted when publication delivered to client
+	// subscribed on channel.
+	AnalyticsEventDelivery = "delivery"
+)
+
+// AnalyticsEvent describes connection, subscription or publication delivery
+// event. All fields always present in exported events (empty when not
+// applicable) so events can be inserted into table with fixed schema like:
+//
+//	CREATE TABLE centrifuge_events (
+//		time        DateTime64(3),
+//		type        LowCardinality(String),
+//		node        String,
+//		client      String,
+//		user        String,
+//		channel     String,
+//		publication String,
+//		transport   LowCardinality(String)
+//	) ENGINE = MergeTree() ORDER BY (type, time)
+type AnalyticsEvent struct {
+	// Time is a Unix time of event in milliseconds.
+	Time int64 `json:"time"`
+	// Type is one of connect, disconnect, subscribe, unsubscribe, delivery.
+	Type string `json:"type"`
+	// Node is an ID of node where event happened.
+	Node string `json:"node"`
+	// Client is an ID of client connection.
+	Client string `json:"client"`
+	// User is an ID of user.
+	User string `json:"user"`
+	// Channel of subscription or delivery.
+	Channel string `json:"channel"`
+	// Publication is an UID of delivered publication.
+	Publication string `json:"publication"`
+	// Transport is a name of client transport.
+	Transport string `json:"transport"`
+}
+
+// AnalyticsSink exports batches of analytics events.
+type AnalyticsSink interface {
+	Export(ctx context.Context, events []AnalyticsEvent) error
+}
+
+// AnalyticsConfig is a config for analytics export.
+type AnalyticsConfig struct {
+	// Sink to export events to.
+	Sink AnalyticsSink
+	// SampleRate is a part of events exported, from 0 to 1, 1 by default.
+	// Connection and subscription events sampled by client ID, delivery
+	// events sampled by publication UID – so for every sampled publication
+	// all its deliveries exported.
+	SampleRate float64
+	// BatchSize is a max number of events exported at once, 1000 by default.
+	BatchSize int
+	// FlushInterval is a max time event waits in batch, 1 second by default.
+	FlushInterval time.Duration
+	// QueueSize is a max number of events waiting for export, 10000 by
+	// default. Events are dropped when queue is full.
+	QueueSize int
+	// ExportTimeout is a timeout of single Export call, 10 seconds by default.
+	ExportTimeout time.Duration
+}
+
+const analyticsSampleScale = 1000000
+
+// analyticsExporter batches events and exports them to sink.
+type analyticsExporter struct {
+	node      *Node
+	config    AnalyticsConfig
+	threshold uint32
+	queue     chan AnalyticsEvent
+}
+
+// SetAnalytics enables export of analytics events. Publications get unique
+// UID when analytics enabled to identify deliveries of the same publication.
+// Must be called before Node.Run.
+func (n *Node) SetAnalytics(c AnalyticsConfig) error {
+	if c.Sink == nil {
+		return errors.New("analytics sink required")
+	}
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return errors.New("analytics sample rate must be in range [0, 1]")
+	}
+	if c.SampleRate == 0 {
+		c.SampleRate = 1
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = 1000
+	}
+	if c.FlushInterval == 0 {
+		c.FlushInterval = time.Second
+	}
+	if c.QueueSize == 0 {
+		c.QueueSize = 10000
+	}
+	if c.ExportTimeout == 0 {
+		c.ExportTimeout = 10 * time.Second
+	}
+	e := &analyticsExporter{
+		node:      n,
+		config:    c,
+		threshold: uint32(c.SampleRate * analyticsSampleScale),
+		queue:     make(chan AnalyticsEvent, c.QueueSize),
+	}
+	n.analytics = e
+	go e.run()
+	return nil
+}
+
+// sampled decides whether events with key should be exported.
+func (e *analyticsExporter) sampled(key string) bool {
+	if e.threshold >= analyticsSampleScale {
+		return true
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return hash.Sum32()%analyticsSampleScale < e.threshold
+}
+
+func (e *analyticsExporter) add(event AnalyticsEvent) {
+	event.Time = time.Now().UnixNano() / int64(time.Millisecond)
+	event.Node = e.node.uid
+	select {
+	case e.queue <- event:
+	default:
+		// Queue is full, drop event.
+	}
+}
+
+// clientEvent adds connection or subscription event of client. It's safe
+// to call on nil exporter.
+func (e *analyticsExporter) clientEvent(typ string, c *Client, ch string) {
+	if e == nil || !e.sampled(c.uid) {
+		return
+	}
+	e.add(AnalyticsEvent{
+		Type:      typ,
+		Client:    c.uid,
+		User:      c.UserID(),
+		Channel:   ch,
+		Transport: c.transport.Name(),
+	})
+}
+
+// delivery adds publication delivery event. It's safe to call on nil
+// exporter.
+func (e *analyticsExporter) delivery(c *Client, ch string, pub *Publication) {
+	if e == nil || !e.sampled(pub.UID) {
+		return
+	}
+	e.add(AnalyticsEvent{
+		Type:        AnalyticsEventDelivery,
+		Client:      c.uid,
+		User:        c.UserID(),
+		Channel:     ch,
+		Publication: pub.UID,
+		Transport:   c.transport.Name(),
+	})
+}
+
+func (e *analyticsExporter) export(events []AnalyticsEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.config.ExportTimeout)
+	defer cancel()
+	if err := e.config.Sink.Export(ctx, events); err != nil {
+		e.node.logger.log(newLogEntry(LogLevelError, "error exporting analytics events", map[string]interface{}{"error": err.Error(), "num_events": len(events)}))
+	}
+}
+
+func (e *analyticsExporter) run() {
+	batch := make([]AnalyticsEvent, 0, e.config.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		e.export(batch)
+		batch = make([]AnalyticsEvent, 0, e.config.BatchSize)
+	}
+	ticker := time.NewTicker(e.config.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.node.NotifyShutdown():
+			for {
+				select {
+				case event := <-e.queue:
+					batch = append(batch, event)
+					if len(batch) >= e.config.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		case event := <-e.queue:
+			batch = append(batch, event)
+			if len(batch) >= e.config.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package centrifuge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultClickHouseEndpoint = "http://localhost:8123"
+	defaultClickHouseTable    = "centrifuge_events"
+)
+
+// ClickHouseConfig is a config for ClickHouse analytics sink.
+type ClickHouseConfig struct {
+	// Endpoint of ClickHouse HTTP interface, http://localhost:8123 by default.
+	Endpoint string
+	// Table to insert events into, centrifuge_events by default. See
+	// AnalyticsEvent for table schema.
+	Table string
+	// User and Password for ClickHouse authentication.
+	User     string
+	Password string
+	// Timeout for request to ClickHouse, 10 seconds by default.
+	Timeout time.Duration
+	// HTTPClient allows to set custom HTTP client.
+	HTTPClient *http.Client
+}
+
+// ClickHouseSink inserts analytics events into ClickHouse table over HTTP
+// interface using JSONEachRow format.
+type ClickHouseSink struct {
+	config ClickHouseConfig
+	client *http.Client
+}
+
+// NewClickHouseSink creates ClickHouseSink.
+func NewClickHouseSink(c ClickHouseConfig) *ClickHouseSink {
+	if c.Endpoint == "" {
+		c.Endpoint = defaultClickHouseEndpoint
+	}
+	if c.Table == "" {
+		c.Table = defaultClickHouseTable
+	}
+	client := c.HTTPClient
+	if client == nil {
+		timeout := c.Timeout
+		if timeout == 0 {
+			timeout = 10 * time.Second
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+	return &ClickHouseSink{
+		config: c,
+		client: client,
+	}
+}
+
+// Export is a part of AnalyticsSink interface.
+func (s *ClickHouseSink) Export(ctx context.Context, events []AnalyticsEvent) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, event := range events {
+		if err := encoder.Encode(clickHouseEvent{
+			AnalyticsEvent: event,
+			Time:           float64(event.Time) / 1000,
+		}); err != nil {
+			return err
+		}
+	}
+
+	query := url.Values{}
+	query.Set("query", fmt.Sprintf("INSERT INTO %s FORMAT JSONEachRow", s.config.Table))
+	req, err := http.NewRequest(http.MethodPost, s.config.Endpoint+"/?"+query.Encode(), &buf)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if s.config.User != "" {
+		req.Header.Set("X-ClickHouse-User", s.config.User)
+		req.Header.Set("X-ClickHouse-Key", s.config.Password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected ClickHouse response status: %d, %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return nil
+}
+
+// clickHouseEvent encodes time in seconds with fractional part as expected
+// by DateTime64 column.
+type clickHouseEvent struct {
+	AnalyticsEvent
+	Time float64 `json:"time"`
+}
@@ -0,0 +1,47 @@
+package centrifuge
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClickHouseSink(t *testing.T) {
+	var query, body, user string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("query")
+		user = r.Header.Get("X-ClickHouse-User")
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	sink := NewClickHouseSink(ClickHouseConfig{Endpoint: server.URL, User: "default"})
+	err := sink.Export(context.Background(), []AnalyticsEvent{
+		{Time: 1556198400123, Type: AnalyticsEventDelivery, Client: "1", Channel: "test"},
+		{Time: 1556198400124, Type: AnalyticsEventConnect, Client: "2"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO centrifuge_events FORMAT JSONEachRow", query)
+	assert.Equal(t, "default", user)
+	assert.Equal(t, `{"type":"delivery","node":"","client":"1","user":"","channel":"test","publication":"","transport":"","time":1556198400.123}
+{"type":"connect","node":"","client":"2","user":"","channel":"","publication":"","transport":"","time":1556198400.124}
+`, body)
+}
+
+func TestClickHouseSinkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Code: 60. DB::Exception: Table default.centrifuge_events doesn't exist."))
+	}))
+	defer server.Close()
+
+	sink := NewClickHouseSink(ClickHouseConfig{Endpoint: server.URL})
+	err := sink.Export(context.Background(), []AnalyticsEvent{{Type: AnalyticsEventConnect}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't exist")
+}
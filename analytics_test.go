@@ -0,0 +1,91 @@
+package centrifuge
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAnalyticsSink struct {
+	mu     sync.Mutex
+	events []AnalyticsEvent
+}
+
+func (s *testAnalyticsSink) Export(ctx context.Context, events []AnalyticsEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, events...)
+	return nil
+}
+
+func (s *testAnalyticsSink) types() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var types []string
+	for _, e := range s.events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestNodeSetAnalyticsErrors(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	assert.Error(t, node.SetAnalytics(AnalyticsConfig{}))
+	assert.Error(t, node.SetAnalytics(AnalyticsConfig{Sink: &testAnalyticsSink{}, SampleRate: 2}))
+}
+
+func TestAnalyticsEvents(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	sink := &testAnalyticsSink{}
+	assert.NoError(t, node.SetAnalytics(AnalyticsConfig{Sink: sink, FlushInterval: 10 * time.Millisecond}))
+
+	ctx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(ctx, node, newTestTransport())
+	connectClient(t, client)
+	subscribeClient(t, client, "test")
+	assert.NoError(t, node.Publish("test", []byte(`{}`)))
+	assert.NoError(t, client.unsubscribe("test"))
+	client.Close(nil)
+
+	expected := []string{AnalyticsEventConnect, AnalyticsEventSubscribe, AnalyticsEventDelivery, AnalyticsEventUnsubscribe, AnalyticsEventDisconnect}
+	for i := 0; i < 100; i++ {
+		if len(sink.types()) == len(expected) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, expected, sink.types())
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	delivery := sink.events[2]
+	assert.Equal(t, client.ID(), delivery.Client)
+	assert.Equal(t, "42", delivery.User)
+	assert.Equal(t, "test", delivery.Channel)
+	assert.NotEmpty(t, delivery.Publication)
+	assert.Equal(t, node.uid, delivery.Node)
+	assert.Equal(t, "test_transport", delivery.Transport)
+	assert.NotZero(t, delivery.Time)
+}
+
+func TestAnalyticsSampled(t *testing.T) {
+	e := &analyticsExporter{threshold: analyticsSampleScale / 2}
+	numSampled := 0
+	for i := 0; i < 1000; i++ {
+		key := string(rune(i)) + "key"
+		if e.sampled(key) {
+			numSampled++
+		}
+		// Decision must be stable for the same key.
+		assert.Equal(t, e.sampled(key), e.sampled(key))
+	}
+	assert.True(t, numSampled > 400 && numSampled < 600)
+
+	e = &analyticsExporter{threshold: 0}
+	assert.False(t, e.sampled("key"))
+	e = &analyticsExporter{threshold: analyticsSampleScale}
+	assert.True(t, e.sampled("key"))
+}
@@ -0,0 +1,275 @@
+package centrifuge
+
+import (
+	"github.com/centrifugal/centrifuge/apiproto"
+)
+
+// apiExecutor executes server API commands. It's shared between GRPC and
+// HTTP server API implementations.
+type apiExecutor struct {
+	node *Node
+}
+
+func newAPIExecutor(n *Node) *apiExecutor {
+	return &apiExecutor{
+		node: n,
+	}
+}
+
+func apiError(err *Error) *apiproto.Error {
+	return &apiproto.Error{
+		Code:    err.Code,
+		Message: err.Message,
+	}
+}
+
+// apiPublishError converts error returned from Node publish to API error.
+func (h *apiExecutor) apiPublishError(ch string, err error) *apiproto.Error {
+	if err == ErrNoChannelOptions {
+		return apiError(ErrorNamespaceNotFound)
+	}
+	h.node.logger.log(newLogEntry(LogLevelError, "error publishing message in engine", map[string]interface{}{"error": err.Error(), "channel": ch}))
+	return apiError(ErrorInternal)
+}
+
+// Publish publishes data into channel.
+func (h *apiExecutor) Publish(cmd *apiproto.PublishRequest) *apiproto.PublishResponse {
+	resp := &apiproto.PublishResponse{}
+	if cmd.Channel == "" || len(cmd.Data) == 0 {
+		resp.Error = apiError(ErrorBadRequest)
+		return resp
+	}
+	if err := h.node.Publish(cmd.Channel, cmd.Data); err != nil {
+		resp.Error = h.apiPublishError(cmd.Channel, err)
+		return resp
+	}
+	resp.Result = &apiproto.PublishResult{}
+	return resp
+}
+
+// Broadcast publishes the same data into many channels.
+func (h *apiExecutor) Broadcast(cmd *apiproto.BroadcastRequest) *apiproto.BroadcastResponse {
+	resp := &apiproto.BroadcastResponse{}
+	if len(cmd.Channels) == 0 || len(cmd.Data) == 0 {
+		resp.Error = apiError(ErrorBadRequest)
+		return resp
+	}
+	for _, ch := range cmd.Channels {
+		if ch == "" {
+			resp.Error = apiError(ErrorBadRequest)
+			return resp
+		}
+	}
+	for _, ch := range cmd.Channels {
+		if err := h.node.Publish(ch, cmd.Data); err != nil {
+			resp.Error = h.apiPublishError(ch, err)
+			return resp
+		}
+	}
+	resp.Result = &apiproto.BroadcastResult{}
+	return resp
+}
+
+// Unsubscribe unsubscribes user from channel.
+func (h *apiExecutor) Unsubscribe(cmd *apiproto.UnsubscribeRequest) *apiproto.UnsubscribeResponse {
+	resp := &apiproto.UnsubscribeResponse{}
+	if cmd.User == "" {
+		resp.Error = apiError(ErrorBadRequest)
+		return resp
+	}
+	if cmd.Channel != "" {
+		if _, ok := h.node.ChannelOpts(cmd.Channel); !ok {
+			resp.Error = apiError(ErrorNamespaceNotFound)
+			return resp
+		}
+	}
+	if err := h.node.Unsubscribe(cmd.User, cmd.Channel); err != nil {
+		h.node.logger.log(newLogEntry(LogLevelError, "error unsubscribing user from channel", map[string]interface{}{"error": err.Error(), "channel": cmd.Channel, "user": cmd.User}))
+		resp.Error = apiError(ErrorInternal)
+		return resp
+	}
+	resp.Result = &apiproto.UnsubscribeResult{}
+	return resp
+}
+
+// Disconnect disconnects user.
+func (h *apiExecutor) Disconnect(cmd *apiproto.DisconnectRequest) *apiproto.DisconnectResponse {
+	resp := &apiproto.DisconnectResponse{}
+	if cmd.User == "" {
+		resp.Error = apiError(ErrorBadRequest)
+		return resp
+	}
+	if err := h.node.Disconnect(cmd.User, WithReconnect(cmd.Reconnect)); err != nil {
+		h.node.logger.log(newLogEntry(LogLevelError, "error disconnecting user", map[string]interface{}{"error": err.Error(), "user": cmd.User}))
+		resp.Error = apiError(ErrorInternal)
+		return resp
+	}
+	resp.Result = &apiproto.DisconnectResult{}
+	return resp
+}
+
+// channelOptsError checks that channel exists and has required option enabled.
+func (h *apiExecutor) channelOptsError(ch string, enabled func(ChannelOptions) bool) *apiproto.Error {
+	if ch == "" {
+		return apiError(ErrorBadRequest)
+	}
+	chOpts, ok := h.node.ChannelOpts(ch)
+	if !ok {
+		return apiError(ErrorNamespaceNotFound)
+	}
+	if !enabled(chOpts) {
+		return apiError(ErrorNotAvailable)
+	}
+	return nil
+}
+
+func presenceEnabled(chOpts ChannelOptions) bool {
+	return chOpts.Presence
+}
+
+func historyEnabled(chOpts ChannelOptions) bool {
+	return chOpts.HistorySize > 0 && chOpts.HistoryLifetime > 0
+}
+
+func apiClientInfo(info *ClientInfo) *apiproto.ClientInfo {
+	if info == nil {
+		return nil
+	}
+	return &apiproto.ClientInfo{
+		User:     info.User,
+		Client:   info.Client,
+		ConnInfo: apiproto.Raw(info.ConnInfo),
+		ChanInfo: apiproto.Raw(info.ChanInfo),
+	}
+}
+
+// Presence returns channel presence information.
+func (h *apiExecutor) Presence(cmd *apiproto.PresenceRequest) *apiproto.PresenceResponse {
+	resp := &apiproto.PresenceResponse{}
+	if err := h.channelOptsError(cmd.Channel, presenceEnabled); err != nil {
+		resp.Error = err
+		return resp
+	}
+	presence, err := h.node.Presence(cmd.Channel)
+	if err != nil {
+		h.node.logger.log(newLogEntry(LogLevelError, "error calling presence", map[string]interface{}{"error": err.Error(), "channel": cmd.Channel}))
+		resp.Error = apiError(ErrorInternal)
+		return resp
+	}
+	apiPresence := make(map[string]*apiproto.ClientInfo, len(presence))
+	for k, v := range presence {
+		apiPresence[k] = apiClientInfo(v)
+	}
+	resp.Result = &apiproto.PresenceResult{
+		Presence: apiPresence,
+	}
+	return resp
+}
+
+// PresenceStats returns short channel presence information.
+func (h *apiExecutor) PresenceStats(cmd *apiproto.PresenceStatsRequest) *apiproto.PresenceStatsResponse {
+	resp := &apiproto.PresenceStatsResponse{}
+	if err := h.channelOptsError(cmd.Channel, presenceEnabled); err != nil {
+		resp.Error = err
+		return resp
+	}
+	stats, err := h.node.PresenceStats(cmd.Channel)
+	if err != nil {
+		h.node.logger.log(newLogEntry(LogLevelError, "error calling presence stats", map[string]interface{}{"error": err.Error(), "channel": cmd.Channel}))
+		resp.Error = apiError(ErrorInternal)
+		return resp
+	}
+	resp.Result = &apiproto.PresenceStatsResult{
+		NumClients: uint32(stats.NumClients),
+		NumUsers:   uint32(stats.NumUsers),
+	}
+	return resp
+}
+
+// History returns channel history.
+func (h *apiExecutor) History(cmd *apiproto.HistoryRequest) *apiproto.HistoryResponse {
+	resp := &apiproto.HistoryResponse{}
+	if err := h.channelOptsError(cmd.Channel, historyEnabled); err != nil {
+		resp.Error = err
+		return resp
+	}
+	result, err := h.node.History(cmd.Channel)
+	if err != nil {
+		h.node.logger.log(newLogEntry(LogLevelError, "error calling history", map[string]interface{}{"error": err.Error(), "channel": cmd.Channel}))
+		resp.Error = apiError(ErrorInternal)
+		return resp
+	}
+	history := result.Publications
+	apiPubs := make([]*apiproto.Publication, len(history))
+	for i, pub := range history {
+		apiPubs[i] = &apiproto.Publication{
+			UID:  pub.UID,
+			Data: apiproto.Raw(pub.Data),
+			Info: apiClientInfo(pub.Info),
+			Seq:  pub.Seq,
+			Gen:  pub.Gen,
+		}
+	}
+	resp.Result = &apiproto.HistoryResult{
+		Publications: apiPubs,
+	}
+	return resp
+}
+
+// HistoryRemove removes all history information for channel.
+func (h *apiExecutor) HistoryRemove(cmd *apiproto.HistoryRemoveRequest) *apiproto.HistoryRemoveResponse {
+	resp := &apiproto.HistoryRemoveResponse{}
+	if err := h.channelOptsError(cmd.Channel, historyEnabled); err != nil {
+		resp.Error = err
+		return resp
+	}
+	if err := h.node.RemoveHistory(cmd.Channel); err != nil {
+		h.node.logger.log(newLogEntry(LogLevelError, "error calling history remove", map[string]interface{}{"error": err.Error(), "channel": cmd.Channel}))
+		resp.Error = apiError(ErrorInternal)
+		return resp
+	}
+	resp.Result = &apiproto.HistoryRemoveResult{}
+	return resp
+}
+
+// Channels returns active channels.
+func (h *apiExecutor) Channels(cmd *apiproto.ChannelsRequest) *apiproto.ChannelsResponse {
+	resp := &apiproto.ChannelsResponse{}
+	channels, err := h.node.Channels()
+	if err != nil {
+		h.node.logger.log(newLogEntry(LogLevelError, "error calling channels", map[string]interface{}{"error": err.Error()}))
+		resp.Error = apiError(ErrorInternal)
+		return resp
+	}
+	resp.Result = &apiproto.ChannelsResult{
+		Channels: channels,
+	}
+	return resp
+}
+
+// Info returns information about running nodes.
+func (h *apiExecutor) Info(cmd *apiproto.InfoRequest) *apiproto.InfoResponse {
+	resp := &apiproto.InfoResponse{}
+	info, err := h.node.Info()
+	if err != nil {
+		h.node.logger.log(newLogEntry(LogLevelError, "error calling info", map[string]interface{}{"error": err.Error()}))
+		resp.Error = apiError(ErrorInternal)
+		return resp
+	}
+	nodes := make([]*apiproto.NodeResult, len(info.Nodes))
+	for i, nd := range info.Nodes {
+		nodes[i] = &apiproto.NodeResult{
+			UID:         nd.UID,
+			Name:        nd.Name,
+			Version:     nd.Version,
+			NumClients:  nd.NumClients,
+			NumUsers:    nd.NumUsers,
+			NumChannels: nd.NumChannels,
+			Uptime:      nd.Uptime,
+		}
+	}
+	resp.Result = &apiproto.InfoResult{
+		Nodes: nodes,
+	}
+	return resp
+}
@@ -0,0 +1,139 @@
+package centrifuge
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/centrifugal/centrifuge/apiproto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCAPIServiceConfig for GRPC API Service.
+type GRPCAPIServiceConfig struct {
+	// Key is an API key. If set then every request must contain authorization
+	// metadata with value "apikey <Key>". Leave empty if GRPC server protected
+	// in another way, for example with TLS client certificates.
+	Key string
+}
+
+// RegisterGRPCServerAPI registers GRPC API service in provided GRPC server.
+// Application backends written in any language can use API described in
+// apiproto package to publish messages, manage connections and get
+// information about channels.
+func RegisterGRPCServerAPI(n *Node, server *grpc.Server, config GRPCAPIServiceConfig) error {
+	apiproto.RegisterCentrifugeApiServer(server, newGRPCAPIService(n, config))
+	return nil
+}
+
+// grpcAPIService can answer on GRPC API requests.
+type grpcAPIService struct {
+	config GRPCAPIServiceConfig
+	api    *apiExecutor
+}
+
+func newGRPCAPIService(n *Node, c GRPCAPIServiceConfig) *grpcAPIService {
+	return &grpcAPIService{
+		config: c,
+		api:    newAPIExecutor(n),
+	}
+}
+
+// authorize checks API key passed in request metadata.
+func (s *grpcAPIService) authorize(ctx context.Context) error {
+	if s.config.Key == "" {
+		return nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		for _, v := range md.Get("authorization") {
+			if subtle.ConstantTimeCompare([]byte(v), []byte("apikey "+s.config.Key)) == 1 {
+				return nil
+			}
+		}
+	}
+	return status.Error(codes.Unauthenticated, "unauthenticated")
+}
+
+// Publish into channel.
+func (s *grpcAPIService) Publish(ctx context.Context, req *apiproto.PublishRequest) (*apiproto.PublishResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.api.Publish(req), nil
+}
+
+// Broadcast into channels.
+func (s *grpcAPIService) Broadcast(ctx context.Context, req *apiproto.BroadcastRequest) (*apiproto.BroadcastResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.api.Broadcast(req), nil
+}
+
+// Unsubscribe user from channel.
+func (s *grpcAPIService) Unsubscribe(ctx context.Context, req *apiproto.UnsubscribeRequest) (*apiproto.UnsubscribeResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.api.Unsubscribe(req), nil
+}
+
+// Disconnect user.
+func (s *grpcAPIService) Disconnect(ctx context.Context, req *apiproto.DisconnectRequest) (*apiproto.DisconnectResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.api.Disconnect(req), nil
+}
+
+// Presence in channel.
+func (s *grpcAPIService) Presence(ctx context.Context, req *apiproto.PresenceRequest) (*apiproto.PresenceResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.api.Presence(req), nil
+}
+
+// PresenceStats in channel.
+func (s *grpcAPIService) PresenceStats(ctx context.Context, req *apiproto.PresenceStatsRequest) (*apiproto.PresenceStatsResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.api.PresenceStats(req), nil
+}
+
+// History in channel.
+func (s *grpcAPIService) History(ctx context.Context, req *apiproto.HistoryRequest) (*apiproto.HistoryResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.api.History(req), nil
+}
+
+// HistoryRemove removes all history information for channel.
+func (s *grpcAPIService) HistoryRemove(ctx context.Context, req *apiproto.HistoryRemoveRequest) (*apiproto.HistoryRemoveResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.api.HistoryRemove(req), nil
+}
+
+// Channels allows to retrieve list of channels.
+func (s *grpcAPIService) Channels(ctx context.Context, req *apiproto.ChannelsRequest) (*apiproto.ChannelsResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.api.Channels(req), nil
+}
+
+// Info returns information about Centrifuge state.
+func (s *grpcAPIService) Info(ctx context.Context, req *apiproto.InfoRequest) (*apiproto.InfoResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.api.Info(req), nil
+}
@@ -0,0 +1,125 @@
+package centrifuge
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/centrifugal/centrifuge/apiproto"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestGRPCAPIClient(t *testing.T, n *Node, config GRPCAPIServiceConfig) (apiproto.CentrifugeApiClient, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	assert.NoError(t, RegisterGRPCServerAPI(n, server, config))
+	go server.Serve(lis)
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	assert.NoError(t, err)
+	return apiproto.NewCentrifugeApiClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func TestGRPCAPI(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	c := n.Config()
+	c.HistorySize = 10
+	c.HistoryLifetime = 60
+	c.Presence = true
+	n.Reload(c)
+
+	client, stop := newTestGRPCAPIClient(t, n, GRPCAPIServiceConfig{})
+	defer stop()
+	ctx := context.Background()
+
+	pubResp, err := client.Publish(ctx, &apiproto.PublishRequest{Channel: "test", Data: apiproto.Raw(`{}`)})
+	assert.NoError(t, err)
+	assert.Nil(t, pubResp.Error)
+
+	pubResp, err = client.Publish(ctx, &apiproto.PublishRequest{Channel: "", Data: apiproto.Raw(`{}`)})
+	assert.NoError(t, err)
+	assert.Equal(t, ErrorBadRequest.Code, pubResp.Error.Code)
+
+	pubResp, err = client.Publish(ctx, &apiproto.PublishRequest{Channel: "unknown:test", Data: apiproto.Raw(`{}`)})
+	assert.NoError(t, err)
+	assert.Equal(t, ErrorNamespaceNotFound.Code, pubResp.Error.Code)
+
+	broadcastResp, err := client.Broadcast(ctx, &apiproto.BroadcastRequest{Channels: []string{"test", "test2"}, Data: apiproto.Raw(`{"b":1}`)})
+	assert.NoError(t, err)
+	assert.Nil(t, broadcastResp.Error)
+
+	historyResp, err := client.History(ctx, &apiproto.HistoryRequest{Channel: "test"})
+	assert.NoError(t, err)
+	assert.Nil(t, historyResp.Error)
+	assert.Len(t, historyResp.Result.Publications, 2)
+	assert.Equal(t, `{"b":1}`, string(historyResp.Result.Publications[1].Data))
+
+	historyRemoveResp, err := client.HistoryRemove(ctx, &apiproto.HistoryRemoveRequest{Channel: "test"})
+	assert.NoError(t, err)
+	assert.Nil(t, historyRemoveResp.Error)
+	historyResp, _ = client.History(ctx, &apiproto.HistoryRequest{Channel: "test"})
+	assert.Len(t, historyResp.Result.Publications, 0)
+
+	presenceResp, err := client.Presence(ctx, &apiproto.PresenceRequest{Channel: "test"})
+	assert.NoError(t, err)
+	assert.Nil(t, presenceResp.Error)
+
+	presenceStatsResp, err := client.PresenceStats(ctx, &apiproto.PresenceStatsRequest{Channel: "test"})
+	assert.NoError(t, err)
+	assert.Nil(t, presenceStatsResp.Error)
+	assert.Equal(t, uint32(0), presenceStatsResp.Result.NumClients)
+
+	unsubscribeResp, err := client.Unsubscribe(ctx, &apiproto.UnsubscribeRequest{Channel: "test", User: "42"})
+	assert.NoError(t, err)
+	assert.Nil(t, unsubscribeResp.Error)
+
+	disconnectResp, err := client.Disconnect(ctx, &apiproto.DisconnectRequest{User: "42"})
+	assert.NoError(t, err)
+	assert.Nil(t, disconnectResp.Error)
+
+	channelsResp, err := client.Channels(ctx, &apiproto.ChannelsRequest{})
+	assert.NoError(t, err)
+	assert.Nil(t, channelsResp.Error)
+
+	infoResp, err := client.Info(ctx, &apiproto.InfoRequest{})
+	assert.NoError(t, err)
+	assert.Nil(t, infoResp.Error)
+	assert.Len(t, infoResp.Result.Nodes, 1)
+}
+
+func TestGRPCAPINotAvailable(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	client, stop := newTestGRPCAPIClient(t, n, GRPCAPIServiceConfig{})
+	defer stop()
+	ctx := context.Background()
+
+	presenceResp, err := client.Presence(ctx, &apiproto.PresenceRequest{Channel: "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, ErrorNotAvailable.Code, presenceResp.Error.Code)
+
+	historyResp, err := client.History(ctx, &apiproto.HistoryRequest{Channel: "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, ErrorNotAvailable.Code, historyResp.Error.Code)
+}
+
+func TestGRPCAPIKey(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	client, stop := newTestGRPCAPIClient(t, n, GRPCAPIServiceConfig{Key: "secret"})
+	defer stop()
+
+	_, err := client.Info(context.Background(), &apiproto.InfoRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "apikey secret")
+	resp, err := client.Info(ctx, &apiproto.InfoRequest{})
+	assert.NoError(t, err)
+	assert.Nil(t, resp.Error)
+}
@@ -0,0 +1,214 @@
+package centrifuge
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/centrifugal/centrifuge/apiproto"
+)
+
+// APIConfig for HTTP API handler.
+type APIConfig struct {
+	// Key is an API key. If set then every request must contain Authorization
+	// header with value "apikey <Key>". Leave empty if handler protected in
+	// another way, for example with middleware.
+	Key string
+	// RequestMaxSize limits size of request body in bytes, request with
+	// bigger body rejected with 413 status. 10 MB by default.
+	RequestMaxSize int64
+}
+
+const defaultAPIRequestMaxSize = 10 * 1024 * 1024
+
+// APIHandler is responsible for processing HTTP API commands. Request body
+// contains one or more newline-delimited JSON commands like
+// {"method": "publish", "params": {"channel": "news", "data": {}}}. Response
+// body contains newline-delimited JSON replies in the same order.
+type APIHandler struct {
+	node   *Node
+	config APIConfig
+	api    *apiExecutor
+}
+
+// NewAPIHandler creates new APIHandler.
+func NewAPIHandler(n *Node, c APIConfig) *APIHandler {
+	if c.RequestMaxSize == 0 {
+		c.RequestMaxSize = defaultAPIRequestMaxSize
+	}
+	return &APIHandler{
+		node:   n,
+		config: c,
+		api:    newAPIExecutor(n),
+	}
+}
+
+type apiCommand struct {
+	ID     uint32          `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type apiReply struct {
+	ID     uint32          `json:"id,omitempty"`
+	Error  *apiproto.Error `json:"error,omitempty"`
+	Result interface{}     `json:"result,omitempty"`
+}
+
+// set sets reply error or result. Result is not set in case of error as
+// typed nil pointer would be encoded into JSON as null.
+func (r *apiReply) set(err *apiproto.Error, result interface{}) {
+	if err != nil {
+		r.Error = err
+		return
+	}
+	r.Result = result
+}
+
+func (s *APIHandler) authorized(r *http.Request) bool {
+	if s.config.Key == "" {
+		return true
+	}
+	authHeader := r.Header.Get("Authorization")
+	if !strings.HasPrefix(strings.ToLower(authHeader), "apikey ") {
+		return false
+	}
+	key := authHeader[len("apikey "):]
+	return subtle.ConstantTimeCompare([]byte(key), []byte(s.config.Key)) == 1
+}
+
+func (s *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var commands []*apiCommand
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.config.RequestMaxSize))
+	for {
+		var cmd apiCommand
+		err := decoder.Decode(&cmd)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			s.node.logger.log(newLogEntry(LogLevelInfo, "error decoding API data", map[string]interface{}{"error": err.Error()}))
+			// MaxBytesReader error has no type to check.
+			if strings.Contains(err.Error(), "request body too large") {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		commands = append(commands, &cmd)
+	}
+	if len(commands) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, cmd := range commands {
+		reply := s.handleCommand(cmd)
+		reply.ID = cmd.ID
+		if err := encoder.Encode(reply); err != nil {
+			s.node.logger.log(newLogEntry(LogLevelError, "error encoding API reply", map[string]interface{}{"error": err.Error()}))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(buf.Bytes())
+}
+
+func (s *APIHandler) handleCommand(cmd *apiCommand) *apiReply {
+	reply := &apiReply{}
+
+	decode := func(params interface{}) bool {
+		if len(cmd.Params) == 0 {
+			return true
+		}
+		if err := json.Unmarshal(cmd.Params, params); err != nil {
+			s.node.logger.log(newLogEntry(LogLevelInfo, "error decoding API params", map[string]interface{}{"error": err.Error(), "method": cmd.Method}))
+			reply.Error = apiError(ErrorBadRequest)
+			return false
+		}
+		return true
+	}
+
+	switch cmd.Method {
+	case "publish":
+		var req apiproto.PublishRequest
+		if decode(&req) {
+			resp := s.api.Publish(&req)
+			reply.set(resp.Error, resp.Result)
+		}
+	case "broadcast":
+		var req apiproto.BroadcastRequest
+		if decode(&req) {
+			resp := s.api.Broadcast(&req)
+			reply.set(resp.Error, resp.Result)
+		}
+	case "unsubscribe":
+		var req apiproto.UnsubscribeRequest
+		if decode(&req) {
+			resp := s.api.Unsubscribe(&req)
+			reply.set(resp.Error, resp.Result)
+		}
+	case "disconnect":
+		var req apiproto.DisconnectRequest
+		if decode(&req) {
+			resp := s.api.Disconnect(&req)
+			reply.set(resp.Error, resp.Result)
+		}
+	case "presence":
+		var req apiproto.PresenceRequest
+		if decode(&req) {
+			resp := s.api.Presence(&req)
+			reply.set(resp.Error, resp.Result)
+		}
+	case "presence_stats":
+		var req apiproto.PresenceStatsRequest
+		if decode(&req) {
+			resp := s.api.PresenceStats(&req)
+			reply.set(resp.Error, resp.Result)
+		}
+	case "history":
+		var req apiproto.HistoryRequest
+		if decode(&req) {
+			resp := s.api.History(&req)
+			reply.set(resp.Error, resp.Result)
+		}
+	case "history_remove":
+		var req apiproto.HistoryRemoveRequest
+		if decode(&req) {
+			resp := s.api.HistoryRemove(&req)
+			reply.set(resp.Error, resp.Result)
+		}
+	case "channels":
+		var req apiproto.ChannelsRequest
+		if decode(&req) {
+			resp := s.api.Channels(&req)
+			reply.set(resp.Error, resp.Result)
+		}
+	case "info":
+		var req apiproto.InfoRequest
+		if decode(&req) {
+			resp := s.api.Info(&req)
+			reply.set(resp.Error, resp.Result)
+		}
+	default:
+		reply.Error = apiError(ErrorMethodNotFound)
+	}
+	return reply
+}
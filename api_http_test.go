@@ -0,0 +1,108 @@
+package centrifuge
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func apiHTTPRequest(t *testing.T, h http.Handler, key string, body string) (*httptest.ResponseRecorder, []map[string]json.RawMessage) {
+	req := httptest.NewRequest(http.MethodPost, "/api", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set("Authorization", "apikey "+key)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var replies []map[string]json.RawMessage
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var reply map[string]json.RawMessage
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &reply))
+		replies = append(replies, reply)
+	}
+	return rec, replies
+}
+
+func TestAPIHandler(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	c := n.Config()
+	c.HistorySize = 10
+	c.HistoryLifetime = 60
+	n.Reload(c)
+
+	h := NewAPIHandler(n, APIConfig{Key: "secret"})
+
+	body := `{"id":1,"method":"publish","params":{"channel":"test","data":{"text":"hello"}}}
+{"id":2,"method":"broadcast","params":{"channels":["test","test2"],"data":{"text":"broadcast"}}}
+{"id":3,"method":"history","params":{"channel":"test"}}
+{"id":4,"method":"presence","params":{"channel":"test"}}
+{"id":5,"method":"unknown"}
+{"id":6,"method":"publish","params":{"channel":"test"}}
+{"id":7,"method":"info"}`
+
+	rec, replies := apiHTTPRequest(t, h, "secret", body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, replies, 7)
+
+	assert.Equal(t, `1`, string(replies[0]["id"]))
+	assert.Nil(t, replies[0]["error"])
+	assert.Equal(t, `{}`, string(replies[0]["result"]))
+
+	assert.Nil(t, replies[1]["error"])
+
+	assert.Nil(t, replies[2]["error"])
+	var historyResult struct {
+		Publications []struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"publications"`
+	}
+	assert.NoError(t, json.Unmarshal(replies[2]["result"], &historyResult))
+	assert.Len(t, historyResult.Publications, 2)
+	assert.Equal(t, `{"text":"hello"}`, string(historyResult.Publications[0].Data))
+
+	assert.Equal(t, `{"code":108,"message":"not available"}`, string(replies[3]["error"]))
+	assert.Nil(t, replies[3]["result"])
+	assert.Equal(t, `{"code":104,"message":"method not found"}`, string(replies[4]["error"]))
+	assert.Equal(t, `{"code":107,"message":"bad request"}`, string(replies[5]["error"]))
+	assert.Nil(t, replies[6]["error"])
+}
+
+func TestAPIHandlerErrors(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	h := NewAPIHandler(n, APIConfig{Key: "secret"})
+
+	rec, _ := apiHTTPRequest(t, h, "", `{"method":"info"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec, _ = apiHTTPRequest(t, h, "wrong", `{"method":"info"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec, _ = apiHTTPRequest(t, h, "secret", `{"method":`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, _ = apiHTTPRequest(t, h, "secret", ``)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/api", nil)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestAPIHandlerRequestMaxSize(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	h := NewAPIHandler(n, APIConfig{RequestMaxSize: 32})
+
+	rec, replies := apiHTTPRequest(t, h, "", `{"method":"info"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, replies, 1)
+
+	rec, _ = apiHTTPRequest(t, h, "", `{"method":"info"}
+{"method":"info"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
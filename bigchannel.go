@@ -0,0 +1,193 @@
+package centrifuge
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+)
+
+const defaultBigChannelPublishInterval = 100 * time.Millisecond
+
+// bigChannelOptions turns off options not supported by big channels.
+func bigChannelOptions(opts ChannelOptions) ChannelOptions {
+	if !opts.BigChannel {
+		return opts
+	}
+	opts.Presence = false
+	opts.JoinLeave = false
+	opts.HistoryRecover = false
+	return opts
+}
+
+// bigChannelWarnings describes options ignored because of big channel mode.
+func (c *Config) bigChannelWarnings() []string {
+	var warnings []string
+	check := func(namespace string, opts ChannelOptions) {
+		if !opts.BigChannel {
+			return
+		}
+		var ignored []string
+		if opts.Presence {
+			ignored = append(ignored, "presence")
+		}
+		if opts.JoinLeave {
+			ignored = append(ignored, "join_leave")
+		}
+		if opts.HistoryRecover {
+			ignored = append(ignored, "history_recover")
+		}
+		if len(ignored) == 0 {
+			return
+		}
+		if namespace == "" {
+			namespace = "top level"
+		}
+		warnings = append(warnings, namespace+": "+strings.Join(ignored, ", ")+" ignored for big channel")
+	}
+	check("", c.ChannelOptions)
+	for _, ns := range c.Namespaces {
+		check(ns.Name, ns.ChannelOptions)
+	}
+	return warnings
+}
+
+func (n *Node) logBigChannelWarnings(c Config) {
+	for _, warning := range c.bigChannelWarnings() {
+		n.logger.log(newLogEntry(LogLevelInfo, "incompatible channel options", map[string]interface{}{"warning": warning}))
+	}
+}
+
+// handleBigChannelPublication conflates publications of big channel so
+// subscribers receive at most one publication per BigChannelPublishInterval.
+func (n *Node) handleBigChannelPublication(ch string, pub *Publication, chOpts *ChannelOptions) error {
+	if !n.bigChannels.add(ch, "", pub) {
+		// Publication will be delivered when interval ends if no newer
+		// publication arrives.
+		return nil
+	}
+	n.scheduleBigChannelFlush(ch, chOpts)
+	return n.hub.broadcastBigChannelPublication(ch, pub, chOpts)
+}
+
+func (n *Node) scheduleBigChannelFlush(ch string, chOpts *ChannelOptions) {
+	interval := chOpts.BigChannelPublishInterval
+	if interval <= 0 {
+		interval = defaultBigChannelPublishInterval
+	}
+	time.AfterFunc(interval, func() {
+		n.flushBigChannel(ch)
+	})
+}
+
+// flushBigChannel delivers the latest publication conflated during interval.
+func (n *Node) flushBigChannel(ch string) {
+	pub, ok := n.bigChannels.flush(ch, "")
+	if !ok {
+		return
+	}
+	chOpts, ok := n.ChannelOpts(ch)
+	if !ok {
+		return
+	}
+	n.scheduleBigChannelFlush(ch, &chOpts)
+	if err := n.hub.broadcastBigChannelPublication(ch, pub, &chOpts); err != nil {
+		n.logger.log(newLogEntry(LogLevelError, "error broadcasting big channel publication", map[string]interface{}{"channel": ch, "error": err.Error()}))
+	}
+}
+
+// broadcastBigChannelPublication sends publication to all clients
+// subscribed on channel splitting them between several workers.
+func (h *Hub) broadcastBigChannelPublication(channel string, pub *Publication, chOpts *ChannelOptions) error {
+	h.mu.RLock()
+	channelSubscriptions, ok := h.subs[channel]
+	if !ok {
+		h.mu.RUnlock()
+		return nil
+	}
+	clients := make([]*Client, 0, len(channelSubscriptions))
+	var hasJSON, hasProtobuf bool
+	for uid := range channelSubscriptions {
+		c, ok := h.conns[uid]
+		if !ok {
+			continue
+		}
+		if c.Transport().Encoding() == proto.EncodingProtobuf {
+			hasProtobuf = true
+		} else {
+			hasJSON = true
+		}
+		clients = append(clients, c)
+	}
+	h.mu.RUnlock()
+
+	if len(clients) == 0 {
+		return nil
+	}
+
+	name := logicalChannel(channel)
+
+	// Serialize frames once before fan-out so workers only write prepared bytes.
+	var jsonReply, protobufReply *preparedReply
+	var err error
+	if hasJSON {
+		jsonReply, err = newPublicationReply(name, pub, proto.EncodingJSON)
+		if err != nil {
+			return err
+		}
+	}
+	if hasProtobuf {
+		protobufReply, err = newPublicationReply(name, pub, proto.EncodingProtobuf)
+		if err != nil {
+			return err
+		}
+	}
+
+	workers := chOpts.BigChannelWorkers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(clients) {
+		workers = len(clients)
+	}
+	chunkSize := (len(clients) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(clients); start += chunkSize {
+		end := start + chunkSize
+		if end > len(clients) {
+			end = len(clients)
+		}
+		wg.Add(1)
+		go func(chunk []*Client) {
+			defer wg.Done()
+			for _, c := range chunk {
+				reply := jsonReply
+				if c.Transport().Encoding() == proto.EncodingProtobuf {
+					reply = protobufReply
+				}
+				c.writePublication(name, pub, reply, chOpts)
+				c.node.analytics.delivery(c, name, pub)
+			}
+		}(clients[start:end])
+	}
+	wg.Wait()
+	return nil
+}
+
+// newPublicationReply returns publication push reply serialized for encoding.
+func newPublicationReply(channel string, pub *Publication, enc proto.Encoding) (*preparedReply, error) {
+	data, err := proto.GetPushEncoder(enc).EncodePublication(pub)
+	if err != nil {
+		return nil, err
+	}
+	messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewPublicationPush(channel, data))
+	if err != nil {
+		return nil, err
+	}
+	reply := newPreparedReply(&proto.Reply{Result: messageBytes}, enc)
+	reply.Data()
+	return reply, nil
+}
@@ -0,0 +1,84 @@
+package centrifuge
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBigChannelOptions(t *testing.T) {
+	c := DefaultConfig
+	c.Namespaces = []ChannelNamespace{
+		{
+			Name: "big",
+			ChannelOptions: ChannelOptions{
+				BigChannel:     true,
+				Presence:       true,
+				JoinLeave:      true,
+				HistoryRecover: true,
+			},
+		},
+		{
+			Name: "compatible",
+			ChannelOptions: ChannelOptions{
+				BigChannel: true,
+			},
+		},
+	}
+	warnings := c.bigChannelWarnings()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, "big: presence, join_leave, history_recover ignored for big channel", warnings[0])
+
+	opts, ok := c.channelOpts("big")
+	assert.True(t, ok)
+	assert.True(t, opts.BigChannel)
+	assert.False(t, opts.Presence)
+	assert.False(t, opts.JoinLeave)
+	assert.False(t, opts.HistoryRecover)
+}
+
+func TestBigChannelConflation(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	config := node.Config()
+	config.BigChannel = true
+	config.BigChannelPublishInterval = 50 * time.Millisecond
+	config.BigChannelWorkers = 2
+	assert.NoError(t, node.Reload(config))
+
+	var transports []*testTransport
+	for i := 0; i < 3; i++ {
+		transport := newTestTransport()
+		transport.sink = make(chan []byte, 100)
+		newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+		client, _ := newClient(newCtx, node, transport)
+		connectClient(t, client)
+		subscribeClient(t, client, "test")
+		transports = append(transports, transport)
+	}
+
+	for i := 1; i <= 3; i++ {
+		assert.NoError(t, node.handlePublication("test", &Publication{Data: []byte(`{"n":` + strconv.Itoa(i) + `}`)}))
+	}
+
+	for _, transport := range transports {
+		var received []string
+		timeout := time.After(200 * time.Millisecond)
+	loop:
+		for {
+			select {
+			case data := <-transport.sink:
+				received = append(received, string(data))
+			case <-timeout:
+				break loop
+			}
+		}
+		assert.Len(t, received, 2)
+		assert.True(t, strings.Contains(received[0], `"n":1`))
+		assert.True(t, strings.Contains(received[1], `"n":3`))
+	}
+}
@@ -1,5 +1,9 @@
 package centrifuge
 
+import (
+	"time"
+)
+
 // ChannelNamespace allows to create channels with different channel options.
 type ChannelNamespace struct {
 	// Name is a unique namespace name.
@@ -38,6 +42,21 @@ type ChannelOptions struct {
 	// into join/leave event broadcast to all other active subscribers.
 	JoinLeave bool `mapstructure:"join_leave" json:"join_leave"`
 
+	// JoinLeaveBatchThreshold is a number of channel subscribers on node
+	// starting from which join and leave messages are not sent one by one
+	// but coalesced into periodic membership pushes containing number of
+	// joined and left clients and a sample of their infos. 0 means no
+	// coalescing.
+	JoinLeaveBatchThreshold int `mapstructure:"join_leave_batch_threshold" json:"join_leave_batch_threshold"`
+
+	// JoinLeaveBatchInterval is how often coalesced membership pushes are
+	// sent, 1 second by default.
+	JoinLeaveBatchInterval time.Duration `mapstructure:"join_leave_batch_interval" json:"join_leave_batch_interval"`
+
+	// JoinLeaveBatchSample is a max number of joined and left client infos
+	// included into membership push, 10 by default.
+	JoinLeaveBatchSample int `mapstructure:"join_leave_batch_sample" json:"join_leave_batch_sample"`
+
 	// Presence turns on presence information for channels.
 	// Presence is a structure with clients currently subscribed on channel.
 	Presence bool `json:"presence"`
@@ -58,4 +77,88 @@ type ChannelOptions struct {
 	// client. This option uses publications from history and must be used
 	// with reasonable HistorySize and HistoryLifetime configuration.
 	HistoryRecover bool `mapstructure:"history_recover" json:"history_recover"`
+
+	// CloudEvents turns on wrapping publication data into CloudEvents 1.0
+	// JSON envelope (structured mode). Channel name set as event subject.
+	CloudEvents bool `mapstructure:"cloudevents" json:"cloudevents"`
+
+	// PushFallback turns on sending publications as push notifications to
+	// devices of users who are offline at the moment of publishing. Only
+	// makes sense for user limited channels (like "notifications#42") as
+	// recipients are taken from user part of channel name. When Presence
+	// enabled user considered online if it's subscribed on channel on any
+	// node, otherwise only current node connections of user are checked.
+	PushFallback bool `mapstructure:"push_fallback" json:"push_fallback"`
+
+	// InactiveSubscribers turns on reporting durable channel subscribers
+	// (registered with Node.AddDurableSubscriber) who were offline when
+	// publication was published and thus missed it in realtime. Reports
+	// are delivered to InactiveSubscribers node event handler. Online
+	// check works the same way as for PushFallback option.
+	InactiveSubscribers bool `mapstructure:"inactive_subscribers" json:"inactive_subscribers"`
+
+	// HandlerConcurrency limits number of Subscribe, Publish and SubRefresh
+	// client event handlers running concurrently for channels of namespace,
+	// so slow downstream dependency of one channel family can't exhaust node
+	// resources. 0 means no limit.
+	HandlerConcurrency int `mapstructure:"handler_concurrency" json:"handler_concurrency"`
+
+	// HandlerQueueSize is a max number of handler calls waiting for a free
+	// slot when HandlerConcurrency reached. Calls above queue size rejected
+	// with ErrorLimitExceeded at once. 0 means no waiting.
+	HandlerQueueSize int `mapstructure:"handler_queue_size" json:"handler_queue_size"`
+
+	// HandlerQueueTimeout is a max time handler call waits in queue before
+	// rejected with ErrorLimitExceeded. 0 means waiting until slot released.
+	HandlerQueueTimeout time.Duration `mapstructure:"handler_queue_timeout" json:"handler_queue_timeout"`
+
+	// IdleTTL turns on purging channels which had no subscribers and no
+	// publications during this period: channel history removed from engine
+	// and ChannelExpired node event handler called. Activity tracked on
+	// nodes where channel was used so in cluster channel can live up to two
+	// IdleTTL periods after last publication made on other node. Presence
+	// is not purged as channel with presence is never considered idle and
+	// presence entries expire on their own. Roles and read positions are
+	// kept too as they are application state which must survive periods
+	// of inactivity. 0 means channels never expire.
+	IdleTTL time.Duration `mapstructure:"idle_ttl" json:"idle_ttl"`
+
+	// Priority of channel publications under client queue pressure. 0 is a
+	// normal priority. Publications of channels with negative priority are
+	// dropped when client queue size exceeds ClientQueueMaxSize/(1-Priority),
+	// i.e. priority -1 publications dropped when queue is half full, -2 when
+	// it's one third full and so on, so lower priority channels give way to
+	// others first. Negative priority can't be used together with
+	// HistoryRecover as dropped publications break recovery. Publications of
+	// channels with positive priority are queued until queue size exceeds
+	// ClientQueueMaxSize*(1+Priority) before ClientQueuePolicy applied, so
+	// they still get through when normal priority messages overflow queue.
+	Priority int `mapstructure:"priority" json:"priority"`
+
+	// BigChannel tunes channel for broadcasting to a huge number of
+	// subscribers (100k+). Presence and join/leave messages are not
+	// maintained for big channels, publication frames are serialized once
+	// before fan-out, fan-out is spread across BigChannelWorkers goroutines
+	// and publications are conflated on every node – delivered no more often
+	// than BigChannelPublishInterval with only the latest one delivered.
+	// As conflation breaks publication sequence Presence, JoinLeave and
+	// HistoryRecover options are ignored for big channels and warning logged
+	// if they are set.
+	BigChannel bool `mapstructure:"big_channel" json:"big_channel"`
+
+	// BigChannelPublishInterval is a min interval between publications
+	// delivered to big channel subscribers, 100 milliseconds by default.
+	BigChannelPublishInterval time.Duration `mapstructure:"big_channel_publish_interval" json:"big_channel_publish_interval"`
+
+	// BigChannelWorkers is a number of goroutines big channel publication is
+	// delivered by, number of CPU by default.
+	BigChannelWorkers int `mapstructure:"big_channel_workers" json:"big_channel_workers"`
+
+	// Partitions splits channel into a number of partitions to spread
+	// fan-out work over several broker PUB/SUB channels and hub entries.
+	// Each subscriber is assigned to one partition by hash of client ID
+	// while publications, join and leave messages are sent to all
+	// partitions. Partitioning is transparent to clients. 0 or 1 means
+	// channel is not partitioned.
+	Partitions int `mapstructure:"partitions" json:"partitions"`
 }
@@ -0,0 +1,160 @@
+package centrifuge
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// idleChannelsCheckInterval is how often node looks for expired idle
+// channels.
+const idleChannelsCheckInterval = time.Second
+
+// idleChannel is an activity state of channel with IdleTTL option.
+type idleChannel struct {
+	activeAt time.Time
+	// position is a last known history position of channel, used to detect
+	// publications made from other nodes.
+	position      RecoveryPosition
+	positionKnown bool
+}
+
+// idleChannels tracks activity of channels with IdleTTL option which were
+// used on this node.
+type idleChannels struct {
+	mu       sync.Mutex
+	channels map[string]*idleChannel
+}
+
+func newIdleChannels() *idleChannels {
+	return &idleChannels{
+		channels: make(map[string]*idleChannel),
+	}
+}
+
+func (c *idleChannels) touch(ch string, pub *Publication) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	state, ok := c.channels[ch]
+	if !ok {
+		state = &idleChannel{}
+		c.channels[ch] = state
+	}
+	state.activeAt = time.Now()
+	if pub != nil && (pub.Seq > 0 || pub.Gen > 0) {
+		state.position.Seq = pub.Seq
+		state.position.Gen = pub.Gen
+		state.positionKnown = true
+	}
+}
+
+// touchChannel marks channel as active if it has IdleTTL option set.
+func (n *Node) touchChannel(ch string, pub *Publication, chOpts *ChannelOptions) {
+	if chOpts.IdleTTL <= 0 {
+		return
+	}
+	n.idleChannels.touch(ch, pub)
+}
+
+func (n *Node) runIdleChannels() {
+	ticker := time.NewTicker(idleChannelsCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.shutdownCh:
+			return
+		case <-ticker.C:
+			n.expireIdleChannels(time.Now())
+		}
+	}
+}
+
+// expireIdleChannels purges channels which had no subscribers and no
+// publications during IdleTTL. Only history is removed – idle channel has
+// no presence by definition and roles and read positions are owned by
+// application, see IdleTTL option description.
+func (n *Node) expireIdleChannels(now time.Time) {
+	n.idleChannels.mu.Lock()
+	candidates := make(map[string]idleChannel)
+	for ch, state := range n.idleChannels.channels {
+		candidates[ch] = *state
+	}
+	n.idleChannels.mu.Unlock()
+
+	for ch, state := range candidates {
+		chOpts, ok := n.ChannelOpts(ch)
+		if !ok || chOpts.IdleTTL <= 0 {
+			n.forgetIdleChannel(ch, state.activeAt)
+			continue
+		}
+		if now.Sub(state.activeAt) < chOpts.IdleTTL {
+			continue
+		}
+		if active, position := n.channelActive(ch, state, &chOpts); active {
+			n.idleChannels.mu.Lock()
+			if current, ok := n.idleChannels.channels[ch]; ok && current.activeAt.Equal(state.activeAt) {
+				current.activeAt = now
+				current.position = position
+				current.positionKnown = true
+			}
+			n.idleChannels.mu.Unlock()
+			continue
+		}
+		if historyEnabled(chOpts) {
+			if err := n.RemoveHistory(ch); err != nil {
+				n.logger.log(newLogEntry(LogLevelError, "error removing history of idle channel", map[string]interface{}{"channel": ch, "error": err.Error()}))
+				continue
+			}
+		}
+		if !n.forgetIdleChannel(ch, state.activeAt) {
+			// Channel became active while we were purging it.
+			continue
+		}
+		n.logger.log(newLogEntry(LogLevelDebug, "idle channel expired", map[string]interface{}{"channel": ch}))
+		if n.eventHub.channelExpiredHandler != nil {
+			n.eventHub.channelExpiredHandler(context.Background(), ChannelExpiredEvent{
+				Channel: ch,
+			})
+		}
+	}
+}
+
+// channelActive checks channel state which could be changed by other nodes.
+// It also returns current history position of channel.
+func (n *Node) channelActive(ch string, state idleChannel, chOpts *ChannelOptions) (bool, RecoveryPosition) {
+	position := state.position
+	if n.numSubscribers(ch) > 0 {
+		return true, position
+	}
+	if chOpts.Presence {
+		stats, err := n.PresenceStats(ch)
+		if err != nil || stats.NumClients > 0 {
+			return true, position
+		}
+	}
+	if historyEnabled(*chOpts) {
+		current, err := n.currentRecoveryState(ch)
+		if err != nil {
+			return true, position
+		}
+		// Without known position we can't say whether channel got publications
+		// from other nodes so give it one more IdleTTL.
+		if !state.positionKnown || current.Seq != position.Seq || current.Gen != position.Gen {
+			return true, current
+		}
+	}
+	return false, position
+}
+
+// forgetIdleChannel stops tracking channel if it was not active since
+// activeAt.
+func (n *Node) forgetIdleChannel(ch string, activeAt time.Time) bool {
+	n.idleChannels.mu.Lock()
+	defer n.idleChannels.mu.Unlock()
+	current, ok := n.idleChannels.channels[ch]
+	if !ok || !current.activeAt.Equal(activeAt) {
+		return false
+	}
+	delete(n.idleChannels.channels, ch)
+	return true
+}
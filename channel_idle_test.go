@@ -0,0 +1,87 @@
+package centrifuge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nodeWithIdleNamespace(t *testing.T) (*Node, *[]string) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.Namespaces = []ChannelNamespace{{
+		Name: "idle",
+		ChannelOptions: ChannelOptions{
+			HistorySize:     10,
+			HistoryLifetime: 60,
+			IdleTTL:         time.Minute,
+		},
+	}}
+	assert.NoError(t, node.Reload(config))
+	var expired []string
+	node.On().ChannelExpired(func(ctx context.Context, e ChannelExpiredEvent) {
+		expired = append(expired, e.Channel)
+	})
+	return node, &expired
+}
+
+func TestIdleChannelExpired(t *testing.T) {
+	node, expired := nodeWithIdleNamespace(t)
+
+	assert.NoError(t, node.Publish("idle:test", []byte(`{}`)))
+	assert.NoError(t, node.Publish("test", []byte(`{}`)))
+
+	node.expireIdleChannels(time.Now())
+	assert.Len(t, *expired, 0)
+
+	node.expireIdleChannels(time.Now().Add(2 * time.Minute))
+	assert.Equal(t, []string{"idle:test"}, *expired)
+	history, err := node.History("idle:test")
+	pubs := history.Publications
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 0)
+
+	// Channel not tracked anymore.
+	node.expireIdleChannels(time.Now().Add(4 * time.Minute))
+	assert.Len(t, *expired, 1)
+}
+
+func TestIdleChannelWithSubscribers(t *testing.T) {
+	node, expired := nodeWithIdleNamespace(t)
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	subscribeClient(t, client, "idle:test")
+
+	assert.NoError(t, node.Publish("idle:test", []byte(`{}`)))
+	node.expireIdleChannels(time.Now().Add(2 * time.Minute))
+	assert.Len(t, *expired, 0)
+
+	client.Close(nil)
+	node.expireIdleChannels(time.Now().Add(time.Minute / 2))
+	assert.Len(t, *expired, 0)
+	node.expireIdleChannels(time.Now().Add(2 * time.Minute))
+	assert.Equal(t, []string{"idle:test"}, *expired)
+}
+
+func TestIdleChannelPublishedOnOtherNode(t *testing.T) {
+	node, expired := nodeWithIdleNamespace(t)
+
+	assert.NoError(t, node.Publish("idle:test", []byte(`{}`)))
+	// Simulate publication made on another node.
+	chOpts, _ := node.ChannelOpts("idle:test")
+	_, err := node.historyManager.AddHistory("idle:test", &Publication{Data: []byte(`{}`)}, &chOpts)
+	assert.NoError(t, err)
+
+	now := time.Now().Add(2 * time.Minute)
+	node.expireIdleChannels(now)
+	assert.Len(t, *expired, 0)
+
+	node.expireIdleChannels(now.Add(2 * time.Minute))
+	assert.Equal(t, []string{"idle:test"}, *expired)
+}
@@ -21,13 +21,14 @@ import (
 // ClientEventHub allows to deal with client event handlers.
 // All its methods are not goroutine-safe and supposed to be called once on client connect.
 type ClientEventHub struct {
-	disconnectHandler  DisconnectHandler
-	subscribeHandler   SubscribeHandler
-	unsubscribeHandler UnsubscribeHandler
-	publishHandler     PublishHandler
-	subRefreshHandler  SubRefreshHandler
-	rpcHandler         RPCHandler
-	messageHandler     MessageHandler
+	disconnectHandler    DisconnectHandler
+	subscribeHandler     SubscribeHandler
+	unsubscribeHandler   UnsubscribeHandler
+	publishHandler       PublishHandler
+	subRefreshHandler    SubRefreshHandler
+	rpcHandler           RPCHandler
+	messageHandler       MessageHandler
+	queueOverflowHandler QueueOverflowHandler
 }
 
 // Disconnect allows to set DisconnectHandler.
@@ -60,6 +61,13 @@ func (c *ClientEventHub) Subscribe(h SubscribeHandler) {
 	c.subscribeHandler = h
 }
 
+// QueueOverflow allows to set QueueOverflowHandler.
+// QueueOverflowHandler called asynchronously when client's message queue
+// exceeded Config.ClientQueueMaxSize.
+func (c *ClientEventHub) QueueOverflow(h QueueOverflowHandler) {
+	c.queueOverflowHandler = h
+}
+
 // Unsubscribe allows to set UnsubscribeHandler.
 // UnsubscribeHandler called when client unsubscribes from channel.
 func (c *ClientEventHub) Unsubscribe(h UnsubscribeHandler) {
@@ -87,6 +95,21 @@ type Client struct {
 	// presenceMu allows to sync presence routine with client closing.
 	presenceMu sync.Mutex
 
+	// commands runs client commands concurrently if command concurrency
+	// configured, nil means commands handled one by one.
+	commands *commandExecutor
+	// subscribeMu allows only one subscription in progress as publication
+	// recovery synchronization supports only one channel at a time.
+	subscribeMu sync.Mutex
+	// connectSubs are channels from connect reply to subscribe connection
+	// to after connect reply sent.
+	connectSubs []string
+	// clientSideRefresh is true when connection expiration is extended by
+	// client refresh commands.
+	clientSideRefresh bool
+	// inflight is a number of commands received but not answered yet.
+	inflight int32
+
 	ctx       context.Context
 	node      *Node
 	transport transport
@@ -102,7 +125,6 @@ type Client struct {
 	channels map[string]ChannelContext
 
 	staleTimer    *time.Timer
-	expireTimer   *time.Timer
 	presenceTimer *time.Timer
 
 	disconnect *Disconnect
@@ -142,8 +164,19 @@ func newClient(ctx context.Context, n *Node, t transport) (*Client, error) {
 		pubBuffer: make([]*Publication, 0),
 	}
 
+	if config.ClientCommandConcurrency > 1 {
+		c.commands = newCommandExecutor(config.ClientCommandConcurrency)
+	}
+
 	messageWriterConf := writerConfig{
-		MaxQueueSize: config.ClientQueueMaxSize,
+		MaxQueueSize:       config.ClientQueueMaxSize,
+		MaxMessagesInFrame: config.ClientMaxMessagesInFrame,
+		WriteDelay:         config.ClientWriteDelay,
+		QueuePolicy:        config.ClientQueuePolicy,
+		DropFn: func(dropped int) {
+			queueDroppedCount.WithLabelValues(t.Name(), config.ClientQueuePolicy.String()).Add(float64(dropped))
+			c.queueOverflow(QueueOverflowEvent{Policy: config.ClientQueuePolicy, Dropped: dropped})
+		},
 		WriteFn: func(data ...[]byte) error {
 			if len(data) == 1 {
 				// no need in extra byte buffers in this path.
@@ -199,9 +232,19 @@ func (c *Client) closeUnauthenticated() {
 }
 
 func (c *Client) transportSend(reply *preparedReply) error {
+	return c.transportSendChannel("", reply, 0)
+}
+
+// transportSendChannel sends publication of channel ch which can be dropped
+// by Config.ClientQueuePolicy, see ChannelOptions.Priority for priority.
+func (c *Client) transportSendChannel(ch string, reply *preparedReply, priority int) error {
 	data := reply.Data()
-	disconnect := c.messageWriter.enqueue(data)
+	disconnect := c.messageWriter.enqueueChannel(ch, data, priority)
 	if disconnect != nil {
+		if disconnect == DisconnectSlow {
+			queueDroppedCount.WithLabelValues(c.transport.Name(), "overflow").Inc()
+			c.queueOverflow(QueueOverflowEvent{Policy: c.messageWriter.config.QueuePolicy, Disconnect: disconnect})
+		}
 		// Close in goroutine to not block message broadcast.
 		go c.Close(disconnect)
 		return io.EOF
@@ -209,6 +252,14 @@ func (c *Client) transportSend(reply *preparedReply) error {
 	return nil
 }
 
+// queueOverflow calls QueueOverflowHandler in goroutine to not block
+// message broadcast.
+func (c *Client) queueOverflow(e QueueOverflowEvent) {
+	if handler := c.eventHub.queueOverflowHandler; handler != nil {
+		go handler(e)
+	}
+}
+
 // updateChannelPresence updates client presence info for channel so it
 // won't expire until client disconnect.
 func (c *Client) updateChannelPresence(ch string) error {
@@ -230,7 +281,16 @@ func (c *Client) checkSubscriptionExpiration(channel string, channelContext Chan
 		// Subscription expired.
 		if c.eventHub.subRefreshHandler != nil {
 			// Give subscription a chance to be refreshed via SubRefreshHandler.
-			reply := c.eventHub.subRefreshHandler(SubRefreshEvent{Channel: channel})
+			release, ok := c.node.acquireHandler(channel)
+			if !ok {
+				// Keep subscription till next check.
+				return true
+			}
+			reply := func() SubRefreshReply {
+				// Slot must be released even if handler panics.
+				defer release()
+				return c.eventHub.subRefreshHandler(SubRefreshEvent{Channel: channel})
+			}()
 			if reply.Expired || (reply.ExpireAt > 0 && reply.ExpireAt < now) {
 				return false
 			}
@@ -388,6 +448,181 @@ func (c *Client) Send(data Raw) error {
 	return c.transportSend(reply)
 }
 
+// Subscribe subscribes client to channel on server side. Client receives
+// sub push with channel recovery state followed by channel publications
+// without sending subscribe command. Channel permission checks and
+// subscribe handler are skipped as application itself decides about
+// subscription.
+func (c *Client) Subscribe(ch string) error {
+	c.mu.RLock()
+	closed := c.closed
+	authenticated := c.authenticated
+	c.mu.RUnlock()
+	if closed {
+		return nil
+	}
+	if !authenticated {
+		return ErrorUnauthorized
+	}
+
+	if strings.Contains(ch, partitionSeparator) {
+		return ErrorPermissionDenied
+	}
+
+	chOpts, ok := c.node.ChannelOpts(ch)
+	if !ok {
+		return ErrorNamespaceNotFound
+	}
+
+	c.subscribeMu.Lock()
+	defer c.subscribeMu.Unlock()
+
+	channelLimit := c.node.Config().ClientChannelLimit
+
+	c.mu.RLock()
+	numChannels := len(c.channels)
+	_, ok = c.channels[ch]
+	c.mu.RUnlock()
+	if ok {
+		return ErrorAlreadySubscribed
+	}
+	if channelLimit > 0 && numChannels >= channelLimit {
+		return ErrorChannelLimitExceeded
+	}
+
+	if chOpts.HistoryRecover {
+		c.setInSubscribe(ch, true)
+		defer c.setInSubscribe(ch, false)
+	}
+
+	err := c.node.addSubscription(ch, c)
+	if err != nil {
+		return err
+	}
+
+	c.mu.RLock()
+	info := c.clientInfo(ch)
+	c.mu.RUnlock()
+
+	if chOpts.Presence {
+		err = c.node.addPresence(ch, c.uid, info)
+		if err != nil {
+			c.node.removeSubscription(ch, c)
+			return err
+		}
+	}
+
+	// rollback undoes subscription and presence on errors below.
+	rollback := func() {
+		if chOpts.Presence {
+			c.node.removePresence(ch, c.uid, c.user)
+		}
+		c.node.removeSubscription(ch, c)
+	}
+
+	sub := &proto.Sub{}
+	channelContext := ChannelContext{}
+	if chOpts.HistoryRecover {
+		recovery, err := c.node.currentRecoveryState(ch)
+		if err != nil {
+			rollback()
+			return err
+		}
+		sub.Recoverable = true
+		sub.Seq = recovery.Seq
+		sub.Gen = recovery.Gen
+		sub.Epoch = recovery.Epoch
+		channelContext.recoveryPosition = recovery
+		channelContext.positionCheckTime = time.Now()
+	}
+
+	pushEncoder := proto.GetPushEncoder(c.transport.Encoding())
+	data, err := pushEncoder.EncodeSub(sub)
+	if err != nil {
+		rollback()
+		return err
+	}
+	result, err := pushEncoder.Encode(proto.NewSubPush(ch, data))
+	if err != nil {
+		rollback()
+		return err
+	}
+	reply := newPreparedReply(&proto.Reply{
+		Result: result,
+	}, c.transport.Encoding())
+
+	c.mu.Lock()
+	c.channels[ch] = channelContext
+	c.mu.Unlock()
+
+	if chOpts.HistoryRecover {
+		// Publications received while subscribing must follow sub push and
+		// precede publications received after subscribing.
+		c.pubBufferMu.Lock()
+		err = c.transportSend(reply)
+		if err == nil {
+			err = c.writeBufferedPublications(ch, &chOpts, channelContext.recoveryPosition)
+		}
+		c.setInSubscribe(ch, false)
+		c.pubBufferMu.Unlock()
+		if err != nil {
+			return err
+		}
+	} else if err := c.transportSend(reply); err != nil {
+		return err
+	}
+
+	if chOpts.JoinLeave {
+		join := &proto.Join{
+			Info: *info,
+		}
+		go c.node.publishJoin(ch, join, &chOpts)
+	}
+
+	c.node.analytics.clientEvent(AnalyticsEventSubscribe, c, ch)
+
+	if c.node.logger.enabled(LogLevelDebug) {
+		c.node.logger.log(newLogEntry(LogLevelDebug, "client subscribed to channel on server side", map[string]interface{}{"client": c.uid, "user": c.user, "channel": ch}))
+	}
+	return nil
+}
+
+// writeBufferedPublications sends publications buffered while subscribing
+// which are newer than position client received in sub push. Lock on
+// pubBuffer must be held outside.
+func (c *Client) writeBufferedPublications(ch string, chOpts *ChannelOptions, position RecoveryPosition) error {
+	pubs := uniquePublications(c.pubBuffer)
+	c.pubBuffer = nil
+	sort.Slice(pubs, func(i, j int) bool {
+		if pubs[i].Gen != pubs[j].Gen {
+			return pubs[i].Gen < pubs[j].Gen
+		}
+		return pubs[i].Seq < pubs[j].Seq
+	})
+	pushEncoder := proto.GetPushEncoder(c.transport.Encoding())
+	for _, pub := range pubs {
+		if pub.Gen < position.Gen || (pub.Gen == position.Gen && pub.Seq <= position.Seq) {
+			// Already counted in position client received.
+			continue
+		}
+		data, err := pushEncoder.EncodePublication(pub)
+		if err != nil {
+			return err
+		}
+		result, err := pushEncoder.Encode(proto.NewPublicationPush(ch, data))
+		if err != nil {
+			return err
+		}
+		reply := newPreparedReply(&proto.Reply{
+			Result: result,
+		}, c.transport.Encoding())
+		if err := c.writePublicationUpdatePosition(ch, pub, reply, chOpts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Unsubscribe allows to unsubscribe client from channel.
 func (c *Client) Unsubscribe(ch string, resubscribe bool) error {
 	c.mu.RLock()
@@ -457,17 +692,19 @@ func (c *Client) Close(disconnect *Disconnect) error {
 	authenticated := c.authenticated
 	c.mu.RUnlock()
 
+	// Connection is added into hub before marked authenticated so always
+	// removed, removing connection not in hub is a no-op.
+	err := c.node.removeClient(c)
+	if err != nil {
+		c.node.logger.log(newLogEntry(LogLevelError, "error removing client", map[string]interface{}{"user": c.user, "client": c.uid, "error": err.Error()}))
+	}
 	if authenticated {
-		err := c.node.removeClient(c)
-		if err != nil {
-			c.node.logger.log(newLogEntry(LogLevelError, "error removing client", map[string]interface{}{"user": c.user, "client": c.uid, "error": err.Error()}))
-		}
+		c.node.analytics.clientEvent(AnalyticsEventDisconnect, c, "")
 	}
 
+	c.node.expirations.remove(c)
+
 	c.mu.Lock()
-	if c.expireTimer != nil {
-		c.expireTimer.Stop()
-	}
 	if c.presenceTimer != nil {
 		c.presenceTimer.Stop()
 	}
@@ -479,6 +716,16 @@ func (c *Client) Close(disconnect *Disconnect) error {
 	// Close writer and send messages remaining in writer queue if any.
 	c.messageWriter.close()
 
+	if disconnect != nil && disconnect != DisconnectNormal && disconnect.Reconnect && disconnect.ReconnectAdvice == nil {
+		config := c.node.Config()
+		if advice := config.reconnectAdvice(); advice != nil {
+			// Copy as predefined disconnects are shared between clients.
+			withAdvice := *disconnect
+			withAdvice.ReconnectAdvice = advice
+			disconnect = &withAdvice
+		}
+	}
+
 	c.transport.Close(disconnect)
 
 	if disconnect != nil && disconnect.Reason != "" {
@@ -543,6 +790,15 @@ func (c *Client) handleRawData(data []byte) bool {
 
 	enc := c.transport.Encoding()
 
+	if c.commands != nil {
+		// Commands are handled after transport reuses data buffer.
+		data = append([]byte(nil), data...)
+	}
+
+	config := c.node.Config()
+	maxInflight := config.ClientMaxInflightCommands
+	maxInflightReplySize := config.ClientMaxInflightReplySize
+
 	encoder := proto.GetReplyEncoder(enc)
 	decoder := proto.GetCommandDecoder(enc, data)
 
@@ -558,6 +814,14 @@ func (c *Client) handleRawData(data []byte) bool {
 			proto.PutReplyEncoder(enc, encoder)
 			return false
 		}
+		if replyErr := c.acquireCommand(maxInflight, maxInflightReplySize); replyErr != nil {
+			c.rejectCommand(enc, cmd, replyErr)
+			continue
+		}
+		if c.commands != nil {
+			c.dispatchCommand(enc, cmd)
+			continue
+		}
 		var encodeErr error
 		write := func(rep *proto.Reply) error {
 			encodeErr = encoder.Encode(rep)
@@ -584,6 +848,7 @@ func (c *Client) handleRawData(data []byte) bool {
 			return nil
 		}
 		disconnect := c.handle(cmd, write, flush)
+		c.releaseCommand()
 		if disconnect != nil {
 			c.node.logger.log(newLogEntry(LogLevelInfo, "disconnect after handling command", map[string]interface{}{"command": fmt.Sprintf("%v", cmd), "client": c.ID(), "user": c.UserID(), "reason": disconnect.Reason}))
 			c.Close(disconnect)
@@ -623,7 +888,7 @@ type replyWriter struct {
 }
 
 // handle dispatches Command into correct command handler.
-func (c *Client) handle(cmd *proto.Command, writeFn func(*proto.Reply) error, flush func() error) *Disconnect {
+func (c *Client) handle(cmd *proto.Command, writeFn func(*proto.Reply) error, flush func() error) (disconnect *Disconnect) {
 	c.mu.Lock()
 	if c.closed {
 		c.mu.Unlock()
@@ -631,11 +896,16 @@ func (c *Client) handle(cmd *proto.Command, writeFn func(*proto.Reply) error, fl
 	}
 	c.mu.Unlock()
 
-	var disconnect *Disconnect
-
 	method := cmd.Method
 	params := cmd.Params
 
+	defer func() {
+		if r := recover(); r != nil {
+			c.node.logPanic("panic recovered while handling command", r, map[string]interface{}{"method": strings.ToLower(method.String()), "client": c.ID(), "user": c.UserID()})
+			disconnect = DisconnectServerError
+		}
+	}()
+
 	write := func(rep *proto.Reply) error {
 		rep.ID = cmd.ID
 		if rep.Error != nil {
@@ -660,6 +930,20 @@ func (c *Client) handle(cmd *proto.Command, writeFn func(*proto.Reply) error, fl
 	}
 
 	started := time.Now()
+	if len(c.node.interceptors) > 0 {
+		disconnect = c.interceptCommand(cmd, rw)
+	} else {
+		disconnect = c.dispatch(method, params, rw)
+	}
+	methodName := strings.ToLower(proto.MethodType_name[int32(method)])
+	duration := time.Since(started).Seconds()
+	commandDurationSummary.WithLabelValues(methodName).Observe(duration)
+	commandDurationHistogram.WithLabelValues(methodName, c.transport.Name()).Observe(duration)
+	return disconnect
+}
+
+// dispatch calls handler of command method.
+func (c *Client) dispatch(method proto.MethodType, params proto.Raw, rw *replyWriter) (disconnect *Disconnect) {
 	switch method {
 	case proto.MethodTypeConnect:
 		disconnect = c.handleConnect(params, rw)
@@ -685,10 +969,11 @@ func (c *Client) handle(cmd *proto.Command, writeFn func(*proto.Reply) error, fl
 		disconnect = c.handleRPC(params, rw)
 	case proto.MethodTypeSend:
 		disconnect = c.handleSend(params, rw)
+	case proto.MethodTypeMarkRead:
+		disconnect = c.handleMarkRead(params, rw)
 	default:
 		rw.write(&proto.Reply{Error: ErrorMethodNotFound})
 	}
-	commandDurationSummary.WithLabelValues(strings.ToLower(proto.MethodType_name[int32(method)])).Observe(time.Since(started).Seconds())
 	return disconnect
 }
 
@@ -701,8 +986,16 @@ func (c *Client) expire() {
 		return
 	}
 
-	if c.node.eventHub.refreshHandler != nil {
+	c.mu.RLock()
+	serverSideRefresh := c.node.eventHub.refreshHandler != nil && !c.clientSideRefresh
+	c.mu.RUnlock()
+
+	if serverSideRefresh {
 		reply := c.node.eventHub.refreshHandler(c.ctx, c, RefreshEvent{})
+		if reply.Expired {
+			c.Close(DisconnectExpired)
+			return
+		}
 		if reply.ExpireAt > 0 {
 			c.mu.Lock()
 			c.exp = reply.ExpireAt
@@ -723,19 +1016,10 @@ func (c *Client) expire() {
 
 	ttl := exp - time.Now().Unix()
 
-	if c.node.eventHub.refreshHandler != nil {
+	if serverSideRefresh {
 		if ttl > 0 {
-			c.mu.RLock()
-			if c.expireTimer != nil {
-				c.expireTimer.Stop()
-			}
-			c.mu.RUnlock()
-
 			duration := time.Duration(ttl) * time.Second
-
-			c.mu.Lock()
-			c.expireTimer = time.AfterFunc(duration, c.expire)
-			c.mu.Unlock()
+			c.node.expirations.add(c, time.Now().Add(duration))
 		}
 	}
 
@@ -772,6 +1056,19 @@ func (c *Client) handleConnect(params proto.Raw, rw *replyWriter) *Disconnect {
 
 	rw.write(&proto.Reply{Result: replyRes})
 	rw.flush()
+	if resp.Result != nil && resp.Result.Redirect != nil {
+		return DisconnectRedirect
+	}
+	c.mu.Lock()
+	subs := c.connectSubs
+	c.connectSubs = nil
+	c.mu.Unlock()
+	for _, ch := range subs {
+		if err := c.Subscribe(ch); err != nil {
+			c.node.logger.log(newLogEntry(LogLevelError, "error subscribing on connect", map[string]interface{}{"channel": ch, "user": c.user, "client": c.uid, "error": err.Error()}))
+			return DisconnectServerError
+		}
+	}
 	if c.node.eventHub.connectedHandler != nil {
 		c.node.eventHub.connectedHandler(c.ctx, c)
 	}
@@ -970,6 +1267,32 @@ func (c *Client) handleHistory(params proto.Raw, rw *replyWriter) *Disconnect {
 	return nil
 }
 
+func (c *Client) handleMarkRead(params proto.Raw, rw *replyWriter) *Disconnect {
+	cmd, err := proto.GetParamsDecoder(c.transport.Encoding()).DecodeMarkRead(params)
+	if err != nil {
+		c.node.logger.log(newLogEntry(LogLevelInfo, "error decoding mark read", map[string]interface{}{"error": err.Error()}))
+		return DisconnectBadRequest
+	}
+	resp, disconnect := c.markReadCmd(cmd)
+	if disconnect != nil {
+		return disconnect
+	}
+	if resp.Error != nil {
+		rw.write(&proto.Reply{Error: resp.Error})
+		return nil
+	}
+	var replyRes []byte
+	if resp.Result != nil {
+		replyRes, err = proto.GetResultEncoder(c.transport.Encoding()).EncodeMarkReadResult(resp.Result)
+		if err != nil {
+			c.node.logger.log(newLogEntry(LogLevelError, "error encoding mark read", map[string]interface{}{"error": err.Error()}))
+			return DisconnectServerError
+		}
+	}
+	rw.write(&proto.Reply{Result: replyRes})
+	return nil
+}
+
 func (c *Client) handlePing(params proto.Raw, rw *replyWriter) *Disconnect {
 	cmd, err := proto.GetParamsDecoder(c.transport.Encoding()).DecodePing(params)
 	if err != nil {
@@ -1004,7 +1327,8 @@ func (c *Client) handleRPC(params proto.Raw, rw *replyWriter) *Disconnect {
 			return DisconnectBadRequest
 		}
 		rpcReply := c.eventHub.rpcHandler(RPCEvent{
-			Data: cmd.Data,
+			Method: cmd.Method,
+			Data:   cmd.Data,
 		})
 		if rpcReply.Disconnect != nil {
 			return rpcReply.Disconnect
@@ -1082,12 +1406,18 @@ func (c *Client) connectCmd(cmd *proto.ConnectRequest) (*proto.ConnectResponse,
 		return resp, DisconnectBadRequest
 	}
 
+	select {
+	case <-c.node.NotifyShutdown():
+		// Node does not accept new connections while shutting down.
+		return resp, DisconnectShutdown
+	default:
+	}
+
 	config := c.node.Config()
 	version := config.Version
 	insecure := config.ClientInsecure
 	clientAnonymous := config.ClientAnonymous
 	closeDelay := config.ClientExpiredCloseDelay
-	userConnectionLimit := config.ClientUserConnectionLimit
 
 	var credentials *Credentials
 	var authData proto.Raw
@@ -1105,6 +1435,14 @@ func (c *Client) connectCmd(cmd *proto.ConnectRequest) (*proto.ConnectResponse,
 			resp.Error = reply.Error
 			return resp, nil
 		}
+		if reply.Redirect != nil {
+			c.node.logger.log(newLogEntry(LogLevelDebug, "client redirected", map[string]interface{}{"client": c.uid, "endpoints": reply.Redirect.Endpoints}))
+			resp.Result = &proto.ConnectResult{
+				Version:  version,
+				Redirect: reply.Redirect,
+			}
+			return resp, nil
+		}
 		if reply.Credentials != nil {
 			credentials = reply.Credentials
 		}
@@ -1116,6 +1454,16 @@ func (c *Client) connectCmd(cmd *proto.ConnectRequest) (*proto.ConnectResponse,
 		if reply.Data != nil {
 			authData = reply.Data
 		}
+		if len(reply.Subscriptions) > 0 {
+			c.mu.Lock()
+			c.connectSubs = reply.Subscriptions
+			c.mu.Unlock()
+		}
+		if reply.ClientSideRefresh {
+			c.mu.Lock()
+			c.clientSideRefresh = true
+			c.mu.Unlock()
+		}
 	}
 
 	if credentials == nil {
@@ -1137,6 +1485,27 @@ func (c *Client) connectCmd(cmd *proto.ConnectRequest) (*proto.ConnectResponse,
 		c.info = credentials.Info
 		c.exp = credentials.ExpireAt
 		c.mu.Unlock()
+	} else if cmd.Token != "" && c.node.oidc != nil {
+		// Connection token is OpenID Connect token.
+		credentials, claims, err := c.node.oidc.Authenticate(c.ctx, cmd.Token)
+		if err != nil {
+			if err == ErrOIDCTokenExpired {
+				resp.Error = ErrorTokenExpired
+				return resp, nil
+			}
+			c.node.logger.log(newLogEntry(LogLevelInfo, "invalid connection token", map[string]interface{}{"error": err.Error(), "client": c.uid}))
+			return resp, DisconnectInvalidToken
+		}
+		jti, _ := claims.Claims["jti"].(string)
+		if disconnect := c.checkTokenUses(jti, credentials.ExpireAt); disconnect != nil {
+			return resp, disconnect
+		}
+		c.mu.Lock()
+		c.user = credentials.UserID
+		c.info = credentials.Info
+		c.exp = credentials.ExpireAt
+		c.ctx = context.WithValue(c.ctx, oidcClaimsContextKey, claims)
+		c.mu.Unlock()
 	} else if cmd.Token != "" {
 		// Explicit auth Credentials not provided in auth handler and in context, try
 		// to extract credentials from connection JWT.
@@ -1166,6 +1535,9 @@ func (c *Client) connectCmd(cmd *proto.ConnectRequest) (*proto.ConnectResponse,
 			info = claims.Info
 			b64info = claims.Base64Info
 			exp = claims.StandardClaims.ExpiresAt
+			if disconnect := c.checkTokenUses(claims.StandardClaims.Id, exp); disconnect != nil {
+				return resp, disconnect
+			}
 		} else {
 			if validationErr, ok := err.(*jwt.ValidationError); ok {
 				if validationErr.Errors == jwt.ValidationErrorExpired {
@@ -1215,12 +1587,6 @@ func (c *Client) connectCmd(cmd *proto.ConnectRequest) (*proto.ConnectResponse,
 
 	c.node.logger.log(newLogEntry(LogLevelDebug, "client authenticated", map[string]interface{}{"client": c.uid, "user": c.user}))
 
-	if userConnectionLimit > 0 && user != "" && len(c.node.hub.userConnections(user)) >= userConnectionLimit {
-		c.node.logger.log(newLogEntry(LogLevelInfo, "limit of connections for user reached", map[string]interface{}{"user": user, "client": c.uid, "limit": userConnectionLimit}))
-		resp.Error = ErrorLimitExceeded
-		return resp, nil
-	}
-
 	c.mu.RLock()
 	if exp > 0 && !insecure {
 		expires = true
@@ -1236,9 +1602,27 @@ func (c *Client) connectCmd(cmd *proto.ConnectRequest) (*proto.ConnectResponse,
 	c.mu.RUnlock()
 
 	res := &proto.ConnectResult{
-		Version: version,
-		Expires: expires,
-		TTL:     ttl,
+		Version:         version,
+		Expires:         expires,
+		TTL:             ttl,
+		ReconnectAdvice: config.reconnectAdvice(),
+	}
+
+	// Limits are checked by hub so connection registered before marked
+	// authenticated.
+	err := c.node.addClient(c)
+	switch err {
+	case nil:
+	case errHubUserConnectionLimit:
+		c.node.logger.log(newLogEntry(LogLevelInfo, "limit of connections for user reached", map[string]interface{}{"user": user, "client": c.uid, "limit": config.userConnectionLimit()}))
+		resp.Error = ErrorConnectionLimitExceeded
+		return resp, nil
+	case errHubConnectionLimit:
+		c.node.logger.log(newLogEntry(LogLevelInfo, "limit of connections on node reached", map[string]interface{}{"user": user, "client": c.uid, "limit": config.ClientConnectionLimit}))
+		return resp, DisconnectConnectionLimit
+	default:
+		c.node.logger.log(newLogEntry(LogLevelError, "error adding client", map[string]interface{}{"client": c.uid, "error": err.Error()}))
+		return resp, DisconnectServerError
 	}
 
 	resp.Result = res
@@ -1252,21 +1636,14 @@ func (c *Client) connectCmd(cmd *proto.ConnectRequest) (*proto.ConnectResponse,
 	}
 	c.addPresenceUpdate()
 	c.mu.Unlock()
-
-	err := c.node.addClient(c)
-	if err != nil {
-		c.node.logger.log(newLogEntry(LogLevelError, "error adding client", map[string]interface{}{"client": c.uid, "error": err.Error()}))
-		return resp, DisconnectServerError
-	}
+	c.node.analytics.clientEvent(AnalyticsEventConnect, c, "")
 
 	if exp > 0 {
 		duration := closeDelay + time.Duration(ttl)*time.Second
-		c.mu.Lock()
-		c.expireTimer = time.AfterFunc(duration, c.expire)
-		c.mu.Unlock()
+		c.node.expirations.add(c, time.Now().Add(duration))
 	}
 
-	if c.node.eventHub.refreshHandler != nil {
+	if c.node.eventHub.refreshHandler != nil && !c.clientSideRefresh {
 		// Only require client-side refresh when no refresh handler set.
 		resp.Result.Expires = false
 		resp.Result.TTL = 0
@@ -1304,36 +1681,71 @@ func (c *Client) refreshCmd(cmd *proto.RefreshRequest) (*proto.RefreshResponse,
 		c.node.logger.log(newLogEntry(LogLevelInfo, "refresh token required", map[string]interface{}{"client": c.uid, "user": c.UserID()}))
 		return resp, DisconnectInvalidToken
 	}
-	parsedToken, err := jwt.ParseWithClaims(token, &connectTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+
+	c.mu.RLock()
+	clientSideRefresh := c.clientSideRefresh
+	c.mu.RUnlock()
+
+	if clientSideRefresh && c.node.eventHub.refreshHandler != nil {
+		reply := c.node.eventHub.refreshHandler(c.ctx, c, RefreshEvent{
+			ClientSideRefresh: true,
+			Token:             token,
+		})
+		if reply.Expired {
+			return resp, DisconnectExpired
+		}
+		user = c.UserID()
+		info = reply.Info
+		expireAt = reply.ExpireAt
+	} else if c.node.oidc != nil {
+		credentials, _, err := c.node.oidc.Authenticate(c.ctx, token)
+		if err != nil {
+			if err == ErrOIDCTokenExpired {
+				resp.Error = ErrorTokenExpired
+				return resp, nil
+			}
+			c.node.logger.log(newLogEntry(LogLevelInfo, "invalid refresh token", map[string]interface{}{"error": err.Error(), "client": c.uid, "user": c.UserID()}))
+			return resp, DisconnectInvalidToken
 		}
-		if secret == "" {
-			return nil, fmt.Errorf("secret not set")
+		if credentials.UserID != c.UserID() {
+			c.node.logger.log(newLogEntry(LogLevelInfo, "refresh token user mismatch", map[string]interface{}{"client": c.uid, "user": c.UserID(), "token_user": credentials.UserID}))
+			return resp, DisconnectInvalidToken
 		}
-		return []byte(secret), nil
-	})
-	if parsedToken == nil && err != nil {
-		c.node.logger.log(newLogEntry(LogLevelInfo, "invalid refresh token", map[string]interface{}{"error": err.Error(), "client": c.uid, "user": c.UserID()}))
-		return resp, DisconnectInvalidToken
-	}
-	if claims, ok := parsedToken.Claims.(*connectTokenClaims); ok && parsedToken.Valid {
-		user = claims.StandardClaims.Subject
-		info = claims.Info
-		b64info = claims.Base64Info
-		expireAt = claims.StandardClaims.ExpiresAt
+		user = credentials.UserID
+		info = credentials.Info
+		expireAt = credentials.ExpireAt
 	} else {
-		if validationErr, ok := err.(*jwt.ValidationError); ok {
-			if validationErr.Errors == jwt.ValidationErrorExpired {
-				// The only problem with token is its expiration - no other errors set in bitfield.
-				resp.Error = ErrorTokenExpired
-				return resp, nil
+		parsedToken, err := jwt.ParseWithClaims(token, &connectTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			if secret == "" {
+				return nil, fmt.Errorf("secret not set")
+			}
+			return []byte(secret), nil
+		})
+		if parsedToken == nil && err != nil {
+			c.node.logger.log(newLogEntry(LogLevelInfo, "invalid refresh token", map[string]interface{}{"error": err.Error(), "client": c.uid, "user": c.UserID()}))
+			return resp, DisconnectInvalidToken
+		}
+		if claims, ok := parsedToken.Claims.(*connectTokenClaims); ok && parsedToken.Valid {
+			user = claims.StandardClaims.Subject
+			info = claims.Info
+			b64info = claims.Base64Info
+			expireAt = claims.StandardClaims.ExpiresAt
+		} else {
+			if validationErr, ok := err.(*jwt.ValidationError); ok {
+				if validationErr.Errors == jwt.ValidationErrorExpired {
+					// The only problem with token is its expiration - no other errors set in bitfield.
+					resp.Error = ErrorTokenExpired
+					return resp, nil
+				}
+				c.node.logger.log(newLogEntry(LogLevelInfo, "invalid refresh token", map[string]interface{}{"error": err.Error(), "client": c.uid, "user": c.UserID()}))
+				return resp, DisconnectInvalidToken
 			}
 			c.node.logger.log(newLogEntry(LogLevelInfo, "invalid refresh token", map[string]interface{}{"error": err.Error(), "client": c.uid, "user": c.UserID()}))
 			return resp, DisconnectInvalidToken
 		}
-		c.node.logger.log(newLogEntry(LogLevelInfo, "invalid refresh token", map[string]interface{}{"error": err.Error(), "client": c.uid, "user": c.UserID()}))
-		return resp, DisconnectInvalidToken
 	}
 
 	res := &proto.RefreshResult{
@@ -1369,12 +1781,9 @@ func (c *Client) refreshCmd(cmd *proto.RefreshRequest) (*proto.RefreshResponse,
 				}
 				c.info = byteInfo
 			}
-			if c.expireTimer != nil {
-				c.expireTimer.Stop()
-			}
-			duration := time.Duration(timeToExpire)*time.Second + config.ClientExpiredCloseDelay
-			c.expireTimer = time.AfterFunc(duration, c.expire)
 			c.mu.Unlock()
+			duration := time.Duration(timeToExpire)*time.Second + config.ClientExpiredCloseDelay
+			c.node.expirations.add(c, time.Now().Add(duration))
 		} else {
 			resp.Error = ErrorExpired
 			return resp, nil
@@ -1401,6 +1810,7 @@ func (c *Client) subscribeCmd(cmd *proto.SubscribeRequest, rw *replyWriter) *Dis
 	channelMaxLength := config.ChannelMaxLength
 	channelLimit := config.ClientChannelLimit
 	insecure := config.ClientInsecure
+	maxRecovered := config.ClientRecoveryMaxPublicationLimit
 
 	res := &proto.SubscribeResult{}
 
@@ -1410,13 +1820,19 @@ func (c *Client) subscribeCmd(cmd *proto.SubscribeRequest, rw *replyWriter) *Dis
 		return nil
 	}
 
+	if strings.Contains(channel, partitionSeparator) {
+		// Partition channels are internal and can't be subscribed directly.
+		rw.write(&proto.Reply{Error: ErrorPermissionDenied})
+		return nil
+	}
+
 	c.mu.RLock()
 	numChannels := len(c.channels)
 	c.mu.RUnlock()
 
 	if channelLimit > 0 && numChannels >= channelLimit {
 		c.node.logger.log(newLogEntry(LogLevelInfo, "maximum limit of channels per client reached", map[string]interface{}{"limit": channelLimit, "user": c.user, "client": c.uid}))
-		rw.write(&proto.Reply{Error: ErrorLimitExceeded})
+		rw.write(&proto.Reply{Error: ErrorChannelLimitExceeded})
 		return nil
 	}
 
@@ -1436,7 +1852,12 @@ func (c *Client) subscribeCmd(cmd *proto.SubscribeRequest, rw *replyWriter) *Dis
 		return nil
 	}
 
-	chOpts, ok := c.node.ChannelOpts(channel)
+	chOpts, ok, err := c.node.channelOptions(channel)
+	if err != nil {
+		c.node.logger.log(newLogEntry(LogLevelError, "error getting channel options", map[string]interface{}{"channel": channel, "user": c.user, "client": c.uid, "error": err.Error()}))
+		rw.write(&proto.Reply{Error: ErrorInternal})
+		return nil
+	}
 	if !ok {
 		rw.write(&proto.Reply{Error: ErrorNamespaceNotFound})
 		return nil
@@ -1525,9 +1946,18 @@ func (c *Client) subscribeCmd(cmd *proto.SubscribeRequest, rw *replyWriter) *Dis
 	}
 
 	if c.eventHub.subscribeHandler != nil {
-		reply := c.eventHub.subscribeHandler(SubscribeEvent{
-			Channel: channel,
-		})
+		release, ok := c.node.acquireHandler(channel)
+		if !ok {
+			rw.write(&proto.Reply{Error: ErrorLimitExceeded})
+			return nil
+		}
+		reply := func() SubscribeReply {
+			// Slot must be released even if handler panics.
+			defer release()
+			return c.eventHub.subscribeHandler(SubscribeEvent{
+				Channel: channel,
+			})
+		}()
 		if reply.Disconnect != nil {
 			return reply.Disconnect
 		}
@@ -1559,11 +1989,30 @@ func (c *Client) subscribeCmd(cmd *proto.SubscribeRequest, rw *replyWriter) *Dis
 		}
 	}
 
+	// Lock taken after handler so handler could call Client.Subscribe.
+	c.subscribeMu.Lock()
+	defer c.subscribeMu.Unlock()
+
+	c.mu.RLock()
+	numChannels = len(c.channels)
+	_, ok = c.channels[channel]
+	c.mu.RUnlock()
+
+	if ok {
+		// Handler could subscribe client on this channel.
+		rw.write(&proto.Reply{Error: ErrorAlreadySubscribed})
+		return nil
+	}
+	if channelLimit > 0 && numChannels >= channelLimit {
+		rw.write(&proto.Reply{Error: ErrorChannelLimitExceeded})
+		return nil
+	}
+
 	if chOpts.HistoryRecover {
 		c.setInSubscribe(channel, true)
 	}
 
-	err := c.node.addSubscription(channel, c)
+	err = c.node.addSubscription(channel, c)
 	if err != nil {
 		c.node.logger.log(newLogEntry(LogLevelError, "error adding subscription", map[string]interface{}{"channel": channel, "user": c.user, "client": c.uid, "error": err.Error()}))
 		if chOpts.HistoryRecover {
@@ -1629,6 +2078,12 @@ func (c *Client) subscribeCmd(cmd *proto.SubscribeRequest, rw *replyWriter) *Dis
 			} else {
 				recovered = publications[0].Seq == nextSeq && publications[0].Gen == nextGen && latestEpoch == cmd.Epoch
 			}
+			if maxRecovered > 0 && len(publications) > maxRecovered {
+				// Too many publications missed, client should not rely on
+				// recovery and better reload state.
+				res.Publications = publications[len(publications)-maxRecovered:]
+				recovered = false
+			}
 			res.Recovered = recovered
 
 			recoveredLabel := "no"
@@ -1666,7 +2121,7 @@ func (c *Client) subscribeCmd(cmd *proto.SubscribeRequest, rw *replyWriter) *Dis
 			}
 			return res.Publications[i].Seq > res.Publications[j].Seq
 		})
-		res.Publications = uniquePublications(res.Publications)
+		res.Publications = unexpiredPublications(uniquePublications(res.Publications))
 	}
 
 	replyRes, err := proto.GetResultEncoder(c.transport.Encoding()).EncodeSubscribeResult(res)
@@ -1709,6 +2164,8 @@ func (c *Client) subscribeCmd(cmd *proto.SubscribeRequest, rw *replyWriter) *Dis
 	c.channels[channel] = channelContext
 	c.mu.Unlock()
 
+	c.node.analytics.clientEvent(AnalyticsEventSubscribe, c, channel)
+
 	if c.node.logger.enabled(LogLevelDebug) {
 		c.node.logger.log(newLogEntry(LogLevelDebug, "client subscribed to channel", map[string]interface{}{"client": c.uid, "user": c.user, "channel": cmd.Channel}))
 	}
@@ -1717,6 +2174,14 @@ func (c *Client) subscribeCmd(cmd *proto.SubscribeRequest, rw *replyWriter) *Dis
 }
 
 func (c *Client) writePublicationUpdatePosition(ch string, pub *Publication, reply *preparedReply, chOpts *ChannelOptions) error {
+	if c.messageWriter.overloaded(chOpts.Priority) {
+		// Dropped before recovery position updated so next publication of
+		// recoverable channel disconnects client with insufficient state and
+		// client recovers missed publications on reconnect.
+		droppedPublicationsCount.WithLabelValues(strconv.Itoa(chOpts.Priority)).Inc()
+		queueDroppedCount.WithLabelValues(c.transport.Name(), "priority").Inc()
+		return nil
+	}
 	if chOpts.HistoryRecover {
 		c.mu.Lock()
 		channelContext, ok := c.channels[ch]
@@ -1736,10 +2201,17 @@ func (c *Client) writePublicationUpdatePosition(ch string, pub *Publication, rep
 		c.channels[ch] = channelContext
 		c.mu.Unlock()
 	}
-	return c.transportSend(reply)
+	if publicationExpired(pub, time.Now().UnixNano()/int64(time.Millisecond)) {
+		// Position updated above but stale publication not sent.
+		return nil
+	}
+	return c.transportSendChannel(ch, reply, chOpts.Priority)
 }
 
 func (c *Client) writePublication(ch string, pub *Publication, reply *preparedReply, chOpts *ChannelOptions) error {
+	if pub.Ephemeral {
+		return c.writeEphemeral(ch, reply, chOpts)
+	}
 	if c.isInSubscribe(ch) {
 		// Client currently in process of subscribing to this channel. In this case we keep
 		// publications in slice buffer. Publications from this temporary buffer will be sent in
@@ -1757,6 +2229,25 @@ func (c *Client) writePublication(ch string, pub *Publication, reply *preparedRe
 	return c.writePublicationUpdatePosition(ch, pub, reply, chOpts)
 }
 
+// writeEphemeral sends ephemeral publication without touching recovery
+// position. Ephemeral publications are dropped while client subscribing and
+// under queue pressure as if channel had low priority.
+func (c *Client) writeEphemeral(ch string, reply *preparedReply, chOpts *ChannelOptions) error {
+	if c.isInSubscribe(ch) {
+		return nil
+	}
+	priority := chOpts.Priority
+	if priority > -1 {
+		priority = -1
+	}
+	if c.messageWriter.overloaded(priority) {
+		droppedPublicationsCount.WithLabelValues(strconv.Itoa(priority)).Inc()
+		queueDroppedCount.WithLabelValues(c.transport.Name(), "priority").Inc()
+		return nil
+	}
+	return c.transportSendChannel(ch, reply, 0)
+}
+
 func (c *Client) writeJoin(ch string, reply *preparedReply) error {
 	return c.transportSend(reply)
 }
@@ -1765,6 +2256,10 @@ func (c *Client) writeLeave(ch string, reply *preparedReply) error {
 	return c.transportSend(reply)
 }
 
+func (c *Client) writeMembership(ch string, reply *preparedReply) error {
+	return c.transportSend(reply)
+}
+
 func uniquePublications(s []*Publication) []*Publication {
 	keys := make(map[uint64]struct{})
 	list := []*Publication{}
@@ -1913,7 +2408,7 @@ func (c *Client) unsubscribe(channel string) error {
 		c.mu.Unlock()
 
 		if chOpts.Presence {
-			err := c.node.removePresence(channel, c.uid)
+			err := c.node.removePresence(channel, c.uid, c.user)
 			if err != nil {
 				c.node.logger.log(newLogEntry(LogLevelError, "error removing channel presence", map[string]interface{}{"channel": channel, "user": c.user, "client": c.uid, "error": err.Error()}))
 			}
@@ -1931,6 +2426,7 @@ func (c *Client) unsubscribe(channel string) error {
 			c.node.logger.log(newLogEntry(LogLevelError, "error removing subscription", map[string]interface{}{"channel": channel, "user": c.user, "client": c.uid, "error": err.Error()}))
 			return err
 		}
+		c.node.analytics.clientEvent(AnalyticsEventUnsubscribe, c, channel)
 
 		if c.eventHub.unsubscribeHandler != nil {
 			c.eventHub.unsubscribeHandler(UnsubscribeEvent{
@@ -1985,7 +2481,12 @@ func (c *Client) publishCmd(cmd *proto.PublishRequest) (*proto.PublishResponse,
 
 	resp := &proto.PublishResponse{}
 
-	chOpts, ok := c.node.ChannelOpts(ch)
+	chOpts, ok, err := c.node.channelOptions(ch)
+	if err != nil {
+		c.node.logger.log(newLogEntry(LogLevelError, "error getting channel options", map[string]interface{}{"channel": ch, "user": c.user, "client": c.uid, "error": err.Error()}))
+		resp.Error = ErrorInternal
+		return resp, nil
+	}
 	if !ok {
 		c.node.logger.log(newLogEntry(LogLevelInfo, "attempt to publish to non-existing namespace", map[string]interface{}{"channel": ch, "user": c.user, "client": c.uid}))
 		resp.Error = ErrorNamespaceNotFound
@@ -2013,12 +2514,26 @@ func (c *Client) publishCmd(cmd *proto.PublishRequest) (*proto.PublishResponse,
 		return resp, nil
 	}
 
+	if c.node.UserMuted(ch, c.user) {
+		resp.Error = ErrorMuted
+		return resp, nil
+	}
+
 	if c.eventHub.publishHandler != nil {
-		reply := c.eventHub.publishHandler(PublishEvent{
-			Channel: ch,
-			Data:    data,
-			Info:    info,
-		})
+		release, ok := c.node.acquireHandler(ch)
+		if !ok {
+			resp.Error = ErrorLimitExceeded
+			return resp, nil
+		}
+		reply := func() PublishReply {
+			// Slot must be released even if handler panics.
+			defer release()
+			return c.eventHub.publishHandler(PublishEvent{
+				Channel: ch,
+				Data:    data,
+				Info:    info,
+			})
+		}()
 		if reply.Disconnect != nil {
 			return resp, reply.Disconnect
 		}
@@ -2028,7 +2543,19 @@ func (c *Client) publishCmd(cmd *proto.PublishRequest) (*proto.PublishResponse,
 		}
 	}
 
-	err := c.node.publish(ch, data, info)
+	var opts []PublishOption
+	if cmd.IdempotencyKey != "" {
+		// Keys of different users must not clash so one user can't prevent
+		// publications of another one.
+		opts = append(opts, WithIdempotencyKey(c.user+":"+cmd.IdempotencyKey))
+	}
+
+	err = c.node.publish(ch, data, info, opts...)
+	if err == ErrPublishInProgress {
+		// Client can retry publish later to find out whether it succeeded.
+		resp.Error = ErrorNotAvailable
+		return resp, nil
+	}
 	if err != nil {
 		c.node.logger.log(newLogEntry(LogLevelError, "error publishing", map[string]interface{}{"channel": ch, "user": c.user, "client": c.uid, "error": err.Error()}))
 		resp.Error = ErrorInternal
@@ -2052,7 +2579,12 @@ func (c *Client) presenceCmd(cmd *proto.PresenceRequest) (*proto.PresenceRespons
 
 	resp := &proto.PresenceResponse{}
 
-	chOpts, ok := c.node.ChannelOpts(ch)
+	chOpts, ok, err := c.node.channelOptions(ch)
+	if err != nil {
+		c.node.logger.log(newLogEntry(LogLevelError, "error getting channel options", map[string]interface{}{"channel": ch, "user": c.user, "client": c.uid, "error": err.Error()}))
+		resp.Error = ErrorInternal
+		return resp, nil
+	}
 	if !ok {
 		resp.Error = ErrorNamespaceNotFound
 		return resp, nil
@@ -2107,7 +2639,12 @@ func (c *Client) presenceStatsCmd(cmd *proto.PresenceStatsRequest) (*proto.Prese
 		return resp, nil
 	}
 
-	chOpts, ok := c.node.ChannelOpts(ch)
+	chOpts, ok, err := c.node.channelOptions(ch)
+	if err != nil {
+		c.node.logger.log(newLogEntry(LogLevelError, "error getting channel options", map[string]interface{}{"channel": ch, "user": c.user, "client": c.uid, "error": err.Error()}))
+		resp.Error = ErrorInternal
+		return resp, nil
+	}
 	if !ok {
 		resp.Error = ErrorNamespaceNotFound
 		return resp, nil
@@ -2157,7 +2694,12 @@ func (c *Client) historyCmd(cmd *proto.HistoryRequest) (*proto.HistoryResponse,
 		return resp, nil
 	}
 
-	chOpts, ok := c.node.ChannelOpts(ch)
+	chOpts, ok, err := c.node.channelOptions(ch)
+	if err != nil {
+		c.node.logger.log(newLogEntry(LogLevelError, "error getting channel options", map[string]interface{}{"channel": ch, "user": c.user, "client": c.uid, "error": err.Error()}))
+		resp.Error = ErrorInternal
+		return resp, nil
+	}
 	if !ok {
 		resp.Error = ErrorNamespaceNotFound
 		return resp, nil
@@ -2168,7 +2710,15 @@ func (c *Client) historyCmd(cmd *proto.HistoryRequest) (*proto.HistoryResponse,
 		return resp, nil
 	}
 
-	pubs, err := c.node.History(ch)
+	var opts []HistoryOption
+	if cmd.UseSince {
+		opts = append(opts, WithSince(RecoveryPosition{Seq: cmd.Seq, Gen: cmd.Gen, Epoch: cmd.Epoch}))
+	}
+	if cmd.Limit > 0 {
+		opts = append(opts, WithLimit(int(cmd.Limit)))
+	}
+
+	history, err := c.node.History(ch, opts...)
 	if err != nil {
 		c.node.logger.log(newLogEntry(LogLevelError, "error getting history", map[string]interface{}{"channel": ch, "user": c.user, "client": c.uid, "error": err.Error()}))
 		resp.Error = ErrorInternal
@@ -2176,12 +2726,55 @@ func (c *Client) historyCmd(cmd *proto.HistoryRequest) (*proto.HistoryResponse,
 	}
 
 	resp.Result = &proto.HistoryResult{
-		Publications: pubs,
+		Publications: history.Publications,
+		Seq:          history.Position.Seq,
+		Gen:          history.Position.Gen,
+		Epoch:        history.Position.Epoch,
 	}
 
 	return resp, nil
 }
 
+// markReadCmd handles mark read command - it saves position in channel up
+// to which user read publications. Client must be subscribed to channel.
+func (c *Client) markReadCmd(cmd *proto.MarkReadRequest) (*proto.MarkReadResponse, *Disconnect) {
+
+	ch := cmd.Channel
+
+	if ch == "" {
+		return nil, DisconnectBadRequest
+	}
+
+	resp := &proto.MarkReadResponse{}
+
+	c.mu.RLock()
+	_, ok := c.channels[ch]
+	c.mu.RUnlock()
+
+	if !ok {
+		resp.Error = ErrorPermissionDenied
+		return resp, nil
+	}
+
+	err := c.node.SetReadPosition(c.user, ch, RecoveryPosition{
+		Seq:   cmd.Seq,
+		Gen:   cmd.Gen,
+		Epoch: cmd.Epoch,
+	})
+	if err == ErrReadPositionNotSupported {
+		resp.Error = ErrorNotAvailable
+		return resp, nil
+	} else if err != nil {
+		c.node.logger.log(newLogEntry(LogLevelError, "error setting read position", map[string]interface{}{"channel": ch, "user": c.user, "client": c.uid, "error": err.Error()}))
+		resp.Error = ErrorInternal
+		return resp, nil
+	}
+
+	resp.Result = &proto.MarkReadResult{}
+
+	return resp, nil
+}
+
 // pingCmd handles ping command from client.
 func (c *Client) pingCmd(cmd *proto.PingRequest) (*proto.PingResponse, *Disconnect) {
 	return &proto.PingResponse{}, nil
@@ -3,6 +3,7 @@ package centrifuge
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"strconv"
 	"strings"
@@ -166,6 +167,57 @@ func TestClientConnectWithValidToken(t *testing.T) {
 	assert.Equal(t, false, resp.Result.Expires)
 }
 
+func TestClientConnectRedirect(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	node.On().ClientConnecting(func(ctx context.Context, t Transport, e ConnectEvent) ConnectReply {
+		return ConnectReply{
+			Redirect: &Redirect{Endpoints: []string{"ws://other:8000/connection/websocket"}, Reason: "overloaded"},
+		}
+	})
+
+	transport := newTestTransport()
+	client, _ := newClient(context.Background(), node, transport)
+	var replies []*proto.Reply
+	rw := testReplyWriter(&replies)
+	params, _ := json.Marshal(&proto.ConnectRequest{})
+	disconnect := client.handleConnect(params, rw)
+	assert.Equal(t, DisconnectRedirect, disconnect)
+	assert.Len(t, replies, 1)
+	assert.Nil(t, replies[0].Error)
+	assert.True(t, strings.Contains(string(replies[0].Result), `"redirect":{"endpoints":["ws://other:8000/connection/websocket"],"reason":"overloaded"}`))
+	assert.False(t, client.authenticated)
+	assert.Equal(t, 0, node.hub.NumClients())
+}
+
+func TestClientReconnectAdvice(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientReconnectMinDelay = time.Second
+	config.ClientReconnectMaxDelay = time.Minute
+	config.ClientReconnectJitter = 500 * time.Millisecond
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	resp, disconnect := client.connectCmd(&proto.ConnectRequest{})
+	assert.Nil(t, disconnect)
+	advice := &ReconnectAdvice{MinDelay: 1000, MaxDelay: 60000, Jitter: 500}
+	assert.Equal(t, advice, resp.Result.ReconnectAdvice)
+
+	assert.NoError(t, client.Close(DisconnectShutdown))
+	assert.Equal(t, advice, transport.disconnect.ReconnectAdvice)
+	assert.Equal(t, DisconnectShutdown.Reason, transport.disconnect.Reason)
+	// Predefined disconnect not modified.
+	assert.Nil(t, DisconnectShutdown.ReconnectAdvice)
+
+	transport = newTestTransport()
+	client, _ = newClient(newCtx, node, transport)
+	assert.NoError(t, client.Close(DisconnectForceNoReconnect))
+	assert.Nil(t, transport.disconnect.ReconnectAdvice)
+}
+
 func TestClientConnectWithExpiringToken(t *testing.T) {
 	node := nodeWithMemoryEngine()
 
@@ -252,6 +304,76 @@ func TestClientConnectContextCredentials(t *testing.T) {
 	assert.Equal(t, "42", client.UserID())
 }
 
+func TestClientConnectUserConnectionLimit(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientUserConnectionLimit = 1
+	assert.NoError(t, node.Reload(config))
+
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, newTestTransport())
+	connectClient(t, client)
+
+	other, _ := newClient(newCtx, node, newTestTransport())
+	resp, disconnect := other.connectCmd(&proto.ConnectRequest{})
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorConnectionLimitExceeded, resp.Error)
+	assert.False(t, other.authenticated)
+	assert.Equal(t, 1, node.hub.NumClients())
+
+	client.Close(nil)
+	connectClient(t, other)
+}
+
+func TestClientConnectUserConnectionLimitAlias(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.UserConnectionLimit = 1
+	assert.NoError(t, node.Reload(config))
+
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, newTestTransport())
+	connectClient(t, client)
+
+	other, _ := newClient(newCtx, node, newTestTransport())
+	resp, disconnect := other.connectCmd(&proto.ConnectRequest{})
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorConnectionLimitExceeded, resp.Error)
+}
+
+func TestClientConnectUserConnectionLimitAnonymous(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientAnonymous = true
+	config.ClientInsecure = true
+	config.ClientUserConnectionLimit = 1
+	assert.NoError(t, node.Reload(config))
+
+	// Anonymous connections with empty user ID are not limited per user.
+	for i := 0; i < 2; i++ {
+		client, _ := newClient(context.Background(), node, newTestTransport())
+		connectClient(t, client)
+		assert.Equal(t, "", client.UserID())
+	}
+	assert.Equal(t, 2, node.hub.NumClients())
+}
+
+func TestClientConnectConnectionLimit(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientConnectionLimit = 1
+	assert.NoError(t, node.Reload(config))
+
+	client, _ := newClient(SetCredentials(context.Background(), &Credentials{UserID: "42"}), node, newTestTransport())
+	connectClient(t, client)
+
+	other, _ := newClient(SetCredentials(context.Background(), &Credentials{UserID: "43"}), node, newTestTransport())
+	_, disconnect := other.connectCmd(&proto.ConnectRequest{})
+	assert.Equal(t, DisconnectConnectionLimit, disconnect)
+	assert.False(t, other.authenticated)
+	assert.Equal(t, 1, node.hub.NumClients())
+}
+
 func TestClientConnectWithExpiredContextCredentials(t *testing.T) {
 	node := nodeWithMemoryEngine()
 
@@ -276,6 +398,67 @@ func TestClientConnectWithExpiredContextCredentials(t *testing.T) {
 	assert.Equal(t, ErrorExpired, resp.Error)
 }
 
+func TestClientSideRefreshHandler(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	node.On().ClientConnecting(func(ctx context.Context, t Transport, e ConnectEvent) ConnectReply {
+		return ConnectReply{
+			Credentials: &Credentials{
+				UserID:   "42",
+				ExpireAt: time.Now().Unix() + 60,
+			},
+			ClientSideRefresh: true,
+		}
+	})
+	node.On().ClientRefresh(func(ctx context.Context, c *Client, e RefreshEvent) RefreshReply {
+		if !e.ClientSideRefresh || e.Token != "valid" {
+			return RefreshReply{Expired: true}
+		}
+		return RefreshReply{
+			ExpireAt: time.Now().Unix() + 120,
+			Info:     []byte(`{"refreshed":true}`),
+		}
+	})
+
+	transport := newTestTransport()
+	client, _ := newClient(context.Background(), node, transport)
+	resp, disconnect := client.connectCmd(&proto.ConnectRequest{})
+	assert.Nil(t, disconnect)
+	assert.True(t, resp.Result.Expires)
+	assert.True(t, resp.Result.TTL > 0)
+
+	refreshResp, disconnect := client.refreshCmd(&proto.RefreshRequest{Token: "valid"})
+	assert.Nil(t, disconnect)
+	assert.True(t, refreshResp.Result.Expires)
+	assert.True(t, refreshResp.Result.TTL > 60)
+	assert.Equal(t, Raw(`{"refreshed":true}`), client.info)
+
+	_, disconnect = client.refreshCmd(&proto.RefreshRequest{Token: "invalid"})
+	assert.Equal(t, DisconnectExpired, disconnect)
+}
+
+func TestServerSideRefreshExpired(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	node.On().ClientRefresh(func(ctx context.Context, c *Client, e RefreshEvent) RefreshReply {
+		assert.False(t, e.ClientSideRefresh)
+		return RefreshReply{Expired: true}
+	})
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{
+		UserID:   "42",
+		ExpireAt: time.Now().Unix() + 60,
+	})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	client.expire()
+	assert.True(t, client.closed)
+	assert.Equal(t, DisconnectExpired.Reason, transport.disconnect.Reason)
+}
+
 func connectClient(t *testing.T, client *Client) *proto.ConnectResult {
 	connectResp, disconnect := client.connectCmd(&proto.ConnectRequest{})
 	assert.Nil(t, disconnect)
@@ -349,6 +532,26 @@ func TestClientSubscribe(t *testing.T) {
 	assert.Equal(t, ErrorAlreadySubscribed, replies[0].Error)
 }
 
+func TestClientSubscribeChannelLimit(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientChannelLimit = 1
+	assert.NoError(t, node.Reload(config))
+
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, newTestTransport())
+	connectClient(t, client)
+	subscribeClient(t, client, "test1")
+
+	replies := []*proto.Reply{}
+	disconnect := client.subscribeCmd(&proto.SubscribeRequest{
+		Channel: "test2",
+	}, testReplyWriter(&replies))
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorChannelLimitExceeded, replies[0].Error)
+	assert.Equal(t, 1, len(client.Channels()))
+}
+
 func TestClientSubscribeReceivePublication(t *testing.T) {
 	node := nodeWithMemoryEngine()
 	transport := newTestTransport()
@@ -583,6 +786,46 @@ func TestClientSubscribeLast(t *testing.T) {
 	assert.Equal(t, uint32(10), result.Seq)
 }
 
+func TestClientPriorityDropKeepsRecoveryPosition(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	config.HistoryRecover = true
+	assert.NoError(t, node.Reload(config))
+
+	client, _ := newClient(SetCredentials(context.Background(), &Credentials{UserID: "42"}), node, newTestTransport())
+	connectClient(t, client)
+	subscribeClient(t, client, "test")
+
+	w, release := newBlockedWriter(t, writerConfig{MaxQueueSize: 8})
+	defer release()
+	client.messageWriter = w
+	assert.Nil(t, w.enqueue([]byte("12345678")))
+
+	// Channel options with negative priority and recovery can only come
+	// from ChannelOptionsFunc as config validation rejects them.
+	chOpts := &ChannelOptions{HistoryRecover: true, Priority: -1}
+	reply := newPreparedReply(&proto.Reply{Result: []byte(`{}`)}, proto.EncodingJSON)
+	assert.NoError(t, client.writePublicationUpdatePosition("test", &Publication{Seq: 1}, reply, chOpts))
+	assert.Equal(t, uint32(0), client.channels["test"].recoveryPosition.Seq)
+}
+
+func TestConfigValidatePriorityRecover(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.HistoryRecover = true
+	config.Priority = -1
+	assert.Error(t, node.Reload(config))
+
+	config = node.Config()
+	config.Namespaces = []ChannelNamespace{{Name: "low", ChannelOptions: ChannelOptions{HistoryRecover: true, Priority: -1}}}
+	assert.Error(t, node.Reload(config))
+
+	config.Namespaces[0].Priority = 1
+	assert.NoError(t, node.Reload(config))
+}
+
 func TestClientUnsubscribe(t *testing.T) {
 	node := nodeWithMemoryEngine()
 	transport := newTestTransport()
@@ -613,6 +856,204 @@ func TestClientUnsubscribe(t *testing.T) {
 	assert.Equal(t, 0, node.Hub().NumChannels())
 }
 
+func TestClientSubscribeServerSide(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+
+	assert.Equal(t, ErrorUnauthorized, client.Subscribe("test"))
+
+	connectClient(t, client)
+	assert.NoError(t, client.Subscribe("test"))
+	waitSinkContains(t, transport, `"type":7,"channel":"test"`)
+	assert.Equal(t, 1, len(client.Channels()))
+	assert.Equal(t, 1, node.Hub().NumSubscribers("test"))
+	assert.Equal(t, ErrorAlreadySubscribed, client.Subscribe("test"))
+	assert.Equal(t, ErrorNamespaceNotFound, client.Subscribe("unknown:test"))
+
+	assert.NoError(t, node.Publish("test", []byte(`{"text":"server"}`)))
+	waitSinkContains(t, transport, `{"text":"server"}`)
+}
+
+func TestClientSubscribeServerSideLimits(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientChannelLimit = 1
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	assert.Equal(t, ErrorPermissionDenied, client.Subscribe(partitionChannel("test", 0)))
+	assert.NoError(t, client.Subscribe("test1"))
+	assert.Equal(t, ErrorChannelLimitExceeded, client.Subscribe("test2"))
+}
+
+func TestClientSubscribeServerSideFromHandler(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	transport := newTestTransport()
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	client.On().Subscribe(func(e SubscribeEvent) SubscribeReply {
+		if e.Channel == "test1" {
+			assert.NoError(t, client.Subscribe("test2"))
+		}
+		return SubscribeReply{}
+	})
+	connectClient(t, client)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		subscribeClient(t, client, "test1")
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("subscribe from handler deadlocked")
+	}
+	assert.Len(t, client.Channels(), 2)
+}
+
+func TestClientSubscribeServerSideRecover(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	config.HistoryRecover = true
+	assert.NoError(t, node.Reload(config))
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, node.Publish("test", []byte("{}")))
+	}
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	assert.NoError(t, client.Subscribe("test"))
+	waitSinkContains(t, transport, `"data":{"recoverable":true,"seq":3,`)
+	assert.Equal(t, uint32(3), client.channels["test"].recoveryPosition.Seq)
+
+	assert.NoError(t, node.Publish("test", []byte(`{"text":"next"}`)))
+	waitSinkContains(t, transport, `"seq":4`)
+}
+
+// failingRecoveryHistory fails to return recovery state of channel.
+type failingRecoveryHistory struct {
+	*MemoryEngine
+}
+
+func (e *failingRecoveryHistory) History(ch string, filter HistoryFilter) ([]*Publication, RecoveryPosition, error) {
+	return nil, RecoveryPosition{}, errors.New("history unavailable")
+}
+
+func TestClientSubscribeServerSideRollback(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.Presence = true
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	config.HistoryRecover = true
+	assert.NoError(t, node.Reload(config))
+	node.SetHistoryManager(&failingRecoveryHistory{testMemoryEngine()})
+
+	transport := newTestTransport()
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	assert.Error(t, client.Subscribe("test"))
+	assert.Len(t, client.Channels(), 0)
+	assert.Equal(t, 0, node.Hub().NumSubscribers("test"))
+	presence, err := node.Presence("test")
+	assert.NoError(t, err)
+	assert.Len(t, presence, 0)
+}
+
+func TestClientSubscribeServerSideSendError(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	transport := newTestTransport()
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	assert.NoError(t, client.messageWriter.close())
+	assert.Equal(t, io.EOF, client.Subscribe("test"))
+}
+
+func TestClientSubscribeServerSideBufferedPublications(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.HistoryRecover = true
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	// Publication already in position is skipped, next one sent.
+	client.pubBuffer = []*Publication{{Seq: 1, Data: []byte(`"old"`)}, {Seq: 2, Data: []byte(`"new"`)}}
+	client.channels["test"] = ChannelContext{recoveryPosition: RecoveryPosition{Seq: 1}}
+	chOpts, _ := node.ChannelOpts("test")
+	assert.NoError(t, client.writeBufferedPublications("test", &chOpts, RecoveryPosition{Seq: 1}))
+	data := string(<-transport.sink)
+	assert.True(t, strings.Contains(data, `"new"`))
+	assert.False(t, strings.Contains(data, `"old"`))
+	assert.Equal(t, uint32(2), client.channels["test"].recoveryPosition.Seq)
+	assert.Len(t, client.pubBuffer, 0)
+}
+
+func TestClientConnectSubscriptions(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	node.On().ClientConnecting(func(ctx context.Context, t Transport, e ConnectEvent) ConnectReply {
+		return ConnectReply{
+			Credentials:   &Credentials{UserID: "42"},
+			Subscriptions: []string{"test1", "test2"},
+		}
+	})
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	client, _ := newClient(context.Background(), node, transport)
+	var replies []*proto.Reply
+	rw := testReplyWriter(&replies)
+	params, _ := json.Marshal(&proto.ConnectRequest{})
+	disconnect := client.handleConnect(params, rw)
+	assert.Nil(t, disconnect)
+	assert.Len(t, replies, 1)
+	assert.Equal(t, 2, len(client.Channels()))
+	waitSinkContains(t, transport, `"channel":"test2"`)
+}
+
+func TestNodeSubscribe(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	subscribeClient(t, client, "test1")
+
+	assert.NoError(t, node.Subscribe("42", "test1"))
+	assert.NoError(t, node.Subscribe("42", "test2"))
+	assert.Equal(t, 2, len(client.Channels()))
+	assert.NoError(t, node.Subscribe("13", "test2"))
+	assert.Equal(t, ErrNoChannelOptions, node.Subscribe("42", "unknown:test"))
+}
+
 func TestClientPublish(t *testing.T) {
 	node := nodeWithMemoryEngine()
 	transport := newTestTransport()
@@ -788,6 +1229,97 @@ func TestClientHistory(t *testing.T) {
 	assert.Nil(t, historyResp.Result)
 }
 
+func TestClientSubscribeRecoverMaxPublicationLimit(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	config.HistoryRecover = true
+	config.ClientRecoveryMaxPublicationLimit = 3
+	assert.NoError(t, node.Reload(config))
+
+	for i := 1; i <= 8; i++ {
+		assert.NoError(t, node.Publish("test", []byte(`{"n": `+strconv.Itoa(i)+`}`)))
+	}
+	_, recoveryPosition, _ := node.historyManager.History("test", HistoryFilter{})
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	replies := []*proto.Reply{}
+	rw := testReplyWriter(&replies)
+	disconnect := client.subscribeCmd(&proto.SubscribeRequest{
+		Channel: "test",
+		Recover: true,
+		Seq:     2,
+		Epoch:   recoveryPosition.Epoch,
+	}, rw)
+	assert.Nil(t, disconnect)
+	res := extractSubscribeResult(replies)
+	assert.False(t, res.Recovered)
+	assert.Len(t, res.Publications, 3)
+	assert.Equal(t, uint32(8), res.Publications[0].Seq)
+	assert.Equal(t, uint32(6), res.Publications[2].Seq)
+
+	// Within limit recovery succeeds.
+	client, _ = newClient(newCtx, node, transport)
+	connectClient(t, client)
+	replies = nil
+	rw = testReplyWriter(&replies)
+	disconnect = client.subscribeCmd(&proto.SubscribeRequest{
+		Channel: "test",
+		Recover: true,
+		Seq:     5,
+		Epoch:   recoveryPosition.Epoch,
+	}, rw)
+	assert.Nil(t, disconnect)
+	res = extractSubscribeResult(replies)
+	assert.True(t, res.Recovered)
+	assert.Len(t, res.Publications, 3)
+}
+
+func TestClientHistoryPagination(t *testing.T) {
+	node := nodeWithHistory(t, 10)
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, node.Publish("test", []byte(`{}`)))
+	}
+
+	connectClient(t, client)
+	subscribeClient(t, client, "test")
+
+	historyResp, disconnect := client.historyCmd(&proto.HistoryRequest{
+		Channel: "test",
+		Limit:   2,
+	})
+	assert.Nil(t, disconnect)
+	assert.Nil(t, historyResp.Error)
+	assert.Len(t, historyResp.Result.Publications, 2)
+	assert.Equal(t, uint32(1), historyResp.Result.Publications[0].Seq)
+	assert.Equal(t, uint32(5), historyResp.Result.Seq)
+	assert.NotEqual(t, "", historyResp.Result.Epoch)
+
+	historyResp, disconnect = client.historyCmd(&proto.HistoryRequest{
+		Channel:  "test",
+		UseSince: true,
+		Seq:      2,
+		Epoch:    historyResp.Result.Epoch,
+		Limit:    2,
+	})
+	assert.Nil(t, disconnect)
+	assert.Nil(t, historyResp.Error)
+	assert.Len(t, historyResp.Result.Publications, 2)
+	assert.Equal(t, uint32(3), historyResp.Result.Publications[0].Seq)
+	assert.Equal(t, uint32(4), historyResp.Result.Publications[1].Seq)
+}
+
 func TestClientCloseUnauthenticated(t *testing.T) {
 	node := nodeWithMemoryEngine()
 
@@ -974,3 +1506,83 @@ func TestClientSubscribeRecoverMemory(t *testing.T) {
 		})
 	}
 }
+
+func TestClientRPC(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	var replies []*proto.Reply
+	rw := testReplyWriter(&replies)
+
+	params, _ := json.Marshal(&proto.RPCRequest{Method: "sum", Data: []byte(`[1,2]`)})
+	disconnect := client.handleRPC(params, rw)
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorNotAvailable, replies[0].Error)
+
+	client.On().RPC(func(e RPCEvent) RPCReply {
+		if e.Method != "sum" {
+			return RPCReply{Error: ErrorMethodNotFound}
+		}
+		assert.Equal(t, Raw(`[1,2]`), e.Data)
+		return RPCReply{Data: Raw(`3`)}
+	})
+
+	replies = nil
+	disconnect = client.handleRPC(params, rw)
+	assert.Nil(t, disconnect)
+	var result proto.RPCResult
+	assert.NoError(t, json.Unmarshal(replies[0].Result, &result))
+	assert.Equal(t, Raw(`3`), result.Data)
+
+	replies = nil
+	params, _ = json.Marshal(&proto.RPCRequest{Method: "unknown"})
+	disconnect = client.handleRPC(params, rw)
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorMethodNotFound, replies[0].Error)
+}
+
+func TestClientQueueOverflow(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientQueueMaxSize = 150
+	config.ClientQueuePolicy = QueuePolicyDropOldest
+	node.Reload(config)
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte)
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	events := make(chan QueueOverflowEvent, 10)
+	client.On().QueueOverflow(func(e QueueOverflowEvent) QueueOverflowReply {
+		events <- e
+		return QueueOverflowReply{}
+	})
+	connectClient(t, client)
+	subscribeClient(t, client, "test")
+
+	// First publication blocks in transport write.
+	assert.NoError(t, node.Publish("test", []byte(`{"seq":1}`)))
+	for client.messageWriter.messages.Len() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// Second publication dropped when third one does not fit queue.
+	padding := strings.Repeat("x", 80)
+	assert.NoError(t, node.Publish("test", []byte(`{"seq":2,"padding":"`+padding+`"}`)))
+	assert.NoError(t, node.Publish("test", []byte(`{"seq":3,"padding":"`+padding+`"}`)))
+
+	select {
+	case e := <-events:
+		assert.Equal(t, QueuePolicyDropOldest, e.Policy)
+		assert.Equal(t, 1, e.Dropped)
+		assert.Nil(t, e.Disconnect)
+	case <-time.After(time.Second):
+		t.Fatal("queue overflow event not received")
+	}
+	assert.Contains(t, string(<-transport.sink), `{"seq":1}`)
+	assert.Contains(t, string(<-transport.sink), `{"seq":3,`)
+	assert.False(t, client.closed)
+}
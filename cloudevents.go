@@ -0,0 +1,363 @@
+package centrifuge
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/uuid"
+)
+
+const (
+	// CloudEventsSpecVersion is a version of CloudEvents specification supported.
+	CloudEventsSpecVersion = "1.0"
+	// CloudEventTypePublication is a type attribute of CloudEvents envelope
+	// used for Publication data wrapped by Centrifuge.
+	CloudEventTypePublication = "centrifuge.publication"
+
+	cloudEventsContentType = "application/cloudevents+json"
+	cloudEventsHTTPPrefix  = "Ce-"
+)
+
+var (
+	// ErrInvalidCloudEvent returned when CloudEvent does not contain required
+	// attributes or can not be decoded.
+	ErrInvalidCloudEvent = errors.New("invalid CloudEvent")
+)
+
+// CloudEvent is a CloudEvents 1.0 event. In structured mode it is sent as
+// JSON object, in binary mode attributes are transferred in transport
+// headers and Data is a payload of transport message.
+type CloudEvent struct {
+	// ID identifies the event, Centrifuge generates UUID for publications.
+	ID string
+	// Source identifies the context in which an event happened.
+	Source string
+	// SpecVersion is a version of CloudEvents specification event uses.
+	SpecVersion string
+	// Type describes the type of event.
+	Type string
+	// DataContentType is a content type of Data.
+	DataContentType string
+	// DataSchema identifies the schema that Data adheres to.
+	DataSchema string
+	// Subject of the event, Centrifuge puts channel name here.
+	Subject string
+	// Time when event happened.
+	Time time.Time
+	// Data is an event payload.
+	Data []byte
+	// Extensions contain extension context attributes.
+	Extensions map[string]string
+}
+
+var cloudEventAttributes = map[string]struct{}{
+	"id":              {},
+	"source":          {},
+	"specversion":     {},
+	"type":            {},
+	"datacontenttype": {},
+	"dataschema":      {},
+	"subject":         {},
+	"time":            {},
+	"data":            {},
+	"data_base64":     {},
+}
+
+// Validate checks that event contains all required attributes.
+func (e *CloudEvent) Validate() error {
+	if e.ID == "" || e.Source == "" || e.Type == "" || e.SpecVersion != CloudEventsSpecVersion {
+		return ErrInvalidCloudEvent
+	}
+	return nil
+}
+
+func isJSONContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || mediaType == "text/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// MarshalJSON encodes event in CloudEvents JSON format (structured mode).
+func (e CloudEvent) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(e.Extensions)+8)
+	for k, v := range e.Extensions {
+		m[k] = v
+	}
+	m["id"] = e.ID
+	m["source"] = e.Source
+	m["specversion"] = e.SpecVersion
+	m["type"] = e.Type
+	if e.DataContentType != "" {
+		m["datacontenttype"] = e.DataContentType
+	}
+	if e.DataSchema != "" {
+		m["dataschema"] = e.DataSchema
+	}
+	if e.Subject != "" {
+		m["subject"] = e.Subject
+	}
+	if !e.Time.IsZero() {
+		m["time"] = e.Time.UTC().Format(time.RFC3339Nano)
+	}
+	if len(e.Data) > 0 {
+		if isJSONContentType(e.DataContentType) && json.Valid(e.Data) {
+			m["data"] = json.RawMessage(e.Data)
+		} else {
+			m["data_base64"] = base64.StdEncoding.EncodeToString(e.Data)
+		}
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes event from CloudEvents JSON format (structured mode).
+func (e *CloudEvent) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return err
+	}
+	var event CloudEvent
+	str := func(key string) (string, error) {
+		raw, ok := m[key]
+		if !ok {
+			return "", nil
+		}
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	}
+	fields := []struct {
+		key string
+		val *string
+	}{
+		{"id", &event.ID},
+		{"source", &event.Source},
+		{"specversion", &event.SpecVersion},
+		{"type", &event.Type},
+		{"datacontenttype", &event.DataContentType},
+		{"dataschema", &event.DataSchema},
+		{"subject", &event.Subject},
+	}
+	for _, f := range fields {
+		*f.val, err = str(f.key)
+		if err != nil {
+			return ErrInvalidCloudEvent
+		}
+	}
+	if t, _ := str("time"); t != "" {
+		event.Time, err = time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return ErrInvalidCloudEvent
+		}
+	}
+	if raw, ok := m["data"]; ok {
+		event.Data = []byte(raw)
+		if !isJSONContentType(event.DataContentType) {
+			// Non-JSON data in structured mode encoded as JSON string.
+			var s string
+			if err := json.Unmarshal(raw, &s); err == nil {
+				event.Data = []byte(s)
+			}
+		}
+	} else if b64, _ := str("data_base64"); b64 != "" {
+		event.Data, err = base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			return ErrInvalidCloudEvent
+		}
+	}
+	for k, raw := range m {
+		if _, ok := cloudEventAttributes[k]; ok {
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			s = string(raw)
+		}
+		if event.Extensions == nil {
+			event.Extensions = make(map[string]string)
+		}
+		event.Extensions[k] = s
+	}
+	*e = event
+	return nil
+}
+
+// WriteHTTPHeaders sets event context attributes as HTTP headers to send
+// event in binary mode. Event Data must be used as request body.
+func (e *CloudEvent) WriteHTTPHeaders(h http.Header) {
+	h.Set(cloudEventsHTTPPrefix+"Id", e.ID)
+	h.Set(cloudEventsHTTPPrefix+"Source", e.Source)
+	h.Set(cloudEventsHTTPPrefix+"Specversion", e.SpecVersion)
+	h.Set(cloudEventsHTTPPrefix+"Type", e.Type)
+	if e.DataContentType != "" {
+		h.Set("Content-Type", e.DataContentType)
+	}
+	if e.DataSchema != "" {
+		h.Set(cloudEventsHTTPPrefix+"Dataschema", e.DataSchema)
+	}
+	if e.Subject != "" {
+		h.Set(cloudEventsHTTPPrefix+"Subject", e.Subject)
+	}
+	if !e.Time.IsZero() {
+		h.Set(cloudEventsHTTPPrefix+"Time", e.Time.UTC().Format(time.RFC3339Nano))
+	}
+	for k, v := range e.Extensions {
+		h.Set(cloudEventsHTTPPrefix+k, v)
+	}
+}
+
+// CloudEventFromHTTP extracts CloudEvent from HTTP request sent in structured
+// (Content-Type application/cloudevents+json) or binary (ce-* headers) mode.
+func CloudEventFromHTTP(r *http.Request) (CloudEvent, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return CloudEvent{}, err
+	}
+	var event CloudEvent
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == cloudEventsContentType {
+		err := json.Unmarshal(body, &event)
+		if err != nil {
+			return CloudEvent{}, ErrInvalidCloudEvent
+		}
+	} else {
+		for k, v := range r.Header {
+			if len(v) == 0 || !strings.HasPrefix(k, cloudEventsHTTPPrefix) {
+				continue
+			}
+			name := strings.ToLower(strings.TrimPrefix(k, cloudEventsHTTPPrefix))
+			switch name {
+			case "id":
+				event.ID = v[0]
+			case "source":
+				event.Source = v[0]
+			case "specversion":
+				event.SpecVersion = v[0]
+			case "type":
+				event.Type = v[0]
+			case "dataschema":
+				event.DataSchema = v[0]
+			case "subject":
+				event.Subject = v[0]
+			case "time":
+				event.Time, err = time.Parse(time.RFC3339Nano, v[0])
+				if err != nil {
+					return CloudEvent{}, ErrInvalidCloudEvent
+				}
+			default:
+				if event.Extensions == nil {
+					event.Extensions = make(map[string]string)
+				}
+				event.Extensions[name] = v[0]
+			}
+		}
+		event.DataContentType = r.Header.Get("Content-Type")
+		event.Data = body
+	}
+	if err := event.Validate(); err != nil {
+		return CloudEvent{}, err
+	}
+	return event, nil
+}
+
+// newPublicationCloudEvent wraps publication data into CloudEvent.
+func (n *Node) newPublicationCloudEvent(ch string, data []byte) (*CloudEvent, error) {
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	config := n.Config()
+	source := config.CloudEventsSource
+	if source == "" {
+		source = config.Name
+	}
+	return &CloudEvent{
+		ID:              uid.String(),
+		Source:          source,
+		SpecVersion:     CloudEventsSpecVersion,
+		Type:            CloudEventTypePublication,
+		DataContentType: "application/json",
+		Subject:         ch,
+		Time:            time.Now(),
+		Data:            data,
+	}, nil
+}
+
+// CloudEventsConfig represents config for CloudEventsHandler.
+type CloudEventsConfig struct {
+	// ChannelFunc allows to choose channel to publish event to. By default
+	// event subject attribute used as channel name.
+	ChannelFunc func(CloudEvent) (string, error)
+}
+
+// CloudEventsHandler accepts CloudEvents sent over HTTP in structured or
+// binary mode and publishes them into channels. If channel has CloudEvents
+// option enabled event is published as is, otherwise only event data is
+// published. This is a server-side API so handler must be protected from
+// public access.
+type CloudEventsHandler struct {
+	node   *Node
+	config CloudEventsConfig
+}
+
+// NewCloudEventsHandler creates new CloudEventsHandler.
+func NewCloudEventsHandler(n *Node, c CloudEventsConfig) *CloudEventsHandler {
+	return &CloudEventsHandler{
+		node:   n,
+		config: c,
+	}
+}
+
+func (s *CloudEventsHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	event, err := CloudEventFromHTTP(r)
+	if err != nil {
+		s.node.logger.log(newLogEntry(LogLevelInfo, "error decoding CloudEvent", map[string]interface{}{"error": err.Error()}))
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	err = s.node.PublishCloudEvent(event, s.config.ChannelFunc)
+	if err != nil {
+		if err == ErrInvalidCloudEvent || err == ErrNoChannelOptions {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.node.logger.log(newLogEntry(LogLevelError, "error publishing CloudEvent", map[string]interface{}{"error": err.Error()}))
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rw.WriteHeader(http.StatusAccepted)
+}
+
+// PublishCloudEvent publishes CloudEvent received by some ingest endpoint
+// into channel returned by channelFunc (if nil event subject used as channel).
+// If channel has CloudEvents option enabled event published as is, otherwise
+// only event data published.
+func (n *Node) PublishCloudEvent(event CloudEvent, channelFunc func(CloudEvent) (string, error)) error {
+	ch := event.Subject
+	if channelFunc != nil {
+		var err error
+		ch, err = channelFunc(event)
+		if err != nil {
+			return err
+		}
+	}
+	if ch == "" {
+		return ErrInvalidCloudEvent
+	}
+	return n.publish(ch, event.Data, nil, withCloudEvent(&event))
+}
@@ -0,0 +1,110 @@
+package centrifuge
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudEventJSON(t *testing.T) {
+	event := CloudEvent{
+		ID:              "1",
+		Source:          "test",
+		SpecVersion:     CloudEventsSpecVersion,
+		Type:            "test.event",
+		DataContentType: "application/json",
+		Subject:         "channel",
+		Time:            time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		Data:            []byte(`{"input":"test"}`),
+		Extensions:      map[string]string{"traceparent": "00-1"},
+	}
+	data, err := json.Marshal(event)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"data":{"input":"test"}`)
+	assert.Contains(t, string(data), `"traceparent":"00-1"`)
+
+	var decoded CloudEvent
+	err = json.Unmarshal(data, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, event, decoded)
+
+	event.DataContentType = "application/octet-stream"
+	event.Data = []byte{0, 1, 2}
+	data, err = json.Marshal(event)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"data_base64":"AAEC"`)
+	err = json.Unmarshal(data, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 1, 2}, decoded.Data)
+}
+
+func TestNodePublishCloudEvents(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	c := n.Config()
+	c.CloudEvents = true
+	c.HistorySize = 10
+	c.HistoryLifetime = 60
+	n.Reload(c)
+
+	err := n.Publish("test", []byte(`{"input":"test"}`))
+	assert.NoError(t, err)
+
+	history, err := n.History("test")
+	pubs := history.Publications
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 1)
+
+	var event CloudEvent
+	err = json.Unmarshal(pubs[0].Data, &event)
+	assert.NoError(t, err)
+	assert.NoError(t, event.Validate())
+	assert.Equal(t, CloudEventTypePublication, event.Type)
+	assert.Equal(t, "test", event.Subject)
+	assert.Equal(t, `{"input":"test"}`, string(event.Data))
+}
+
+func TestCloudEventsHandler(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	c := n.Config()
+	c.HistorySize = 10
+	c.HistoryLifetime = 60
+	n.Reload(c)
+
+	server := httptest.NewServer(NewCloudEventsHandler(n, CloudEventsConfig{}))
+	defer server.Close()
+
+	// Binary mode.
+	req, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader([]byte(`{"input":"binary"}`)))
+	event := CloudEvent{ID: "1", Source: "test", SpecVersion: CloudEventsSpecVersion, Type: "test.event", Subject: "test", DataContentType: "application/json"}
+	event.WriteHTTPHeaders(req.Header)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	// Structured mode.
+	event.Data = []byte(`{"input":"structured"}`)
+	data, _ := json.Marshal(event)
+	resp, err = http.Post(server.URL, cloudEventsContentType, bytes.NewReader(data))
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	history, err := n.History("test")
+	pubs := history.Publications
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 2)
+	assert.Equal(t, `{"input":"binary"}`, string(pubs[0].Data))
+	assert.Equal(t, `{"input":"structured"}`, string(pubs[1].Data))
+
+	// Missing required attributes.
+	resp, err = http.Post(server.URL, cloudEventsContentType, bytes.NewReader([]byte(`{"id":"1"}`)))
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
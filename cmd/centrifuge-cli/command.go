@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+)
+
+const helpText = `Commands:
+  subscribe <channel> [token]       subscribe on channel
+  unsubscribe <channel>             unsubscribe from channel
+  publish <channel> <json>          publish data into channel
+  presence <channel>                get channel presence
+  presence_stats <channel>          get channel presence stats
+  history <channel>                 get channel history
+  mark_read <channel> <seq> <gen> <epoch>
+                                    mark channel read up to position
+  rpc <json>                        send RPC with data
+  send <json>                       send async message with data
+  ping                              ping server
+  help                              show this help
+  quit                              close connection and exit`
+
+var errEmptyLine = errors.New("empty line")
+
+// parseCommand converts CLI input line into protocol command. Params are
+// encoded with JSON as CLI always uses JSON protocol.
+func parseCommand(line string) (*proto.Command, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil, errEmptyLine
+	}
+	name := fields[0]
+	args := fields[1:]
+
+	var method proto.MethodType
+	var params interface{}
+
+	switch name {
+	case "subscribe":
+		if len(args) < 1 || len(args) > 2 {
+			return nil, errors.New("usage: subscribe <channel> [token]")
+		}
+		req := &proto.SubscribeRequest{Channel: args[0]}
+		if len(args) == 2 {
+			req.Token = args[1]
+		}
+		method, params = proto.MethodTypeSubscribe, req
+	case "unsubscribe":
+		if len(args) != 1 {
+			return nil, errors.New("usage: unsubscribe <channel>")
+		}
+		method, params = proto.MethodTypeUnsubscribe, &proto.UnsubscribeRequest{Channel: args[0]}
+	case "publish":
+		if len(args) < 2 {
+			return nil, errors.New("usage: publish <channel> <json>")
+		}
+		data, err := jsonData(trimFields(line, 2))
+		if err != nil {
+			return nil, err
+		}
+		method, params = proto.MethodTypePublish, &proto.PublishRequest{Channel: args[0], Data: data}
+	case "presence":
+		if len(args) != 1 {
+			return nil, errors.New("usage: presence <channel>")
+		}
+		method, params = proto.MethodTypePresence, &proto.PresenceRequest{Channel: args[0]}
+	case "presence_stats":
+		if len(args) != 1 {
+			return nil, errors.New("usage: presence_stats <channel>")
+		}
+		method, params = proto.MethodTypePresenceStats, &proto.PresenceStatsRequest{Channel: args[0]}
+	case "history":
+		if len(args) != 1 {
+			return nil, errors.New("usage: history <channel>")
+		}
+		method, params = proto.MethodTypeHistory, &proto.HistoryRequest{Channel: args[0]}
+	case "mark_read":
+		if len(args) != 4 {
+			return nil, errors.New("usage: mark_read <channel> <seq> <gen> <epoch>")
+		}
+		seq, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return nil, errors.New("seq must be a number")
+		}
+		gen, err := strconv.ParseUint(args[2], 10, 32)
+		if err != nil {
+			return nil, errors.New("gen must be a number")
+		}
+		method, params = proto.MethodTypeMarkRead, &proto.MarkReadRequest{Channel: args[0], Seq: uint32(seq), Gen: uint32(gen), Epoch: args[3]}
+	case "rpc":
+		if len(args) < 1 {
+			return nil, errors.New("usage: rpc <json>")
+		}
+		data, err := jsonData(trimFields(line, 1))
+		if err != nil {
+			return nil, err
+		}
+		method, params = proto.MethodTypeRPC, &proto.RPCRequest{Data: data}
+	case "send":
+		if len(args) < 1 {
+			return nil, errors.New("usage: send <json>")
+		}
+		data, err := jsonData(trimFields(line, 1))
+		if err != nil {
+			return nil, err
+		}
+		method, params = proto.MethodTypeSend, &proto.SendRequest{Data: data}
+	case "ping":
+		method, params = proto.MethodTypePing, &proto.PingRequest{}
+	default:
+		return nil, fmt.Errorf("unknown command %q, type help to see available commands", name)
+	}
+
+	encodedParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.Command{
+		Method: method,
+		Params: proto.Raw(encodedParams),
+	}, nil
+}
+
+func jsonData(s string) (proto.Raw, error) {
+	if !json.Valid([]byte(s)) {
+		return nil, fmt.Errorf("invalid JSON: %s", s)
+	}
+	return proto.Raw(s), nil
+}
+
+// trimFields strips first n whitespace separated fields from s and returns
+// the rest as is, so JSON payloads can contain spaces.
+func trimFields(s string, n int) string {
+	s = strings.TrimSpace(s)
+	for i := 0; i < n; i++ {
+		idx := strings.IndexFunc(s, unicode.IsSpace)
+		if idx < 0 {
+			return ""
+		}
+		s = strings.TrimSpace(s[idx:])
+	}
+	return s
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommand(t *testing.T) {
+	cmd, err := parseCommand("subscribe news")
+	assert.NoError(t, err)
+	assert.Equal(t, proto.MethodTypeSubscribe, cmd.Method)
+	var subReq proto.SubscribeRequest
+	assert.NoError(t, json.Unmarshal(cmd.Params, &subReq))
+	assert.Equal(t, "news", subReq.Channel)
+
+	cmd, err = parseCommand("subscribe $private token")
+	assert.NoError(t, err)
+	subReq = proto.SubscribeRequest{}
+	assert.NoError(t, json.Unmarshal(cmd.Params, &subReq))
+	assert.Equal(t, "$private", subReq.Channel)
+	assert.Equal(t, "token", subReq.Token)
+
+	cmd, err = parseCommand("  publish  news {\"text\": \"hello world\"}")
+	assert.NoError(t, err)
+	assert.Equal(t, proto.MethodTypePublish, cmd.Method)
+	assert.JSONEq(t, `{"channel":"news","data":{"text":"hello world"}}`, string(cmd.Params))
+
+	cmd, err = parseCommand("rpc {\"method\": \"test\"}")
+	assert.NoError(t, err)
+	assert.Equal(t, proto.MethodTypeRPC, cmd.Method)
+	assert.JSONEq(t, `{"data":{"method":"test"}}`, string(cmd.Params))
+
+	cmd, err = parseCommand("history news")
+	assert.NoError(t, err)
+	assert.Equal(t, proto.MethodTypeHistory, cmd.Method)
+
+	cmd, err = parseCommand("mark_read news 10 0 xyz")
+	assert.NoError(t, err)
+	assert.Equal(t, proto.MethodTypeMarkRead, cmd.Method)
+	assert.JSONEq(t, `{"channel":"news","seq":10,"gen":0,"epoch":"xyz"}`, string(cmd.Params))
+
+	cmd, err = parseCommand("ping")
+	assert.NoError(t, err)
+	assert.Equal(t, proto.MethodTypePing, cmd.Method)
+}
+
+func TestParseCommandErrors(t *testing.T) {
+	_, err := parseCommand("   ")
+	assert.Equal(t, errEmptyLine, err)
+	_, err = parseCommand("subscribe")
+	assert.Error(t, err)
+	_, err = parseCommand("publish news {invalid")
+	assert.Error(t, err)
+	_, err = parseCommand("mark_read news x 0 xyz")
+	assert.Error(t, err)
+	_, err = parseCommand("unknown")
+	assert.Error(t, err)
+}
+
+func TestPrintReply(t *testing.T) {
+	var buf bytes.Buffer
+	c := newCLI(nil, &buf)
+	c.pending[1] = proto.MethodTypeHistory
+	c.printReply(&proto.Reply{ID: 1, Error: &proto.Error{Code: 108, Message: "not available"}})
+	assert.Equal(t, "<- history [1] error 108: not available\n", buf.String())
+	_, ok := c.pending[1]
+	assert.False(t, ok)
+
+	buf.Reset()
+	c.printReply(&proto.Reply{Result: proto.Raw(`{"type":0,"channel":"news","data":{"data":{}}}`)})
+	assert.Contains(t, buf.String(), "<- push publication news")
+}
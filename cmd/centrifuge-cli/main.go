@@ -0,0 +1,190 @@
+// Command centrifuge-cli is an interactive client for manual testing of
+// Centrifuge based servers. It connects over Websocket using JSON protocol,
+// reads commands from stdin and pretty-prints replies and pushes.
+//
+// Usage:
+//
+//	centrifuge-cli -url ws://localhost:8000/connection/websocket -token <JWT>
+//
+// Type help after start to see available commands.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+
+	"github.com/gorilla/websocket"
+)
+
+var (
+	url   = flag.String("url", "ws://localhost:8000/connection/websocket", "Websocket endpoint URL")
+	token = flag.String("token", "", "connection JWT")
+	data  = flag.String("data", "", "JSON data to send in connect command")
+)
+
+type cli struct {
+	conn *websocket.Conn
+	out  io.Writer
+
+	mu      sync.Mutex
+	nextID  uint32
+	pending map[uint32]proto.MethodType
+}
+
+func newCLI(conn *websocket.Conn, out io.Writer) *cli {
+	return &cli{
+		conn:    conn,
+		out:     out,
+		pending: make(map[uint32]proto.MethodType),
+	}
+}
+
+// send assigns unique id to command and writes it into connection.
+func (c *cli) send(cmd *proto.Command) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextID++
+	cmd.ID = c.nextID
+	c.pending[cmd.ID] = cmd.Method
+	encoded, err := json.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(websocket.TextMessage, encoded)
+}
+
+func (c *cli) connect(token string, data string) error {
+	req := &proto.ConnectRequest{Token: token}
+	if data != "" {
+		d, err := jsonData(data)
+		if err != nil {
+			return err
+		}
+		req.Data = d
+	}
+	params, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return c.send(&proto.Command{Method: proto.MethodTypeConnect, Params: proto.Raw(params)})
+}
+
+// read reads messages from connection until error. Single Websocket message
+// can contain several newline-delimited replies.
+func (c *cli) read() error {
+	for {
+		_, msg, err := c.conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		for _, line := range bytes.Split(msg, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			var reply proto.Reply
+			if err := json.Unmarshal(line, &reply); err != nil {
+				fmt.Fprintf(c.out, "<- malformed reply: %s\n", line)
+				continue
+			}
+			c.printReply(&reply)
+		}
+	}
+}
+
+func (c *cli) printReply(reply *proto.Reply) {
+	if reply.ID == 0 {
+		c.printPush(reply.Result)
+		return
+	}
+	c.mu.Lock()
+	method, ok := c.pending[reply.ID]
+	delete(c.pending, reply.ID)
+	c.mu.Unlock()
+
+	name := "unknown"
+	if ok {
+		name = strings.ToLower(method.String())
+	}
+	if reply.Error != nil {
+		fmt.Fprintf(c.out, "<- %s [%d] error %d: %s\n", name, reply.ID, reply.Error.Code, reply.Error.Message)
+		return
+	}
+	fmt.Fprintf(c.out, "<- %s [%d] %s\n", name, reply.ID, indent(reply.Result))
+}
+
+func (c *cli) printPush(data proto.Raw) {
+	var push proto.Push
+	if err := json.Unmarshal(data, &push); err != nil {
+		fmt.Fprintf(c.out, "<- malformed push: %s\n", data)
+		return
+	}
+	fmt.Fprintf(c.out, "<- push %s %s %s\n", strings.ToLower(push.Type.String()), push.Channel, indent(push.Data))
+}
+
+// indent formats JSON for human reading, invalid JSON returned as is.
+func indent(data []byte) string {
+	if len(data) == 0 {
+		return "{}"
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return string(data)
+	}
+	return buf.String()
+}
+
+func main() {
+	flag.Parse()
+
+	conn, _, err := websocket.DefaultDialer.Dial(*url, nil)
+	if err != nil {
+		log.Fatalf("error connecting to %s: %v", *url, err)
+	}
+	defer conn.Close()
+
+	c := newCLI(conn, os.Stdout)
+	if err := c.connect(*token, *data); err != nil {
+		log.Fatalf("error sending connect command: %v", err)
+	}
+
+	go func() {
+		if err := c.read(); err != nil {
+			log.Printf("connection closed: %v", err)
+		}
+		os.Exit(0)
+	}()
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch strings.TrimSpace(line) {
+		case "quit", "exit":
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		case "help":
+			fmt.Println(helpText)
+			continue
+		}
+		cmd, err := parseCommand(line)
+		if err == errEmptyLine {
+			continue
+		}
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		if err := c.send(cmd); err != nil {
+			log.Fatalf("error sending command: %v", err)
+		}
+	}
+}
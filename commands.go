@@ -0,0 +1,228 @@
+package centrifuge
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+)
+
+// commandQueueSize is a number of keyed commands of one connection which
+// can wait for a worker in addition to commands being run.
+const commandQueueSize = 64
+
+// commandExecutor runs commands of one connection concurrently using
+// bounded number of workers. Commands with the same key are run one by one
+// in order they were added.
+type commandExecutor struct {
+	sem chan struct{}
+	// pending limits number of keyed commands added but not finished yet.
+	pending chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	queues  map[string][]func()
+}
+
+func newCommandExecutor(concurrency int) *commandExecutor {
+	return &commandExecutor{
+		sem:     make(chan struct{}, concurrency),
+		pending: make(chan struct{}, concurrency+commandQueueSize),
+		queues:  make(map[string][]func()),
+	}
+}
+
+// run runs fn in one of workers. Commands without key wait for free worker
+// and keyed commands wait for free place in queue before run returns so
+// connection reading slows down when all workers are busy.
+func (e *commandExecutor) run(key string, fn func()) {
+	if key == "" {
+		e.wg.Add(1)
+		e.sem <- struct{}{}
+		go func() {
+			defer e.wg.Done()
+			defer func() { <-e.sem }()
+			fn()
+		}()
+		return
+	}
+	e.pending <- struct{}{}
+	e.wg.Add(1)
+	e.mu.Lock()
+	if queue, ok := e.queues[key]; ok {
+		// Previous command with key still running, it will run this one.
+		e.queues[key] = append(queue, fn)
+		e.mu.Unlock()
+		return
+	}
+	e.queues[key] = nil
+	e.mu.Unlock()
+	go e.runQueue(key, fn)
+}
+
+func (e *commandExecutor) runQueue(key string, fn func()) {
+	for {
+		e.sem <- struct{}{}
+		fn()
+		<-e.sem
+		<-e.pending
+
+		e.mu.Lock()
+		queue := e.queues[key]
+		if len(queue) == 0 {
+			delete(e.queues, key)
+			e.mu.Unlock()
+			e.wg.Done()
+			return
+		}
+		fn = queue[0]
+		queue[0] = nil
+		e.queues[key] = queue[1:]
+		e.mu.Unlock()
+		e.wg.Done()
+	}
+}
+
+// wait waits until all added commands finished.
+func (e *commandExecutor) wait() {
+	e.wg.Wait()
+}
+
+// commandKey returns key of command. Commands working with channel are
+// keyed by channel so they keep order relative to each other.
+func commandKey(enc proto.Encoding, cmd *proto.Command) string {
+	decoder := proto.GetParamsDecoder(enc)
+	switch cmd.Method {
+	case proto.MethodTypeSubscribe:
+		if req, err := decoder.DecodeSubscribe(cmd.Params); err == nil {
+			return req.Channel
+		}
+	case proto.MethodTypeSubRefresh:
+		if req, err := decoder.DecodeSubRefresh(cmd.Params); err == nil {
+			return req.Channel
+		}
+	case proto.MethodTypeUnsubscribe:
+		if req, err := decoder.DecodeUnsubscribe(cmd.Params); err == nil {
+			return req.Channel
+		}
+	case proto.MethodTypePublish:
+		if req, err := decoder.DecodePublish(cmd.Params); err == nil {
+			return req.Channel
+		}
+	case proto.MethodTypePresence:
+		if req, err := decoder.DecodePresence(cmd.Params); err == nil {
+			return req.Channel
+		}
+	case proto.MethodTypePresenceStats:
+		if req, err := decoder.DecodePresenceStats(cmd.Params); err == nil {
+			return req.Channel
+		}
+	case proto.MethodTypeHistory:
+		if req, err := decoder.DecodeHistory(cmd.Params); err == nil {
+			return req.Channel
+		}
+	case proto.MethodTypeMarkRead:
+		if req, err := decoder.DecodeMarkRead(cmd.Params); err == nil {
+			return req.Channel
+		}
+	}
+	return ""
+}
+
+// dispatchCommand passes command to connection command executor. Connect
+// and refresh commands change connection state used by all other commands
+// so they are handled only after all previous commands finished and
+// before next commands started.
+func (c *Client) dispatchCommand(enc proto.Encoding, cmd *proto.Command) {
+	if cmd.Method == proto.MethodTypeConnect || cmd.Method == proto.MethodTypeRefresh {
+		c.commands.wait()
+		c.handleCommand(enc, cmd)
+		c.releaseCommand()
+		return
+	}
+	c.commands.run(commandKey(enc, cmd), func() {
+		defer c.releaseCommand()
+		c.handleCommand(enc, cmd)
+	})
+}
+
+// acquireCommand counts command as in-flight until releaseCommand called.
+// It returns error to reply with if connection has too many unanswered
+// commands or too much reply data waiting to be sent.
+func (c *Client) acquireCommand(maxInflight int, maxInflightReplySize int) *Error {
+	if maxInflightReplySize > 0 && c.messageWriter.size() > maxInflightReplySize {
+		return ErrorTooManyRequests
+	}
+	inflight := atomic.AddInt32(&c.inflight, 1)
+	if maxInflight > 0 && int(inflight) > maxInflight {
+		atomic.AddInt32(&c.inflight, -1)
+		return ErrorTooManyRequests
+	}
+	return nil
+}
+
+func (c *Client) releaseCommand() {
+	atomic.AddInt32(&c.inflight, -1)
+}
+
+// rejectCommand replies to command with error without handling it.
+func (c *Client) rejectCommand(enc proto.Encoding, cmd *proto.Command, replyErr *Error) {
+	c.node.logger.log(newLogEntry(LogLevelInfo, "client command rejected", map[string]interface{}{"command": fmt.Sprintf("%v", cmd), "client": c.ID(), "user": c.UserID(), "error": replyErr.Error()}))
+	replyErrorCount.WithLabelValues(strings.ToLower(proto.MethodType_name[int32(cmd.Method)]), strconv.FormatUint(uint64(replyErr.Code), 10)).Inc()
+	if cmd.ID == 0 {
+		// Command does not expect reply.
+		return
+	}
+	encoder := proto.GetReplyEncoder(enc)
+	defer proto.PutReplyEncoder(enc, encoder)
+	if err := encoder.Encode(&proto.Reply{ID: cmd.ID, Error: replyErr}); err != nil {
+		c.node.logger.log(newLogEntry(LogLevelError, "error encoding reply", map[string]interface{}{"command": fmt.Sprintf("%v", cmd), "client": c.ID(), "user": c.UserID(), "error": err.Error()}))
+		return
+	}
+	if disconnect := c.messageWriter.enqueue(encoder.Finish()); disconnect != nil {
+		c.Close(disconnect)
+	}
+}
+
+// handleCommand handles single command and sends its reply to connection.
+func (c *Client) handleCommand(enc proto.Encoding, cmd *proto.Command) {
+	encoder := proto.GetReplyEncoder(enc)
+	defer proto.PutReplyEncoder(enc, encoder)
+
+	var encodeErr error
+	write := func(rep *proto.Reply) error {
+		encodeErr = encoder.Encode(rep)
+		if encodeErr != nil {
+			c.node.logger.log(newLogEntry(LogLevelError, "error encoding reply", map[string]interface{}{"reply": fmt.Sprintf("%v", rep), "command": fmt.Sprintf("%v", cmd), "client": c.ID(), "user": c.UserID(), "error": encodeErr.Error()}))
+		}
+		return encodeErr
+	}
+	flush := func() error {
+		buf := encoder.Finish()
+		if len(buf) > 0 {
+			disconnect := c.messageWriter.enqueue(buf)
+			if disconnect != nil {
+				if c.node.logger.enabled(LogLevelDebug) {
+					c.node.logger.log(newLogEntry(LogLevelDebug, "disconnect after sending reply", map[string]interface{}{"client": c.ID(), "user": c.UserID(), "reason": disconnect.Reason}))
+				}
+				c.Close(disconnect)
+				return fmt.Errorf("flush error")
+			}
+		}
+		encoder.Reset()
+		return nil
+	}
+	disconnect := c.handle(cmd, write, flush)
+	if disconnect != nil {
+		c.node.logger.log(newLogEntry(LogLevelInfo, "disconnect after handling command", map[string]interface{}{"command": fmt.Sprintf("%v", cmd), "client": c.ID(), "user": c.UserID(), "reason": disconnect.Reason}))
+		c.Close(disconnect)
+		return
+	}
+	if encodeErr != nil {
+		c.Close(DisconnectServerError)
+		return
+	}
+	flush()
+}
@@ -0,0 +1,178 @@
+package centrifuge
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandExecutorKeyOrder(t *testing.T) {
+	e := newCommandExecutor(2)
+	var mu sync.Mutex
+	var order []int
+	blocked := make(chan struct{})
+	e.run("a", func() {
+		<-blocked
+		mu.Lock()
+		order = append(order, 1)
+		mu.Unlock()
+	})
+	e.run("a", func() {
+		mu.Lock()
+		order = append(order, 2)
+		mu.Unlock()
+	})
+	done := make(chan struct{})
+	// Command with other key not blocked by key a.
+	e.run("b", func() {
+		close(done)
+	})
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("command blocked by other key")
+	}
+	close(blocked)
+	e.wait()
+	assert.Equal(t, []int{1, 2}, order)
+	e.mu.Lock()
+	assert.Len(t, e.queues, 0)
+	e.mu.Unlock()
+}
+
+func TestCommandExecutorQueueFull(t *testing.T) {
+	e := newCommandExecutor(1)
+	blocked := make(chan struct{})
+	for i := 0; i < cap(e.pending); i++ {
+		e.run(strconv.Itoa(i), func() {
+			<-blocked
+		})
+	}
+	added := make(chan struct{})
+	go func() {
+		e.run("next", func() {})
+		close(added)
+	}()
+	select {
+	case <-added:
+		t.Fatal("command added to full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(blocked)
+	select {
+	case <-added:
+	case <-time.After(time.Second):
+		t.Fatal("command not added after queue released")
+	}
+	e.wait()
+	assert.Len(t, e.pending, 0)
+}
+
+func waitSinkContains(t *testing.T, transport *testTransport, s string) {
+	var data string
+	for {
+		select {
+		case d := <-transport.sink:
+			data += string(d)
+			if strings.Contains(data, s) {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s not received", s)
+		}
+	}
+}
+
+func TestClientCommandConcurrency(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientCommandConcurrency = 2
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	unblock := make(chan struct{})
+	client.On().RPC(func(e RPCEvent) RPCReply {
+		<-unblock
+		return RPCReply{}
+	})
+
+	ok := client.handleRawData([]byte(`{"id":1,"method":9,"params":{"data":{}}}` + "\n" + `{"id":2,"method":1,"params":{"channel":"test"}}`))
+	assert.True(t, ok)
+	// Subscribe handled while RPC still in progress.
+	waitSinkContains(t, transport, `"id":2`)
+	close(unblock)
+	waitSinkContains(t, transport, `"id":1`)
+}
+
+func TestClientMaxInflightCommands(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientCommandConcurrency = 4
+	config.ClientMaxInflightCommands = 1
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	unblock := make(chan struct{})
+	client.On().RPC(func(e RPCEvent) RPCReply {
+		<-unblock
+		return RPCReply{}
+	})
+
+	ok := client.handleRawData([]byte(`{"id":1,"method":9,"params":{"data":{}}}` + "\n" + `{"id":2,"method":9,"params":{"data":{}}}`))
+	assert.True(t, ok)
+	waitSinkContains(t, transport, `{"id":2,"error":{"code":112`)
+	close(unblock)
+	waitSinkContains(t, transport, `"id":1`)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&client.inflight))
+}
+
+func TestClientMaxInflightReplySize(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientMaxInflightReplySize = 10
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	// Writes block until sink read so replies stay in connection queue.
+	transport.sink = make(chan []byte)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	assert.Nil(t, client.messageWriter.enqueue([]byte("blocked")))
+	assert.Nil(t, client.messageWriter.enqueue([]byte("queued message")))
+	assert.Equal(t, ErrorTooManyRequests, client.acquireCommand(0, 10))
+	assert.Nil(t, client.acquireCommand(0, 100))
+	client.releaseCommand()
+
+	ok := client.handleRawData([]byte(`{"id":1,"method":7}`))
+	assert.True(t, ok)
+	var data string
+	for !strings.Contains(data, `"code":112`) {
+		select {
+		case d := <-transport.sink:
+			data += string(d)
+		case <-time.After(time.Second):
+			t.Fatal("rejection not received")
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package centrifuge
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// handlerLimiter bounds number of concurrently running channel event
+// handlers of namespace.
+type handlerLimiter struct {
+	concurrency  int
+	queueSize    int
+	queueTimeout time.Duration
+	sem          chan struct{}
+	waiting      int32
+}
+
+func newHandlerLimiter(chOpts ChannelOptions) *handlerLimiter {
+	return &handlerLimiter{
+		concurrency:  chOpts.HandlerConcurrency,
+		queueSize:    chOpts.HandlerQueueSize,
+		queueTimeout: chOpts.HandlerQueueTimeout,
+		sem:          make(chan struct{}, chOpts.HandlerConcurrency),
+	}
+}
+
+func (l *handlerLimiter) matches(chOpts ChannelOptions) bool {
+	return l.concurrency == chOpts.HandlerConcurrency && l.queueSize == chOpts.HandlerQueueSize && l.queueTimeout == chOpts.HandlerQueueTimeout
+}
+
+func (l *handlerLimiter) release() {
+	<-l.sem
+}
+
+// acquire takes handler slot waiting in queue if all slots busy. It returns
+// false if queue is full, queue timeout elapsed or node is shutting down.
+func (l *handlerLimiter) acquire(shutdownCh chan struct{}) bool {
+	select {
+	case l.sem <- struct{}{}:
+		return true
+	default:
+	}
+	if atomic.AddInt32(&l.waiting, 1) > int32(l.queueSize) {
+		atomic.AddInt32(&l.waiting, -1)
+		return false
+	}
+	defer atomic.AddInt32(&l.waiting, -1)
+	var timeout <-chan time.Time
+	if l.queueTimeout > 0 {
+		timer := time.NewTimer(l.queueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case l.sem <- struct{}{}:
+		return true
+	case <-timeout:
+		return false
+	case <-shutdownCh:
+		return false
+	}
+}
+
+// handlerLimiters keeps handler limiters of namespaces.
+type handlerLimiters struct {
+	mu       sync.Mutex
+	limiters map[string]*handlerLimiter
+}
+
+func newHandlerLimiters() *handlerLimiters {
+	return &handlerLimiters{
+		limiters: make(map[string]*handlerLimiter),
+	}
+}
+
+// get returns limiter for namespace creating new one if namespace options
+// changed since limiter creation.
+func (l *handlerLimiters) get(ns string, chOpts ChannelOptions) *handlerLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limiter, ok := l.limiters[ns]
+	if !ok || !limiter.matches(chOpts) {
+		limiter = newHandlerLimiter(chOpts)
+		l.limiters[ns] = limiter
+	}
+	return limiter
+}
+
+func noopRelease() {}
+
+// acquireHandler must be called before running channel event handler. It
+// returns func to call when handler finished and false if handler must not
+// be called as namespace handler concurrency limit reached.
+func (n *Node) acquireHandler(ch string) (func(), bool) {
+	n.mu.RLock()
+	ns := n.namespaceName(ch)
+	chOpts, ok := n.config.channelOpts(ns)
+	n.mu.RUnlock()
+	if !ok || chOpts.HandlerConcurrency <= 0 {
+		return noopRelease, true
+	}
+	limiter := n.handlerLimiters.get(ns, chOpts)
+	if !limiter.acquire(n.shutdownCh) {
+		n.logger.log(newLogEntry(LogLevelInfo, "handler concurrency limit reached", map[string]interface{}{"channel": ch, "namespace": ns}))
+		return noopRelease, false
+	}
+	return limiter.release, true
+}
@@ -0,0 +1,130 @@
+package centrifuge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerLimiter(t *testing.T) {
+	shutdownCh := make(chan struct{})
+	l := newHandlerLimiter(ChannelOptions{HandlerConcurrency: 1})
+	assert.True(t, l.acquire(shutdownCh))
+	// No queue – rejected at once.
+	assert.False(t, l.acquire(shutdownCh))
+	l.release()
+	assert.True(t, l.acquire(shutdownCh))
+	l.release()
+
+	l = newHandlerLimiter(ChannelOptions{HandlerConcurrency: 1, HandlerQueueSize: 1, HandlerQueueTimeout: 50 * time.Millisecond})
+	assert.True(t, l.acquire(shutdownCh))
+	start := time.Now()
+	assert.False(t, l.acquire(shutdownCh))
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		l.release()
+	}()
+	assert.True(t, l.acquire(shutdownCh))
+
+	l = newHandlerLimiter(ChannelOptions{HandlerConcurrency: 1, HandlerQueueSize: 1})
+	assert.True(t, l.acquire(shutdownCh))
+	close(shutdownCh)
+	assert.False(t, l.acquire(shutdownCh))
+}
+
+func TestHandlerLimitersReload(t *testing.T) {
+	limiters := newHandlerLimiters()
+	chOpts := ChannelOptions{HandlerConcurrency: 1}
+	l := limiters.get("ns", chOpts)
+	assert.Equal(t, l, limiters.get("ns", chOpts))
+	chOpts.HandlerConcurrency = 2
+	assert.NotEqual(t, l, limiters.get("ns", chOpts))
+}
+
+func TestClientPublishHandlerConcurrency(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.Namespaces = []ChannelNamespace{{
+		Name: "slow",
+		ChannelOptions: ChannelOptions{
+			Publish:            true,
+			HandlerConcurrency: 1,
+		},
+	}}
+	config.Publish = true
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	client.On().Publish(func(e PublishEvent) PublishReply {
+		if e.Channel == "slow:test" {
+			close(started)
+			<-unblock
+		}
+		return PublishReply{}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		resp, disconnect := client.publishCmd(&proto.PublishRequest{Channel: "slow:test", Data: []byte(`{}`)})
+		assert.Nil(t, disconnect)
+		assert.Nil(t, resp.Error)
+	}()
+	<-started
+
+	resp, disconnect := client.publishCmd(&proto.PublishRequest{Channel: "slow:other", Data: []byte(`{}`)})
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorLimitExceeded, resp.Error)
+
+	// Other namespaces are not affected.
+	resp, disconnect = client.publishCmd(&proto.PublishRequest{Channel: "test", Data: []byte(`{}`)})
+	assert.Nil(t, disconnect)
+	assert.Nil(t, resp.Error)
+
+	close(unblock)
+	<-done
+	resp, disconnect = client.publishCmd(&proto.PublishRequest{Channel: "slow:other", Data: []byte(`{}`)})
+	assert.Nil(t, disconnect)
+	assert.Nil(t, resp.Error)
+}
+
+func TestClientPublishHandlerPanicReleasesSlot(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.Publish = true
+	config.HandlerConcurrency = 1
+	assert.NoError(t, node.Reload(config))
+
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, newTestTransport())
+	connectClient(t, client)
+
+	client.On().Publish(func(e PublishEvent) PublishReply {
+		if string(e.Data) == `{"panic":true}` {
+			panic("boom")
+		}
+		return PublishReply{}
+	})
+
+	func() {
+		defer func() { recover() }()
+		client.publishCmd(&proto.PublishRequest{Channel: "test", Data: []byte(`{"panic":true}`)})
+	}()
+
+	resp, disconnect := client.publishCmd(&proto.PublishRequest{Channel: "test", Data: []byte(`{}`)})
+	assert.Nil(t, disconnect)
+	assert.Nil(t, resp.Error)
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"regexp"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Config contains Application configuration options.
@@ -20,6 +22,12 @@ type Config struct {
 	ChannelOptions
 	// Namespaces – list of namespaces for custom channel options.
 	Namespaces []ChannelNamespace
+	// ChannelOptionsFunc allows to resolve channel options dynamically. When
+	// set it's used instead of ChannelOptions and Namespaces, bool result
+	// reports whether channel options found. Func is called on every
+	// subscribe, publish, presence and history request so it must be fast
+	// and safe for concurrent use.
+	ChannelOptionsFunc func(channel string) (ChannelOptions, bool, error)
 	// ClientInsecure turns on insecure mode for client connections - when it's
 	// turned on then no authentication required at all when connecting to Centrifugo,
 	// anonymous access and publish allowed for all channels, no connection expire
@@ -61,13 +69,79 @@ type Config struct {
 	// ClientRequestMaxSize sets maximum size in bytes of allowed client request.
 	ClientRequestMaxSize int
 	// ClientQueueMaxSize is a maximum size of client's message queue in bytes.
-	// After this queue size exceeded Centrifugo closes client's connection.
+	// What happens after this queue size exceeded defined by ClientQueuePolicy,
+	// by default Centrifugo closes client's connection.
 	ClientQueueMaxSize int
+	// ClientQueuePolicy is a policy applied when client's message queue
+	// exceeds ClientQueueMaxSize. Dropping publications breaks recovery
+	// position checks so channels with HistoryRecover should stay with
+	// QueuePolicyDisconnect.
+	ClientQueuePolicy QueuePolicy
+	// ClientMaxMessagesInFrame limits number of queued messages coalesced
+	// into one transport frame, for example into one WebSocket message. 0
+	// means 4 messages, 1 disables coalescing, negative value means all
+	// messages queued.
+	ClientMaxMessagesInFrame int
+	// ClientWriteDelay is a time connection waits after message queued
+	// before writing it so more messages can be coalesced into one frame.
+	// This trades latency for less frames in fan-out heavy scenarios. 0
+	// means writing at once.
+	ClientWriteDelay time.Duration
+	// ClientCommandConcurrency sets maximum number of commands of one
+	// connection handled concurrently so slow command like RPC does not
+	// delay next commands. Commands working with the same channel are still
+	// handled in order they were received, connect and refresh commands are
+	// handled after all previous commands finished. 0 or 1 means commands
+	// are handled one by one.
+	ClientCommandConcurrency int
+	// ClientMaxInflightCommands limits number of commands of one connection
+	// received but not answered yet. Excess commands are rejected with
+	// ErrorTooManyRequests. 0 - unlimited.
+	ClientMaxInflightCommands int
+	// ClientMaxInflightReplySize limits size in bytes of connection queue
+	// new commands accepted at. While more reply data is waiting to be sent
+	// commands are rejected with ErrorTooManyRequests. 0 - unlimited.
+	ClientMaxInflightReplySize int
 	// ClientChannelLimit sets upper limit of channels each client can subscribe to.
+	// Subscribe over limit is rejected with ErrorChannelLimitExceeded.
 	ClientChannelLimit int
+	// ClientRecoveryMaxPublicationLimit limits number of publications sent
+	// to client recovering missed publications on subscribe. When more
+	// publications missed only latest ones are sent and subscription marked
+	// as not recovered so client can load state in other way. 0 - unlimited.
+	ClientRecoveryMaxPublicationLimit int
 	// ClientUserConnectionLimit limits number of client connections from user with the
-	// same ID. 0 - unlimited.
+	// same ID. Connect of user over limit is rejected with
+	// ErrorConnectionLimitExceeded. Anonymous connections with empty user ID
+	// are not limited by it, only by ClientConnectionLimit. 0 - unlimited.
 	ClientUserConnectionLimit int
+	// UserConnectionLimit is an alias of ClientUserConnectionLimit used when
+	// ClientUserConnectionLimit is not set.
+	UserConnectionLimit int
+	// ClientConnectionLimit limits number of authenticated client connections
+	// on node. Client connecting over limit disconnected with
+	// DisconnectConnectionLimit. Both connection limits are checked by node
+	// hub atomically with connection registration and apply to connections
+	// of this node only. 0 - unlimited.
+	ClientConnectionLimit int
+	// ClientTokenMaxUses limits number of times connection JWT or OpenID
+	// Connect token with jti claim can be used to connect during its
+	// validity. Uses are counted in engine which must implement
+	// TokenUseCounter so limit applies to all nodes. Tokens without jti
+	// claim are not limited. 0 - unlimited.
+	ClientTokenMaxUses int
+	// ClientReconnectMinDelay is a minimal delay before reconnect advised to
+	// clients in connect result and disconnect frames. Changing it together
+	// with ClientReconnectMaxDelay allows to slow down reconnect storms
+	// without updating client applications. Advice is not sent if both
+	// delays are zero.
+	ClientReconnectMinDelay time.Duration
+	// ClientReconnectMaxDelay is a maximal delay before reconnect advised to
+	// clients when backing off after repeated reconnect attempts.
+	ClientReconnectMaxDelay time.Duration
+	// ClientReconnectJitter is a maximal random delay clients advised to add
+	// to reconnect delay to spread reconnects over time.
+	ClientReconnectJitter time.Duration
 	// ChannelPrivatePrefix is a prefix in channel name which indicates that
 	// channel is private.
 	ChannelPrivatePrefix string
@@ -81,9 +155,35 @@ type Config struct {
 	ChannelUserSeparator string
 	// ChannelMaxLength is a maximum length of channel name.
 	ChannelMaxLength int
+	// ShutdownDrainInterval spreads disconnects of clients on node shutdown
+	// randomly over interval so clients reconnecting to other nodes do not
+	// come at once. Interval is shortened to fit deadline of context passed
+	// to Node.Shutdown. 0 means disconnecting all clients at once.
+	ShutdownDrainInterval time.Duration
 	// NodeInfoMetricsAggregateInterval sets interval for automatic metrics aggregation.
 	// It's not very reasonable to have it less than one second.
 	NodeInfoMetricsAggregateInterval time.Duration
+	// MetricsRegisterer is a Prometheus registerer to register library
+	// metrics in when Node created. If it's also a prometheus.Gatherer it's
+	// used to aggregate node metrics. prometheus.DefaultRegisterer used if
+	// not set. Metrics shared by all nodes of process, collectors already
+	// registered in registerer by another Node skipped.
+	MetricsRegisterer prometheus.Registerer
+	// MetricsChannelNamespace turns on channel namespace label of channel
+	// metrics. Only namespaces from Namespaces used as label values.
+	MetricsChannelNamespace bool
+	// CloudEventsSource sets source attribute of CloudEvents envelope for
+	// publications in channels with CloudEvents option on. Node Name used
+	// if not set.
+	CloudEventsSource string
+	// PublishIdempotencyWindow is how long idempotency keys of publications
+	// remembered to skip duplicate publishes. 5 minutes used if not set.
+	PublishIdempotencyWindow time.Duration
+	// LocalSubscriptionQueueSize is a maximum number of publications waiting
+	// for handler of one local subscription. Oldest publications dropped
+	// when handler can't keep up with channel traffic. Applies to local
+	// subscriptions created after change. 0 - unlimited.
+	LocalSubscriptionQueueSize int
 
 	// LogLevel is a log level to use. By default nothing will be logged.
 	LogLevel LogLevel
@@ -100,6 +200,10 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if c.ChannelOptions.HistoryRecover && c.ChannelOptions.Priority < 0 {
+		return errors.New(errPrefix + "negative priority can't be used together with history recover")
+	}
+
 	var nss []string
 	for _, n := range c.Namespaces {
 		name := n.Name
@@ -110,6 +214,9 @@ func (c *Config) Validate() error {
 		if stringInSlice(name, nss) {
 			return errors.New(errPrefix + "namespace name must be unique")
 		}
+		if n.HistoryRecover && n.Priority < 0 {
+			return errors.New(errPrefix + "negative priority can't be used together with history recover in namespace " + name)
+		}
 		nss = append(nss, name)
 	}
 	return nil
@@ -118,16 +225,37 @@ func (c *Config) Validate() error {
 // channelOpts searches for channel options for specified namespace key.
 func (c *Config) channelOpts(namespaceName string) (ChannelOptions, bool) {
 	if namespaceName == "" {
-		return c.ChannelOptions, true
+		return bigChannelOptions(c.ChannelOptions), true
 	}
 	for _, n := range c.Namespaces {
 		if n.Name == namespaceName {
-			return n.ChannelOptions, true
+			return bigChannelOptions(n.ChannelOptions), true
 		}
 	}
 	return ChannelOptions{}, false
 }
 
+// userConnectionLimit returns limit of connections from one user.
+func (c *Config) userConnectionLimit() int {
+	if c.ClientUserConnectionLimit != 0 {
+		return c.ClientUserConnectionLimit
+	}
+	return c.UserConnectionLimit
+}
+
+// reconnectAdvice returns reconnect advice to send to clients or nil if
+// reconnect delays not configured.
+func (c *Config) reconnectAdvice() *ReconnectAdvice {
+	if c.ClientReconnectMinDelay == 0 && c.ClientReconnectMaxDelay == 0 {
+		return nil
+	}
+	return &ReconnectAdvice{
+		MinDelay: uint32(c.ClientReconnectMinDelay / time.Millisecond),
+		MaxDelay: uint32(c.ClientReconnectMaxDelay / time.Millisecond),
+		Jitter:   uint32(c.ClientReconnectJitter / time.Millisecond),
+	}
+}
+
 const (
 	// nodeInfoPublishInterval is an interval how often node must publish
 	// node control message.
@@ -165,4 +293,6 @@ var DefaultConfig = Config{
 	ClientRequestMaxSize:            65536,    // 64KB by default
 	ClientQueueMaxSize:              10485760, // 10MB by default
 	ClientChannelLimit:              128,
+
+	LocalSubscriptionQueueSize: 10000,
 }
@@ -8,6 +8,10 @@ type Disconnect struct {
 	Reason string `json:"reason"`
 	// Reconnect gives client an advice to reconnect after disconnect or not.
 	Reconnect bool `json:"reconnect"`
+	// ReconnectAdvice tells client how long to wait before reconnecting.
+	// If not set then advice from node Config is used for disconnects with
+	// Reconnect flag on.
+	ReconnectAdvice *ReconnectAdvice `json:"reconnect_advice,omitempty"`
 }
 
 // Some predefined disconnect structures used by library internally. Though
@@ -97,4 +101,19 @@ var (
 		Reason:    "force disconnect",
 		Reconnect: false,
 	}
+	// DisconnectRedirect sent after connect result with redirect advice so
+	// client reconnects to one of advised endpoints.
+	DisconnectRedirect = &Disconnect{
+		Code:      3013,
+		Reason:    "redirect",
+		Reconnect: true,
+	}
+	// DisconnectConnectionLimit sent when node reached limit of connections
+	// set by Config.ClientConnectionLimit. Client can reconnect later or to
+	// another node.
+	DisconnectConnectionLimit = &Disconnect{
+		Code:      3014,
+		Reason:    "connection limit",
+		Reconnect: true,
+	}
 )
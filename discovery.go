@@ -0,0 +1,70 @@
+package centrifuge
+
+import (
+	"context"
+	"sort"
+)
+
+// Peer is a node discovered by Discovery.
+type Peer struct {
+	// Name of peer, for example Kubernetes pod name. Can be empty.
+	Name string
+	// Addr is a peer address in host:port form.
+	Addr string
+}
+
+// Discovery resolves peer nodes so node does not need manual peer list.
+type Discovery interface {
+	// Run discovers peers until context canceled calling update with full
+	// list of currently known peers every time it changes. Peer list
+	// includes current node.
+	Run(ctx context.Context, update func([]Peer)) error
+}
+
+// SetDiscovery sets Discovery to resolve peer nodes with. Must be called
+// before Node.Run.
+func (n *Node) SetDiscovery(d Discovery) {
+	n.discovery = d
+}
+
+// Peers returns peer nodes resolved by Discovery.
+func (n *Node) Peers() []Peer {
+	return n.nodes.listPeers()
+}
+
+// runDiscovery runs Discovery until node shutdown.
+func (n *Node) runDiscovery() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-n.NotifyShutdown()
+		cancel()
+	}()
+	err := n.discovery.Run(ctx, func(peers []Peer) {
+		n.nodes.setPeers(peers)
+		if n.logger.enabled(LogLevelDebug) {
+			n.logger.log(newLogEntry(LogLevelDebug, "peers updated", map[string]interface{}{"num_peers": len(peers)}))
+		}
+	})
+	if err != nil && ctx.Err() == nil {
+		n.logger.log(newLogEntry(LogLevelError, "discovery stopped", map[string]interface{}{"error": err.Error()}))
+	}
+}
+
+// sortPeers sorts peers by address so peer lists can be compared.
+func sortPeers(peers []Peer) {
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].Addr < peers[j].Addr
+	})
+}
+
+func equalPeers(a, b []Peer) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
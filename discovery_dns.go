@@ -0,0 +1,107 @@
+package centrifuge
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DNSDiscoveryConfig is a config for DNS discovery.
+type DNSDiscoveryConfig struct {
+	// Name to resolve, for example Kubernetes headless service name like
+	// centrifuge.default.svc.cluster.local.
+	Name string
+	// Port of peers. If zero then ports taken from SRV records of Name.
+	Port int
+	// Interval between resolves, 10 seconds by default.
+	Interval time.Duration
+	// Resolver allows to set custom resolver, net.DefaultResolver by default.
+	Resolver *net.Resolver
+}
+
+// DNSDiscovery periodically resolves peers from DNS records. Using it with
+// Kubernetes headless service gives addresses of all ready pods.
+type DNSDiscovery struct {
+	node   *Node
+	config DNSDiscoveryConfig
+	// lookup overrides resolving, used in tests.
+	lookup func(ctx context.Context) ([]Peer, error)
+}
+
+// NewDNSDiscovery creates DNSDiscovery.
+func NewDNSDiscovery(n *Node, c DNSDiscoveryConfig) (*DNSDiscovery, error) {
+	if c.Name == "" {
+		return nil, errors.New("DNS name required")
+	}
+	if c.Interval == 0 {
+		c.Interval = 10 * time.Second
+	}
+	if c.Resolver == nil {
+		c.Resolver = net.DefaultResolver
+	}
+	d := &DNSDiscovery{
+		node:   n,
+		config: c,
+	}
+	d.lookup = d.lookupPeers
+	return d, nil
+}
+
+func (d *DNSDiscovery) lookupPeers(ctx context.Context) ([]Peer, error) {
+	if d.config.Port == 0 {
+		_, records, err := d.config.Resolver.LookupSRV(ctx, "", "", d.config.Name)
+		if err != nil {
+			return nil, err
+		}
+		peers := make([]Peer, 0, len(records))
+		for _, r := range records {
+			target := strings.TrimSuffix(r.Target, ".")
+			peers = append(peers, Peer{
+				Name: target,
+				Addr: net.JoinHostPort(target, strconv.Itoa(int(r.Port))),
+			})
+		}
+		return peers, nil
+	}
+	hosts, err := d.config.Resolver.LookupHost(ctx, d.config.Name)
+	if err != nil {
+		return nil, err
+	}
+	peers := make([]Peer, 0, len(hosts))
+	for _, host := range hosts {
+		peers = append(peers, Peer{
+			Addr: net.JoinHostPort(host, strconv.Itoa(d.config.Port)),
+		})
+	}
+	return peers, nil
+}
+
+// Run is a part of Discovery interface.
+func (d *DNSDiscovery) Run(ctx context.Context, update func([]Peer)) error {
+	var current []Peer
+	first := true
+	for {
+		peers, err := d.lookup(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			d.node.logger.log(newLogEntry(LogLevelError, "error resolving peers", map[string]interface{}{"name": d.config.Name, "error": err.Error()}))
+		} else {
+			sortPeers(peers)
+			if first || !equalPeers(current, peers) {
+				first = false
+				current = peers
+				update(peers)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(d.config.Interval):
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package centrifuge
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	kubernetesServiceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+// KubernetesDiscoveryConfig is a config for Kubernetes discovery. Defaults
+// are taken from pod service account so inside cluster only Service must be
+// set. Service account must be allowed to get, list and watch endpoints.
+type KubernetesDiscoveryConfig struct {
+	// Service name which endpoints are peers.
+	Service string
+	// Namespace of service, pod namespace by default.
+	Namespace string
+	// PortName is a name of endpoint port, first port used by default.
+	PortName string
+	// APIServer is a Kubernetes API server URL, taken from KUBERNETES_SERVICE_HOST
+	// and KUBERNETES_SERVICE_PORT environment variables by default.
+	APIServer string
+	// Token to authenticate in API server, service account token by default.
+	Token string
+	// HTTPClient allows to set custom HTTP client, by default client
+	// trusting service account CA used.
+	HTTPClient *http.Client
+	// ReconnectDelay is a delay before watching again after error, 1 second
+	// by default.
+	ReconnectDelay time.Duration
+}
+
+// KubernetesDiscovery resolves peers from service endpoints using Kubernetes
+// API and watches them for updates.
+type KubernetesDiscovery struct {
+	node   *Node
+	config KubernetesDiscoveryConfig
+	client *http.Client
+}
+
+// NewKubernetesDiscovery creates KubernetesDiscovery.
+func NewKubernetesDiscovery(n *Node, c KubernetesDiscoveryConfig) (*KubernetesDiscovery, error) {
+	if c.Service == "" {
+		return nil, errors.New("Kubernetes service name required")
+	}
+	if c.Namespace == "" {
+		namespace, err := ioutil.ReadFile(kubernetesServiceAccountDir + "/namespace")
+		if err != nil {
+			return nil, fmt.Errorf("error reading pod namespace: %v", err)
+		}
+		c.Namespace = strings.TrimSpace(string(namespace))
+	}
+	if c.APIServer == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return nil, errors.New("Kubernetes API server address not found in environment")
+		}
+		c.APIServer = "https://" + net.JoinHostPort(host, port)
+	}
+	if c.Token == "" {
+		token, err := ioutil.ReadFile(kubernetesServiceAccountDir + "/token")
+		if err != nil {
+			return nil, fmt.Errorf("error reading service account token: %v", err)
+		}
+		c.Token = strings.TrimSpace(string(token))
+	}
+	client := c.HTTPClient
+	if client == nil {
+		ca, err := ioutil.ReadFile(kubernetesServiceAccountDir + "/ca.crt")
+		if err != nil {
+			return nil, fmt.Errorf("error reading service account CA: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("invalid service account CA")
+		}
+		client = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: pool},
+			},
+		}
+	}
+	if c.ReconnectDelay == 0 {
+		c.ReconnectDelay = time.Second
+	}
+	return &KubernetesDiscovery{
+		node:   n,
+		config: c,
+		client: client,
+	}, nil
+}
+
+type kubernetesObjectMeta struct {
+	Name            string `json:"name"`
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+type kubernetesEndpoints struct {
+	Metadata kubernetesObjectMeta `json:"metadata"`
+	Subsets  []struct {
+		Addresses []struct {
+			IP        string `json:"ip"`
+			TargetRef *struct {
+				Name string `json:"name"`
+			} `json:"targetRef"`
+		} `json:"addresses"`
+		Ports []struct {
+			Name string `json:"name"`
+			Port int    `json:"port"`
+		} `json:"ports"`
+	} `json:"subsets"`
+}
+
+type kubernetesEndpointsList struct {
+	Metadata kubernetesObjectMeta  `json:"metadata"`
+	Items    []kubernetesEndpoints `json:"items"`
+}
+
+type kubernetesWatchEvent struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+// peers extracts ready peer addresses from endpoints.
+func (d *KubernetesDiscovery) peers(endpoints *kubernetesEndpoints) []Peer {
+	var peers []Peer
+	for _, subset := range endpoints.Subsets {
+		port := 0
+		for _, p := range subset.Ports {
+			if d.config.PortName == "" || p.Name == d.config.PortName {
+				port = p.Port
+				break
+			}
+		}
+		if port == 0 {
+			continue
+		}
+		for _, address := range subset.Addresses {
+			peer := Peer{Addr: net.JoinHostPort(address.IP, strconv.Itoa(port))}
+			if address.TargetRef != nil {
+				peer.Name = address.TargetRef.Name
+			}
+			peers = append(peers, peer)
+		}
+	}
+	sortPeers(peers)
+	return peers
+}
+
+func (d *KubernetesDiscovery) request(ctx context.Context, query url.Values) (*http.Response, error) {
+	query.Set("fieldSelector", "metadata.name="+d.config.Service)
+	endpoint := fmt.Sprintf("%s/api/v1/namespaces/%s/endpoints?%s", strings.TrimSuffix(d.config.APIServer, "/"), url.PathEscape(d.config.Namespace), query.Encode())
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer "+d.config.Token)
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected Kubernetes API response status: %d", resp.StatusCode)
+	}
+	return resp, nil
+}
+
+// list returns current peers and resource version to start watch from.
+func (d *KubernetesDiscovery) list(ctx context.Context) ([]Peer, string, error) {
+	resp, err := d.request(ctx, url.Values{})
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	var list kubernetesEndpointsList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, "", err
+	}
+	var peers []Peer
+	for i := range list.Items {
+		peers = append(peers, d.peers(&list.Items[i])...)
+	}
+	sortPeers(peers)
+	return peers, list.Metadata.ResourceVersion, nil
+}
+
+// watch calls update on every endpoints change until watch stream closed.
+func (d *KubernetesDiscovery) watch(ctx context.Context, resourceVersion string, update func([]Peer)) error {
+	resp, err := d.request(ctx, url.Values{
+		"watch":           []string{"true"},
+		"resourceVersion": []string{resourceVersion},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var event kubernetesWatchEvent
+		if err := decoder.Decode(&event); err != nil {
+			return err
+		}
+		switch event.Type {
+		case "ADDED", "MODIFIED":
+			var endpoints kubernetesEndpoints
+			if err := json.Unmarshal(event.Object, &endpoints); err != nil {
+				return err
+			}
+			update(d.peers(&endpoints))
+		case "DELETED":
+			update(nil)
+		case "ERROR":
+			// Usually resource version is too old, list again.
+			return fmt.Errorf("watch error: %s", event.Object)
+		}
+	}
+}
+
+// Run is a part of Discovery interface.
+func (d *KubernetesDiscovery) Run(ctx context.Context, update func([]Peer)) error {
+	var current []Peer
+	first := true
+	updateIfChanged := func(peers []Peer) {
+		if first || !equalPeers(current, peers) {
+			first = false
+			current = peers
+			update(peers)
+		}
+	}
+	for {
+		peers, resourceVersion, err := d.list(ctx)
+		if err == nil {
+			updateIfChanged(peers)
+			err = d.watch(ctx, resourceVersion, updateIfChanged)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		d.node.logger.log(newLogEntry(LogLevelInfo, "Kubernetes endpoints watch interrupted", map[string]interface{}{"service": d.config.Service, "error": err.Error()}))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(d.config.ReconnectDelay):
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package centrifuge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testKubernetesEndpoints = `{"metadata":{"name":"centrifuge","resourceVersion":"%s"},"subsets":[{"addresses":[%s],"ports":[{"name":"metrics","port":9000},{"name":"http","port":8000}]}]}`
+
+func TestKubernetesDiscovery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/namespaces/default/endpoints", r.URL.Path)
+		assert.Equal(t, "metadata.name=centrifuge", r.URL.Query().Get("fieldSelector"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		if r.URL.Query().Get("watch") == "" {
+			endpoints := fmt.Sprintf(testKubernetesEndpoints, "1", `{"ip":"10.0.0.1","targetRef":{"name":"centrifuge-0"}}`)
+			fmt.Fprintf(w, `{"metadata":{"resourceVersion":"1"},"items":[%s]}`, endpoints)
+			return
+		}
+		assert.Equal(t, "1", r.URL.Query().Get("resourceVersion"))
+		endpoints := fmt.Sprintf(testKubernetesEndpoints, "2", `{"ip":"10.0.0.1","targetRef":{"name":"centrifuge-0"}},{"ip":"10.0.0.2","targetRef":{"name":"centrifuge-1"}}`)
+		fmt.Fprintf(w, `{"type":"MODIFIED","object":%s}`+"\n", endpoints)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	d, err := NewKubernetesDiscovery(nodeWithMemoryEngine(), KubernetesDiscoveryConfig{
+		Service:    "centrifuge",
+		Namespace:  "default",
+		PortName:   "http",
+		APIServer:  server.URL,
+		Token:      "token",
+		HTTPClient: server.Client(),
+	})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan []Peer, 10)
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx, func(peers []Peer) {
+			updates <- peers
+		})
+		close(done)
+	}()
+	assert.Equal(t, []Peer{{Name: "centrifuge-0", Addr: "10.0.0.1:8000"}}, waitPeers(t, updates))
+	assert.Equal(t, []Peer{{Name: "centrifuge-0", Addr: "10.0.0.1:8000"}, {Name: "centrifuge-1", Addr: "10.0.0.2:8000"}}, waitPeers(t, updates))
+	cancel()
+	<-done
+}
+
+func TestKubernetesDiscoveryRequiresService(t *testing.T) {
+	_, err := NewKubernetesDiscovery(nodeWithMemoryEngine(), KubernetesDiscoveryConfig{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package centrifuge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDiscovery struct {
+	peers []Peer
+}
+
+func (d *testDiscovery) Run(ctx context.Context, update func([]Peer)) error {
+	update(d.peers)
+	<-ctx.Done()
+	return nil
+}
+
+func waitPeers(t *testing.T, updates chan []Peer) []Peer {
+	select {
+	case peers := <-updates:
+		return peers
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting peers")
+	}
+	return nil
+}
+
+func TestNodeDiscovery(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	node.SetDiscovery(&testDiscovery{peers: []Peer{{Addr: "10.0.0.1:8000"}, {Addr: "10.0.0.2:8000"}}})
+	assert.NoError(t, node.Run())
+	defer node.Shutdown(context.Background())
+	for i := 0; i < 100 && len(node.Peers()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, []Peer{{Addr: "10.0.0.1:8000"}, {Addr: "10.0.0.2:8000"}}, node.Peers())
+}
+
+func TestDNSDiscovery(t *testing.T) {
+	_, err := NewDNSDiscovery(nodeWithMemoryEngine(), DNSDiscoveryConfig{})
+	assert.Error(t, err)
+
+	d, err := NewDNSDiscovery(nodeWithMemoryEngine(), DNSDiscoveryConfig{
+		Name:     "centrifuge.default.svc.cluster.local",
+		Port:     8000,
+		Interval: time.Millisecond,
+	})
+	assert.NoError(t, err)
+	results := make(chan []Peer, 3)
+	results <- []Peer{{Addr: "10.0.0.2:8000"}, {Addr: "10.0.0.1:8000"}}
+	results <- []Peer{{Addr: "10.0.0.1:8000"}, {Addr: "10.0.0.2:8000"}}
+	results <- []Peer{{Addr: "10.0.0.1:8000"}}
+	d.lookup = func(ctx context.Context) ([]Peer, error) {
+		select {
+		case peers := <-results:
+			return peers, nil
+		default:
+			return []Peer{{Addr: "10.0.0.1:8000"}}, nil
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := make(chan []Peer, 10)
+	go d.Run(ctx, func(peers []Peer) {
+		updates <- peers
+	})
+	assert.Equal(t, []Peer{{Addr: "10.0.0.1:8000"}, {Addr: "10.0.0.2:8000"}}, waitPeers(t, updates))
+	// Same peers in another order must not produce update.
+	assert.Equal(t, []Peer{{Addr: "10.0.0.1:8000"}}, waitPeers(t, updates))
+}
@@ -0,0 +1,118 @@
+package centrifuge
+
+import (
+	"context"
+	"sync"
+)
+
+// DurableSubscriberStore keeps durable channel subscribers – users which are
+// interested in channel publications even when they are not connected. Node
+// uses in-memory store by default which is only suitable for single node
+// setups – provide shared store with Node.SetDurableSubscriberStore when
+// running several nodes.
+type DurableSubscriberStore interface {
+	// AddDurableSubscriber adds user to durable subscribers of channel.
+	AddDurableSubscriber(ch string, user string) error
+	// RemoveDurableSubscriber removes user from durable subscribers of channel.
+	RemoveDurableSubscriber(ch string, user string) error
+	// DurableSubscribers returns durable subscribers of channel.
+	DurableSubscribers(ch string) ([]string, error)
+}
+
+type memoryDurableSubscriberStore struct {
+	mu   sync.RWMutex
+	subs map[string]map[string]struct{}
+}
+
+func newMemoryDurableSubscriberStore() *memoryDurableSubscriberStore {
+	return &memoryDurableSubscriberStore{
+		subs: make(map[string]map[string]struct{}),
+	}
+}
+
+func (s *memoryDurableSubscriberStore) AddDurableSubscriber(ch string, user string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subs[ch]; !ok {
+		s.subs[ch] = make(map[string]struct{})
+	}
+	s.subs[ch][user] = struct{}{}
+	return nil
+}
+
+func (s *memoryDurableSubscriberStore) RemoveDurableSubscriber(ch string, user string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subs[ch], user)
+	if len(s.subs[ch]) == 0 {
+		delete(s.subs, ch)
+	}
+	return nil
+}
+
+func (s *memoryDurableSubscriberStore) DurableSubscribers(ch string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	users := make([]string, 0, len(s.subs[ch]))
+	for user := range s.subs[ch] {
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// SetDurableSubscriberStore sets store to keep durable subscribers in.
+func (n *Node) SetDurableSubscriberStore(s DurableSubscriberStore) {
+	n.durableSubsMu.Lock()
+	defer n.durableSubsMu.Unlock()
+	n.durableSubs = s
+}
+
+// AddDurableSubscriber registers user as durable subscriber of channel.
+func (n *Node) AddDurableSubscriber(ch string, user string) error {
+	n.durableSubsMu.RLock()
+	store := n.durableSubs
+	n.durableSubsMu.RUnlock()
+	return store.AddDurableSubscriber(ch, user)
+}
+
+// RemoveDurableSubscriber unregisters durable subscriber of channel.
+func (n *Node) RemoveDurableSubscriber(ch string, user string) error {
+	n.durableSubsMu.RLock()
+	store := n.durableSubs
+	n.durableSubsMu.RUnlock()
+	return store.RemoveDurableSubscriber(ch, user)
+}
+
+// reportInactiveSubscribers calls InactiveSubscribers handler with durable
+// subscribers of channel who are offline at the moment.
+func (n *Node) reportInactiveSubscribers(ch string, pub *Publication, chOpts *ChannelOptions) {
+	handler := n.eventHub.inactiveSubscribersHandler
+	if handler == nil {
+		return
+	}
+	n.durableSubsMu.RLock()
+	store := n.durableSubs
+	n.durableSubsMu.RUnlock()
+
+	users, err := store.DurableSubscribers(ch)
+	if err != nil {
+		n.logger.log(newLogEntry(LogLevelError, "error getting durable subscribers", map[string]interface{}{"channel": ch, "error": err.Error()}))
+		return
+	}
+	if len(users) == 0 {
+		return
+	}
+	offline, err := n.offlineUsers(ch, users, chOpts)
+	if err != nil {
+		n.logger.log(newLogEntry(LogLevelError, "error checking online users for inactive subscribers", map[string]interface{}{"channel": ch, "error": err.Error()}))
+		return
+	}
+	if len(offline) == 0 {
+		return
+	}
+	go handler(context.Background(), InactiveSubscribersEvent{
+		Channel:     ch,
+		Publication: pub,
+		Users:       offline,
+	})
+}
@@ -0,0 +1,60 @@
+package centrifuge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeDurableSubscribers(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	assert.NoError(t, node.AddDurableSubscriber("test", "42"))
+	assert.NoError(t, node.AddDurableSubscriber("test", "43"))
+	users, _ := node.durableSubs.DurableSubscribers("test")
+	assert.ElementsMatch(t, []string{"42", "43"}, users)
+	assert.NoError(t, node.RemoveDurableSubscriber("test", "43"))
+	users, _ = node.durableSubs.DurableSubscribers("test")
+	assert.Equal(t, []string{"42"}, users)
+}
+
+func TestNodeInactiveSubscribers(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.InactiveSubscribers = true
+	node.Reload(config)
+
+	events := make(chan InactiveSubscribersEvent, 1)
+	node.On().InactiveSubscribers(func(ctx context.Context, e InactiveSubscribersEvent) {
+		events <- e
+	})
+
+	node.AddDurableSubscriber("test", "42")
+	node.AddDurableSubscriber("test", "43")
+
+	ctx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(ctx, node, newTestTransport())
+	client.connectCmd(&proto.ConnectRequest{})
+
+	assert.NoError(t, node.Publish("test", []byte(`{}`)))
+
+	select {
+	case e := <-events:
+		assert.Equal(t, "test", e.Channel)
+		assert.Equal(t, `{}`, string(e.Publication.Data))
+		assert.Equal(t, []string{"43"}, e.Users)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for inactive subscribers event")
+	}
+
+	// No event expected when all durable subscribers online.
+	node.RemoveDurableSubscriber("test", "43")
+	assert.NoError(t, node.Publish("test", []byte(`{}`)))
+	select {
+	case <-events:
+		t.Fatal("unexpected inactive subscribers event")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,60 @@
+package centrifuge
+
+import (
+	"errors"
+)
+
+// ErrInvalidReference returned when edit or tombstone references
+// publication offset which does not exist in channel.
+var ErrInvalidReference = errors.New("invalid publication reference")
+
+// EditPublication publishes new data for publication with offset seq and
+// gen in channel. Edit is a publication itself: it gets its own offset, is
+// appended to channel history after original publication and is delivered
+// to channel subscribers with PublicationOpEdit operation so clients can
+// replace data of referenced publication. As edits are kept in history
+// recovering clients receive them in order together with other missed
+// publications.
+func (n *Node) EditPublication(ch string, seq uint32, gen uint32, data []byte) error {
+	if err := n.checkReference(ch, seq, gen); err != nil {
+		return err
+	}
+	actionCount.WithLabelValues("edit_publication").Inc()
+	return n.publish(ch, data, nil, withReference(PublicationOpEdit, seq, gen))
+}
+
+// DeletePublication publishes tombstone for publication with offset seq
+// and gen in channel. Like edit tombstone is appended to channel history
+// and delivered to subscribers with PublicationOpTombstone operation so
+// clients can remove referenced publication.
+func (n *Node) DeletePublication(ch string, seq uint32, gen uint32) error {
+	if err := n.checkReference(ch, seq, gen); err != nil {
+		return err
+	}
+	actionCount.WithLabelValues("delete_publication").Inc()
+	return n.publish(ch, nil, nil, withReference(PublicationOpTombstone, seq, gen))
+}
+
+// checkReference makes sure that offset can reference publication in
+// channel. If channel has history then offset must not be ahead of
+// current channel top position.
+func (n *Node) checkReference(ch string, seq uint32, gen uint32) error {
+	if seq == 0 && gen == 0 {
+		return ErrInvalidReference
+	}
+	chOpts, ok := n.ChannelOpts(ch)
+	if !ok {
+		return ErrNoChannelOptions
+	}
+	if n.historyManager == nil || chOpts.HistorySize <= 0 || chOpts.HistoryLifetime <= 0 {
+		return nil
+	}
+	current, err := n.currentRecoveryState(ch)
+	if err != nil {
+		return err
+	}
+	if packUint64(seq, gen) > packUint64(current.Seq, current.Gen) {
+		return ErrInvalidReference
+	}
+	return nil
+}
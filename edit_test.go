@@ -0,0 +1,80 @@
+package centrifuge
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeEditPublication(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	config.HistoryRecover = true
+	assert.NoError(t, node.Reload(config))
+
+	assert.NoError(t, node.Publish("test", []byte(`{"text": "helo"}`)))
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	subscribeClient(t, client, "test")
+
+	assert.NoError(t, node.EditPublication("test", 1, 0, []byte(`{"text": "hello"}`)))
+	select {
+	case data := <-transport.sink:
+		assert.True(t, strings.Contains(string(data), `"op":1`))
+		assert.True(t, strings.Contains(string(data), `"ref_seq":1`))
+		assert.True(t, strings.Contains(string(data), "hello"))
+	case <-time.After(time.Second):
+		t.Fatal("edit not delivered")
+	}
+
+	assert.NoError(t, node.DeletePublication("test", 1, 0))
+	select {
+	case data := <-transport.sink:
+		assert.True(t, strings.Contains(string(data), `"op":2`))
+		assert.True(t, strings.Contains(string(data), `"ref_seq":1`))
+	case <-time.After(time.Second):
+		t.Fatal("tombstone not delivered")
+	}
+
+	history, err := node.History("test")
+	pubs := history.Publications
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 3)
+	ops := map[PublicationOp]uint32{}
+	for _, pub := range pubs {
+		ops[pub.Op] = pub.Seq
+		if pub.Op != PublicationOpNew {
+			assert.Equal(t, uint32(1), pub.RefSeq)
+		}
+	}
+	assert.Equal(t, map[PublicationOp]uint32{PublicationOpNew: 1, PublicationOpEdit: 2, PublicationOpTombstone: 3}, ops)
+
+	client.mu.RLock()
+	assert.Equal(t, uint32(3), client.channels["test"].recoveryPosition.Seq)
+	client.mu.RUnlock()
+}
+
+func TestNodeEditPublicationInvalidReference(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	assert.NoError(t, node.Reload(config))
+
+	assert.Equal(t, ErrInvalidReference, node.EditPublication("test", 0, 0, []byte(`{}`)))
+	assert.Equal(t, ErrInvalidReference, node.DeletePublication("test", 1, 0))
+	assert.NoError(t, node.Publish("test", []byte(`{}`)))
+	assert.NoError(t, node.DeletePublication("test", 1, 0))
+}
@@ -124,6 +124,19 @@ type PresenceManager interface {
 	RemovePresence(ch string, clientID string) error
 }
 
+// PresenceOccupancyManager is an optional part of PresenceManager which
+// reports when channel gets first presence or loses last one. Report must
+// be atomic with presence change so concurrent joins and leaves on
+// different nodes produce exactly one change.
+type PresenceOccupancyManager interface {
+	// AddPresenceOccupancy works as AddPresence and returns true if
+	// channel had no presence before.
+	AddPresenceOccupancy(ch string, clientID string, info *ClientInfo, expire time.Duration) (bool, error)
+	// RemovePresenceOccupancy works as RemovePresence and returns true if
+	// channel has no presence after.
+	RemovePresenceOccupancy(ch string, clientID string) (bool, error)
+}
+
 // Engine is responsible for PUB/SUB mechanics, channel history and
 // presence information.
 type Engine interface {
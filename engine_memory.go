@@ -21,10 +21,17 @@ import (
 // need maximum performance and do not too many online clients. Consider configuring
 // your load balancer to have one backup Centrifuge node for HA in this case.
 type MemoryEngine struct {
-	node         *Node
-	presenceHub  *presenceHub
-	historyHub   *historyHub
-	eventHandler BrokerEventHandler
+	node                  *Node
+	presenceHub           *presenceHub
+	historyHub            *historyHub
+	scheduleHub           *scheduleHub
+	readHub               *readHub
+	roleHub               *roleHub
+	disconnectScheduleHub *disconnectScheduleHub
+	rateHub               *rateHub
+	tokenUseHub           *tokenUseHub
+	idempotencyHub        *idempotencyHub
+	eventHandler          BrokerEventHandler
 }
 
 // MemoryEngineConfig is a memory engine config.
@@ -33,9 +40,16 @@ type MemoryEngineConfig struct{}
 // NewMemoryEngine initializes Memory Engine.
 func NewMemoryEngine(n *Node, conf MemoryEngineConfig) (*MemoryEngine, error) {
 	e := &MemoryEngine{
-		node:        n,
-		presenceHub: newPresenceHub(),
-		historyHub:  newHistoryHub(),
+		node:                  n,
+		presenceHub:           newPresenceHub(),
+		historyHub:            newHistoryHub(),
+		scheduleHub:           newScheduleHub(),
+		readHub:               newReadHub(),
+		roleHub:               newRoleHub(),
+		disconnectScheduleHub: newDisconnectScheduleHub(),
+		rateHub:               newRateHub(),
+		tokenUseHub:           newTokenUseHub(),
+		idempotencyHub:        newIdempotencyHub(),
 	}
 	e.historyHub.initialize()
 	return e, nil
@@ -89,6 +103,16 @@ func (e *MemoryEngine) RemovePresence(ch string, uid string) error {
 	return e.presenceHub.remove(ch, uid)
 }
 
+// AddPresenceOccupancy - see PresenceOccupancyManager interface description.
+func (e *MemoryEngine) AddPresenceOccupancy(ch string, uid string, info *ClientInfo, exp time.Duration) (bool, error) {
+	return e.presenceHub.addOccupancy(ch, uid, info)
+}
+
+// RemovePresenceOccupancy - see PresenceOccupancyManager interface description.
+func (e *MemoryEngine) RemovePresenceOccupancy(ch string, uid string) (bool, error) {
+	return e.presenceHub.removeOccupancy(ch, uid)
+}
+
 // Presence - see engine interface description.
 func (e *MemoryEngine) Presence(ch string) (map[string]*ClientInfo, error) {
 	return e.presenceHub.get(ch)
@@ -109,6 +133,11 @@ func (e *MemoryEngine) AddHistory(ch string, pub *Publication, opts *ChannelOpti
 	return e.historyHub.add(ch, pub, opts)
 }
 
+// AddHistoryTx - see HistoryTxManager interface description.
+func (e *MemoryEngine) AddHistoryTx(entries []HistoryTxEntry) ([]*Publication, error) {
+	return e.historyHub.addTx(entries)
+}
+
 // RemoveHistory - see engine interface description.
 func (e *MemoryEngine) RemoveHistory(ch string) error {
 	return e.historyHub.remove(ch)
@@ -116,7 +145,82 @@ func (e *MemoryEngine) RemoveHistory(ch string) error {
 
 // Channels - see engine interface description.
 func (e *MemoryEngine) Channels() ([]string, error) {
-	return e.node.Hub().Channels(), nil
+	return e.node.subscribedChannels(), nil
+}
+
+// AddScheduled - see ScheduleManager interface description.
+func (e *MemoryEngine) AddScheduled(pub *ScheduledPublication) error {
+	return e.scheduleHub.add(pub)
+}
+
+// PopScheduled - see ScheduleManager interface description.
+func (e *MemoryEngine) PopScheduled(now time.Time, limit int) ([]*ScheduledPublication, error) {
+	return e.scheduleHub.pop(now, limit)
+}
+
+// AddScheduledDisconnect - see DisconnectScheduler interface description.
+func (e *MemoryEngine) AddScheduledDisconnect(d *ScheduledDisconnect) error {
+	return e.disconnectScheduleHub.add(d)
+}
+
+// PopScheduledDisconnects - see DisconnectScheduler interface description.
+func (e *MemoryEngine) PopScheduledDisconnects(now time.Time, limit int) ([]*ScheduledDisconnect, error) {
+	return e.disconnectScheduleHub.pop(now, limit)
+}
+
+// SetReadPosition - see ReadPositionManager interface description.
+func (e *MemoryEngine) SetReadPosition(ch string, user string, pos RecoveryPosition) error {
+	return e.readHub.set(ch, user, pos)
+}
+
+// ReadPosition - see ReadPositionManager interface description.
+func (e *MemoryEngine) ReadPosition(ch string, user string) (RecoveryPosition, bool, error) {
+	return e.readHub.get(ch, user)
+}
+
+// SetRole - see RoleManager interface description.
+func (e *MemoryEngine) SetRole(ch string, user string, role ChannelRole) error {
+	return e.roleHub.set(ch, user, role)
+}
+
+// RemoveRole - see RoleManager interface description.
+func (e *MemoryEngine) RemoveRole(ch string, user string) error {
+	return e.roleHub.remove(ch, user)
+}
+
+// Role - see RoleManager interface description.
+func (e *MemoryEngine) Role(ch string, user string) (ChannelRole, bool, error) {
+	return e.roleHub.get(ch, user)
+}
+
+// Roles - see RoleManager interface description.
+func (e *MemoryEngine) Roles(ch string) (map[string]ChannelRole, error) {
+	return e.roleHub.getAll(ch)
+}
+
+// IncrRate - see RateCounter interface description.
+func (e *MemoryEngine) IncrRate(key string, window int64, interval time.Duration) (int64, int64, error) {
+	return e.rateHub.incr(key, window, interval)
+}
+
+// IncrTokenUse - see TokenUseCounter interface description.
+func (e *MemoryEngine) IncrTokenUse(id string, expireAt time.Time) (int64, error) {
+	return e.tokenUseHub.incr(id, expireAt)
+}
+
+// SaveIdempotencyKey - see IdempotencyStore interface description.
+func (e *MemoryEngine) SaveIdempotencyKey(ch string, key string, ttl time.Duration) (IdempotencyKeyStatus, error) {
+	return e.idempotencyHub.save(ch, key, ttl)
+}
+
+// ConfirmIdempotencyKey - see IdempotencyStore interface description.
+func (e *MemoryEngine) ConfirmIdempotencyKey(ch string, key string, ttl time.Duration) error {
+	return e.idempotencyHub.confirm(ch, key, ttl)
+}
+
+// RemoveIdempotencyKey - see IdempotencyStore interface description.
+func (e *MemoryEngine) RemoveIdempotencyKey(ch string, key string) error {
+	return e.idempotencyHub.remove(ch, key)
 }
 
 type presenceHub struct {
@@ -131,6 +235,12 @@ func newPresenceHub() *presenceHub {
 }
 
 func (h *presenceHub) add(ch string, uid string, info *ClientInfo) error {
+	_, err := h.addOccupancy(ch, uid, info)
+	return err
+}
+
+// addOccupancy adds presence and returns true if channel had no presence.
+func (h *presenceHub) addOccupancy(ch string, uid string, info *ClientInfo) (bool, error) {
 	h.Lock()
 	defer h.Unlock()
 
@@ -139,18 +249,25 @@ func (h *presenceHub) add(ch string, uid string, info *ClientInfo) error {
 		h.presence[ch] = make(map[string]*ClientInfo)
 	}
 	h.presence[ch][uid] = info
-	return nil
+	return !ok, nil
 }
 
 func (h *presenceHub) remove(ch string, uid string) error {
+	_, err := h.removeOccupancy(ch, uid)
+	return err
+}
+
+// removeOccupancy removes presence and returns true if channel has no
+// presence left.
+func (h *presenceHub) removeOccupancy(ch string, uid string) (bool, error) {
 	h.Lock()
 	defer h.Unlock()
 
 	if _, ok := h.presence[ch]; !ok {
-		return nil
+		return false, nil
 	}
 	if _, ok := h.presence[ch][uid]; !ok {
-		return nil
+		return false, nil
 	}
 
 	delete(h.presence[ch], uid)
@@ -158,9 +275,10 @@ func (h *presenceHub) remove(ch string, uid string) error {
 	// clean up map if needed
 	if len(h.presence[ch]) == 0 {
 		delete(h.presence, ch)
+		return true, nil
 	}
 
-	return nil
+	return false, nil
 }
 
 func (h *presenceHub) get(ch string) (map[string]*ClientInfo, error) {
@@ -294,6 +412,10 @@ func unpackUint64(val uint64) (uint32, uint32) {
 	return uint32(val), uint32(val >> 32)
 }
 
+func packUint64(seq, gen uint32) uint64 {
+	return uint64(gen)<<32 | uint64(seq)
+}
+
 func (h *historyHub) getSequence(ch string) (uint32, uint32, string) {
 	h.sequencesMu.Lock()
 	defer h.sequencesMu.Unlock()
@@ -310,7 +432,26 @@ func (h *historyHub) getSequence(ch string) (uint32, uint32, string) {
 func (h *historyHub) add(ch string, pub *Publication, opts *ChannelOptions) (*Publication, error) {
 	h.Lock()
 	defer h.Unlock()
+	return h.addUnsafe(ch, pub, opts)
+}
 
+// addTx adds publications into channel histories under one lock so
+// readers see either all of them or none.
+func (h *historyHub) addTx(entries []HistoryTxEntry) ([]*Publication, error) {
+	h.Lock()
+	defer h.Unlock()
+	pubs := make([]*Publication, len(entries))
+	for i, entry := range entries {
+		pub, err := h.addUnsafe(entry.Channel, entry.Publication, entry.Options)
+		if err != nil {
+			return nil, err
+		}
+		pubs[i] = pub
+	}
+	return pubs, nil
+}
+
+func (h *historyHub) addUnsafe(ch string, pub *Publication, opts *ChannelOptions) (*Publication, error) {
 	index := h.next(ch)
 	pub.Seq, pub.Gen = unpackUint64(index)
 
@@ -409,13 +550,13 @@ func (h *historyHub) getUnsafe(ch string, filter HistoryFilter) ([]*Publication,
 
 	if position > -1 {
 		pubs := allPubs[position:]
-		if filter.Limit >= 0 {
+		if filter.Limit >= 0 && filter.Limit < len(pubs) {
 			return pubs[:filter.Limit], latestPosition, nil
 		}
 		return pubs, latestPosition, nil
 	}
 
-	if filter.Limit >= 0 {
+	if filter.Limit >= 0 && filter.Limit < len(allPubs) {
 		return allPubs[:filter.Limit], latestPosition, nil
 	}
 	return allPubs, latestPosition, nil
@@ -436,3 +577,316 @@ const (
 	maxSeq uint32 = math.MaxUint32 // maximum uint32 value
 	maxGen uint32 = math.MaxUint32 // maximum uint32 value
 )
+
+// scheduleHub keeps scheduled publications ordered by time.
+type scheduleHub struct {
+	sync.Mutex
+	pubs  map[string]*ScheduledPublication
+	queue priority.Queue
+}
+
+func newScheduleHub() *scheduleHub {
+	return &scheduleHub{
+		pubs:  make(map[string]*ScheduledPublication),
+		queue: priority.MakeQueue(),
+	}
+}
+
+func (h *scheduleHub) add(pub *ScheduledPublication) error {
+	h.Lock()
+	defer h.Unlock()
+	h.pubs[pub.ID] = pub
+	heap.Push(&h.queue, &priority.Item{Value: pub.ID, Priority: pub.Time})
+	return nil
+}
+
+func (h *scheduleHub) pop(now time.Time, limit int) ([]*ScheduledPublication, error) {
+	h.Lock()
+	defer h.Unlock()
+	nowMs := now.UnixNano() / int64(time.Millisecond)
+	var due []*ScheduledPublication
+	for h.queue.Len() > 0 && len(due) < limit {
+		item := heap.Pop(&h.queue).(*priority.Item)
+		if item.Priority > nowMs {
+			heap.Push(&h.queue, item)
+			break
+		}
+		pub, ok := h.pubs[item.Value]
+		if !ok {
+			continue
+		}
+		delete(h.pubs, item.Value)
+		due = append(due, pub)
+	}
+	return due, nil
+}
+
+// disconnectScheduleHub keeps scheduled disconnects ordered by time.
+type disconnectScheduleHub struct {
+	sync.Mutex
+	disconnects map[string]*ScheduledDisconnect
+	queue       priority.Queue
+}
+
+func newDisconnectScheduleHub() *disconnectScheduleHub {
+	return &disconnectScheduleHub{
+		disconnects: make(map[string]*ScheduledDisconnect),
+		queue:       priority.MakeQueue(),
+	}
+}
+
+func (h *disconnectScheduleHub) add(d *ScheduledDisconnect) error {
+	h.Lock()
+	defer h.Unlock()
+	h.disconnects[d.ID] = d
+	heap.Push(&h.queue, &priority.Item{Value: d.ID, Priority: d.Time})
+	return nil
+}
+
+func (h *disconnectScheduleHub) pop(now time.Time, limit int) ([]*ScheduledDisconnect, error) {
+	h.Lock()
+	defer h.Unlock()
+	nowMs := now.UnixNano() / int64(time.Millisecond)
+	var due []*ScheduledDisconnect
+	for h.queue.Len() > 0 && len(due) < limit {
+		item := heap.Pop(&h.queue).(*priority.Item)
+		if item.Priority > nowMs {
+			heap.Push(&h.queue, item)
+			break
+		}
+		d, ok := h.disconnects[item.Value]
+		if !ok {
+			continue
+		}
+		delete(h.disconnects, item.Value)
+		due = append(due, d)
+	}
+	return due, nil
+}
+
+type readHub struct {
+	sync.RWMutex
+	positions map[string]map[string]RecoveryPosition
+}
+
+func newReadHub() *readHub {
+	return &readHub{
+		positions: make(map[string]map[string]RecoveryPosition),
+	}
+}
+
+func (h *readHub) set(ch string, user string, pos RecoveryPosition) error {
+	h.Lock()
+	defer h.Unlock()
+	if _, ok := h.positions[ch]; !ok {
+		h.positions[ch] = make(map[string]RecoveryPosition)
+	}
+	if cur, ok := h.positions[ch][user]; ok && cur.Epoch == pos.Epoch && packUint64(cur.Seq, cur.Gen) >= packUint64(pos.Seq, pos.Gen) {
+		// Read position never moves backwards within epoch.
+		return nil
+	}
+	h.positions[ch][user] = pos
+	return nil
+}
+
+func (h *readHub) get(ch string, user string) (RecoveryPosition, bool, error) {
+	h.RLock()
+	defer h.RUnlock()
+	pos, ok := h.positions[ch][user]
+	return pos, ok, nil
+}
+
+type roleHub struct {
+	sync.RWMutex
+	roles map[string]map[string]ChannelRole
+}
+
+func newRoleHub() *roleHub {
+	return &roleHub{
+		roles: make(map[string]map[string]ChannelRole),
+	}
+}
+
+func (h *roleHub) set(ch string, user string, role ChannelRole) error {
+	h.Lock()
+	defer h.Unlock()
+	if _, ok := h.roles[ch]; !ok {
+		h.roles[ch] = make(map[string]ChannelRole)
+	}
+	h.roles[ch][user] = role
+	return nil
+}
+
+func (h *roleHub) remove(ch string, user string) error {
+	h.Lock()
+	defer h.Unlock()
+	if _, ok := h.roles[ch]; !ok {
+		return nil
+	}
+	delete(h.roles[ch], user)
+	if len(h.roles[ch]) == 0 {
+		delete(h.roles, ch)
+	}
+	return nil
+}
+
+func (h *roleHub) get(ch string, user string) (ChannelRole, bool, error) {
+	h.RLock()
+	defer h.RUnlock()
+	role, ok := h.roles[ch][user]
+	return role, ok, nil
+}
+
+func (h *roleHub) getAll(ch string) (map[string]ChannelRole, error) {
+	h.RLock()
+	defer h.RUnlock()
+	roles := make(map[string]ChannelRole, len(h.roles[ch]))
+	for user, role := range h.roles[ch] {
+		roles[user] = role
+	}
+	return roles, nil
+}
+
+type rateKey struct {
+	key    string
+	window int64
+}
+
+type rateValue struct {
+	value    int64
+	expireAt time.Time
+}
+
+// rateHub keeps counters of rate limiter windows.
+type rateHub struct {
+	sync.Mutex
+	counters  map[rateKey]rateValue
+	lastClean time.Time
+}
+
+func newRateHub() *rateHub {
+	return &rateHub{
+		counters:  make(map[rateKey]rateValue),
+		lastClean: time.Now(),
+	}
+}
+
+func (h *rateHub) incr(key string, window int64, interval time.Duration) (int64, int64, error) {
+	h.Lock()
+	defer h.Unlock()
+	now := time.Now()
+	if now.Sub(h.lastClean) >= rateLimitCleanInterval {
+		h.lastClean = now
+		for k, v := range h.counters {
+			if !now.Before(v.expireAt) {
+				delete(h.counters, k)
+			}
+		}
+	}
+	current := h.counters[rateKey{key: key, window: window}]
+	current.value++
+	if current.expireAt.IsZero() {
+		current.expireAt = now.Add(2 * interval)
+	}
+	h.counters[rateKey{key: key, window: window}] = current
+	previous := h.counters[rateKey{key: key, window: window - 1}]
+	return current.value, previous.value, nil
+}
+
+// tokenUseHub counts uses of connection tokens.
+type tokenUseHub struct {
+	sync.Mutex
+	uses      map[string]rateValue
+	lastClean time.Time
+}
+
+func newTokenUseHub() *tokenUseHub {
+	return &tokenUseHub{
+		uses:      make(map[string]rateValue),
+		lastClean: time.Now(),
+	}
+}
+
+func (h *tokenUseHub) incr(id string, expireAt time.Time) (int64, error) {
+	h.Lock()
+	defer h.Unlock()
+	now := time.Now()
+	if now.Sub(h.lastClean) >= rateLimitCleanInterval {
+		h.lastClean = now
+		for k, v := range h.uses {
+			if !now.Before(v.expireAt) {
+				delete(h.uses, k)
+			}
+		}
+	}
+	uses, ok := h.uses[id]
+	if !ok || !now.Before(uses.expireAt) {
+		uses = rateValue{expireAt: expireAt}
+	}
+	uses.value++
+	h.uses[id] = uses
+	return uses.value, nil
+}
+
+type idempotencyKey struct {
+	channel string
+	key     string
+}
+
+type idempotencyEntry struct {
+	expireAt  time.Time
+	published bool
+}
+
+type idempotencyHub struct {
+	sync.Mutex
+	keys      map[idempotencyKey]idempotencyEntry
+	lastClean time.Time
+}
+
+func newIdempotencyHub() *idempotencyHub {
+	return &idempotencyHub{
+		keys:      make(map[idempotencyKey]idempotencyEntry),
+		lastClean: time.Now(),
+	}
+}
+
+func (h *idempotencyHub) save(ch string, key string, ttl time.Duration) (IdempotencyKeyStatus, error) {
+	h.Lock()
+	defer h.Unlock()
+	now := time.Now()
+	if now.Sub(h.lastClean) >= rateLimitCleanInterval {
+		h.lastClean = now
+		for k, entry := range h.keys {
+			if !now.Before(entry.expireAt) {
+				delete(h.keys, k)
+			}
+		}
+	}
+	k := idempotencyKey{channel: ch, key: key}
+	if entry, ok := h.keys[k]; ok && now.Before(entry.expireAt) {
+		if entry.published {
+			return IdempotencyKeyPublished, nil
+		}
+		return IdempotencyKeyPending, nil
+	}
+	h.keys[k] = idempotencyEntry{expireAt: now.Add(ttl)}
+	return IdempotencyKeySaved, nil
+}
+
+func (h *idempotencyHub) confirm(ch string, key string, ttl time.Duration) error {
+	h.Lock()
+	defer h.Unlock()
+	k := idempotencyKey{channel: ch, key: key}
+	if _, ok := h.keys[k]; ok {
+		h.keys[k] = idempotencyEntry{expireAt: time.Now().Add(ttl), published: true}
+	}
+	return nil
+}
+
+func (h *idempotencyHub) remove(ch string, key string) error {
+	h.Lock()
+	defer h.Unlock()
+	delete(h.keys, idempotencyKey{channel: ch, key: key})
+	return nil
+}
@@ -86,6 +86,25 @@ func TestMemoryEngineSubscribeUnsubscribe(t *testing.T) {
 	assert.NoError(t, e.Unsubscribe("channel"))
 }
 
+func TestMemoryEnginePresenceOccupancy(t *testing.T) {
+	e := testMemoryEngine()
+	occupied, err := e.AddPresenceOccupancy("channel", "uid1", &ClientInfo{User: "1"}, time.Second)
+	assert.NoError(t, err)
+	assert.True(t, occupied)
+	occupied, _ = e.AddPresenceOccupancy("channel", "uid1", &ClientInfo{User: "1"}, time.Second)
+	assert.False(t, occupied)
+	occupied, _ = e.AddPresenceOccupancy("channel", "uid2", &ClientInfo{User: "1"}, time.Second)
+	assert.False(t, occupied)
+
+	vacated, err := e.RemovePresenceOccupancy("channel", "uid1")
+	assert.NoError(t, err)
+	assert.False(t, vacated)
+	vacated, _ = e.RemovePresenceOccupancy("channel", "uid2")
+	assert.True(t, vacated)
+	vacated, _ = e.RemovePresenceOccupancy("channel", "uid2")
+	assert.False(t, vacated)
+}
+
 func TestMemoryPresenceHub(t *testing.T) {
 	h := newPresenceHub()
 	assert.Equal(t, 0, len(h.presence))
@@ -3,6 +3,7 @@ package centrifuge
 import (
 	"bytes"
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"hash/fnv"
@@ -71,19 +72,28 @@ type RedisEngine struct {
 
 // shard has everything to connect to Redis instance.
 type shard struct {
-	node              *Node
-	engine            *RedisEngine
-	config            RedisShardConfig
-	pool              *redis.Pool
-	subCh             chan subRequest
-	pubCh             chan pubRequest
-	dataCh            chan dataRequest
-	addPresenceScript *redis.Script
-	remPresenceScript *redis.Script
-	presenceScript    *redis.Script
-	historyScript     *redis.Script
-	addHistoryScript  *redis.Script
-	messagePrefix     string
+	node                *Node
+	engine              *RedisEngine
+	config              RedisShardConfig
+	pool                *redis.Pool
+	cluster             *redisCluster
+	subCh               chan subRequest
+	pubCh               chan pubRequest
+	dataCh              chan dataRequest
+	addPresenceScript   *redis.Script
+	remPresenceScript   *redis.Script
+	presenceScript      *redis.Script
+	presenceStatsScript *redis.Script
+	historyScript       *redis.Script
+	addHistoryScript    *redis.Script
+	addHistoryTxScript  *redis.Script
+	addScheduledScript  *redis.Script
+	popScheduledScript  *redis.Script
+	setReadScript       *redis.Script
+	incrRateScript      *redis.Script
+	incrTokenUseScript  *redis.Script
+	idempotencyScript   *redis.Script
+	messagePrefix       string
 }
 
 // RedisEngineConfig is a config for Redis Engine.
@@ -112,6 +122,12 @@ type RedisShardConfig struct {
 	MasterName string
 	// SentinelAddrs is a slice of Sentinel addresses.
 	SentinelAddrs []string
+	// ClusterAddrs is a slice of Redis Cluster node addresses in host:port
+	// form used to discover cluster topology. When set shard works with
+	// Redis Cluster: keys of channel are put into one hash slot and routed
+	// to master serving it, Host, Port, DB, MasterName and SentinelAddrs are
+	// not used.
+	ClusterAddrs []string
 	// Prefix to use before every channel name and key in Redis.
 	Prefix string
 	// IdleTimeout is timeout after which idle connections to Redis will be closed.
@@ -162,10 +178,13 @@ func newPool(n *Node, conf RedisShardConfig) *redis.Pool {
 	db := conf.DB
 
 	serverAddr := net.JoinHostPort(host, strconv.Itoa(port))
-	useSentinel := conf.MasterName != "" && len(conf.SentinelAddrs) > 0
+	useCluster := len(conf.ClusterAddrs) > 0
+	useSentinel := !useCluster && conf.MasterName != "" && len(conf.SentinelAddrs) > 0
 
 	usingPassword := password != ""
-	if !useSentinel {
+	if useCluster {
+		n.Log(NewLogEntry(LogLevelInfo, fmt.Sprintf("Redis: Cluster %v, using password: %v", conf.ClusterAddrs, usingPassword)))
+	} else if !useSentinel {
 		n.Log(NewLogEntry(LogLevelInfo, fmt.Sprintf("Redis: %s/%d, using password: %v", serverAddr, db, usingPassword)))
 	} else {
 		n.Log(NewLogEntry(LogLevelInfo, fmt.Sprintf("Redis: Sentinel for name: %s, db: %d, using password: %v", conf.MasterName, db, usingPassword)))
@@ -173,6 +192,9 @@ func newPool(n *Node, conf RedisShardConfig) *redis.Pool {
 
 	var lastMu sync.Mutex
 	var lastMaster string
+	// nextClusterAddr is an index of cluster node to dial next, nodes are
+	// tried in turn so connection moves to another node after failures.
+	var nextClusterAddr int
 
 	poolSize := defaultPoolSize
 
@@ -225,6 +247,12 @@ func newPool(n *Node, conf RedisShardConfig) *redis.Pool {
 		IdleTimeout: conf.IdleTimeout,
 		Dial: func() (redis.Conn, error) {
 			var err error
+			if useCluster {
+				lastMu.Lock()
+				serverAddr = conf.ClusterAddrs[nextClusterAddr%len(conf.ClusterAddrs)]
+				nextClusterAddr++
+				lastMu.Unlock()
+			}
 			if useSentinel {
 				serverAddr, err = sntnl.MasterAddr()
 				if err != nil {
@@ -267,7 +295,7 @@ func newPool(n *Node, conf RedisShardConfig) *redis.Pool {
 			}
 			c, err := redis.Dial("tcp", serverAddr, opts...)
 			if err != nil {
-				n.Log(NewLogEntry(LogLevelError, "error dialing to Redis", map[string]interface{}{"error": err.Error()}))
+				n.Log(NewLogEntry(LogLevelError, "error dialing to Redis", map[string]interface{}{"addr": serverAddr, "error": err.Error()}))
 				return nil, err
 			}
 
@@ -279,7 +307,7 @@ func newPool(n *Node, conf RedisShardConfig) *redis.Pool {
 				}
 			}
 
-			if db != 0 {
+			if db != 0 && !useCluster {
 				if _, err := c.Do("SELECT", db); err != nil {
 					c.Close()
 					n.Log(NewLogEntry(LogLevelError, "error selecting Redis db", map[string]interface{}{"error": err.Error()}))
@@ -344,6 +372,8 @@ var (
 	// ARGV[2] - history size ltrim right bound
 	// ARGV[3] - history lifetime
 	// ARGV[4] - channel to publish message to if needed.
+	// ARGV[5] - number of channel partitions to publish message to, partition
+	// channel names built the same way as partitionChannel does.
 	addHistorySource = `
 	local sequence = redis.call("incr", KEYS[2])
 	local payload = "__" .. sequence .. "__" .. ARGV[1]
@@ -351,51 +381,142 @@ var (
 	redis.call("ltrim", KEYS[1], 0, ARGV[2])
 	redis.call("expire", KEYS[1], ARGV[3])
 	if ARGV[4] ~= '' then
-		redis.call("publish", ARGV[4], payload)
+		local partitions = tonumber(ARGV[5])
+		if partitions > 1 then
+			for i = 0, partitions - 1 do
+				redis.call("publish", ARGV[4] .. "\0" .. i, payload)
+			end
+		else
+			redis.call("publish", ARGV[4], payload)
+		end
 	end
 	return sequence
 		`
 
+	// KEYS[2*i-1] - history list key of i-th publication
+	// KEYS[2*i] - history sequence key of i-th publication
+	// ARGV[5*i-4] - message payload of i-th publication
+	// ARGV[5*i-3] - history size ltrim right bound of i-th publication
+	// ARGV[5*i-2] - history lifetime of i-th publication
+	// ARGV[5*i-1] - channel to publish i-th publication to if needed
+	// ARGV[5*i] - number of channel partitions to publish i-th publication to
+	addHistoryTxSource = `
+local sequences = {}
+for i = 1, #KEYS / 2 do
+	local sequence = redis.call("incr", KEYS[2*i])
+	local payload = "__" .. sequence .. "__" .. ARGV[5*i-4]
+	redis.call("lpush", KEYS[2*i-1], payload)
+	redis.call("ltrim", KEYS[2*i-1], 0, ARGV[5*i-3])
+	redis.call("expire", KEYS[2*i-1], ARGV[5*i-2])
+	if ARGV[5*i-1] ~= '' then
+		local partitions = tonumber(ARGV[5*i])
+		if partitions > 1 then
+			for j = 0, partitions - 1 do
+				redis.call("publish", ARGV[5*i-1] .. "\0" .. j, payload)
+			end
+		else
+			redis.call("publish", ARGV[5*i-1], payload)
+		end
+	end
+	sequences[i] = sequence
+end
+return sequences
+		`
+
 	// KEYS[1] - presence set key
 	// KEYS[2] - presence hash key
+	// KEYS[3] - presence user hash key
 	// ARGV[1] - key expire seconds
 	// ARGV[2] - expire at for set member
 	// ARGV[3] - uid
 	// ARGV[4] - info payload
+	// ARGV[5] - user ID
+	// ARGV[6] - now string
 	addPresenceSource = `
+local expired = redis.call("zrangebyscore", KEYS[1], "0", ARGV[6])
+if #expired > 0 then
+  for num = 1, #expired do
+    redis.call("hdel", KEYS[2], expired[num])
+    redis.call("hdel", KEYS[3], expired[num])
+  end
+  redis.call("zremrangebyscore", KEYS[1], "0", ARGV[6])
+end
+local occupied = 0
+if redis.call("exists", KEYS[2]) == 0 then
+  occupied = 1
+end
 redis.call("zadd", KEYS[1], ARGV[2], ARGV[3])
 redis.call("hset", KEYS[2], ARGV[3], ARGV[4])
+redis.call("hset", KEYS[3], ARGV[3], ARGV[5])
 redis.call("expire", KEYS[1], ARGV[1])
 redis.call("expire", KEYS[2], ARGV[1])
+redis.call("expire", KEYS[3], ARGV[1])
+return occupied
 	`
 
 	// KEYS[1] - presence set key
 	// KEYS[2] - presence hash key
+	// KEYS[3] - presence user hash key
 	// ARGV[1] - uid
 	remPresenceSource = `
-redis.call("hdel", KEYS[2], ARGV[1])
+local removed = redis.call("hdel", KEYS[2], ARGV[1])
+redis.call("hdel", KEYS[3], ARGV[1])
 redis.call("zrem", KEYS[1], ARGV[1])
+if removed == 1 and redis.call("exists", KEYS[2]) == 0 then
+  return 1
+end
+return 0
 	`
 
 	// KEYS[1] - presence set key
 	// KEYS[2] - presence hash key
+	// KEYS[3] - presence user hash key
 	// ARGV[1] - now string
 	presenceSource = `
 local expired = redis.call("zrangebyscore", KEYS[1], "0", ARGV[1])
 if #expired > 0 then
   for num = 1, #expired do
     redis.call("hdel", KEYS[2], expired[num])
+    redis.call("hdel", KEYS[3], expired[num])
   end
   redis.call("zremrangebyscore", KEYS[1], "0", ARGV[1])
 end
 return redis.call("hgetall", KEYS[2])
 	`
 
+	// KEYS[1] - presence set key
+	// KEYS[2] - presence hash key
+	// KEYS[3] - presence user hash key
+	// ARGV[1] - now string
+	presenceStatsSource = `
+local expired = redis.call("zrangebyscore", KEYS[1], "0", ARGV[1])
+if #expired > 0 then
+  for num = 1, #expired do
+    redis.call("hdel", KEYS[2], expired[num])
+    redis.call("hdel", KEYS[3], expired[num])
+  end
+  redis.call("zremrangebyscore", KEYS[1], "0", ARGV[1])
+end
+local users = {}
+local numUsers = 0
+local userIDs = redis.call("hvals", KEYS[3])
+for num = 1, #userIDs do
+  if not users[userIDs[num]] then
+    users[userIDs[num]] = true
+    numUsers = numUsers + 1
+  end
+end
+return {redis.call("hlen", KEYS[2]), numUsers}
+	`
+
 	// KEYS[1] - history sequence key
 	// KEYS[2] - history epoch key
 	// KEYS[3] - history list key
 	// ARGV[1] - include publications into response
-	// ARGV[2] - publications list right bound
+	// ARGV[2] - max number of publications to return, -1 means no limit
+	// ARGV[3] - sequence to return publications after, empty for oldest
+	// List keeps newest publication first and sequences of list entries
+	// are contiguous so only range with requested publications loaded.
 	historySource = `
 redis.replicate_commands()
 local seq = redis.call("get", KEYS[1])
@@ -408,10 +529,110 @@ else
 end
 local pubs = nil
 if ARGV[1] ~= "0" then
-	pubs = redis.call("lrange", KEYS[3], 0, ARGV[2])
+	local len = redis.call("llen", KEYS[3])
+	local right = len - 1
+	if ARGV[3] ~= "" and seq then
+		local newer = tonumber(seq) - tonumber(ARGV[3])
+		if newer == 0 then
+			right = -1
+		elseif newer > 0 and newer <= len then
+			right = newer - 1
+		end
+	end
+	local left = 0
+	local limit = tonumber(ARGV[2])
+	if limit >= 0 then
+		left = right - limit + 1
+		if left < 0 then
+			left = 0
+		end
+	end
+	if right >= 0 then
+		pubs = redis.call("lrange", KEYS[3], left, right)
+	else
+		pubs = {}
+	end
 end
 return {seq, epoch, pubs}
 	`
+
+	// KEYS[1] - scheduled set key
+	// KEYS[2] - scheduled hash key
+	// ARGV[1] - publish time in milliseconds
+	// ARGV[2] - scheduled publication ID
+	// ARGV[3] - scheduled publication payload
+	addScheduledSource = `
+redis.call("hset", KEYS[2], ARGV[2], ARGV[3])
+redis.call("zadd", KEYS[1], ARGV[1], ARGV[2])
+	`
+
+	// KEYS[1] - scheduled set key
+	// KEYS[2] - scheduled hash key
+	// ARGV[1] - now in milliseconds
+	// ARGV[2] - max number of publications to return
+	popScheduledSource = `
+local ids = redis.call("zrangebyscore", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, ARGV[2])
+if #ids == 0 then
+  return {}
+end
+redis.call("zrem", KEYS[1], unpack(ids))
+local payloads = redis.call("hmget", KEYS[2], unpack(ids))
+redis.call("hdel", KEYS[2], unpack(ids))
+return payloads
+	`
+
+	// KEYS[1] - read positions hash key
+	// ARGV[1] - user ID
+	// ARGV[2] - read offset
+	// ARGV[3] - read epoch
+	setReadSource = `
+local cur = redis.call("hget", KEYS[1], ARGV[1])
+if cur then
+  local offset, epoch = string.match(cur, "^(%d+):(.*)$")
+  if epoch == ARGV[3] and tonumber(offset) >= tonumber(ARGV[2]) then
+    return 0
+  end
+end
+redis.call("hset", KEYS[1], ARGV[1], ARGV[2] .. ":" .. ARGV[3])
+return 1
+	`
+
+	// KEYS[1] - current window counter key
+	// KEYS[2] - previous window counter key
+	// ARGV[1] - counter lifetime in milliseconds
+	incrRateSource = `
+local current = redis.call("incr", KEYS[1])
+if current == 1 then
+  redis.call("pexpire", KEYS[1], ARGV[1])
+end
+local previous = redis.call("get", KEYS[2])
+if not previous then
+  previous = 0
+end
+return {current, tonumber(previous)}
+	`
+
+	// KEYS[1] - token uses key
+	// ARGV[1] - token expiration Unix time in seconds
+	incrTokenUseSource = `
+local uses = redis.call("incr", KEYS[1])
+if uses == 1 then
+  redis.call("expireat", KEYS[1], ARGV[1])
+end
+return uses
+	`
+
+	// KEYS[1] - idempotency key
+	// ARGV[1] - key lifetime in milliseconds
+	idempotencySource = `
+if redis.call("set", KEYS[1], "pending", "px", ARGV[1], "nx") then
+  return 0
+end
+if redis.call("get", KEYS[1]) == "published" then
+  return 2
+end
+return 1
+	`
 )
 
 func (e *RedisEngine) getShard(channel string) *shard {
@@ -487,6 +708,17 @@ func (e *RedisEngine) RemovePresence(ch string, uid string) error {
 	return e.getShard(ch).RemovePresence(ch, uid)
 }
 
+// AddPresenceOccupancy - see PresenceOccupancyManager interface description.
+func (e *RedisEngine) AddPresenceOccupancy(ch string, uid string, info *ClientInfo, exp time.Duration) (bool, error) {
+	expire := int(exp.Seconds())
+	return e.getShard(ch).AddPresenceOccupancy(ch, uid, info, expire)
+}
+
+// RemovePresenceOccupancy - see PresenceOccupancyManager interface description.
+func (e *RedisEngine) RemovePresenceOccupancy(ch string, uid string) (bool, error) {
+	return e.getShard(ch).RemovePresenceOccupancy(ch, uid)
+}
+
 // Presence - see engine interface description.
 func (e *RedisEngine) Presence(ch string) (map[string]*ClientInfo, error) {
 	return e.getShard(ch).Presence(ch)
@@ -504,7 +736,42 @@ func (e *RedisEngine) History(ch string, filter HistoryFilter) ([]*Publication,
 
 // AddHistory - see engine interface description.
 func (e *RedisEngine) AddHistory(ch string, pub *Publication, opts *ChannelOptions) (*Publication, error) {
-	return e.getShard(ch).AddHistory(ch, pub, opts, e.config.PublishOnHistoryAdd)
+	return e.getShard(ch).AddHistory(ch, pub, opts, e.publishOnHistoryAdd(ch, opts))
+}
+
+// publishOnHistoryAdd returns true if publication can be published by the
+// same Lua script which adds it into history. Script can only publish into
+// channel partitions located on the same shard as channel history.
+func (e *RedisEngine) publishOnHistoryAdd(ch string, opts *ChannelOptions) bool {
+	if !e.config.PublishOnHistoryAdd {
+		return false
+	}
+	s := e.getShard(ch)
+	for _, brokerCh := range brokerChannels(ch, opts) {
+		if e.getShard(brokerCh) != s {
+			return false
+		}
+	}
+	return true
+}
+
+// AddHistoryTx - see HistoryTxManager interface description. All channels
+// must belong to the same Redis shard, ErrTxCrossShard returned otherwise.
+func (e *RedisEngine) AddHistoryTx(entries []HistoryTxEntry) ([]*Publication, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	s := e.getShard(entries[0].Channel)
+	for _, entry := range entries[1:] {
+		if e.getShard(entry.Channel) != s {
+			return nil, ErrTxCrossShard
+		}
+	}
+	publish := make([]bool, len(entries))
+	for i, entry := range entries {
+		publish[i] = e.publishOnHistoryAdd(entry.Channel, entry.Options)
+	}
+	return s.AddHistoryTx(entries, publish)
 }
 
 // RemoveHistory - see engine interface description.
@@ -512,6 +779,103 @@ func (e *RedisEngine) RemoveHistory(ch string) error {
 	return e.getShard(ch).RemoveHistory(ch)
 }
 
+// AddScheduled - see ScheduleManager interface description.
+func (e *RedisEngine) AddScheduled(pub *ScheduledPublication) error {
+	return e.getShard(pub.Channel).AddScheduled(pub)
+}
+
+// PopScheduled - see ScheduleManager interface description.
+func (e *RedisEngine) PopScheduled(now time.Time, limit int) ([]*ScheduledPublication, error) {
+	var pubs []*ScheduledPublication
+	for _, shard := range e.shards {
+		if len(pubs) >= limit {
+			break
+		}
+		shardPubs, err := shard.PopScheduled(now, limit-len(pubs))
+		if err != nil {
+			return pubs, err
+		}
+		pubs = append(pubs, shardPubs...)
+	}
+	return pubs, nil
+}
+
+// AddScheduledDisconnect - see DisconnectScheduler interface description.
+func (e *RedisEngine) AddScheduledDisconnect(d *ScheduledDisconnect) error {
+	return e.getShard(d.User).AddScheduledDisconnect(d)
+}
+
+// PopScheduledDisconnects - see DisconnectScheduler interface description.
+func (e *RedisEngine) PopScheduledDisconnects(now time.Time, limit int) ([]*ScheduledDisconnect, error) {
+	var disconnects []*ScheduledDisconnect
+	for _, shard := range e.shards {
+		if len(disconnects) >= limit {
+			break
+		}
+		shardDisconnects, err := shard.PopScheduledDisconnects(now, limit-len(disconnects))
+		if err != nil {
+			return disconnects, err
+		}
+		disconnects = append(disconnects, shardDisconnects...)
+	}
+	return disconnects, nil
+}
+
+// SetReadPosition - see ReadPositionManager interface description.
+func (e *RedisEngine) SetReadPosition(ch string, user string, pos RecoveryPosition) error {
+	return e.getShard(ch).SetReadPosition(ch, user, pos)
+}
+
+// ReadPosition - see ReadPositionManager interface description.
+func (e *RedisEngine) ReadPosition(ch string, user string) (RecoveryPosition, bool, error) {
+	return e.getShard(ch).ReadPosition(ch, user)
+}
+
+// SetRole - see RoleManager interface description.
+func (e *RedisEngine) SetRole(ch string, user string, role ChannelRole) error {
+	return e.getShard(ch).SetRole(ch, user, role)
+}
+
+// RemoveRole - see RoleManager interface description.
+func (e *RedisEngine) RemoveRole(ch string, user string) error {
+	return e.getShard(ch).RemoveRole(ch, user)
+}
+
+// Role - see RoleManager interface description.
+func (e *RedisEngine) Role(ch string, user string) (ChannelRole, bool, error) {
+	return e.getShard(ch).Role(ch, user)
+}
+
+// Roles - see RoleManager interface description.
+func (e *RedisEngine) Roles(ch string) (map[string]ChannelRole, error) {
+	return e.getShard(ch).Roles(ch)
+}
+
+// IncrRate - see RateCounter interface description.
+func (e *RedisEngine) IncrRate(key string, window int64, interval time.Duration) (int64, int64, error) {
+	return e.getShard(key).IncrRate(key, window, interval)
+}
+
+// IncrTokenUse - see TokenUseCounter interface description.
+func (e *RedisEngine) IncrTokenUse(id string, expireAt time.Time) (int64, error) {
+	return e.getShard(id).IncrTokenUse(id, expireAt)
+}
+
+// SaveIdempotencyKey - see IdempotencyStore interface description.
+func (e *RedisEngine) SaveIdempotencyKey(ch string, key string, ttl time.Duration) (IdempotencyKeyStatus, error) {
+	return e.getShard(ch).SaveIdempotencyKey(ch, key, ttl)
+}
+
+// ConfirmIdempotencyKey - see IdempotencyStore interface description.
+func (e *RedisEngine) ConfirmIdempotencyKey(ch string, key string, ttl time.Duration) error {
+	return e.getShard(ch).ConfirmIdempotencyKey(ch, key, ttl)
+}
+
+// RemoveIdempotencyKey - see IdempotencyStore interface description.
+func (e *RedisEngine) RemoveIdempotencyKey(ch string, key string) error {
+	return e.getShard(ch).RemoveIdempotencyKey(ch, key)
+}
+
 // Channels - see engine interface description.
 func (e *RedisEngine) Channels() ([]string, error) {
 	channelMap := map[string]struct{}{}
@@ -540,19 +904,41 @@ func (e *RedisEngine) Channels() ([]string, error) {
 // newShard initializes new Redis shard.
 func newShard(n *Node, conf RedisShardConfig) (*shard, error) {
 	shard := &shard{
-		node:              n,
-		config:            conf,
-		pool:              newPool(n, conf),
-		addPresenceScript: redis.NewScript(2, addPresenceSource),
-		remPresenceScript: redis.NewScript(2, remPresenceSource),
-		presenceScript:    redis.NewScript(2, presenceSource),
-		historyScript:     redis.NewScript(3, historySource),
-		addHistoryScript:  redis.NewScript(2, addHistorySource),
+		node:                n,
+		config:              conf,
+		pool:                newPool(n, conf),
+		addPresenceScript:   redis.NewScript(3, addPresenceSource),
+		remPresenceScript:   redis.NewScript(3, remPresenceSource),
+		presenceScript:      redis.NewScript(3, presenceSource),
+		presenceStatsScript: redis.NewScript(3, presenceStatsSource),
+		historyScript:       redis.NewScript(3, historySource),
+		addHistoryScript:    redis.NewScript(2, addHistorySource),
+		addHistoryTxScript:  redis.NewScript(-1, addHistoryTxSource),
+		addScheduledScript:  redis.NewScript(2, addScheduledSource),
+		popScheduledScript:  redis.NewScript(2, popScheduledSource),
+		setReadScript:       redis.NewScript(1, setReadSource),
+		incrRateScript:      redis.NewScript(2, incrRateSource),
+		incrTokenUseScript:  redis.NewScript(1, incrTokenUseSource),
+		idempotencyScript:   redis.NewScript(1, idempotencySource),
 	}
 	shard.pubCh = make(chan pubRequest)
 	shard.subCh = make(chan subRequest)
 	shard.dataCh = make(chan dataRequest)
 	shard.messagePrefix = conf.Prefix + redisClientChannelPrefix
+	if len(conf.ClusterAddrs) > 0 {
+		if conf.DB != 0 {
+			return nil, errors.New("Redis Cluster supports only database 0")
+		}
+		cluster, err := newRedisCluster(n, conf)
+		if err != nil {
+			return nil, err
+		}
+		shard.cluster = cluster
+		go shard.runForever(func() {
+			shard.runClusterDataPipeline()
+		})
+		return shard, nil
+	}
 	go shard.runForever(func() {
 		shard.runDataPipeline()
 	})
@@ -571,24 +957,74 @@ func (s *shard) pingChannelID() channelID {
 	return channelID(s.config.Prefix + redisPingChannelSuffix)
 }
 
+// keyTag wraps part of key in hash tag when shard works with Redis Cluster
+// so all keys with the same part belong to one hash slot and can be used
+// by one Lua script.
+func (s *shard) keyTag(part string) string {
+	if s.cluster == nil {
+		return part
+	}
+	return "{" + part + "}"
+}
+
 func (s *shard) getPresenceHashKey(ch string) channelID {
-	return channelID(s.config.Prefix + ".presence.data." + ch)
+	return channelID(s.config.Prefix + ".presence.data." + s.keyTag(ch))
 }
 
 func (s *shard) getPresenceSetKey(ch string) channelID {
-	return channelID(s.config.Prefix + ".presence.expire." + ch)
+	return channelID(s.config.Prefix + ".presence.expire." + s.keyTag(ch))
+}
+
+func (s *shard) getPresenceUserKey(ch string) channelID {
+	return channelID(s.config.Prefix + ".presence.user." + s.keyTag(ch))
 }
 
 func (s *shard) getHistoryKey(ch string) channelID {
-	return channelID(s.config.Prefix + ".history.list." + ch)
+	return channelID(s.config.Prefix + ".history.list." + s.keyTag(ch))
 }
 
 func (s *shard) gethistorySeqKey(ch string) channelID {
-	return channelID(s.config.Prefix + ".history.seq." + ch)
+	return channelID(s.config.Prefix + ".history.seq." + s.keyTag(ch))
 }
 
 func (s *shard) gethistoryEpochKey(ch string) channelID {
-	return channelID(s.config.Prefix + ".history.epoch." + ch)
+	return channelID(s.config.Prefix + ".history.epoch." + s.keyTag(ch))
+}
+
+func (s *shard) getScheduledSetKey() channelID {
+	return channelID(s.config.Prefix + "." + s.keyTag("scheduled") + ".time")
+}
+
+func (s *shard) getScheduledHashKey() channelID {
+	return channelID(s.config.Prefix + "." + s.keyTag("scheduled") + ".data")
+}
+
+func (s *shard) getScheduledDisconnectSetKey() channelID {
+	return channelID(s.config.Prefix + "." + s.keyTag("disconnect") + ".time")
+}
+
+func (s *shard) getScheduledDisconnectHashKey() channelID {
+	return channelID(s.config.Prefix + "." + s.keyTag("disconnect") + ".data")
+}
+
+func (s *shard) getReadHashKey(ch string) channelID {
+	return channelID(s.config.Prefix + ".read." + s.keyTag(ch))
+}
+
+func (s *shard) getRoleHashKey(ch string) channelID {
+	return channelID(s.config.Prefix + ".roles." + s.keyTag(ch))
+}
+
+func (s *shard) getTokenUseKey(id string) channelID {
+	return channelID(s.config.Prefix + ".token." + s.keyTag(id))
+}
+
+func (s *shard) getIdempotencyKey(ch string, key string) channelID {
+	return channelID(s.config.Prefix + ".idempotency." + s.keyTag(ch) + "." + key)
+}
+
+func (s *shard) getRateKey(key string, window int64) channelID {
+	return channelID(s.config.Prefix + ".rate." + s.keyTag(key) + "." + strconv.FormatInt(window, 10))
 }
 
 // Run Redis shard.
@@ -784,7 +1220,7 @@ func (s *shard) runPubSub(eventHandler BrokerEventHandler) {
 		chIDs[0] = controlChannel
 		chIDs[1] = pingChannel
 
-		for _, ch := range s.node.Hub().Channels() {
+		for _, ch := range s.node.subscribedChannels() {
 			if s.engine.getShard(ch) == s {
 				chIDs = append(chIDs, s.messageChannelID(ch))
 			}
@@ -964,10 +1400,25 @@ const (
 	dataOpAddPresence dataOp = iota
 	dataOpRemovePresence
 	dataOpPresence
+	dataOpPresenceStats
 	dataOpHistory
 	dataOpAddHistory
 	dataOpHistoryRemove
+	dataOpAddHistoryTx
 	dataOpChannels
+	dataOpAddScheduled
+	dataOpPopScheduled
+	dataOpSetRead
+	dataOpRead
+	dataOpSetRole
+	dataOpRemoveRole
+	dataOpRole
+	dataOpRoles
+	dataOpIncrRate
+	dataOpIncrTokenUse
+	dataOpSaveIdempotencyKey
+	dataOpConfirmIdempotencyKey
+	dataOpRemoveIdempotencyKey
 )
 
 type dataResponse struct {
@@ -1000,45 +1451,121 @@ func (dr *dataRequest) result() *dataResponse {
 	return <-dr.resp
 }
 
-func (s *shard) runDataPipeline() {
-
-	conn := s.pool.Get()
-
-	err := s.addPresenceScript.Load(conn)
-	if err != nil {
-		s.node.Log(NewLogEntry(LogLevelError, "error loading add presence Lua", map[string]interface{}{"error": err.Error()}))
-		// Can not proceed if script has not been loaded.
-		conn.Close()
-		return
+// loadScripts loads Lua scripts into Redis so they can be called by hash.
+func (s *shard) loadScripts(conn redis.Conn) error {
+	scripts := []struct {
+		name   string
+		script *redis.Script
+	}{
+		{"add presence", s.addPresenceScript},
+		{"presence", s.presenceScript},
+		{"presence stats", s.presenceStatsScript},
+		{"remove presence", s.remPresenceScript},
+		{"history seq", s.historyScript},
+		{"add history", s.addHistoryScript},
+		{"add history tx", s.addHistoryTxScript},
+		{"add scheduled", s.addScheduledScript},
+		{"pop scheduled", s.popScheduledScript},
+		{"set read", s.setReadScript},
+		{"incr rate", s.incrRateScript},
+		{"incr token use", s.incrTokenUseScript},
+		{"save idempotency key", s.idempotencyScript},
+	}
+	for _, s := range scripts {
+		if err := s.script.Load(conn); err != nil {
+			return fmt.Errorf("error loading %s Lua: %v", s.name, err)
+		}
 	}
+	return nil
+}
 
-	err = s.presenceScript.Load(conn)
-	if err != nil {
-		s.node.Log(NewLogEntry(LogLevelError, "error loading presence Lua", map[string]interface{}{"error": err.Error()}))
-		// Can not proceed if script has not been loaded.
-		conn.Close()
+// dataCommand returns Lua script or Redis command data operation uses.
+func (s *shard) dataCommand(op dataOp) (*redis.Script, string) {
+	switch op {
+	case dataOpAddPresence:
+		return s.addPresenceScript, ""
+	case dataOpRemovePresence:
+		return s.remPresenceScript, ""
+	case dataOpPresence:
+		return s.presenceScript, ""
+	case dataOpPresenceStats:
+		return s.presenceStatsScript, ""
+	case dataOpHistory:
+		return s.historyScript, ""
+	case dataOpAddHistory:
+		return s.addHistoryScript, ""
+	case dataOpHistoryRemove:
+		return nil, "DEL"
+	case dataOpAddHistoryTx:
+		return s.addHistoryTxScript, ""
+	case dataOpChannels:
+		return nil, "PUBSUB"
+	case dataOpAddScheduled:
+		return s.addScheduledScript, ""
+	case dataOpPopScheduled:
+		return s.popScheduledScript, ""
+	case dataOpSetRead:
+		return s.setReadScript, ""
+	case dataOpRead:
+		return nil, "HGET"
+	case dataOpSetRole:
+		return nil, "HSET"
+	case dataOpRemoveRole:
+		return nil, "HDEL"
+	case dataOpRole:
+		return nil, "HGET"
+	case dataOpRoles:
+		return nil, "HGETALL"
+	case dataOpIncrRate:
+		return s.incrRateScript, ""
+	case dataOpIncrTokenUse:
+		return s.incrTokenUseScript, ""
+	case dataOpSaveIdempotencyKey:
+		return s.idempotencyScript, ""
+	case dataOpConfirmIdempotencyKey:
+		return nil, "SET"
+	case dataOpRemoveIdempotencyKey:
+		return nil, "DEL"
+	}
+	return nil, ""
+}
+
+// sendData writes data request into connection output buffer.
+func (s *shard) sendData(conn redis.Conn, dr *dataRequest) {
+	script, command := s.dataCommand(dr.op)
+	if script != nil {
+		script.SendHash(conn, dr.args...)
 		return
 	}
+	conn.Send(command, dr.args...)
+}
 
-	err = s.remPresenceScript.Load(conn)
-	if err != nil {
-		s.node.Log(NewLogEntry(LogLevelError, "error loading remove presence Lua", map[string]interface{}{"error": err.Error()}))
-		// Can not proceed if script has not been loaded.
-		conn.Close()
-		return
+// doData sends data request and waits for reply. Missing scripts are loaded
+// automatically.
+func (s *shard) doData(conn redis.Conn, dr *dataRequest) (interface{}, error) {
+	script, command := s.dataCommand(dr.op)
+	if script != nil {
+		return script.Do(conn, dr.args...)
 	}
+	return conn.Do(command, dr.args...)
+}
 
-	err = s.historyScript.Load(conn)
-	if err != nil {
-		s.node.Log(NewLogEntry(LogLevelError, "error loading history seq Lua", map[string]interface{}{"error": err.Error()}))
-		// Can not proceed if script has not been loaded.
-		conn.Close()
-		return
-	}
+// isNoScriptError checks for NOSCRIPT error. In normal circumstances this
+// should never happen. The only possible situation is when Redis scripts
+// were flushed. Redigo does the same check but for single EVALSHA command:
+// see https://github.com/garyburd/redigo/blob/master/redis/script.go#L64
+func isNoScriptError(err error) bool {
+	e, ok := err.(redis.Error)
+	return ok && strings.HasPrefix(string(e), "NOSCRIPT ")
+}
+
+func (s *shard) runDataPipeline() {
 
-	err = s.addHistoryScript.Load(conn)
+	conn := s.pool.Get()
+
+	err := s.loadScripts(conn)
 	if err != nil {
-		s.node.Log(NewLogEntry(LogLevelError, "error loading add history Lua", map[string]interface{}{"error": err.Error()}))
+		s.node.Log(NewLogEntry(LogLevelError, err.Error(), nil))
 		// Can not proceed if script has not been loaded.
 		conn.Close()
 		return
@@ -1063,22 +1590,7 @@ func (s *shard) runDataPipeline() {
 		conn := s.pool.Get()
 
 		for i := range drs {
-			switch drs[i].op {
-			case dataOpAddPresence:
-				s.addPresenceScript.SendHash(conn, drs[i].args...)
-			case dataOpRemovePresence:
-				s.remPresenceScript.SendHash(conn, drs[i].args...)
-			case dataOpPresence:
-				s.presenceScript.SendHash(conn, drs[i].args...)
-			case dataOpHistory:
-				s.historyScript.SendHash(conn, drs[i].args...)
-			case dataOpAddHistory:
-				s.addHistoryScript.SendHash(conn, drs[i].args...)
-			case dataOpHistoryRemove:
-				conn.Send("DEL", drs[i].args...)
-			case dataOpChannels:
-				conn.Send("PUBSUB", drs[i].args...)
-			}
+			s.sendData(conn, &drs[i])
 		}
 
 		err := conn.Flush()
@@ -1093,15 +1605,8 @@ func (s *shard) runDataPipeline() {
 		var noScriptError bool
 		for i := range drs {
 			reply, err := conn.Receive()
-			if err != nil {
-				// Check for NOSCRIPT error. In normal circumstances this should never happen.
-				// The only possible situation is when Redis scripts were flushed. In this case
-				// we will return from this func and load publish script from scratch.
-				// Redigo does the same check but for single EVALSHA command: see
-				// https://github.com/garyburd/redigo/blob/master/redis/script.go#L64
-				if e, ok := err.(redis.Error); ok && strings.HasPrefix(string(e), "NOSCRIPT ") {
-					noScriptError = true
-				}
+			if err != nil && isNoScriptError(err) {
+				noScriptError = true
 			}
 			drs[i].done(reply, err)
 		}
@@ -1115,9 +1620,139 @@ func (s *shard) runDataPipeline() {
 	}
 }
 
+// dataRequestKey returns key data request works with, it defines Redis
+// Cluster node request must be sent to.
+func dataRequestKey(dr *dataRequest) string {
+	index := 0
+	switch dr.op {
+	case dataOpChannels:
+		return ""
+	case dataOpAddHistoryTx:
+		// First argument is a number of keys.
+		index = 1
+	}
+	if len(dr.args) <= index {
+		return ""
+	}
+	switch key := dr.args[index].(type) {
+	case channelID:
+		return string(key)
+	case string:
+		return key
+	}
+	return ""
+}
+
+// runClusterDataPipeline is a data pipeline for shard working with Redis
+// Cluster. Batch of requests is split between masters by hash slots of
+// request keys. Pipeline restarts reloading cluster topology and Lua
+// scripts when slots moved, master became unavailable or script missing.
+func (s *shard) runClusterDataPipeline() {
+	err := s.cluster.refresh()
+	if err != nil {
+		s.node.Log(NewLogEntry(LogLevelError, "error loading Redis Cluster slots", map[string]interface{}{"error": err.Error()}))
+		return
+	}
+	for _, addr := range s.cluster.masters() {
+		conn := s.cluster.pool(addr).Get()
+		err := s.loadScripts(conn)
+		conn.Close()
+		if err != nil {
+			s.node.Log(NewLogEntry(LogLevelError, err.Error(), map[string]interface{}{"addr": addr}))
+			return
+		}
+	}
+
+	var drs []dataRequest
+
+	for dr := range s.dataCh {
+		drs = append(drs, dr)
+	loop:
+		for len(drs) < redisDataBatchLimit {
+			select {
+			case req := <-s.dataCh:
+				drs = append(drs, req)
+			default:
+				break loop
+			}
+		}
+
+		batches := map[string][]dataRequest{}
+		for i := range drs {
+			addr := s.cluster.addr(dataRequestKey(&drs[i]))
+			batches[addr] = append(batches[addr], drs[i])
+		}
+		restart := false
+		for addr, batch := range batches {
+			if !s.runClusterDataBatch(addr, batch) {
+				restart = true
+			}
+		}
+		if restart {
+			return
+		}
+		drs = nil
+	}
+}
+
+// runClusterDataBatch sends batch of requests to cluster master. It returns
+// false if pipeline must be restarted.
+func (s *shard) runClusterDataBatch(addr string, drs []dataRequest) bool {
+	conn := s.cluster.pool(addr).Get()
+	defer conn.Close()
+
+	for i := range drs {
+		s.sendData(conn, &drs[i])
+	}
+	err := conn.Flush()
+	if err != nil {
+		for i := range drs {
+			drs[i].done(nil, err)
+		}
+		s.node.Log(NewLogEntry(LogLevelError, "error flushing data pipeline", map[string]interface{}{"addr": addr, "error": err.Error()}))
+		return false
+	}
+	ok := true
+	for i := range drs {
+		reply, err := conn.Receive()
+		if err != nil {
+			if isNoScriptError(err) {
+				ok = false
+			} else if target, ask, redirected := redisClusterRedirect(err); redirected {
+				if !ask {
+					// Slot moved to another master permanently.
+					ok = false
+				}
+				reply, err = s.redirectData(target, ask, &drs[i])
+			} else if _, isRedisErr := err.(redis.Error); !isRedisErr {
+				// Connection problem, master may be unavailable.
+				ok = false
+			}
+		}
+		drs[i].done(reply, err)
+	}
+	return ok
+}
+
+// redirectData sends request once more to cluster node it was redirected
+// to by MOVED or ASK error.
+func (s *shard) redirectData(addr string, ask bool, dr *dataRequest) (interface{}, error) {
+	conn := s.cluster.pool(addr).Get()
+	defer conn.Close()
+	if ask {
+		if _, err := conn.Do("ASKING"); err != nil {
+			return nil, err
+		}
+	}
+	return s.doData(conn, dr)
+}
+
 var (
 	// ErrPublished returned to indicate that node should not publish message to broker.
 	ErrPublished = errors.New("message published")
+	// ErrTxCrossShard returned when channels of transactional publish
+	// belong to different Redis shards or Redis Cluster hash slots.
+	ErrTxCrossShard = errors.New("transaction channels belong to different shards")
 )
 
 // Publish - see engine interface description.
@@ -1314,33 +1949,57 @@ func (s *shard) getDataResponse(r dataRequest) *dataResponse {
 
 // AddPresence - see engine interface description.
 func (s *shard) AddPresence(ch string, uid string, info *ClientInfo, expire int) error {
+	_, err := s.AddPresenceOccupancy(ch, uid, info, expire)
+	return err
+}
+
+// AddPresenceOccupancy - see PresenceOccupancyManager interface description.
+func (s *shard) AddPresenceOccupancy(ch string, uid string, info *ClientInfo, expire int) (bool, error) {
 	infoJSON, err := info.Marshal()
 	if err != nil {
-		return err
+		return false, err
 	}
-	expireAt := time.Now().Unix() + int64(expire)
+	now := time.Now().Unix()
+	expireAt := now + int64(expire)
 	hashKey := s.getPresenceHashKey(ch)
 	setKey := s.getPresenceSetKey(ch)
-	dr := newDataRequest(dataOpAddPresence, []interface{}{setKey, hashKey, expire, expireAt, uid, infoJSON})
+	userKey := s.getPresenceUserKey(ch)
+	dr := newDataRequest(dataOpAddPresence, []interface{}{setKey, hashKey, userKey, expire, expireAt, uid, infoJSON, info.User, now})
 	resp := s.getDataResponse(dr)
-	return resp.err
+	if resp.err != nil {
+		return false, resp.err
+	}
+	occupied, err := redis.Int(resp.reply, nil)
+	return occupied == 1, err
 }
 
 // RemovePresence - see engine interface description.
 func (s *shard) RemovePresence(ch string, uid string) error {
+	_, err := s.RemovePresenceOccupancy(ch, uid)
+	return err
+}
+
+// RemovePresenceOccupancy - see PresenceOccupancyManager interface description.
+func (s *shard) RemovePresenceOccupancy(ch string, uid string) (bool, error) {
 	hashKey := s.getPresenceHashKey(ch)
 	setKey := s.getPresenceSetKey(ch)
-	dr := newDataRequest(dataOpRemovePresence, []interface{}{setKey, hashKey, uid})
+	userKey := s.getPresenceUserKey(ch)
+	dr := newDataRequest(dataOpRemovePresence, []interface{}{setKey, hashKey, userKey, uid})
 	resp := s.getDataResponse(dr)
-	return resp.err
+	if resp.err != nil {
+		return false, resp.err
+	}
+	vacated, err := redis.Int(resp.reply, nil)
+	return vacated == 1, err
 }
 
 // Presence - see engine interface description.
 func (s *shard) Presence(ch string) (map[string]*ClientInfo, error) {
 	hashKey := s.getPresenceHashKey(ch)
 	setKey := s.getPresenceSetKey(ch)
+	userKey := s.getPresenceUserKey(ch)
 	now := int(time.Now().Unix())
-	dr := newDataRequest(dataOpPresence, []interface{}{setKey, hashKey, now})
+	dr := newDataRequest(dataOpPresence, []interface{}{setKey, hashKey, userKey, now})
 	resp := s.getDataResponse(dr)
 	if resp.err != nil {
 		return nil, resp.err
@@ -1348,28 +2007,28 @@ func (s *shard) Presence(ch string) (map[string]*ClientInfo, error) {
 	return mapStringClientInfo(resp.reply, nil)
 }
 
-// Presence - see engine interface description.
+// PresenceStats - see engine interface description. Stats are calculated
+// in Redis so client infos are not loaded.
 func (s *shard) PresenceStats(ch string) (PresenceStats, error) {
-	presence, err := s.Presence(ch)
+	hashKey := s.getPresenceHashKey(ch)
+	setKey := s.getPresenceSetKey(ch)
+	userKey := s.getPresenceUserKey(ch)
+	now := int(time.Now().Unix())
+	dr := newDataRequest(dataOpPresenceStats, []interface{}{setKey, hashKey, userKey, now})
+	resp := s.getDataResponse(dr)
+	if resp.err != nil {
+		return PresenceStats{}, resp.err
+	}
+	values, err := redis.Ints(resp.reply, nil)
 	if err != nil {
 		return PresenceStats{}, err
 	}
-
-	numClients := len(presence)
-	numUsers := 0
-	uniqueUsers := map[string]struct{}{}
-
-	for _, info := range presence {
-		userID := info.User
-		if _, ok := uniqueUsers[userID]; !ok {
-			uniqueUsers[userID] = struct{}{}
-			numUsers++
-		}
+	if len(values) != 2 {
+		return PresenceStats{}, errors.New("wrong Redis reply")
 	}
-
 	return PresenceStats{
-		NumClients: numClients,
-		NumUsers:   numUsers,
+		NumClients: values[0],
+		NumUsers:   values[1],
 	}, nil
 }
 
@@ -1380,13 +2039,18 @@ func (s *shard) History(ch string, filter HistoryFilter) ([]*Publication, Recove
 	historyKey := s.getHistoryKey(ch)
 
 	var includePubs = true
-	var rightBound = -1
-	if filter.Limit == 0 {
-		rightBound = 0
+	limit := filter.Limit
+	if limit < 0 {
+		limit = -1
+	} else if limit == 0 {
 		includePubs = false
 	}
+	var sinceSequence string
+	if filter.Since != nil {
+		sinceSequence = strconv.FormatUint(packUint64(filter.Since.Seq, filter.Since.Gen), 10)
+	}
 
-	dr := newDataRequest(dataOpHistory, []interface{}{historySeqKey, historyEpochKey, historyKey, includePubs, rightBound})
+	dr := newDataRequest(dataOpHistory, []interface{}{historySeqKey, historyEpochKey, historyKey, includePubs, limit, sinceSequence})
 	resp := s.getDataResponse(dr)
 	if resp.err != nil {
 		return nil, RecoveryPosition{}, resp.err
@@ -1452,19 +2116,20 @@ func (s *shard) History(ch string, filter HistoryFilter) ([]*Publication, Recove
 
 	if position > -1 {
 		pubs := publications[position:]
-		if filter.Limit >= 0 {
+		if filter.Limit >= 0 && filter.Limit < len(pubs) {
 			return pubs[:filter.Limit], latestPosition, nil
 		}
 		return pubs, latestPosition, nil
 	}
 
-	if filter.Limit >= 0 {
+	if filter.Limit >= 0 && filter.Limit < len(publications) {
 		return publications[:filter.Limit], latestPosition, nil
 	}
 	return publications, latestPosition, nil
 }
 
-func (s *shard) AddHistory(ch string, pub *Publication, opts *ChannelOptions, publishOnHistoryAdd bool) (*Publication, error) {
+// historyMessage encodes publication to keep in history list.
+func historyMessage(ch string, pub *Publication) ([]byte, error) {
 	data, err := pub.Marshal()
 	if err != nil {
 		return nil, err
@@ -1474,7 +2139,11 @@ func (s *shard) AddHistory(ch string, pub *Publication, opts *ChannelOptions, pu
 		Channel: ch,
 		Data:    data,
 	}
-	byteMessage, err := push.Marshal()
+	return push.Marshal()
+}
+
+func (s *shard) AddHistory(ch string, pub *Publication, opts *ChannelOptions, publishOnHistoryAdd bool) (*Publication, error) {
+	byteMessage, err := historyMessage(ch, pub)
 	if err != nil {
 		return nil, err
 	}
@@ -1486,7 +2155,7 @@ func (s *shard) AddHistory(ch string, pub *Publication, opts *ChannelOptions, pu
 
 	historyKey := s.getHistoryKey(ch)
 	sequenceKey := s.gethistorySeqKey(ch)
-	dr := newDataRequest(dataOpAddHistory, []interface{}{historyKey, sequenceKey, byteMessage, opts.HistorySize - 1, opts.HistoryLifetime, publishChannel})
+	dr := newDataRequest(dataOpAddHistory, []interface{}{historyKey, sequenceKey, byteMessage, opts.HistorySize - 1, opts.HistoryLifetime, publishChannel, opts.Partitions})
 	resp := s.getDataResponse(dr)
 	if resp.err != nil {
 		return nil, resp.err
@@ -1506,6 +2175,55 @@ func (s *shard) AddHistory(ch string, pub *Publication, opts *ChannelOptions, pu
 	return pub, nil
 }
 
+// AddHistoryTx adds publications into histories of shard channels in one
+// Lua script call so Redis applies all of them atomically. Publications of
+// entries with publishOnHistoryAdd set are published by the script.
+func (s *shard) AddHistoryTx(entries []HistoryTxEntry, publishOnHistoryAdd []bool) ([]*Publication, error) {
+	if s.cluster != nil {
+		slot := redisClusterSlot(string(s.getHistoryKey(entries[0].Channel)))
+		for _, entry := range entries[1:] {
+			if redisClusterSlot(string(s.getHistoryKey(entry.Channel))) != slot {
+				return nil, ErrTxCrossShard
+			}
+		}
+	}
+	keys := make([]interface{}, 0, 2*len(entries))
+	args := make([]interface{}, 0, 5*len(entries))
+	for i, entry := range entries {
+		byteMessage, err := historyMessage(entry.Channel, entry.Publication)
+		if err != nil {
+			return nil, err
+		}
+		var publishChannel channelID
+		if publishOnHistoryAdd[i] {
+			publishChannel = s.messageChannelID(entry.Channel)
+		}
+		keys = append(keys, s.getHistoryKey(entry.Channel), s.gethistorySeqKey(entry.Channel))
+		args = append(args, byteMessage, entry.Options.HistorySize-1, entry.Options.HistoryLifetime, publishChannel, entry.Options.Partitions)
+	}
+	dr := newDataRequest(dataOpAddHistoryTx, append(append([]interface{}{len(keys)}, keys...), args...))
+	resp := s.getDataResponse(dr)
+	if resp.err != nil {
+		return nil, resp.err
+	}
+
+	pubs := make([]*Publication, len(entries))
+	indexes, err := redis.Int64s(resp.reply, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i, index := range indexes {
+		if publishOnHistoryAdd[i] {
+			// Already published by script.
+			continue
+		}
+		pub := entries[i].Publication
+		pub.Seq, pub.Gen = unpackUint64(uint64(index))
+		pubs[i] = pub
+	}
+	return pubs, nil
+}
+
 // RemoveHistory - see engine interface description.
 func (s *shard) RemoveHistory(ch string) error {
 	historyKey := s.getHistoryKey(ch)
@@ -1514,6 +2232,229 @@ func (s *shard) RemoveHistory(ch string) error {
 	return resp.err
 }
 
+// AddScheduled - see ScheduleManager interface description.
+func (s *shard) AddScheduled(pub *ScheduledPublication) error {
+	payload, err := json.Marshal(pub)
+	if err != nil {
+		return err
+	}
+	dr := newDataRequest(dataOpAddScheduled, []interface{}{s.getScheduledSetKey(), s.getScheduledHashKey(), pub.Time, pub.ID, payload})
+	resp := s.getDataResponse(dr)
+	return resp.err
+}
+
+// PopScheduled - see ScheduleManager interface description.
+func (s *shard) PopScheduled(now time.Time, limit int) ([]*ScheduledPublication, error) {
+	nowMs := now.UnixNano() / int64(time.Millisecond)
+	dr := newDataRequest(dataOpPopScheduled, []interface{}{s.getScheduledSetKey(), s.getScheduledHashKey(), nowMs, limit})
+	resp := s.getDataResponse(dr)
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	values, err := redis.ByteSlices(resp.reply, nil)
+	if err != nil {
+		return nil, err
+	}
+	pubs := make([]*ScheduledPublication, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		var pub ScheduledPublication
+		if err := json.Unmarshal(value, &pub); err != nil {
+			s.node.Log(NewLogEntry(LogLevelError, "error decoding scheduled publication", map[string]interface{}{"error": err.Error()}))
+			continue
+		}
+		pubs = append(pubs, &pub)
+	}
+	return pubs, nil
+}
+
+// AddScheduledDisconnect - see DisconnectScheduler interface description.
+// Uses the same Lua script as scheduled publications with separate keys.
+func (s *shard) AddScheduledDisconnect(d *ScheduledDisconnect) error {
+	payload, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	dr := newDataRequest(dataOpAddScheduled, []interface{}{s.getScheduledDisconnectSetKey(), s.getScheduledDisconnectHashKey(), d.Time, d.ID, payload})
+	resp := s.getDataResponse(dr)
+	return resp.err
+}
+
+// PopScheduledDisconnects - see DisconnectScheduler interface description.
+func (s *shard) PopScheduledDisconnects(now time.Time, limit int) ([]*ScheduledDisconnect, error) {
+	nowMs := now.UnixNano() / int64(time.Millisecond)
+	dr := newDataRequest(dataOpPopScheduled, []interface{}{s.getScheduledDisconnectSetKey(), s.getScheduledDisconnectHashKey(), nowMs, limit})
+	resp := s.getDataResponse(dr)
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	values, err := redis.ByteSlices(resp.reply, nil)
+	if err != nil {
+		return nil, err
+	}
+	disconnects := make([]*ScheduledDisconnect, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		var d ScheduledDisconnect
+		if err := json.Unmarshal(value, &d); err != nil {
+			s.node.Log(NewLogEntry(LogLevelError, "error decoding scheduled disconnect", map[string]interface{}{"error": err.Error()}))
+			continue
+		}
+		disconnects = append(disconnects, &d)
+	}
+	return disconnects, nil
+}
+
+// SetReadPosition - see ReadPositionManager interface description.
+func (s *shard) SetReadPosition(ch string, user string, pos RecoveryPosition) error {
+	offset := strconv.FormatUint(packUint64(pos.Seq, pos.Gen), 10)
+	dr := newDataRequest(dataOpSetRead, []interface{}{s.getReadHashKey(ch), user, offset, pos.Epoch})
+	resp := s.getDataResponse(dr)
+	return resp.err
+}
+
+// ReadPosition - see ReadPositionManager interface description.
+func (s *shard) ReadPosition(ch string, user string) (RecoveryPosition, bool, error) {
+	dr := newDataRequest(dataOpRead, []interface{}{s.getReadHashKey(ch), user})
+	resp := s.getDataResponse(dr)
+	if resp.err != nil {
+		return RecoveryPosition{}, false, resp.err
+	}
+	value, err := redis.String(resp.reply, nil)
+	if err == redis.ErrNil {
+		return RecoveryPosition{}, false, nil
+	} else if err != nil {
+		return RecoveryPosition{}, false, err
+	}
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return RecoveryPosition{}, false, errors.New("malformed read position")
+	}
+	offset, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return RecoveryPosition{}, false, err
+	}
+	seq, gen := unpackUint64(offset)
+	return RecoveryPosition{Seq: seq, Gen: gen, Epoch: parts[1]}, true, nil
+}
+
+// SetRole - see RoleManager interface description.
+func (s *shard) SetRole(ch string, user string, role ChannelRole) error {
+	dr := newDataRequest(dataOpSetRole, []interface{}{s.getRoleHashKey(ch), user, string(role)})
+	resp := s.getDataResponse(dr)
+	return resp.err
+}
+
+// RemoveRole - see RoleManager interface description.
+func (s *shard) RemoveRole(ch string, user string) error {
+	dr := newDataRequest(dataOpRemoveRole, []interface{}{s.getRoleHashKey(ch), user})
+	resp := s.getDataResponse(dr)
+	return resp.err
+}
+
+// Role - see RoleManager interface description.
+func (s *shard) Role(ch string, user string) (ChannelRole, bool, error) {
+	dr := newDataRequest(dataOpRole, []interface{}{s.getRoleHashKey(ch), user})
+	resp := s.getDataResponse(dr)
+	if resp.err != nil {
+		return "", false, resp.err
+	}
+	value, err := redis.String(resp.reply, nil)
+	if err == redis.ErrNil {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+	return ChannelRole(value), true, nil
+}
+
+// Roles - see RoleManager interface description.
+func (s *shard) Roles(ch string) (map[string]ChannelRole, error) {
+	dr := newDataRequest(dataOpRoles, []interface{}{s.getRoleHashKey(ch)})
+	resp := s.getDataResponse(dr)
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	values, err := redis.StringMap(resp.reply, nil)
+	if err != nil {
+		return nil, err
+	}
+	roles := make(map[string]ChannelRole, len(values))
+	for user, role := range values {
+		roles[user] = ChannelRole(role)
+	}
+	return roles, nil
+}
+
+// IncrRate - see RateCounter interface description.
+func (s *shard) IncrRate(key string, window int64, interval time.Duration) (int64, int64, error) {
+	lifetime := int64(2*interval) / int64(time.Millisecond)
+	dr := newDataRequest(dataOpIncrRate, []interface{}{s.getRateKey(key, window), s.getRateKey(key, window-1), lifetime})
+	resp := s.getDataResponse(dr)
+	if resp.err != nil {
+		return 0, 0, resp.err
+	}
+	values, err := redis.Int64s(resp.reply, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(values) != 2 {
+		return 0, 0, errors.New("wrong number of rate counters")
+	}
+	return values[0], values[1], nil
+}
+
+// IncrTokenUse - see TokenUseCounter interface description.
+func (s *shard) IncrTokenUse(id string, expireAt time.Time) (int64, error) {
+	dr := newDataRequest(dataOpIncrTokenUse, []interface{}{s.getTokenUseKey(id), expireAt.Unix()})
+	resp := s.getDataResponse(dr)
+	if resp.err != nil {
+		return 0, resp.err
+	}
+	return redis.Int64(resp.reply, nil)
+}
+
+// SaveIdempotencyKey - see IdempotencyStore interface description.
+func (s *shard) SaveIdempotencyKey(ch string, key string, ttl time.Duration) (IdempotencyKeyStatus, error) {
+	dr := newDataRequest(dataOpSaveIdempotencyKey, []interface{}{s.getIdempotencyKey(ch, key), idempotencyTTLMs(ttl)})
+	resp := s.getDataResponse(dr)
+	if resp.err != nil {
+		return 0, resp.err
+	}
+	status, err := redis.Int(resp.reply, nil)
+	if err != nil {
+		return 0, err
+	}
+	return IdempotencyKeyStatus(status), nil
+}
+
+// ConfirmIdempotencyKey - see IdempotencyStore interface description.
+func (s *shard) ConfirmIdempotencyKey(ch string, key string, ttl time.Duration) error {
+	// XX so expired or removed key not created again.
+	dr := newDataRequest(dataOpConfirmIdempotencyKey, []interface{}{s.getIdempotencyKey(ch, key), "published", "PX", idempotencyTTLMs(ttl), "XX"})
+	resp := s.getDataResponse(dr)
+	return resp.err
+}
+
+func idempotencyTTLMs(ttl time.Duration) int64 {
+	ttlMs := int64(ttl / time.Millisecond)
+	if ttlMs <= 0 {
+		ttlMs = 1
+	}
+	return ttlMs
+}
+
+// RemoveIdempotencyKey - see IdempotencyStore interface description.
+func (s *shard) RemoveIdempotencyKey(ch string, key string) error {
+	dr := newDataRequest(dataOpRemoveIdempotencyKey, []interface{}{s.getIdempotencyKey(ch, key)})
+	resp := s.getDataResponse(dr)
+	return resp.err
+}
+
 // Channels - see engine interface description.
 // Requires Redis >= 2.8.0 (http://redis.io/commands/pubsub)
 func (s *shard) Channels() ([]string, error) {
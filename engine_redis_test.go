@@ -796,3 +796,261 @@ func TestClientSubscribeRecoverRedis(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisEngineScheduled(t *testing.T) {
+	e := newTestRedisEngine()
+	conn := e.shards[0].pool.Get()
+	conn.Do("DEL", e.shards[0].getScheduledSetKey(), e.shards[0].getScheduledHashKey())
+	conn.Close()
+
+	now := time.Now()
+	nowMs := now.UnixNano() / int64(time.Millisecond)
+	assert.NoError(t, e.AddScheduled(&ScheduledPublication{ID: "2", Channel: "test", Data: []byte(`{}`), Time: nowMs + 2000}))
+	assert.NoError(t, e.AddScheduled(&ScheduledPublication{ID: "1", Channel: "test", Data: []byte(`{}`), Time: nowMs + 1000}))
+	assert.NoError(t, e.AddScheduled(&ScheduledPublication{ID: "3", Channel: "test", Data: []byte{0x00, 0xff}, Time: nowMs + 2500}))
+
+	pubs, err := e.PopScheduled(now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 0)
+
+	pubs, err = e.PopScheduled(now.Add(3*time.Second), 10)
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 3)
+	assert.Equal(t, "1", pubs[0].ID)
+	assert.Equal(t, []byte(`{}`), pubs[0].Data)
+	// Binary payload survives JSON encoding.
+	assert.Equal(t, []byte{0x00, 0xff}, pubs[2].Data)
+
+	pubs, err = e.PopScheduled(now.Add(3*time.Second), 10)
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 0)
+}
+
+func TestRedisEngineScheduledDisconnects(t *testing.T) {
+	e := newTestRedisEngine()
+	conn := e.shards[0].pool.Get()
+	conn.Do("DEL", e.shards[0].getScheduledDisconnectSetKey(), e.shards[0].getScheduledDisconnectHashKey())
+	conn.Close()
+
+	now := time.Now()
+	nowMs := now.UnixNano() / int64(time.Millisecond)
+	assert.NoError(t, e.AddScheduledDisconnect(&ScheduledDisconnect{ID: "2", User: "42", Time: nowMs + 2000, Code: 4000, Reason: "session ended"}))
+	assert.NoError(t, e.AddScheduledDisconnect(&ScheduledDisconnect{ID: "1", User: "42", Time: nowMs + 1000}))
+
+	disconnects, err := e.PopScheduledDisconnects(now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, disconnects, 0)
+
+	disconnects, err = e.PopScheduledDisconnects(now.Add(3*time.Second), 10)
+	assert.NoError(t, err)
+	assert.Len(t, disconnects, 2)
+	assert.Equal(t, "1", disconnects[0].ID)
+	assert.Equal(t, 4000, disconnects[1].Code)
+
+	disconnects, err = e.PopScheduledDisconnects(now.Add(3*time.Second), 10)
+	assert.NoError(t, err)
+	assert.Len(t, disconnects, 0)
+}
+
+func TestRedisEngineAddHistoryTx(t *testing.T) {
+	e := newTestRedisEngine()
+	conn := e.shards[0].pool.Get()
+	for _, ch := range []string{"tx_a", "tx_b"} {
+		conn.Do("DEL", e.shards[0].getHistoryKey(ch), e.shards[0].gethistorySeqKey(ch))
+	}
+	conn.Close()
+
+	opts := &ChannelOptions{HistorySize: 10, HistoryLifetime: 60}
+	_, err := e.AddHistory("tx_a", &Publication{Data: []byte(`{}`)}, opts)
+	assert.NoError(t, err)
+
+	pubs, err := e.AddHistoryTx([]HistoryTxEntry{
+		{Channel: "tx_a", Publication: &Publication{Data: []byte(`{}`)}, Options: opts},
+		{Channel: "tx_b", Publication: &Publication{Data: []byte(`{}`)}, Options: opts},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 2)
+	assert.Equal(t, uint32(2), pubs[0].Seq)
+	assert.Equal(t, uint32(1), pubs[1].Seq)
+
+	history, _, err := e.History("tx_b", HistoryFilter{Limit: -1})
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+
+func TestRedisEngineReadPosition(t *testing.T) {
+	e := newTestRedisEngine()
+	conn := e.shards[0].pool.Get()
+	conn.Do("DEL", e.shards[0].getReadHashKey("test"))
+	conn.Close()
+
+	_, ok, err := e.ReadPosition("test", "42")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, e.SetReadPosition("test", "42", RecoveryPosition{Seq: 2, Gen: 1, Epoch: "xyz"}))
+	pos, ok, err := e.ReadPosition("test", "42")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, RecoveryPosition{Seq: 2, Gen: 1, Epoch: "xyz"}, pos)
+
+	// Position does not move backwards within epoch.
+	assert.NoError(t, e.SetReadPosition("test", "42", RecoveryPosition{Seq: 5, Gen: 0, Epoch: "xyz"}))
+	pos, _, _ = e.ReadPosition("test", "42")
+	assert.Equal(t, RecoveryPosition{Seq: 2, Gen: 1, Epoch: "xyz"}, pos)
+
+	assert.NoError(t, e.SetReadPosition("test", "42", RecoveryPosition{Seq: 1, Gen: 0, Epoch: "new"}))
+	pos, _, _ = e.ReadPosition("test", "42")
+	assert.Equal(t, RecoveryPosition{Seq: 1, Gen: 0, Epoch: "new"}, pos)
+}
+
+func TestRedisEngineRoles(t *testing.T) {
+	e := newTestRedisEngine()
+	conn := e.shards[0].pool.Get()
+	conn.Do("DEL", e.shards[0].getRoleHashKey("test"))
+	conn.Close()
+
+	_, ok, err := e.Role("test", "42")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, e.SetRole("test", "42", ChannelRoleModerator))
+	assert.NoError(t, e.SetRole("test", "43", ChannelRoleMember))
+	role, ok, err := e.Role("test", "42")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, ChannelRoleModerator, role)
+
+	roles, err := e.Roles("test")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]ChannelRole{"42": ChannelRoleModerator, "43": ChannelRoleMember}, roles)
+
+	assert.NoError(t, e.RemoveRole("test", "42"))
+	_, ok, _ = e.Role("test", "42")
+	assert.False(t, ok)
+}
+
+func TestRedisEngineIncrRate(t *testing.T) {
+	e := newTestRedisEngine()
+	conn := e.shards[0].pool.Get()
+	conn.Do("DEL", e.shards[0].getRateKey("test", 1), e.shards[0].getRateKey("test", 2))
+	conn.Close()
+
+	current, previous, err := e.IncrRate("test", 1, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), current)
+	assert.Equal(t, int64(0), previous)
+	current, _, _ = e.IncrRate("test", 1, time.Minute)
+	assert.Equal(t, int64(2), current)
+
+	current, previous, err = e.IncrRate("test", 2, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), current)
+	assert.Equal(t, int64(2), previous)
+}
+
+func TestRedisEngineIncrTokenUse(t *testing.T) {
+	e := newTestRedisEngine()
+	conn := e.shards[0].pool.Get()
+	conn.Do("DEL", e.shards[0].getTokenUseKey("token"))
+	conn.Close()
+
+	uses, err := e.IncrTokenUse("token", time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), uses)
+	uses, err = e.IncrTokenUse("token", time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), uses)
+}
+
+func TestRedisEngineIdempotencyKey(t *testing.T) {
+	e := newTestRedisEngine()
+	assert.NoError(t, e.RemoveIdempotencyKey("channel", "key"))
+	assert.NoError(t, e.RemoveIdempotencyKey("other", "key"))
+
+	status, err := e.SaveIdempotencyKey("channel", "key", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, IdempotencyKeySaved, status)
+	status, err = e.SaveIdempotencyKey("channel", "key", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, IdempotencyKeyPending, status)
+	status, err = e.SaveIdempotencyKey("other", "key", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, IdempotencyKeySaved, status)
+
+	assert.NoError(t, e.ConfirmIdempotencyKey("channel", "key", time.Minute))
+	status, err = e.SaveIdempotencyKey("channel", "key", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, IdempotencyKeyPublished, status)
+
+	assert.NoError(t, e.RemoveIdempotencyKey("channel", "key"))
+	status, err = e.SaveIdempotencyKey("channel", "key", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, IdempotencyKeySaved, status)
+}
+
+func TestRedisEnginePresenceStats(t *testing.T) {
+	e := newTestRedisEngine()
+	assert.NoError(t, e.RemovePresence("presence_stats", "uid1"))
+	assert.NoError(t, e.RemovePresence("presence_stats", "uid2"))
+	assert.NoError(t, e.RemovePresence("presence_stats", "uid3"))
+
+	occupied, err := e.AddPresenceOccupancy("presence_stats", "uid1", &ClientInfo{User: "1"}, 25*time.Second)
+	assert.NoError(t, err)
+	assert.True(t, occupied)
+	occupied, err = e.AddPresenceOccupancy("presence_stats", "uid2", &ClientInfo{User: "1"}, 25*time.Second)
+	assert.NoError(t, err)
+	assert.False(t, occupied)
+	assert.NoError(t, e.AddPresence("presence_stats", "uid3", &ClientInfo{User: "2"}, 25*time.Second))
+
+	stats, err := e.PresenceStats("presence_stats")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stats.NumClients)
+	assert.Equal(t, 2, stats.NumUsers)
+
+	vacated, err := e.RemovePresenceOccupancy("presence_stats", "uid1")
+	assert.NoError(t, err)
+	assert.False(t, vacated)
+	assert.NoError(t, e.RemovePresence("presence_stats", "uid3"))
+	stats, err = e.PresenceStats("presence_stats")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.NumClients)
+	assert.Equal(t, 1, stats.NumUsers)
+
+	vacated, err = e.RemovePresenceOccupancy("presence_stats", "uid2")
+	assert.NoError(t, err)
+	assert.True(t, vacated)
+}
+
+func TestRedisEngineHistorySinceLimit(t *testing.T) {
+	e := newTestRedisEngine()
+	assert.NoError(t, e.RemoveHistory("history_paging"))
+	for i := 0; i < 5; i++ {
+		_, err := e.AddHistory("history_paging", &Publication{Data: Raw("{}")}, &ChannelOptions{HistorySize: 10, HistoryLifetime: 2})
+		assert.NoError(t, err)
+	}
+	_, r, err := e.History("history_paging", HistoryFilter{Limit: 0})
+	assert.NoError(t, err)
+
+	pubs, _, err := e.History("history_paging", HistoryFilter{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 2)
+	assert.Equal(t, r.Seq-4, pubs[0].Seq)
+	assert.Equal(t, r.Seq-3, pubs[1].Seq)
+
+	pubs, _, err = e.History("history_paging", HistoryFilter{
+		Limit: 2,
+		Since: &RecoveryPosition{Seq: pubs[1].Seq, Gen: pubs[1].Gen, Epoch: r.Epoch},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 2)
+	assert.Equal(t, r.Seq-2, pubs[0].Seq)
+	assert.Equal(t, r.Seq-1, pubs[1].Seq)
+
+	pubs, _, err = e.History("history_paging", HistoryFilter{
+		Limit: 2,
+		Since: &RecoveryPosition{Seq: r.Seq, Gen: r.Gen, Epoch: r.Epoch},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 0)
+}
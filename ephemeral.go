@@ -0,0 +1,151 @@
+package centrifuge
+
+import (
+	"sync"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+)
+
+// EphemeralOptions define fields to alter behaviour of PublishEphemeral
+// operation.
+type EphemeralOptions struct {
+	// Info is an optional publisher info attached to publication.
+	Info *ClientInfo
+	// CoalesceKey identifies sender of ephemeral publications, for example
+	// user ID. Publications with the same key published into channel during
+	// CoalesceWindow are coalesced so only the latest one is delivered.
+	CoalesceKey string
+	// CoalesceWindow is a time window to coalesce publications within.
+	CoalesceWindow time.Duration
+}
+
+// EphemeralOption is a type to represent various PublishEphemeral options.
+type EphemeralOption func(*EphemeralOptions)
+
+// WithEphemeralInfo allows to attach publisher info to ephemeral publication.
+func WithEphemeralInfo(info *ClientInfo) EphemeralOption {
+	return func(opts *EphemeralOptions) {
+		opts.Info = info
+	}
+}
+
+// WithCoalesce allows to coalesce ephemeral publications with the same key
+// so that at most one publication per key delivered during window. First
+// publication is published immediately, the latest one published during
+// window is published when window ends.
+func WithCoalesce(key string, window time.Duration) EphemeralOption {
+	return func(opts *EphemeralOptions) {
+		opts.CoalesceKey = key
+		opts.CoalesceWindow = window
+	}
+}
+
+// PublishEphemeral publishes best-effort data into channel. Ephemeral
+// publication is never saved into history, does not take part in recovery
+// and can be dropped for slow clients. This fits well for traffic like
+// typing indicators or cursor positions where only the latest state matters.
+func (n *Node) PublishEphemeral(ch string, data []byte, opts ...EphemeralOption) error {
+	chOpts, ok := n.ChannelOpts(ch)
+	if !ok {
+		return ErrNoChannelOptions
+	}
+
+	ephemeralOpts := &EphemeralOptions{}
+	for _, opt := range opts {
+		opt(ephemeralOpts)
+	}
+
+	pub := &Publication{
+		Data:      data,
+		Info:      ephemeralOpts.Info,
+		Ephemeral: true,
+		Binary:    proto.IsBinaryData(data),
+	}
+
+	if ephemeralOpts.CoalesceKey != "" && ephemeralOpts.CoalesceWindow > 0 {
+		if !n.ephemeral.add(ch, ephemeralOpts.CoalesceKey, pub) {
+			// Window already open, publication will be sent when it ends.
+			return nil
+		}
+		n.scheduleEphemeralFlush(ch, ephemeralOpts.CoalesceKey, ephemeralOpts.CoalesceWindow)
+	}
+	return n.publishEphemeral(ch, pub, &chOpts)
+}
+
+func (n *Node) publishEphemeral(ch string, pub *Publication, chOpts *ChannelOptions) error {
+	messagesSentCount.WithLabelValues("ephemeral").Inc()
+	return n.brokerPublish(ch, pub, chOpts)
+}
+
+func (n *Node) scheduleEphemeralFlush(ch string, key string, window time.Duration) {
+	time.AfterFunc(window, func() {
+		n.flushEphemeral(ch, key, window)
+	})
+}
+
+// flushEphemeral publishes the latest publication coalesced during window.
+// Publishing starts next window so publications keep being coalesced while
+// sender is active.
+func (n *Node) flushEphemeral(ch string, key string, window time.Duration) {
+	pub, ok := n.ephemeral.flush(ch, key)
+	if !ok {
+		return
+	}
+	n.scheduleEphemeralFlush(ch, key, window)
+	chOpts, ok := n.ChannelOpts(ch)
+	if !ok {
+		return
+	}
+	if err := n.publishEphemeral(ch, pub, &chOpts); err != nil {
+		n.logger.log(newLogEntry(LogLevelError, "error publishing ephemeral publication", map[string]interface{}{"channel": ch, "error": err.Error()}))
+	}
+}
+
+type coalesceKey struct {
+	channel string
+	key     string
+}
+
+// pubCoalescer keeps open coalescing windows and the latest
+// publication published during each of them.
+type pubCoalescer struct {
+	mu      sync.Mutex
+	pending map[coalesceKey]*Publication
+}
+
+func newPubCoalescer() *pubCoalescer {
+	return &pubCoalescer{
+		pending: make(map[coalesceKey]*Publication),
+	}
+}
+
+// add returns true if there is no open window for key so publication must
+// be published immediately. Otherwise publication replaces pending one.
+func (c *pubCoalescer) add(ch string, key string, pub *Publication) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	k := coalesceKey{channel: ch, key: key}
+	if _, ok := c.pending[k]; ok {
+		c.pending[k] = pub
+		return false
+	}
+	// Open window without pending publication.
+	c.pending[k] = nil
+	return true
+}
+
+// flush returns pending publication of window. If nothing was published
+// during window it's closed and false returned.
+func (c *pubCoalescer) flush(ch string, key string) (*Publication, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	k := coalesceKey{channel: ch, key: key}
+	pub := c.pending[k]
+	if pub == nil {
+		delete(c.pending, k)
+		return nil, false
+	}
+	c.pending[k] = nil
+	return pub, true
+}
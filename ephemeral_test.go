@@ -0,0 +1,136 @@
+package centrifuge
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPubCoalescer(t *testing.T) {
+	c := newPubCoalescer()
+	assert.True(t, c.add("test", "42", &Publication{Data: []byte(`1`)}))
+	assert.False(t, c.add("test", "42", &Publication{Data: []byte(`2`)}))
+	assert.False(t, c.add("test", "42", &Publication{Data: []byte(`3`)}))
+	// Other keys and channels have their own windows.
+	assert.True(t, c.add("test", "43", &Publication{}))
+	assert.True(t, c.add("other", "42", &Publication{}))
+
+	pub, ok := c.flush("test", "42")
+	assert.True(t, ok)
+	assert.Equal(t, []byte(`3`), []byte(pub.Data))
+
+	// Nothing published during next window so it's closed.
+	_, ok = c.flush("test", "42")
+	assert.False(t, ok)
+	assert.True(t, c.add("test", "42", &Publication{}))
+}
+
+func TestPublishEphemeral(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	config.HistoryRecover = true
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	subscribeClient(t, client, "test")
+
+	assert.NoError(t, node.PublishEphemeral("test", []byte(`{"typing": true}`), WithEphemeralInfo(&ClientInfo{User: "43"})))
+
+	select {
+	case data := <-transport.sink:
+		assert.True(t, strings.Contains(string(data), "typing"))
+		assert.True(t, strings.Contains(string(data), "ephemeral"))
+	case <-time.After(time.Second):
+		t.Fatal("ephemeral publication not delivered")
+	}
+
+	history, err := node.History("test")
+	pubs := history.Publications
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 0)
+
+	// Ephemeral publication does not break recovery position.
+	assert.NoError(t, node.Publish("test", []byte(`{}`)))
+	select {
+	case <-transport.sink:
+	case <-time.After(time.Second):
+		t.Fatal("publication not delivered")
+	}
+	client.mu.RLock()
+	assert.False(t, client.closed)
+	assert.Equal(t, uint32(1), client.channels["test"].recoveryPosition.Seq)
+	client.mu.RUnlock()
+}
+
+func TestPublishEphemeralCoalesce(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	subscribeClient(t, client, "test")
+
+	for i := 1; i <= 3; i++ {
+		data := []byte(`{"pos": ` + strconv.Itoa(i) + `}`)
+		assert.NoError(t, node.PublishEphemeral("test", data, WithCoalesce("43", 50*time.Millisecond)))
+	}
+
+	var received []string
+	timeout := time.After(300 * time.Millisecond)
+loop:
+	for {
+		select {
+		case data := <-transport.sink:
+			received = append(received, string(data))
+		case <-timeout:
+			break loop
+		}
+	}
+	assert.Len(t, received, 2)
+	assert.True(t, strings.Contains(received[0], `"pos":1`))
+	assert.True(t, strings.Contains(received[1], `"pos":3`))
+}
+
+func TestClientWriteEphemeralDropped(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	client.mu.Lock()
+	client.channels["test"] = ChannelContext{}
+	client.mu.Unlock()
+	client.setInSubscribe("test", true)
+
+	chOpts, _ := node.ChannelOpts("test")
+	reply := newPreparedReply(&proto.Reply{}, proto.EncodingJSON)
+	assert.NoError(t, client.writePublication("test", &Publication{Ephemeral: true}, reply, &chOpts))
+
+	select {
+	case <-transport.sink:
+		t.Fatal("ephemeral publication delivered while subscribing")
+	case <-time.After(50 * time.Millisecond):
+	}
+	client.pubBufferMu.Lock()
+	assert.Len(t, client.pubBuffer, 0)
+	client.pubBufferMu.Unlock()
+}
@@ -66,4 +66,27 @@ var (
 		Code:    110,
 		Message: "expired",
 	}
+	// ErrorMuted means that user is muted in channel and can't publish into it.
+	ErrorMuted = &Error{
+		Code:    111,
+		Message: "muted",
+	}
+	// ErrorTooManyRequests means that connection has too many unanswered
+	// commands or too much reply data not sent yet so command was rejected.
+	ErrorTooManyRequests = &Error{
+		Code:    112,
+		Message: "too many requests",
+	}
+	// ErrorConnectionLimitExceeded means that user reached limit of
+	// concurrent connections set by Config.ClientUserConnectionLimit.
+	ErrorConnectionLimitExceeded = &Error{
+		Code:    113,
+		Message: "connection limit exceeded",
+	}
+	// ErrorChannelLimitExceeded means that client reached limit of channels
+	// it can subscribe to set by Config.ClientChannelLimit.
+	ErrorChannelLimitExceeded = &Error{
+		Code:    114,
+		Message: "channel limit exceeded",
+	}
 )
@@ -28,6 +28,18 @@ type ConnectReply struct {
 	Credentials *Credentials
 	// Data allows to set custom data in connect reply.
 	Data Raw
+	// Redirect advises client to connect to one of alternative endpoints
+	// instead of current node, for example to route client to closer region
+	// or to shed load of overloaded node. Client is not authenticated and
+	// disconnected after receiving connect result with redirect.
+	Redirect *Redirect
+	// Subscriptions are channels to subscribe connection to on server side
+	// right after connect reply sent. See Client.Subscribe.
+	Subscriptions []string
+	// ClientSideRefresh tells client to refresh expiring connection itself
+	// sending refresh command with new token. Token is passed to
+	// RefreshHandler if it's set instead of being validated as JWT.
+	ClientSideRefresh bool
 }
 
 // ConnectingHandler called when new client authenticates on server.
@@ -37,18 +49,69 @@ type ConnectingHandler func(context.Context, Transport, ConnectEvent) ConnectRep
 type ConnectedHandler func(context.Context, *Client)
 
 // RefreshEvent contains fields related to refresh event.
-type RefreshEvent struct{}
+type RefreshEvent struct {
+	// ClientSideRefresh is true when refresh initiated by client refresh
+	// command.
+	ClientSideRefresh bool
+	// Token is a token client sent in refresh command.
+	Token string
+}
 
 // RefreshReply contains fields determining the reaction on refresh event.
 type RefreshReply struct {
+	// Expired when set closes connection with DisconnectExpired.
+	Expired bool
+	// ExpireAt is a new connection expiration time as Unix seconds.
 	ExpireAt int64
-	Info     Raw
+	// Info allows to update connection info.
+	Info Raw
 }
 
 // RefreshHandler called when it's time to validate client connection and
 // update it's expiration time.
 type RefreshHandler func(context.Context, *Client, RefreshEvent) RefreshReply
 
+// InactiveSubscribersEvent contains fields related to publication missed
+// by durable channel subscribers.
+type InactiveSubscribersEvent struct {
+	Channel     string
+	Publication *Publication
+	// Users are durable subscribers of channel who were offline when
+	// publication was published.
+	Users []string
+}
+
+// InactiveSubscribersHandler called when durable subscribers of channel
+// missed publication. Handler called in separate goroutine.
+type InactiveSubscribersHandler func(context.Context, InactiveSubscribersEvent)
+
+// ChannelExpiredEvent contains fields related to idle channel expiration.
+type ChannelExpiredEvent struct {
+	Channel string
+}
+
+// ChannelExpiredHandler called after channel with IdleTTL option had no
+// activity during IdleTTL and was purged from engine.
+type ChannelExpiredHandler func(context.Context, ChannelExpiredEvent)
+
+// ChannelPresenceEvent contains fields related to change of channel
+// presence.
+type ChannelPresenceEvent struct {
+	Channel string
+	// Occupied is true when first client joined channel presence and false
+	// when last client left it.
+	Occupied bool
+	// Client and User of connection which joined or left.
+	Client string
+	User   string
+}
+
+// ChannelPresenceHandler called when channel with Presence option gets
+// first client in presence or last client leaves it over all nodes.
+// Presence expired without leave, for example when node crashed, does not
+// produce vacated event. Handler called in separate goroutine.
+type ChannelPresenceHandler func(context.Context, ChannelPresenceEvent)
+
 // DisconnectEvent contains fields related to disconnect event.
 type DisconnectEvent struct {
 	Disconnect *Disconnect
@@ -123,6 +186,9 @@ type SubRefreshHandler func(SubRefreshEvent) SubRefreshReply
 
 // RPCEvent contains fields related to rpc request.
 type RPCEvent struct {
+	// Method is an optional name of RPC method client calls.
+	Method string
+	// Data is an RPC payload.
 	Data Raw
 }
 
@@ -148,3 +214,41 @@ type MessageReply struct {
 
 // MessageHandler must handle incoming async message from client.
 type MessageHandler func(MessageEvent) MessageReply
+
+// QueueOverflowEvent contains fields related to client queue overflow.
+type QueueOverflowEvent struct {
+	// Policy applied to overflowed queue.
+	Policy QueuePolicy
+	// Dropped is a number of messages dropped from queue.
+	Dropped int
+	// Disconnect is set when client disconnected as queue still exceeds limit.
+	Disconnect *Disconnect
+}
+
+// QueueOverflowReply contains fields determining the reaction on queue
+// overflow event.
+type QueueOverflowReply struct{}
+
+// QueueOverflowHandler called when client's message queue exceeded
+// Config.ClientQueueMaxSize.
+type QueueOverflowHandler func(QueueOverflowEvent) QueueOverflowReply
+
+// SurveyEvent contains fields related to survey request.
+type SurveyEvent struct {
+	// Op is an operation name application uses to route survey.
+	Op string
+	// Data is a survey payload.
+	Data Raw
+}
+
+// SurveyReply contains fields of node answer to survey.
+type SurveyReply struct {
+	// Code is an application defined result code.
+	Code uint32
+	// Data is an answer payload.
+	Data Raw
+}
+
+// SurveyHandler called when node receives survey request. Handler of
+// every node answers it so surveying node gets replies from all nodes.
+type SurveyHandler func(SurveyEvent) SurveyReply
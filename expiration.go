@@ -0,0 +1,190 @@
+package centrifuge
+
+import (
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// expirationResolution is a tick of expiration wheel, connection
+	// expires not earlier than its deadline and not later than one tick
+	// after it.
+	expirationResolution = time.Second
+	// expirationSlots is a number of slots in expiration wheel. Deadlines
+	// further than expirationSlots ticks ahead wait for several rounds.
+	expirationSlots = 512
+	// expirationBatchSize is a max number of connections expired at once.
+	expirationBatchSize = 256
+	// expirationMaxJitter is a max pause between expiration batches so
+	// refresh proxy and engine are not hit by all connections at once.
+	expirationMaxJitter = 50 * time.Millisecond
+	// expirationMetricsMinutes is a number of minutes ahead expirations
+	// are reported for.
+	expirationMetricsMinutes = 5
+)
+
+type expirationEntry struct {
+	slot     int
+	deadline time.Time
+}
+
+// expirationWheel is a hashed timing wheel keeping connection expiration
+// deadlines. One wheel per node replaces per-connection timers: adding
+// and removing deadline is O(1) and due connections are collected once
+// per tick.
+type expirationWheel struct {
+	mu         sync.Mutex
+	resolution time.Duration
+	slots      []map[*Client]struct{}
+	entries    map[*Client]expirationEntry
+	// pos is a slot processed on last tick.
+	pos int
+	// current is a time of last tick.
+	current time.Time
+}
+
+func newExpirationWheel(resolution time.Duration, numSlots int, now time.Time) *expirationWheel {
+	slots := make([]map[*Client]struct{}, numSlots)
+	for i := range slots {
+		slots[i] = make(map[*Client]struct{})
+	}
+	return &expirationWheel{
+		resolution: resolution,
+		slots:      slots,
+		entries:    make(map[*Client]expirationEntry),
+		current:    now,
+	}
+}
+
+// add sets expiration deadline of connection replacing previous one.
+func (w *expirationWheel) add(c *Client, deadline time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.removeUnsafe(c)
+	ticks := int((deadline.Sub(w.current) + w.resolution - 1) / w.resolution)
+	if ticks < 1 {
+		ticks = 1
+	}
+	slot := (w.pos + ticks) % len(w.slots)
+	w.slots[slot][c] = struct{}{}
+	w.entries[c] = expirationEntry{slot: slot, deadline: deadline}
+}
+
+// remove removes expiration deadline of connection.
+func (w *expirationWheel) remove(c *Client) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.removeUnsafe(c)
+}
+
+func (w *expirationWheel) removeUnsafe(c *Client) {
+	entry, ok := w.entries[c]
+	if !ok {
+		return
+	}
+	delete(w.slots[entry.slot], c)
+	delete(w.entries, c)
+}
+
+// advance moves wheel to now and returns connections which deadline
+// passed. Returned connections are removed from wheel.
+func (w *expirationWheel) advance(now time.Time) []*Client {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var due []*Client
+	ticks := 0
+	for !w.current.Add(w.resolution).After(now) {
+		w.current = w.current.Add(w.resolution)
+		w.pos = (w.pos + 1) % len(w.slots)
+		ticks++
+		if ticks > len(w.slots) {
+			// All slots already visited, just catch up with time.
+			continue
+		}
+		for c := range w.slots[w.pos] {
+			if w.entries[c].deadline.After(now) {
+				// Deadline is in one of next rounds.
+				continue
+			}
+			due = append(due, c)
+			delete(w.slots[w.pos], c)
+			delete(w.entries, c)
+		}
+	}
+	return due
+}
+
+// upcoming returns number of connections expiring in each of next
+// minutes after now.
+func (w *expirationWheel) upcoming(now time.Time, minutes int) []int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	counts := make([]int, minutes)
+	for _, entry := range w.entries {
+		minute := int(entry.deadline.Sub(now) / time.Minute)
+		if minute < 0 {
+			minute = 0
+		}
+		if minute < minutes {
+			counts[minute]++
+		}
+	}
+	return counts
+}
+
+// runExpirations expires connections which deadline passed until node
+// shutdown.
+func (n *Node) runExpirations() {
+	ticker := time.NewTicker(n.expirations.resolution)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.shutdownCh:
+			return
+		case now := <-ticker.C:
+			n.expireBatches(n.expirations.advance(now))
+		}
+	}
+}
+
+// expireBatches expires connections in batches with random pause between
+// batches.
+func (n *Node) expireBatches(clients []*Client) {
+	for len(clients) > 0 {
+		batch := clients
+		if len(batch) > expirationBatchSize {
+			batch = batch[:expirationBatchSize]
+		}
+		clients = clients[len(batch):]
+
+		var wg sync.WaitGroup
+		wg.Add(len(batch))
+		for _, c := range batch {
+			go func(c *Client) {
+				defer wg.Done()
+				c.expire()
+			}(c)
+		}
+		wg.Wait()
+
+		if len(clients) == 0 {
+			return
+		}
+		select {
+		case <-n.shutdownCh:
+			return
+		case <-time.After(time.Duration(rand.Int63n(int64(expirationMaxJitter)))):
+		}
+	}
+}
+
+// updateExpirationGauges reports number of connections expiring in
+// upcoming minutes.
+func (n *Node) updateExpirationGauges() {
+	counts := n.expirations.upcoming(time.Now(), expirationMetricsMinutes)
+	for i, count := range counts {
+		upcomingExpirationsGauge.WithLabelValues(strconv.Itoa(i + 1)).Set(float64(count))
+	}
+}
@@ -0,0 +1,82 @@
+package centrifuge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpirationWheel(t *testing.T) {
+	now := time.Now()
+	w := newExpirationWheel(time.Second, 8, now)
+	c1 := &Client{}
+	c2 := &Client{}
+	c3 := &Client{}
+	w.add(c1, now.Add(2*time.Second))
+	w.add(c2, now.Add(3*time.Second))
+	// Deadline after full wheel round.
+	w.add(c3, now.Add(11*time.Second))
+
+	assert.Len(t, w.advance(now.Add(time.Second)), 0)
+	assert.Equal(t, []*Client{c1}, w.advance(now.Add(2*time.Second)))
+	assert.Equal(t, []*Client{c2}, w.advance(now.Add(3*time.Second)))
+	assert.Len(t, w.advance(now.Add(10*time.Second)), 0)
+	assert.Equal(t, []*Client{c3}, w.advance(now.Add(11*time.Second)))
+	assert.Len(t, w.entries, 0)
+}
+
+func TestExpirationWheelRemove(t *testing.T) {
+	now := time.Now()
+	w := newExpirationWheel(time.Second, 8, now)
+	c1 := &Client{}
+	c2 := &Client{}
+	w.add(c1, now.Add(time.Second))
+	w.add(c2, now.Add(time.Second))
+	w.remove(c1)
+	// Adding again replaces previous deadline.
+	w.add(c2, now.Add(5*time.Second))
+	assert.Len(t, w.advance(now.Add(4*time.Second)), 0)
+	assert.Equal(t, []*Client{c2}, w.advance(now.Add(5*time.Second)))
+}
+
+func TestExpirationWheelCatchUp(t *testing.T) {
+	now := time.Now()
+	w := newExpirationWheel(time.Second, 4, now)
+	c1 := &Client{}
+	c2 := &Client{}
+	w.add(c1, now.Add(2*time.Second))
+	w.add(c2, now.Add(100*time.Second))
+	assert.Len(t, w.advance(now.Add(50*time.Second)), 1)
+	assert.Len(t, w.advance(now.Add(99*time.Second)), 0)
+	assert.Len(t, w.advance(now.Add(100*time.Second)), 1)
+}
+
+func TestExpirationWheelUpcoming(t *testing.T) {
+	now := time.Now()
+	w := newExpirationWheel(time.Second, 8, now)
+	w.add(&Client{}, now.Add(10*time.Second))
+	w.add(&Client{}, now.Add(20*time.Second))
+	w.add(&Client{}, now.Add(90*time.Second))
+	w.add(&Client{}, now.Add(time.Hour))
+	assert.Equal(t, []int{2, 1, 0}, w.upcoming(now, 3))
+}
+
+func TestClientExpired(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientExpiredCloseDelay = 0
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42", ExpireAt: time.Now().Unix() + 1})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	assert.Len(t, node.expirations.entries, 1)
+
+	disconnect := waitTransportClosed(t, transport)
+	assert.Equal(t, DisconnectExpired, disconnect)
+	assert.Len(t, node.expirations.entries, 0)
+}
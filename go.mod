@@ -5,11 +5,18 @@ go 1.12
 require (
 	github.com/FZambia/eagle v0.0.1
 	github.com/FZambia/sentinel v1.0.0
+	github.com/Shopify/sarama v1.22.0
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
+	github.com/eclipse/paho.mqtt.golang v1.2.0
 	github.com/gogo/protobuf v1.2.1
 	github.com/gomodule/redigo v2.0.0+incompatible
 	github.com/gorilla/websocket v1.4.0
 	github.com/igm/sockjs-go v0.0.0-20180629114527-4e63e74d3787
+	github.com/jackc/pgx v3.6.0+incompatible
+	github.com/nats-io/nats.go v1.9.1
+	github.com/pkg/errors v0.9.1 // indirect
 	github.com/prometheus/client_golang v0.9.2
+	github.com/streadway/amqp v0.0.0-20190404075320-75d898a42a94
 	github.com/stretchr/testify v1.3.0
+	google.golang.org/grpc v1.20.0
 )
@@ -0,0 +1,310 @@
+package centrifuge
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/centrifugal/centrifuge/apiproto"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultAdminStatsInterval = 5 * time.Second
+	defaultAdminNumChannels   = 20
+	defaultAdminNumErrors     = 20
+	adminAuthTimeout          = 10 * time.Second
+	adminWriteTimeout         = time.Second
+)
+
+// AdminConfig for AdminHandler.
+type AdminConfig struct {
+	// Key is an admin key. Connection must send auth command with this key
+	// as first message: {"method": "auth", "params": {"key": "<Key>"}}.
+	Key string
+	// StatsInterval is how often stats pushed to admin connections, 5
+	// seconds by default.
+	StatsInterval time.Duration
+	// NumChannels is a max number of most active channels in stats, 20 by default.
+	NumChannels int
+	// NumErrors is a max number of recent errors kept and pushed in stats,
+	// 20 by default.
+	NumErrors int
+	// ReadBufferSize is a parameter that is used for raw websocket Upgrader.
+	ReadBufferSize int
+	// WriteBufferSize is a parameter that is used for raw websocket Upgrader.
+	WriteBufferSize int
+	// CheckOrigin func to provide custom origin check logic.
+	// nil means allow all origins.
+	CheckOrigin func(r *http.Request) bool
+}
+
+// AdminHandler handles admin Websocket connections. After authentication
+// connection receives periodic stats pushes like:
+// {"type": "stats", "data": {"time": 1556198400, "num_clients": 10, ...}}
+// Admin connection can also send the same commands as supported by
+// APIHandler (for example disconnect, unsubscribe, presence, history,
+// channels, info) and receives replies to them.
+type AdminHandler struct {
+	node   *Node
+	config AdminConfig
+	api    *APIHandler
+
+	mu    sync.RWMutex
+	conns map[*adminConn]struct{}
+
+	errorsMu     sync.Mutex
+	recentErrors []adminError
+}
+
+// NewAdminHandler creates new AdminHandler and starts collecting stats.
+func NewAdminHandler(n *Node, c AdminConfig) *AdminHandler {
+	if c.StatsInterval == 0 {
+		c.StatsInterval = defaultAdminStatsInterval
+	}
+	if c.NumChannels == 0 {
+		c.NumChannels = defaultAdminNumChannels
+	}
+	if c.NumErrors == 0 {
+		c.NumErrors = defaultAdminNumErrors
+	}
+	h := &AdminHandler{
+		node:   n,
+		config: c,
+		api:    NewAPIHandler(n, APIConfig{}),
+		conns:  make(map[*adminConn]struct{}),
+	}
+	n.activity.enable()
+	n.logger.addErrorHandler(h.handleError)
+	go h.runStats()
+	return h
+}
+
+type adminError struct {
+	Time    int64                  `json:"time"`
+	Message string                 `json:"message"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+type adminChannelStats struct {
+	Channel      string `json:"channel"`
+	Publications int    `json:"publications"`
+	Subscribers  int    `json:"subscribers"`
+}
+
+type adminStats struct {
+	Time        int64                `json:"time"`
+	Info        *apiproto.InfoResult `json:"info,omitempty"`
+	NumClients  int                  `json:"num_clients"`
+	NumUsers    int                  `json:"num_users"`
+	NumChannels int                  `json:"num_channels"`
+	// Channels are most active channels of node during last interval.
+	Channels []adminChannelStats `json:"channels"`
+	Errors   []adminError        `json:"errors"`
+}
+
+type adminPush struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+type adminConn struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+func (c *adminConn) write(v interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(adminWriteTimeout))
+	return c.conn.WriteJSON(v)
+}
+
+func (h *AdminHandler) handleError(entry LogEntry) {
+	h.errorsMu.Lock()
+	defer h.errorsMu.Unlock()
+	h.recentErrors = append(h.recentErrors, adminError{
+		Time:    time.Now().Unix(),
+		Message: entry.Message,
+		Fields:  entry.Fields,
+	})
+	if len(h.recentErrors) > h.config.NumErrors {
+		h.recentErrors = h.recentErrors[len(h.recentErrors)-h.config.NumErrors:]
+	}
+}
+
+func (h *AdminHandler) runStats() {
+	for {
+		select {
+		case <-h.node.NotifyShutdown():
+			return
+		case <-time.After(h.config.StatsInterval):
+		}
+		stats := h.stats()
+		h.mu.RLock()
+		conns := make([]*adminConn, 0, len(h.conns))
+		for c := range h.conns {
+			conns = append(conns, c)
+		}
+		h.mu.RUnlock()
+		for _, c := range conns {
+			if err := c.write(adminPush{Type: "stats", Data: stats}); err != nil {
+				c.conn.Close()
+			}
+		}
+	}
+}
+
+func (h *AdminHandler) stats() *adminStats {
+	stats := &adminStats{
+		Time:        time.Now().Unix(),
+		NumClients:  h.node.hub.NumClients(),
+		NumUsers:    h.node.hub.NumUsers(),
+		NumChannels: h.node.hub.NumChannels(),
+	}
+	if resp := h.api.api.Info(&apiproto.InfoRequest{}); resp.Error == nil {
+		stats.Info = resp.Result
+	}
+
+	counts := h.node.activity.reset()
+	channels := make([]adminChannelStats, 0, len(counts))
+	for ch, count := range counts {
+		channels = append(channels, adminChannelStats{
+			Channel:      ch,
+			Publications: count,
+			Subscribers:  h.node.numSubscribers(ch),
+		})
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		if channels[i].Publications == channels[j].Publications {
+			return channels[i].Channel < channels[j].Channel
+		}
+		return channels[i].Publications > channels[j].Publications
+	})
+	if len(channels) > h.config.NumChannels {
+		channels = channels[:h.config.NumChannels]
+	}
+	stats.Channels = channels
+
+	h.errorsMu.Lock()
+	stats.Errors = make([]adminError, len(h.recentErrors))
+	copy(stats.Errors, h.recentErrors)
+	h.errorsMu.Unlock()
+	return stats
+}
+
+type adminAuthParams struct {
+	Key string `json:"key"`
+}
+
+func (h *AdminHandler) authenticate(conn *websocket.Conn) bool {
+	conn.SetReadDeadline(time.Now().Add(adminAuthTimeout))
+	defer conn.SetReadDeadline(time.Time{})
+	var cmd apiCommand
+	if err := conn.ReadJSON(&cmd); err != nil || cmd.Method != "auth" {
+		return false
+	}
+	var params adminAuthParams
+	if err := json.Unmarshal(cmd.Params, &params); err != nil {
+		return false
+	}
+	if h.config.Key == "" || subtle.ConstantTimeCompare([]byte(params.Key), []byte(h.config.Key)) != 1 {
+		return false
+	}
+	conn.SetWriteDeadline(time.Now().Add(adminWriteTimeout))
+	return conn.WriteJSON(&apiReply{ID: cmd.ID, Result: struct{}{}}) == nil
+}
+
+func (h *AdminHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  h.config.ReadBufferSize,
+		WriteBufferSize: h.config.WriteBufferSize,
+	}
+	if h.config.CheckOrigin != nil {
+		upgrader.CheckOrigin = h.config.CheckOrigin
+	} else {
+		upgrader.CheckOrigin = func(r *http.Request) bool {
+			// Allow all connections.
+			return true
+		}
+	}
+
+	conn, err := upgrader.Upgrade(rw, r, nil)
+	if err != nil {
+		h.node.logger.log(newLogEntry(LogLevelDebug, "websocket upgrade error", map[string]interface{}{"error": err.Error()}))
+		return
+	}
+	defer conn.Close()
+
+	if !h.authenticate(conn) {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "unauthorized"), time.Now().Add(adminWriteTimeout))
+		return
+	}
+
+	c := &adminConn{conn: conn}
+	h.mu.Lock()
+	h.conns[c] = struct{}{}
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.conns, c)
+		h.mu.Unlock()
+	}()
+
+	if err := c.write(adminPush{Type: "stats", Data: h.stats()}); err != nil {
+		return
+	}
+
+	for {
+		var cmd apiCommand
+		if err := conn.ReadJSON(&cmd); err != nil {
+			return
+		}
+		reply := h.api.handleCommand(&cmd)
+		reply.ID = cmd.ID
+		if err := c.write(reply); err != nil {
+			return
+		}
+	}
+}
+
+// channelActivity counts publications delivered to channels of node. It's
+// only enabled when admin handler used.
+type channelActivity struct {
+	enabled int32
+	mu      sync.Mutex
+	counts  map[string]int
+}
+
+func newChannelActivity() *channelActivity {
+	return &channelActivity{
+		counts: make(map[string]int),
+	}
+}
+
+func (a *channelActivity) enable() {
+	atomic.StoreInt32(&a.enabled, 1)
+}
+
+func (a *channelActivity) record(ch string) {
+	if atomic.LoadInt32(&a.enabled) == 0 {
+		return
+	}
+	a.mu.Lock()
+	a.counts[ch]++
+	a.mu.Unlock()
+}
+
+// reset returns collected counts and starts collecting from scratch.
+func (a *channelActivity) reset() map[string]int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	counts := a.counts
+	a.counts = make(map[string]int)
+	return counts
+}
@@ -0,0 +1,91 @@
+package centrifuge
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAdminMessage struct {
+	ID     uint32          `json:"id"`
+	Type   string          `json:"type"`
+	Data   json.RawMessage `json:"data"`
+	Error  json.RawMessage `json:"error"`
+	Result json.RawMessage `json:"result"`
+}
+
+func newTestAdminConn(t *testing.T, n *Node, key string) (*websocket.Conn, func()) {
+	server := httptest.NewServer(NewAdminHandler(n, AdminConfig{
+		Key:           "secret",
+		StatsInterval: 20 * time.Millisecond,
+	}))
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, conn.WriteJSON(map[string]interface{}{"id": 1, "method": "auth", "params": map[string]string{"key": key}}))
+	return conn, func() {
+		conn.Close()
+		server.Close()
+	}
+}
+
+func TestAdminHandlerUnauthorized(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	conn, stop := newTestAdminConn(t, n, "wrong")
+	defer stop()
+	var msg testAdminMessage
+	err := conn.ReadJSON(&msg)
+	assert.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation))
+}
+
+func TestAdminHandler(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	conn, stop := newTestAdminConn(t, n, "secret")
+	defer stop()
+
+	var msg testAdminMessage
+	assert.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, uint32(1), msg.ID)
+	assert.Equal(t, `{}`, string(msg.Result))
+
+	msg = testAdminMessage{}
+	assert.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, "stats", msg.Type)
+
+	assert.NoError(t, n.Publish("test", []byte(`{}`)))
+	assert.NoError(t, n.Publish("test", []byte(`{}`)))
+	n.logger.log(newLogEntry(LogLevelError, "test error"))
+
+	var stats adminStats
+	for i := 0; i < 10; i++ {
+		msg = testAdminMessage{}
+		assert.NoError(t, conn.ReadJSON(&msg))
+		assert.Equal(t, "stats", msg.Type)
+		stats = adminStats{}
+		assert.NoError(t, json.Unmarshal(msg.Data, &stats))
+		if len(stats.Channels) > 0 {
+			break
+		}
+	}
+	assert.Equal(t, []adminChannelStats{{Channel: "test", Publications: 2}}, stats.Channels)
+	assert.Len(t, stats.Errors, 1)
+	assert.Equal(t, "test error", stats.Errors[0].Message)
+	assert.Len(t, stats.Info.Nodes, 1)
+
+	assert.NoError(t, conn.WriteJSON(map[string]interface{}{"id": 2, "method": "disconnect", "params": map[string]string{"user": "42"}}))
+	for {
+		msg = testAdminMessage{}
+		assert.NoError(t, conn.ReadJSON(&msg))
+		if msg.Type == "" {
+			break
+		}
+	}
+	assert.Equal(t, uint32(2), msg.ID)
+	assert.Nil(t, msg.Error)
+	assert.Equal(t, `{}`, string(msg.Result))
+}
@@ -0,0 +1,290 @@
+package centrifuge
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+)
+
+const (
+	transportHTTPStream = "http_stream"
+)
+
+// streamConnectCommandID is an ID of internal connect command issued when
+// unidirectional connection established.
+const streamConnectCommandID = 1
+
+// streamFormat describes how unidirectional transport frames messages
+// written into HTTP response.
+type streamFormat struct {
+	// message frames single JSON encoded protocol message.
+	message func([]byte) []byte
+	// disconnect frames disconnect advice sent before closing response.
+	disconnect func([]byte) []byte
+	// ping frames keep-alive message.
+	ping []byte
+}
+
+// streamTransport is a unidirectional transport which writes protocol
+// messages into streaming HTTP response. Client can't send commands over
+// it: connection is authenticated using initial request and subscribed to
+// channels on server side.
+type streamTransport struct {
+	mu        sync.Mutex
+	name      string
+	rw        http.ResponseWriter
+	flusher   http.Flusher
+	req       *http.Request
+	format    streamFormat
+	closed    bool
+	closeCh   chan struct{}
+	pingTimer *time.Timer
+	interval  time.Duration
+}
+
+func newStreamTransport(name string, rw http.ResponseWriter, flusher http.Flusher, req *http.Request, format streamFormat, pingInterval time.Duration) *streamTransport {
+	transport := &streamTransport{
+		name:     name,
+		rw:       rw,
+		flusher:  flusher,
+		req:      req,
+		format:   format,
+		closeCh:  make(chan struct{}),
+		interval: pingInterval,
+	}
+	if pingInterval > 0 {
+		transport.addPing()
+	}
+	return transport
+}
+
+func (t *streamTransport) ping() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	if err := t.writeUnsafe(t.format.ping); err != nil {
+		go t.Close(DisconnectServerError)
+		return
+	}
+	t.pingTimer = time.AfterFunc(t.interval, t.ping)
+}
+
+func (t *streamTransport) addPing() {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return
+	}
+	t.pingTimer = time.AfterFunc(t.interval, t.ping)
+	t.mu.Unlock()
+}
+
+func (t *streamTransport) Name() string {
+	return t.name
+}
+
+func (t *streamTransport) Encoding() proto.Encoding {
+	return proto.EncodingJSON
+}
+
+func (t *streamTransport) Info() TransportInfo {
+	return TransportInfo{
+		Request: t.req,
+	}
+}
+
+func (t *streamTransport) writeUnsafe(data []byte) error {
+	if _, err := t.rw.Write(data); err != nil {
+		return err
+	}
+	t.flusher.Flush()
+	return nil
+}
+
+// Write accepts newline delimited JSON messages and writes each of them
+// as separate stream message.
+func (t *streamTransport) Write(data []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	var buf bytes.Buffer
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		buf.Write(t.format.message(line))
+	}
+	return t.writeUnsafe(buf.Bytes())
+}
+
+// Close marks transport closed. Response must not be used after Close so
+// handler waits for it before returning.
+func (t *streamTransport) Close(disconnect *Disconnect) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	if t.pingTimer != nil {
+		t.pingTimer.Stop()
+	}
+	defer close(t.closeCh)
+	if disconnect != nil && disconnect != DisconnectNormal {
+		data, err := json.Marshal(disconnect)
+		if err != nil {
+			return err
+		}
+		return t.writeUnsafe(t.format.disconnect(data))
+	}
+	return nil
+}
+
+// streamConnectRequest builds connect request from initial HTTP request.
+// POST requests contain JSON encoded connect request in body, GET requests
+// can pass connection token in token query parameter.
+func streamConnectRequest(r *http.Request, maxSize int) (*proto.ConnectRequest, error) {
+	req := &proto.ConnectRequest{}
+	if r.Method != http.MethodPost {
+		req.Token = r.URL.Query().Get("token")
+		return req, nil
+	}
+	var body io.Reader = r.Body
+	if maxSize > 0 {
+		body = io.LimitReader(r.Body, int64(maxSize)+1)
+	}
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if maxSize > 0 && len(data) > maxSize {
+		return nil, errors.New("connect request too large")
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return req, nil
+	}
+	if err := json.Unmarshal(data, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// serveStream authenticates unidirectional connection and keeps response
+// open until connection closed by server or client went away.
+func serveStream(n *Node, rw http.ResponseWriter, r *http.Request, name string, format streamFormat, pingInterval time.Duration) {
+	transportConnectCount.WithLabelValues(name).Inc()
+
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		n.logger.log(newLogEntry(LogLevelError, "response streaming not supported", map[string]interface{}{"transport": name}))
+		rw.WriteHeader(http.StatusNotImplemented)
+		return
+	}
+
+	config := n.Config()
+	if pingInterval == 0 {
+		pingInterval = config.ClientPingInterval
+	}
+
+	connectRequest, err := streamConnectRequest(r, config.ClientRequestMaxSize)
+	if err != nil {
+		n.logger.log(newLogEntry(LogLevelInfo, "error decoding connect request", map[string]interface{}{"transport": name, "error": err.Error()}))
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	select {
+	case <-n.NotifyShutdown():
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		return
+	default:
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	transport := newStreamTransport(name, rw, flusher, r, format, pingInterval)
+	// Response must not be written after handler returned.
+	defer transport.Close(nil)
+
+	c, err := newClient(r.Context(), n, transport)
+	if err != nil {
+		n.logger.log(newLogEntry(LogLevelError, "error creating client", map[string]interface{}{"transport": name}))
+		return
+	}
+	n.logger.log(newLogEntry(LogLevelDebug, "client connection established", map[string]interface{}{"client": c.ID(), "transport": name}))
+	defer func(started time.Time) {
+		n.logger.log(newLogEntry(LogLevelDebug, "client connection completed", map[string]interface{}{"client": c.ID(), "transport": name, "duration": time.Since(started)}))
+	}(time.Now())
+	defer c.Close(nil)
+
+	connectParams, _ := json.Marshal(connectRequest)
+	cmd, _ := json.Marshal(&proto.Command{
+		ID:     streamConnectCommandID,
+		Method: proto.MethodTypeConnect,
+		Params: connectParams,
+	})
+	if ok := c.handleRawData(cmd); !ok {
+		return
+	}
+
+	select {
+	case <-transport.closeCh:
+	case <-r.Context().Done():
+	}
+}
+
+// HTTPStreamConfig represents config for HTTPStreamHandler.
+type HTTPStreamConfig struct {
+	// PingInterval is an interval of empty lines sent to keep connection
+	// alive. Zero value means Config.ClientPingInterval used.
+	PingInterval time.Duration
+}
+
+// HTTPStreamHandler handles unidirectional client connections streaming
+// newline delimited JSON protocol messages in HTTP response body. Messages
+// are the same as WebSocket transport sends: connect reply followed by
+// pushes, lines with disconnect object sent before server closes
+// connection. Connect request is taken from POST request body or token
+// query parameter. Client can't send commands so it should be subscribed
+// to channels on server side, for example using ConnectReply.Subscriptions.
+type HTTPStreamHandler struct {
+	node   *Node
+	config HTTPStreamConfig
+}
+
+// NewHTTPStreamHandler creates new HTTPStreamHandler.
+func NewHTTPStreamHandler(n *Node, c HTTPStreamConfig) *HTTPStreamHandler {
+	return &HTTPStreamHandler{
+		node:   n,
+		config: c,
+	}
+}
+
+var httpStreamFormat = streamFormat{
+	message: func(data []byte) []byte {
+		return append(append([]byte{}, data...), '\n')
+	},
+	disconnect: func(data []byte) []byte {
+		return append(append([]byte(`{"disconnect":`), data...), '}', '\n')
+	},
+	ping: []byte("\n"),
+}
+
+func (s *HTTPStreamHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "application/x-ndjson")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("X-Accel-Buffering", "no")
+	serveStream(s.node, rw, r, transportHTTPStream, httpStreamFormat, s.config.PingInterval)
+}
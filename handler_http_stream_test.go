@@ -0,0 +1,58 @@
+package centrifuge
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readStreamLine(t *testing.T, r *bufio.Reader) string {
+	for {
+		line, err := r.ReadString('\n')
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+}
+
+func TestHTTPStreamHandler(t *testing.T) {
+	n := nodeWithStreamSubscriptions()
+	server := httptest.NewServer(NewHTTPStreamHandler(n, HTTPStreamConfig{}))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(`{"token":"secret"}`))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	r := bufio.NewReader(resp.Body)
+	assert.Contains(t, readStreamLine(t, r), `"id":1`)
+	assert.Contains(t, readStreamLine(t, r), `"type":7`)
+
+	assert.NoError(t, n.Publish("test", []byte(`{"text":"hello"}`)))
+	line := readStreamLine(t, r)
+	assert.Contains(t, line, `"channel":"test"`)
+	assert.Contains(t, line, `{"text":"hello"}`)
+
+	assert.NoError(t, n.Disconnect("42", WithReconnect(true)))
+	assert.True(t, strings.HasPrefix(readStreamLine(t, r), `{"disconnect":{`))
+}
+
+func TestHTTPStreamHandlerBadRequest(t *testing.T) {
+	n := nodeWithStreamSubscriptions()
+	server := httptest.NewServer(NewHTTPStreamHandler(n, HTTPStreamConfig{}))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(`{`))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,642 @@
+package centrifuge
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/centrifugal/centrifuge/internal/uuid"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	transportSignalR = "signalr"
+)
+
+// SignalR JSON hub protocol message types.
+const (
+	signalrMessageTypeInvocation       = 1
+	signalrMessageTypeStreamItem       = 2
+	signalrMessageTypeCompletion       = 3
+	signalrMessageTypeStreamInvocation = 4
+	signalrMessageTypeCancelInvocation = 5
+	signalrMessageTypePing             = 6
+	signalrMessageTypeClose            = 7
+)
+
+// signalrRecordSeparator terminates every SignalR JSON hub protocol message.
+const signalrRecordSeparator = 0x1e
+
+// Hub method names client can invoke. Invocations of any other hub method
+// with invocation ID set are sent to server as RPC, invocations without
+// invocation ID (non-blocking) are sent as asynchronous messages.
+const (
+	signalrTargetSubscribe     = "Subscribe"
+	signalrTargetUnsubscribe   = "Unsubscribe"
+	signalrTargetPublish       = "Publish"
+	signalrTargetPresence      = "Presence"
+	signalrTargetPresenceStats = "PresenceStats"
+	signalrTargetHistory       = "History"
+)
+
+// Client hub methods server invokes to deliver asynchronous pushes.
+const (
+	signalrClientTargetPublication = "publication"
+	signalrClientTargetJoin        = "join"
+	signalrClientTargetLeave       = "leave"
+	signalrClientTargetUnsub       = "unsub"
+	signalrClientTargetMessage     = "message"
+)
+
+// signalrConnectCommandID is an ID of internal connect command issued after
+// successful SignalR handshake. IDs for invocations start after it.
+const signalrConnectCommandID = 1
+
+type signalrHandshakeRequest struct {
+	Protocol string `json:"protocol"`
+	Version  int    `json:"version"`
+}
+
+type signalrHandshakeResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+type signalrMessage struct {
+	Type           int               `json:"type"`
+	InvocationID   string            `json:"invocationId,omitempty"`
+	Target         string            `json:"target,omitempty"`
+	Arguments      []json.RawMessage `json:"arguments,omitempty"`
+	Result         json.RawMessage   `json:"result,omitempty"`
+	Error          string            `json:"error,omitempty"`
+	AllowReconnect bool              `json:"allowReconnect,omitempty"`
+}
+
+// signalrRPCData is a payload of RPC request made from SignalR invocation.
+type signalrRPCData struct {
+	Target    string            `json:"target"`
+	Arguments []json.RawMessage `json:"arguments"`
+}
+
+type signalrInvocation struct {
+	invocationID string
+	method       proto.MethodType
+}
+
+// signalrTransport translates Centrifuge JSON protocol replies written by
+// client into SignalR JSON hub protocol messages.
+type signalrTransport struct {
+	mu        sync.RWMutex
+	writeMu   sync.Mutex
+	conn      *websocket.Conn
+	req       *http.Request
+	closed    bool
+	closeCh   chan struct{}
+	opts      *websocketTransportOptions
+	pingTimer *time.Timer
+
+	commandID   uint32
+	invocations map[uint32]signalrInvocation
+}
+
+func newSignalRTransport(conn *websocket.Conn, req *http.Request, opts *websocketTransportOptions) *signalrTransport {
+	return &signalrTransport{
+		conn:        conn,
+		req:         req,
+		closeCh:     make(chan struct{}),
+		opts:        opts,
+		commandID:   signalrConnectCommandID,
+		invocations: make(map[uint32]signalrInvocation),
+	}
+}
+
+func (t *signalrTransport) ping() {
+	select {
+	case <-t.closeCh:
+		return
+	default:
+		err := t.writeMessages(&signalrMessage{Type: signalrMessageTypePing})
+		if err != nil {
+			t.Close(DisconnectServerError)
+			return
+		}
+		t.addPing()
+	}
+}
+
+func (t *signalrTransport) addPing() {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return
+	}
+	t.pingTimer = time.AfterFunc(t.opts.pingInterval, t.ping)
+	t.mu.Unlock()
+}
+
+func (t *signalrTransport) Name() string {
+	return transportSignalR
+}
+
+func (t *signalrTransport) Encoding() proto.Encoding {
+	return proto.EncodingJSON
+}
+
+func (t *signalrTransport) Info() TransportInfo {
+	return TransportInfo{
+		Request: t.req,
+	}
+}
+
+func (t *signalrTransport) addInvocation(invocationID string, method proto.MethodType) uint32 {
+	id := atomic.AddUint32(&t.commandID, 1)
+	t.mu.Lock()
+	t.invocations[id] = signalrInvocation{invocationID: invocationID, method: method}
+	t.mu.Unlock()
+	return id
+}
+
+func (t *signalrTransport) popInvocation(id uint32) (signalrInvocation, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	invocation, ok := t.invocations[id]
+	if ok {
+		delete(t.invocations, id)
+	}
+	return invocation, ok
+}
+
+// Write accepts newline delimited JSON replies and converts them to
+// SignalR hub protocol messages.
+func (t *signalrTransport) Write(data []byte) error {
+	select {
+	case <-t.closeCh:
+		return nil
+	default:
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var reply proto.Reply
+		err := json.Unmarshal(line, &reply)
+		if err != nil {
+			return err
+		}
+		msg, err := t.convertReply(&reply)
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			continue
+		}
+		encoded, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+		buf.WriteByte(signalrRecordSeparator)
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	return t.write(buf.Bytes())
+}
+
+func (t *signalrTransport) convertReply(reply *proto.Reply) (interface{}, error) {
+	if reply.ID == signalrConnectCommandID {
+		if reply.Error != nil {
+			return &signalrHandshakeResponse{Error: reply.Error.Message}, nil
+		}
+		return &signalrHandshakeResponse{}, nil
+	}
+
+	if reply.ID > 0 {
+		invocation, ok := t.popInvocation(reply.ID)
+		if !ok || invocation.invocationID == "" {
+			// Non-blocking invocation – client does not expect completion.
+			return nil, nil
+		}
+		msg := &signalrMessage{
+			Type:         signalrMessageTypeCompletion,
+			InvocationID: invocation.invocationID,
+		}
+		if reply.Error != nil {
+			msg.Error = reply.Error.Message
+			return msg, nil
+		}
+		if invocation.method == proto.MethodTypeRPC && len(reply.Result) > 0 {
+			var res proto.RPCResult
+			err := json.Unmarshal(reply.Result, &res)
+			if err != nil {
+				return nil, err
+			}
+			msg.Result = json.RawMessage(res.Data)
+			return msg, nil
+		}
+		if len(reply.Result) > 0 {
+			msg.Result = json.RawMessage(reply.Result)
+		}
+		return msg, nil
+	}
+
+	var push proto.Push
+	err := json.Unmarshal(reply.Result, &push)
+	if err != nil {
+		return nil, err
+	}
+	var target string
+	switch push.Type {
+	case proto.PushTypePublication:
+		target = signalrClientTargetPublication
+	case proto.PushTypeJoin:
+		target = signalrClientTargetJoin
+	case proto.PushTypeLeave:
+		target = signalrClientTargetLeave
+	case proto.PushTypeUnsub:
+		target = signalrClientTargetUnsub
+	case proto.PushTypeMessage:
+		return &signalrMessage{
+			Type:      signalrMessageTypeInvocation,
+			Target:    signalrClientTargetMessage,
+			Arguments: []json.RawMessage{signalrRawData(push.Data)},
+		}, nil
+	default:
+		return nil, nil
+	}
+	channel, _ := json.Marshal(push.Channel)
+	return &signalrMessage{
+		Type:      signalrMessageTypeInvocation,
+		Target:    target,
+		Arguments: []json.RawMessage{channel, signalrRawData(push.Data)},
+	}, nil
+}
+
+func signalrRawData(data proto.Raw) json.RawMessage {
+	if len(data) == 0 {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(data)
+}
+
+func (t *signalrTransport) writeMessages(msgs ...interface{}) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	for _, msg := range msgs {
+		encoded, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+		buf.WriteByte(signalrRecordSeparator)
+	}
+	return t.write(buf.Bytes())
+}
+
+func (t *signalrTransport) write(data []byte) error {
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	if t.opts.writeTimeout > 0 {
+		t.conn.SetWriteDeadline(time.Now().Add(t.opts.writeTimeout))
+	}
+	err := t.conn.WriteMessage(websocket.TextMessage, data)
+	if err != nil {
+		return err
+	}
+	if t.opts.writeTimeout > 0 {
+		t.conn.SetWriteDeadline(time.Time{})
+	}
+	return nil
+}
+
+func (t *signalrTransport) Close(disconnect *Disconnect) error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	if t.pingTimer != nil {
+		t.pingTimer.Stop()
+	}
+	close(t.closeCh)
+	t.mu.Unlock()
+
+	if disconnect != nil {
+		t.writeMessages(&signalrMessage{
+			Type:           signalrMessageTypeClose,
+			Error:          disconnect.Reason,
+			AllowReconnect: disconnect.Reconnect,
+		})
+		deadline := time.Now().Add(time.Second)
+		reason, err := json.Marshal(disconnect)
+		if err != nil {
+			return err
+		}
+		msg := websocket.FormatCloseMessage(disconnect.Code, string(reason))
+		t.conn.WriteControl(websocket.CloseMessage, msg, deadline)
+		return t.conn.Close()
+	}
+	return t.conn.Close()
+}
+
+var errSignalRUnsupportedProtocol = errors.New("only json protocol of version 1 supported")
+
+// convertMessage converts SignalR message into Centrifuge JSON protocol command.
+// Nil command returned for messages which do not require processing by client.
+func (t *signalrTransport) convertMessage(msg *signalrMessage) (*proto.Command, error) {
+	switch msg.Type {
+	case signalrMessageTypeInvocation:
+	case signalrMessageTypePing, signalrMessageTypeCancelInvocation, signalrMessageTypeStreamItem, signalrMessageTypeCompletion:
+		return nil, nil
+	default:
+		return nil, ErrorNotAvailable
+	}
+
+	var method proto.MethodType
+	var params interface{}
+
+	switch msg.Target {
+	case signalrTargetSubscribe, signalrTargetUnsubscribe, signalrTargetPresence, signalrTargetPresenceStats, signalrTargetHistory:
+		if len(msg.Arguments) != 1 {
+			return nil, ErrorBadRequest
+		}
+		var channel string
+		if err := json.Unmarshal(msg.Arguments[0], &channel); err != nil {
+			return nil, ErrorBadRequest
+		}
+		switch msg.Target {
+		case signalrTargetSubscribe:
+			method, params = proto.MethodTypeSubscribe, &proto.SubscribeRequest{Channel: channel}
+		case signalrTargetUnsubscribe:
+			method, params = proto.MethodTypeUnsubscribe, &proto.UnsubscribeRequest{Channel: channel}
+		case signalrTargetPresence:
+			method, params = proto.MethodTypePresence, &proto.PresenceRequest{Channel: channel}
+		case signalrTargetPresenceStats:
+			method, params = proto.MethodTypePresenceStats, &proto.PresenceStatsRequest{Channel: channel}
+		case signalrTargetHistory:
+			method, params = proto.MethodTypeHistory, &proto.HistoryRequest{Channel: channel}
+		}
+	case signalrTargetPublish:
+		if len(msg.Arguments) != 2 {
+			return nil, ErrorBadRequest
+		}
+		var channel string
+		if err := json.Unmarshal(msg.Arguments[0], &channel); err != nil {
+			return nil, ErrorBadRequest
+		}
+		method, params = proto.MethodTypePublish, &proto.PublishRequest{Channel: channel, Data: proto.Raw(msg.Arguments[1])}
+	default:
+		data, err := json.Marshal(signalrRPCData{Target: msg.Target, Arguments: msg.Arguments})
+		if err != nil {
+			return nil, err
+		}
+		if msg.InvocationID == "" {
+			method, params = proto.MethodTypeSend, &proto.SendRequest{Data: data}
+		} else {
+			method, params = proto.MethodTypeRPC, &proto.RPCRequest{Data: data}
+		}
+	}
+
+	encodedParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &proto.Command{
+		Method: method,
+		Params: encodedParams,
+	}
+	if method != proto.MethodTypeSend {
+		cmd.ID = t.addInvocation(msg.InvocationID, method)
+	}
+	return cmd, nil
+}
+
+// SignalRConfig represents config for SignalRHandler.
+type SignalRConfig struct {
+	// ReadBufferSize is a parameter that is used for raw websocket Upgrader.
+	// If set to zero reasonable default value will be used.
+	ReadBufferSize int
+
+	// WriteBufferSize is a parameter that is used for raw websocket Upgrader.
+	// If set to zero reasonable default value will be used.
+	WriteBufferSize int
+
+	// CheckOrigin func to provide custom origin check logic.
+	// nil means allow all origins.
+	CheckOrigin func(r *http.Request) bool
+}
+
+// SignalRHandler serves clients using SignalR JSON hub protocol over
+// Websocket transport. It handles both negotiate requests (path ending
+// with /negotiate) and Websocket connections so it should be mounted on
+// hub URL prefix, for example:
+//
+//	mux.Handle("/hub", centrifuge.NewSignalRHandler(node, centrifuge.SignalRConfig{}))
+//	mux.Handle("/hub/", centrifuge.NewSignalRHandler(node, centrifuge.SignalRConfig{}))
+//
+// Client authenticates with connection JWT passed in access_token URL query
+// parameter as SignalR clients do. Hub methods Subscribe, Unsubscribe,
+// Presence, PresenceStats and History accept channel as the only argument,
+// Publish accepts channel and data. Invocations of all other hub methods
+// are passed to RPC handler (or to Message handler for non-blocking
+// invocations) with JSON object containing target and arguments as data.
+// Server delivers channel pushes invoking publication, join, leave and
+// unsub client methods with channel and payload arguments, asynchronous
+// messages are delivered over message client method.
+type SignalRHandler struct {
+	node   *Node
+	config SignalRConfig
+}
+
+// NewSignalRHandler creates new SignalRHandler.
+func NewSignalRHandler(n *Node, c SignalRConfig) *SignalRHandler {
+	return &SignalRHandler{
+		node:   n,
+		config: c,
+	}
+}
+
+type signalrAvailableTransport struct {
+	Transport       string   `json:"transport"`
+	TransferFormats []string `json:"transferFormats"`
+}
+
+type signalrNegotiateResponse struct {
+	ConnectionID        string                      `json:"connectionId"`
+	AvailableTransports []signalrAvailableTransport `json:"availableTransports"`
+}
+
+func (s *SignalRHandler) handleNegotiate(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	uuidObject, err := uuid.NewV4()
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := signalrNegotiateResponse{
+		ConnectionID: uuidObject.String(),
+		AvailableTransports: []signalrAvailableTransport{
+			{Transport: "WebSockets", TransferFormats: []string{"Text"}},
+		},
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Write(data)
+}
+
+func (s *SignalRHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/negotiate") {
+		s.handleNegotiate(rw, r)
+		return
+	}
+
+	transportConnectCount.WithLabelValues(transportSignalR).Inc()
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  s.config.ReadBufferSize,
+		WriteBufferSize: s.config.WriteBufferSize,
+	}
+	if s.config.CheckOrigin != nil {
+		upgrader.CheckOrigin = s.config.CheckOrigin
+	} else {
+		upgrader.CheckOrigin = func(r *http.Request) bool {
+			// Allow all connections.
+			return true
+		}
+	}
+
+	conn, err := upgrader.Upgrade(rw, r, nil)
+	if err != nil {
+		s.node.logger.log(newLogEntry(LogLevelDebug, "signalr upgrade error", map[string]interface{}{"error": err.Error()}))
+		return
+	}
+
+	config := s.node.Config()
+	pingInterval := config.ClientPingInterval
+	writeTimeout := config.ClientMessageWriteTimeout
+	maxRequestSize := config.ClientRequestMaxSize
+
+	if maxRequestSize > 0 {
+		conn.SetReadLimit(int64(maxRequestSize))
+	}
+
+	// Separate goroutine for better GC of caller's data.
+	go func() {
+		opts := &websocketTransportOptions{
+			pingInterval: pingInterval,
+			writeTimeout: writeTimeout,
+			enc:          proto.EncodingJSON,
+		}
+
+		transport := newSignalRTransport(conn, r, opts)
+
+		select {
+		case <-s.node.NotifyShutdown():
+			transport.Close(DisconnectShutdown)
+			return
+		default:
+		}
+
+		c, err := newClient(r.Context(), s.node, transport)
+		if err != nil {
+			s.node.logger.log(newLogEntry(LogLevelError, "error creating client", map[string]interface{}{"transport": transportSignalR}))
+			return
+		}
+		s.node.logger.log(newLogEntry(LogLevelDebug, "client connection established", map[string]interface{}{"client": c.ID(), "transport": transportSignalR}))
+		defer func(started time.Time) {
+			s.node.logger.log(newLogEntry(LogLevelDebug, "client connection completed", map[string]interface{}{"client": c.ID(), "transport": transportSignalR, "duration": time.Since(started)}))
+		}(time.Now())
+		defer c.Close(nil)
+
+		handshakeDone := false
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			for _, frame := range bytes.Split(data, []byte{signalrRecordSeparator}) {
+				if len(frame) == 0 {
+					continue
+				}
+				if !handshakeDone {
+					var handshake signalrHandshakeRequest
+					err := json.Unmarshal(frame, &handshake)
+					if err != nil || handshake.Protocol != "json" || handshake.Version != 1 {
+						transport.writeMessages(&signalrHandshakeResponse{Error: errSignalRUnsupportedProtocol.Error()})
+						c.Close(DisconnectBadRequest)
+						return
+					}
+					handshakeDone = true
+					if pingInterval > 0 {
+						transport.addPing()
+					}
+					connectParams, _ := json.Marshal(&proto.ConnectRequest{
+						Token: r.URL.Query().Get("access_token"),
+					})
+					cmd, _ := json.Marshal(&proto.Command{
+						ID:     signalrConnectCommandID,
+						Method: proto.MethodTypeConnect,
+						Params: connectParams,
+					})
+					if ok := c.handleRawData(cmd); !ok {
+						return
+					}
+					continue
+				}
+				var msg signalrMessage
+				err := json.Unmarshal(frame, &msg)
+				if err != nil {
+					s.node.logger.log(newLogEntry(LogLevelInfo, "error decoding signalr message", map[string]interface{}{"client": c.ID(), "user": c.UserID(), "error": err.Error()}))
+					c.Close(DisconnectBadRequest)
+					return
+				}
+				if msg.Type == signalrMessageTypeClose {
+					return
+				}
+				cmd, err := transport.convertMessage(&msg)
+				if err != nil {
+					if msg.InvocationID != "" {
+						transport.writeMessages(&signalrMessage{
+							Type:         signalrMessageTypeCompletion,
+							InvocationID: msg.InvocationID,
+							Error:        err.Error(),
+						})
+						continue
+					}
+					s.node.logger.log(newLogEntry(LogLevelInfo, "unsupported signalr message", map[string]interface{}{"client": c.ID(), "user": c.UserID(), "error": err.Error()}))
+					continue
+				}
+				if cmd == nil {
+					continue
+				}
+				encodedCmd, err := json.Marshal(cmd)
+				if err != nil {
+					c.Close(DisconnectServerError)
+					return
+				}
+				if ok := c.handleRawData(encodedCmd); !ok {
+					return
+				}
+			}
+		}
+	}()
+}
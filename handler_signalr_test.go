@@ -0,0 +1,136 @@
+package centrifuge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func readSignalRMessages(t *testing.T, conn *websocket.Conn) [][]byte {
+	_, data, err := conn.ReadMessage()
+	assert.NoError(t, err)
+	var frames [][]byte
+	for _, frame := range bytes.Split(data, []byte{signalrRecordSeparator}) {
+		if len(frame) > 0 {
+			frames = append(frames, frame)
+		}
+	}
+	return frames
+}
+
+func writeSignalRMessage(t *testing.T, conn *websocket.Conn, msg interface{}) {
+	data, err := json.Marshal(msg)
+	assert.NoError(t, err)
+	err = conn.WriteMessage(websocket.TextMessage, append(data, signalrRecordSeparator))
+	assert.NoError(t, err)
+}
+
+func TestSignalRHandlerNegotiate(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	server := httptest.NewServer(NewSignalRHandler(n, SignalRConfig{}))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/hub/negotiate", "text/plain", nil)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var negotiate signalrNegotiateResponse
+	err = json.NewDecoder(resp.Body).Decode(&negotiate)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, negotiate.ConnectionID)
+	assert.Equal(t, "WebSockets", negotiate.AvailableTransports[0].Transport)
+}
+
+func TestSignalRHandler(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	c := n.Config()
+	c.ClientInsecure = true
+	n.Reload(c)
+
+	n.On().ClientConnected(func(ctx context.Context, client *Client) {
+		client.On().RPC(func(e RPCEvent) RPCReply {
+			return RPCReply{Data: e.Data}
+		})
+	})
+
+	server := httptest.NewServer(NewSignalRHandler(n, SignalRConfig{}))
+	defer server.Close()
+
+	url := "ws" + server.URL[4:]
+	conn, _, err := websocket.DefaultDialer.Dial(url+"/hub?id=test", nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	writeSignalRMessage(t, conn, signalrHandshakeRequest{Protocol: "json", Version: 1})
+	frames := readSignalRMessages(t, conn)
+	assert.Equal(t, "{}", string(frames[0]))
+
+	writeSignalRMessage(t, conn, signalrMessage{
+		Type:         signalrMessageTypeInvocation,
+		InvocationID: "1",
+		Target:       signalrTargetSubscribe,
+		Arguments:    []json.RawMessage{json.RawMessage(`"test"`)},
+	})
+	frames = readSignalRMessages(t, conn)
+	var completion signalrMessage
+	err = json.Unmarshal(frames[0], &completion)
+	assert.NoError(t, err)
+	assert.Equal(t, signalrMessageTypeCompletion, completion.Type)
+	assert.Equal(t, "1", completion.InvocationID)
+	assert.Empty(t, completion.Error)
+
+	err = n.Publish("test", []byte(`{"input":"test"}`))
+	assert.NoError(t, err)
+	frames = readSignalRMessages(t, conn)
+	var invocation signalrMessage
+	err = json.Unmarshal(frames[0], &invocation)
+	assert.NoError(t, err)
+	assert.Equal(t, signalrMessageTypeInvocation, invocation.Type)
+	assert.Equal(t, signalrClientTargetPublication, invocation.Target)
+	assert.Equal(t, `"test"`, string(invocation.Arguments[0]))
+	var pub Publication
+	err = json.Unmarshal(invocation.Arguments[1], &pub)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"input":"test"}`, string(pub.Data))
+
+	writeSignalRMessage(t, conn, signalrMessage{
+		Type:         signalrMessageTypeInvocation,
+		InvocationID: "2",
+		Target:       "Echo",
+		Arguments:    []json.RawMessage{json.RawMessage(`"hello"`)},
+	})
+	frames = readSignalRMessages(t, conn)
+	err = json.Unmarshal(frames[0], &completion)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", completion.InvocationID)
+	var rpcData signalrRPCData
+	err = json.Unmarshal(completion.Result, &rpcData)
+	assert.NoError(t, err)
+	assert.Equal(t, "Echo", rpcData.Target)
+	assert.Equal(t, `"hello"`, string(rpcData.Arguments[0]))
+}
+
+func TestSignalRHandlerUnsupportedProtocol(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	server := httptest.NewServer(NewSignalRHandler(n, SignalRConfig{}))
+	defer server.Close()
+
+	url := "ws" + server.URL[4:]
+	conn, _, err := websocket.DefaultDialer.Dial(url+"/hub", nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	writeSignalRMessage(t, conn, signalrHandshakeRequest{Protocol: "messagepack", Version: 1})
+	frames := readSignalRMessages(t, conn)
+	var resp signalrHandshakeResponse
+	err = json.Unmarshal(frames[0], &resp)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Error)
+}
@@ -0,0 +1,68 @@
+package centrifuge
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	transportSSE = "sse"
+)
+
+// SSEConfig represents config for SSEHandler.
+type SSEConfig struct {
+	// PingInterval is an interval of comments sent to keep connection
+	// alive. Zero value means Config.ClientPingInterval used.
+	PingInterval time.Duration
+}
+
+// SSEHandler handles unidirectional client connections using Server-Sent
+// Events (EventSource). Every protocol message WebSocket transport would
+// send – connect reply and pushes – is sent as data of message event,
+// disconnect event sent before server closes connection. Connect request
+// is taken from POST request body or token query parameter. Client can't
+// send commands so it should be subscribed to channels on server side,
+// for example using ConnectReply.Subscriptions.
+type SSEHandler struct {
+	node   *Node
+	config SSEConfig
+}
+
+// NewSSEHandler creates new SSEHandler.
+func NewSSEHandler(n *Node, c SSEConfig) *SSEHandler {
+	return &SSEHandler{
+		node:   n,
+		config: c,
+	}
+}
+
+var sseFormat = streamFormat{
+	message: func(data []byte) []byte {
+		return sseEvent("", data)
+	},
+	disconnect: func(data []byte) []byte {
+		return sseEvent("disconnect", data)
+	},
+	ping: []byte(":\n\n"),
+}
+
+// sseEvent encodes single line data into event stream format.
+func sseEvent(event string, data []byte) []byte {
+	buf := make([]byte, 0, len(event)+len(data)+16)
+	if event != "" {
+		buf = append(buf, "event: "...)
+		buf = append(buf, event...)
+		buf = append(buf, '\n')
+	}
+	buf = append(buf, "data: "...)
+	buf = append(buf, data...)
+	return append(buf, '\n', '\n')
+}
+
+func (s *SSEHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("Connection", "keep-alive")
+	rw.Header().Set("X-Accel-Buffering", "no")
+	serveStream(s.node, rw, r, transportSSE, sseFormat, s.config.PingInterval)
+}
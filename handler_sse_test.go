@@ -0,0 +1,95 @@
+package centrifuge
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nodeWithStreamSubscriptions() *Node {
+	n := nodeWithMemoryEngine()
+	n.On().ClientConnecting(func(ctx context.Context, t Transport, e ConnectEvent) ConnectReply {
+		if e.Token != "secret" {
+			return ConnectReply{Disconnect: DisconnectInvalidToken}
+		}
+		return ConnectReply{
+			Credentials:   &Credentials{UserID: "42"},
+			Subscriptions: []string{"test"},
+		}
+	})
+	return n
+}
+
+// readSSEEvent reads event stream until event with data found.
+func readSSEEvent(t *testing.T, r *bufio.Reader) (string, string) {
+	var event string
+	for {
+		line, err := r.ReadString('\n')
+		if !assert.NoError(t, err) {
+			return "", ""
+		}
+		line = strings.TrimRight(line, "\n")
+		if strings.HasPrefix(line, "event: ") {
+			event = strings.TrimPrefix(line, "event: ")
+		}
+		if strings.HasPrefix(line, "data: ") {
+			return event, strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestSSEHandler(t *testing.T) {
+	n := nodeWithStreamSubscriptions()
+	server := httptest.NewServer(NewSSEHandler(n, SSEConfig{}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "?token=secret")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	r := bufio.NewReader(resp.Body)
+	event, data := readSSEEvent(t, r)
+	assert.Equal(t, "", event)
+	assert.Contains(t, data, `"id":1`)
+	assert.Contains(t, data, `"client":`)
+
+	_, data = readSSEEvent(t, r)
+	assert.Contains(t, data, `"type":7`)
+	assert.Contains(t, data, `"channel":"test"`)
+
+	assert.NoError(t, n.Publish("test", []byte(`{"text":"hello"}`)))
+	_, data = readSSEEvent(t, r)
+	assert.Contains(t, data, `"channel":"test"`)
+	assert.Contains(t, data, `{"text":"hello"}`)
+
+	assert.NoError(t, n.Disconnect("42"))
+	event, data = readSSEEvent(t, r)
+	assert.Equal(t, "disconnect", event)
+	assert.Contains(t, data, `"reconnect":false`)
+}
+
+func TestSSEHandlerInvalidToken(t *testing.T) {
+	n := nodeWithStreamSubscriptions()
+	server := httptest.NewServer(NewSSEHandler(n, SSEConfig{}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "?token=invalid")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	event, data := readSSEEvent(t, bufio.NewReader(resp.Body))
+	assert.Equal(t, "disconnect", event)
+	assert.Contains(t, data, DisconnectInvalidToken.Reason)
+}
+
+func TestSSEEvent(t *testing.T) {
+	assert.Equal(t, "data: {}\n\n", string(sseEvent("", []byte(`{}`))))
+	assert.Equal(t, "event: disconnect\ndata: {}\n\n", string(sseEvent("disconnect", []byte(`{}`))))
+}
@@ -0,0 +1,717 @@
+package centrifuge
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	transportSTOMP = "stomp"
+)
+
+// STOMP 1.2 frame commands.
+const (
+	stompCommandConnect     = "CONNECT"
+	stompCommandStomp       = "STOMP"
+	stompCommandConnected   = "CONNECTED"
+	stompCommandSend        = "SEND"
+	stompCommandSubscribe   = "SUBSCRIBE"
+	stompCommandUnsubscribe = "UNSUBSCRIBE"
+	stompCommandAck         = "ACK"
+	stompCommandNack        = "NACK"
+	stompCommandDisconnect  = "DISCONNECT"
+	stompCommandMessage     = "MESSAGE"
+	stompCommandReceipt     = "RECEIPT"
+	stompCommandError       = "ERROR"
+)
+
+const stompVersion = "1.2"
+
+// stompConnectCommandID is an ID of internal connect command issued for
+// STOMP CONNECT frame. IDs for other frames start after it.
+const stompConnectCommandID = 1
+
+var (
+	errSTOMPMalformedFrame     = errors.New("malformed frame")
+	errSTOMPUnsupportedVersion = errors.New("only STOMP 1.2 supported")
+)
+
+// stompFrame is a single STOMP frame. Header order is kept as STOMP 1.2
+// says that first header entry wins when header repeated.
+type stompFrame struct {
+	command string
+	headers [][2]string
+	body    []byte
+}
+
+func newSTOMPFrame(command string, headers ...string) *stompFrame {
+	f := &stompFrame{command: command}
+	for i := 0; i+1 < len(headers); i += 2 {
+		f.addHeader(headers[i], headers[i+1])
+	}
+	return f
+}
+
+func (f *stompFrame) addHeader(key, value string) {
+	f.headers = append(f.headers, [2]string{key, value})
+}
+
+func (f *stompFrame) header(key string) string {
+	for _, h := range f.headers {
+		if h[0] == key {
+			return h[1]
+		}
+	}
+	return ""
+}
+
+var stompHeaderEscaper = strings.NewReplacer("\\", "\\\\", "\r", "\\r", "\n", "\\n", ":", "\\c")
+var stompHeaderUnescaper = strings.NewReplacer("\\\\", "\\", "\\r", "\r", "\\n", "\n", "\\c", ":")
+
+// encode writes frame into buffer. Header values are escaped for all
+// frames except CONNECTED as required by STOMP 1.2.
+func (f *stompFrame) encode(buf *bytes.Buffer) {
+	escape := f.command != stompCommandConnected
+	buf.WriteString(f.command)
+	buf.WriteByte('\n')
+	for _, h := range f.headers {
+		if escape {
+			buf.WriteString(stompHeaderEscaper.Replace(h[0]))
+			buf.WriteByte(':')
+			buf.WriteString(stompHeaderEscaper.Replace(h[1]))
+		} else {
+			buf.WriteString(h[0])
+			buf.WriteByte(':')
+			buf.WriteString(h[1])
+		}
+		buf.WriteByte('\n')
+	}
+	if len(f.body) > 0 {
+		buf.WriteString("content-length:")
+		buf.WriteString(strconv.Itoa(len(f.body)))
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	buf.Write(f.body)
+	buf.WriteByte(0)
+}
+
+// decodeSTOMPFrames parses all frames from data. Heart-beat EOLs between
+// frames are skipped.
+func decodeSTOMPFrames(data []byte) ([]*stompFrame, error) {
+	var frames []*stompFrame
+	for {
+		data = bytes.TrimLeft(data, "\r\n")
+		if len(data) == 0 {
+			return frames, nil
+		}
+		frame, rest, err := decodeSTOMPFrame(data)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, frame)
+		data = rest
+	}
+}
+
+func decodeSTOMPFrame(data []byte) (*stompFrame, []byte, error) {
+	headerEnd := bytes.Index(data, []byte("\n\n"))
+	sepLen := 2
+	if crlfEnd := bytes.Index(data, []byte("\r\n\r\n")); crlfEnd >= 0 && (headerEnd < 0 || crlfEnd < headerEnd) {
+		headerEnd = crlfEnd
+		sepLen = 4
+	}
+	if headerEnd < 0 {
+		return nil, nil, errSTOMPMalformedFrame
+	}
+	lines := strings.Split(strings.Replace(string(data[:headerEnd]), "\r\n", "\n", -1), "\n")
+	frame := &stompFrame{command: lines[0]}
+	escape := frame.command != stompCommandConnect && frame.command != stompCommandStomp
+	for _, line := range lines[1:] {
+		idx := strings.Index(line, ":")
+		if idx < 0 {
+			return nil, nil, errSTOMPMalformedFrame
+		}
+		key, value := line[:idx], line[idx+1:]
+		if escape {
+			key, value = stompHeaderUnescaper.Replace(key), stompHeaderUnescaper.Replace(value)
+		}
+		frame.addHeader(key, value)
+	}
+	rest := data[headerEnd+sepLen:]
+	if cl := frame.header("content-length"); cl != "" {
+		length, err := strconv.Atoi(cl)
+		if err != nil || length < 0 || len(rest) < length+1 || rest[length] != 0 {
+			return nil, nil, errSTOMPMalformedFrame
+		}
+		frame.body = rest[:length]
+		return frame, rest[length+1:], nil
+	}
+	end := bytes.IndexByte(rest, 0)
+	if end < 0 {
+		return nil, nil, errSTOMPMalformedFrame
+	}
+	frame.body = rest[:end]
+	return frame, rest[end+1:], nil
+}
+
+type stompPendingFrame struct {
+	command string
+	receipt string
+	channel string
+}
+
+// stompTransport translates Centrifuge JSON protocol replies written by
+// client into STOMP frames.
+type stompTransport struct {
+	mu        sync.RWMutex
+	writeMu   sync.Mutex
+	conn      *websocket.Conn
+	req       *http.Request
+	closed    bool
+	closeCh   chan struct{}
+	opts      *websocketTransportOptions
+	pingTimer *time.Timer
+
+	commandID uint32
+	messageID uint64
+	pending   map[uint32]stompPendingFrame
+	// subscriptions match STOMP subscription IDs with channels.
+	subscriptions map[string]string
+	// channels match channels with STOMP subscription IDs.
+	channels map[string]string
+}
+
+func newSTOMPTransport(conn *websocket.Conn, req *http.Request, opts *websocketTransportOptions) *stompTransport {
+	return &stompTransport{
+		conn:          conn,
+		req:           req,
+		closeCh:       make(chan struct{}),
+		opts:          opts,
+		commandID:     stompConnectCommandID,
+		pending:       make(map[uint32]stompPendingFrame),
+		subscriptions: make(map[string]string),
+		channels:      make(map[string]string),
+	}
+}
+
+func (t *stompTransport) ping() {
+	select {
+	case <-t.closeCh:
+		return
+	default:
+		err := t.write([]byte("\n"))
+		if err != nil {
+			t.Close(DisconnectServerError)
+			return
+		}
+		t.addPing()
+	}
+}
+
+func (t *stompTransport) addPing() {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return
+	}
+	t.pingTimer = time.AfterFunc(t.opts.pingInterval, t.ping)
+	t.mu.Unlock()
+}
+
+func (t *stompTransport) Name() string {
+	return transportSTOMP
+}
+
+func (t *stompTransport) Encoding() proto.Encoding {
+	return proto.EncodingJSON
+}
+
+func (t *stompTransport) Info() TransportInfo {
+	return TransportInfo{
+		Request: t.req,
+	}
+}
+
+func (t *stompTransport) addPending(command, receipt, channel string) uint32 {
+	id := atomic.AddUint32(&t.commandID, 1)
+	t.mu.Lock()
+	t.pending[id] = stompPendingFrame{command: command, receipt: receipt, channel: channel}
+	t.mu.Unlock()
+	return id
+}
+
+func (t *stompTransport) popPending(id uint32) (stompPendingFrame, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pending, ok := t.pending[id]
+	if ok {
+		delete(t.pending, id)
+	}
+	return pending, ok
+}
+
+func (t *stompTransport) addSubscription(id, channel string) {
+	t.mu.Lock()
+	t.subscriptions[id] = channel
+	t.channels[channel] = id
+	t.mu.Unlock()
+}
+
+func (t *stompTransport) removeChannel(channel string) {
+	t.mu.Lock()
+	if id, ok := t.channels[channel]; ok {
+		delete(t.subscriptions, id)
+		delete(t.channels, channel)
+	}
+	t.mu.Unlock()
+}
+
+func (t *stompTransport) subscriptionChannel(id string) (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	channel, ok := t.subscriptions[id]
+	return channel, ok
+}
+
+func (t *stompTransport) channelSubscription(channel string) (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	id, ok := t.channels[channel]
+	return id, ok
+}
+
+// Write accepts newline delimited JSON replies and converts them to
+// STOMP frames.
+func (t *stompTransport) Write(data []byte) error {
+	select {
+	case <-t.closeCh:
+		return nil
+	default:
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var reply proto.Reply
+		err := json.Unmarshal(line, &reply)
+		if err != nil {
+			return err
+		}
+		frame, err := t.convertReply(&reply)
+		if err != nil {
+			return err
+		}
+		if frame != nil {
+			frame.encode(buf)
+		}
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	return t.write(buf.Bytes())
+}
+
+func (t *stompTransport) convertReply(reply *proto.Reply) (*stompFrame, error) {
+	if reply.ID == stompConnectCommandID {
+		if reply.Error != nil {
+			return newSTOMPFrame(stompCommandError, "message", reply.Error.Message), nil
+		}
+		var res proto.ConnectResult
+		err := json.Unmarshal(reply.Result, &res)
+		if err != nil {
+			return nil, err
+		}
+		heartbeat := "0,0"
+		if t.opts.pingInterval > 0 {
+			heartbeat = strconv.FormatInt(int64(t.opts.pingInterval/time.Millisecond), 10) + ",0"
+		}
+		return newSTOMPFrame(
+			stompCommandConnected,
+			"version", stompVersion,
+			"heart-beat", heartbeat,
+			"session", res.Client,
+			"server", "centrifuge",
+		), nil
+	}
+
+	if reply.ID > 0 {
+		pending, ok := t.popPending(reply.ID)
+		if !ok {
+			return nil, nil
+		}
+		if reply.Error != nil {
+			if pending.command == stompCommandSubscribe {
+				t.removeChannel(pending.channel)
+			}
+			frame := newSTOMPFrame(stompCommandError, "message", reply.Error.Message)
+			if pending.receipt != "" {
+				frame.addHeader("receipt-id", pending.receipt)
+			}
+			return frame, nil
+		}
+		if pending.receipt == "" {
+			return nil, nil
+		}
+		return newSTOMPFrame(stompCommandReceipt, "receipt-id", pending.receipt), nil
+	}
+
+	var push proto.Push
+	err := json.Unmarshal(reply.Result, &push)
+	if err != nil {
+		return nil, err
+	}
+	switch push.Type {
+	case proto.PushTypePublication:
+		var pub proto.Publication
+		err := json.Unmarshal(push.Data, &pub)
+		if err != nil {
+			return nil, err
+		}
+		subscription, ok := t.channelSubscription(push.Channel)
+		if !ok {
+			return nil, nil
+		}
+		messageID := pub.UID
+		if messageID == "" {
+			messageID = strconv.FormatUint(atomic.AddUint64(&t.messageID, 1), 10)
+		}
+		frame := newSTOMPFrame(
+			stompCommandMessage,
+			"subscription", subscription,
+			"message-id", messageID,
+			"destination", push.Channel,
+			"content-type", "application/json",
+		)
+		frame.body = pub.Data
+		return frame, nil
+	case proto.PushTypeUnsub:
+		t.removeChannel(push.Channel)
+	}
+	// Join, leave and asynchronous messages have no STOMP representation.
+	return nil, nil
+}
+
+func (t *stompTransport) write(data []byte) error {
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	if t.opts.writeTimeout > 0 {
+		t.conn.SetWriteDeadline(time.Now().Add(t.opts.writeTimeout))
+	}
+	err := t.conn.WriteMessage(websocket.TextMessage, data)
+	if err != nil {
+		return err
+	}
+	if t.opts.writeTimeout > 0 {
+		t.conn.SetWriteDeadline(time.Time{})
+	}
+	return nil
+}
+
+func (t *stompTransport) writeFrame(frame *stompFrame) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	frame.encode(buf)
+	return t.write(buf.Bytes())
+}
+
+func (t *stompTransport) Close(disconnect *Disconnect) error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	if t.pingTimer != nil {
+		t.pingTimer.Stop()
+	}
+	close(t.closeCh)
+	t.mu.Unlock()
+
+	if disconnect != nil && disconnect != DisconnectNormal {
+		t.writeFrame(newSTOMPFrame(stompCommandError, "message", disconnect.Reason))
+		deadline := time.Now().Add(time.Second)
+		reason, err := json.Marshal(disconnect)
+		if err != nil {
+			return err
+		}
+		msg := websocket.FormatCloseMessage(disconnect.Code, string(reason))
+		t.conn.WriteControl(websocket.CloseMessage, msg, deadline)
+		return t.conn.Close()
+	}
+	return t.conn.Close()
+}
+
+// stompBodyData converts STOMP frame body into JSON payload. Bodies which
+// are not valid JSON are published as JSON strings.
+func stompBodyData(body []byte) (proto.Raw, error) {
+	if json.Valid(body) {
+		return proto.Raw(body), nil
+	}
+	data, err := json.Marshal(string(body))
+	if err != nil {
+		return nil, err
+	}
+	return proto.Raw(data), nil
+}
+
+// convertFrame converts STOMP client frame into Centrifuge JSON protocol
+// command. Nil command returned for frames which do not require processing
+// by client.
+func (t *stompTransport) convertFrame(frame *stompFrame) (*proto.Command, error) {
+	var method proto.MethodType
+	var params interface{}
+	var channel string
+
+	receipt := frame.header("receipt")
+
+	switch frame.command {
+	case stompCommandSubscribe:
+		destination, id := frame.header("destination"), frame.header("id")
+		if destination == "" || id == "" {
+			return nil, ErrorBadRequest
+		}
+		if _, ok := t.channelSubscription(destination); ok {
+			return nil, ErrorAlreadySubscribed
+		}
+		t.addSubscription(id, destination)
+		channel = destination
+		method, params = proto.MethodTypeSubscribe, &proto.SubscribeRequest{Channel: destination}
+	case stompCommandUnsubscribe:
+		var ok bool
+		channel, ok = t.subscriptionChannel(frame.header("id"))
+		if !ok {
+			return nil, ErrorBadRequest
+		}
+		t.removeChannel(channel)
+		method, params = proto.MethodTypeUnsubscribe, &proto.UnsubscribeRequest{Channel: channel}
+	case stompCommandSend:
+		destination := frame.header("destination")
+		if destination == "" {
+			return nil, ErrorBadRequest
+		}
+		data, err := stompBodyData(frame.body)
+		if err != nil {
+			return nil, err
+		}
+		method, params = proto.MethodTypePublish, &proto.PublishRequest{Channel: destination, Data: data}
+	case stompCommandAck, stompCommandNack:
+		// Centrifuge delivers publications without per message acknowledgement,
+		// so ACK and NACK frames are only confirmed with receipt if asked.
+		if receipt != "" {
+			t.writeFrame(newSTOMPFrame(stompCommandReceipt, "receipt-id", receipt))
+		}
+		return nil, nil
+	default:
+		return nil, ErrorMethodNotFound
+	}
+
+	encodedParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.Command{
+		ID:     t.addPending(frame.command, receipt, channel),
+		Method: method,
+		Params: encodedParams,
+	}, nil
+}
+
+// STOMPConfig represents config for STOMPHandler.
+type STOMPConfig struct {
+	// ReadBufferSize is a parameter that is used for raw websocket Upgrader.
+	// If set to zero reasonable default value will be used.
+	ReadBufferSize int
+
+	// WriteBufferSize is a parameter that is used for raw websocket Upgrader.
+	// If set to zero reasonable default value will be used.
+	WriteBufferSize int
+
+	// CheckOrigin func to provide custom origin check logic.
+	// nil means allow all origins.
+	CheckOrigin func(r *http.Request) bool
+}
+
+// STOMPHandler serves clients speaking STOMP 1.2 over Websocket. Client
+// authenticates with connection JWT passed in passcode header of CONNECT
+// frame. SUBSCRIBE and UNSUBSCRIBE frames subscribe to and unsubscribe from
+// channel set as destination, SEND frame publishes its body into destination
+// channel. Publications are delivered to subscribers as MESSAGE frames.
+// Centrifuge has no per message acknowledgements so ACK and NACK frames are
+// accepted for compatibility with clients using client ack modes but have
+// no effect on delivery. Transactions are not supported.
+type STOMPHandler struct {
+	node   *Node
+	config STOMPConfig
+}
+
+// NewSTOMPHandler creates new STOMPHandler.
+func NewSTOMPHandler(n *Node, c STOMPConfig) *STOMPHandler {
+	return &STOMPHandler{
+		node:   n,
+		config: c,
+	}
+}
+
+func (s *STOMPHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	transportConnectCount.WithLabelValues(transportSTOMP).Inc()
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  s.config.ReadBufferSize,
+		WriteBufferSize: s.config.WriteBufferSize,
+		Subprotocols:    []string{"v12.stomp"},
+	}
+	if s.config.CheckOrigin != nil {
+		upgrader.CheckOrigin = s.config.CheckOrigin
+	} else {
+		upgrader.CheckOrigin = func(r *http.Request) bool {
+			// Allow all connections.
+			return true
+		}
+	}
+
+	conn, err := upgrader.Upgrade(rw, r, nil)
+	if err != nil {
+		s.node.logger.log(newLogEntry(LogLevelDebug, "stomp upgrade error", map[string]interface{}{"error": err.Error()}))
+		return
+	}
+
+	config := s.node.Config()
+	pingInterval := config.ClientPingInterval
+	writeTimeout := config.ClientMessageWriteTimeout
+	maxRequestSize := config.ClientRequestMaxSize
+
+	if maxRequestSize > 0 {
+		conn.SetReadLimit(int64(maxRequestSize))
+	}
+
+	// Separate goroutine for better GC of caller's data.
+	go func() {
+		opts := &websocketTransportOptions{
+			pingInterval: pingInterval,
+			writeTimeout: writeTimeout,
+			enc:          proto.EncodingJSON,
+		}
+
+		transport := newSTOMPTransport(conn, r, opts)
+
+		select {
+		case <-s.node.NotifyShutdown():
+			transport.Close(DisconnectShutdown)
+			return
+		default:
+		}
+
+		c, err := newClient(r.Context(), s.node, transport)
+		if err != nil {
+			s.node.logger.log(newLogEntry(LogLevelError, "error creating client", map[string]interface{}{"transport": transportSTOMP}))
+			return
+		}
+		s.node.logger.log(newLogEntry(LogLevelDebug, "client connection established", map[string]interface{}{"client": c.ID(), "transport": transportSTOMP}))
+		defer func(started time.Time) {
+			s.node.logger.log(newLogEntry(LogLevelDebug, "client connection completed", map[string]interface{}{"client": c.ID(), "transport": transportSTOMP, "duration": time.Since(started)}))
+		}(time.Now())
+		defer c.Close(nil)
+
+		connected := false
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			frames, err := decodeSTOMPFrames(data)
+			if err != nil {
+				s.node.logger.log(newLogEntry(LogLevelInfo, "error decoding stomp frame", map[string]interface{}{"client": c.ID(), "user": c.UserID(), "error": err.Error()}))
+				c.Close(DisconnectBadRequest)
+				return
+			}
+			for _, frame := range frames {
+				if !connected {
+					if frame.command != stompCommandConnect && frame.command != stompCommandStomp {
+						c.Close(DisconnectBadRequest)
+						return
+					}
+					if !stompVersionSupported(frame.header("accept-version")) {
+						transport.writeFrame(newSTOMPFrame(stompCommandError, "version", stompVersion, "message", errSTOMPUnsupportedVersion.Error()))
+						c.Close(DisconnectBadRequest)
+						return
+					}
+					connected = true
+					if pingInterval > 0 {
+						transport.addPing()
+					}
+					connectParams, _ := json.Marshal(&proto.ConnectRequest{
+						Token: frame.header("passcode"),
+					})
+					cmd, _ := json.Marshal(&proto.Command{
+						ID:     stompConnectCommandID,
+						Method: proto.MethodTypeConnect,
+						Params: connectParams,
+					})
+					if ok := c.handleRawData(cmd); !ok {
+						return
+					}
+					continue
+				}
+				if frame.command == stompCommandDisconnect {
+					if receipt := frame.header("receipt"); receipt != "" {
+						transport.writeFrame(newSTOMPFrame(stompCommandReceipt, "receipt-id", receipt))
+					}
+					c.Close(DisconnectNormal)
+					return
+				}
+				cmd, err := transport.convertFrame(frame)
+				if err != nil {
+					errFrame := newSTOMPFrame(stompCommandError, "message", err.Error())
+					if receipt := frame.header("receipt"); receipt != "" {
+						errFrame.addHeader("receipt-id", receipt)
+					}
+					transport.writeFrame(errFrame)
+					continue
+				}
+				if cmd == nil {
+					continue
+				}
+				encodedCmd, err := json.Marshal(cmd)
+				if err != nil {
+					c.Close(DisconnectServerError)
+					return
+				}
+				if ok := c.handleRawData(encodedCmd); !ok {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// stompVersionSupported checks accept-version header of CONNECT frame.
+// Missing header means STOMP 1.0 client but we still allow such clients
+// as most of them are compatible with 1.2 frame format.
+func stompVersionSupported(acceptVersion string) bool {
+	if acceptVersion == "" {
+		return true
+	}
+	for _, v := range strings.Split(acceptVersion, ",") {
+		if strings.TrimSpace(v) == stompVersion {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package centrifuge
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeSTOMPFrame(t *testing.T, conn *websocket.Conn, frame *stompFrame) {
+	var buf bytes.Buffer
+	frame.encode(&buf)
+	err := conn.WriteMessage(websocket.TextMessage, buf.Bytes())
+	assert.NoError(t, err)
+}
+
+func readSTOMPFrame(t *testing.T, conn *websocket.Conn) *stompFrame {
+	_, data, err := conn.ReadMessage()
+	assert.NoError(t, err)
+	frames, err := decodeSTOMPFrames(data)
+	assert.NoError(t, err)
+	assert.Len(t, frames, 1)
+	return frames[0]
+}
+
+func TestSTOMPFrameEncodeDecode(t *testing.T) {
+	frame := newSTOMPFrame(stompCommandSend, "destination", "a:b", "receipt", "1")
+	frame.body = []byte("hello\x00world")
+	var buf bytes.Buffer
+	frame.encode(&buf)
+	buf.WriteString("\n")
+	frame.encode(&buf)
+
+	frames, err := decodeSTOMPFrames(buf.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, frames, 2)
+	assert.Equal(t, stompCommandSend, frames[0].command)
+	assert.Equal(t, "a:b", frames[0].header("destination"))
+	assert.Equal(t, "hello\x00world", string(frames[1].body))
+
+	_, err = decodeSTOMPFrames([]byte("SEND\ndestination:test\n\nno terminator"))
+	assert.Equal(t, errSTOMPMalformedFrame, err)
+}
+
+func TestSTOMPHandler(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	c := n.Config()
+	c.ClientInsecure = true
+	n.Reload(c)
+
+	server := httptest.NewServer(NewSTOMPHandler(n, STOMPConfig{}))
+	defer server.Close()
+
+	url := "ws" + server.URL[4:]
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	writeSTOMPFrame(t, conn, newSTOMPFrame(stompCommandConnect, "accept-version", "1.1,1.2", "host", "localhost"))
+	frame := readSTOMPFrame(t, conn)
+	assert.Equal(t, stompCommandConnected, frame.command)
+	assert.Equal(t, stompVersion, frame.header("version"))
+
+	writeSTOMPFrame(t, conn, newSTOMPFrame(stompCommandSubscribe, "id", "sub-0", "destination", "test", "receipt", "r1"))
+	frame = readSTOMPFrame(t, conn)
+	assert.Equal(t, stompCommandReceipt, frame.command)
+	assert.Equal(t, "r1", frame.header("receipt-id"))
+
+	send := newSTOMPFrame(stompCommandSend, "destination", "test")
+	send.body = []byte(`{"input":"test"}`)
+	writeSTOMPFrame(t, conn, send)
+	frame = readSTOMPFrame(t, conn)
+	assert.Equal(t, stompCommandMessage, frame.command)
+	assert.Equal(t, "sub-0", frame.header("subscription"))
+	assert.Equal(t, "test", frame.header("destination"))
+	assert.Equal(t, `{"input":"test"}`, string(frame.body))
+
+	writeSTOMPFrame(t, conn, newSTOMPFrame(stompCommandAck, "id", frame.header("message-id"), "receipt", "r2"))
+	frame = readSTOMPFrame(t, conn)
+	assert.Equal(t, stompCommandReceipt, frame.command)
+	assert.Equal(t, "r2", frame.header("receipt-id"))
+
+	writeSTOMPFrame(t, conn, newSTOMPFrame(stompCommandUnsubscribe, "id", "sub-0", "receipt", "r3"))
+	frame = readSTOMPFrame(t, conn)
+	assert.Equal(t, stompCommandReceipt, frame.command)
+	assert.Equal(t, "r3", frame.header("receipt-id"))
+
+	writeSTOMPFrame(t, conn, newSTOMPFrame(stompCommandUnsubscribe, "id", "sub-0"))
+	frame = readSTOMPFrame(t, conn)
+	assert.Equal(t, stompCommandError, frame.command)
+}
+
+func TestSTOMPHandlerUnsupportedVersion(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	server := httptest.NewServer(NewSTOMPHandler(n, STOMPConfig{}))
+	defer server.Close()
+
+	url := "ws" + server.URL[4:]
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	writeSTOMPFrame(t, conn, newSTOMPFrame(stompCommandConnect, "accept-version", "1.0"))
+	frame := readSTOMPFrame(t, conn)
+	assert.Equal(t, stompCommandError, frame.command)
+}
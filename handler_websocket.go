@@ -1,6 +1,7 @@
 package centrifuge
 
 import (
+	"compress/flate"
 	"encoding/json"
 	"net/http"
 	"sync"
@@ -141,6 +142,29 @@ func (t *websocketTransport) Close(disconnect *Disconnect) error {
 	return t.conn.Close()
 }
 
+const (
+	// websocketProtobufSubprotocol negotiates Protobuf protocol encoding.
+	websocketProtobufSubprotocol = "centrifuge-protobuf"
+	// websocketJSONSubprotocol negotiates JSON protocol encoding.
+	websocketJSONSubprotocol = "centrifuge-json"
+)
+
+// websocketEncoding returns encoding of connection. Encoding negotiated using
+// subprotocol, format query parameter supported for clients which can't set
+// Sec-WebSocket-Protocol header.
+func websocketEncoding(r *http.Request, subprotocol string) proto.Encoding {
+	switch subprotocol {
+	case websocketProtobufSubprotocol:
+		return proto.EncodingProtobuf
+	case websocketJSONSubprotocol:
+		return proto.EncodingJSON
+	}
+	if r.URL.Query().Get("format") == "protobuf" {
+		return proto.EncodingProtobuf
+	}
+	return proto.EncodingJSON
+}
+
 // WebsocketConfig represents config for WebsocketHandler.
 type WebsocketConfig struct {
 	// Compression allows to enable websocket permessage-deflate
@@ -151,6 +175,8 @@ type WebsocketConfig struct {
 
 	// CompressionLevel sets a level for websocket compression.
 	// See posiible value description at https://golang.org/pkg/compress/flate/#NewWriter
+	// Zero value means flate.DefaultCompression, use flate.HuffmanOnly or
+	// flate.BestSpeed for less CPU usage.
 	CompressionLevel int
 
 	// CompressionMinSize allows to set minimal limit in bytes for
@@ -172,7 +198,11 @@ type WebsocketConfig struct {
 	CheckOrigin func(r *http.Request) bool
 }
 
-// WebsocketHandler handles websocket client connections.
+// WebsocketHandler handles websocket client connections. Connection uses
+// Protobuf protocol encoding when client negotiated centrifuge-protobuf
+// subprotocol (or passed format=protobuf query parameter), JSON otherwise.
+// Binary publication data sent to JSON connections in base64 encoded
+// b64data field.
 type WebsocketHandler struct {
 	node   *Node
 	config WebsocketConfig
@@ -197,6 +227,7 @@ func (s *WebsocketHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		ReadBufferSize:    s.config.ReadBufferSize,
 		WriteBufferSize:   s.config.WriteBufferSize,
 		EnableCompression: s.config.Compression,
+		Subprotocols:      []string{websocketProtobufSubprotocol, websocketJSONSubprotocol},
 	}
 	if s.config.CheckOrigin != nil {
 		upgrader.CheckOrigin = s.config.CheckOrigin
@@ -214,6 +245,10 @@ func (s *WebsocketHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	if compression {
+		if compressionLevel == 0 {
+			// Level 0 is flate.NoCompression which makes no sense here.
+			compressionLevel = flate.DefaultCompression
+		}
 		err := conn.SetCompressionLevel(compressionLevel)
 		if err != nil {
 			s.node.logger.log(newLogEntry(LogLevelError, "websocket error setting compression level", map[string]interface{}{"error": err.Error()}))
@@ -234,10 +269,7 @@ func (s *WebsocketHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		conn.SetPongHandler(func(string) error { conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
 	}
 
-	var enc = proto.EncodingJSON
-	if r.URL.Query().Get("format") == "protobuf" {
-		enc = proto.EncodingProtobuf
-	}
+	enc := websocketEncoding(r, conn.Subprotocol())
 
 	// Separate goroutine for better GC of caller's data.
 	go func() {
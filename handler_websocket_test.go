@@ -2,6 +2,7 @@ package centrifuge
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"net/http"
@@ -33,6 +34,38 @@ func TestWebsocketHandler(t *testing.T) {
 	defer conn.Close()
 }
 
+func TestWebsocketHandlerCompression(t *testing.T) {
+	n, _ := New(Config{})
+	n.On().ClientConnecting(func(ctx context.Context, t Transport, e ConnectEvent) ConnectReply {
+		return ConnectReply{Credentials: &Credentials{UserID: "42"}}
+	})
+	mux := http.NewServeMux()
+	mux.Handle("/connection/websocket", NewWebsocketHandler(n, WebsocketConfig{
+		Compression:        true,
+		CompressionMinSize: 1,
+	}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	url := "ws" + server.URL[4:]
+
+	dialer := websocket.Dialer{EnableCompression: true}
+	conn, resp, err := dialer.Dial(url+"/connection/websocket", nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+	assert.Contains(t, resp.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+
+	params, _ := json.Marshal(&proto.ConnectRequest{})
+	cmdBytes, _ := json.Marshal(&proto.Command{ID: 1, Method: proto.MethodTypeConnect, Params: params})
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, cmdBytes))
+	_, data, err := conn.ReadMessage()
+	assert.NoError(t, err)
+	var reply proto.Reply
+	assert.NoError(t, json.Unmarshal(data, &reply))
+	assert.Equal(t, uint32(1), reply.ID)
+	assert.Nil(t, reply.Error)
+}
+
 func newRealConnJSON(b testing.TB, channel string, url string) *websocket.Conn {
 	conn, _, err := websocket.DefaultDialer.Dial(url+"/connection/websocket", nil)
 	assert.NoError(b, err)
@@ -69,7 +102,11 @@ func newRealConnJSON(b testing.TB, channel string, url string) *websocket.Conn {
 func newRealConnProtobuf(b testing.TB, channel string, url string) *websocket.Conn {
 	conn, _, err := websocket.DefaultDialer.Dial(url+"/connection/websocket?format=protobuf", nil)
 	assert.NoError(b, err)
+	subscribeRealConnProtobuf(b, conn, channel)
+	return conn
+}
 
+func subscribeRealConnProtobuf(b testing.TB, conn *websocket.Conn, channel string) {
 	connectRequest := &proto.ConnectRequest{}
 	params, _ := connectRequest.Marshal()
 	cmd := &proto.Command{
@@ -87,7 +124,7 @@ func newRealConnProtobuf(b testing.TB, channel string, url string) *websocket.Co
 	buf.Write(cmdBytes)
 
 	conn.WriteMessage(websocket.BinaryMessage, buf.Bytes())
-	_, _, err = conn.ReadMessage()
+	_, _, err := conn.ReadMessage()
 	assert.NoError(b, err)
 
 	subscribeRequest := &proto.SubscribeRequest{
@@ -110,7 +147,59 @@ func newRealConnProtobuf(b testing.TB, channel string, url string) *websocket.Co
 	conn.WriteMessage(websocket.BinaryMessage, buf.Bytes())
 	_, _, err = conn.ReadMessage()
 	assert.NoError(b, err)
-	return conn
+}
+
+func TestWebsocketHandlerSubprotocol(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	c := n.Config()
+	c.ClientInsecure = true
+	n.Reload(c)
+
+	mux := http.NewServeMux()
+	mux.Handle("/connection/websocket", NewWebsocketHandler(n, WebsocketConfig{}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	url := "ws" + server.URL[4:]
+
+	jsonConn := newRealConnJSON(t, "test", url)
+	defer jsonConn.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{"centrifuge-protobuf"}}
+	protobufConn, resp, err := dialer.Dial(url+"/connection/websocket", nil)
+	assert.NoError(t, err)
+	defer protobufConn.Close()
+	assert.Equal(t, "centrifuge-protobuf", resp.Header.Get("Sec-WebSocket-Protocol"))
+	subscribeRealConnProtobuf(t, protobufConn, "test")
+
+	payload := []byte{0x00, 0xff, 0x7b, 0x01}
+	assert.NoError(t, n.Publish("test", payload))
+
+	// JSON client receives binary payload base64 encoded.
+	_, data, err := jsonConn.ReadMessage()
+	assert.NoError(t, err)
+	var rep proto.Reply
+	assert.NoError(t, json.Unmarshal(data, &rep))
+	var push proto.Push
+	assert.NoError(t, json.Unmarshal(rep.Result, &push))
+	var pub proto.Publication
+	assert.NoError(t, json.Unmarshal(push.Data, &pub))
+	assert.Equal(t, payload, pub.B64Data)
+
+	// Protobuf client receives payload untouched.
+	messageType, data, err := protobufConn.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, websocket.BinaryMessage, messageType)
+	length, size := binary.Uvarint(data)
+	assert.True(t, size > 0)
+	rep = proto.Reply{}
+	assert.NoError(t, rep.Unmarshal(data[size:size+int(length)]))
+	push = proto.Push{}
+	assert.NoError(t, push.Unmarshal(rep.Result))
+	pub = proto.Publication{}
+	assert.NoError(t, pub.Unmarshal(push.Data))
+	assert.Equal(t, payload, []byte(pub.Data))
+	assert.Nil(t, pub.B64Data)
 }
 
 // TestWebsocketHandlerConcurrentConnections allows to catch errors related
@@ -0,0 +1,61 @@
+package centrifuge
+
+import (
+	"sync"
+)
+
+// nodeHealth keeps node readiness state and notifies watchers about its
+// changes.
+type nodeHealth struct {
+	mu          sync.RWMutex
+	running     bool
+	shutdown    bool
+	maintenance bool
+	// changed is closed and replaced on every state change.
+	changed chan struct{}
+}
+
+func newNodeHealth() *nodeHealth {
+	return &nodeHealth{
+		changed: make(chan struct{}),
+	}
+}
+
+func (h *nodeHealth) update(fn func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	before := h.readyLocked()
+	fn()
+	if h.readyLocked() != before {
+		close(h.changed)
+		h.changed = make(chan struct{})
+	}
+}
+
+func (h *nodeHealth) readyLocked() bool {
+	return h.running && !h.shutdown && !h.maintenance
+}
+
+// state returns current readiness and channel which will be closed on its
+// change.
+func (h *nodeHealth) state() (bool, chan struct{}) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.readyLocked(), h.changed
+}
+
+// Ready returns true when node is running and can accept new connections,
+// i.e. it's not in maintenance mode and not shutting down.
+func (n *Node) Ready() bool {
+	ready, _ := n.health.state()
+	return ready
+}
+
+// SetMaintenance turns node maintenance mode on or off. Node in maintenance
+// mode is reported as not serving by health checks so load balancers stop
+// routing new connections to it, existing connections are not affected.
+func (n *Node) SetMaintenance(enabled bool) {
+	n.health.update(func() {
+		n.health.maintenance = enabled
+	})
+}
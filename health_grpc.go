@@ -0,0 +1,76 @@
+package centrifuge
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// grpcAPIServiceName is a full name of GRPC API service.
+const grpcAPIServiceName = "apiproto.CentrifugeApi"
+
+// RegisterGRPCServerHealth registers grpc.health.v1 Health service in
+// provided GRPC server. Health of empty service name and of GRPC API service
+// reflects node readiness: node is not serving until started, in
+// maintenance mode and during shutdown.
+func RegisterGRPCServerHealth(n *Node, server *grpc.Server) error {
+	healthpb.RegisterHealthServer(server, newGRPCHealthService(n))
+	return nil
+}
+
+// grpcHealthService implements GRPC health checking protocol.
+type grpcHealthService struct {
+	node *Node
+}
+
+func newGRPCHealthService(n *Node) *grpcHealthService {
+	return &grpcHealthService{
+		node: n,
+	}
+}
+
+func healthStatus(ready bool) healthpb.HealthCheckResponse_ServingStatus {
+	if ready {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+func knownHealthService(service string) bool {
+	return service == "" || service == grpcAPIServiceName
+}
+
+// Check returns current serving status of service.
+func (s *grpcHealthService) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if !knownHealthService(req.Service) {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	return &healthpb.HealthCheckResponse{Status: healthStatus(s.node.Ready())}, nil
+}
+
+// Watch streams serving status of service on every its change.
+func (s *grpcHealthService) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	if !knownHealthService(req.Service) {
+		// Streaming SERVICE_UNKNOWN status as required by protocol, service
+		// can't appear later.
+		if err := stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVICE_UNKNOWN}); err != nil {
+			return err
+		}
+		<-stream.Context().Done()
+		return status.Error(codes.Canceled, "stream has ended")
+	}
+	for {
+		ready, changed := s.node.health.state()
+		if err := stream.Send(&healthpb.HealthCheckResponse{Status: healthStatus(ready)}); err != nil {
+			return err
+		}
+		select {
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		case <-changed:
+		}
+	}
+}
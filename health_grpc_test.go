@@ -0,0 +1,90 @@
+package centrifuge
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func newTestGRPCHealthClient(t *testing.T, n *Node) (healthpb.HealthClient, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	assert.NoError(t, RegisterGRPCServerHealth(n, server))
+	go server.Serve(lis)
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	assert.NoError(t, err)
+	return healthpb.NewHealthClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func TestGRPCHealthCheck(t *testing.T) {
+	n, _ := New(DefaultConfig)
+	client, stop := newTestGRPCHealthClient(t, n)
+	defer stop()
+	ctx := context.Background()
+
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+
+	assert.NoError(t, n.Run())
+	resp, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: grpcAPIServiceName})
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	assert.True(t, n.Ready())
+
+	n.SetMaintenance(true)
+	resp, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+	n.SetMaintenance(false)
+	assert.True(t, n.Ready())
+
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	assert.NoError(t, n.Shutdown(ctx))
+	resp, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+}
+
+func TestGRPCHealthWatch(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	client, stop := newTestGRPCHealthClient(t, n)
+	defer stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+	resp, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+	n.SetMaintenance(true)
+	resp, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+
+	n.SetMaintenance(false)
+	resp, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+	unknown, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.NoError(t, err)
+	resp, err = unknown.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, resp.Status)
+}
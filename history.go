@@ -0,0 +1,53 @@
+package centrifuge
+
+// HistoryOptions define some fields to alter behaviour of History operation.
+type HistoryOptions struct {
+	// Since makes history return publications after position. Nil means
+	// publications from beginning of channel history stream.
+	Since *RecoveryPosition
+	// Limit of publications to return. Zero or negative value means no limit.
+	Limit int
+}
+
+// HistoryOption is a type to represent various History options.
+type HistoryOption func(*HistoryOptions)
+
+// WithSince allows to get publications after position.
+func WithSince(pos RecoveryPosition) HistoryOption {
+	return func(opts *HistoryOptions) {
+		opts.Since = &pos
+	}
+}
+
+// WithLimit allows to limit number of publications returned.
+func WithLimit(limit int) HistoryOption {
+	return func(opts *HistoryOptions) {
+		opts.Limit = limit
+	}
+}
+
+// HistoryResult contains publications of channel history and current
+// position of channel stream. Publications are ordered from oldest to
+// newest, so next page can be requested with position of last publication
+// passed to WithSince. When Since used and first publication does not
+// directly follow it or Epoch of Position differs from Since then some
+// publications were already removed from history.
+type HistoryResult struct {
+	Publications []*Publication
+	Position     RecoveryPosition
+}
+
+func historyFilter(opts []HistoryOption) HistoryFilter {
+	historyOpts := &HistoryOptions{}
+	for _, opt := range opts {
+		opt(historyOpts)
+	}
+	limit := historyOpts.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+	return HistoryFilter{
+		Since: historyOpts.Since,
+		Limit: limit,
+	}
+}
@@ -0,0 +1,35 @@
+package centrifuge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeHistoryOptions(t *testing.T) {
+	node := nodeWithHistory(t, 10)
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, node.Publish("test", []byte(`{}`)))
+	}
+
+	history, err := node.History("test")
+	assert.NoError(t, err)
+	assert.Len(t, history.Publications, 5)
+	assert.Equal(t, uint32(5), history.Position.Seq)
+
+	history, err = node.History("test", WithLimit(2))
+	assert.NoError(t, err)
+	assert.Len(t, history.Publications, 2)
+	assert.Equal(t, uint32(1), history.Publications[0].Seq)
+
+	since := RecoveryPosition{Seq: 3, Epoch: history.Position.Epoch}
+	history, err = node.History("test", WithSince(since))
+	assert.NoError(t, err)
+	assert.Len(t, history.Publications, 2)
+	assert.Equal(t, uint32(4), history.Publications[0].Seq)
+
+	history, err = node.History("test", WithSince(history.Position))
+	assert.NoError(t, err)
+	assert.Len(t, history.Publications, 0)
+	assert.Equal(t, uint32(5), history.Position.Seq)
+}
@@ -2,7 +2,9 @@ package centrifuge
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"time"
 
 	"github.com/centrifugal/centrifuge/internal/proto"
 )
@@ -65,7 +67,9 @@ const (
 )
 
 // shutdown unsubscribes users from all channels and disconnects them.
-func (h *Hub) shutdown(ctx context.Context) error {
+// Disconnects are spread randomly over drainInterval so clients do not
+// reconnect at once.
+func (h *Hub) shutdown(ctx context.Context, drainInterval time.Duration) error {
 	advice := DisconnectShutdown
 
 	// Limit concurrency here to prevent resource usage burst on shutdown.
@@ -87,7 +91,19 @@ func (h *Hub) shutdown(ctx context.Context) error {
 		return nil
 	}
 
-	for _, client := range clients {
+	started := time.Now()
+	delays := drainDelays(ctx, len(clients), drainInterval)
+
+	for i, client := range clients {
+		if wait := delays[i] - time.Since(started); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
 		select {
 		case sem <- struct{}{}:
 		case <-ctx.Done():
@@ -113,15 +129,24 @@ func (h *Hub) shutdown(ctx context.Context) error {
 	}
 }
 
-func (h *Hub) disconnect(user string, reconnect bool) error {
+func (h *Hub) disconnect(user string, disconnect *Disconnect, whitelist []string) error {
 	userConnections := h.userConnections(user)
-	advice := DisconnectForceNoReconnect
-	if reconnect {
-		advice = DisconnectForceReconnect
+	for _, uid := range whitelist {
+		delete(userConnections, uid)
 	}
 	for _, c := range userConnections {
 		go func(cc *Client) {
-			cc.Close(advice)
+			cc.Close(disconnect)
+		}(c)
+	}
+	return nil
+}
+
+func (h *Hub) demandRefresh(user string) error {
+	userConnections := h.userConnections(user)
+	for _, c := range userConnections {
+		go func(cc *Client) {
+			cc.demandRefresh()
 		}(c)
 	}
 	return nil
@@ -138,14 +163,47 @@ func (h *Hub) unsubscribe(user string, ch string) error {
 	return nil
 }
 
-// add adds connection into clientHub connections registry.
-func (h *Hub) add(c *Client) error {
+func (h *Hub) subscribe(user string, ch string) error {
+	userConnections := h.userConnections(user)
+	for _, c := range userConnections {
+		err := c.Subscribe(ch)
+		if err != nil && err != ErrorAlreadySubscribed {
+			return err
+		}
+	}
+	return nil
+}
+
+// hubLimits are connection limits checked by hub when connection added.
+type hubLimits struct {
+	// connections limits number of connections on node, 0 - unlimited.
+	connections int
+	// userConnections limits number of connections of one user, 0 - unlimited.
+	userConnections int
+}
+
+var (
+	errHubConnectionLimit     = errors.New("connection limit reached")
+	errHubUserConnectionLimit = errors.New("user connection limit reached")
+)
+
+// add adds connection into clientHub connections registry. Connection is
+// not added if it exceeds one of limits.
+func (h *Hub) add(c *Client, limits hubLimits) error {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
 	uid := c.ID()
 	user := c.UserID()
 
+	if _, ok := h.conns[uid]; !ok && limits.connections > 0 && len(h.conns) >= limits.connections {
+		return errHubConnectionLimit
+	}
+	// Anonymous connections share empty user ID so not limited per user.
+	if _, ok := h.users[user][uid]; !ok && limits.userConnections > 0 && user != "" && len(h.users[user]) >= limits.userConnections {
+		return errHubUserConnectionLimit
+	}
+
 	h.conns[uid] = c
 
 	_, ok := h.users[user]
@@ -207,6 +265,24 @@ func (h *Hub) userConnections(userID string) map[string]*Client {
 	return conns
 }
 
+// connection returns client connection with specified ID, nil if client
+// is not connected to this node.
+func (h *Hub) connection(uid string) *Client {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.conns[uid]
+}
+
+// send sends async message to client connection with specified ID if it's
+// connected to this node.
+func (h *Hub) send(uid string, data Raw) error {
+	c := h.connection(uid)
+	if c == nil {
+		return nil
+	}
+	return c.Send(data)
+}
+
 // addSub adds connection into clientHub subscriptions registry.
 func (h *Hub) addSub(ch string, c *Client) (bool, error) {
 	h.mu.Lock()
@@ -265,6 +341,9 @@ func (h *Hub) broadcastPublication(channel string, pub *Publication, chOpts *Cha
 		return nil
 	}
 
+	// Clients see partition channels under original channel name.
+	name := logicalChannel(channel)
+
 	var jsonReply *preparedReply
 	var protobufReply *preparedReply
 
@@ -281,7 +360,7 @@ func (h *Hub) broadcastPublication(channel string, pub *Publication, chOpts *Cha
 				if err != nil {
 					return err
 				}
-				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewPublicationPush(channel, data))
+				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewPublicationPush(name, data))
 				if err != nil {
 					return err
 				}
@@ -290,14 +369,14 @@ func (h *Hub) broadcastPublication(channel string, pub *Publication, chOpts *Cha
 				}
 				jsonReply = newPreparedReply(reply, proto.EncodingJSON)
 			}
-			c.writePublication(channel, pub, jsonReply, chOpts)
+			c.writePublication(name, pub, jsonReply, chOpts)
 		} else if enc == proto.EncodingProtobuf {
 			if protobufReply == nil {
 				data, err := proto.GetPushEncoder(enc).EncodePublication(pub)
 				if err != nil {
 					return err
 				}
-				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewPublicationPush(channel, data))
+				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewPublicationPush(name, data))
 				if err != nil {
 					return err
 				}
@@ -306,8 +385,9 @@ func (h *Hub) broadcastPublication(channel string, pub *Publication, chOpts *Cha
 				}
 				protobufReply = newPreparedReply(reply, proto.EncodingProtobuf)
 			}
-			c.writePublication(channel, pub, protobufReply, chOpts)
+			c.writePublication(name, pub, protobufReply, chOpts)
 		}
+		c.node.analytics.delivery(c, name, pub)
 	}
 	return nil
 }
@@ -323,6 +403,8 @@ func (h *Hub) broadcastJoin(channel string, join *proto.Join) error {
 		return nil
 	}
 
+	name := logicalChannel(channel)
+
 	var jsonReply *preparedReply
 	var protobufReply *preparedReply
 
@@ -339,7 +421,7 @@ func (h *Hub) broadcastJoin(channel string, join *proto.Join) error {
 				if err != nil {
 					return err
 				}
-				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewJoinPush(channel, data))
+				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewJoinPush(name, data))
 				if err != nil {
 					return err
 				}
@@ -348,14 +430,14 @@ func (h *Hub) broadcastJoin(channel string, join *proto.Join) error {
 				}
 				jsonReply = newPreparedReply(reply, proto.EncodingJSON)
 			}
-			c.writeJoin(channel, jsonReply)
+			c.writeJoin(name, jsonReply)
 		} else if enc == proto.EncodingProtobuf {
 			if protobufReply == nil {
 				data, err := proto.GetPushEncoder(enc).EncodeJoin(join)
 				if err != nil {
 					return err
 				}
-				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewJoinPush(channel, data))
+				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewJoinPush(name, data))
 				if err != nil {
 					return err
 				}
@@ -364,7 +446,7 @@ func (h *Hub) broadcastJoin(channel string, join *proto.Join) error {
 				}
 				protobufReply = newPreparedReply(reply, proto.EncodingProtobuf)
 			}
-			c.writeJoin(channel, protobufReply)
+			c.writeJoin(name, protobufReply)
 		}
 	}
 	return nil
@@ -381,6 +463,8 @@ func (h *Hub) broadcastLeave(channel string, leave *proto.Leave) error {
 		return nil
 	}
 
+	name := logicalChannel(channel)
+
 	var jsonReply *preparedReply
 	var protobufReply *preparedReply
 
@@ -397,7 +481,7 @@ func (h *Hub) broadcastLeave(channel string, leave *proto.Leave) error {
 				if err != nil {
 					return err
 				}
-				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewLeavePush(channel, data))
+				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewLeavePush(name, data))
 				if err != nil {
 					return err
 				}
@@ -406,14 +490,74 @@ func (h *Hub) broadcastLeave(channel string, leave *proto.Leave) error {
 				}
 				jsonReply = newPreparedReply(reply, proto.EncodingJSON)
 			}
-			c.writeLeave(channel, jsonReply)
+			c.writeLeave(name, jsonReply)
 		} else if enc == proto.EncodingProtobuf {
 			if protobufReply == nil {
 				data, err := proto.GetPushEncoder(enc).EncodeLeave(leave)
 				if err != nil {
 					return err
 				}
-				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewLeavePush(channel, data))
+				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewLeavePush(name, data))
+				if err != nil {
+					return err
+				}
+				reply := &proto.Reply{
+					Result: messageBytes,
+				}
+				protobufReply = newPreparedReply(reply, proto.EncodingProtobuf)
+			}
+			c.writeLeave(name, protobufReply)
+		}
+	}
+	return nil
+}
+
+// broadcastMembership sends membership summary to all clients subscribed on channel.
+func (h *Hub) broadcastMembership(channel string, membership *proto.Membership) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	// get connections currently subscribed on channel
+	channelSubscriptions, ok := h.subs[channel]
+	if !ok {
+		return nil
+	}
+
+	name := logicalChannel(channel)
+
+	var jsonReply *preparedReply
+	var protobufReply *preparedReply
+
+	// iterate over them and send message individually
+	for uid := range channelSubscriptions {
+		c, ok := h.conns[uid]
+		if !ok {
+			continue
+		}
+		enc := c.Transport().Encoding()
+		if enc == proto.EncodingJSON {
+			if jsonReply == nil {
+				data, err := proto.GetPushEncoder(enc).EncodeMembership(membership)
+				if err != nil {
+					return err
+				}
+				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewMembershipPush(name, data))
+				if err != nil {
+					return err
+				}
+				reply := &proto.Reply{
+					Result: messageBytes,
+				}
+				jsonReply = newPreparedReply(reply, proto.EncodingJSON)
+			}
+			c.writeMembership(name, jsonReply)
+		} else if enc == proto.EncodingProtobuf {
+			if protobufReply == nil {
+				data, err := proto.GetPushEncoder(enc).EncodeMembership(membership)
+				if err != nil {
+					return err
+				}
+				messageBytes, err := proto.GetPushEncoder(enc).Encode(proto.NewMembershipPush(name, data))
 				if err != nil {
 					return err
 				}
@@ -422,7 +566,7 @@ func (h *Hub) broadcastLeave(channel string, leave *proto.Leave) error {
 				}
 				protobufReply = newPreparedReply(reply, proto.EncodingProtobuf)
 			}
-			c.writeLeave(channel, protobufReply)
+			c.writeMembership(name, protobufReply)
 		}
 	}
 	return nil
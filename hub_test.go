@@ -3,6 +3,7 @@ package centrifuge
 import (
 	"context"
 	"io"
+	"net/http"
 	"sync"
 	"testing"
 
@@ -16,6 +17,7 @@ type testTransport struct {
 	sink       chan []byte
 	closed     bool
 	disconnect *Disconnect
+	req        *http.Request
 }
 
 func newTestTransport() *testTransport {
@@ -43,7 +45,7 @@ func (t *testTransport) Encoding() Encoding {
 }
 
 func (t *testTransport) Info() TransportInfo {
-	return TransportInfo{}
+	return TransportInfo{Request: t.req}
 }
 
 func (t *testTransport) Close(disconnect *Disconnect) error {
@@ -59,7 +61,7 @@ func TestHub(t *testing.T) {
 	c, err := newClient(context.Background(), nodeWithMemoryEngine(), newTestTransport())
 	assert.NoError(t, err)
 	c.user = "test"
-	h.add(c)
+	h.add(c, hubLimits{})
 	assert.Equal(t, len(h.users), 1)
 	conns := h.userConnections("test")
 	assert.Equal(t, 1, len(conns))
@@ -70,15 +72,40 @@ func TestHub(t *testing.T) {
 	assert.Equal(t, 1, len(conns))
 }
 
+func TestHubLimits(t *testing.T) {
+	h := newHub()
+	node := nodeWithMemoryEngine()
+	newUserClient := func(user string) *Client {
+		c, err := newClient(context.Background(), node, newTestTransport())
+		assert.NoError(t, err)
+		c.user = user
+		return c
+	}
+	limits := hubLimits{connections: 3, userConnections: 2}
+
+	c1 := newUserClient("test")
+	assert.NoError(t, h.add(c1, limits))
+	assert.NoError(t, h.add(newUserClient("test"), limits))
+	assert.Equal(t, errHubUserConnectionLimit, h.add(newUserClient("test"), limits))
+	// Adding already added connection is not limited.
+	assert.NoError(t, h.add(c1, limits))
+	assert.NoError(t, h.add(newUserClient("other"), limits))
+	assert.Equal(t, errHubConnectionLimit, h.add(newUserClient("another"), limits))
+	assert.Equal(t, 3, h.NumClients())
+
+	h.remove(c1)
+	assert.NoError(t, h.add(newUserClient("test"), limits))
+}
+
 func TestHubShutdown(t *testing.T) {
 	h := newHub()
-	err := h.shutdown(context.Background())
+	err := h.shutdown(context.Background(), 0)
 	assert.NoError(t, err)
 	h = newHub()
 	c, err := newClient(context.Background(), nodeWithMemoryEngine(), newTestTransport())
 	assert.NoError(t, err)
-	h.add(c)
-	err = h.shutdown(context.Background())
+	h.add(c, hubLimits{})
+	err = h.shutdown(context.Background(), 0)
 	assert.NoError(t, err)
 }
 
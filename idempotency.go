@@ -0,0 +1,97 @@
+package centrifuge
+
+import (
+	"errors"
+	"time"
+)
+
+// defaultPublishIdempotencyWindow used if Config.PublishIdempotencyWindow
+// is not set.
+const defaultPublishIdempotencyWindow = 5 * time.Minute
+
+var (
+	// ErrIdempotencyNotSupported returned when publication has idempotency
+	// key but engine does not implement IdempotencyStore.
+	ErrIdempotencyNotSupported = errors.New("idempotent publish not supported")
+	// ErrPublishInProgress returned when publish with the same idempotency
+	// key is still in progress so it's not known yet whether publication
+	// will be published. Publish can be retried later.
+	ErrPublishInProgress = errors.New("publish with the same idempotency key in progress")
+)
+
+// IdempotencyKeyStatus is a status of idempotency key returned by
+// IdempotencyStore on save.
+type IdempotencyKeyStatus int
+
+const (
+	// IdempotencyKeySaved means key was not used before and now saved as
+	// pending, so publication must be published.
+	IdempotencyKeySaved IdempotencyKeyStatus = iota
+	// IdempotencyKeyPending means publish with key is still in progress.
+	IdempotencyKeyPending
+	// IdempotencyKeyPublished means publication with key already published.
+	IdempotencyKeyPublished
+)
+
+// IdempotencyStore is an optional part of Engine which remembers
+// idempotency keys of publications so publish retried with the same key
+// is not delivered twice. Keys are shared by all nodes when store is.
+type IdempotencyStore interface {
+	// SaveIdempotencyKey saves key of publication into channel as pending
+	// for ttl if key is not saved yet, otherwise current status of key
+	// returned.
+	SaveIdempotencyKey(ch string, key string, ttl time.Duration) (IdempotencyKeyStatus, error)
+	// ConfirmIdempotencyKey marks pending key as published for ttl.
+	ConfirmIdempotencyKey(ch string, key string, ttl time.Duration) error
+	// RemoveIdempotencyKey removes key so publish failed can be retried.
+	RemoveIdempotencyKey(ch string, key string) error
+}
+
+// SetIdempotencyStore allows to set IdempotencyStore to use.
+func (n *Node) SetIdempotencyStore(s IdempotencyStore) {
+	n.idempotencyStore = s
+}
+
+// WithIdempotencyKey allows to set idempotency key of publication. Publish
+// with key already used in channel during Config.PublishIdempotencyWindow
+// returns nil without publishing again if first publish succeeded, and
+// ErrPublishInProgress if first publish has not finished yet. Key of failed
+// publish is forgotten so publish can be retried with it.
+func WithIdempotencyKey(key string) PublishOption {
+	return func(opts *PublishOptions) {
+		opts.IdempotencyKey = key
+	}
+}
+
+func (n *Node) idempotencyWindow() time.Duration {
+	window := n.Config().PublishIdempotencyWindow
+	if window <= 0 {
+		window = defaultPublishIdempotencyWindow
+	}
+	return window
+}
+
+// saveIdempotencyKey saves key of publication, IdempotencyKeySaved returned
+// means publication must be published.
+func (n *Node) saveIdempotencyKey(ch string, key string) (IdempotencyKeyStatus, error) {
+	if n.idempotencyStore == nil {
+		return 0, ErrIdempotencyNotSupported
+	}
+	actionCount.WithLabelValues("save_idempotency_key").Inc()
+	return n.idempotencyStore.SaveIdempotencyKey(ch, key, n.idempotencyWindow())
+}
+
+// confirmIdempotencyKey marks key of publication as published.
+func (n *Node) confirmIdempotencyKey(ch string, key string) {
+	if err := n.idempotencyStore.ConfirmIdempotencyKey(ch, key, n.idempotencyWindow()); err != nil {
+		// Duplicates get ErrPublishInProgress until key expires.
+		n.logger.log(newLogEntry(LogLevelError, "error confirming idempotency key", map[string]interface{}{"channel": ch, "key": key, "error": err.Error()}))
+	}
+}
+
+// removeIdempotencyKey forgets key of publication which was not published.
+func (n *Node) removeIdempotencyKey(ch string, key string) {
+	if err := n.idempotencyStore.RemoveIdempotencyKey(ch, key); err != nil {
+		n.logger.log(newLogEntry(LogLevelError, "error removing idempotency key", map[string]interface{}{"channel": ch, "key": key, "error": err.Error()}))
+	}
+}
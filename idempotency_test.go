@@ -0,0 +1,164 @@
+package centrifuge
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyHub(t *testing.T) {
+	h := newIdempotencyHub()
+	status, err := h.save("test", "key", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, IdempotencyKeySaved, status)
+	status, _ = h.save("test", "key", time.Minute)
+	assert.Equal(t, IdempotencyKeyPending, status)
+	status, _ = h.save("other", "key", time.Minute)
+	assert.Equal(t, IdempotencyKeySaved, status)
+
+	assert.NoError(t, h.confirm("test", "key", time.Minute))
+	status, _ = h.save("test", "key", time.Minute)
+	assert.Equal(t, IdempotencyKeyPublished, status)
+	// Confirm does not save unknown key.
+	assert.NoError(t, h.confirm("unknown", "key", time.Minute))
+	status, _ = h.save("unknown", "key", time.Minute)
+	assert.Equal(t, IdempotencyKeySaved, status)
+
+	assert.NoError(t, h.remove("test", "key"))
+	status, _ = h.save("test", "key", time.Millisecond)
+	assert.Equal(t, IdempotencyKeySaved, status)
+	time.Sleep(5 * time.Millisecond)
+	status, _ = h.save("test", "key", time.Minute)
+	assert.Equal(t, IdempotencyKeySaved, status)
+}
+
+func nodeWithIdempotentHistory(t *testing.T) *Node {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	assert.NoError(t, node.Reload(config))
+	return node
+}
+
+func TestNodePublishIdempotencyKey(t *testing.T) {
+	node := nodeWithIdempotentHistory(t)
+
+	assert.NoError(t, node.Publish("test", []byte(`1`), WithIdempotencyKey("key")))
+	assert.NoError(t, node.Publish("test", []byte(`1`), WithIdempotencyKey("key")))
+	assert.NoError(t, node.Publish("test", []byte(`2`), WithIdempotencyKey("other")))
+	assert.NoError(t, node.Publish("test", []byte(`3`)))
+
+	res, err := node.History("test")
+	assert.NoError(t, err)
+	assert.Len(t, res.Publications, 3)
+}
+
+// failingHistoryEngine fails to add publications into history.
+type failingHistoryEngine struct {
+	*MemoryEngine
+	fail bool
+}
+
+func (e *failingHistoryEngine) AddHistory(ch string, pub *Publication, opts *ChannelOptions) (*Publication, error) {
+	if e.fail {
+		return nil, errors.New("boom")
+	}
+	return e.MemoryEngine.AddHistory(ch, pub, opts)
+}
+
+func TestNodePublishIdempotencyKeyRetryAfterError(t *testing.T) {
+	node := nodeWithIdempotentHistory(t)
+	e := &failingHistoryEngine{MemoryEngine: node.historyManager.(*MemoryEngine), fail: true}
+	node.SetHistoryManager(e)
+
+	assert.Error(t, node.Publish("test", []byte(`1`), WithIdempotencyKey("key")))
+	e.fail = false
+	assert.NoError(t, node.Publish("test", []byte(`1`), WithIdempotencyKey("key")))
+	res, err := node.History("test")
+	assert.NoError(t, err)
+	assert.Len(t, res.Publications, 1)
+}
+
+// blockingHistoryEngine blocks adding publications into history until
+// released.
+type blockingHistoryEngine struct {
+	*MemoryEngine
+	started chan struct{}
+	release chan struct{}
+}
+
+func (e *blockingHistoryEngine) AddHistory(ch string, pub *Publication, opts *ChannelOptions) (*Publication, error) {
+	close(e.started)
+	<-e.release
+	return e.MemoryEngine.AddHistory(ch, pub, opts)
+}
+
+func TestNodePublishIdempotencyKeyInProgress(t *testing.T) {
+	node := nodeWithIdempotentHistory(t)
+	e := &blockingHistoryEngine{
+		MemoryEngine: node.historyManager.(*MemoryEngine),
+		started:      make(chan struct{}),
+		release:      make(chan struct{}),
+	}
+	node.SetHistoryManager(e)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- node.Publish("test", []byte(`1`), WithIdempotencyKey("key"))
+	}()
+	select {
+	case <-e.started:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for first publish")
+	}
+	assert.Equal(t, ErrPublishInProgress, node.Publish("test", []byte(`1`), WithIdempotencyKey("key")))
+
+	close(e.release)
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for first publish")
+	}
+	node.SetHistoryManager(e.MemoryEngine)
+	assert.NoError(t, node.Publish("test", []byte(`1`), WithIdempotencyKey("key")))
+	res, err := node.History("test")
+	assert.NoError(t, err)
+	assert.Len(t, res.Publications, 1)
+}
+
+func TestNodePublishIdempotencyNotSupported(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	node.SetIdempotencyStore(nil)
+	assert.Equal(t, ErrIdempotencyNotSupported, node.Publish("test", []byte(`1`), WithIdempotencyKey("key")))
+}
+
+func TestClientPublishIdempotencyKey(t *testing.T) {
+	node := nodeWithIdempotentHistory(t)
+	config := node.Config()
+	config.Publish = true
+	assert.NoError(t, node.Reload(config))
+
+	publish := func(user string, key string) {
+		transport := newTestTransport()
+		ctx := SetCredentials(context.Background(), &Credentials{UserID: user})
+		client, _ := newClient(ctx, node, transport)
+		connectClient(t, client)
+		resp, disconnect := client.publishCmd(&proto.PublishRequest{Channel: "test", Data: []byte(`{}`), IdempotencyKey: key})
+		assert.Nil(t, disconnect)
+		assert.Nil(t, resp.Error)
+	}
+	publish("42", "key")
+	publish("42", "key")
+	// Keys of different users do not clash.
+	publish("43", "key")
+
+	res, err := node.History("test")
+	assert.NoError(t, err)
+	assert.Len(t, res.Publications, 2)
+}
@@ -0,0 +1,23 @@
+package centrifuge
+
+import (
+	"time"
+)
+
+const (
+	ingestMinRetryDelay = 100 * time.Millisecond
+	ingestMaxRetryDelay = 10 * time.Second
+)
+
+// ingestRetryDelay returns exponentially growing delay before next attempt
+// of ingest to publish message or to reconnect to external system.
+func ingestRetryDelay(attempt int) time.Duration {
+	delay := ingestMinRetryDelay
+	for i := 0; i < attempt && delay < ingestMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > ingestMaxRetryDelay {
+		delay = ingestMaxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,82 @@
+package centrifuge
+
+import (
+	"context"
+	"strings"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+)
+
+// CommandEvent describes client command passed to CommandHandler.
+type CommandEvent struct {
+	// Method is a lowercase name of command method, for example "connect",
+	// "subscribe", "publish" or "rpc".
+	Method string
+	// Params are command params encoded by client.
+	Params Raw
+}
+
+// CommandReply is a result of client command handling.
+type CommandReply struct {
+	// Error sent to client in reply, nil if command succeeded.
+	Error *Error
+	// Disconnect closes client connection if set.
+	Disconnect *Disconnect
+}
+
+// CommandHandler handles client command.
+type CommandHandler func(context.Context, *Client, CommandEvent) CommandReply
+
+// CommandInterceptor wraps CommandHandler to add behaviour around handling
+// of every client command: logging, tracing, rate limiting etc. Reply
+// returned by next handler is already sent to client when next returns.
+// Interceptor can reject command without calling next by returning reply
+// with Error or Disconnect set – such Error is sent to client.
+type CommandInterceptor func(next CommandHandler) CommandHandler
+
+// Use adds interceptors to chain wrapping handling of client commands.
+// Interceptors called in order they were added: first one is outermost.
+// Must be called before Node.Run.
+func (n *Node) Use(interceptors ...CommandInterceptor) {
+	n.interceptors = append(n.interceptors, interceptors...)
+}
+
+// interceptCommand handles command passing it through node interceptors.
+func (c *Client) interceptCommand(cmd *proto.Command, rw *replyWriter) *Disconnect {
+	var called bool
+	var replyError *Error
+	handlerRW := &replyWriter{
+		write: func(rep *proto.Reply) error {
+			replyError = rep.Error
+			return rw.write(rep)
+		},
+		flush: rw.flush,
+	}
+
+	var handler CommandHandler = func(ctx context.Context, client *Client, e CommandEvent) CommandReply {
+		called = true
+		disconnect := c.dispatch(cmd.Method, cmd.Params, handlerRW)
+		return CommandReply{Error: replyError, Disconnect: disconnect}
+	}
+	interceptors := c.node.interceptors
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		handler = interceptors[i](handler)
+	}
+
+	reply := handler(c.ctx, c, CommandEvent{
+		Method: strings.ToLower(proto.MethodType_name[int32(cmd.Method)]),
+		Params: Raw(cmd.Params),
+	})
+	if reply.Disconnect != nil {
+		return reply.Disconnect
+	}
+	if !called && cmd.Method != proto.MethodTypeSend {
+		// Command rejected by interceptor, client still waits for reply.
+		replyError := reply.Error
+		if replyError == nil {
+			replyError = ErrorInternal
+		}
+		rw.write(&proto.Reply{Error: replyError})
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package centrifuge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func handleTestCommand(client *Client, cmd *proto.Command) ([]*proto.Reply, *Disconnect) {
+	replies := []*proto.Reply{}
+	writeFn := func(rep *proto.Reply) error {
+		replies = append(replies, rep)
+		return nil
+	}
+	flushFn := func() error { return nil }
+	disconnect := client.handle(cmd, writeFn, flushFn)
+	return replies, disconnect
+}
+
+func TestNodeUseInterceptorOrder(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	var calls []string
+	node.Use(func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, c *Client, e CommandEvent) CommandReply {
+			calls = append(calls, "first:"+e.Method)
+			reply := next(ctx, c, e)
+			calls = append(calls, "first:done")
+			return reply
+		}
+	}, func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, c *Client, e CommandEvent) CommandReply {
+			calls = append(calls, "second:"+e.Method)
+			reply := next(ctx, c, e)
+			assert.Equal(t, "42", c.UserID())
+			return reply
+		}
+	})
+
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, newTestTransport())
+
+	replies, disconnect := handleTestCommand(client, &proto.Command{
+		ID:     1,
+		Method: proto.MethodTypeConnect,
+		Params: []byte(`{}`),
+	})
+	assert.Nil(t, disconnect)
+	assert.Len(t, replies, 1)
+	assert.Nil(t, replies[0].Error)
+	assert.True(t, client.authenticated)
+	assert.Equal(t, []string{"first:connect", "second:connect", "first:done"}, calls)
+}
+
+func TestNodeUseInterceptorReject(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.Publish = true
+	node.Reload(config)
+
+	node.Use(func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, c *Client, e CommandEvent) CommandReply {
+			if e.Method == "publish" {
+				return CommandReply{Error: ErrorPermissionDenied}
+			}
+			return next(ctx, c, e)
+		}
+	})
+
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, newTestTransport())
+	connectClient(t, client)
+
+	replies, disconnect := handleTestCommand(client, &proto.Command{
+		ID:     2,
+		Method: proto.MethodTypePublish,
+		Params: []byte(`{"channel":"test","data":{}}`),
+	})
+	assert.Nil(t, disconnect)
+	assert.Len(t, replies, 1)
+	assert.Equal(t, uint32(2), replies[0].ID)
+	assert.Equal(t, ErrorPermissionDenied, replies[0].Error)
+
+	replies, disconnect = handleTestCommand(client, &proto.Command{
+		ID:     3,
+		Method: proto.MethodTypePing,
+	})
+	assert.Nil(t, disconnect)
+	assert.Len(t, replies, 1)
+	assert.Nil(t, replies[0].Error)
+}
+
+func TestNodeUseInterceptorReply(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	var replyErrors []*Error
+	node.Use(func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, c *Client, e CommandEvent) CommandReply {
+			reply := next(ctx, c, e)
+			replyErrors = append(replyErrors, reply.Error)
+			return reply
+		}
+	})
+
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, newTestTransport())
+	connectClient(t, client)
+
+	replies, disconnect := handleTestCommand(client, &proto.Command{
+		ID:     1,
+		Method: proto.MethodTypePublish,
+		Params: []byte(`{"channel":"test","data":{}}`),
+	})
+	assert.Nil(t, disconnect)
+	assert.Len(t, replies, 1)
+	assert.Equal(t, []*Error{ErrorPermissionDenied}, replyErrors)
+
+	disconnectInterceptor := func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, c *Client, e CommandEvent) CommandReply {
+			return CommandReply{Disconnect: DisconnectBadRequest}
+		}
+	}
+	node.Use(disconnectInterceptor)
+	replies, disconnect = handleTestCommand(client, &proto.Command{
+		ID:     2,
+		Method: proto.MethodTypePing,
+	})
+	assert.Equal(t, DisconnectBadRequest, disconnect)
+	assert.Len(t, replies, 0)
+}
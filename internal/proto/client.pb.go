@@ -1,58 +1,19 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
 // source: client.proto
 
-/*
-	Package proto is a generated protocol buffer package.
-
-	It is generated from these files:
-		client.proto
-
-	It has these top-level messages:
-		Error
-		Command
-		Reply
-		Push
-		ClientInfo
-		Publication
-		Join
-		Leave
-		Unsub
-		Message
-		ConnectRequest
-		ConnectResult
-		RefreshRequest
-		RefreshResult
-		SubscribeRequest
-		SubscribeResult
-		SubRefreshRequest
-		SubRefreshResult
-		UnsubscribeRequest
-		UnsubscribeResult
-		PublishRequest
-		PublishResult
-		PresenceRequest
-		PresenceResult
-		PresenceStatsRequest
-		PresenceStatsResult
-		HistoryRequest
-		HistoryResult
-		PingRequest
-		PingResult
-		RPCRequest
-		RPCResult
-		SendRequest
-*/
 package proto
 
-import proto1 "github.com/gogo/protobuf/proto"
-import fmt "fmt"
-import math "math"
-import _ "github.com/gogo/protobuf/gogoproto"
-
-import io "io"
+import (
+	bytes "bytes"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+)
 
 // Reference imports to suppress errors if they are not otherwise used.
-var _ = proto1.Marshal
+var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
@@ -60,7 +21,7 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto1.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 type MethodType int32
 
@@ -77,6 +38,7 @@ const (
 	MethodTypeRPC           MethodType = 9
 	MethodTypeRefresh       MethodType = 10
 	MethodTypeSubRefresh    MethodType = 11
+	MethodTypeMarkRead      MethodType = 12
 )
 
 var MethodType_name = map[int32]string{
@@ -92,7 +54,9 @@ var MethodType_name = map[int32]string{
 	9:  "RPC",
 	10: "REFRESH",
 	11: "SUB_REFRESH",
+	12: "MARK_READ",
 }
+
 var MethodType_value = map[string]int32{
 	"CONNECT":        0,
 	"SUBSCRIBE":      1,
@@ -106,21 +70,28 @@ var MethodType_value = map[string]int32{
 	"RPC":            9,
 	"REFRESH":        10,
 	"SUB_REFRESH":    11,
+	"MARK_READ":      12,
 }
 
 func (x MethodType) String() string {
-	return proto1.EnumName(MethodType_name, int32(x))
+	return proto.EnumName(MethodType_name, int32(x))
+}
+
+func (MethodType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{0}
 }
-func (MethodType) EnumDescriptor() ([]byte, []int) { return fileDescriptorClient, []int{0} }
 
 type PushType int32
 
 const (
-	PushTypePublication PushType = 0
-	PushTypeJoin        PushType = 1
-	PushTypeLeave       PushType = 2
-	PushTypeUnsub       PushType = 3
-	PushTypeMessage     PushType = 4
+	PushTypePublication   PushType = 0
+	PushTypeJoin          PushType = 1
+	PushTypeLeave         PushType = 2
+	PushTypeUnsub         PushType = 3
+	PushTypeMessage       PushType = 4
+	PushTypeMembership    PushType = 5
+	PushTypeRefreshDemand PushType = 6
+	PushTypeSub           PushType = 7
 )
 
 var PushType_name = map[int32]string{
@@ -129,29 +100,95 @@ var PushType_name = map[int32]string{
 	2: "LEAVE",
 	3: "UNSUB",
 	4: "MESSAGE",
+	5: "MEMBERSHIP",
+	6: "REFRESH_DEMAND",
+	7: "SUB",
 }
+
 var PushType_value = map[string]int32{
-	"PUBLICATION": 0,
-	"JOIN":        1,
-	"LEAVE":       2,
-	"UNSUB":       3,
-	"MESSAGE":     4,
+	"PUBLICATION":    0,
+	"JOIN":           1,
+	"LEAVE":          2,
+	"UNSUB":          3,
+	"MESSAGE":        4,
+	"MEMBERSHIP":     5,
+	"REFRESH_DEMAND": 6,
+	"SUB":            7,
 }
 
 func (x PushType) String() string {
-	return proto1.EnumName(PushType_name, int32(x))
+	return proto.EnumName(PushType_name, int32(x))
+}
+
+func (PushType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{1}
+}
+
+type PublicationOp int32
+
+const (
+	PublicationOpNew       PublicationOp = 0
+	PublicationOpEdit      PublicationOp = 1
+	PublicationOpTombstone PublicationOp = 2
+)
+
+var PublicationOp_name = map[int32]string{
+	0: "NEW",
+	1: "EDIT",
+	2: "TOMBSTONE",
+}
+
+var PublicationOp_value = map[string]int32{
+	"NEW":       0,
+	"EDIT":      1,
+	"TOMBSTONE": 2,
+}
+
+func (x PublicationOp) String() string {
+	return proto.EnumName(PublicationOp_name, int32(x))
+}
+
+func (PublicationOp) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{2}
 }
-func (PushType) EnumDescriptor() ([]byte, []int) { return fileDescriptorClient, []int{1} }
 
 type Error struct {
 	Code    uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code"`
 	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message"`
 }
 
-func (m *Error) Reset()                    { *m = Error{} }
-func (m *Error) String() string            { return proto1.CompactTextString(m) }
-func (*Error) ProtoMessage()               {}
-func (*Error) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{0} }
+func (m *Error) Reset()         { *m = Error{} }
+func (m *Error) String() string { return proto.CompactTextString(m) }
+func (*Error) ProtoMessage()    {}
+func (*Error) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{0}
+}
+func (m *Error) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Error) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Error.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Error) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Error.Merge(m, src)
+}
+func (m *Error) XXX_Size() int {
+	return m.Size()
+}
+func (m *Error) XXX_DiscardUnknown() {
+	xxx_messageInfo_Error.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Error proto.InternalMessageInfo
 
 func (m *Error) GetCode() uint32 {
 	if m != nil {
@@ -173,10 +210,38 @@ type Command struct {
 	Params Raw        `protobuf:"bytes,3,opt,name=params,proto3,customtype=Raw" json:"params,omitempty"`
 }
 
-func (m *Command) Reset()                    { *m = Command{} }
-func (m *Command) String() string            { return proto1.CompactTextString(m) }
-func (*Command) ProtoMessage()               {}
-func (*Command) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{1} }
+func (m *Command) Reset()         { *m = Command{} }
+func (m *Command) String() string { return proto.CompactTextString(m) }
+func (*Command) ProtoMessage()    {}
+func (*Command) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{1}
+}
+func (m *Command) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Command) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Command.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Command) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Command.Merge(m, src)
+}
+func (m *Command) XXX_Size() int {
+	return m.Size()
+}
+func (m *Command) XXX_DiscardUnknown() {
+	xxx_messageInfo_Command.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Command proto.InternalMessageInfo
 
 func (m *Command) GetID() uint32 {
 	if m != nil {
@@ -194,14 +259,42 @@ func (m *Command) GetMethod() MethodType {
 
 type Reply struct {
 	ID     uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Error  *Error `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	Error  *Error `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	Result Raw    `protobuf:"bytes,3,opt,name=result,proto3,customtype=Raw" json:"result,omitempty"`
 }
 
-func (m *Reply) Reset()                    { *m = Reply{} }
-func (m *Reply) String() string            { return proto1.CompactTextString(m) }
-func (*Reply) ProtoMessage()               {}
-func (*Reply) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{2} }
+func (m *Reply) Reset()         { *m = Reply{} }
+func (m *Reply) String() string { return proto.CompactTextString(m) }
+func (*Reply) ProtoMessage()    {}
+func (*Reply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{2}
+}
+func (m *Reply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Reply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Reply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Reply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Reply.Merge(m, src)
+}
+func (m *Reply) XXX_Size() int {
+	return m.Size()
+}
+func (m *Reply) XXX_DiscardUnknown() {
+	xxx_messageInfo_Reply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Reply proto.InternalMessageInfo
 
 func (m *Reply) GetID() uint32 {
 	if m != nil {
@@ -223,10 +316,38 @@ type Push struct {
 	Data    Raw      `protobuf:"bytes,3,opt,name=data,proto3,customtype=Raw" json:"data"`
 }
 
-func (m *Push) Reset()                    { *m = Push{} }
-func (m *Push) String() string            { return proto1.CompactTextString(m) }
-func (*Push) ProtoMessage()               {}
-func (*Push) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{3} }
+func (m *Push) Reset()         { *m = Push{} }
+func (m *Push) String() string { return proto.CompactTextString(m) }
+func (*Push) ProtoMessage()    {}
+func (*Push) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{3}
+}
+func (m *Push) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Push) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Push.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Push) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Push.Merge(m, src)
+}
+func (m *Push) XXX_Size() int {
+	return m.Size()
+}
+func (m *Push) XXX_DiscardUnknown() {
+	xxx_messageInfo_Push.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Push proto.InternalMessageInfo
 
 func (m *Push) GetType() PushType {
 	if m != nil {
@@ -249,10 +370,38 @@ type ClientInfo struct {
 	ChanInfo Raw    `protobuf:"bytes,4,opt,name=chan_info,json=chanInfo,proto3,customtype=Raw" json:"chan_info,omitempty"`
 }
 
-func (m *ClientInfo) Reset()                    { *m = ClientInfo{} }
-func (m *ClientInfo) String() string            { return proto1.CompactTextString(m) }
-func (*ClientInfo) ProtoMessage()               {}
-func (*ClientInfo) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{4} }
+func (m *ClientInfo) Reset()         { *m = ClientInfo{} }
+func (m *ClientInfo) String() string { return proto.CompactTextString(m) }
+func (*ClientInfo) ProtoMessage()    {}
+func (*ClientInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{4}
+}
+func (m *ClientInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClientInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ClientInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ClientInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientInfo.Merge(m, src)
+}
+func (m *ClientInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClientInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientInfo proto.InternalMessageInfo
 
 func (m *ClientInfo) GetUser() string {
 	if m != nil {
@@ -269,17 +418,52 @@ func (m *ClientInfo) GetClient() string {
 }
 
 type Publication struct {
-	Seq  uint32      `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
-	Gen  uint32      `protobuf:"varint,2,opt,name=gen,proto3" json:"gen,omitempty"`
-	UID  string      `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
-	Data Raw         `protobuf:"bytes,4,opt,name=data,proto3,customtype=Raw" json:"data"`
-	Info *ClientInfo `protobuf:"bytes,5,opt,name=info" json:"info,omitempty"`
+	Seq       uint32        `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Gen       uint32        `protobuf:"varint,2,opt,name=gen,proto3" json:"gen,omitempty"`
+	UID       string        `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Data      Raw           `protobuf:"bytes,4,opt,name=data,proto3,customtype=Raw" json:"data"`
+	Info      *ClientInfo   `protobuf:"bytes,5,opt,name=info,proto3" json:"info,omitempty"`
+	ExpireAt  int64         `protobuf:"varint,6,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	Ephemeral bool          `protobuf:"varint,7,opt,name=ephemeral,proto3" json:"ephemeral,omitempty"`
+	Op        PublicationOp `protobuf:"varint,8,opt,name=op,proto3,enum=proto.PublicationOp" json:"op,omitempty"`
+	RefSeq    uint32        `protobuf:"varint,9,opt,name=ref_seq,json=refSeq,proto3" json:"ref_seq,omitempty"`
+	RefGen    uint32        `protobuf:"varint,10,opt,name=ref_gen,json=refGen,proto3" json:"ref_gen,omitempty"`
+	B64Data   []byte        `protobuf:"bytes,11,opt,name=b64data,proto3" json:"b64data,omitempty"`
+	Binary    bool          `protobuf:"varint,12,opt,name=binary,proto3" json:"-"`
+}
+
+func (m *Publication) Reset()         { *m = Publication{} }
+func (m *Publication) String() string { return proto.CompactTextString(m) }
+func (*Publication) ProtoMessage()    {}
+func (*Publication) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{5}
+}
+func (m *Publication) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Publication) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Publication.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Publication) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Publication.Merge(m, src)
+}
+func (m *Publication) XXX_Size() int {
+	return m.Size()
+}
+func (m *Publication) XXX_DiscardUnknown() {
+	xxx_messageInfo_Publication.DiscardUnknown(m)
 }
 
-func (m *Publication) Reset()                    { *m = Publication{} }
-func (m *Publication) String() string            { return proto1.CompactTextString(m) }
-func (*Publication) ProtoMessage()               {}
-func (*Publication) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{5} }
+var xxx_messageInfo_Publication proto.InternalMessageInfo
 
 func (m *Publication) GetSeq() uint32 {
 	if m != nil {
@@ -309,876 +493,2062 @@ func (m *Publication) GetInfo() *ClientInfo {
 	return nil
 }
 
-type Join struct {
-	Info ClientInfo `protobuf:"bytes,1,opt,name=info" json:"info"`
+func (m *Publication) GetExpireAt() int64 {
+	if m != nil {
+		return m.ExpireAt
+	}
+	return 0
 }
 
-func (m *Join) Reset()                    { *m = Join{} }
-func (m *Join) String() string            { return proto1.CompactTextString(m) }
-func (*Join) ProtoMessage()               {}
-func (*Join) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{6} }
-
-func (m *Join) GetInfo() ClientInfo {
+func (m *Publication) GetEphemeral() bool {
 	if m != nil {
-		return m.Info
+		return m.Ephemeral
 	}
-	return ClientInfo{}
+	return false
 }
 
-type Leave struct {
-	Info ClientInfo `protobuf:"bytes,1,opt,name=info" json:"info"`
+func (m *Publication) GetOp() PublicationOp {
+	if m != nil {
+		return m.Op
+	}
+	return PublicationOpNew
 }
 
-func (m *Leave) Reset()                    { *m = Leave{} }
-func (m *Leave) String() string            { return proto1.CompactTextString(m) }
-func (*Leave) ProtoMessage()               {}
-func (*Leave) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{7} }
-
-func (m *Leave) GetInfo() ClientInfo {
+func (m *Publication) GetRefSeq() uint32 {
 	if m != nil {
-		return m.Info
+		return m.RefSeq
 	}
-	return ClientInfo{}
+	return 0
 }
 
-type Unsub struct {
-	Resubscribe bool `protobuf:"varint,1,opt,name=resubscribe,proto3" json:"resubscribe,omitempty"`
+func (m *Publication) GetRefGen() uint32 {
+	if m != nil {
+		return m.RefGen
+	}
+	return 0
 }
 
-func (m *Unsub) Reset()                    { *m = Unsub{} }
-func (m *Unsub) String() string            { return proto1.CompactTextString(m) }
-func (*Unsub) ProtoMessage()               {}
-func (*Unsub) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{8} }
+func (m *Publication) GetB64Data() []byte {
+	if m != nil {
+		return m.B64Data
+	}
+	return nil
+}
 
-func (m *Unsub) GetResubscribe() bool {
+func (m *Publication) GetBinary() bool {
 	if m != nil {
-		return m.Resubscribe
+		return m.Binary
 	}
 	return false
 }
 
-type Message struct {
-	Data Raw `protobuf:"bytes,1,opt,name=data,proto3,customtype=Raw" json:"data"`
+type Join struct {
+	Info ClientInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info"`
 }
 
-func (m *Message) Reset()                    { *m = Message{} }
-func (m *Message) String() string            { return proto1.CompactTextString(m) }
-func (*Message) ProtoMessage()               {}
-func (*Message) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{9} }
-
-type ConnectRequest struct {
-	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token"`
-	Data  Raw    `protobuf:"bytes,2,opt,name=data,proto3,customtype=Raw" json:"data,omitempty"`
+func (m *Join) Reset()         { *m = Join{} }
+func (m *Join) String() string { return proto.CompactTextString(m) }
+func (*Join) ProtoMessage()    {}
+func (*Join) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{6}
+}
+func (m *Join) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Join) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Join.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Join) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Join.Merge(m, src)
+}
+func (m *Join) XXX_Size() int {
+	return m.Size()
+}
+func (m *Join) XXX_DiscardUnknown() {
+	xxx_messageInfo_Join.DiscardUnknown(m)
 }
 
-func (m *ConnectRequest) Reset()                    { *m = ConnectRequest{} }
-func (m *ConnectRequest) String() string            { return proto1.CompactTextString(m) }
-func (*ConnectRequest) ProtoMessage()               {}
-func (*ConnectRequest) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{10} }
+var xxx_messageInfo_Join proto.InternalMessageInfo
 
-func (m *ConnectRequest) GetToken() string {
+func (m *Join) GetInfo() ClientInfo {
 	if m != nil {
-		return m.Token
+		return m.Info
 	}
-	return ""
+	return ClientInfo{}
 }
 
-type ConnectResult struct {
-	Client  string `protobuf:"bytes,1,opt,name=client,proto3" json:"client"`
-	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version"`
-	Expires bool   `protobuf:"varint,3,opt,name=expires,proto3" json:"expires,omitempty"`
-	TTL     uint32 `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
-	Data    Raw    `protobuf:"bytes,5,opt,name=data,proto3,customtype=Raw" json:"data,omitempty"`
+type Leave struct {
+	Info ClientInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info"`
 }
 
-func (m *ConnectResult) Reset()                    { *m = ConnectResult{} }
-func (m *ConnectResult) String() string            { return proto1.CompactTextString(m) }
-func (*ConnectResult) ProtoMessage()               {}
-func (*ConnectResult) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{11} }
-
-func (m *ConnectResult) GetClient() string {
-	if m != nil {
-		return m.Client
+func (m *Leave) Reset()         { *m = Leave{} }
+func (m *Leave) String() string { return proto.CompactTextString(m) }
+func (*Leave) ProtoMessage()    {}
+func (*Leave) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{7}
+}
+func (m *Leave) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Leave) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Leave.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return ""
+}
+func (m *Leave) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Leave.Merge(m, src)
+}
+func (m *Leave) XXX_Size() int {
+	return m.Size()
+}
+func (m *Leave) XXX_DiscardUnknown() {
+	xxx_messageInfo_Leave.DiscardUnknown(m)
 }
 
-func (m *ConnectResult) GetVersion() string {
+var xxx_messageInfo_Leave proto.InternalMessageInfo
+
+func (m *Leave) GetInfo() ClientInfo {
 	if m != nil {
-		return m.Version
+		return m.Info
 	}
-	return ""
+	return ClientInfo{}
 }
 
-func (m *ConnectResult) GetExpires() bool {
-	if m != nil {
-		return m.Expires
-	}
-	return false
+type Membership struct {
+	Joined uint32        `protobuf:"varint,1,opt,name=joined,proto3" json:"joined"`
+	Left   uint32        `protobuf:"varint,2,opt,name=left,proto3" json:"left"`
+	Joins  []*ClientInfo `protobuf:"bytes,3,rep,name=joins,proto3" json:"joins,omitempty"`
+	Leaves []*ClientInfo `protobuf:"bytes,4,rep,name=leaves,proto3" json:"leaves,omitempty"`
 }
 
-func (m *ConnectResult) GetTTL() uint32 {
-	if m != nil {
-		return m.TTL
+func (m *Membership) Reset()         { *m = Membership{} }
+func (m *Membership) String() string { return proto.CompactTextString(m) }
+func (*Membership) ProtoMessage()    {}
+func (*Membership) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{8}
+}
+func (m *Membership) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Membership) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Membership.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return 0
 }
-
-type RefreshRequest struct {
-	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token"`
+func (m *Membership) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Membership.Merge(m, src)
+}
+func (m *Membership) XXX_Size() int {
+	return m.Size()
+}
+func (m *Membership) XXX_DiscardUnknown() {
+	xxx_messageInfo_Membership.DiscardUnknown(m)
 }
 
-func (m *RefreshRequest) Reset()                    { *m = RefreshRequest{} }
-func (m *RefreshRequest) String() string            { return proto1.CompactTextString(m) }
-func (*RefreshRequest) ProtoMessage()               {}
-func (*RefreshRequest) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{12} }
+var xxx_messageInfo_Membership proto.InternalMessageInfo
 
-func (m *RefreshRequest) GetToken() string {
+func (m *Membership) GetJoined() uint32 {
 	if m != nil {
-		return m.Token
+		return m.Joined
 	}
-	return ""
+	return 0
 }
 
-type RefreshResult struct {
-	Client  string `protobuf:"bytes,1,opt,name=client,proto3" json:"client"`
-	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version"`
-	Expires bool   `protobuf:"varint,3,opt,name=expires,proto3" json:"expires,omitempty"`
-	TTL     uint32 `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+func (m *Membership) GetLeft() uint32 {
+	if m != nil {
+		return m.Left
+	}
+	return 0
 }
 
-func (m *RefreshResult) Reset()                    { *m = RefreshResult{} }
-func (m *RefreshResult) String() string            { return proto1.CompactTextString(m) }
-func (*RefreshResult) ProtoMessage()               {}
-func (*RefreshResult) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{13} }
-
-func (m *RefreshResult) GetClient() string {
+func (m *Membership) GetJoins() []*ClientInfo {
 	if m != nil {
-		return m.Client
+		return m.Joins
 	}
-	return ""
+	return nil
 }
 
-func (m *RefreshResult) GetVersion() string {
+func (m *Membership) GetLeaves() []*ClientInfo {
 	if m != nil {
-		return m.Version
+		return m.Leaves
 	}
-	return ""
+	return nil
 }
 
-func (m *RefreshResult) GetExpires() bool {
-	if m != nil {
-		return m.Expires
+type RefreshDemand struct {
+	TTL uint32 `protobuf:"varint,1,opt,name=ttl,proto3" json:"ttl"`
+}
+
+func (m *RefreshDemand) Reset()         { *m = RefreshDemand{} }
+func (m *RefreshDemand) String() string { return proto.CompactTextString(m) }
+func (*RefreshDemand) ProtoMessage()    {}
+func (*RefreshDemand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{9}
+}
+func (m *RefreshDemand) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RefreshDemand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RefreshDemand.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return false
+}
+func (m *RefreshDemand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefreshDemand.Merge(m, src)
+}
+func (m *RefreshDemand) XXX_Size() int {
+	return m.Size()
+}
+func (m *RefreshDemand) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefreshDemand.DiscardUnknown(m)
 }
 
-func (m *RefreshResult) GetTTL() uint32 {
+var xxx_messageInfo_RefreshDemand proto.InternalMessageInfo
+
+func (m *RefreshDemand) GetTTL() uint32 {
 	if m != nil {
 		return m.TTL
 	}
 	return 0
 }
 
-type SubscribeRequest struct {
-	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
-	Token   string `protobuf:"bytes,2,opt,name=token,proto3" json:"token"`
-	Recover bool   `protobuf:"varint,3,opt,name=recover,proto3" json:"recover"`
-	Seq     uint32 `protobuf:"varint,4,opt,name=seq,proto3" json:"seq"`
-	Gen     uint32 `protobuf:"varint,5,opt,name=gen,proto3" json:"gen"`
-	Epoch   string `protobuf:"bytes,6,opt,name=epoch,proto3" json:"epoch"`
+type Unsub struct {
+	Resubscribe bool `protobuf:"varint,1,opt,name=resubscribe,proto3" json:"resubscribe,omitempty"`
+}
+
+func (m *Unsub) Reset()         { *m = Unsub{} }
+func (m *Unsub) String() string { return proto.CompactTextString(m) }
+func (*Unsub) ProtoMessage()    {}
+func (*Unsub) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{10}
+}
+func (m *Unsub) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Unsub) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Unsub.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Unsub) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Unsub.Merge(m, src)
+}
+func (m *Unsub) XXX_Size() int {
+	return m.Size()
+}
+func (m *Unsub) XXX_DiscardUnknown() {
+	xxx_messageInfo_Unsub.DiscardUnknown(m)
 }
 
-func (m *SubscribeRequest) Reset()                    { *m = SubscribeRequest{} }
-func (m *SubscribeRequest) String() string            { return proto1.CompactTextString(m) }
-func (*SubscribeRequest) ProtoMessage()               {}
-func (*SubscribeRequest) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{14} }
+var xxx_messageInfo_Unsub proto.InternalMessageInfo
 
-func (m *SubscribeRequest) GetChannel() string {
+func (m *Unsub) GetResubscribe() bool {
 	if m != nil {
-		return m.Channel
+		return m.Resubscribe
 	}
-	return ""
+	return false
 }
 
-func (m *SubscribeRequest) GetToken() string {
-	if m != nil {
-		return m.Token
+type Sub struct {
+	Recoverable bool   `protobuf:"varint,1,opt,name=recoverable,proto3" json:"recoverable,omitempty"`
+	Seq         uint32 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Gen         uint32 `protobuf:"varint,3,opt,name=gen,proto3" json:"gen,omitempty"`
+	Epoch       string `protobuf:"bytes,4,opt,name=epoch,proto3" json:"epoch,omitempty"`
+}
+
+func (m *Sub) Reset()         { *m = Sub{} }
+func (m *Sub) String() string { return proto.CompactTextString(m) }
+func (*Sub) ProtoMessage()    {}
+func (*Sub) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{11}
+}
+func (m *Sub) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Sub) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Sub.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return ""
+}
+func (m *Sub) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Sub.Merge(m, src)
+}
+func (m *Sub) XXX_Size() int {
+	return m.Size()
+}
+func (m *Sub) XXX_DiscardUnknown() {
+	xxx_messageInfo_Sub.DiscardUnknown(m)
 }
 
-func (m *SubscribeRequest) GetRecover() bool {
+var xxx_messageInfo_Sub proto.InternalMessageInfo
+
+func (m *Sub) GetRecoverable() bool {
 	if m != nil {
-		return m.Recover
+		return m.Recoverable
 	}
 	return false
 }
 
-func (m *SubscribeRequest) GetSeq() uint32 {
+func (m *Sub) GetSeq() uint32 {
 	if m != nil {
 		return m.Seq
 	}
 	return 0
 }
 
-func (m *SubscribeRequest) GetGen() uint32 {
+func (m *Sub) GetGen() uint32 {
 	if m != nil {
 		return m.Gen
 	}
 	return 0
 }
 
-func (m *SubscribeRequest) GetEpoch() string {
+func (m *Sub) GetEpoch() string {
 	if m != nil {
 		return m.Epoch
 	}
 	return ""
 }
 
-type SubscribeResult struct {
-	Expires      bool           `protobuf:"varint,1,opt,name=expires,proto3" json:"expires,omitempty"`
-	TTL          uint32         `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
-	Recoverable  bool           `protobuf:"varint,3,opt,name=recoverable,proto3" json:"recoverable,omitempty"`
-	Seq          uint32         `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
-	Gen          uint32         `protobuf:"varint,5,opt,name=gen,proto3" json:"gen,omitempty"`
-	Epoch        string         `protobuf:"bytes,6,opt,name=epoch,proto3" json:"epoch,omitempty"`
-	Publications []*Publication `protobuf:"bytes,7,rep,name=publications" json:"publications,omitempty"`
-	Recovered    bool           `protobuf:"varint,8,opt,name=recovered,proto3" json:"recovered,omitempty"`
+type Message struct {
+	Data    Raw    `protobuf:"bytes,1,opt,name=data,proto3,customtype=Raw" json:"data"`
+	B64Data []byte `protobuf:"bytes,2,opt,name=b64data,proto3" json:"b64data,omitempty"`
+}
+
+func (m *Message) Reset()         { *m = Message{} }
+func (m *Message) String() string { return proto.CompactTextString(m) }
+func (*Message) ProtoMessage()    {}
+func (*Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{12}
+}
+func (m *Message) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Message.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Message.Merge(m, src)
+}
+func (m *Message) XXX_Size() int {
+	return m.Size()
+}
+func (m *Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_Message.DiscardUnknown(m)
 }
 
-func (m *SubscribeResult) Reset()                    { *m = SubscribeResult{} }
-func (m *SubscribeResult) String() string            { return proto1.CompactTextString(m) }
-func (*SubscribeResult) ProtoMessage()               {}
-func (*SubscribeResult) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{15} }
+var xxx_messageInfo_Message proto.InternalMessageInfo
 
-func (m *SubscribeResult) GetExpires() bool {
+func (m *Message) GetB64Data() []byte {
 	if m != nil {
-		return m.Expires
+		return m.B64Data
 	}
-	return false
+	return nil
 }
 
-func (m *SubscribeResult) GetTTL() uint32 {
+type ConnectRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token"`
+	Data  Raw    `protobuf:"bytes,2,opt,name=data,proto3,customtype=Raw" json:"data,omitempty"`
+}
+
+func (m *ConnectRequest) Reset()         { *m = ConnectRequest{} }
+func (m *ConnectRequest) String() string { return proto.CompactTextString(m) }
+func (*ConnectRequest) ProtoMessage()    {}
+func (*ConnectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{13}
+}
+func (m *ConnectRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConnectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectRequest.Merge(m, src)
+}
+func (m *ConnectRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectRequest proto.InternalMessageInfo
+
+func (m *ConnectRequest) GetToken() string {
 	if m != nil {
-		return m.TTL
+		return m.Token
 	}
-	return 0
+	return ""
 }
 
-func (m *SubscribeResult) GetRecoverable() bool {
+type ConnectResult struct {
+	Client          string           `protobuf:"bytes,1,opt,name=client,proto3" json:"client"`
+	Version         string           `protobuf:"bytes,2,opt,name=version,proto3" json:"version"`
+	Expires         bool             `protobuf:"varint,3,opt,name=expires,proto3" json:"expires,omitempty"`
+	TTL             uint32           `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Data            Raw              `protobuf:"bytes,5,opt,name=data,proto3,customtype=Raw" json:"data,omitempty"`
+	Redirect        *Redirect        `protobuf:"bytes,6,opt,name=redirect,proto3" json:"redirect,omitempty"`
+	ReconnectAdvice *ReconnectAdvice `protobuf:"bytes,7,opt,name=reconnect_advice,json=reconnectAdvice,proto3" json:"reconnect_advice,omitempty"`
+}
+
+func (m *ConnectResult) Reset()         { *m = ConnectResult{} }
+func (m *ConnectResult) String() string { return proto.CompactTextString(m) }
+func (*ConnectResult) ProtoMessage()    {}
+func (*ConnectResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{14}
+}
+func (m *ConnectResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConnectResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectResult.Merge(m, src)
+}
+func (m *ConnectResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectResult proto.InternalMessageInfo
+
+func (m *ConnectResult) GetClient() string {
 	if m != nil {
-		return m.Recoverable
+		return m.Client
 	}
-	return false
+	return ""
 }
 
-func (m *SubscribeResult) GetSeq() uint32 {
+func (m *ConnectResult) GetVersion() string {
 	if m != nil {
-		return m.Seq
+		return m.Version
 	}
-	return 0
+	return ""
 }
 
-func (m *SubscribeResult) GetGen() uint32 {
+func (m *ConnectResult) GetExpires() bool {
 	if m != nil {
-		return m.Gen
+		return m.Expires
 	}
-	return 0
+	return false
 }
 
-func (m *SubscribeResult) GetEpoch() string {
+func (m *ConnectResult) GetTTL() uint32 {
 	if m != nil {
-		return m.Epoch
+		return m.TTL
 	}
-	return ""
+	return 0
 }
 
-func (m *SubscribeResult) GetPublications() []*Publication {
+func (m *ConnectResult) GetRedirect() *Redirect {
 	if m != nil {
-		return m.Publications
+		return m.Redirect
 	}
 	return nil
 }
 
-func (m *SubscribeResult) GetRecovered() bool {
+func (m *ConnectResult) GetReconnectAdvice() *ReconnectAdvice {
 	if m != nil {
-		return m.Recovered
+		return m.ReconnectAdvice
 	}
-	return false
+	return nil
 }
 
-type SubRefreshRequest struct {
-	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
-	Token   string `protobuf:"bytes,2,opt,name=token,proto3" json:"token"`
+type Redirect struct {
+	Endpoints []string `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints"`
+	Reason    string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *Redirect) Reset()         { *m = Redirect{} }
+func (m *Redirect) String() string { return proto.CompactTextString(m) }
+func (*Redirect) ProtoMessage()    {}
+func (*Redirect) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{15}
+}
+func (m *Redirect) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Redirect) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Redirect.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Redirect) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Redirect.Merge(m, src)
+}
+func (m *Redirect) XXX_Size() int {
+	return m.Size()
+}
+func (m *Redirect) XXX_DiscardUnknown() {
+	xxx_messageInfo_Redirect.DiscardUnknown(m)
 }
 
-func (m *SubRefreshRequest) Reset()                    { *m = SubRefreshRequest{} }
-func (m *SubRefreshRequest) String() string            { return proto1.CompactTextString(m) }
-func (*SubRefreshRequest) ProtoMessage()               {}
-func (*SubRefreshRequest) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{16} }
+var xxx_messageInfo_Redirect proto.InternalMessageInfo
 
-func (m *SubRefreshRequest) GetChannel() string {
+func (m *Redirect) GetEndpoints() []string {
 	if m != nil {
-		return m.Channel
+		return m.Endpoints
 	}
-	return ""
+	return nil
 }
 
-func (m *SubRefreshRequest) GetToken() string {
+func (m *Redirect) GetReason() string {
 	if m != nil {
-		return m.Token
+		return m.Reason
 	}
 	return ""
 }
 
-type SubRefreshResult struct {
-	Expires bool   `protobuf:"varint,1,opt,name=expires,proto3" json:"expires,omitempty"`
-	TTL     uint32 `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+type ReconnectAdvice struct {
+	MinDelay uint32 `protobuf:"varint,1,opt,name=min_delay,json=minDelay,proto3" json:"min_delay"`
+	MaxDelay uint32 `protobuf:"varint,2,opt,name=max_delay,json=maxDelay,proto3" json:"max_delay"`
+	Jitter   uint32 `protobuf:"varint,3,opt,name=jitter,proto3" json:"jitter,omitempty"`
 }
 
-func (m *SubRefreshResult) Reset()                    { *m = SubRefreshResult{} }
-func (m *SubRefreshResult) String() string            { return proto1.CompactTextString(m) }
-func (*SubRefreshResult) ProtoMessage()               {}
-func (*SubRefreshResult) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{17} }
-
-func (m *SubRefreshResult) GetExpires() bool {
-	if m != nil {
-		return m.Expires
+func (m *ReconnectAdvice) Reset()         { *m = ReconnectAdvice{} }
+func (m *ReconnectAdvice) String() string { return proto.CompactTextString(m) }
+func (*ReconnectAdvice) ProtoMessage()    {}
+func (*ReconnectAdvice) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{16}
+}
+func (m *ReconnectAdvice) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReconnectAdvice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReconnectAdvice.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return false
+}
+func (m *ReconnectAdvice) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconnectAdvice.Merge(m, src)
+}
+func (m *ReconnectAdvice) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReconnectAdvice) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconnectAdvice.DiscardUnknown(m)
 }
 
-func (m *SubRefreshResult) GetTTL() uint32 {
+var xxx_messageInfo_ReconnectAdvice proto.InternalMessageInfo
+
+func (m *ReconnectAdvice) GetMinDelay() uint32 {
 	if m != nil {
-		return m.TTL
+		return m.MinDelay
 	}
 	return 0
 }
 
-type UnsubscribeRequest struct {
-	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
+func (m *ReconnectAdvice) GetMaxDelay() uint32 {
+	if m != nil {
+		return m.MaxDelay
+	}
+	return 0
 }
 
-func (m *UnsubscribeRequest) Reset()                    { *m = UnsubscribeRequest{} }
-func (m *UnsubscribeRequest) String() string            { return proto1.CompactTextString(m) }
-func (*UnsubscribeRequest) ProtoMessage()               {}
-func (*UnsubscribeRequest) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{18} }
-
-func (m *UnsubscribeRequest) GetChannel() string {
+func (m *ReconnectAdvice) GetJitter() uint32 {
 	if m != nil {
-		return m.Channel
+		return m.Jitter
 	}
-	return ""
+	return 0
 }
 
-type UnsubscribeResult struct {
+type RefreshRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token"`
 }
 
-func (m *UnsubscribeResult) Reset()                    { *m = UnsubscribeResult{} }
-func (m *UnsubscribeResult) String() string            { return proto1.CompactTextString(m) }
-func (*UnsubscribeResult) ProtoMessage()               {}
-func (*UnsubscribeResult) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{19} }
-
-type PublishRequest struct {
-	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
-	Data    Raw    `protobuf:"bytes,2,opt,name=data,proto3,customtype=Raw" json:"data"`
+func (m *RefreshRequest) Reset()         { *m = RefreshRequest{} }
+func (m *RefreshRequest) String() string { return proto.CompactTextString(m) }
+func (*RefreshRequest) ProtoMessage()    {}
+func (*RefreshRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{17}
+}
+func (m *RefreshRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RefreshRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RefreshRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RefreshRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefreshRequest.Merge(m, src)
+}
+func (m *RefreshRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RefreshRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefreshRequest.DiscardUnknown(m)
 }
 
-func (m *PublishRequest) Reset()                    { *m = PublishRequest{} }
-func (m *PublishRequest) String() string            { return proto1.CompactTextString(m) }
-func (*PublishRequest) ProtoMessage()               {}
-func (*PublishRequest) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{20} }
+var xxx_messageInfo_RefreshRequest proto.InternalMessageInfo
 
-func (m *PublishRequest) GetChannel() string {
+func (m *RefreshRequest) GetToken() string {
 	if m != nil {
-		return m.Channel
+		return m.Token
 	}
 	return ""
 }
 
-type PublishResult struct {
+type RefreshResult struct {
+	Client  string `protobuf:"bytes,1,opt,name=client,proto3" json:"client"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version"`
+	Expires bool   `protobuf:"varint,3,opt,name=expires,proto3" json:"expires,omitempty"`
+	TTL     uint32 `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
 }
 
-func (m *PublishResult) Reset()                    { *m = PublishResult{} }
-func (m *PublishResult) String() string            { return proto1.CompactTextString(m) }
-func (*PublishResult) ProtoMessage()               {}
-func (*PublishResult) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{21} }
-
-type PresenceRequest struct {
-	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
+func (m *RefreshResult) Reset()         { *m = RefreshResult{} }
+func (m *RefreshResult) String() string { return proto.CompactTextString(m) }
+func (*RefreshResult) ProtoMessage()    {}
+func (*RefreshResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{18}
+}
+func (m *RefreshResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RefreshResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RefreshResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RefreshResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefreshResult.Merge(m, src)
+}
+func (m *RefreshResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *RefreshResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefreshResult.DiscardUnknown(m)
 }
 
-func (m *PresenceRequest) Reset()                    { *m = PresenceRequest{} }
-func (m *PresenceRequest) String() string            { return proto1.CompactTextString(m) }
-func (*PresenceRequest) ProtoMessage()               {}
-func (*PresenceRequest) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{22} }
+var xxx_messageInfo_RefreshResult proto.InternalMessageInfo
 
-func (m *PresenceRequest) GetChannel() string {
+func (m *RefreshResult) GetClient() string {
 	if m != nil {
-		return m.Channel
+		return m.Client
 	}
 	return ""
 }
 
-type PresenceResult struct {
-	Presence map[string]*ClientInfo `protobuf:"bytes,1,rep,name=presence" json:"presence" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+func (m *RefreshResult) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
 }
 
-func (m *PresenceResult) Reset()                    { *m = PresenceResult{} }
-func (m *PresenceResult) String() string            { return proto1.CompactTextString(m) }
-func (*PresenceResult) ProtoMessage()               {}
-func (*PresenceResult) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{23} }
+func (m *RefreshResult) GetExpires() bool {
+	if m != nil {
+		return m.Expires
+	}
+	return false
+}
 
-func (m *PresenceResult) GetPresence() map[string]*ClientInfo {
+func (m *RefreshResult) GetTTL() uint32 {
 	if m != nil {
-		return m.Presence
+		return m.TTL
 	}
-	return nil
+	return 0
 }
 
-type PresenceStatsRequest struct {
+type SubscribeRequest struct {
 	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
+	Token   string `protobuf:"bytes,2,opt,name=token,proto3" json:"token"`
+	Recover bool   `protobuf:"varint,3,opt,name=recover,proto3" json:"recover"`
+	Seq     uint32 `protobuf:"varint,4,opt,name=seq,proto3" json:"seq"`
+	Gen     uint32 `protobuf:"varint,5,opt,name=gen,proto3" json:"gen"`
+	Epoch   string `protobuf:"bytes,6,opt,name=epoch,proto3" json:"epoch"`
+}
+
+func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()    {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{19}
+}
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+}
+func (m *SubscribeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
 }
 
-func (m *PresenceStatsRequest) Reset()                    { *m = PresenceStatsRequest{} }
-func (m *PresenceStatsRequest) String() string            { return proto1.CompactTextString(m) }
-func (*PresenceStatsRequest) ProtoMessage()               {}
-func (*PresenceStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{24} }
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
 
-func (m *PresenceStatsRequest) GetChannel() string {
+func (m *SubscribeRequest) GetChannel() string {
 	if m != nil {
 		return m.Channel
 	}
 	return ""
 }
 
-type PresenceStatsResult struct {
-	NumClients uint32 `protobuf:"varint,1,opt,name=num_clients,json=numClients,proto3" json:"num_clients"`
-	NumUsers   uint32 `protobuf:"varint,2,opt,name=num_users,json=numUsers,proto3" json:"num_users"`
+func (m *SubscribeRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
 }
 
-func (m *PresenceStatsResult) Reset()                    { *m = PresenceStatsResult{} }
-func (m *PresenceStatsResult) String() string            { return proto1.CompactTextString(m) }
-func (*PresenceStatsResult) ProtoMessage()               {}
-func (*PresenceStatsResult) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{25} }
-
-func (m *PresenceStatsResult) GetNumClients() uint32 {
+func (m *SubscribeRequest) GetRecover() bool {
 	if m != nil {
-		return m.NumClients
+		return m.Recover
 	}
-	return 0
+	return false
 }
 
-func (m *PresenceStatsResult) GetNumUsers() uint32 {
+func (m *SubscribeRequest) GetSeq() uint32 {
 	if m != nil {
-		return m.NumUsers
+		return m.Seq
 	}
 	return 0
 }
 
-type HistoryRequest struct {
-	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
+func (m *SubscribeRequest) GetGen() uint32 {
+	if m != nil {
+		return m.Gen
+	}
+	return 0
 }
 
-func (m *HistoryRequest) Reset()                    { *m = HistoryRequest{} }
-func (m *HistoryRequest) String() string            { return proto1.CompactTextString(m) }
-func (*HistoryRequest) ProtoMessage()               {}
-func (*HistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{26} }
-
-func (m *HistoryRequest) GetChannel() string {
+func (m *SubscribeRequest) GetEpoch() string {
 	if m != nil {
-		return m.Channel
+		return m.Epoch
 	}
 	return ""
 }
 
-type HistoryResult struct {
-	Publications []*Publication `protobuf:"bytes,1,rep,name=publications" json:"publications"`
+type SubscribeResult struct {
+	Expires      bool           `protobuf:"varint,1,opt,name=expires,proto3" json:"expires,omitempty"`
+	TTL          uint32         `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Recoverable  bool           `protobuf:"varint,3,opt,name=recoverable,proto3" json:"recoverable,omitempty"`
+	Seq          uint32         `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
+	Gen          uint32         `protobuf:"varint,5,opt,name=gen,proto3" json:"gen,omitempty"`
+	Epoch        string         `protobuf:"bytes,6,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Publications []*Publication `protobuf:"bytes,7,rep,name=publications,proto3" json:"publications,omitempty"`
+	Recovered    bool           `protobuf:"varint,8,opt,name=recovered,proto3" json:"recovered,omitempty"`
 }
 
-func (m *HistoryResult) Reset()                    { *m = HistoryResult{} }
-func (m *HistoryResult) String() string            { return proto1.CompactTextString(m) }
-func (*HistoryResult) ProtoMessage()               {}
-func (*HistoryResult) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{27} }
-
-func (m *HistoryResult) GetPublications() []*Publication {
-	if m != nil {
-		return m.Publications
+func (m *SubscribeResult) Reset()         { *m = SubscribeResult{} }
+func (m *SubscribeResult) String() string { return proto.CompactTextString(m) }
+func (*SubscribeResult) ProtoMessage()    {}
+func (*SubscribeResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{20}
+}
+func (m *SubscribeResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
 }
-
-type PingRequest struct {
+func (m *SubscribeResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeResult.Merge(m, src)
 }
-
-func (m *PingRequest) Reset()                    { *m = PingRequest{} }
-func (m *PingRequest) String() string            { return proto1.CompactTextString(m) }
-func (*PingRequest) ProtoMessage()               {}
-func (*PingRequest) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{28} }
-
-type PingResult struct {
+func (m *SubscribeResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeResult.DiscardUnknown(m)
 }
 
-func (m *PingResult) Reset()                    { *m = PingResult{} }
-func (m *PingResult) String() string            { return proto1.CompactTextString(m) }
-func (*PingResult) ProtoMessage()               {}
-func (*PingResult) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{29} }
+var xxx_messageInfo_SubscribeResult proto.InternalMessageInfo
 
-type RPCRequest struct {
-	Data Raw `protobuf:"bytes,1,opt,name=data,proto3,customtype=Raw" json:"data"`
+func (m *SubscribeResult) GetExpires() bool {
+	if m != nil {
+		return m.Expires
+	}
+	return false
 }
 
-func (m *RPCRequest) Reset()                    { *m = RPCRequest{} }
-func (m *RPCRequest) String() string            { return proto1.CompactTextString(m) }
-func (*RPCRequest) ProtoMessage()               {}
-func (*RPCRequest) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{30} }
+func (m *SubscribeResult) GetTTL() uint32 {
+	if m != nil {
+		return m.TTL
+	}
+	return 0
+}
 
-type RPCResult struct {
-	Data Raw `protobuf:"bytes,1,opt,name=data,proto3,customtype=Raw" json:"data,omitempty"`
+func (m *SubscribeResult) GetRecoverable() bool {
+	if m != nil {
+		return m.Recoverable
+	}
+	return false
 }
 
-func (m *RPCResult) Reset()                    { *m = RPCResult{} }
-func (m *RPCResult) String() string            { return proto1.CompactTextString(m) }
-func (*RPCResult) ProtoMessage()               {}
-func (*RPCResult) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{31} }
+func (m *SubscribeResult) GetSeq() uint32 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
 
-type SendRequest struct {
-	Data Raw `protobuf:"bytes,1,opt,name=data,proto3,customtype=Raw" json:"data"`
+func (m *SubscribeResult) GetGen() uint32 {
+	if m != nil {
+		return m.Gen
+	}
+	return 0
 }
 
-func (m *SendRequest) Reset()                    { *m = SendRequest{} }
-func (m *SendRequest) String() string            { return proto1.CompactTextString(m) }
-func (*SendRequest) ProtoMessage()               {}
-func (*SendRequest) Descriptor() ([]byte, []int) { return fileDescriptorClient, []int{32} }
+func (m *SubscribeResult) GetEpoch() string {
+	if m != nil {
+		return m.Epoch
+	}
+	return ""
+}
 
-func init() {
-	proto1.RegisterType((*Error)(nil), "proto.Error")
-	proto1.RegisterType((*Command)(nil), "proto.Command")
-	proto1.RegisterType((*Reply)(nil), "proto.Reply")
-	proto1.RegisterType((*Push)(nil), "proto.Push")
-	proto1.RegisterType((*ClientInfo)(nil), "proto.ClientInfo")
-	proto1.RegisterType((*Publication)(nil), "proto.Publication")
-	proto1.RegisterType((*Join)(nil), "proto.Join")
-	proto1.RegisterType((*Leave)(nil), "proto.Leave")
-	proto1.RegisterType((*Unsub)(nil), "proto.Unsub")
-	proto1.RegisterType((*Message)(nil), "proto.Message")
-	proto1.RegisterType((*ConnectRequest)(nil), "proto.ConnectRequest")
-	proto1.RegisterType((*ConnectResult)(nil), "proto.ConnectResult")
-	proto1.RegisterType((*RefreshRequest)(nil), "proto.RefreshRequest")
-	proto1.RegisterType((*RefreshResult)(nil), "proto.RefreshResult")
-	proto1.RegisterType((*SubscribeRequest)(nil), "proto.SubscribeRequest")
-	proto1.RegisterType((*SubscribeResult)(nil), "proto.SubscribeResult")
-	proto1.RegisterType((*SubRefreshRequest)(nil), "proto.SubRefreshRequest")
-	proto1.RegisterType((*SubRefreshResult)(nil), "proto.SubRefreshResult")
-	proto1.RegisterType((*UnsubscribeRequest)(nil), "proto.UnsubscribeRequest")
-	proto1.RegisterType((*UnsubscribeResult)(nil), "proto.UnsubscribeResult")
-	proto1.RegisterType((*PublishRequest)(nil), "proto.PublishRequest")
-	proto1.RegisterType((*PublishResult)(nil), "proto.PublishResult")
-	proto1.RegisterType((*PresenceRequest)(nil), "proto.PresenceRequest")
-	proto1.RegisterType((*PresenceResult)(nil), "proto.PresenceResult")
-	proto1.RegisterType((*PresenceStatsRequest)(nil), "proto.PresenceStatsRequest")
-	proto1.RegisterType((*PresenceStatsResult)(nil), "proto.PresenceStatsResult")
-	proto1.RegisterType((*HistoryRequest)(nil), "proto.HistoryRequest")
-	proto1.RegisterType((*HistoryResult)(nil), "proto.HistoryResult")
-	proto1.RegisterType((*PingRequest)(nil), "proto.PingRequest")
-	proto1.RegisterType((*PingResult)(nil), "proto.PingResult")
-	proto1.RegisterType((*RPCRequest)(nil), "proto.RPCRequest")
-	proto1.RegisterType((*RPCResult)(nil), "proto.RPCResult")
-	proto1.RegisterType((*SendRequest)(nil), "proto.SendRequest")
-	proto1.RegisterEnum("proto.MethodType", MethodType_name, MethodType_value)
-	proto1.RegisterEnum("proto.PushType", PushType_name, PushType_value)
+func (m *SubscribeResult) GetPublications() []*Publication {
+	if m != nil {
+		return m.Publications
+	}
+	return nil
 }
-func (this *Error) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *SubscribeResult) GetRecovered() bool {
+	if m != nil {
+		return m.Recovered
 	}
+	return false
+}
 
-	that1, ok := that.(*Error)
-	if !ok {
-		that2, ok := that.(Error)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type SubRefreshRequest struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
+	Token   string `protobuf:"bytes,2,opt,name=token,proto3" json:"token"`
+}
+
+func (m *SubRefreshRequest) Reset()         { *m = SubRefreshRequest{} }
+func (m *SubRefreshRequest) String() string { return proto.CompactTextString(m) }
+func (*SubRefreshRequest) ProtoMessage()    {}
+func (*SubRefreshRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{21}
+}
+func (m *SubRefreshRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubRefreshRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubRefreshRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Code != that1.Code {
-		return false
-	}
-	if this.Message != that1.Message {
-		return false
+}
+func (m *SubRefreshRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubRefreshRequest.Merge(m, src)
+}
+func (m *SubRefreshRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubRefreshRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubRefreshRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubRefreshRequest proto.InternalMessageInfo
+
+func (m *SubRefreshRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
 	}
-	return true
+	return ""
 }
-func (this *Command) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *SubRefreshRequest) GetToken() string {
+	if m != nil {
+		return m.Token
 	}
+	return ""
+}
 
-	that1, ok := that.(*Command)
-	if !ok {
-		that2, ok := that.(Command)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type SubRefreshResult struct {
+	Expires bool   `protobuf:"varint,1,opt,name=expires,proto3" json:"expires,omitempty"`
+	TTL     uint32 `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+}
+
+func (m *SubRefreshResult) Reset()         { *m = SubRefreshResult{} }
+func (m *SubRefreshResult) String() string { return proto.CompactTextString(m) }
+func (*SubRefreshResult) ProtoMessage()    {}
+func (*SubRefreshResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{22}
+}
+func (m *SubRefreshResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubRefreshResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubRefreshResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.ID != that1.ID {
-		return false
-	}
-	if this.Method != that1.Method {
-		return false
-	}
-	if !this.Params.Equal(that1.Params) {
-		return false
+}
+func (m *SubRefreshResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubRefreshResult.Merge(m, src)
+}
+func (m *SubRefreshResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubRefreshResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubRefreshResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubRefreshResult proto.InternalMessageInfo
+
+func (m *SubRefreshResult) GetExpires() bool {
+	if m != nil {
+		return m.Expires
 	}
-	return true
+	return false
 }
-func (this *Reply) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *SubRefreshResult) GetTTL() uint32 {
+	if m != nil {
+		return m.TTL
 	}
+	return 0
+}
 
-	that1, ok := that.(*Reply)
-	if !ok {
-		that2, ok := that.(Reply)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type UnsubscribeRequest struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
+}
+
+func (m *UnsubscribeRequest) Reset()         { *m = UnsubscribeRequest{} }
+func (m *UnsubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*UnsubscribeRequest) ProtoMessage()    {}
+func (*UnsubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{23}
+}
+func (m *UnsubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UnsubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UnsubscribeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.ID != that1.ID {
-		return false
-	}
-	if !this.Error.Equal(that1.Error) {
-		return false
-	}
-	if !this.Result.Equal(that1.Result) {
-		return false
-	}
-	return true
 }
-func (this *Push) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *UnsubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnsubscribeRequest.Merge(m, src)
+}
+func (m *UnsubscribeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *UnsubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnsubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnsubscribeRequest proto.InternalMessageInfo
+
+func (m *UnsubscribeRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
 	}
+	return ""
+}
 
-	that1, ok := that.(*Push)
-	if !ok {
-		that2, ok := that.(Push)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type UnsubscribeResult struct {
+}
+
+func (m *UnsubscribeResult) Reset()         { *m = UnsubscribeResult{} }
+func (m *UnsubscribeResult) String() string { return proto.CompactTextString(m) }
+func (*UnsubscribeResult) ProtoMessage()    {}
+func (*UnsubscribeResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{24}
+}
+func (m *UnsubscribeResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UnsubscribeResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UnsubscribeResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Type != that1.Type {
-		return false
-	}
-	if this.Channel != that1.Channel {
-		return false
+}
+func (m *UnsubscribeResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnsubscribeResult.Merge(m, src)
+}
+func (m *UnsubscribeResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *UnsubscribeResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnsubscribeResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnsubscribeResult proto.InternalMessageInfo
+
+type PublishRequest struct {
+	Channel        string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
+	Data           Raw    `protobuf:"bytes,2,opt,name=data,proto3,customtype=Raw" json:"data"`
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+}
+
+func (m *PublishRequest) Reset()         { *m = PublishRequest{} }
+func (m *PublishRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishRequest) ProtoMessage()    {}
+func (*PublishRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{25}
+}
+func (m *PublishRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PublishRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PublishRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if !this.Data.Equal(that1.Data) {
-		return false
+}
+func (m *PublishRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishRequest.Merge(m, src)
+}
+func (m *PublishRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PublishRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishRequest proto.InternalMessageInfo
+
+func (m *PublishRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
 	}
-	return true
+	return ""
 }
-func (this *ClientInfo) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *PublishRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
 	}
+	return ""
+}
 
-	that1, ok := that.(*ClientInfo)
-	if !ok {
-		that2, ok := that.(ClientInfo)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type PublishResult struct {
+}
+
+func (m *PublishResult) Reset()         { *m = PublishResult{} }
+func (m *PublishResult) String() string { return proto.CompactTextString(m) }
+func (*PublishResult) ProtoMessage()    {}
+func (*PublishResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{26}
+}
+func (m *PublishResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PublishResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PublishResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.User != that1.User {
-		return false
-	}
-	if this.Client != that1.Client {
-		return false
-	}
-	if !this.ConnInfo.Equal(that1.ConnInfo) {
-		return false
-	}
-	if !this.ChanInfo.Equal(that1.ChanInfo) {
-		return false
-	}
-	return true
 }
-func (this *Publication) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (m *PublishResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishResult.Merge(m, src)
+}
+func (m *PublishResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *PublishResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishResult.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*Publication)
-	if !ok {
-		that2, ok := that.(Publication)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+var xxx_messageInfo_PublishResult proto.InternalMessageInfo
+
+type PresenceRequest struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
+}
+
+func (m *PresenceRequest) Reset()         { *m = PresenceRequest{} }
+func (m *PresenceRequest) String() string { return proto.CompactTextString(m) }
+func (*PresenceRequest) ProtoMessage()    {}
+func (*PresenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{27}
+}
+func (m *PresenceRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PresenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PresenceRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Seq != that1.Seq {
-		return false
-	}
-	if this.Gen != that1.Gen {
-		return false
+}
+func (m *PresenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresenceRequest.Merge(m, src)
+}
+func (m *PresenceRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PresenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresenceRequest proto.InternalMessageInfo
+
+func (m *PresenceRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
 	}
-	if this.UID != that1.UID {
-		return false
+	return ""
+}
+
+type PresenceResult struct {
+	Presence map[string]*ClientInfo `protobuf:"bytes,1,rep,name=presence,proto3" json:"presence" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *PresenceResult) Reset()         { *m = PresenceResult{} }
+func (m *PresenceResult) String() string { return proto.CompactTextString(m) }
+func (*PresenceResult) ProtoMessage()    {}
+func (*PresenceResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{28}
+}
+func (m *PresenceResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PresenceResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PresenceResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if !this.Data.Equal(that1.Data) {
-		return false
+}
+func (m *PresenceResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresenceResult.Merge(m, src)
+}
+func (m *PresenceResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *PresenceResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresenceResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresenceResult proto.InternalMessageInfo
+
+func (m *PresenceResult) GetPresence() map[string]*ClientInfo {
+	if m != nil {
+		return m.Presence
 	}
-	if !this.Info.Equal(that1.Info) {
-		return false
+	return nil
+}
+
+type PresenceStatsRequest struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
+}
+
+func (m *PresenceStatsRequest) Reset()         { *m = PresenceStatsRequest{} }
+func (m *PresenceStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*PresenceStatsRequest) ProtoMessage()    {}
+func (*PresenceStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{29}
+}
+func (m *PresenceStatsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PresenceStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PresenceStatsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *Join) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *PresenceStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresenceStatsRequest.Merge(m, src)
+}
+func (m *PresenceStatsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PresenceStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresenceStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresenceStatsRequest proto.InternalMessageInfo
+
+func (m *PresenceStatsRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
 	}
+	return ""
+}
 
-	that1, ok := that.(*Join)
-	if !ok {
-		that2, ok := that.(Join)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type PresenceStatsResult struct {
+	NumClients uint32 `protobuf:"varint,1,opt,name=num_clients,json=numClients,proto3" json:"num_clients"`
+	NumUsers   uint32 `protobuf:"varint,2,opt,name=num_users,json=numUsers,proto3" json:"num_users"`
+}
+
+func (m *PresenceStatsResult) Reset()         { *m = PresenceStatsResult{} }
+func (m *PresenceStatsResult) String() string { return proto.CompactTextString(m) }
+func (*PresenceStatsResult) ProtoMessage()    {}
+func (*PresenceStatsResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{30}
+}
+func (m *PresenceStatsResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PresenceStatsResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PresenceStatsResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Info.Equal(&that1.Info) {
-		return false
+}
+func (m *PresenceStatsResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresenceStatsResult.Merge(m, src)
+}
+func (m *PresenceStatsResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *PresenceStatsResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresenceStatsResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresenceStatsResult proto.InternalMessageInfo
+
+func (m *PresenceStatsResult) GetNumClients() uint32 {
+	if m != nil {
+		return m.NumClients
 	}
-	return true
+	return 0
 }
-func (this *Leave) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *PresenceStatsResult) GetNumUsers() uint32 {
+	if m != nil {
+		return m.NumUsers
 	}
+	return 0
+}
 
-	that1, ok := that.(*Leave)
-	if !ok {
-		that2, ok := that.(Leave)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type HistoryRequest struct {
+	Channel  string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
+	UseSince bool   `protobuf:"varint,2,opt,name=use_since,json=useSince,proto3" json:"use_since,omitempty"`
+	Seq      uint32 `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+	Gen      uint32 `protobuf:"varint,4,opt,name=gen,proto3" json:"gen,omitempty"`
+	Epoch    string `protobuf:"bytes,5,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Limit    int32  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *HistoryRequest) Reset()         { *m = HistoryRequest{} }
+func (m *HistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*HistoryRequest) ProtoMessage()    {}
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{31}
+}
+func (m *HistoryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HistoryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Info.Equal(&that1.Info) {
-		return false
+}
+func (m *HistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryRequest.Merge(m, src)
+}
+func (m *HistoryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *HistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryRequest proto.InternalMessageInfo
+
+func (m *HistoryRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
 	}
-	return true
+	return ""
 }
-func (this *Unsub) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *HistoryRequest) GetUseSince() bool {
+	if m != nil {
+		return m.UseSince
 	}
+	return false
+}
 
-	that1, ok := that.(*Unsub)
-	if !ok {
-		that2, ok := that.(Unsub)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *HistoryRequest) GetSeq() uint32 {
+	if m != nil {
+		return m.Seq
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return 0
+}
+
+func (m *HistoryRequest) GetGen() uint32 {
+	if m != nil {
+		return m.Gen
 	}
-	if this.Resubscribe != that1.Resubscribe {
-		return false
+	return 0
+}
+
+func (m *HistoryRequest) GetEpoch() string {
+	if m != nil {
+		return m.Epoch
 	}
-	return true
+	return ""
 }
-func (this *Message) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *HistoryRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
 	}
+	return 0
+}
 
-	that1, ok := that.(*Message)
-	if !ok {
-		that2, ok := that.(Message)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type HistoryResult struct {
+	Publications []*Publication `protobuf:"bytes,1,rep,name=publications,proto3" json:"publications"`
+	Seq          uint32         `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Gen          uint32         `protobuf:"varint,3,opt,name=gen,proto3" json:"gen,omitempty"`
+	Epoch        string         `protobuf:"bytes,4,opt,name=epoch,proto3" json:"epoch,omitempty"`
+}
+
+func (m *HistoryResult) Reset()         { *m = HistoryResult{} }
+func (m *HistoryResult) String() string { return proto.CompactTextString(m) }
+func (*HistoryResult) ProtoMessage()    {}
+func (*HistoryResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{32}
+}
+func (m *HistoryResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HistoryResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HistoryResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Data.Equal(that1.Data) {
-		return false
+}
+func (m *HistoryResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryResult.Merge(m, src)
+}
+func (m *HistoryResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *HistoryResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryResult proto.InternalMessageInfo
+
+func (m *HistoryResult) GetPublications() []*Publication {
+	if m != nil {
+		return m.Publications
 	}
-	return true
+	return nil
 }
-func (this *ConnectRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *HistoryResult) GetSeq() uint32 {
+	if m != nil {
+		return m.Seq
 	}
+	return 0
+}
 
-	that1, ok := that.(*ConnectRequest)
-	if !ok {
-		that2, ok := that.(ConnectRequest)
+func (m *HistoryResult) GetGen() uint32 {
+	if m != nil {
+		return m.Gen
+	}
+	return 0
+}
+
+func (m *HistoryResult) GetEpoch() string {
+	if m != nil {
+		return m.Epoch
+	}
+	return ""
+}
+
+type PingRequest struct {
+}
+
+func (m *PingRequest) Reset()         { *m = PingRequest{} }
+func (m *PingRequest) String() string { return proto.CompactTextString(m) }
+func (*PingRequest) ProtoMessage()    {}
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{33}
+}
+func (m *PingRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PingRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PingRequest.Merge(m, src)
+}
+func (m *PingRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PingRequest proto.InternalMessageInfo
+
+type PingResult struct {
+}
+
+func (m *PingResult) Reset()         { *m = PingResult{} }
+func (m *PingResult) String() string { return proto.CompactTextString(m) }
+func (*PingResult) ProtoMessage()    {}
+func (*PingResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{34}
+}
+func (m *PingResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PingResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PingResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PingResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PingResult.Merge(m, src)
+}
+func (m *PingResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *PingResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_PingResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PingResult proto.InternalMessageInfo
+
+type RPCRequest struct {
+	Data   Raw    `protobuf:"bytes,1,opt,name=data,proto3,customtype=Raw" json:"data"`
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+}
+
+func (m *RPCRequest) Reset()         { *m = RPCRequest{} }
+func (m *RPCRequest) String() string { return proto.CompactTextString(m) }
+func (*RPCRequest) ProtoMessage()    {}
+func (*RPCRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{35}
+}
+func (m *RPCRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RPCRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RPCRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RPCRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RPCRequest.Merge(m, src)
+}
+func (m *RPCRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RPCRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RPCRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RPCRequest proto.InternalMessageInfo
+
+func (m *RPCRequest) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+type RPCResult struct {
+	Data Raw `protobuf:"bytes,1,opt,name=data,proto3,customtype=Raw" json:"data,omitempty"`
+}
+
+func (m *RPCResult) Reset()         { *m = RPCResult{} }
+func (m *RPCResult) String() string { return proto.CompactTextString(m) }
+func (*RPCResult) ProtoMessage()    {}
+func (*RPCResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{36}
+}
+func (m *RPCResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RPCResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RPCResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RPCResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RPCResult.Merge(m, src)
+}
+func (m *RPCResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *RPCResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_RPCResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RPCResult proto.InternalMessageInfo
+
+type SendRequest struct {
+	Data Raw `protobuf:"bytes,1,opt,name=data,proto3,customtype=Raw" json:"data"`
+}
+
+func (m *SendRequest) Reset()         { *m = SendRequest{} }
+func (m *SendRequest) String() string { return proto.CompactTextString(m) }
+func (*SendRequest) ProtoMessage()    {}
+func (*SendRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{37}
+}
+func (m *SendRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SendRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SendRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendRequest.Merge(m, src)
+}
+func (m *SendRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SendRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendRequest proto.InternalMessageInfo
+
+type MarkReadRequest struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
+	Seq     uint32 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq"`
+	Gen     uint32 `protobuf:"varint,3,opt,name=gen,proto3" json:"gen"`
+	Epoch   string `protobuf:"bytes,4,opt,name=epoch,proto3" json:"epoch"`
+}
+
+func (m *MarkReadRequest) Reset()         { *m = MarkReadRequest{} }
+func (m *MarkReadRequest) String() string { return proto.CompactTextString(m) }
+func (*MarkReadRequest) ProtoMessage()    {}
+func (*MarkReadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{38}
+}
+func (m *MarkReadRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MarkReadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MarkReadRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MarkReadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MarkReadRequest.Merge(m, src)
+}
+func (m *MarkReadRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *MarkReadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MarkReadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MarkReadRequest proto.InternalMessageInfo
+
+func (m *MarkReadRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *MarkReadRequest) GetSeq() uint32 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *MarkReadRequest) GetGen() uint32 {
+	if m != nil {
+		return m.Gen
+	}
+	return 0
+}
+
+func (m *MarkReadRequest) GetEpoch() string {
+	if m != nil {
+		return m.Epoch
+	}
+	return ""
+}
+
+type MarkReadResult struct {
+}
+
+func (m *MarkReadResult) Reset()         { *m = MarkReadResult{} }
+func (m *MarkReadResult) String() string { return proto.CompactTextString(m) }
+func (*MarkReadResult) ProtoMessage()    {}
+func (*MarkReadResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_014de31d7ac8c57c, []int{39}
+}
+func (m *MarkReadResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MarkReadResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MarkReadResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MarkReadResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MarkReadResult.Merge(m, src)
+}
+func (m *MarkReadResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *MarkReadResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_MarkReadResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MarkReadResult proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("proto.MethodType", MethodType_name, MethodType_value)
+	proto.RegisterEnum("proto.PushType", PushType_name, PushType_value)
+	proto.RegisterEnum("proto.PublicationOp", PublicationOp_name, PublicationOp_value)
+	proto.RegisterType((*Error)(nil), "proto.Error")
+	proto.RegisterType((*Command)(nil), "proto.Command")
+	proto.RegisterType((*Reply)(nil), "proto.Reply")
+	proto.RegisterType((*Push)(nil), "proto.Push")
+	proto.RegisterType((*ClientInfo)(nil), "proto.ClientInfo")
+	proto.RegisterType((*Publication)(nil), "proto.Publication")
+	proto.RegisterType((*Join)(nil), "proto.Join")
+	proto.RegisterType((*Leave)(nil), "proto.Leave")
+	proto.RegisterType((*Membership)(nil), "proto.Membership")
+	proto.RegisterType((*RefreshDemand)(nil), "proto.RefreshDemand")
+	proto.RegisterType((*Unsub)(nil), "proto.Unsub")
+	proto.RegisterType((*Sub)(nil), "proto.Sub")
+	proto.RegisterType((*Message)(nil), "proto.Message")
+	proto.RegisterType((*ConnectRequest)(nil), "proto.ConnectRequest")
+	proto.RegisterType((*ConnectResult)(nil), "proto.ConnectResult")
+	proto.RegisterType((*Redirect)(nil), "proto.Redirect")
+	proto.RegisterType((*ReconnectAdvice)(nil), "proto.ReconnectAdvice")
+	proto.RegisterType((*RefreshRequest)(nil), "proto.RefreshRequest")
+	proto.RegisterType((*RefreshResult)(nil), "proto.RefreshResult")
+	proto.RegisterType((*SubscribeRequest)(nil), "proto.SubscribeRequest")
+	proto.RegisterType((*SubscribeResult)(nil), "proto.SubscribeResult")
+	proto.RegisterType((*SubRefreshRequest)(nil), "proto.SubRefreshRequest")
+	proto.RegisterType((*SubRefreshResult)(nil), "proto.SubRefreshResult")
+	proto.RegisterType((*UnsubscribeRequest)(nil), "proto.UnsubscribeRequest")
+	proto.RegisterType((*UnsubscribeResult)(nil), "proto.UnsubscribeResult")
+	proto.RegisterType((*PublishRequest)(nil), "proto.PublishRequest")
+	proto.RegisterType((*PublishResult)(nil), "proto.PublishResult")
+	proto.RegisterType((*PresenceRequest)(nil), "proto.PresenceRequest")
+	proto.RegisterType((*PresenceResult)(nil), "proto.PresenceResult")
+	proto.RegisterMapType((map[string]*ClientInfo)(nil), "proto.PresenceResult.PresenceEntry")
+	proto.RegisterType((*PresenceStatsRequest)(nil), "proto.PresenceStatsRequest")
+	proto.RegisterType((*PresenceStatsResult)(nil), "proto.PresenceStatsResult")
+	proto.RegisterType((*HistoryRequest)(nil), "proto.HistoryRequest")
+	proto.RegisterType((*HistoryResult)(nil), "proto.HistoryResult")
+	proto.RegisterType((*PingRequest)(nil), "proto.PingRequest")
+	proto.RegisterType((*PingResult)(nil), "proto.PingResult")
+	proto.RegisterType((*RPCRequest)(nil), "proto.RPCRequest")
+	proto.RegisterType((*RPCResult)(nil), "proto.RPCResult")
+	proto.RegisterType((*SendRequest)(nil), "proto.SendRequest")
+	proto.RegisterType((*MarkReadRequest)(nil), "proto.MarkReadRequest")
+	proto.RegisterType((*MarkReadResult)(nil), "proto.MarkReadResult")
+}
+
+func init() { proto.RegisterFile("client.proto", fileDescriptor_014de31d7ac8c57c) }
+
+var fileDescriptor_014de31d7ac8c57c = []byte{
+	// 2351 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0x4f, 0x6f, 0xdb, 0xc8,
+	0x15, 0x37, 0x45, 0xc9, 0x92, 0x9e, 0x2c, 0x89, 0x1e, 0x3b, 0x5e, 0x59, 0x4d, 0x4c, 0x81, 0xbb,
+	0x49, 0x1c, 0x37, 0x7f, 0x9a, 0x6c, 0x9a, 0x6c, 0x37, 0xdb, 0x2e, 0x2c, 0x59, 0x1b, 0x7b, 0x37,
+	0x96, 0x0d, 0x4a, 0x6e, 0x51, 0xf4, 0x20, 0x50, 0xd2, 0xd8, 0xe6, 0x46, 0x22, 0x15, 0x92, 0xca,
+	0xc6, 0xb7, 0x1e, 0x0b, 0x01, 0x45, 0x8b, 0x5e, 0x8a, 0x1e, 0x74, 0x28, 0x8a, 0x02, 0x0b, 0xec,
+	0xa1, 0xc7, 0xf6, 0x1b, 0x74, 0x2f, 0x2d, 0x72, 0x5c, 0xf4, 0x40, 0xb4, 0xce, 0x4d, 0x5f, 0xa0,
+	0x3d, 0x16, 0xf3, 0x87, 0xd4, 0x50, 0x89, 0x13, 0x39, 0x68, 0x51, 0xf4, 0x22, 0x91, 0xbf, 0xf7,
+	0x66, 0xe6, 0xcd, 0x7b, 0x6f, 0x7e, 0xef, 0x71, 0x60, 0xa1, 0xdd, 0x35, 0xb1, 0xe5, 0xdd, 0xec,
+	0x3b, 0xb6, 0x67, 0xa3, 0x04, 0xfd, 0x2b, 0xde, 0x38, 0x32, 0xbd, 0xe3, 0x41, 0xeb, 0x66, 0xdb,
+	0xee, 0xdd, 0x3a, 0xb2, 0x8f, 0xec, 0x5b, 0x14, 0x6e, 0x0d, 0x0e, 0xe9, 0x1b, 0x7d, 0xa1, 0x4f,
+	0x6c, 0x94, 0xf6, 0x08, 0x12, 0x55, 0xc7, 0xb1, 0x1d, 0x74, 0x11, 0xe2, 0x6d, 0xbb, 0x83, 0x0b,
+	0x52, 0x49, 0x5a, 0xcf, 0x96, 0x53, 0x63, 0x5f, 0xa5, 0xef, 0x3a, 0xfd, 0x45, 0x97, 0x21, 0xd9,
+	0xc3, 0xae, 0x6b, 0x1c, 0xe1, 0x42, 0xac, 0x24, 0xad, 0xa7, 0xcb, 0x99, 0xb1, 0xaf, 0x06, 0x90,
+	0x1e, 0x3c, 0x68, 0x5f, 0x49, 0x90, 0xac, 0xd8, 0xbd, 0x9e, 0x61, 0x75, 0xd0, 0x15, 0x88, 0x99,
+	0x1d, 0x3e, 0xdd, 0xca, 0xa9, 0xaf, 0xc6, 0x76, 0xb6, 0xc6, 0xbe, 0xba, 0x60, 0x76, 0xae, 0xdb,
+	0x3d, 0xd3, 0xc3, 0xbd, 0xbe, 0x77, 0xa2, 0xc7, 0xcc, 0x0e, 0xfa, 0x18, 0xe6, 0x7b, 0xd8, 0x3b,
+	0xb6, 0x3b, 0x74, 0xe6, 0xdc, 0x9d, 0x45, 0x66, 0xd9, 0xcd, 0x5d, 0x0a, 0x36, 0x4e, 0xfa, 0xb8,
+	0xbc, 0x3c, 0xf6, 0x55, 0x85, 0x29, 0x09, 0x83, 0xf9, 0x30, 0x74, 0x1f, 0xe6, 0xfb, 0x86, 0x63,
+	0xf4, 0xdc, 0x82, 0x5c, 0x92, 0xd6, 0x17, 0xca, 0xea, 0xd7, 0xbe, 0x3a, 0xf7, 0x37, 0x5f, 0x95,
+	0x75, 0xe3, 0x0b, 0x32, 0x90, 0x09, 0xc5, 0x81, 0x0c, 0xd1, 0x7e, 0x2b, 0x41, 0x42, 0xc7, 0xfd,
+	0xee, 0xc9, 0xcc, 0xb6, 0xde, 0x87, 0x04, 0x26, 0xde, 0xa2, 0xa6, 0x66, 0xee, 0x2c, 0x70, 0x53,
+	0xa9, 0x07, 0xcb, 0x4b, 0x63, 0x5f, 0xcd, 0x53, 0xb1, 0x30, 0x8a, 0xe9, 0x13, 0x1b, 0x1d, 0xec,
+	0x0e, 0xba, 0xde, 0x19, 0x36, 0x32, 0xa1, 0x68, 0x23, 0x43, 0xb4, 0xdf, 0x48, 0x10, 0xdf, 0x1f,
+	0xb8, 0xc7, 0xe8, 0x3e, 0xc4, 0xbd, 0x93, 0x3e, 0x8b, 0x4f, 0xee, 0x4e, 0x9e, 0xaf, 0x4c, 0x44,
+	0xd4, 0x45, 0x68, 0xec, 0xab, 0x39, 0xa2, 0x20, 0xcc, 0x41, 0x07, 0xa0, 0x5b, 0x90, 0x6c, 0x1f,
+	0x1b, 0x96, 0x85, 0xbb, 0x3c, 0x74, 0x17, 0xc6, 0xbe, 0xba, 0xc8, 0x21, 0x41, 0x3b, 0xd0, 0x42,
+	0x57, 0x21, 0xde, 0x31, 0x3c, 0x83, 0x5b, 0xba, 0x14, 0xb5, 0x94, 0x8a, 0x74, 0xfa, 0xab, 0x3d,
+	0x97, 0x00, 0x2a, 0x34, 0x05, 0x77, 0xac, 0x43, 0x9b, 0x64, 0xd0, 0xc0, 0xc5, 0x0e, 0xb5, 0x30,
+	0xcd, 0x32, 0x88, 0xbc, 0xeb, 0xf4, 0x17, 0x69, 0x30, 0xcf, 0xd2, 0x95, 0x5b, 0x01, 0x63, 0x5f,
+	0xe5, 0x88, 0xce, 0xff, 0xd1, 0xc7, 0x90, 0x6e, 0xdb, 0x96, 0xd5, 0x34, 0xad, 0x43, 0x9b, 0x2f,
+	0xaf, 0x45, 0x97, 0x5f, 0x0a, 0xe5, 0x82, 0xe5, 0x29, 0x02, 0x52, 0x13, 0xc8, 0x04, 0xc7, 0x06,
+	0x9f, 0x20, 0xfe, 0xea, 0x09, 0x8e, 0x8d, 0x57, 0x4c, 0x70, 0x6c, 0xd0, 0x09, 0xb4, 0x3f, 0xc7,
+	0x21, 0xb3, 0x3f, 0x68, 0x75, 0xcd, 0xb6, 0xe1, 0x99, 0xb6, 0x85, 0xde, 0x05, 0xd9, 0xc5, 0x4f,
+	0x78, 0x66, 0x2c, 0x8e, 0x7d, 0x35, 0xeb, 0xe2, 0x27, 0xc2, 0x48, 0x22, 0x25, 0x4a, 0x47, 0xd8,
+	0x2a, 0xc4, 0x26, 0x4a, 0x47, 0xd8, 0x12, 0x95, 0x8e, 0xb0, 0x85, 0x36, 0x40, 0x1e, 0x98, 0x1d,
+	0xba, 0xab, 0x74, 0xb9, 0x70, 0xea, 0xab, 0xf2, 0x01, 0x4d, 0xb2, 0xec, 0x20, 0x92, 0x65, 0x44,
+	0x29, 0x8c, 0x40, 0xfc, 0x0d, 0x11, 0x40, 0xdf, 0x83, 0x38, 0xdd, 0x6a, 0x82, 0xa6, 0x63, 0x70,
+	0x72, 0x26, 0x31, 0x61, 0x69, 0x31, 0xb5, 0x5b, 0x3a, 0x04, 0xdd, 0x85, 0x34, 0x7e, 0xd6, 0x37,
+	0x1d, 0xdc, 0x34, 0xbc, 0xc2, 0x7c, 0x49, 0x5a, 0x97, 0xcb, 0xef, 0x10, 0xff, 0x84, 0xa0, 0xe8,
+	0x1f, 0x06, 0x6e, 0x7a, 0xe8, 0xbb, 0x90, 0xc6, 0xfd, 0x63, 0xdc, 0xc3, 0x8e, 0xd1, 0x2d, 0x24,
+	0x4b, 0xd2, 0x7a, 0x8a, 0x8f, 0x0a, 0x40, 0x61, 0xd4, 0x44, 0x13, 0xdd, 0x83, 0x98, 0xdd, 0x2f,
+	0xa4, 0x68, 0xea, 0x2e, 0x87, 0xa9, 0x1b, 0xba, 0x79, 0xaf, 0x5f, 0x56, 0xc8, 0x79, 0xb3, 0xfb,
+	0xe2, 0x79, 0xb3, 0xfb, 0xe8, 0x26, 0x24, 0x1d, 0x7c, 0xd8, 0x24, 0x21, 0x48, 0x53, 0xef, 0xd2,
+	0xdc, 0xe5, 0x50, 0xf4, 0xb4, 0x1c, 0xd6, 0xf1, 0x93, 0x40, 0x9f, 0x44, 0x03, 0xa2, 0xfa, 0xd1,
+	0x88, 0x10, 0xfd, 0x87, 0xd8, 0x42, 0x1f, 0x42, 0xb2, 0x75, 0xef, 0x2e, 0xf5, 0x75, 0x86, 0xfa,
+	0xba, 0x74, 0xea, 0xab, 0xc9, 0xf2, 0xbd, 0xbb, 0x5b, 0x86, 0x67, 0x90, 0xa1, 0x5c, 0x2a, 0x1e,
+	0x13, 0x0e, 0xa1, 0x4b, 0x30, 0xdf, 0x32, 0x2d, 0xc3, 0x39, 0x29, 0x2c, 0x50, 0x3f, 0x24, 0xc6,
+	0xbe, 0x2a, 0xdd, 0xd0, 0x39, 0xa8, 0x3d, 0x80, 0xf8, 0xa7, 0xb6, 0x69, 0xa1, 0xf7, 0x79, 0x88,
+	0xa4, 0xb3, 0x42, 0xb4, 0x40, 0xc2, 0x4b, 0xe2, 0x4a, 0xd4, 0x58, 0x70, 0xb4, 0x8f, 0x20, 0xf1,
+	0x08, 0x1b, 0x4f, 0xf1, 0xdb, 0x8d, 0xfe, 0x8b, 0x04, 0xb0, 0x8b, 0x7b, 0x2d, 0xec, 0xb8, 0xc7,
+	0x66, 0x9f, 0x9c, 0xbc, 0xcf, 0x6d, 0xd3, 0xc2, 0x01, 0xc1, 0xd1, 0x93, 0xc7, 0x10, 0x9d, 0xff,
+	0x93, 0xb3, 0xdb, 0xc5, 0x87, 0x1e, 0xcf, 0x61, 0x7a, 0x76, 0xc9, 0xbb, 0x4e, 0x7f, 0xd1, 0x47,
+	0x90, 0x20, 0x7a, 0x84, 0x60, 0xe5, 0x57, 0x9b, 0x41, 0xb9, 0x8f, 0xea, 0x88, 0xdc, 0x47, 0x01,
+	0x42, 0xf0, 0x5d, 0xb2, 0x19, 0xb7, 0x10, 0x3f, 0x6b, 0x38, 0x25, 0x78, 0xa6, 0x24, 0x46, 0x89,
+	0x21, 0xda, 0x6d, 0xc8, 0xea, 0xf8, 0xd0, 0xc1, 0xee, 0xf1, 0x16, 0xa6, 0xa5, 0xa5, 0x04, 0xb2,
+	0xe7, 0x75, 0xf9, 0x76, 0x72, 0xe4, 0x2c, 0x35, 0x1a, 0x8f, 0xc6, 0xbe, 0x4a, 0x50, 0x9d, 0xfc,
+	0x68, 0x5b, 0x90, 0x38, 0xb0, 0xdc, 0x41, 0x0b, 0x3d, 0x80, 0x0c, 0x61, 0xd2, 0x96, 0xdb, 0x76,
+	0xcc, 0x16, 0x63, 0xcf, 0x54, 0x79, 0x75, 0xec, 0xab, 0x17, 0x04, 0x58, 0x58, 0x53, 0xd4, 0xd6,
+	0xfe, 0x28, 0x81, 0x5c, 0x0f, 0x26, 0x69, 0xdb, 0x4f, 0xb1, 0x63, 0xb4, 0xba, 0x53, 0x93, 0x84,
+	0x70, 0x74, 0x92, 0x10, 0x0e, 0x28, 0x24, 0x36, 0x0b, 0x85, 0xc8, 0xaf, 0xa5, 0x90, 0x6b, 0x90,
+	0xc0, 0x7d, 0xbb, 0x7d, 0x4c, 0x79, 0x21, 0xcd, 0xeb, 0x0d, 0x01, 0x22, 0xf5, 0x86, 0x00, 0x9a,
+	0x05, 0xc9, 0x5d, 0x56, 0x93, 0x43, 0x32, 0x91, 0xde, 0x44, 0x26, 0xc2, 0x61, 0x88, 0x9d, 0xf3,
+	0x30, 0x68, 0x1d, 0xc8, 0x55, 0x6c, 0xcb, 0xc2, 0x6d, 0x4f, 0xc7, 0x4f, 0x06, 0xd8, 0xf5, 0x90,
+	0x0a, 0x09, 0xcf, 0x7e, 0x8c, 0x2d, 0x5e, 0x0e, 0xd2, 0x63, 0x5f, 0x65, 0x80, 0xce, 0xfe, 0xd0,
+	0x6d, 0x88, 0x0b, 0x6b, 0x5d, 0x8a, 0xda, 0x95, 0x9b, 0x5a, 0x88, 0x15, 0x9c, 0x5f, 0xc9, 0x90,
+	0x0d, 0x97, 0x21, 0xe5, 0x51, 0xa8, 0x2a, 0xd2, 0x99, 0x55, 0xe5, 0x32, 0x24, 0x9f, 0x62, 0xc7,
+	0x35, 0x6d, 0x4b, 0xec, 0x5d, 0x38, 0xa4, 0x07, 0x0f, 0xa4, 0x4e, 0x32, 0x9a, 0x63, 0x7d, 0x44,
+	0x8a, 0x71, 0x07, 0x87, 0xc4, 0x3d, 0x73, 0x08, 0x6d, 0xb0, 0x2c, 0x8c, 0xd3, 0x98, 0x15, 0x26,
+	0x59, 0x98, 0xf5, 0x3c, 0x91, 0x06, 0x89, 0x52, 0xb8, 0xd9, 0xc4, 0xcc, 0x9b, 0x45, 0x15, 0x48,
+	0x39, 0xb8, 0x63, 0x3a, 0xb8, 0xcd, 0xf8, 0x39, 0x13, 0x16, 0x7d, 0x9d, 0xc3, 0xe5, 0x95, 0xb1,
+	0xaf, 0xa2, 0x40, 0x49, 0xe4, 0xeb, 0x00, 0x43, 0x06, 0x28, 0x24, 0x17, 0xa9, 0xcb, 0x9a, 0x46,
+	0xe7, 0xa9, 0xd9, 0xc6, 0x94, 0xb6, 0x33, 0x77, 0x56, 0xc2, 0xc9, 0xb8, 0x78, 0x93, 0x4a, 0xcb,
+	0x6b, 0x63, 0x5f, 0x2d, 0x4e, 0x8f, 0x11, 0xe6, 0xce, 0x3b, 0xd1, 0x01, 0x1a, 0x86, 0x54, 0x60,
+	0x10, 0xfa, 0x36, 0xa4, 0xb1, 0xd5, 0xe9, 0xdb, 0xa6, 0xe5, 0xb9, 0x05, 0xa9, 0x24, 0xaf, 0xa7,
+	0xcb, 0xd9, 0xb1, 0xaf, 0x4e, 0x40, 0x7d, 0xf2, 0x88, 0xae, 0x93, 0x9e, 0xc8, 0x70, 0xc3, 0xb0,
+	0x2c, 0xb3, 0x46, 0x88, 0x20, 0x51, 0xaa, 0x26, 0x88, 0xf6, 0x6b, 0x09, 0xf2, 0x53, 0xb6, 0xa2,
+	0x0d, 0x48, 0xf7, 0x4c, 0xab, 0xd9, 0xc1, 0x5d, 0xe3, 0x84, 0xb3, 0x01, 0x5d, 0x2e, 0x04, 0xf5,
+	0x54, 0xcf, 0xb4, 0xb6, 0xc8, 0x13, 0xd5, 0x35, 0x9e, 0x71, 0xdd, 0x98, 0xa0, 0x1b, 0x80, 0x7a,
+	0xaa, 0x67, 0x3c, 0x63, 0xba, 0xd7, 0x61, 0xfe, 0x73, 0xd3, 0xf3, 0xb0, 0xc3, 0x0f, 0x24, 0xb5,
+	0x8c, 0x21, 0xa2, 0x65, 0x0c, 0xd1, 0x6e, 0x43, 0x8e, 0xd3, 0xd3, 0xac, 0xb9, 0x4f, 0x88, 0x25,
+	0x1b, 0x8e, 0xf9, 0x7f, 0x4a, 0x64, 0xed, 0x1b, 0x09, 0x94, 0x7a, 0x40, 0x90, 0xc1, 0x7e, 0x2f,
+	0x4f, 0x5a, 0x4c, 0x69, 0x62, 0x18, 0x87, 0x26, 0x8d, 0x65, 0xe8, 0x96, 0xd8, 0x19, 0x94, 0x70,
+	0x19, 0x92, 0x9c, 0x39, 0xb9, 0xe5, 0x74, 0x1e, 0x0e, 0xe9, 0xc1, 0x03, 0x5a, 0x65, 0x8c, 0xca,
+	0xec, 0x4d, 0x12, 0xde, 0x77, 0xf1, 0x13, 0xc6, 0xa3, 0xab, 0x8c, 0x47, 0x13, 0x13, 0xd1, 0x11,
+	0xb6, 0x18, 0x7b, 0xaa, 0x01, 0x7b, 0xce, 0x4f, 0x56, 0xa7, 0x40, 0xc0, 0x99, 0x5f, 0xc9, 0x90,
+	0x17, 0xb6, 0x46, 0xc3, 0x22, 0xf8, 0x52, 0x3a, 0x8f, 0x2f, 0x63, 0xb3, 0x90, 0xc2, 0x54, 0x59,
+	0x91, 0xdf, 0xa6, 0xac, 0xc4, 0x67, 0x29, 0x2b, 0x89, 0xd9, 0xca, 0xca, 0xfc, 0x9b, 0xca, 0x0a,
+	0xd2, 0x61, 0xa1, 0x3f, 0x69, 0xdb, 0xdc, 0x42, 0x92, 0x16, 0x74, 0xf4, 0x72, 0x47, 0x57, 0x2e,
+	0x8e, 0x7d, 0x75, 0x45, 0xd4, 0x15, 0x26, 0x8b, 0xcc, 0x41, 0x5a, 0x4a, 0xbe, 0x2f, 0xdc, 0x29,
+	0xa4, 0x26, 0x2d, 0x65, 0x08, 0x8a, 0x2d, 0x65, 0x08, 0x6a, 0x3f, 0x81, 0xc5, 0xfa, 0xa0, 0x35,
+	0x75, 0xf0, 0xfe, 0x43, 0x89, 0xa8, 0xd9, 0xa0, 0x88, 0x93, 0xff, 0xd7, 0x53, 0x41, 0x7b, 0x00,
+	0x88, 0xf6, 0x2b, 0x6f, 0x73, 0xae, 0xb4, 0x25, 0x58, 0x8c, 0x0c, 0xa6, 0x1f, 0x8e, 0x5f, 0x4a,
+	0x90, 0xa3, 0x01, 0x39, 0xb7, 0x77, 0xae, 0x46, 0x0a, 0xf3, 0x6b, 0x1a, 0x86, 0x4f, 0x20, 0x6f,
+	0x76, 0x70, 0xaf, 0x6f, 0x7b, 0xd8, 0x6a, 0x9f, 0x34, 0x1f, 0xe3, 0x13, 0xfe, 0x79, 0x73, 0x69,
+	0xec, 0xab, 0xab, 0x53, 0x22, 0x61, 0xc7, 0x39, 0x41, 0xf4, 0x19, 0x3e, 0xd1, 0xf2, 0x90, 0x0d,
+	0x2d, 0xa5, 0xb6, 0x7f, 0x00, 0xf9, 0x7d, 0x07, 0xbb, 0xd8, 0x6a, 0x9f, 0xd7, 0x15, 0x7f, 0x20,
+	0xbb, 0x0e, 0x87, 0xd2, 0xb8, 0xed, 0x42, 0xaa, 0xcf, 0x11, 0x5a, 0x92, 0x32, 0x77, 0xde, 0x0d,
+	0xf2, 0x35, 0xa2, 0x18, 0xbe, 0x56, 0x2d, 0xcf, 0x39, 0x29, 0x2f, 0x8c, 0x7d, 0x35, 0x1c, 0xa8,
+	0x87, 0x4f, 0xc5, 0x1a, 0x64, 0x23, 0x8a, 0x48, 0x01, 0x99, 0xec, 0x9c, 0x5a, 0xa5, 0x93, 0x47,
+	0x74, 0x15, 0x12, 0x4f, 0x8d, 0xee, 0x00, 0xf3, 0x5b, 0x82, 0x97, 0xfb, 0x5d, 0x9d, 0xc9, 0x3f,
+	0x8c, 0x7d, 0x20, 0x69, 0xdf, 0x87, 0xe5, 0x60, 0xbe, 0xba, 0x67, 0x78, 0xee, 0x39, 0x37, 0xec,
+	0xc2, 0xd2, 0xd4, 0x70, 0xba, 0xe9, 0xef, 0x40, 0xc6, 0x1a, 0xf4, 0x9a, 0xac, 0x70, 0xb8, 0xbc,
+	0x36, 0xe6, 0xc7, 0xbe, 0x2a, 0xc2, 0x3a, 0x58, 0x83, 0x1e, 0xb3, 0x8a, 0x64, 0x6b, 0x9a, 0x88,
+	0xc8, 0xb7, 0xba, 0x2b, 0xd6, 0xc7, 0x10, 0xd4, 0x53, 0xd6, 0xa0, 0x77, 0x40, 0x9e, 0xb4, 0x9f,
+	0xc7, 0x20, 0xb7, 0x6d, 0xba, 0x9e, 0xed, 0x9c, 0x9c, 0x33, 0xb7, 0xee, 0x42, 0x7a, 0xe0, 0xe2,
+	0xa6, 0x6b, 0x92, 0x68, 0xc4, 0x26, 0x87, 0x3d, 0x04, 0xc5, 0x2e, 0x66, 0xe0, 0xe2, 0x3a, 0xc1,
+	0x02, 0xae, 0x93, 0x67, 0xe1, 0xba, 0xf8, 0x6c, 0x5c, 0x97, 0x78, 0x23, 0xd7, 0x5d, 0x83, 0x44,
+	0xd7, 0xec, 0x99, 0xac, 0xf9, 0x4a, 0x30, 0x55, 0x0a, 0x88, 0xaa, 0x14, 0xd0, 0xfe, 0x2a, 0x41,
+	0x36, 0xf4, 0x07, 0xf5, 0xff, 0xf6, 0x14, 0x51, 0x4a, 0x67, 0x12, 0x25, 0xfd, 0xf0, 0x15, 0x75,
+	0xa7, 0xe8, 0xf1, 0x7f, 0xf2, 0xf9, 0x90, 0x85, 0xcc, 0xbe, 0x69, 0x1d, 0xf1, 0xe0, 0x6a, 0x0b,
+	0x00, 0xec, 0x95, 0x9e, 0xce, 0x36, 0x80, 0xbe, 0x5f, 0x09, 0x02, 0x3f, 0xf3, 0xe7, 0xc5, 0xf5,
+	0xc8, 0x3d, 0x5f, 0xfa, 0xf5, 0x97, 0x7a, 0xda, 0x0f, 0x20, 0x4d, 0x17, 0xa1, 0xde, 0xbc, 0x1d,
+	0x59, 0x63, 0xa6, 0x4f, 0x85, 0x7b, 0x90, 0xa9, 0x63, 0xab, 0x73, 0x5e, 0x2b, 0xb5, 0x5f, 0x48,
+	0x90, 0xdf, 0x35, 0x9c, 0xc7, 0x3a, 0x36, 0x3a, 0xe7, 0xcc, 0xed, 0x55, 0x31, 0x52, 0xaf, 0x6c,
+	0x4b, 0xe4, 0xd7, 0xb5, 0x25, 0xf1, 0x33, 0xda, 0x12, 0x05, 0x72, 0x13, 0x83, 0x88, 0x3b, 0x36,
+	0xfe, 0x29, 0x93, 0xef, 0xfb, 0xe0, 0x76, 0x14, 0x69, 0x90, 0xac, 0xec, 0xd5, 0x6a, 0xd5, 0x4a,
+	0x43, 0x99, 0x2b, 0x5e, 0x18, 0x8e, 0x4a, 0x8b, 0x13, 0x21, 0xff, 0x5a, 0x42, 0x57, 0x20, 0x5d,
+	0x3f, 0x28, 0xd7, 0x2b, 0xfa, 0x4e, 0xb9, 0xaa, 0x48, 0xc5, 0x77, 0x86, 0xa3, 0xd2, 0xd2, 0x44,
+	0x2b, 0xec, 0x7a, 0xd0, 0x06, 0x64, 0x0e, 0x6a, 0x13, 0xcd, 0x58, 0x71, 0x75, 0x38, 0x2a, 0x5d,
+	0x98, 0x68, 0x0a, 0x75, 0x86, 0xac, 0xbb, 0x7f, 0x50, 0x7e, 0xb4, 0x53, 0xdf, 0x56, 0xe4, 0xe9,
+	0x75, 0x39, 0x9f, 0xa3, 0xf7, 0x20, 0xb5, 0xaf, 0x57, 0xeb, 0xd5, 0x5a, 0xa5, 0xaa, 0xc4, 0x8b,
+	0x2b, 0xc3, 0x51, 0x09, 0x09, 0x4a, 0x9c, 0xb8, 0xd0, 0x2d, 0xc8, 0x05, 0x5a, 0xcd, 0x7a, 0x63,
+	0xb3, 0x51, 0x57, 0x12, 0xc5, 0x6f, 0x0d, 0x47, 0xa5, 0x77, 0x5e, 0xd6, 0xa5, 0x24, 0x47, 0x96,
+	0xde, 0xde, 0xa9, 0x37, 0xf6, 0xf4, 0x1f, 0x2b, 0xf3, 0xd3, 0x4b, 0xf3, 0x83, 0x48, 0xae, 0x34,
+	0xf6, 0x77, 0x6a, 0x0f, 0x95, 0x64, 0x11, 0x0d, 0x47, 0xa5, 0x9c, 0x30, 0x95, 0x69, 0x1d, 0x11,
+	0x69, 0xbd, 0x5a, 0xdb, 0x52, 0x52, 0xd3, 0x52, 0x92, 0x35, 0xa8, 0x08, 0xb2, 0xbe, 0x5f, 0x51,
+	0xd2, 0xc5, 0xc5, 0xe1, 0xa8, 0x94, 0x9d, 0x08, 0xf5, 0xfd, 0x0a, 0x59, 0x5b, 0xaf, 0x7e, 0xa2,
+	0x57, 0xeb, 0xdb, 0x0a, 0x4c, 0xaf, 0xcd, 0x3b, 0x06, 0x74, 0x0d, 0x32, 0xf5, 0x83, 0x72, 0x33,
+	0xd0, 0xcb, 0x14, 0x0b, 0xc3, 0x51, 0x69, 0x39, 0xe2, 0xf0, 0x40, 0xf5, 0x32, 0xa4, 0x77, 0x37,
+	0xf5, 0xcf, 0x9a, 0x7a, 0x75, 0x73, 0x4b, 0x59, 0x98, 0x76, 0x51, 0x10, 0xf9, 0x62, 0xfc, 0x67,
+	0xbf, 0x5b, 0x9b, 0xdb, 0xf8, 0x7d, 0x0c, 0x52, 0xc1, 0x95, 0x2f, 0x5a, 0x87, 0x0c, 0xf5, 0x7f,
+	0x65, 0xb3, 0xb1, 0xb3, 0x57, 0x53, 0xe6, 0x58, 0x54, 0x03, 0xb1, 0x78, 0x8b, 0x59, 0x84, 0xf8,
+	0xa7, 0x7b, 0x3b, 0x35, 0x45, 0x2a, 0x2a, 0xc3, 0x51, 0x69, 0x21, 0x50, 0xa1, 0xf7, 0x53, 0x17,
+	0x21, 0xf1, 0xa8, 0xba, 0xf9, 0x43, 0x12, 0x6b, 0xba, 0xd9, 0x40, 0xc8, 0xee, 0x9f, 0x2e, 0x42,
+	0x82, 0xe6, 0x83, 0x22, 0x47, 0xa5, 0xec, 0x72, 0xa5, 0x04, 0xc9, 0xdd, 0x6a, 0xbd, 0xbe, 0xf9,
+	0x90, 0x04, 0x77, 0x69, 0x38, 0x2a, 0xe5, 0x03, 0x79, 0x70, 0xf9, 0x70, 0x05, 0x60, 0xb7, 0xba,
+	0x5b, 0xae, 0xea, 0xf5, 0xed, 0x9d, 0x7d, 0x25, 0xc1, 0xb6, 0x37, 0x51, 0x0a, 0xef, 0xa8, 0x6e,
+	0x40, 0x8e, 0x3b, 0xab, 0xb9, 0x55, 0xdd, 0xdd, 0xac, 0x6d, 0x29, 0xf3, 0x2c, 0xf5, 0x02, 0xdd,
+	0xe8, 0x05, 0x50, 0x01, 0x64, 0x62, 0x54, 0xb2, 0x98, 0x1f, 0x8e, 0x4a, 0x99, 0x40, 0xa7, 0x3e,
+	0x68, 0x71, 0x3f, 0xfd, 0x54, 0x82, 0xac, 0xe0, 0x80, 0xbd, 0x3e, 0xba, 0x04, 0x72, 0xad, 0xfa,
+	0x23, 0x65, 0xae, 0xb8, 0x3c, 0x1c, 0x95, 0x94, 0x88, 0xac, 0x86, 0xbf, 0x40, 0x2a, 0xc4, 0xab,
+	0x5b, 0x3b, 0x0d, 0x45, 0x62, 0x11, 0x8d, 0xc8, 0xab, 0x1d, 0xd3, 0x43, 0xd7, 0x20, 0xdd, 0xd8,
+	0xdb, 0x2d, 0xd7, 0x1b, 0x7b, 0x35, 0xe2, 0xaa, 0xe2, 0x70, 0x54, 0x5a, 0x89, 0x68, 0x35, 0xec,
+	0x5e, 0xcb, 0xf5, 0x6c, 0x0b, 0x33, 0x13, 0xca, 0xef, 0xfd, 0xeb, 0x1f, 0x6b, 0xd2, 0x97, 0xa7,
+	0x6b, 0xd2, 0x9f, 0x4e, 0xd7, 0xa4, 0xaf, 0x4f, 0xd7, 0xa4, 0xe7, 0xa7, 0x6b, 0xd2, 0xdf, 0x4f,
+	0xd7, 0xa4, 0x5f, 0xbe, 0x58, 0x9b, 0x7b, 0xfe, 0x62, 0x6d, 0xee, 0x9b, 0x17, 0x6b, 0x73, 0xad,
+	0x79, 0x5a, 0x10, 0xde, 0xff, 0xf7, 0x00, 0x01, 0x0a, 0x9b, 0xb5, 0xcb, 0x19, 0x00, 0x00,
+}
+
+func (this *Error) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Error)
+	if !ok {
+		that2, ok := that.(Error)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1190,22 +2560,22 @@ func (this *ConnectRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Token != that1.Token {
+	if this.Code != that1.Code {
 		return false
 	}
-	if !this.Data.Equal(that1.Data) {
+	if this.Message != that1.Message {
 		return false
 	}
 	return true
 }
-func (this *ConnectResult) Equal(that interface{}) bool {
+func (this *Command) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ConnectResult)
+	that1, ok := that.(*Command)
 	if !ok {
-		that2, ok := that.(ConnectResult)
+		that2, ok := that.(Command)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1217,31 +2587,25 @@ func (this *ConnectResult) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Client != that1.Client {
-		return false
-	}
-	if this.Version != that1.Version {
-		return false
-	}
-	if this.Expires != that1.Expires {
+	if this.ID != that1.ID {
 		return false
 	}
-	if this.TTL != that1.TTL {
+	if this.Method != that1.Method {
 		return false
 	}
-	if !this.Data.Equal(that1.Data) {
+	if !this.Params.Equal(that1.Params) {
 		return false
 	}
 	return true
 }
-func (this *RefreshRequest) Equal(that interface{}) bool {
+func (this *Reply) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*RefreshRequest)
+	that1, ok := that.(*Reply)
 	if !ok {
-		that2, ok := that.(RefreshRequest)
+		that2, ok := that.(Reply)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1253,19 +2617,25 @@ func (this *RefreshRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Token != that1.Token {
+	if this.ID != that1.ID {
+		return false
+	}
+	if !this.Error.Equal(that1.Error) {
+		return false
+	}
+	if !this.Result.Equal(that1.Result) {
 		return false
 	}
 	return true
 }
-func (this *RefreshResult) Equal(that interface{}) bool {
+func (this *Push) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*RefreshResult)
+	that1, ok := that.(*Push)
 	if !ok {
-		that2, ok := that.(RefreshResult)
+		that2, ok := that.(Push)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1277,28 +2647,25 @@ func (this *RefreshResult) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Client != that1.Client {
-		return false
-	}
-	if this.Version != that1.Version {
+	if this.Type != that1.Type {
 		return false
 	}
-	if this.Expires != that1.Expires {
+	if this.Channel != that1.Channel {
 		return false
 	}
-	if this.TTL != that1.TTL {
+	if !this.Data.Equal(that1.Data) {
 		return false
 	}
 	return true
 }
-func (this *SubscribeRequest) Equal(that interface{}) bool {
+func (this *ClientInfo) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*SubscribeRequest)
+	that1, ok := that.(*ClientInfo)
 	if !ok {
-		that2, ok := that.(SubscribeRequest)
+		that2, ok := that.(ClientInfo)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1310,34 +2677,28 @@ func (this *SubscribeRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Channel != that1.Channel {
-		return false
-	}
-	if this.Token != that1.Token {
-		return false
-	}
-	if this.Recover != that1.Recover {
+	if this.User != that1.User {
 		return false
 	}
-	if this.Seq != that1.Seq {
+	if this.Client != that1.Client {
 		return false
 	}
-	if this.Gen != that1.Gen {
+	if !this.ConnInfo.Equal(that1.ConnInfo) {
 		return false
 	}
-	if this.Epoch != that1.Epoch {
+	if !this.ChanInfo.Equal(that1.ChanInfo) {
 		return false
 	}
 	return true
 }
-func (this *SubscribeResult) Equal(that interface{}) bool {
+func (this *Publication) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*SubscribeResult)
+	that1, ok := that.(*Publication)
 	if !ok {
-		that2, ok := that.(SubscribeResult)
+		that2, ok := that.(Publication)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1349,45 +2710,52 @@ func (this *SubscribeResult) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Expires != that1.Expires {
+	if this.Seq != that1.Seq {
 		return false
 	}
-	if this.TTL != that1.TTL {
+	if this.Gen != that1.Gen {
 		return false
 	}
-	if this.Recoverable != that1.Recoverable {
+	if this.UID != that1.UID {
 		return false
 	}
-	if this.Seq != that1.Seq {
+	if !this.Data.Equal(that1.Data) {
 		return false
 	}
-	if this.Gen != that1.Gen {
+	if !this.Info.Equal(that1.Info) {
 		return false
 	}
-	if this.Epoch != that1.Epoch {
+	if this.ExpireAt != that1.ExpireAt {
 		return false
 	}
-	if len(this.Publications) != len(that1.Publications) {
+	if this.Ephemeral != that1.Ephemeral {
 		return false
 	}
-	for i := range this.Publications {
-		if !this.Publications[i].Equal(that1.Publications[i]) {
-			return false
-		}
+	if this.Op != that1.Op {
+		return false
 	}
-	if this.Recovered != that1.Recovered {
+	if this.RefSeq != that1.RefSeq {
+		return false
+	}
+	if this.RefGen != that1.RefGen {
+		return false
+	}
+	if !bytes.Equal(this.B64Data, that1.B64Data) {
+		return false
+	}
+	if this.Binary != that1.Binary {
 		return false
 	}
 	return true
 }
-func (this *SubRefreshRequest) Equal(that interface{}) bool {
+func (this *Join) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*SubRefreshRequest)
+	that1, ok := that.(*Join)
 	if !ok {
-		that2, ok := that.(SubRefreshRequest)
+		that2, ok := that.(Join)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1399,22 +2767,43 @@ func (this *SubRefreshRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Channel != that1.Channel {
+	if !this.Info.Equal(&that1.Info) {
 		return false
 	}
-	if this.Token != that1.Token {
+	return true
+}
+func (this *Leave) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Leave)
+	if !ok {
+		that2, ok := that.(Leave)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Info.Equal(&that1.Info) {
 		return false
 	}
 	return true
 }
-func (this *SubRefreshResult) Equal(that interface{}) bool {
+func (this *Membership) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*SubRefreshResult)
+	that1, ok := that.(*Membership)
 	if !ok {
-		that2, ok := that.(SubRefreshResult)
+		that2, ok := that.(Membership)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1426,22 +2815,38 @@ func (this *SubRefreshResult) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Expires != that1.Expires {
+	if this.Joined != that1.Joined {
 		return false
 	}
-	if this.TTL != that1.TTL {
+	if this.Left != that1.Left {
+		return false
+	}
+	if len(this.Joins) != len(that1.Joins) {
+		return false
+	}
+	for i := range this.Joins {
+		if !this.Joins[i].Equal(that1.Joins[i]) {
+			return false
+		}
+	}
+	if len(this.Leaves) != len(that1.Leaves) {
 		return false
 	}
+	for i := range this.Leaves {
+		if !this.Leaves[i].Equal(that1.Leaves[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *UnsubscribeRequest) Equal(that interface{}) bool {
+func (this *RefreshDemand) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*UnsubscribeRequest)
+	that1, ok := that.(*RefreshDemand)
 	if !ok {
-		that2, ok := that.(UnsubscribeRequest)
+		that2, ok := that.(RefreshDemand)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1453,19 +2858,19 @@ func (this *UnsubscribeRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Channel != that1.Channel {
+	if this.TTL != that1.TTL {
 		return false
 	}
 	return true
 }
-func (this *UnsubscribeResult) Equal(that interface{}) bool {
+func (this *Unsub) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*UnsubscribeResult)
+	that1, ok := that.(*Unsub)
 	if !ok {
-		that2, ok := that.(UnsubscribeResult)
+		that2, ok := that.(Unsub)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1477,16 +2882,19 @@ func (this *UnsubscribeResult) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if this.Resubscribe != that1.Resubscribe {
+		return false
+	}
 	return true
 }
-func (this *PublishRequest) Equal(that interface{}) bool {
+func (this *Sub) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*PublishRequest)
+	that1, ok := that.(*Sub)
 	if !ok {
-		that2, ok := that.(PublishRequest)
+		that2, ok := that.(Sub)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1498,22 +2906,28 @@ func (this *PublishRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Channel != that1.Channel {
+	if this.Recoverable != that1.Recoverable {
 		return false
 	}
-	if !this.Data.Equal(that1.Data) {
+	if this.Seq != that1.Seq {
+		return false
+	}
+	if this.Gen != that1.Gen {
+		return false
+	}
+	if this.Epoch != that1.Epoch {
 		return false
 	}
 	return true
 }
-func (this *PublishResult) Equal(that interface{}) bool {
+func (this *Message) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*PublishResult)
+	that1, ok := that.(*Message)
 	if !ok {
-		that2, ok := that.(PublishResult)
+		that2, ok := that.(Message)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1525,16 +2939,22 @@ func (this *PublishResult) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if !this.Data.Equal(that1.Data) {
+		return false
+	}
+	if !bytes.Equal(this.B64Data, that1.B64Data) {
+		return false
+	}
 	return true
 }
-func (this *PresenceRequest) Equal(that interface{}) bool {
+func (this *ConnectRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*PresenceRequest)
+	that1, ok := that.(*ConnectRequest)
 	if !ok {
-		that2, ok := that.(PresenceRequest)
+		that2, ok := that.(ConnectRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1546,19 +2966,22 @@ func (this *PresenceRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Channel != that1.Channel {
+	if this.Token != that1.Token {
+		return false
+	}
+	if !this.Data.Equal(that1.Data) {
 		return false
 	}
 	return true
 }
-func (this *PresenceResult) Equal(that interface{}) bool {
+func (this *ConnectResult) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*PresenceResult)
+	that1, ok := that.(*ConnectResult)
 	if !ok {
-		that2, ok := that.(PresenceResult)
+		that2, ok := that.(ConnectResult)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1570,24 +2993,37 @@ func (this *PresenceResult) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Presence) != len(that1.Presence) {
+	if this.Client != that1.Client {
 		return false
 	}
-	for i := range this.Presence {
-		if !this.Presence[i].Equal(that1.Presence[i]) {
-			return false
-		}
+	if this.Version != that1.Version {
+		return false
+	}
+	if this.Expires != that1.Expires {
+		return false
+	}
+	if this.TTL != that1.TTL {
+		return false
+	}
+	if !this.Data.Equal(that1.Data) {
+		return false
+	}
+	if !this.Redirect.Equal(that1.Redirect) {
+		return false
+	}
+	if !this.ReconnectAdvice.Equal(that1.ReconnectAdvice) {
+		return false
 	}
 	return true
 }
-func (this *PresenceStatsRequest) Equal(that interface{}) bool {
+func (this *Redirect) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*PresenceStatsRequest)
+	that1, ok := that.(*Redirect)
 	if !ok {
-		that2, ok := that.(PresenceStatsRequest)
+		that2, ok := that.(Redirect)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1599,19 +3035,27 @@ func (this *PresenceStatsRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Channel != that1.Channel {
+	if len(this.Endpoints) != len(that1.Endpoints) {
+		return false
+	}
+	for i := range this.Endpoints {
+		if this.Endpoints[i] != that1.Endpoints[i] {
+			return false
+		}
+	}
+	if this.Reason != that1.Reason {
 		return false
 	}
 	return true
 }
-func (this *PresenceStatsResult) Equal(that interface{}) bool {
+func (this *ReconnectAdvice) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*PresenceStatsResult)
+	that1, ok := that.(*ReconnectAdvice)
 	if !ok {
-		that2, ok := that.(PresenceStatsResult)
+		that2, ok := that.(ReconnectAdvice)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1623,22 +3067,25 @@ func (this *PresenceStatsResult) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.NumClients != that1.NumClients {
+	if this.MinDelay != that1.MinDelay {
 		return false
 	}
-	if this.NumUsers != that1.NumUsers {
+	if this.MaxDelay != that1.MaxDelay {
+		return false
+	}
+	if this.Jitter != that1.Jitter {
 		return false
 	}
 	return true
 }
-func (this *HistoryRequest) Equal(that interface{}) bool {
+func (this *RefreshRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HistoryRequest)
+	that1, ok := that.(*RefreshRequest)
 	if !ok {
-		that2, ok := that.(HistoryRequest)
+		that2, ok := that.(RefreshRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1650,19 +3097,19 @@ func (this *HistoryRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Channel != that1.Channel {
+	if this.Token != that1.Token {
 		return false
 	}
 	return true
 }
-func (this *HistoryResult) Equal(that interface{}) bool {
+func (this *RefreshResult) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HistoryResult)
+	that1, ok := that.(*RefreshResult)
 	if !ok {
-		that2, ok := that.(HistoryResult)
+		that2, ok := that.(RefreshResult)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1674,24 +3121,28 @@ func (this *HistoryResult) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Publications) != len(that1.Publications) {
+	if this.Client != that1.Client {
 		return false
 	}
-	for i := range this.Publications {
-		if !this.Publications[i].Equal(that1.Publications[i]) {
-			return false
-		}
+	if this.Version != that1.Version {
+		return false
+	}
+	if this.Expires != that1.Expires {
+		return false
+	}
+	if this.TTL != that1.TTL {
+		return false
 	}
 	return true
 }
-func (this *PingRequest) Equal(that interface{}) bool {
+func (this *SubscribeRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*PingRequest)
+	that1, ok := that.(*SubscribeRequest)
 	if !ok {
-		that2, ok := that.(PingRequest)
+		that2, ok := that.(SubscribeRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1703,16 +3154,34 @@ func (this *PingRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if this.Channel != that1.Channel {
+		return false
+	}
+	if this.Token != that1.Token {
+		return false
+	}
+	if this.Recover != that1.Recover {
+		return false
+	}
+	if this.Seq != that1.Seq {
+		return false
+	}
+	if this.Gen != that1.Gen {
+		return false
+	}
+	if this.Epoch != that1.Epoch {
+		return false
+	}
 	return true
 }
-func (this *PingResult) Equal(that interface{}) bool {
+func (this *SubscribeResult) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*PingResult)
+	that1, ok := that.(*SubscribeResult)
 	if !ok {
-		that2, ok := that.(PingResult)
+		that2, ok := that.(SubscribeResult)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1724,16 +3193,45 @@ func (this *PingResult) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if this.Expires != that1.Expires {
+		return false
+	}
+	if this.TTL != that1.TTL {
+		return false
+	}
+	if this.Recoverable != that1.Recoverable {
+		return false
+	}
+	if this.Seq != that1.Seq {
+		return false
+	}
+	if this.Gen != that1.Gen {
+		return false
+	}
+	if this.Epoch != that1.Epoch {
+		return false
+	}
+	if len(this.Publications) != len(that1.Publications) {
+		return false
+	}
+	for i := range this.Publications {
+		if !this.Publications[i].Equal(that1.Publications[i]) {
+			return false
+		}
+	}
+	if this.Recovered != that1.Recovered {
+		return false
+	}
 	return true
 }
-func (this *RPCRequest) Equal(that interface{}) bool {
+func (this *SubRefreshRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*RPCRequest)
+	that1, ok := that.(*SubRefreshRequest)
 	if !ok {
-		that2, ok := that.(RPCRequest)
+		that2, ok := that.(SubRefreshRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1745,19 +3243,22 @@ func (this *RPCRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Data.Equal(that1.Data) {
+	if this.Channel != that1.Channel {
+		return false
+	}
+	if this.Token != that1.Token {
 		return false
 	}
 	return true
 }
-func (this *RPCResult) Equal(that interface{}) bool {
+func (this *SubRefreshResult) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*RPCResult)
+	that1, ok := that.(*SubRefreshResult)
 	if !ok {
-		that2, ok := that.(RPCResult)
+		that2, ok := that.(SubRefreshResult)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1769,19 +3270,22 @@ func (this *RPCResult) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Data.Equal(that1.Data) {
+	if this.Expires != that1.Expires {
+		return false
+	}
+	if this.TTL != that1.TTL {
 		return false
 	}
 	return true
 }
-func (this *SendRequest) Equal(that interface{}) bool {
+func (this *UnsubscribeRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*SendRequest)
+	that1, ok := that.(*UnsubscribeRequest)
 	if !ok {
-		that2, ok := that.(SendRequest)
+		that2, ok := that.(UnsubscribeRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1793,359 +3297,465 @@ func (this *SendRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Data.Equal(that1.Data) {
+	if this.Channel != that1.Channel {
 		return false
 	}
 	return true
 }
-func (m *Error) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
+func (this *UnsubscribeResult) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Error) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Code != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.Code))
+	that1, ok := that.(*UnsubscribeResult)
+	if !ok {
+		that2, ok := that.(UnsubscribeResult)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Message) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Message)))
-		i += copy(dAtA[i:], m.Message)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *Command) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *PublishRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *Command) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.ID))
+	that1, ok := that.(*PublishRequest)
+	if !ok {
+		that2, ok := that.(PublishRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.Method != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.Method))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	dAtA[i] = 0x1a
-	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.Params.Size()))
-	n1, err := m.Params.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if this.Channel != that1.Channel {
+		return false
 	}
-	i += n1
-	return i, nil
-}
-
-func (m *Reply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if !this.Data.Equal(that1.Data) {
+		return false
 	}
-	return dAtA[:n], nil
+	if this.IdempotencyKey != that1.IdempotencyKey {
+		return false
+	}
+	return true
 }
-
-func (m *Reply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.ID))
+func (this *PublishResult) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if m.Error != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.Error.Size()))
-		n2, err := m.Error.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+
+	that1, ok := that.(*PublishResult)
+	if !ok {
+		that2, ok := that.(PublishResult)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n2
 	}
-	dAtA[i] = 0x1a
-	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.Result.Size()))
-	n3, err := m.Result.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	i += n3
-	return i, nil
+	return true
 }
-
-func (m *Push) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *PresenceRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *Push) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Type != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.Type))
+	that1, ok := that.(*PresenceRequest)
+	if !ok {
+		that2, ok := that.(PresenceRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Channel) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
-		i += copy(dAtA[i:], m.Channel)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	dAtA[i] = 0x1a
-	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
-	n4, err := m.Data.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if this.Channel != that1.Channel {
+		return false
 	}
-	i += n4
-	return i, nil
+	return true
 }
-
-func (m *ClientInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *PresenceResult) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *ClientInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.User) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.User)))
-		i += copy(dAtA[i:], m.User)
+	that1, ok := that.(*PresenceResult)
+	if !ok {
+		that2, ok := that.(PresenceResult)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Client) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Client)))
-		i += copy(dAtA[i:], m.Client)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	dAtA[i] = 0x1a
-	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.ConnInfo.Size()))
-	n5, err := m.ConnInfo.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(this.Presence) != len(that1.Presence) {
+		return false
 	}
-	i += n5
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.ChanInfo.Size()))
-	n6, err := m.ChanInfo.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	for i := range this.Presence {
+		if !this.Presence[i].Equal(that1.Presence[i]) {
+			return false
+		}
 	}
-	i += n6
-	return i, nil
+	return true
 }
-
-func (m *Publication) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *PresenceStatsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *Publication) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Seq != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.Seq))
+	that1, ok := that.(*PresenceStatsRequest)
+	if !ok {
+		that2, ok := that.(PresenceStatsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.Gen != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.Gen))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.UID) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.UID)))
-		i += copy(dAtA[i:], m.UID)
+	if this.Channel != that1.Channel {
+		return false
 	}
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
-	n7, err := m.Data.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return true
+}
+func (this *PresenceStatsResult) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	i += n7
-	if m.Info != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.Info.Size()))
-		n8, err := m.Info.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+
+	that1, ok := that.(*PresenceStatsResult)
+	if !ok {
+		that2, ok := that.(PresenceStatsResult)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n8
 	}
-	return i, nil
-}
-
-func (m *Join) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *Join) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.Info.Size()))
-	n9, err := m.Info.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if this.NumClients != that1.NumClients {
+		return false
 	}
-	i += n9
-	return i, nil
-}
-
-func (m *Leave) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if this.NumUsers != that1.NumUsers {
+		return false
 	}
-	return dAtA[:n], nil
+	return true
 }
+func (this *HistoryRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Leave) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.Info.Size()))
-	n10, err := m.Info.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	that1, ok := that.(*HistoryRequest)
+	if !ok {
+		that2, ok := that.(HistoryRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	i += n10
-	return i, nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Channel != that1.Channel {
+		return false
+	}
+	if this.UseSince != that1.UseSince {
+		return false
+	}
+	if this.Seq != that1.Seq {
+		return false
+	}
+	if this.Gen != that1.Gen {
+		return false
+	}
+	if this.Epoch != that1.Epoch {
+		return false
+	}
+	if this.Limit != that1.Limit {
+		return false
+	}
+	return true
 }
+func (this *HistoryResult) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Unsub) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*HistoryResult)
+	if !ok {
+		that2, ok := that.(HistoryResult)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Publications) != len(that1.Publications) {
+		return false
+	}
+	for i := range this.Publications {
+		if !this.Publications[i].Equal(that1.Publications[i]) {
+			return false
+		}
+	}
+	if this.Seq != that1.Seq {
+		return false
+	}
+	if this.Gen != that1.Gen {
+		return false
+	}
+	if this.Epoch != that1.Epoch {
+		return false
+	}
+	return true
 }
+func (this *PingRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Unsub) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Resubscribe {
-		dAtA[i] = 0x8
-		i++
-		if m.Resubscribe {
-			dAtA[i] = 1
+	that1, ok := that.(*PingRequest)
+	if !ok {
+		that2, ok := that.(PingRequest)
+		if ok {
+			that1 = &that2
 		} else {
-			dAtA[i] = 0
+			return false
 		}
-		i++
 	}
-	return i, nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
 }
+func (this *PingResult) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Message) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*PingResult)
+	if !ok {
+		that2, ok := that.(PingResult)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
 }
+func (this *RPCRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Message) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
-	n11, err := m.Data.MarshalTo(dAtA[i:])
+	that1, ok := that.(*RPCRequest)
+	if !ok {
+		that2, ok := that.(RPCRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Data.Equal(that1.Data) {
+		return false
+	}
+	if this.Method != that1.Method {
+		return false
+	}
+	return true
+}
+func (this *RPCResult) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RPCResult)
+	if !ok {
+		that2, ok := that.(RPCResult)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Data.Equal(that1.Data) {
+		return false
+	}
+	return true
+}
+func (this *SendRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SendRequest)
+	if !ok {
+		that2, ok := that.(SendRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Data.Equal(that1.Data) {
+		return false
+	}
+	return true
+}
+func (this *MarkReadRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MarkReadRequest)
+	if !ok {
+		that2, ok := that.(MarkReadRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Channel != that1.Channel {
+		return false
+	}
+	if this.Seq != that1.Seq {
+		return false
+	}
+	if this.Gen != that1.Gen {
+		return false
+	}
+	if this.Epoch != that1.Epoch {
+		return false
+	}
+	return true
+}
+func (this *MarkReadResult) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MarkReadResult)
+	if !ok {
+		that2, ok := that.(MarkReadResult)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
+}
+func (m *Error) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Error) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Code))
+	}
+	if len(m.Message) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
 	}
-	i += n11
 	return i, nil
 }
 
-func (m *ConnectRequest) Marshal() (dAtA []byte, err error) {
+func (m *Command) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2155,29 +3765,33 @@ func (m *ConnectRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ConnectRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Command) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Token) > 0 {
-		dAtA[i] = 0xa
+	if m.ID != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Token)))
-		i += copy(dAtA[i:], m.Token)
+		i = encodeVarintClient(dAtA, i, uint64(m.ID))
 	}
-	dAtA[i] = 0x12
+	if m.Method != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Method))
+	}
+	dAtA[i] = 0x1a
 	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
-	n12, err := m.Data.MarshalTo(dAtA[i:])
+	i = encodeVarintClient(dAtA, i, uint64(m.Params.Size()))
+	n1, err := m.Params.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n12
+	i += n1
 	return i, nil
 }
 
-func (m *ConnectResult) Marshal() (dAtA []byte, err error) {
+func (m *Reply) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2187,50 +3801,38 @@ func (m *ConnectResult) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ConnectResult) MarshalTo(dAtA []byte) (int, error) {
+func (m *Reply) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Client) > 0 {
-		dAtA[i] = 0xa
+	if m.ID != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Client)))
-		i += copy(dAtA[i:], m.Client)
+		i = encodeVarintClient(dAtA, i, uint64(m.ID))
 	}
-	if len(m.Version) > 0 {
+	if m.Error != nil {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Version)))
-		i += copy(dAtA[i:], m.Version)
-	}
-	if m.Expires {
-		dAtA[i] = 0x18
-		i++
-		if m.Expires {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+		i = encodeVarintClient(dAtA, i, uint64(m.Error.Size()))
+		n2, err := m.Error.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i++
-	}
-	if m.TTL != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.TTL))
+		i += n2
 	}
-	dAtA[i] = 0x2a
+	dAtA[i] = 0x1a
 	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
-	n13, err := m.Data.MarshalTo(dAtA[i:])
+	i = encodeVarintClient(dAtA, i, uint64(m.Result.Size()))
+	n3, err := m.Result.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n13
+	i += n3
 	return i, nil
 }
 
-func (m *RefreshRequest) Marshal() (dAtA []byte, err error) {
+func (m *Push) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2240,21 +3842,34 @@ func (m *RefreshRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RefreshRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Push) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Token) > 0 {
-		dAtA[i] = 0xa
+	if m.Type != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Token)))
-		i += copy(dAtA[i:], m.Token)
+		i = encodeVarintClient(dAtA, i, uint64(m.Type))
+	}
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
 	}
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
+	n4, err := m.Data.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n4
 	return i, nil
 }
 
-func (m *RefreshResult) Marshal() (dAtA []byte, err error) {
+func (m *ClientInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2264,42 +3879,43 @@ func (m *RefreshResult) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RefreshResult) MarshalTo(dAtA []byte) (int, error) {
+func (m *ClientInfo) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Client) > 0 {
+	if len(m.User) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Client)))
-		i += copy(dAtA[i:], m.Client)
+		i = encodeVarintClient(dAtA, i, uint64(len(m.User)))
+		i += copy(dAtA[i:], m.User)
 	}
-	if len(m.Version) > 0 {
+	if len(m.Client) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Version)))
-		i += copy(dAtA[i:], m.Version)
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Client)))
+		i += copy(dAtA[i:], m.Client)
 	}
-	if m.Expires {
-		dAtA[i] = 0x18
-		i++
-		if m.Expires {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintClient(dAtA, i, uint64(m.ConnInfo.Size()))
+	n5, err := m.ConnInfo.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if m.TTL != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.TTL))
+	i += n5
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintClient(dAtA, i, uint64(m.ChanInfo.Size()))
+	n6, err := m.ChanInfo.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n6
 	return i, nil
 }
 
-func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
+func (m *Publication) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2309,124 +3925,85 @@ func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Publication) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Channel) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
-		i += copy(dAtA[i:], m.Channel)
-	}
-	if len(m.Token) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Token)))
-		i += copy(dAtA[i:], m.Token)
-	}
-	if m.Recover {
-		dAtA[i] = 0x18
-		i++
-		if m.Recover {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
 	if m.Seq != 0 {
-		dAtA[i] = 0x20
+		dAtA[i] = 0x8
 		i++
 		i = encodeVarintClient(dAtA, i, uint64(m.Seq))
 	}
 	if m.Gen != 0 {
-		dAtA[i] = 0x28
+		dAtA[i] = 0x10
 		i++
 		i = encodeVarintClient(dAtA, i, uint64(m.Gen))
 	}
-	if len(m.Epoch) > 0 {
-		dAtA[i] = 0x32
+	if len(m.UID) > 0 {
+		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Epoch)))
-		i += copy(dAtA[i:], m.Epoch)
+		i = encodeVarintClient(dAtA, i, uint64(len(m.UID)))
+		i += copy(dAtA[i:], m.UID)
 	}
-	return i, nil
-}
-
-func (m *SubscribeResult) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
+	n7, err := m.Data.MarshalTo(dAtA[i:])
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *SubscribeResult) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Expires {
-		dAtA[i] = 0x8
+	i += n7
+	if m.Info != nil {
+		dAtA[i] = 0x2a
 		i++
-		if m.Expires {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+		i = encodeVarintClient(dAtA, i, uint64(m.Info.Size()))
+		n8, err := m.Info.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i++
+		i += n8
 	}
-	if m.TTL != 0 {
-		dAtA[i] = 0x10
+	if m.ExpireAt != 0 {
+		dAtA[i] = 0x30
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.TTL))
+		i = encodeVarintClient(dAtA, i, uint64(m.ExpireAt))
 	}
-	if m.Recoverable {
-		dAtA[i] = 0x18
+	if m.Ephemeral {
+		dAtA[i] = 0x38
 		i++
-		if m.Recoverable {
+		if m.Ephemeral {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
 		i++
 	}
-	if m.Seq != 0 {
-		dAtA[i] = 0x20
+	if m.Op != 0 {
+		dAtA[i] = 0x40
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.Seq))
+		i = encodeVarintClient(dAtA, i, uint64(m.Op))
 	}
-	if m.Gen != 0 {
-		dAtA[i] = 0x28
+	if m.RefSeq != 0 {
+		dAtA[i] = 0x48
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.Gen))
+		i = encodeVarintClient(dAtA, i, uint64(m.RefSeq))
 	}
-	if len(m.Epoch) > 0 {
-		dAtA[i] = 0x32
+	if m.RefGen != 0 {
+		dAtA[i] = 0x50
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Epoch)))
-		i += copy(dAtA[i:], m.Epoch)
+		i = encodeVarintClient(dAtA, i, uint64(m.RefGen))
 	}
-	if len(m.Publications) > 0 {
-		for _, msg := range m.Publications {
-			dAtA[i] = 0x3a
-			i++
-			i = encodeVarintClient(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	if len(m.B64Data) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.B64Data)))
+		i += copy(dAtA[i:], m.B64Data)
 	}
-	if m.Recovered {
-		dAtA[i] = 0x40
+	if m.Binary {
+		dAtA[i] = 0x60
 		i++
-		if m.Recovered {
+		if m.Binary {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
@@ -2436,7 +4013,7 @@ func (m *SubscribeResult) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *SubRefreshRequest) Marshal() (dAtA []byte, err error) {
+func (m *Join) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2446,27 +4023,23 @@ func (m *SubRefreshRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SubRefreshRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Join) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Channel) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
-		i += copy(dAtA[i:], m.Channel)
-	}
-	if len(m.Token) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Token)))
-		i += copy(dAtA[i:], m.Token)
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintClient(dAtA, i, uint64(m.Info.Size()))
+	n9, err := m.Info.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n9
 	return i, nil
 }
 
-func (m *SubRefreshResult) Marshal() (dAtA []byte, err error) {
+func (m *Leave) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2476,30 +4049,23 @@ func (m *SubRefreshResult) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SubRefreshResult) MarshalTo(dAtA []byte) (int, error) {
+func (m *Leave) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Expires {
-		dAtA[i] = 0x8
-		i++
-		if m.Expires {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if m.TTL != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.TTL))
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintClient(dAtA, i, uint64(m.Info.Size()))
+	n10, err := m.Info.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n10
 	return i, nil
 }
 
-func (m *UnsubscribeRequest) Marshal() (dAtA []byte, err error) {
+func (m *Membership) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2509,39 +4075,49 @@ func (m *UnsubscribeRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UnsubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Membership) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Channel) > 0 {
-		dAtA[i] = 0xa
+	if m.Joined != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
-		i += copy(dAtA[i:], m.Channel)
+		i = encodeVarintClient(dAtA, i, uint64(m.Joined))
 	}
-	return i, nil
-}
-
-func (m *UnsubscribeResult) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if m.Left != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Left))
+	}
+	if len(m.Joins) > 0 {
+		for _, msg := range m.Joins {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintClient(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Leaves) > 0 {
+		for _, msg := range m.Leaves {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintClient(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *UnsubscribeResult) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
 	return i, nil
 }
 
-func (m *PublishRequest) Marshal() (dAtA []byte, err error) {
+func (m *RefreshDemand) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2551,29 +4127,20 @@ func (m *PublishRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PublishRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *RefreshDemand) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Channel) > 0 {
-		dAtA[i] = 0xa
+	if m.TTL != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
-		i += copy(dAtA[i:], m.Channel)
-	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
-	n14, err := m.Data.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+		i = encodeVarintClient(dAtA, i, uint64(m.TTL))
 	}
-	i += n14
 	return i, nil
 }
 
-func (m *PublishResult) Marshal() (dAtA []byte, err error) {
+func (m *Unsub) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2583,15 +4150,25 @@ func (m *PublishResult) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PublishResult) MarshalTo(dAtA []byte) (int, error) {
+func (m *Unsub) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if m.Resubscribe {
+		dAtA[i] = 0x8
+		i++
+		if m.Resubscribe {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
-func (m *PresenceRequest) Marshal() (dAtA []byte, err error) {
+func (m *Sub) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2601,21 +4178,41 @@ func (m *PresenceRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PresenceRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Sub) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Channel) > 0 {
-		dAtA[i] = 0xa
+	if m.Recoverable {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
-		i += copy(dAtA[i:], m.Channel)
+		if m.Recoverable {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Seq != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Seq))
+	}
+	if m.Gen != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Gen))
+	}
+	if len(m.Epoch) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Epoch)))
+		i += copy(dAtA[i:], m.Epoch)
 	}
 	return i, nil
 }
 
-func (m *PresenceResult) Marshal() (dAtA []byte, err error) {
+func (m *Message) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2625,43 +4222,29 @@ func (m *PresenceResult) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PresenceResult) MarshalTo(dAtA []byte) (int, error) {
+func (m *Message) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Presence) > 0 {
-		for k, _ := range m.Presence {
-			dAtA[i] = 0xa
-			i++
-			v := m.Presence[k]
-			msgSize := 0
-			if v != nil {
-				msgSize = v.Size()
-				msgSize += 1 + sovClient(uint64(msgSize))
-			}
-			mapSize := 1 + len(k) + sovClient(uint64(len(k))) + msgSize
-			i = encodeVarintClient(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintClient(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			if v != nil {
-				dAtA[i] = 0x12
-				i++
-				i = encodeVarintClient(dAtA, i, uint64(v.Size()))
-				n15, err := v.MarshalTo(dAtA[i:])
-				if err != nil {
-					return 0, err
-				}
-				i += n15
-			}
-		}
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
+	n11, err := m.Data.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n11
+	if len(m.B64Data) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.B64Data)))
+		i += copy(dAtA[i:], m.B64Data)
 	}
 	return i, nil
 }
 
-func (m *PresenceStatsRequest) Marshal() (dAtA []byte, err error) {
+func (m *ConnectRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2671,21 +4254,29 @@ func (m *PresenceStatsRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PresenceStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConnectRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Channel) > 0 {
+	if len(m.Token) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
-		i += copy(dAtA[i:], m.Channel)
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Token)))
+		i += copy(dAtA[i:], m.Token)
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
+	n12, err := m.Data.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n12
 	return i, nil
 }
 
-func (m *PresenceStatsResult) Marshal() (dAtA []byte, err error) {
+func (m *ConnectResult) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2695,49 +4286,70 @@ func (m *PresenceStatsResult) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PresenceStatsResult) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConnectResult) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.NumClients != 0 {
-		dAtA[i] = 0x8
+	if len(m.Client) > 0 {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.NumClients))
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Client)))
+		i += copy(dAtA[i:], m.Client)
 	}
-	if m.NumUsers != 0 {
-		dAtA[i] = 0x10
+	if len(m.Version) > 0 {
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(m.NumUsers))
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
 	}
-	return i, nil
-}
-
-func (m *HistoryRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	if m.Expires {
+		dAtA[i] = 0x18
+		i++
+		if m.Expires {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.TTL != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.TTL))
+	}
+	dAtA[i] = 0x2a
+	i++
+	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
+	n13, err := m.Data.MarshalTo(dAtA[i:])
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HistoryRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Channel) > 0 {
-		dAtA[i] = 0xa
+	i += n13
+	if m.Redirect != nil {
+		dAtA[i] = 0x32
 		i++
-		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
-		i += copy(dAtA[i:], m.Channel)
+		i = encodeVarintClient(dAtA, i, uint64(m.Redirect.Size()))
+		n14, err := m.Redirect.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	if m.ReconnectAdvice != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.ReconnectAdvice.Size()))
+		n15, err := m.ReconnectAdvice.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
 	}
 	return i, nil
 }
 
-func (m *HistoryResult) Marshal() (dAtA []byte, err error) {
+func (m *Redirect) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2747,45 +4359,36 @@ func (m *HistoryResult) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HistoryResult) MarshalTo(dAtA []byte) (int, error) {
+func (m *Redirect) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Publications) > 0 {
-		for _, msg := range m.Publications {
+	if len(m.Endpoints) > 0 {
+		for _, s := range m.Endpoints {
 			dAtA[i] = 0xa
 			i++
-			i = encodeVarintClient(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
 			}
-			i += n
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
 	}
-	return i, nil
-}
-
-func (m *PingRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
 	}
-	return dAtA[:n], nil
-}
-
-func (m *PingRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
 	return i, nil
 }
 
-func (m *PingResult) Marshal() (dAtA []byte, err error) {
+func (m *ReconnectAdvice) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2795,15 +4398,30 @@ func (m *PingResult) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PingResult) MarshalTo(dAtA []byte) (int, error) {
+func (m *ReconnectAdvice) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if m.MinDelay != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.MinDelay))
+	}
+	if m.MaxDelay != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.MaxDelay))
+	}
+	if m.Jitter != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Jitter))
+	}
 	return i, nil
 }
 
-func (m *RPCRequest) Marshal() (dAtA []byte, err error) {
+func (m *RefreshRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2813,23 +4431,21 @@ func (m *RPCRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RPCRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *RefreshRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
-	n16, err := m.Data.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Token) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Token)))
+		i += copy(dAtA[i:], m.Token)
 	}
-	i += n16
 	return i, nil
 }
 
-func (m *RPCResult) Marshal() (dAtA []byte, err error) {
+func (m *RefreshResult) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2839,23 +4455,42 @@ func (m *RPCResult) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RPCResult) MarshalTo(dAtA []byte) (int, error) {
+func (m *RefreshResult) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
-	n17, err := m.Data.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Client) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Client)))
+		i += copy(dAtA[i:], m.Client)
+	}
+	if len(m.Version) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
+	}
+	if m.Expires {
+		dAtA[i] = 0x18
+		i++
+		if m.Expires {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.TTL != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.TTL))
 	}
-	i += n17
 	return i, nil
 }
 
-func (m *SendRequest) Marshal() (dAtA []byte, err error) {
+func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -2865,909 +4500,2828 @@ func (m *SendRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SendRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *SubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
-	n18, err := m.Data.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n18
-	return i, nil
-}
-
-func encodeVarintClient(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
-}
-func NewPopulatedError(r randyClient, easy bool) *Error {
-	this := &Error{}
-	this.Code = uint32(r.Uint32())
-	this.Message = string(randStringClient(r))
-	if !easy && r.Intn(10) != 0 {
+	if len(m.Token) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Token)))
+		i += copy(dAtA[i:], m.Token)
 	}
-	return this
-}
-
-func NewPopulatedCommand(r randyClient, easy bool) *Command {
-	this := &Command{}
-	this.ID = uint32(r.Uint32())
-	this.Method = MethodType([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}[r.Intn(12)])
-	v1 := NewPopulatedRaw(r)
-	this.Params = *v1
-	if !easy && r.Intn(10) != 0 {
+	if m.Recover {
+		dAtA[i] = 0x18
+		i++
+		if m.Recover {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	return this
-}
-
-func NewPopulatedReply(r randyClient, easy bool) *Reply {
-	this := &Reply{}
-	this.ID = uint32(r.Uint32())
-	if r.Intn(10) != 0 {
-		this.Error = NewPopulatedError(r, easy)
+	if m.Seq != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Seq))
 	}
-	v2 := NewPopulatedRaw(r)
-	this.Result = *v2
-	if !easy && r.Intn(10) != 0 {
+	if m.Gen != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Gen))
 	}
-	return this
-}
-
-func NewPopulatedPush(r randyClient, easy bool) *Push {
-	this := &Push{}
-	this.Type = PushType([]int32{0, 1, 2, 3, 4}[r.Intn(5)])
-	this.Channel = string(randStringClient(r))
-	v3 := NewPopulatedRaw(r)
-	this.Data = *v3
-	if !easy && r.Intn(10) != 0 {
+	if len(m.Epoch) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Epoch)))
+		i += copy(dAtA[i:], m.Epoch)
 	}
-	return this
+	return i, nil
 }
 
-func NewPopulatedClientInfo(r randyClient, easy bool) *ClientInfo {
-	this := &ClientInfo{}
-	this.User = string(randStringClient(r))
-	this.Client = string(randStringClient(r))
-	v4 := NewPopulatedRaw(r)
-	this.ConnInfo = *v4
-	v5 := NewPopulatedRaw(r)
-	this.ChanInfo = *v5
-	if !easy && r.Intn(10) != 0 {
+func (m *SubscribeResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedPublication(r randyClient, easy bool) *Publication {
-	this := &Publication{}
-	this.Seq = uint32(r.Uint32())
-	this.Gen = uint32(r.Uint32())
-	this.UID = string(randStringClient(r))
-	v6 := NewPopulatedRaw(r)
-	this.Data = *v6
-	if r.Intn(10) != 0 {
-		this.Info = NewPopulatedClientInfo(r, easy)
+func (m *SubscribeResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Expires {
+		dAtA[i] = 0x8
+		i++
+		if m.Expires {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	if !easy && r.Intn(10) != 0 {
+	if m.TTL != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.TTL))
 	}
-	return this
+	if m.Recoverable {
+		dAtA[i] = 0x18
+		i++
+		if m.Recoverable {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Seq != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Seq))
+	}
+	if m.Gen != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Gen))
+	}
+	if len(m.Epoch) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Epoch)))
+		i += copy(dAtA[i:], m.Epoch)
+	}
+	if len(m.Publications) > 0 {
+		for _, msg := range m.Publications {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintClient(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Recovered {
+		dAtA[i] = 0x40
+		i++
+		if m.Recovered {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
 }
 
-func NewPopulatedJoin(r randyClient, easy bool) *Join {
-	this := &Join{}
-	v7 := NewPopulatedClientInfo(r, easy)
-	this.Info = *v7
-	if !easy && r.Intn(10) != 0 {
+func (m *SubRefreshRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedLeave(r randyClient, easy bool) *Leave {
-	this := &Leave{}
-	v8 := NewPopulatedClientInfo(r, easy)
-	this.Info = *v8
-	if !easy && r.Intn(10) != 0 {
+func (m *SubRefreshRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
 	}
-	return this
+	if len(m.Token) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Token)))
+		i += copy(dAtA[i:], m.Token)
+	}
+	return i, nil
 }
 
-func NewPopulatedUnsub(r randyClient, easy bool) *Unsub {
-	this := &Unsub{}
-	this.Resubscribe = bool(bool(r.Intn(2) == 0))
-	if !easy && r.Intn(10) != 0 {
+func (m *SubRefreshResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedMessage(r randyClient, easy bool) *Message {
-	this := &Message{}
-	v9 := NewPopulatedRaw(r)
-	this.Data = *v9
-	if !easy && r.Intn(10) != 0 {
+func (m *SubRefreshResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Expires {
+		dAtA[i] = 0x8
+		i++
+		if m.Expires {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	return this
+	if m.TTL != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.TTL))
+	}
+	return i, nil
 }
 
-func NewPopulatedConnectRequest(r randyClient, easy bool) *ConnectRequest {
-	this := &ConnectRequest{}
-	this.Token = string(randStringClient(r))
-	v10 := NewPopulatedRaw(r)
-	this.Data = *v10
-	if !easy && r.Intn(10) != 0 {
+func (m *UnsubscribeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedConnectResult(r randyClient, easy bool) *ConnectResult {
-	this := &ConnectResult{}
-	this.Client = string(randStringClient(r))
-	this.Version = string(randStringClient(r))
-	this.Expires = bool(bool(r.Intn(2) == 0))
-	this.TTL = uint32(r.Uint32())
-	v11 := NewPopulatedRaw(r)
-	this.Data = *v11
-	if !easy && r.Intn(10) != 0 {
+func (m *UnsubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
 	}
-	return this
+	return i, nil
 }
 
-func NewPopulatedRefreshRequest(r randyClient, easy bool) *RefreshRequest {
-	this := &RefreshRequest{}
-	this.Token = string(randStringClient(r))
-	if !easy && r.Intn(10) != 0 {
+func (m *UnsubscribeResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedRefreshResult(r randyClient, easy bool) *RefreshResult {
-	this := &RefreshResult{}
-	this.Client = string(randStringClient(r))
-	this.Version = string(randStringClient(r))
-	this.Expires = bool(bool(r.Intn(2) == 0))
-	this.TTL = uint32(r.Uint32())
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+func (m *UnsubscribeResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
 }
 
-func NewPopulatedSubscribeRequest(r randyClient, easy bool) *SubscribeRequest {
-	this := &SubscribeRequest{}
-	this.Channel = string(randStringClient(r))
-	this.Token = string(randStringClient(r))
-	this.Recover = bool(bool(r.Intn(2) == 0))
-	this.Seq = uint32(r.Uint32())
-	this.Gen = uint32(r.Uint32())
-	this.Epoch = string(randStringClient(r))
-	if !easy && r.Intn(10) != 0 {
+func (m *PublishRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedSubscribeResult(r randyClient, easy bool) *SubscribeResult {
-	this := &SubscribeResult{}
-	this.Expires = bool(bool(r.Intn(2) == 0))
-	this.TTL = uint32(r.Uint32())
-	this.Recoverable = bool(bool(r.Intn(2) == 0))
-	this.Seq = uint32(r.Uint32())
-	this.Gen = uint32(r.Uint32())
-	this.Epoch = string(randStringClient(r))
-	if r.Intn(10) != 0 {
-		v12 := r.Intn(5)
-		this.Publications = make([]*Publication, v12)
-		for i := 0; i < v12; i++ {
-			this.Publications[i] = NewPopulatedPublication(r, easy)
-		}
-	}
-	this.Recovered = bool(bool(r.Intn(2) == 0))
-	if !easy && r.Intn(10) != 0 {
+func (m *PublishRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
 	}
-	return this
-}
-
-func NewPopulatedSubRefreshRequest(r randyClient, easy bool) *SubRefreshRequest {
-	this := &SubRefreshRequest{}
-	this.Channel = string(randStringClient(r))
-	this.Token = string(randStringClient(r))
-	if !easy && r.Intn(10) != 0 {
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
+	n16, err := m.Data.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	return this
-}
-
-func NewPopulatedSubRefreshResult(r randyClient, easy bool) *SubRefreshResult {
-	this := &SubRefreshResult{}
-	this.Expires = bool(bool(r.Intn(2) == 0))
-	this.TTL = uint32(r.Uint32())
-	if !easy && r.Intn(10) != 0 {
+	i += n16
+	if len(m.IdempotencyKey) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.IdempotencyKey)))
+		i += copy(dAtA[i:], m.IdempotencyKey)
 	}
-	return this
+	return i, nil
 }
 
-func NewPopulatedUnsubscribeRequest(r randyClient, easy bool) *UnsubscribeRequest {
-	this := &UnsubscribeRequest{}
-	this.Channel = string(randStringClient(r))
-	if !easy && r.Intn(10) != 0 {
+func (m *PublishResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedUnsubscribeResult(r randyClient, easy bool) *UnsubscribeResult {
-	this := &UnsubscribeResult{}
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+func (m *PublishResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
 }
 
-func NewPopulatedPublishRequest(r randyClient, easy bool) *PublishRequest {
-	this := &PublishRequest{}
-	this.Channel = string(randStringClient(r))
-	v13 := NewPopulatedRaw(r)
-	this.Data = *v13
-	if !easy && r.Intn(10) != 0 {
+func (m *PresenceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedPublishResult(r randyClient, easy bool) *PublishResult {
-	this := &PublishResult{}
-	if !easy && r.Intn(10) != 0 {
+func (m *PresenceRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
 	}
-	return this
+	return i, nil
 }
 
-func NewPopulatedPresenceRequest(r randyClient, easy bool) *PresenceRequest {
-	this := &PresenceRequest{}
-	this.Channel = string(randStringClient(r))
-	if !easy && r.Intn(10) != 0 {
+func (m *PresenceResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedPresenceResult(r randyClient, easy bool) *PresenceResult {
-	this := &PresenceResult{}
-	if r.Intn(10) != 0 {
-		v14 := r.Intn(10)
-		this.Presence = make(map[string]*ClientInfo)
-		for i := 0; i < v14; i++ {
-			this.Presence[randStringClient(r)] = NewPopulatedClientInfo(r, easy)
+func (m *PresenceResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Presence) > 0 {
+		for k, _ := range m.Presence {
+			dAtA[i] = 0xa
+			i++
+			v := m.Presence[k]
+			msgSize := 0
+			if v != nil {
+				msgSize = v.Size()
+				msgSize += 1 + sovClient(uint64(msgSize))
+			}
+			mapSize := 1 + len(k) + sovClient(uint64(len(k))) + msgSize
+			i = encodeVarintClient(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintClient(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			if v != nil {
+				dAtA[i] = 0x12
+				i++
+				i = encodeVarintClient(dAtA, i, uint64(v.Size()))
+				n17, err := v.MarshalTo(dAtA[i:])
+				if err != nil {
+					return 0, err
+				}
+				i += n17
+			}
 		}
 	}
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+	return i, nil
 }
 
-func NewPopulatedPresenceStatsRequest(r randyClient, easy bool) *PresenceStatsRequest {
-	this := &PresenceStatsRequest{}
-	this.Channel = string(randStringClient(r))
-	if !easy && r.Intn(10) != 0 {
+func (m *PresenceStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedPresenceStatsResult(r randyClient, easy bool) *PresenceStatsResult {
-	this := &PresenceStatsResult{}
-	this.NumClients = uint32(r.Uint32())
-	this.NumUsers = uint32(r.Uint32())
-	if !easy && r.Intn(10) != 0 {
+func (m *PresenceStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
 	}
-	return this
+	return i, nil
 }
 
-func NewPopulatedHistoryRequest(r randyClient, easy bool) *HistoryRequest {
-	this := &HistoryRequest{}
-	this.Channel = string(randStringClient(r))
-	if !easy && r.Intn(10) != 0 {
+func (m *PresenceStatsResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedHistoryResult(r randyClient, easy bool) *HistoryResult {
-	this := &HistoryResult{}
-	if r.Intn(10) != 0 {
-		v15 := r.Intn(5)
-		this.Publications = make([]*Publication, v15)
-		for i := 0; i < v15; i++ {
-			this.Publications[i] = NewPopulatedPublication(r, easy)
-		}
+func (m *PresenceStatsResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.NumClients != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.NumClients))
 	}
-	if !easy && r.Intn(10) != 0 {
+	if m.NumUsers != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.NumUsers))
 	}
-	return this
+	return i, nil
 }
 
-func NewPopulatedPingRequest(r randyClient, easy bool) *PingRequest {
-	this := &PingRequest{}
-	if !easy && r.Intn(10) != 0 {
+func (m *HistoryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedPingResult(r randyClient, easy bool) *PingResult {
-	this := &PingResult{}
-	if !easy && r.Intn(10) != 0 {
+func (m *HistoryRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
 	}
-	return this
-}
-
-func NewPopulatedRPCRequest(r randyClient, easy bool) *RPCRequest {
-	this := &RPCRequest{}
-	v16 := NewPopulatedRaw(r)
-	this.Data = *v16
-	if !easy && r.Intn(10) != 0 {
+	if m.UseSince {
+		dAtA[i] = 0x10
+		i++
+		if m.UseSince {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	return this
-}
-
-func NewPopulatedRPCResult(r randyClient, easy bool) *RPCResult {
-	this := &RPCResult{}
-	v17 := NewPopulatedRaw(r)
-	this.Data = *v17
-	if !easy && r.Intn(10) != 0 {
+	if m.Seq != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Seq))
 	}
-	return this
-}
-
-func NewPopulatedSendRequest(r randyClient, easy bool) *SendRequest {
-	this := &SendRequest{}
-	v18 := NewPopulatedRaw(r)
-	this.Data = *v18
-	if !easy && r.Intn(10) != 0 {
+	if m.Gen != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Gen))
 	}
-	return this
+	if len(m.Epoch) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Epoch)))
+		i += copy(dAtA[i:], m.Epoch)
+	}
+	if m.Limit != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Limit))
+	}
+	return i, nil
 }
 
-type randyClient interface {
-	Float32() float32
-	Float64() float64
-	Int63() int64
-	Int31() int32
-	Uint32() uint32
-	Intn(n int) int
+func (m *HistoryResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
 
-func randUTF8RuneClient(r randyClient) rune {
-	ru := r.Intn(62)
-	if ru < 10 {
-		return rune(ru + 48)
-	} else if ru < 36 {
-		return rune(ru + 55)
+func (m *HistoryResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Publications) > 0 {
+		for _, msg := range m.Publications {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintClient(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	return rune(ru + 61)
-}
-func randStringClient(r randyClient) string {
-	v19 := r.Intn(100)
-	tmps := make([]rune, v19)
-	for i := 0; i < v19; i++ {
-		tmps[i] = randUTF8RuneClient(r)
+	if m.Seq != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Seq))
 	}
-	return string(tmps)
-}
-func randUnrecognizedClient(r randyClient, maxFieldNumber int) (dAtA []byte) {
-	l := r.Intn(5)
-	for i := 0; i < l; i++ {
-		wire := r.Intn(4)
-		if wire == 3 {
-			wire = 5
-		}
-		fieldNumber := maxFieldNumber + r.Intn(100)
-		dAtA = randFieldClient(dAtA, r, fieldNumber, wire)
+	if m.Gen != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Gen))
 	}
-	return dAtA
-}
-func randFieldClient(dAtA []byte, r randyClient, fieldNumber int, wire int) []byte {
-	key := uint32(fieldNumber)<<3 | uint32(wire)
-	switch wire {
-	case 0:
-		dAtA = encodeVarintPopulateClient(dAtA, uint64(key))
-		v20 := r.Int63()
-		if r.Intn(2) == 0 {
-			v20 *= -1
-		}
-		dAtA = encodeVarintPopulateClient(dAtA, uint64(v20))
-	case 1:
-		dAtA = encodeVarintPopulateClient(dAtA, uint64(key))
-		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
-	case 2:
-		dAtA = encodeVarintPopulateClient(dAtA, uint64(key))
-		ll := r.Intn(100)
-		dAtA = encodeVarintPopulateClient(dAtA, uint64(ll))
-		for j := 0; j < ll; j++ {
-			dAtA = append(dAtA, byte(r.Intn(256)))
-		}
-	default:
-		dAtA = encodeVarintPopulateClient(dAtA, uint64(key))
-		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	if len(m.Epoch) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Epoch)))
+		i += copy(dAtA[i:], m.Epoch)
 	}
-	return dAtA
+	return i, nil
 }
-func encodeVarintPopulateClient(dAtA []byte, v uint64) []byte {
-	for v >= 1<<7 {
-		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
-		v >>= 7
+
+func (m *PingRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA = append(dAtA, uint8(v))
-	return dAtA
+	return dAtA[:n], nil
 }
-func (m *Error) Size() (n int) {
+
+func (m *PingRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Code != 0 {
-		n += 1 + sovClient(uint64(m.Code))
-	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+	return i, nil
+}
+
+func (m *PingResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Command) Size() (n int) {
+func (m *PingResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovClient(uint64(m.ID))
-	}
-	if m.Method != 0 {
-		n += 1 + sovClient(uint64(m.Method))
+	return i, nil
+}
+
+func (m *RPCRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	l = m.Params.Size()
-	n += 1 + l + sovClient(uint64(l))
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Reply) Size() (n int) {
+func (m *RPCRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovClient(uint64(m.ID))
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
+	n18, err := m.Data.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if m.Error != nil {
-		l = m.Error.Size()
-		n += 1 + l + sovClient(uint64(l))
+	i += n18
+	if len(m.Method) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Method)))
+		i += copy(dAtA[i:], m.Method)
 	}
-	l = m.Result.Size()
-	n += 1 + l + sovClient(uint64(l))
-	return n
+	return i, nil
 }
 
-func (m *Push) Size() (n int) {
+func (m *RPCResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RPCResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Type != 0 {
-		n += 1 + sovClient(uint64(m.Type))
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
+	n19, err := m.Data.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	l = len(m.Channel)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+	i += n19
+	return i, nil
+}
+
+func (m *SendRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	l = m.Data.Size()
-	n += 1 + l + sovClient(uint64(l))
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ClientInfo) Size() (n int) {
+func (m *SendRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.User)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintClient(dAtA, i, uint64(m.Data.Size()))
+	n20, err := m.Data.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	l = len(m.Client)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+	i += n20
+	return i, nil
+}
+
+func (m *MarkReadRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	l = m.ConnInfo.Size()
-	n += 1 + l + sovClient(uint64(l))
-	l = m.ChanInfo.Size()
-	n += 1 + l + sovClient(uint64(l))
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Publication) Size() (n int) {
+func (m *MarkReadRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
+	}
 	if m.Seq != 0 {
-		n += 1 + sovClient(uint64(m.Seq))
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Seq))
 	}
 	if m.Gen != 0 {
-		n += 1 + sovClient(uint64(m.Gen))
-	}
-	l = len(m.UID)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(m.Gen))
 	}
-	l = m.Data.Size()
-	n += 1 + l + sovClient(uint64(l))
-	if m.Info != nil {
-		l = m.Info.Size()
-		n += 1 + l + sovClient(uint64(l))
+	if len(m.Epoch) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Epoch)))
+		i += copy(dAtA[i:], m.Epoch)
 	}
-	return n
+	return i, nil
 }
 
-func (m *Join) Size() (n int) {
-	var l int
-	_ = l
-	l = m.Info.Size()
-	n += 1 + l + sovClient(uint64(l))
-	return n
+func (m *MarkReadResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
 
-func (m *Leave) Size() (n int) {
+func (m *MarkReadResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.Info.Size()
-	n += 1 + l + sovClient(uint64(l))
-	return n
+	return i, nil
 }
 
-func (m *Unsub) Size() (n int) {
-	var l int
-	_ = l
-	if m.Resubscribe {
-		n += 2
+func encodeVarintClient(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
 	}
-	return n
+	dAtA[offset] = uint8(v)
+	return offset + 1
 }
-
-func (m *Message) Size() (n int) {
-	var l int
-	_ = l
-	l = m.Data.Size()
-	n += 1 + l + sovClient(uint64(l))
-	return n
+func NewPopulatedError(r randyClient, easy bool) *Error {
+	this := &Error{}
+	this.Code = uint32(r.Uint32())
+	this.Message = string(randStringClient(r))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
 }
 
-func (m *ConnectRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+func NewPopulatedCommand(r randyClient, easy bool) *Command {
+	this := &Command{}
+	this.ID = uint32(r.Uint32())
+	this.Method = MethodType([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}[r.Intn(13)])
+	v1 := NewPopulatedRaw(r)
+	this.Params = *v1
+	if !easy && r.Intn(10) != 0 {
 	}
-	l = m.Data.Size()
-	n += 1 + l + sovClient(uint64(l))
-	return n
+	return this
 }
 
-func (m *ConnectResult) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Client)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
-	}
-	l = len(m.Version)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+func NewPopulatedReply(r randyClient, easy bool) *Reply {
+	this := &Reply{}
+	this.ID = uint32(r.Uint32())
+	if r.Intn(10) != 0 {
+		this.Error = NewPopulatedError(r, easy)
 	}
-	if m.Expires {
-		n += 2
+	v2 := NewPopulatedRaw(r)
+	this.Result = *v2
+	if !easy && r.Intn(10) != 0 {
 	}
-	if m.TTL != 0 {
-		n += 1 + sovClient(uint64(m.TTL))
+	return this
+}
+
+func NewPopulatedPush(r randyClient, easy bool) *Push {
+	this := &Push{}
+	this.Type = PushType([]int32{0, 1, 2, 3, 4, 5, 6, 7}[r.Intn(8)])
+	this.Channel = string(randStringClient(r))
+	v3 := NewPopulatedRaw(r)
+	this.Data = *v3
+	if !easy && r.Intn(10) != 0 {
 	}
-	l = m.Data.Size()
-	n += 1 + l + sovClient(uint64(l))
-	return n
+	return this
 }
 
-func (m *RefreshRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+func NewPopulatedClientInfo(r randyClient, easy bool) *ClientInfo {
+	this := &ClientInfo{}
+	this.User = string(randStringClient(r))
+	this.Client = string(randStringClient(r))
+	v4 := NewPopulatedRaw(r)
+	this.ConnInfo = *v4
+	v5 := NewPopulatedRaw(r)
+	this.ChanInfo = *v5
+	if !easy && r.Intn(10) != 0 {
 	}
-	return n
+	return this
 }
 
-func (m *RefreshResult) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Client)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+func NewPopulatedPublication(r randyClient, easy bool) *Publication {
+	this := &Publication{}
+	this.Seq = uint32(r.Uint32())
+	this.Gen = uint32(r.Uint32())
+	this.UID = string(randStringClient(r))
+	v6 := NewPopulatedRaw(r)
+	this.Data = *v6
+	if r.Intn(10) != 0 {
+		this.Info = NewPopulatedClientInfo(r, easy)
 	}
-	l = len(m.Version)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+	this.ExpireAt = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.ExpireAt *= -1
 	}
-	if m.Expires {
-		n += 2
+	this.Ephemeral = bool(bool(r.Intn(2) == 0))
+	this.Op = PublicationOp([]int32{0, 1, 2}[r.Intn(3)])
+	this.RefSeq = uint32(r.Uint32())
+	this.RefGen = uint32(r.Uint32())
+	v7 := r.Intn(100)
+	this.B64Data = make([]byte, v7)
+	for i := 0; i < v7; i++ {
+		this.B64Data[i] = byte(r.Intn(256))
 	}
-	if m.TTL != 0 {
-		n += 1 + sovClient(uint64(m.TTL))
+	this.Binary = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
 	}
-	return n
+	return this
 }
 
-func (m *SubscribeRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Channel)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
-	}
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
-	}
-	if m.Recover {
-		n += 2
+func NewPopulatedJoin(r randyClient, easy bool) *Join {
+	this := &Join{}
+	v8 := NewPopulatedClientInfo(r, easy)
+	this.Info = *v8
+	if !easy && r.Intn(10) != 0 {
 	}
-	if m.Seq != 0 {
-		n += 1 + sovClient(uint64(m.Seq))
-	}
-	if m.Gen != 0 {
-		n += 1 + sovClient(uint64(m.Gen))
-	}
-	l = len(m.Epoch)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+	return this
+}
+
+func NewPopulatedLeave(r randyClient, easy bool) *Leave {
+	this := &Leave{}
+	v9 := NewPopulatedClientInfo(r, easy)
+	this.Info = *v9
+	if !easy && r.Intn(10) != 0 {
 	}
-	return n
+	return this
 }
 
-func (m *SubscribeResult) Size() (n int) {
-	var l int
-	_ = l
-	if m.Expires {
-		n += 2
+func NewPopulatedMembership(r randyClient, easy bool) *Membership {
+	this := &Membership{}
+	this.Joined = uint32(r.Uint32())
+	this.Left = uint32(r.Uint32())
+	if r.Intn(10) != 0 {
+		v10 := r.Intn(5)
+		this.Joins = make([]*ClientInfo, v10)
+		for i := 0; i < v10; i++ {
+			this.Joins[i] = NewPopulatedClientInfo(r, easy)
+		}
 	}
-	if m.TTL != 0 {
-		n += 1 + sovClient(uint64(m.TTL))
+	if r.Intn(10) != 0 {
+		v11 := r.Intn(5)
+		this.Leaves = make([]*ClientInfo, v11)
+		for i := 0; i < v11; i++ {
+			this.Leaves[i] = NewPopulatedClientInfo(r, easy)
+		}
 	}
-	if m.Recoverable {
-		n += 2
+	if !easy && r.Intn(10) != 0 {
 	}
-	if m.Seq != 0 {
-		n += 1 + sovClient(uint64(m.Seq))
+	return this
+}
+
+func NewPopulatedRefreshDemand(r randyClient, easy bool) *RefreshDemand {
+	this := &RefreshDemand{}
+	this.TTL = uint32(r.Uint32())
+	if !easy && r.Intn(10) != 0 {
 	}
-	if m.Gen != 0 {
-		n += 1 + sovClient(uint64(m.Gen))
+	return this
+}
+
+func NewPopulatedUnsub(r randyClient, easy bool) *Unsub {
+	this := &Unsub{}
+	this.Resubscribe = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
 	}
-	l = len(m.Epoch)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+	return this
+}
+
+func NewPopulatedSub(r randyClient, easy bool) *Sub {
+	this := &Sub{}
+	this.Recoverable = bool(bool(r.Intn(2) == 0))
+	this.Seq = uint32(r.Uint32())
+	this.Gen = uint32(r.Uint32())
+	this.Epoch = string(randStringClient(r))
+	if !easy && r.Intn(10) != 0 {
 	}
-	if len(m.Publications) > 0 {
-		for _, e := range m.Publications {
-			l = e.Size()
-			n += 1 + l + sovClient(uint64(l))
-		}
+	return this
+}
+
+func NewPopulatedMessage(r randyClient, easy bool) *Message {
+	this := &Message{}
+	v12 := NewPopulatedRaw(r)
+	this.Data = *v12
+	v13 := r.Intn(100)
+	this.B64Data = make([]byte, v13)
+	for i := 0; i < v13; i++ {
+		this.B64Data[i] = byte(r.Intn(256))
 	}
-	if m.Recovered {
-		n += 2
+	if !easy && r.Intn(10) != 0 {
 	}
-	return n
+	return this
 }
 
-func (m *SubRefreshRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Channel)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
-	}
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+func NewPopulatedConnectRequest(r randyClient, easy bool) *ConnectRequest {
+	this := &ConnectRequest{}
+	this.Token = string(randStringClient(r))
+	v14 := NewPopulatedRaw(r)
+	this.Data = *v14
+	if !easy && r.Intn(10) != 0 {
 	}
-	return n
+	return this
 }
 
-func (m *SubRefreshResult) Size() (n int) {
-	var l int
-	_ = l
-	if m.Expires {
-		n += 2
+func NewPopulatedConnectResult(r randyClient, easy bool) *ConnectResult {
+	this := &ConnectResult{}
+	this.Client = string(randStringClient(r))
+	this.Version = string(randStringClient(r))
+	this.Expires = bool(bool(r.Intn(2) == 0))
+	this.TTL = uint32(r.Uint32())
+	v15 := NewPopulatedRaw(r)
+	this.Data = *v15
+	if r.Intn(10) != 0 {
+		this.Redirect = NewPopulatedRedirect(r, easy)
 	}
-	if m.TTL != 0 {
-		n += 1 + sovClient(uint64(m.TTL))
+	if r.Intn(10) != 0 {
+		this.ReconnectAdvice = NewPopulatedReconnectAdvice(r, easy)
 	}
-	return n
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
 }
 
-func (m *UnsubscribeRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Channel)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+func NewPopulatedRedirect(r randyClient, easy bool) *Redirect {
+	this := &Redirect{}
+	v16 := r.Intn(10)
+	this.Endpoints = make([]string, v16)
+	for i := 0; i < v16; i++ {
+		this.Endpoints[i] = string(randStringClient(r))
 	}
-	return n
+	this.Reason = string(randStringClient(r))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
 }
 
-func (m *UnsubscribeResult) Size() (n int) {
-	var l int
-	_ = l
-	return n
+func NewPopulatedReconnectAdvice(r randyClient, easy bool) *ReconnectAdvice {
+	this := &ReconnectAdvice{}
+	this.MinDelay = uint32(r.Uint32())
+	this.MaxDelay = uint32(r.Uint32())
+	this.Jitter = uint32(r.Uint32())
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
 }
 
-func (m *PublishRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Channel)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+func NewPopulatedRefreshRequest(r randyClient, easy bool) *RefreshRequest {
+	this := &RefreshRequest{}
+	this.Token = string(randStringClient(r))
+	if !easy && r.Intn(10) != 0 {
 	}
-	l = m.Data.Size()
-	n += 1 + l + sovClient(uint64(l))
-	return n
+	return this
 }
 
-func (m *PublishResult) Size() (n int) {
-	var l int
-	_ = l
-	return n
+func NewPopulatedRefreshResult(r randyClient, easy bool) *RefreshResult {
+	this := &RefreshResult{}
+	this.Client = string(randStringClient(r))
+	this.Version = string(randStringClient(r))
+	this.Expires = bool(bool(r.Intn(2) == 0))
+	this.TTL = uint32(r.Uint32())
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
 }
 
-func (m *PresenceRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Channel)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+func NewPopulatedSubscribeRequest(r randyClient, easy bool) *SubscribeRequest {
+	this := &SubscribeRequest{}
+	this.Channel = string(randStringClient(r))
+	this.Token = string(randStringClient(r))
+	this.Recover = bool(bool(r.Intn(2) == 0))
+	this.Seq = uint32(r.Uint32())
+	this.Gen = uint32(r.Uint32())
+	this.Epoch = string(randStringClient(r))
+	if !easy && r.Intn(10) != 0 {
 	}
-	return n
+	return this
 }
 
-func (m *PresenceResult) Size() (n int) {
-	var l int
-	_ = l
-	if len(m.Presence) > 0 {
-		for k, v := range m.Presence {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.Size()
-				l += 1 + sovClient(uint64(l))
-			}
-			mapEntrySize := 1 + len(k) + sovClient(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovClient(uint64(mapEntrySize))
+func NewPopulatedSubscribeResult(r randyClient, easy bool) *SubscribeResult {
+	this := &SubscribeResult{}
+	this.Expires = bool(bool(r.Intn(2) == 0))
+	this.TTL = uint32(r.Uint32())
+	this.Recoverable = bool(bool(r.Intn(2) == 0))
+	this.Seq = uint32(r.Uint32())
+	this.Gen = uint32(r.Uint32())
+	this.Epoch = string(randStringClient(r))
+	if r.Intn(10) != 0 {
+		v17 := r.Intn(5)
+		this.Publications = make([]*Publication, v17)
+		for i := 0; i < v17; i++ {
+			this.Publications[i] = NewPopulatedPublication(r, easy)
 		}
 	}
-	return n
+	this.Recovered = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
 }
 
-func (m *PresenceStatsRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Channel)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+func NewPopulatedSubRefreshRequest(r randyClient, easy bool) *SubRefreshRequest {
+	this := &SubRefreshRequest{}
+	this.Channel = string(randStringClient(r))
+	this.Token = string(randStringClient(r))
+	if !easy && r.Intn(10) != 0 {
 	}
-	return n
+	return this
 }
 
-func (m *PresenceStatsResult) Size() (n int) {
-	var l int
-	_ = l
-	if m.NumClients != 0 {
-		n += 1 + sovClient(uint64(m.NumClients))
-	}
-	if m.NumUsers != 0 {
-		n += 1 + sovClient(uint64(m.NumUsers))
+func NewPopulatedSubRefreshResult(r randyClient, easy bool) *SubRefreshResult {
+	this := &SubRefreshResult{}
+	this.Expires = bool(bool(r.Intn(2) == 0))
+	this.TTL = uint32(r.Uint32())
+	if !easy && r.Intn(10) != 0 {
 	}
-	return n
+	return this
 }
 
-func (m *HistoryRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Channel)
-	if l > 0 {
-		n += 1 + l + sovClient(uint64(l))
+func NewPopulatedUnsubscribeRequest(r randyClient, easy bool) *UnsubscribeRequest {
+	this := &UnsubscribeRequest{}
+	this.Channel = string(randStringClient(r))
+	if !easy && r.Intn(10) != 0 {
 	}
-	return n
+	return this
 }
 
-func (m *HistoryResult) Size() (n int) {
-	var l int
-	_ = l
-	if len(m.Publications) > 0 {
-		for _, e := range m.Publications {
-			l = e.Size()
-			n += 1 + l + sovClient(uint64(l))
-		}
+func NewPopulatedUnsubscribeResult(r randyClient, easy bool) *UnsubscribeResult {
+	this := &UnsubscribeResult{}
+	if !easy && r.Intn(10) != 0 {
 	}
-	return n
+	return this
 }
 
-func (m *PingRequest) Size() (n int) {
-	var l int
-	_ = l
-	return n
-}
+func NewPopulatedPublishRequest(r randyClient, easy bool) *PublishRequest {
+	this := &PublishRequest{}
+	this.Channel = string(randStringClient(r))
+	v18 := NewPopulatedRaw(r)
+	this.Data = *v18
+	this.IdempotencyKey = string(randStringClient(r))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
 
-func (m *PingResult) Size() (n int) {
+func NewPopulatedPublishResult(r randyClient, easy bool) *PublishResult {
+	this := &PublishResult{}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPresenceRequest(r randyClient, easy bool) *PresenceRequest {
+	this := &PresenceRequest{}
+	this.Channel = string(randStringClient(r))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPresenceResult(r randyClient, easy bool) *PresenceResult {
+	this := &PresenceResult{}
+	if r.Intn(10) != 0 {
+		v19 := r.Intn(10)
+		this.Presence = make(map[string]*ClientInfo)
+		for i := 0; i < v19; i++ {
+			this.Presence[randStringClient(r)] = NewPopulatedClientInfo(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPresenceStatsRequest(r randyClient, easy bool) *PresenceStatsRequest {
+	this := &PresenceStatsRequest{}
+	this.Channel = string(randStringClient(r))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPresenceStatsResult(r randyClient, easy bool) *PresenceStatsResult {
+	this := &PresenceStatsResult{}
+	this.NumClients = uint32(r.Uint32())
+	this.NumUsers = uint32(r.Uint32())
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedHistoryRequest(r randyClient, easy bool) *HistoryRequest {
+	this := &HistoryRequest{}
+	this.Channel = string(randStringClient(r))
+	this.UseSince = bool(bool(r.Intn(2) == 0))
+	this.Seq = uint32(r.Uint32())
+	this.Gen = uint32(r.Uint32())
+	this.Epoch = string(randStringClient(r))
+	this.Limit = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.Limit *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedHistoryResult(r randyClient, easy bool) *HistoryResult {
+	this := &HistoryResult{}
+	if r.Intn(10) != 0 {
+		v20 := r.Intn(5)
+		this.Publications = make([]*Publication, v20)
+		for i := 0; i < v20; i++ {
+			this.Publications[i] = NewPopulatedPublication(r, easy)
+		}
+	}
+	this.Seq = uint32(r.Uint32())
+	this.Gen = uint32(r.Uint32())
+	this.Epoch = string(randStringClient(r))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPingRequest(r randyClient, easy bool) *PingRequest {
+	this := &PingRequest{}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPingResult(r randyClient, easy bool) *PingResult {
+	this := &PingResult{}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedRPCRequest(r randyClient, easy bool) *RPCRequest {
+	this := &RPCRequest{}
+	v21 := NewPopulatedRaw(r)
+	this.Data = *v21
+	this.Method = string(randStringClient(r))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedRPCResult(r randyClient, easy bool) *RPCResult {
+	this := &RPCResult{}
+	v22 := NewPopulatedRaw(r)
+	this.Data = *v22
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedSendRequest(r randyClient, easy bool) *SendRequest {
+	this := &SendRequest{}
+	v23 := NewPopulatedRaw(r)
+	this.Data = *v23
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedMarkReadRequest(r randyClient, easy bool) *MarkReadRequest {
+	this := &MarkReadRequest{}
+	this.Channel = string(randStringClient(r))
+	this.Seq = uint32(r.Uint32())
+	this.Gen = uint32(r.Uint32())
+	this.Epoch = string(randStringClient(r))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedMarkReadResult(r randyClient, easy bool) *MarkReadResult {
+	this := &MarkReadResult{}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+type randyClient interface {
+	Float32() float32
+	Float64() float64
+	Int63() int64
+	Int31() int32
+	Uint32() uint32
+	Intn(n int) int
+}
+
+func randUTF8RuneClient(r randyClient) rune {
+	ru := r.Intn(62)
+	if ru < 10 {
+		return rune(ru + 48)
+	} else if ru < 36 {
+		return rune(ru + 55)
+	}
+	return rune(ru + 61)
+}
+func randStringClient(r randyClient) string {
+	v24 := r.Intn(100)
+	tmps := make([]rune, v24)
+	for i := 0; i < v24; i++ {
+		tmps[i] = randUTF8RuneClient(r)
+	}
+	return string(tmps)
+}
+func randUnrecognizedClient(r randyClient, maxFieldNumber int) (dAtA []byte) {
+	l := r.Intn(5)
+	for i := 0; i < l; i++ {
+		wire := r.Intn(4)
+		if wire == 3 {
+			wire = 5
+		}
+		fieldNumber := maxFieldNumber + r.Intn(100)
+		dAtA = randFieldClient(dAtA, r, fieldNumber, wire)
+	}
+	return dAtA
+}
+func randFieldClient(dAtA []byte, r randyClient, fieldNumber int, wire int) []byte {
+	key := uint32(fieldNumber)<<3 | uint32(wire)
+	switch wire {
+	case 0:
+		dAtA = encodeVarintPopulateClient(dAtA, uint64(key))
+		v25 := r.Int63()
+		if r.Intn(2) == 0 {
+			v25 *= -1
+		}
+		dAtA = encodeVarintPopulateClient(dAtA, uint64(v25))
+	case 1:
+		dAtA = encodeVarintPopulateClient(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	case 2:
+		dAtA = encodeVarintPopulateClient(dAtA, uint64(key))
+		ll := r.Intn(100)
+		dAtA = encodeVarintPopulateClient(dAtA, uint64(ll))
+		for j := 0; j < ll; j++ {
+			dAtA = append(dAtA, byte(r.Intn(256)))
+		}
+	default:
+		dAtA = encodeVarintPopulateClient(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	}
+	return dAtA
+}
+func encodeVarintPopulateClient(dAtA []byte, v uint64) []byte {
+	for v >= 1<<7 {
+		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
+		v >>= 7
+	}
+	dAtA = append(dAtA, uint8(v))
+	return dAtA
+}
+func (m *Error) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
+	if m.Code != 0 {
+		n += 1 + sovClient(uint64(m.Code))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
 	return n
 }
 
-func (m *RPCRequest) Size() (n int) {
+func (m *Command) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
-	l = m.Data.Size()
+	if m.ID != 0 {
+		n += 1 + sovClient(uint64(m.ID))
+	}
+	if m.Method != 0 {
+		n += 1 + sovClient(uint64(m.Method))
+	}
+	l = m.Params.Size()
 	n += 1 + l + sovClient(uint64(l))
 	return n
 }
 
-func (m *RPCResult) Size() (n int) {
+func (m *Reply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
-	l = m.Data.Size()
+	if m.ID != 0 {
+		n += 1 + sovClient(uint64(m.ID))
+	}
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = m.Result.Size()
 	n += 1 + l + sovClient(uint64(l))
 	return n
 }
 
-func (m *SendRequest) Size() (n int) {
+func (m *Push) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
+	if m.Type != 0 {
+		n += 1 + sovClient(uint64(m.Type))
+	}
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
 	l = m.Data.Size()
 	n += 1 + l + sovClient(uint64(l))
 	return n
 }
 
-func sovClient(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+func (m *ClientInfo) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	return n
-}
-func sozClient(x uint64) (n int) {
-	return sovClient(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (m *Error) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
+	var l int
+	_ = l
+	l = len(m.User)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = len(m.Client)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = m.ConnInfo.Size()
+	n += 1 + l + sovClient(uint64(l))
+	l = m.ChanInfo.Size()
+	n += 1 + l + sovClient(uint64(l))
+	return n
+}
+
+func (m *Publication) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Seq != 0 {
+		n += 1 + sovClient(uint64(m.Seq))
+	}
+	if m.Gen != 0 {
+		n += 1 + sovClient(uint64(m.Gen))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = m.Data.Size()
+	n += 1 + l + sovClient(uint64(l))
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if m.ExpireAt != 0 {
+		n += 1 + sovClient(uint64(m.ExpireAt))
+	}
+	if m.Ephemeral {
+		n += 2
+	}
+	if m.Op != 0 {
+		n += 1 + sovClient(uint64(m.Op))
+	}
+	if m.RefSeq != 0 {
+		n += 1 + sovClient(uint64(m.RefSeq))
+	}
+	if m.RefGen != 0 {
+		n += 1 + sovClient(uint64(m.RefGen))
+	}
+	l = len(m.B64Data)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if m.Binary {
+		n += 2
+	}
+	return n
+}
+
+func (m *Join) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Info.Size()
+	n += 1 + l + sovClient(uint64(l))
+	return n
+}
+
+func (m *Leave) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Info.Size()
+	n += 1 + l + sovClient(uint64(l))
+	return n
+}
+
+func (m *Membership) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Joined != 0 {
+		n += 1 + sovClient(uint64(m.Joined))
+	}
+	if m.Left != 0 {
+		n += 1 + sovClient(uint64(m.Left))
+	}
+	if len(m.Joins) > 0 {
+		for _, e := range m.Joins {
+			l = e.Size()
+			n += 1 + l + sovClient(uint64(l))
+		}
+	}
+	if len(m.Leaves) > 0 {
+		for _, e := range m.Leaves {
+			l = e.Size()
+			n += 1 + l + sovClient(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RefreshDemand) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TTL != 0 {
+		n += 1 + sovClient(uint64(m.TTL))
+	}
+	return n
+}
+
+func (m *Unsub) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Resubscribe {
+		n += 2
+	}
+	return n
+}
+
+func (m *Sub) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Recoverable {
+		n += 2
+	}
+	if m.Seq != 0 {
+		n += 1 + sovClient(uint64(m.Seq))
+	}
+	if m.Gen != 0 {
+		n += 1 + sovClient(uint64(m.Gen))
+	}
+	l = len(m.Epoch)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *Message) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Data.Size()
+	n += 1 + l + sovClient(uint64(l))
+	l = len(m.B64Data)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *ConnectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = m.Data.Size()
+	n += 1 + l + sovClient(uint64(l))
+	return n
+}
+
+func (m *ConnectResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Client)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if m.Expires {
+		n += 2
+	}
+	if m.TTL != 0 {
+		n += 1 + sovClient(uint64(m.TTL))
+	}
+	l = m.Data.Size()
+	n += 1 + l + sovClient(uint64(l))
+	if m.Redirect != nil {
+		l = m.Redirect.Size()
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if m.ReconnectAdvice != nil {
+		l = m.ReconnectAdvice.Size()
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *Redirect) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Endpoints) > 0 {
+		for _, s := range m.Endpoints {
+			l = len(s)
+			n += 1 + l + sovClient(uint64(l))
+		}
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *ReconnectAdvice) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MinDelay != 0 {
+		n += 1 + sovClient(uint64(m.MinDelay))
+	}
+	if m.MaxDelay != 0 {
+		n += 1 + sovClient(uint64(m.MaxDelay))
+	}
+	if m.Jitter != 0 {
+		n += 1 + sovClient(uint64(m.Jitter))
+	}
+	return n
+}
+
+func (m *RefreshRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *RefreshResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Client)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if m.Expires {
+		n += 2
+	}
+	if m.TTL != 0 {
+		n += 1 + sovClient(uint64(m.TTL))
+	}
+	return n
+}
+
+func (m *SubscribeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if m.Recover {
+		n += 2
+	}
+	if m.Seq != 0 {
+		n += 1 + sovClient(uint64(m.Seq))
+	}
+	if m.Gen != 0 {
+		n += 1 + sovClient(uint64(m.Gen))
+	}
+	l = len(m.Epoch)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *SubscribeResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Expires {
+		n += 2
+	}
+	if m.TTL != 0 {
+		n += 1 + sovClient(uint64(m.TTL))
+	}
+	if m.Recoverable {
+		n += 2
+	}
+	if m.Seq != 0 {
+		n += 1 + sovClient(uint64(m.Seq))
+	}
+	if m.Gen != 0 {
+		n += 1 + sovClient(uint64(m.Gen))
+	}
+	l = len(m.Epoch)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if len(m.Publications) > 0 {
+		for _, e := range m.Publications {
+			l = e.Size()
+			n += 1 + l + sovClient(uint64(l))
+		}
+	}
+	if m.Recovered {
+		n += 2
+	}
+	return n
+}
+
+func (m *SubRefreshRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *SubRefreshResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Expires {
+		n += 2
+	}
+	if m.TTL != 0 {
+		n += 1 + sovClient(uint64(m.TTL))
+	}
+	return n
+}
+
+func (m *UnsubscribeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *UnsubscribeResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *PublishRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	l = m.Data.Size()
+	n += 1 + l + sovClient(uint64(l))
+	l = len(m.IdempotencyKey)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *PublishResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *PresenceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *PresenceResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Presence) > 0 {
+		for k, v := range m.Presence {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovClient(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovClient(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovClient(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *PresenceStatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *PresenceStatsResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NumClients != 0 {
+		n += 1 + sovClient(uint64(m.NumClients))
+	}
+	if m.NumUsers != 0 {
+		n += 1 + sovClient(uint64(m.NumUsers))
+	}
+	return n
+}
+
+func (m *HistoryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if m.UseSince {
+		n += 2
+	}
+	if m.Seq != 0 {
+		n += 1 + sovClient(uint64(m.Seq))
+	}
+	if m.Gen != 0 {
+		n += 1 + sovClient(uint64(m.Gen))
+	}
+	l = len(m.Epoch)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovClient(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *HistoryResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Publications) > 0 {
+		for _, e := range m.Publications {
+			l = e.Size()
+			n += 1 + l + sovClient(uint64(l))
+		}
+	}
+	if m.Seq != 0 {
+		n += 1 + sovClient(uint64(m.Seq))
+	}
+	if m.Gen != 0 {
+		n += 1 + sovClient(uint64(m.Gen))
+	}
+	l = len(m.Epoch)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *PingRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *PingResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RPCRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Data.Size()
+	n += 1 + l + sovClient(uint64(l))
+	l = len(m.Method)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *RPCResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Data.Size()
+	n += 1 + l + sovClient(uint64(l))
+	return n
+}
+
+func (m *SendRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Data.Size()
+	n += 1 + l + sovClient(uint64(l))
+	return n
+}
+
+func (m *MarkReadRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	if m.Seq != 0 {
+		n += 1 + sovClient(uint64(m.Seq))
+	}
+	if m.Gen != 0 {
+		n += 1 + sovClient(uint64(m.Gen))
+	}
+	l = len(m.Epoch)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
+	}
+	return n
+}
+
+func (m *MarkReadResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func sovClient(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozClient(x uint64) (n int) {
+	return sovClient(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Error) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Error: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Error: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Command) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Command: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Command: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
+			}
+			m.Method = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Method |= MethodType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Reply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Reply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Reply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Push) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Push: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Push: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= PushType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Channel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ClientInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ClientInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ClientInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.User = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Client", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Client = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnInfo", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ConnInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChanInfo", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ChanInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Publication) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
 		for shift := uint(0); ; shift += 7 {
 			if shift >= 64 {
 				return ErrIntOverflowClient
 			}
-			if iNdEx >= l {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Publication: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Publication: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seq |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gen", wireType)
+			}
+			m.Gen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Gen |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Error: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Error: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Info == nil {
+				m.Info = &ClientInfo{}
+			}
+			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireAt", wireType)
 			}
-			m.Code = 0
+			m.ExpireAt = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -3777,16 +7331,93 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= (uint32(b) & 0x7F) << shift
+				m.ExpireAt |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ephemeral", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Ephemeral = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
+			}
+			m.Op = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Op |= PublicationOp(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefSeq", wireType)
+			}
+			m.RefSeq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RefSeq |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefGen", wireType)
+			}
+			m.RefGen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RefGen |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field B64Data", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -3796,21 +7427,46 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthClient
 			}
-			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.B64Data = append(m.B64Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.B64Data == nil {
+				m.B64Data = []byte{}
+			}
 			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Binary", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Binary = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClient(dAtA[iNdEx:])
@@ -3820,6 +7476,9 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -3832,7 +7491,7 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Command) Unmarshal(dAtA []byte) error {
+func (m *Join) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3847,7 +7506,7 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -3855,17 +7514,17 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Command: wiretype end group for non-group")
+			return fmt.Errorf("proto: Join: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Command: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Join: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
-			m.ID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -3875,35 +7534,83 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= (uint32(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthClient
 			}
-			m.Method = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowClient
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Method |= (MethodType(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
 			}
-		case 3:
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Leave) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Leave: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Leave: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -3913,19 +7620,22 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthClient
 			}
-			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3938,6 +7648,9 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -3950,7 +7663,7 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Reply) Unmarshal(dAtA []byte) error {
+func (m *Membership) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3965,7 +7678,7 @@ func (m *Reply) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -3973,17 +7686,17 @@ func (m *Reply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Reply: wiretype end group for non-group")
+			return fmt.Errorf("proto: Membership: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Reply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Membership: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Joined", wireType)
 			}
-			m.ID = 0
+			m.Joined = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -3993,14 +7706,33 @@ func (m *Reply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= (uint32(b) & 0x7F) << shift
+				m.Joined |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Left", wireType)
+			}
+			m.Left = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Left |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Joins", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4012,7 +7744,7 @@ func (m *Reply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4021,21 +7753,22 @@ func (m *Reply) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Error == nil {
-				m.Error = &Error{}
-			}
-			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Joins = append(m.Joins, &ClientInfo{})
+			if err := m.Joins[len(m.Joins)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Leaves", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -4045,19 +7778,23 @@ func (m *Reply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthClient
 			}
-			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Leaves = append(m.Leaves, &ClientInfo{})
+			if err := m.Leaves[len(m.Leaves)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4070,6 +7807,9 @@ func (m *Reply) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4082,7 +7822,7 @@ func (m *Reply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Push) Unmarshal(dAtA []byte) error {
+func (m *RefreshDemand) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4097,7 +7837,7 @@ func (m *Push) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -4105,65 +7845,17 @@ func (m *Push) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Push: wiretype end group for non-group")
+			return fmt.Errorf("proto: RefreshDemand: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Push: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RefreshDemand: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowClient
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= (PushType(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowClient
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthClient
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Channel = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
 			}
-			var byteLen int
+			m.TTL = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -4173,22 +7865,11 @@ func (m *Push) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.TTL |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthClient
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClient(dAtA[iNdEx:])
@@ -4198,6 +7879,9 @@ func (m *Push) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4210,7 +7894,7 @@ func (m *Push) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ClientInfo) Unmarshal(dAtA []byte) error {
+func (m *Unsub) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4225,7 +7909,7 @@ func (m *ClientInfo) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -4233,105 +7917,17 @@ func (m *ClientInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ClientInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: Unsub: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ClientInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Unsub: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowClient
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthClient
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.User = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Client", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowClient
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthClient
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Client = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConnInfo", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowClient
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthClient
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.ConnInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChanInfo", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resubscribe", wireType)
 			}
-			var byteLen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -4341,22 +7937,12 @@ func (m *ClientInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthClient
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.ChanInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.Resubscribe = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClient(dAtA[iNdEx:])
@@ -4366,6 +7952,9 @@ func (m *ClientInfo) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4378,7 +7967,7 @@ func (m *ClientInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Publication) Unmarshal(dAtA []byte) error {
+func (m *Sub) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4393,21 +7982,41 @@ func (m *Publication) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Publication: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Publication: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Sub: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Sub: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Recoverable", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Recoverable = bool(v != 0)
+		case 2:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
 			}
@@ -4421,12 +8030,12 @@ func (m *Publication) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Seq |= (uint32(b) & 0x7F) << shift
+				m.Seq |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 3:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Gen", wireType)
 			}
@@ -4440,14 +8049,14 @@ func (m *Publication) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Gen |= (uint32(b) & 0x7F) << shift
+				m.Gen |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4459,7 +8068,7 @@ func (m *Publication) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4469,12 +8078,68 @@ func (m *Publication) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UID = string(dAtA[iNdEx:postIndex])
+			m.Epoch = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Message) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Message: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Message: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
@@ -4488,7 +8153,7 @@ func (m *Publication) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4497,6 +8162,9 @@ func (m *Publication) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4504,11 +8172,11 @@ func (m *Publication) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field B64Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -4518,23 +8186,24 @@ func (m *Publication) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthClient
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Info == nil {
-				m.Info = &ClientInfo{}
-			}
-			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.B64Data = append(m.B64Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.B64Data == nil {
+				m.B64Data = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -4546,6 +8215,9 @@ func (m *Publication) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4558,7 +8230,7 @@ func (m *Publication) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Join) Unmarshal(dAtA []byte) error {
+func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4573,7 +8245,7 @@ func (m *Join) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -4581,17 +8253,17 @@ func (m *Join) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Join: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConnectRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Join: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -4601,19 +8273,54 @@ func (m *Join) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthClient
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4626,6 +8333,9 @@ func (m *Join) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4638,7 +8348,7 @@ func (m *Join) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Leave) Unmarshal(dAtA []byte) error {
+func (m *ConnectResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4653,7 +8363,7 @@ func (m *Leave) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -4661,17 +8371,17 @@ func (m *Leave) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Leave: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConnectResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Leave: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConnectResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Client", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -4681,77 +8391,133 @@ func (m *Leave) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthClient
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Client = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Version = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipClient(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Expires = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
+			}
+			m.TTL = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TTL |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if skippy < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthClient
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Unsub) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowClient
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Unsub: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Unsub: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Resubscribe", wireType)
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Redirect", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -4761,67 +8527,33 @@ func (m *Unsub) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Resubscribe = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipClient(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			if msglen < 0 {
+				return ErrInvalidLengthClient
 			}
-			if skippy < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthClient
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Message) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowClient
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.Redirect == nil {
+				m.Redirect = &Redirect{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Redirect.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Message: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Message: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReconnectAdvice", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -4831,19 +8563,25 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthClient
 			}
-			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.ReconnectAdvice == nil {
+				m.ReconnectAdvice = &ReconnectAdvice{}
+			}
+			if err := m.ReconnectAdvice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4856,6 +8594,9 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4868,7 +8609,7 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
+func (m *Redirect) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4883,7 +8624,7 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -4891,15 +8632,15 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConnectRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Redirect: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Redirect: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoints", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4911,7 +8652,7 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4921,16 +8662,19 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.Endpoints = append(m.Endpoints, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -4940,21 +8684,23 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthClient
 			}
-			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4965,6 +8711,9 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4977,7 +8726,7 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConnectResult) Unmarshal(dAtA []byte) error {
+func (m *ReconnectAdvice) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4992,7 +8741,7 @@ func (m *ConnectResult) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -5000,17 +8749,17 @@ func (m *ConnectResult) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConnectResult: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReconnectAdvice: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConnectResult: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReconnectAdvice: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Client", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinDelay", wireType)
 			}
-			var stringLen uint64
+			m.MinDelay = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -5020,55 +8769,16 @@ func (m *ConnectResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.MinDelay |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthClient
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Client = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowClient
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthClient
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Version = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxDelay", wireType)
 			}
-			var v int
+			m.MaxDelay = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -5078,36 +8788,16 @@ func (m *ConnectResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.MaxDelay |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Expires = bool(v != 0)
-		case 4:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
-			}
-			m.TTL = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowClient
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.TTL |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Jitter", wireType)
 			}
-			var byteLen int
+			m.Jitter = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowClient
@@ -5117,22 +8807,11 @@ func (m *ConnectResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.Jitter |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthClient
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClient(dAtA[iNdEx:])
@@ -5142,6 +8821,9 @@ func (m *ConnectResult) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5169,7 +8851,7 @@ func (m *RefreshRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -5197,7 +8879,7 @@ func (m *RefreshRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5207,6 +8889,9 @@ func (m *RefreshRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5221,6 +8906,9 @@ func (m *RefreshRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5248,7 +8936,7 @@ func (m *RefreshResult) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -5276,7 +8964,7 @@ func (m *RefreshResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5286,6 +8974,9 @@ func (m *RefreshResult) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5305,7 +8996,7 @@ func (m *RefreshResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5315,6 +9006,9 @@ func (m *RefreshResult) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5334,7 +9028,7 @@ func (m *RefreshResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5354,7 +9048,7 @@ func (m *RefreshResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TTL |= (uint32(b) & 0x7F) << shift
+				m.TTL |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5368,6 +9062,9 @@ func (m *RefreshResult) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5395,7 +9092,7 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -5423,7 +9120,7 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5433,6 +9130,9 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5452,7 +9152,7 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5462,6 +9162,9 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5481,7 +9184,7 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5501,7 +9204,7 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Seq |= (uint32(b) & 0x7F) << shift
+				m.Seq |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5520,7 +9223,7 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Gen |= (uint32(b) & 0x7F) << shift
+				m.Gen |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5539,7 +9242,7 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5549,6 +9252,9 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5563,6 +9269,9 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5590,7 +9299,7 @@ func (m *SubscribeResult) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -5618,7 +9327,7 @@ func (m *SubscribeResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5638,7 +9347,7 @@ func (m *SubscribeResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TTL |= (uint32(b) & 0x7F) << shift
+				m.TTL |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5657,7 +9366,7 @@ func (m *SubscribeResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5677,7 +9386,7 @@ func (m *SubscribeResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Seq |= (uint32(b) & 0x7F) << shift
+				m.Seq |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5696,7 +9405,7 @@ func (m *SubscribeResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Gen |= (uint32(b) & 0x7F) << shift
+				m.Gen |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5715,7 +9424,7 @@ func (m *SubscribeResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5725,6 +9434,9 @@ func (m *SubscribeResult) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5744,7 +9456,7 @@ func (m *SubscribeResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5752,7 +9464,10 @@ func (m *SubscribeResult) Unmarshal(dAtA []byte) error {
 			if msglen < 0 {
 				return ErrInvalidLengthClient
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5775,7 +9490,7 @@ func (m *SubscribeResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5790,6 +9505,9 @@ func (m *SubscribeResult) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5817,7 +9535,7 @@ func (m *SubRefreshRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -5845,7 +9563,7 @@ func (m *SubRefreshRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5855,6 +9573,9 @@ func (m *SubRefreshRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5874,7 +9595,7 @@ func (m *SubRefreshRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5884,6 +9605,9 @@ func (m *SubRefreshRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5898,6 +9622,9 @@ func (m *SubRefreshRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5925,7 +9652,7 @@ func (m *SubRefreshResult) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -5953,7 +9680,7 @@ func (m *SubRefreshResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5973,7 +9700,7 @@ func (m *SubRefreshResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TTL |= (uint32(b) & 0x7F) << shift
+				m.TTL |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5987,6 +9714,9 @@ func (m *SubRefreshResult) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6014,7 +9744,7 @@ func (m *UnsubscribeRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -6042,7 +9772,7 @@ func (m *UnsubscribeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6052,6 +9782,9 @@ func (m *UnsubscribeRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6066,6 +9799,9 @@ func (m *UnsubscribeRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6093,7 +9829,7 @@ func (m *UnsubscribeResult) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -6116,6 +9852,9 @@ func (m *UnsubscribeResult) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6143,7 +9882,7 @@ func (m *PublishRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -6171,7 +9910,7 @@ func (m *PublishRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6181,6 +9920,9 @@ func (m *PublishRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6200,7 +9942,7 @@ func (m *PublishRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6209,6 +9951,9 @@ func (m *PublishRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6216,6 +9961,38 @@ func (m *PublishRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdempotencyKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IdempotencyKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClient(dAtA[iNdEx:])
@@ -6225,6 +10002,9 @@ func (m *PublishRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6252,7 +10032,7 @@ func (m *PublishResult) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -6275,6 +10055,9 @@ func (m *PublishResult) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6302,7 +10085,7 @@ func (m *PresenceRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -6330,7 +10113,7 @@ func (m *PresenceRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6340,6 +10123,9 @@ func (m *PresenceRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6354,6 +10140,9 @@ func (m *PresenceRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6381,7 +10170,7 @@ func (m *PresenceResult) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -6409,7 +10198,7 @@ func (m *PresenceResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6418,6 +10207,9 @@ func (m *PresenceResult) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6438,7 +10230,7 @@ func (m *PresenceResult) Unmarshal(dAtA []byte) error {
 					}
 					b := dAtA[iNdEx]
 					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
+					wire |= uint64(b&0x7F) << shift
 					if b < 0x80 {
 						break
 					}
@@ -6455,7 +10247,7 @@ func (m *PresenceResult) Unmarshal(dAtA []byte) error {
 						}
 						b := dAtA[iNdEx]
 						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						stringLenmapkey |= uint64(b&0x7F) << shift
 						if b < 0x80 {
 							break
 						}
@@ -6465,6 +10257,9 @@ func (m *PresenceResult) Unmarshal(dAtA []byte) error {
 						return ErrInvalidLengthClient
 					}
 					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthClient
+					}
 					if postStringIndexmapkey > l {
 						return io.ErrUnexpectedEOF
 					}
@@ -6481,7 +10276,7 @@ func (m *PresenceResult) Unmarshal(dAtA []byte) error {
 						}
 						b := dAtA[iNdEx]
 						iNdEx++
-						mapmsglen |= (int(b) & 0x7F) << shift
+						mapmsglen |= int(b&0x7F) << shift
 						if b < 0x80 {
 							break
 						}
@@ -6490,7 +10285,7 @@ func (m *PresenceResult) Unmarshal(dAtA []byte) error {
 						return ErrInvalidLengthClient
 					}
 					postmsgIndex := iNdEx + mapmsglen
-					if mapmsglen < 0 {
+					if postmsgIndex < 0 {
 						return ErrInvalidLengthClient
 					}
 					if postmsgIndex > l {
@@ -6527,6 +10322,9 @@ func (m *PresenceResult) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6554,7 +10352,7 @@ func (m *PresenceStatsRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -6582,7 +10380,7 @@ func (m *PresenceStatsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6592,6 +10390,9 @@ func (m *PresenceStatsRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6606,6 +10407,9 @@ func (m *PresenceStatsRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6633,7 +10437,7 @@ func (m *PresenceStatsResult) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -6661,7 +10465,7 @@ func (m *PresenceStatsResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NumClients |= (uint32(b) & 0x7F) << shift
+				m.NumClients |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6680,7 +10484,7 @@ func (m *PresenceStatsResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NumUsers |= (uint32(b) & 0x7F) << shift
+				m.NumUsers |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6694,6 +10498,9 @@ func (m *PresenceStatsResult) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6721,7 +10528,7 @@ func (m *HistoryRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -6749,7 +10556,7 @@ func (m *HistoryRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6759,11 +10566,123 @@ func (m *HistoryRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
 			m.Channel = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UseSince", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.UseSince = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seq |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gen", wireType)
+			}
+			m.Gen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Gen |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Epoch = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClient(dAtA[iNdEx:])
@@ -6773,6 +10692,9 @@ func (m *HistoryRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6800,7 +10722,7 @@ func (m *HistoryResult) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -6828,7 +10750,7 @@ func (m *HistoryResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6837,13 +10759,86 @@ func (m *HistoryResult) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Publications = append(m.Publications, &Publication{})
+			if err := m.Publications[len(m.Publications)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seq |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gen", wireType)
+			}
+			m.Gen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Gen |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Publications = append(m.Publications, &Publication{})
-			if err := m.Publications[len(m.Publications)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Epoch = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -6854,6 +10849,9 @@ func (m *HistoryResult) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6881,7 +10879,7 @@ func (m *PingRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -6904,6 +10902,9 @@ func (m *PingRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6931,7 +10932,7 @@ func (m *PingResult) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -6954,6 +10955,9 @@ func (m *PingResult) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -6981,7 +10985,7 @@ func (m *RPCRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -7009,7 +11013,7 @@ func (m *RPCRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -7018,6 +11022,9 @@ func (m *RPCRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -7025,6 +11032,38 @@ func (m *RPCRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Method = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClient(dAtA[iNdEx:])
@@ -7034,6 +11073,9 @@ func (m *RPCRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -7061,7 +11103,7 @@ func (m *RPCResult) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -7089,7 +11131,7 @@ func (m *RPCResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -7098,6 +11140,9 @@ func (m *RPCResult) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -7114,6 +11159,9 @@ func (m *RPCResult) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -7141,7 +11189,7 @@ func (m *SendRequest) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -7169,7 +11217,7 @@ func (m *SendRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -7178,6 +11226,9 @@ func (m *SendRequest) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthClient
 			}
 			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -7194,6 +11245,217 @@ func (m *SendRequest) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthClient
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MarkReadRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MarkReadRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MarkReadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Channel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seq |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gen", wireType)
+			}
+			m.Gen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Gen |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Epoch = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MarkReadResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowClient
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MarkReadResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MarkReadResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipClient(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthClient
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthClient
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -7260,10 +11522,13 @@ func skipClient(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			iNdEx += length
 			if length < 0 {
 				return 0, ErrInvalidLengthClient
 			}
+			iNdEx += length
+			if iNdEx < 0 {
+				return 0, ErrInvalidLengthClient
+			}
 			return iNdEx, nil
 		case 3:
 			for {
@@ -7292,6 +11557,9 @@ func skipClient(dAtA []byte) (n int, err error) {
 					return 0, err
 				}
 				iNdEx = start + next
+				if iNdEx < 0 {
+					return 0, ErrInvalidLengthClient
+				}
 			}
 			return iNdEx, nil
 		case 4:
@@ -7310,110 +11578,3 @@ var (
 	ErrInvalidLengthClient = fmt.Errorf("proto: negative length found during unmarshaling")
 	ErrIntOverflowClient   = fmt.Errorf("proto: integer overflow")
 )
-
-func init() { proto1.RegisterFile("client.proto", fileDescriptorClient) }
-
-var fileDescriptorClient = []byte{
-	// 1610 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x57, 0x4b, 0x6f, 0x23, 0x59,
-	0x15, 0x4e, 0xd9, 0xae, 0xd8, 0x3e, 0x7e, 0xa4, 0x72, 0xd3, 0xe9, 0x76, 0x4c, 0x70, 0x59, 0x35,
-	0x64, 0x3a, 0x13, 0x41, 0x87, 0xce, 0x68, 0xc8, 0x40, 0x03, 0xa3, 0xd8, 0x6d, 0x26, 0x1e, 0x25,
-	0x8e, 0x55, 0xe5, 0x20, 0x8d, 0x58, 0x04, 0x3f, 0x6e, 0xec, 0x52, 0xdb, 0x55, 0xee, 0xaa, 0x72,
-	0x20, 0xff, 0x00, 0x79, 0xc5, 0x96, 0x85, 0x17, 0x88, 0x0d, 0x52, 0x2f, 0xd8, 0x20, 0xc1, 0x4f,
-	0xe8, 0x65, 0x2f, 0x11, 0x8b, 0x12, 0x84, 0x05, 0x52, 0xfd, 0x02, 0x96, 0xe8, 0x3e, 0xea, 0xe5,
-	0x26, 0xdd, 0x49, 0x0b, 0x16, 0x6c, 0xec, 0x7b, 0xcf, 0xfb, 0x9c, 0xfb, 0x9d, 0x73, 0xeb, 0x42,
-	0xbe, 0x3f, 0xd6, 0xb1, 0xe1, 0x3c, 0x99, 0x5a, 0xa6, 0x63, 0x22, 0x91, 0xfe, 0x95, 0xbf, 0x33,
-	0xd4, 0x9d, 0xd1, 0xac, 0xf7, 0xa4, 0x6f, 0x4e, 0xf6, 0x87, 0xe6, 0xd0, 0xdc, 0xa7, 0xe4, 0xde,
-	0xec, 0x92, 0xee, 0xe8, 0x86, 0xae, 0x98, 0x96, 0x72, 0x02, 0x62, 0xc3, 0xb2, 0x4c, 0x0b, 0x6d,
-	0x43, 0xaa, 0x6f, 0x0e, 0x70, 0x49, 0xa8, 0x0a, 0xbb, 0x85, 0x5a, 0xc6, 0x73, 0x65, 0xba, 0x57,
-	0xe9, 0x2f, 0xda, 0x81, 0xf4, 0x04, 0xdb, 0x76, 0x77, 0x88, 0x4b, 0x89, 0xaa, 0xb0, 0x9b, 0xad,
-	0xe5, 0x3c, 0x57, 0xf6, 0x49, 0xaa, 0xbf, 0x50, 0x5e, 0x09, 0x90, 0xae, 0x9b, 0x93, 0x49, 0xd7,
-	0x18, 0xa0, 0x8f, 0x21, 0xa1, 0x0f, 0xb8, 0xb9, 0x87, 0x37, 0xae, 0x9c, 0x68, 0x3e, 0xf7, 0x5c,
-	0x39, 0xaf, 0x0f, 0xbe, 0x6d, 0x4e, 0x74, 0x07, 0x4f, 0xa6, 0xce, 0xb5, 0x9a, 0xd0, 0x07, 0xe8,
-	0x0b, 0x58, 0x9d, 0x60, 0x67, 0x64, 0x0e, 0xa8, 0xe5, 0xe2, 0xc1, 0x3a, 0x8b, 0xec, 0xc9, 0x29,
-	0x25, 0x76, 0xae, 0xa7, 0xb8, 0xf6, 0xc0, 0x73, 0x65, 0x89, 0x09, 0x45, 0x94, 0xb9, 0x1a, 0x3a,
-	0x84, 0xd5, 0x69, 0xd7, 0xea, 0x4e, 0xec, 0x52, 0xb2, 0x2a, 0xec, 0xe6, 0x6b, 0xf2, 0x6b, 0x57,
-	0x5e, 0xf9, 0xab, 0x2b, 0x27, 0xd5, 0xee, 0x2f, 0x88, 0x22, 0x63, 0x46, 0x15, 0x19, 0x45, 0xf9,
-	0xad, 0x00, 0xa2, 0x8a, 0xa7, 0xe3, 0xeb, 0x3b, 0xc7, 0x7a, 0x08, 0x22, 0x26, 0xd5, 0xa2, 0xa1,
-	0xe6, 0x0e, 0xf2, 0x3c, 0x54, 0x5a, 0xc1, 0xda, 0x86, 0xe7, 0xca, 0x6b, 0x94, 0x1d, 0xd1, 0x62,
-	0xf2, 0x24, 0x46, 0x0b, 0xdb, 0xb3, 0xb1, 0x73, 0x4b, 0x8c, 0x8c, 0x19, 0x8d, 0x91, 0x51, 0x94,
-	0xdf, 0x08, 0x90, 0x6a, 0xcf, 0xec, 0x11, 0x3a, 0x84, 0x94, 0x73, 0x3d, 0x65, 0xe7, 0x53, 0x3c,
-	0x58, 0xe3, 0x9e, 0x09, 0x8b, 0x96, 0x08, 0x79, 0xae, 0x5c, 0x24, 0x02, 0x11, 0x1b, 0x54, 0x01,
-	0xed, 0x43, 0xba, 0x3f, 0xea, 0x1a, 0x06, 0x1e, 0xf3, 0xa3, 0xdb, 0xf4, 0x5c, 0x79, 0x9d, 0x93,
-	0x22, 0xd2, 0xbe, 0x14, 0x7a, 0x0c, 0xa9, 0x41, 0xd7, 0xe9, 0xf2, 0x48, 0x37, 0xe2, 0x91, 0x52,
-	0x96, 0x4a, 0x7f, 0x95, 0x37, 0x02, 0x40, 0x9d, 0x42, 0xb0, 0x69, 0x5c, 0x9a, 0x04, 0x41, 0x33,
-	0x1b, 0x5b, 0x34, 0xc2, 0x2c, 0x43, 0x10, 0xd9, 0xab, 0xf4, 0x17, 0x29, 0xb0, 0xca, 0xe0, 0xca,
-	0xa3, 0x00, 0xcf, 0x95, 0x39, 0x45, 0xe5, 0xff, 0xe8, 0x0b, 0xc8, 0xf6, 0x4d, 0xc3, 0xb8, 0xd0,
-	0x8d, 0x4b, 0x93, 0xbb, 0x57, 0xe2, 0xee, 0x37, 0x02, 0x7e, 0x24, 0xf2, 0x0c, 0x21, 0xd2, 0x10,
-	0x88, 0x81, 0x51, 0x97, 0x1b, 0x48, 0xfd, 0x67, 0x03, 0x3e, 0x3f, 0x66, 0x60, 0xd4, 0xa5, 0x06,
-	0x94, 0x7f, 0x0a, 0x90, 0x6b, 0xcf, 0x7a, 0x63, 0xbd, 0xdf, 0x75, 0x74, 0xd3, 0x40, 0x1f, 0x41,
-	0xd2, 0xc6, 0x2f, 0x39, 0x32, 0xd6, 0x3d, 0x57, 0x2e, 0xd8, 0xf8, 0x65, 0x44, 0x93, 0x70, 0x89,
-	0xd0, 0x10, 0x1b, 0x34, 0x2f, 0x2e, 0x34, 0xc4, 0x46, 0x54, 0x68, 0x88, 0x0d, 0xb4, 0x07, 0xc9,
-	0x99, 0x3e, 0xa0, 0x59, 0x65, 0x6b, 0xa5, 0x1b, 0x57, 0x4e, 0x9e, 0x53, 0x90, 0x15, 0x66, 0x31,
-	0x94, 0x11, 0xa1, 0xe0, 0x04, 0x52, 0xef, 0x39, 0x01, 0xf4, 0x7d, 0x48, 0xd1, 0x54, 0x45, 0x0a,
-	0x47, 0xbf, 0x73, 0xc2, 0x33, 0x61, 0xb0, 0x58, 0xca, 0x96, 0xaa, 0x28, 0xcf, 0x20, 0xf5, 0x95,
-	0xa9, 0x1b, 0xe8, 0x53, 0x6e, 0x42, 0xb8, 0xcd, 0x44, 0x9e, 0xb8, 0x27, 0x7e, 0x89, 0x18, 0x57,
-	0xfe, 0x21, 0x88, 0x27, 0xb8, 0x7b, 0x85, 0x3f, 0x4c, 0xfb, 0x39, 0x88, 0xe7, 0x86, 0x3d, 0xeb,
-	0xa1, 0x67, 0x90, 0x23, 0x30, 0xef, 0xd9, 0x7d, 0x4b, 0xef, 0x31, 0x68, 0x67, 0x6a, 0x5b, 0x9e,
-	0x2b, 0x6f, 0x46, 0xc8, 0x91, 0xc8, 0xa3, 0xd2, 0xca, 0x01, 0xa4, 0x4f, 0xd9, 0xd8, 0x09, 0xea,
-	0x25, 0xbc, 0x0f, 0xb1, 0x03, 0x28, 0xd6, 0x4d, 0xc3, 0xc0, 0x7d, 0x47, 0xc5, 0x2f, 0x67, 0xd8,
-	0x76, 0x90, 0x0c, 0xa2, 0x63, 0xbe, 0xc0, 0x06, 0x47, 0x6d, 0xd6, 0x73, 0x65, 0x46, 0x50, 0xd9,
-	0x1f, 0x7a, 0xca, 0x6d, 0x27, 0xa8, 0xed, 0x6f, 0xc6, 0x6d, 0x17, 0x09, 0x2b, 0x5a, 0x5a, 0xea,
-	0xc5, 0x13, 0xa0, 0x10, 0xb8, 0x21, 0x5d, 0x1c, 0x01, 0xbf, 0x70, 0x2b, 0xf8, 0x77, 0x20, 0x7d,
-	0x85, 0x2d, 0x5b, 0x37, 0x8d, 0xe8, 0x88, 0xe5, 0x24, 0xd5, 0x5f, 0x90, 0x76, 0xc6, 0xbf, 0x9c,
-	0xea, 0x16, 0x66, 0xe3, 0x2e, 0xc3, 0xda, 0x99, 0x93, 0xa2, 0xed, 0xcc, 0x49, 0x04, 0x78, 0x8e,
-	0x33, 0xa6, 0x58, 0x2a, 0x30, 0xe0, 0x75, 0x3a, 0x27, 0x04, 0x78, 0x8e, 0x13, 0x6d, 0x7f, 0x22,
-	0x14, 0x24, 0x2b, 0xde, 0x3d, 0xd9, 0xa7, 0x50, 0x54, 0xf1, 0xa5, 0x85, 0xed, 0xd1, 0x5d, 0x4b,
-	0xaa, 0xfc, 0x49, 0x80, 0x42, 0xa0, 0xf3, 0xff, 0x54, 0x1f, 0xe5, 0x2f, 0x02, 0x48, 0x9a, 0x8f,
-	0x40, 0x3f, 0xdf, 0x9d, 0x70, 0xc0, 0x0a, 0x61, 0x60, 0x9c, 0x14, 0x8e, 0xd5, 0xa0, 0x2c, 0x89,
-	0x5b, 0x90, 0xb6, 0x03, 0x69, 0x0b, 0xf7, 0xcd, 0x2b, 0x6c, 0xf1, 0xc8, 0xa9, 0x1d, 0x4e, 0x52,
-	0xfd, 0x05, 0xda, 0x62, 0x23, 0x89, 0xc5, 0x9b, 0xf6, 0x5c, 0x99, 0x6c, 0xd9, 0x20, 0xda, 0x62,
-	0x83, 0x48, 0x0c, 0x59, 0x43, 0x6c, 0xb0, 0xf1, 0x23, 0x83, 0x88, 0xa7, 0x66, 0x7f, 0x54, 0x5a,
-	0x0d, 0xbd, 0x53, 0x82, 0xca, 0xfe, 0x94, 0x57, 0x49, 0x58, 0x8b, 0xa4, 0x46, 0x8f, 0x25, 0x52,
-	0x4b, 0xe1, 0x3e, 0xb5, 0x4c, 0xdc, 0x05, 0x6b, 0xb4, 0xf9, 0x69, 0x4a, 0xdd, 0xde, 0x18, 0xf3,
-	0x94, 0x79, 0xf3, 0x07, 0xe4, 0x78, 0xf3, 0x07, 0x64, 0x7f, 0x2e, 0xa7, 0xee, 0x32, 0x97, 0xc5,
-	0x77, 0xce, 0xe5, 0x4f, 0xe2, 0x85, 0x61, 0x97, 0x38, 0x21, 0xc4, 0x2e, 0x71, 0x42, 0x40, 0x2a,
-	0xe4, 0xa7, 0xe1, 0xdd, 0x60, 0x97, 0xd2, 0xd5, 0xe4, 0x6e, 0xee, 0x00, 0x05, 0x57, 0x71, 0xc0,
-	0xaa, 0x95, 0x3d, 0x57, 0x7e, 0x18, 0x95, 0x8d, 0x18, 0x8b, 0xd9, 0x40, 0x9f, 0x41, 0x96, 0xe7,
-	0x85, 0x07, 0xa5, 0x0c, 0xad, 0xc1, 0x23, 0x72, 0x4d, 0x05, 0xc4, 0x88, 0x66, 0x28, 0xa9, 0xfc,
-	0x0c, 0xd6, 0xb5, 0x59, 0x6f, 0xa9, 0xf1, 0xfe, 0x4b, 0x40, 0x54, 0x4c, 0x0a, 0xf2, 0x78, 0x87,
-	0xfe, 0x2f, 0xa1, 0xa0, 0x3c, 0x03, 0x44, 0x2f, 0x84, 0x0f, 0xe9, 0x2b, 0x65, 0x03, 0xd6, 0x63,
-	0xca, 0xf4, 0xb3, 0xe9, 0xe7, 0x50, 0xa4, 0xe7, 0x71, 0xef, 0xe2, 0x3c, 0x8e, 0x8d, 0xfb, 0x77,
-	0x5c, 0x25, 0x6b, 0x50, 0x08, 0x3c, 0x50, 0x97, 0x9f, 0xc3, 0x5a, 0xdb, 0xc2, 0x36, 0x36, 0xfa,
-	0xf7, 0xcd, 0xe0, 0x0f, 0x02, 0x14, 0x43, 0x55, 0x5a, 0xee, 0x53, 0xc8, 0x4c, 0x39, 0xa5, 0x24,
-	0x50, 0x98, 0x7d, 0xe4, 0xc3, 0x2c, 0x26, 0x18, 0x6c, 0x1b, 0x86, 0x63, 0x5d, 0xd7, 0xf2, 0x9e,
-	0x2b, 0x07, 0x8a, 0x6a, 0xb0, 0x2a, 0xb7, 0xa0, 0x10, 0x13, 0x44, 0x12, 0x24, 0x5f, 0xe0, 0x6b,
-	0x16, 0x95, 0x4a, 0x96, 0xe8, 0x31, 0x88, 0x57, 0xdd, 0xf1, 0x0c, 0xf3, 0x4f, 0xdb, 0xb7, 0xaf,
-	0x72, 0x95, 0xf1, 0x7f, 0x90, 0xf8, 0x5c, 0x50, 0x7e, 0x04, 0x0f, 0x7c, 0x7b, 0x9a, 0xd3, 0x75,
-	0xec, 0x7b, 0x26, 0x6c, 0xc3, 0xc6, 0x92, 0x3a, 0x4d, 0xfa, 0xbb, 0x90, 0x33, 0x66, 0x93, 0x0b,
-	0x36, 0xef, 0x6d, 0xfe, 0xd1, 0xb5, 0xe6, 0xb9, 0x72, 0x94, 0xac, 0x82, 0x31, 0x9b, 0xb0, 0xa8,
-	0x08, 0xc8, 0xb2, 0x84, 0x45, 0x3e, 0x30, 0x6d, 0x0e, 0xb5, 0x82, 0xe7, 0xca, 0x21, 0x51, 0xcd,
-	0x18, 0xb3, 0xc9, 0x39, 0x59, 0x29, 0x87, 0x50, 0x3c, 0xd6, 0x6d, 0xc7, 0xb4, 0xae, 0xef, 0x19,
-	0xed, 0xd7, 0x50, 0x08, 0x14, 0x69, 0x9c, 0xc7, 0x4b, 0x73, 0x40, 0xb8, 0x75, 0x0e, 0x48, 0xe4,
-	0x15, 0x11, 0x95, 0x8d, 0x77, 0xbf, 0x52, 0x80, 0x5c, 0x5b, 0x37, 0x86, 0x3c, 0x20, 0x25, 0x0f,
-	0xc0, 0xb6, 0x14, 0x50, 0x9f, 0x01, 0xa8, 0xed, 0xba, 0x1f, 0xec, 0x9d, 0xbf, 0x71, 0x7e, 0x0c,
-	0x59, 0xaa, 0x46, 0x43, 0x7d, 0x1a, 0xd3, 0xba, 0xd3, 0x85, 0xfe, 0x3d, 0xc8, 0x69, 0xd8, 0x18,
-	0xdc, 0xd7, 0xef, 0xde, 0x9b, 0x24, 0x40, 0xf8, 0x66, 0x43, 0x0a, 0xa4, 0xeb, 0x67, 0xad, 0x56,
-	0xa3, 0xde, 0x91, 0x56, 0xca, 0x9b, 0xf3, 0x45, 0x75, 0x3d, 0x64, 0xf2, 0x8f, 0x23, 0xf4, 0x31,
-	0x64, 0xb5, 0xf3, 0x9a, 0x56, 0x57, 0x9b, 0xb5, 0x86, 0x24, 0x94, 0x1f, 0xcd, 0x17, 0xd5, 0x8d,
-	0x50, 0x2a, 0xb8, 0x8d, 0xd0, 0x1e, 0xe4, 0xce, 0x5b, 0xa1, 0x64, 0xa2, 0xbc, 0x35, 0x5f, 0x54,
-	0x37, 0x43, 0xc9, 0x48, 0xff, 0x13, 0xbf, 0xed, 0xf3, 0xda, 0x49, 0x53, 0x3b, 0x96, 0x92, 0xcb,
-	0x7e, 0x79, 0xc3, 0xa2, 0x6f, 0x41, 0xa6, 0xad, 0x36, 0xb4, 0x46, 0xab, 0xde, 0x90, 0x52, 0xe5,
-	0x87, 0xf3, 0x45, 0x15, 0x45, 0x84, 0x38, 0x32, 0xd1, 0x3e, 0x14, 0x7d, 0xa9, 0x0b, 0xad, 0x73,
-	0xd4, 0xd1, 0x24, 0xb1, 0xfc, 0x8d, 0xf9, 0xa2, 0xfa, 0xe8, 0x6d, 0x59, 0x8a, 0x62, 0xe2, 0xfa,
-	0xb8, 0xa9, 0x75, 0xce, 0xd4, 0xaf, 0xa5, 0xd5, 0x65, 0xd7, 0x1c, 0x41, 0xe4, 0x91, 0xd4, 0x6e,
-	0xb6, 0xbe, 0x94, 0xd2, 0x65, 0x34, 0x5f, 0x54, 0x8b, 0x11, 0x53, 0xba, 0x31, 0x24, 0x5c, 0xad,
-	0xd1, 0x7a, 0x2e, 0x65, 0x96, 0xb9, 0xe4, 0x44, 0x50, 0x19, 0x92, 0x6a, 0xbb, 0x2e, 0x65, 0xcb,
-	0xeb, 0xf3, 0x45, 0xb5, 0x10, 0x32, 0xd5, 0x76, 0x9d, 0xf8, 0x56, 0x1b, 0x3f, 0x51, 0x1b, 0xda,
-	0xb1, 0x04, 0xcb, 0xbe, 0xf9, 0x24, 0x47, 0x9f, 0x40, 0x4e, 0x3b, 0xaf, 0x5d, 0xf8, 0x72, 0xb9,
-	0x72, 0x69, 0xbe, 0xa8, 0x3e, 0x88, 0x15, 0x9c, 0x8b, 0x96, 0x53, 0xbf, 0xfa, 0x5d, 0x65, 0x65,
-	0xef, 0x8f, 0x02, 0x64, 0xfc, 0x17, 0x26, 0xda, 0x85, 0x1c, 0x2d, 0x6c, 0xfd, 0xa8, 0xd3, 0x3c,
-	0x6b, 0x49, 0x2b, 0xec, 0xb8, 0x7c, 0x76, 0xf4, 0xd1, 0x54, 0x86, 0xd4, 0x57, 0x67, 0xcd, 0x96,
-	0x24, 0x94, 0xa5, 0xf9, 0xa2, 0x9a, 0xf7, 0x45, 0xe8, 0x73, 0x63, 0x1b, 0xc4, 0x93, 0xc6, 0xd1,
-	0x4f, 0xc9, 0x21, 0xd2, 0x2c, 0x7c, 0x26, 0x7b, 0x4e, 0x6c, 0x83, 0x48, 0x0f, 0x5a, 0x4a, 0xc6,
-	0xb9, 0xec, 0xb9, 0x50, 0x85, 0xf4, 0x69, 0x43, 0xd3, 0x8e, 0xbe, 0x24, 0xa7, 0xb6, 0x31, 0x5f,
-	0x54, 0xd7, 0x7c, 0x3e, 0x7f, 0x08, 0xb0, 0xb0, 0x6b, 0xdb, 0xff, 0xfa, 0x7b, 0x45, 0xf8, 0xfd,
-	0x4d, 0x45, 0xf8, 0xf3, 0x4d, 0x45, 0x78, 0x7d, 0x53, 0x11, 0xde, 0xdc, 0x54, 0x84, 0xbf, 0xdd,
-	0x54, 0x84, 0x5f, 0xff, 0xa3, 0xb2, 0xd2, 0x5b, 0xa5, 0x6d, 0xfa, 0xe9, 0xbf, 0x03, 0x00, 0x00,
-	0xff, 0xff, 0x72, 0x81, 0x68, 0xfe, 0x3e, 0x11, 0x00, 0x00,
-}
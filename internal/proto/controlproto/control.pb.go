@@ -1,31 +1,17 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
 // source: control.proto
 
-/*
-	Package controlproto is a generated protocol buffer package.
-
-	It is generated from these files:
-		control.proto
-
-	It has these top-level messages:
-		Command
-		Node
-		Metrics
-		Unsubscribe
-		Disconnect
-*/
 package controlproto
 
-import proto "github.com/gogo/protobuf/proto"
-import fmt "fmt"
-import math "math"
-import _ "github.com/gogo/protobuf/gogoproto"
-
-import github_com_centrifugal_centrifuge_internal_proto "github.com/centrifugal/centrifuge/internal/proto"
-
-import binary "encoding/binary"
-
-import io "io"
+import (
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	github_com_centrifugal_centrifuge_internal_proto "github.com/centrifugal/centrifuge/internal/proto"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+)
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -41,26 +27,48 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type MethodType int32
 
 const (
-	MethodTypeNode        MethodType = 0
-	MethodTypeUnsubscribe MethodType = 1
-	MethodTypeDisconnect  MethodType = 2
+	MethodTypeNode           MethodType = 0
+	MethodTypeUnsubscribe    MethodType = 1
+	MethodTypeDisconnect     MethodType = 2
+	MethodTypeSend           MethodType = 3
+	MethodTypeMute           MethodType = 4
+	MethodTypeRefresh        MethodType = 5
+	MethodTypeSubscribe      MethodType = 6
+	MethodTypeSurveyRequest  MethodType = 7
+	MethodTypeSurveyResponse MethodType = 8
 )
 
 var MethodType_name = map[int32]string{
 	0: "NODE",
 	1: "UNSUBSCRIBE",
 	2: "DISCONNECT",
+	3: "SEND",
+	4: "MUTE",
+	5: "REFRESH",
+	6: "SUBSCRIBE",
+	7: "SURVEY_REQUEST",
+	8: "SURVEY_RESPONSE",
 }
+
 var MethodType_value = map[string]int32{
-	"NODE":        0,
-	"UNSUBSCRIBE": 1,
-	"DISCONNECT":  2,
+	"NODE":            0,
+	"UNSUBSCRIBE":     1,
+	"DISCONNECT":      2,
+	"SEND":            3,
+	"MUTE":            4,
+	"REFRESH":         5,
+	"SUBSCRIBE":       6,
+	"SURVEY_REQUEST":  7,
+	"SURVEY_RESPONSE": 8,
 }
 
 func (x MethodType) String() string {
 	return proto.EnumName(MethodType_name, int32(x))
 }
-func (MethodType) EnumDescriptor() ([]byte, []int) { return fileDescriptorControl, []int{0} }
+
+func (MethodType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0}
+}
 
 type Command struct {
 	UID    string                                               `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid"`
@@ -68,10 +76,38 @@ type Command struct {
 	Params github_com_centrifugal_centrifuge_internal_proto.Raw `protobuf:"bytes,3,opt,name=params,proto3,customtype=github.com/centrifugal/centrifuge/internal/proto.Raw" json:"params"`
 }
 
-func (m *Command) Reset()                    { *m = Command{} }
-func (m *Command) String() string            { return proto.CompactTextString(m) }
-func (*Command) ProtoMessage()               {}
-func (*Command) Descriptor() ([]byte, []int) { return fileDescriptorControl, []int{0} }
+func (m *Command) Reset()         { *m = Command{} }
+func (m *Command) String() string { return proto.CompactTextString(m) }
+func (*Command) ProtoMessage()    {}
+func (*Command) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{0}
+}
+func (m *Command) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Command) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Command.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Command) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Command.Merge(m, src)
+}
+func (m *Command) XXX_Size() int {
+	return m.Size()
+}
+func (m *Command) XXX_DiscardUnknown() {
+	xxx_messageInfo_Command.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Command proto.InternalMessageInfo
 
 func (m *Command) GetUID() string {
 	if m != nil {
@@ -95,13 +131,41 @@ type Node struct {
 	NumUsers    uint32   `protobuf:"varint,5,opt,name=num_users,json=numUsers,proto3" json:"num_users"`
 	NumChannels uint32   `protobuf:"varint,6,opt,name=num_channels,json=numChannels,proto3" json:"num_channels"`
 	Uptime      uint32   `protobuf:"varint,7,opt,name=uptime,proto3" json:"uptime"`
-	Metrics     *Metrics `protobuf:"bytes,8,opt,name=metrics" json:"metrics"`
+	Metrics     *Metrics `protobuf:"bytes,8,opt,name=metrics,proto3" json:"metrics"`
+}
+
+func (m *Node) Reset()         { *m = Node{} }
+func (m *Node) String() string { return proto.CompactTextString(m) }
+func (*Node) ProtoMessage()    {}
+func (*Node) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{1}
+}
+func (m *Node) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Node.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Node) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node.Merge(m, src)
+}
+func (m *Node) XXX_Size() int {
+	return m.Size()
+}
+func (m *Node) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node.DiscardUnknown(m)
 }
 
-func (m *Node) Reset()                    { *m = Node{} }
-func (m *Node) String() string            { return proto.CompactTextString(m) }
-func (*Node) ProtoMessage()               {}
-func (*Node) Descriptor() ([]byte, []int) { return fileDescriptorControl, []int{1} }
+var xxx_messageInfo_Node proto.InternalMessageInfo
 
 func (m *Node) GetUID() string {
 	if m != nil {
@@ -161,13 +225,41 @@ func (m *Node) GetMetrics() *Metrics {
 
 type Metrics struct {
 	Interval float64            `protobuf:"fixed64,1,opt,name=interval,proto3" json:"interval"`
-	Items    map[string]float64 `protobuf:"bytes,2,rep,name=items" json:"items" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	Items    map[string]float64 `protobuf:"bytes,2,rep,name=items,proto3" json:"items" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+}
+
+func (m *Metrics) Reset()         { *m = Metrics{} }
+func (m *Metrics) String() string { return proto.CompactTextString(m) }
+func (*Metrics) ProtoMessage()    {}
+func (*Metrics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{2}
+}
+func (m *Metrics) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Metrics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Metrics.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Metrics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Metrics.Merge(m, src)
+}
+func (m *Metrics) XXX_Size() int {
+	return m.Size()
+}
+func (m *Metrics) XXX_DiscardUnknown() {
+	xxx_messageInfo_Metrics.DiscardUnknown(m)
 }
 
-func (m *Metrics) Reset()                    { *m = Metrics{} }
-func (m *Metrics) String() string            { return proto.CompactTextString(m) }
-func (*Metrics) ProtoMessage()               {}
-func (*Metrics) Descriptor() ([]byte, []int) { return fileDescriptorControl, []int{2} }
+var xxx_messageInfo_Metrics proto.InternalMessageInfo
 
 func (m *Metrics) GetInterval() float64 {
 	if m != nil {
@@ -188,10 +280,38 @@ type Unsubscribe struct {
 	User    string `protobuf:"bytes,2,opt,name=user,proto3" json:"user"`
 }
 
-func (m *Unsubscribe) Reset()                    { *m = Unsubscribe{} }
-func (m *Unsubscribe) String() string            { return proto.CompactTextString(m) }
-func (*Unsubscribe) ProtoMessage()               {}
-func (*Unsubscribe) Descriptor() ([]byte, []int) { return fileDescriptorControl, []int{3} }
+func (m *Unsubscribe) Reset()         { *m = Unsubscribe{} }
+func (m *Unsubscribe) String() string { return proto.CompactTextString(m) }
+func (*Unsubscribe) ProtoMessage()    {}
+func (*Unsubscribe) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{3}
+}
+func (m *Unsubscribe) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Unsubscribe) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Unsubscribe.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Unsubscribe) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Unsubscribe.Merge(m, src)
+}
+func (m *Unsubscribe) XXX_Size() int {
+	return m.Size()
+}
+func (m *Unsubscribe) XXX_DiscardUnknown() {
+	xxx_messageInfo_Unsubscribe.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Unsubscribe proto.InternalMessageInfo
 
 func (m *Unsubscribe) GetChannel() string {
 	if m != nil {
@@ -207,14 +327,98 @@ func (m *Unsubscribe) GetUser() string {
 	return ""
 }
 
+type Subscribe struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
+	User    string `protobuf:"bytes,2,opt,name=user,proto3" json:"user"`
+}
+
+func (m *Subscribe) Reset()         { *m = Subscribe{} }
+func (m *Subscribe) String() string { return proto.CompactTextString(m) }
+func (*Subscribe) ProtoMessage()    {}
+func (*Subscribe) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{4}
+}
+func (m *Subscribe) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Subscribe) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Subscribe.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Subscribe) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Subscribe.Merge(m, src)
+}
+func (m *Subscribe) XXX_Size() int {
+	return m.Size()
+}
+func (m *Subscribe) XXX_DiscardUnknown() {
+	xxx_messageInfo_Subscribe.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Subscribe proto.InternalMessageInfo
+
+func (m *Subscribe) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *Subscribe) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
 type Disconnect struct {
-	User string `protobuf:"bytes,1,opt,name=user,proto3" json:"user"`
+	User      string   `protobuf:"bytes,1,opt,name=user,proto3" json:"user"`
+	Reconnect bool     `protobuf:"varint,2,opt,name=reconnect,proto3" json:"reconnect"`
+	Code      uint32   `protobuf:"varint,3,opt,name=code,proto3" json:"code"`
+	Reason    string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason"`
+	Whitelist []string `protobuf:"bytes,5,rep,name=whitelist,proto3" json:"whitelist"`
+}
+
+func (m *Disconnect) Reset()         { *m = Disconnect{} }
+func (m *Disconnect) String() string { return proto.CompactTextString(m) }
+func (*Disconnect) ProtoMessage()    {}
+func (*Disconnect) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{5}
+}
+func (m *Disconnect) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Disconnect) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Disconnect.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Disconnect) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Disconnect.Merge(m, src)
+}
+func (m *Disconnect) XXX_Size() int {
+	return m.Size()
+}
+func (m *Disconnect) XXX_DiscardUnknown() {
+	xxx_messageInfo_Disconnect.DiscardUnknown(m)
 }
 
-func (m *Disconnect) Reset()                    { *m = Disconnect{} }
-func (m *Disconnect) String() string            { return proto.CompactTextString(m) }
-func (*Disconnect) ProtoMessage()               {}
-func (*Disconnect) Descriptor() ([]byte, []int) { return fileDescriptorControl, []int{4} }
+var xxx_messageInfo_Disconnect proto.InternalMessageInfo
 
 func (m *Disconnect) GetUser() string {
 	if m != nil {
@@ -223,14 +427,374 @@ func (m *Disconnect) GetUser() string {
 	return ""
 }
 
+func (m *Disconnect) GetReconnect() bool {
+	if m != nil {
+		return m.Reconnect
+	}
+	return false
+}
+
+func (m *Disconnect) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *Disconnect) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Disconnect) GetWhitelist() []string {
+	if m != nil {
+		return m.Whitelist
+	}
+	return nil
+}
+
+type Send struct {
+	Client string                                               `protobuf:"bytes,1,opt,name=client,proto3" json:"client"`
+	Data   github_com_centrifugal_centrifuge_internal_proto.Raw `protobuf:"bytes,2,opt,name=data,proto3,customtype=github.com/centrifugal/centrifuge/internal/proto.Raw" json:"data"`
+}
+
+func (m *Send) Reset()         { *m = Send{} }
+func (m *Send) String() string { return proto.CompactTextString(m) }
+func (*Send) ProtoMessage()    {}
+func (*Send) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{6}
+}
+func (m *Send) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Send) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Send.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Send) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Send.Merge(m, src)
+}
+func (m *Send) XXX_Size() int {
+	return m.Size()
+}
+func (m *Send) XXX_DiscardUnknown() {
+	xxx_messageInfo_Send.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Send proto.InternalMessageInfo
+
+func (m *Send) GetClient() string {
+	if m != nil {
+		return m.Client
+	}
+	return ""
+}
+
+type Mute struct {
+	Channel  string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel"`
+	User     string `protobuf:"bytes,2,opt,name=user,proto3" json:"user"`
+	ExpireAt int64  `protobuf:"varint,3,opt,name=expire_at,json=expireAt,proto3" json:"expire_at"`
+}
+
+func (m *Mute) Reset()         { *m = Mute{} }
+func (m *Mute) String() string { return proto.CompactTextString(m) }
+func (*Mute) ProtoMessage()    {}
+func (*Mute) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{7}
+}
+func (m *Mute) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Mute) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Mute.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Mute) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Mute.Merge(m, src)
+}
+func (m *Mute) XXX_Size() int {
+	return m.Size()
+}
+func (m *Mute) XXX_DiscardUnknown() {
+	xxx_messageInfo_Mute.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Mute proto.InternalMessageInfo
+
+func (m *Mute) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *Mute) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *Mute) GetExpireAt() int64 {
+	if m != nil {
+		return m.ExpireAt
+	}
+	return 0
+}
+
+type Refresh struct {
+	User string `protobuf:"bytes,1,opt,name=user,proto3" json:"user"`
+}
+
+func (m *Refresh) Reset()         { *m = Refresh{} }
+func (m *Refresh) String() string { return proto.CompactTextString(m) }
+func (*Refresh) ProtoMessage()    {}
+func (*Refresh) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{8}
+}
+func (m *Refresh) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Refresh) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Refresh.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Refresh) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Refresh.Merge(m, src)
+}
+func (m *Refresh) XXX_Size() int {
+	return m.Size()
+}
+func (m *Refresh) XXX_DiscardUnknown() {
+	xxx_messageInfo_Refresh.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Refresh proto.InternalMessageInfo
+
+func (m *Refresh) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+type SurveyRequest struct {
+	ID   string                                               `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Op   string                                               `protobuf:"bytes,2,opt,name=op,proto3" json:"op"`
+	Data github_com_centrifugal_centrifuge_internal_proto.Raw `protobuf:"bytes,3,opt,name=data,proto3,customtype=github.com/centrifugal/centrifuge/internal/proto.Raw" json:"data"`
+}
+
+func (m *SurveyRequest) Reset()         { *m = SurveyRequest{} }
+func (m *SurveyRequest) String() string { return proto.CompactTextString(m) }
+func (*SurveyRequest) ProtoMessage()    {}
+func (*SurveyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{9}
+}
+func (m *SurveyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SurveyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SurveyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SurveyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SurveyRequest.Merge(m, src)
+}
+func (m *SurveyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SurveyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SurveyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SurveyRequest proto.InternalMessageInfo
+
+func (m *SurveyRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *SurveyRequest) GetOp() string {
+	if m != nil {
+		return m.Op
+	}
+	return ""
+}
+
+type SurveyResponse struct {
+	ID   string                                               `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Code uint32                                               `protobuf:"varint,2,opt,name=code,proto3" json:"code"`
+	Data github_com_centrifugal_centrifuge_internal_proto.Raw `protobuf:"bytes,3,opt,name=data,proto3,customtype=github.com/centrifugal/centrifuge/internal/proto.Raw" json:"data"`
+}
+
+func (m *SurveyResponse) Reset()         { *m = SurveyResponse{} }
+func (m *SurveyResponse) String() string { return proto.CompactTextString(m) }
+func (*SurveyResponse) ProtoMessage()    {}
+func (*SurveyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{10}
+}
+func (m *SurveyResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SurveyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SurveyResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SurveyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SurveyResponse.Merge(m, src)
+}
+func (m *SurveyResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SurveyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SurveyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SurveyResponse proto.InternalMessageInfo
+
+func (m *SurveyResponse) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *SurveyResponse) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
 func init() {
+	proto.RegisterEnum("controlproto.MethodType", MethodType_name, MethodType_value)
 	proto.RegisterType((*Command)(nil), "controlproto.Command")
 	proto.RegisterType((*Node)(nil), "controlproto.Node")
 	proto.RegisterType((*Metrics)(nil), "controlproto.Metrics")
+	proto.RegisterMapType((map[string]float64)(nil), "controlproto.Metrics.ItemsEntry")
 	proto.RegisterType((*Unsubscribe)(nil), "controlproto.Unsubscribe")
+	proto.RegisterType((*Subscribe)(nil), "controlproto.Subscribe")
 	proto.RegisterType((*Disconnect)(nil), "controlproto.Disconnect")
-	proto.RegisterEnum("controlproto.MethodType", MethodType_name, MethodType_value)
+	proto.RegisterType((*Send)(nil), "controlproto.Send")
+	proto.RegisterType((*Mute)(nil), "controlproto.Mute")
+	proto.RegisterType((*Refresh)(nil), "controlproto.Refresh")
+	proto.RegisterType((*SurveyRequest)(nil), "controlproto.SurveyRequest")
+	proto.RegisterType((*SurveyResponse)(nil), "controlproto.SurveyResponse")
+}
+
+func init() { proto.RegisterFile("control.proto", fileDescriptor_0c5120591600887d) }
+
+var fileDescriptor_0c5120591600887d = []byte{
+	// 1003 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xcd, 0x6f, 0xe3, 0x44,
+	0x14, 0xcf, 0x38, 0x69, 0x3e, 0x5e, 0xd2, 0x6e, 0x30, 0x5b, 0xd6, 0x84, 0xca, 0xb6, 0x2c, 0x3e,
+	0xa2, 0x22, 0x52, 0xe8, 0x72, 0x58, 0xa1, 0xbd, 0xac, 0x13, 0xaf, 0xe8, 0xa1, 0xe9, 0x32, 0x6e,
+	0x10, 0x5c, 0xa8, 0xdc, 0x64, 0xda, 0x5a, 0xc4, 0xe3, 0xe0, 0x8f, 0x96, 0xde, 0x39, 0xa0, 0x9c,
+	0xf8, 0x07, 0x22, 0x0e, 0x48, 0x88, 0x23, 0x47, 0xfe, 0x02, 0xb4, 0xdc, 0xf6, 0x88, 0x38, 0x58,
+	0x90, 0xde, 0x72, 0xe4, 0xc4, 0x11, 0xcd, 0x8c, 0x63, 0x67, 0x77, 0x2b, 0x40, 0xa2, 0x7b, 0x99,
+	0x79, 0xef, 0x37, 0x3f, 0xbf, 0xf7, 0x66, 0xde, 0x6f, 0xc6, 0xb0, 0x3e, 0xf4, 0x69, 0x14, 0xf8,
+	0xe3, 0xce, 0x24, 0xf0, 0x23, 0x5f, 0x6e, 0xa4, 0x2e, 0xf7, 0x5a, 0xef, 0x9c, 0xba, 0xd1, 0x59,
+	0x7c, 0xdc, 0x19, 0xfa, 0xde, 0xce, 0xa9, 0x7f, 0xea, 0xef, 0x70, 0xf8, 0x38, 0x3e, 0xe1, 0x1e,
+	0x77, 0xb8, 0x25, 0x3e, 0x36, 0x7e, 0x41, 0x50, 0xe9, 0xfa, 0x9e, 0xe7, 0xd0, 0x91, 0xac, 0x43,
+	0x31, 0x76, 0x47, 0x0a, 0xd2, 0x51, 0xbb, 0x66, 0x6e, 0xcc, 0x13, 0xad, 0x38, 0xd8, 0xeb, 0x2d,
+	0x12, 0x8d, 0xa1, 0x98, 0x0d, 0xf2, 0x7d, 0x28, 0x7b, 0x24, 0x3a, 0xf3, 0x47, 0x8a, 0xa4, 0xa3,
+	0xf6, 0xc6, 0xae, 0xd2, 0x59, 0xcd, 0xdd, 0xd9, 0xe7, 0x6b, 0x87, 0x97, 0x13, 0x62, 0xc2, 0x22,
+	0xd1, 0x52, 0x2e, 0x4e, 0x67, 0xf9, 0x33, 0x28, 0x4f, 0x9c, 0xc0, 0xf1, 0x42, 0xa5, 0xa8, 0xa3,
+	0x76, 0xc3, 0x7c, 0xf8, 0x38, 0xd1, 0x0a, 0xbf, 0x25, 0xda, 0xfb, 0x2b, 0x25, 0x0f, 0x09, 0x8d,
+	0x02, 0xf7, 0x24, 0x3e, 0x75, 0xc6, 0xb9, 0x4d, 0x76, 0x5c, 0x1a, 0x91, 0x80, 0x3a, 0x63, 0xb1,
+	0x9b, 0x0e, 0x76, 0x2e, 0x58, 0x7c, 0x11, 0x0d, 0xa7, 0xb3, 0x31, 0x97, 0xa0, 0xd4, 0xf7, 0x47,
+	0xe4, 0x3f, 0x6c, 0x64, 0x0b, 0x4a, 0xd4, 0xf1, 0x08, 0xdf, 0x46, 0xcd, 0xac, 0x2e, 0x12, 0x8d,
+	0xfb, 0x98, 0x8f, 0xf2, 0x1b, 0x50, 0x39, 0x27, 0x41, 0xe8, 0xfa, 0x94, 0x57, 0x5a, 0x33, 0xeb,
+	0x8b, 0x44, 0x5b, 0x42, 0x78, 0x69, 0xc8, 0xef, 0x42, 0x9d, 0xc6, 0xde, 0xd1, 0x70, 0xec, 0x12,
+	0x1a, 0x85, 0x4a, 0x49, 0x47, 0xed, 0x75, 0xf3, 0xd6, 0x22, 0xd1, 0x56, 0x61, 0x0c, 0x34, 0xf6,
+	0xba, 0xc2, 0x96, 0xb7, 0xa1, 0xc6, 0x96, 0xe2, 0x90, 0x04, 0xa1, 0xb2, 0xc6, 0xf9, 0xeb, 0x8b,
+	0x44, 0xcb, 0x41, 0x5c, 0xa5, 0xb1, 0x37, 0x60, 0x96, 0x7c, 0x17, 0x1a, 0x3c, 0xcc, 0x99, 0x43,
+	0x29, 0x19, 0x87, 0x4a, 0x99, 0xd3, 0x9b, 0x8b, 0x44, 0x7b, 0x0a, 0xc7, 0x2c, 0x59, 0x37, 0x75,
+	0x64, 0x03, 0xca, 0xf1, 0x24, 0x72, 0x3d, 0xa2, 0x54, 0x38, 0x9d, 0xb7, 0x41, 0x20, 0x38, 0x9d,
+	0xe5, 0xfb, 0x50, 0xf1, 0x48, 0x14, 0xb8, 0xc3, 0x50, 0xa9, 0xea, 0xa8, 0x5d, 0xdf, 0xdd, 0x7c,
+	0xae, 0x8b, 0x6c, 0x51, 0x6c, 0x3a, 0x65, 0xe2, 0xa5, 0x61, 0xfc, 0x88, 0xa0, 0x92, 0x32, 0xe4,
+	0x36, 0x54, 0x79, 0x63, 0xce, 0x9d, 0x31, 0x3f, 0x6c, 0x64, 0x36, 0x16, 0x89, 0x96, 0x61, 0x38,
+	0xb3, 0xe4, 0x07, 0xb0, 0xe6, 0x46, 0xc4, 0x0b, 0x15, 0x49, 0x2f, 0xb6, 0xeb, 0xbb, 0xfa, 0xb5,
+	0x19, 0x3b, 0x7b, 0x8c, 0x62, 0xd1, 0x28, 0xb8, 0x34, 0x6b, 0x8b, 0x44, 0x13, 0x9f, 0x60, 0x31,
+	0xb5, 0xee, 0x01, 0xe4, 0xeb, 0x72, 0x13, 0x8a, 0x9f, 0x93, 0x4b, 0xd1, 0x62, 0xcc, 0x4c, 0xf9,
+	0x36, 0xac, 0x9d, 0x3b, 0xe3, 0x58, 0xf4, 0x14, 0x61, 0xe1, 0x7c, 0x20, 0xdd, 0x43, 0x06, 0x86,
+	0xfa, 0x80, 0x86, 0xf1, 0x71, 0x38, 0x0c, 0xdc, 0x63, 0xde, 0xdd, 0xf4, 0xf0, 0x52, 0x85, 0xf0,
+	0x8d, 0xa6, 0x10, 0x5e, 0x1a, 0x4c, 0x22, 0xac, 0x25, 0xab, 0x12, 0x61, 0x3e, 0xe6, 0xa3, 0xf1,
+	0x08, 0x6a, 0xf6, 0xcd, 0x46, 0xfc, 0x19, 0x01, 0xf4, 0xdc, 0x70, 0xe8, 0x53, 0x4a, 0x86, 0x51,
+	0x46, 0x46, 0xd7, 0x91, 0xe5, 0xb7, 0xa1, 0x16, 0x90, 0x94, 0xca, 0xe3, 0x55, 0x85, 0x90, 0x32,
+	0x10, 0xe7, 0x26, 0x0b, 0x35, 0xf4, 0x47, 0x84, 0x6b, 0x79, 0x5d, 0x84, 0x62, 0x3e, 0xe6, 0x23,
+	0x93, 0x4c, 0x40, 0x9c, 0xd0, 0xa7, 0x5c, 0xc0, 0x35, 0x21, 0x19, 0x81, 0xe0, 0x74, 0x66, 0xe9,
+	0x2e, 0xce, 0xdc, 0x88, 0x8c, 0xdd, 0x30, 0x52, 0xd6, 0xf4, 0x62, 0xbb, 0x26, 0xd2, 0x65, 0x20,
+	0xce, 0x4d, 0xe3, 0x2b, 0x04, 0x25, 0x9b, 0xd0, 0x11, 0x8b, 0x2c, 0x2e, 0x81, 0x82, 0xf2, 0xc8,
+	0x02, 0xc1, 0xe9, 0x2c, 0x7f, 0x02, 0xa5, 0x91, 0x13, 0x39, 0x7c, 0x0f, 0x0d, 0xb3, 0xf7, 0x3f,
+	0x5f, 0x04, 0x1e, 0x0b, 0xf3, 0xd1, 0xb8, 0x80, 0xd2, 0x7e, 0x1c, 0xdd, 0x4c, 0x73, 0xd8, 0xc5,
+	0x25, 0x5f, 0x4e, 0xdc, 0x80, 0x1c, 0x39, 0x11, 0x3f, 0xc7, 0xa2, 0x38, 0x80, 0x0c, 0xc4, 0x55,
+	0x61, 0x3e, 0x88, 0x8c, 0xb7, 0xa0, 0x82, 0xc9, 0x49, 0x40, 0xc2, 0xb3, 0x7f, 0x6e, 0xa2, 0xf1,
+	0x2d, 0x82, 0x75, 0x3b, 0x0e, 0xce, 0xc9, 0x25, 0x26, 0x5f, 0xc4, 0x24, 0x64, 0x9d, 0x92, 0xb2,
+	0x77, 0xab, 0x31, 0x4f, 0x34, 0x89, 0x3f, 0x5b, 0x92, 0x3b, 0xc2, 0x92, 0x3b, 0x92, 0x5f, 0x01,
+	0xc9, 0x9f, 0xa4, 0x05, 0x96, 0x19, 0xee, 0x4f, 0xb0, 0xe4, 0x4f, 0xb2, 0x33, 0x2c, 0xde, 0xf8,
+	0x19, 0x7e, 0x8f, 0x60, 0x63, 0x59, 0x61, 0x38, 0xf1, 0x69, 0x48, 0xfe, 0xa5, 0xc4, 0xa5, 0xd4,
+	0xa4, 0x6b, 0xa5, 0xf6, 0xc2, 0x0a, 0xdd, 0xfe, 0x53, 0x02, 0xc8, 0xff, 0x3e, 0xac, 0x8c, 0xfe,
+	0x41, 0xcf, 0x6a, 0x16, 0x5a, 0xf2, 0x74, 0xa6, 0x6f, 0xe4, 0x2b, 0xfc, 0xf7, 0xb0, 0x0d, 0xf5,
+	0x41, 0xdf, 0x1e, 0x98, 0x76, 0x17, 0xef, 0x99, 0x56, 0x13, 0xb5, 0x5e, 0x9d, 0xce, 0xf4, 0xcd,
+	0x9c, 0xb4, 0xfa, 0x58, 0xb4, 0x01, 0x7a, 0x7b, 0x76, 0xf7, 0xa0, 0xdf, 0xb7, 0xba, 0x87, 0x4d,
+	0xa9, 0xa5, 0x4c, 0x67, 0xfa, 0xed, 0x9c, 0xfa, 0xf4, 0x85, 0xb5, 0xad, 0x7e, 0xaf, 0x59, 0x7c,
+	0x36, 0x27, 0xbf, 0x0b, 0x5b, 0x50, 0xda, 0x1f, 0x1c, 0x5a, 0xcd, 0xd2, 0xb3, 0xab, 0x5c, 0xa3,
+	0x06, 0x54, 0xb0, 0xf5, 0x10, 0x5b, 0xf6, 0x87, 0xcd, 0xb5, 0xd6, 0xe6, 0x74, 0xa6, 0xbf, 0x94,
+	0x13, 0x96, 0x5a, 0x7a, 0x13, 0x6a, 0x79, 0xcd, 0xe5, 0xd6, 0x9d, 0xe9, 0x4c, 0x7f, 0x79, 0x25,
+	0x49, 0x56, 0xf1, 0x0e, 0x6c, 0xd8, 0x03, 0xfc, 0xb1, 0xf5, 0xe9, 0x11, 0xb6, 0x3e, 0x1a, 0x58,
+	0xf6, 0x61, 0xb3, 0xd2, 0x7a, 0x6d, 0x3a, 0xd3, 0xef, 0xac, 0x92, 0x57, 0x45, 0xf7, 0x1e, 0xdc,
+	0xca, 0x3e, 0xb0, 0x1f, 0x1d, 0xf4, 0x6d, 0xab, 0x59, 0x6d, 0x6d, 0x4d, 0x67, 0xba, 0xf2, 0xfc,
+	0x17, 0x42, 0x04, 0xad, 0xd2, 0xd7, 0xdf, 0xa9, 0x05, 0xf3, 0xf5, 0xbf, 0xfe, 0x50, 0xd1, 0x0f,
+	0x73, 0x15, 0xfd, 0x34, 0x57, 0xd1, 0xe3, 0xb9, 0x8a, 0x9e, 0xcc, 0x55, 0xf4, 0xfb, 0x5c, 0x45,
+	0xdf, 0x5c, 0xa9, 0x85, 0x27, 0x57, 0x6a, 0xe1, 0xd7, 0x2b, 0xb5, 0x70, 0x5c, 0xe6, 0x3d, 0xbb,
+	0xfb, 0xf7, 0x00, 0x3e, 0x30, 0x88, 0x96, 0xb6, 0x08, 0x00, 0x00,
 }
+
 func (this *Command) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -365,14 +929,14 @@ func (this *Unsubscribe) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *Disconnect) Equal(that interface{}) bool {
+func (this *Subscribe) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Disconnect)
+	that1, ok := that.(*Subscribe)
 	if !ok {
-		that2, ok := that.(Disconnect)
+		that2, ok := that.(Subscribe)
 		if ok {
 			that1 = &that2
 		} else {
@@ -384,36 +948,221 @@ func (this *Disconnect) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if this.Channel != that1.Channel {
+		return false
+	}
 	if this.User != that1.User {
 		return false
 	}
 	return true
 }
-func (m *Command) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *Disconnect) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *Command) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.UID) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintControl(dAtA, i, uint64(len(m.UID)))
-		i += copy(dAtA[i:], m.UID)
+	that1, ok := that.(*Disconnect)
+	if !ok {
+		that2, ok := that.(Disconnect)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.Method != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintControl(dAtA, i, uint64(m.Method))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.User != that1.User {
+		return false
+	}
+	if this.Reconnect != that1.Reconnect {
+		return false
+	}
+	if this.Code != that1.Code {
+		return false
+	}
+	if this.Reason != that1.Reason {
+		return false
+	}
+	if len(this.Whitelist) != len(that1.Whitelist) {
+		return false
+	}
+	for i := range this.Whitelist {
+		if this.Whitelist[i] != that1.Whitelist[i] {
+			return false
+		}
+	}
+	return true
+}
+func (this *Send) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Send)
+	if !ok {
+		that2, ok := that.(Send)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Client != that1.Client {
+		return false
+	}
+	if !this.Data.Equal(that1.Data) {
+		return false
+	}
+	return true
+}
+func (this *Mute) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Mute)
+	if !ok {
+		that2, ok := that.(Mute)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Channel != that1.Channel {
+		return false
+	}
+	if this.User != that1.User {
+		return false
+	}
+	if this.ExpireAt != that1.ExpireAt {
+		return false
+	}
+	return true
+}
+func (this *Refresh) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Refresh)
+	if !ok {
+		that2, ok := that.(Refresh)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.User != that1.User {
+		return false
+	}
+	return true
+}
+func (this *SurveyRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SurveyRequest)
+	if !ok {
+		that2, ok := that.(SurveyRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID != that1.ID {
+		return false
+	}
+	if this.Op != that1.Op {
+		return false
+	}
+	if !this.Data.Equal(that1.Data) {
+		return false
+	}
+	return true
+}
+func (this *SurveyResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SurveyResponse)
+	if !ok {
+		that2, ok := that.(SurveyResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID != that1.ID {
+		return false
+	}
+	if this.Code != that1.Code {
+		return false
+	}
+	if !this.Data.Equal(that1.Data) {
+		return false
+	}
+	return true
+}
+func (m *Command) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Command) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.UID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(len(m.UID)))
+		i += copy(dAtA[i:], m.UID)
+	}
+	if m.Method != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(m.Method))
 	}
 	dAtA[i] = 0x1a
 	i++
@@ -510,7 +1259,7 @@ func (m *Metrics) MarshalTo(dAtA []byte) (int, error) {
 	if m.Interval != 0 {
 		dAtA[i] = 0x9
 		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Interval))))
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Interval))))
 		i += 8
 	}
 	if len(m.Items) > 0 {
@@ -526,7 +1275,7 @@ func (m *Metrics) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], k)
 			dAtA[i] = 0x11
 			i++
-			binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(v))))
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(v))))
 			i += 8
 		}
 	}
@@ -563,7 +1312,7 @@ func (m *Unsubscribe) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *Disconnect) Marshal() (dAtA []byte, err error) {
+func (m *Subscribe) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -573,80 +1322,312 @@ func (m *Disconnect) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Disconnect) MarshalTo(dAtA []byte) (int, error) {
+func (m *Subscribe) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.User) > 0 {
+	if len(m.Channel) > 0 {
 		dAtA[i] = 0xa
 		i++
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
+	}
+	if len(m.User) > 0 {
+		dAtA[i] = 0x12
+		i++
 		i = encodeVarintControl(dAtA, i, uint64(len(m.User)))
 		i += copy(dAtA[i:], m.User)
 	}
 	return i, nil
 }
 
-func encodeVarintControl(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
-}
-func NewPopulatedCommand(r randyControl, easy bool) *Command {
-	this := &Command{}
-	this.UID = string(randStringControl(r))
-	this.Method = MethodType([]int32{0, 1, 2}[r.Intn(3)])
-	v1 := github_com_centrifugal_centrifuge_internal_proto.NewPopulatedRaw(r)
-	this.Params = *v1
-	if !easy && r.Intn(10) != 0 {
+func (m *Disconnect) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedNode(r randyControl, easy bool) *Node {
-	this := &Node{}
-	this.UID = string(randStringControl(r))
-	this.Name = string(randStringControl(r))
-	this.Version = string(randStringControl(r))
-	this.NumClients = uint32(r.Uint32())
-	this.NumUsers = uint32(r.Uint32())
-	this.NumChannels = uint32(r.Uint32())
-	this.Uptime = uint32(r.Uint32())
-	if r.Intn(10) != 0 {
-		this.Metrics = NewPopulatedMetrics(r, easy)
+func (m *Disconnect) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.User) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(len(m.User)))
+		i += copy(dAtA[i:], m.User)
 	}
-	if !easy && r.Intn(10) != 0 {
+	if m.Reconnect {
+		dAtA[i] = 0x10
+		i++
+		if m.Reconnect {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	return this
-}
-
-func NewPopulatedMetrics(r randyControl, easy bool) *Metrics {
-	this := &Metrics{}
-	this.Interval = float64(r.Float64())
-	if r.Intn(2) == 0 {
-		this.Interval *= -1
+	if m.Code != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(m.Code))
 	}
-	if r.Intn(10) != 0 {
-		v2 := r.Intn(10)
-		this.Items = make(map[string]float64)
-		for i := 0; i < v2; i++ {
-			v3 := randStringControl(r)
-			this.Items[v3] = float64(r.Float64())
-			if r.Intn(2) == 0 {
-				this.Items[v3] *= -1
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	if len(m.Whitelist) > 0 {
+		for _, s := range m.Whitelist {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
 			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
 	}
-	if !easy && r.Intn(10) != 0 {
+	return i, nil
+}
+
+func (m *Send) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedUnsubscribe(r randyControl, easy bool) *Unsubscribe {
+func (m *Send) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Client) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Client)))
+		i += copy(dAtA[i:], m.Client)
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintControl(dAtA, i, uint64(m.Data.Size()))
+	n3, err := m.Data.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n3
+	return i, nil
+}
+
+func (m *Mute) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Mute) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
+	}
+	if len(m.User) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(len(m.User)))
+		i += copy(dAtA[i:], m.User)
+	}
+	if m.ExpireAt != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(m.ExpireAt))
+	}
+	return i, nil
+}
+
+func (m *Refresh) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Refresh) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.User) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(len(m.User)))
+		i += copy(dAtA[i:], m.User)
+	}
+	return i, nil
+}
+
+func (m *SurveyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SurveyRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
+	}
+	if len(m.Op) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Op)))
+		i += copy(dAtA[i:], m.Op)
+	}
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintControl(dAtA, i, uint64(m.Data.Size()))
+	n4, err := m.Data.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n4
+	return i, nil
+}
+
+func (m *SurveyResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SurveyResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
+	}
+	if m.Code != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintControl(dAtA, i, uint64(m.Code))
+	}
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintControl(dAtA, i, uint64(m.Data.Size()))
+	n5, err := m.Data.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n5
+	return i, nil
+}
+
+func encodeVarintControl(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func NewPopulatedCommand(r randyControl, easy bool) *Command {
+	this := &Command{}
+	this.UID = string(randStringControl(r))
+	this.Method = MethodType([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8}[r.Intn(9)])
+	v1 := github_com_centrifugal_centrifuge_internal_proto.NewPopulatedRaw(r)
+	this.Params = *v1
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedNode(r randyControl, easy bool) *Node {
+	this := &Node{}
+	this.UID = string(randStringControl(r))
+	this.Name = string(randStringControl(r))
+	this.Version = string(randStringControl(r))
+	this.NumClients = uint32(r.Uint32())
+	this.NumUsers = uint32(r.Uint32())
+	this.NumChannels = uint32(r.Uint32())
+	this.Uptime = uint32(r.Uint32())
+	if r.Intn(10) != 0 {
+		this.Metrics = NewPopulatedMetrics(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedMetrics(r randyControl, easy bool) *Metrics {
+	this := &Metrics{}
+	this.Interval = float64(r.Float64())
+	if r.Intn(2) == 0 {
+		this.Interval *= -1
+	}
+	if r.Intn(10) != 0 {
+		v2 := r.Intn(10)
+		this.Items = make(map[string]float64)
+		for i := 0; i < v2; i++ {
+			v3 := randStringControl(r)
+			this.Items[v3] = float64(r.Float64())
+			if r.Intn(2) == 0 {
+				this.Items[v3] *= -1
+			}
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedUnsubscribe(r randyControl, easy bool) *Unsubscribe {
 	this := &Unsubscribe{}
 	this.Channel = string(randStringControl(r))
 	this.User = string(randStringControl(r))
@@ -655,9 +1636,79 @@ func NewPopulatedUnsubscribe(r randyControl, easy bool) *Unsubscribe {
 	return this
 }
 
+func NewPopulatedSubscribe(r randyControl, easy bool) *Subscribe {
+	this := &Subscribe{}
+	this.Channel = string(randStringControl(r))
+	this.User = string(randStringControl(r))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
 func NewPopulatedDisconnect(r randyControl, easy bool) *Disconnect {
 	this := &Disconnect{}
 	this.User = string(randStringControl(r))
+	this.Reconnect = bool(bool(r.Intn(2) == 0))
+	this.Code = uint32(r.Uint32())
+	this.Reason = string(randStringControl(r))
+	v4 := r.Intn(10)
+	this.Whitelist = make([]string, v4)
+	for i := 0; i < v4; i++ {
+		this.Whitelist[i] = string(randStringControl(r))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedSend(r randyControl, easy bool) *Send {
+	this := &Send{}
+	this.Client = string(randStringControl(r))
+	v5 := github_com_centrifugal_centrifuge_internal_proto.NewPopulatedRaw(r)
+	this.Data = *v5
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedMute(r randyControl, easy bool) *Mute {
+	this := &Mute{}
+	this.Channel = string(randStringControl(r))
+	this.User = string(randStringControl(r))
+	this.ExpireAt = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.ExpireAt *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedRefresh(r randyControl, easy bool) *Refresh {
+	this := &Refresh{}
+	this.User = string(randStringControl(r))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedSurveyRequest(r randyControl, easy bool) *SurveyRequest {
+	this := &SurveyRequest{}
+	this.ID = string(randStringControl(r))
+	this.Op = string(randStringControl(r))
+	v6 := github_com_centrifugal_centrifuge_internal_proto.NewPopulatedRaw(r)
+	this.Data = *v6
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedSurveyResponse(r randyControl, easy bool) *SurveyResponse {
+	this := &SurveyResponse{}
+	this.ID = string(randStringControl(r))
+	this.Code = uint32(r.Uint32())
+	v7 := github_com_centrifugal_centrifuge_internal_proto.NewPopulatedRaw(r)
+	this.Data = *v7
 	if !easy && r.Intn(10) != 0 {
 	}
 	return this
@@ -682,9 +1733,9 @@ func randUTF8RuneControl(r randyControl) rune {
 	return rune(ru + 61)
 }
 func randStringControl(r randyControl) string {
-	v4 := r.Intn(100)
-	tmps := make([]rune, v4)
-	for i := 0; i < v4; i++ {
+	v8 := r.Intn(100)
+	tmps := make([]rune, v8)
+	for i := 0; i < v8; i++ {
 		tmps[i] = randUTF8RuneControl(r)
 	}
 	return string(tmps)
@@ -706,11 +1757,11 @@ func randFieldControl(dAtA []byte, r randyControl, fieldNumber int, wire int) []
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateControl(dAtA, uint64(key))
-		v5 := r.Int63()
+		v9 := r.Int63()
 		if r.Intn(2) == 0 {
-			v5 *= -1
+			v9 *= -1
 		}
-		dAtA = encodeVarintPopulateControl(dAtA, uint64(v5))
+		dAtA = encodeVarintPopulateControl(dAtA, uint64(v9))
 	case 1:
 		dAtA = encodeVarintPopulateControl(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -736,6 +1787,9 @@ func encodeVarintPopulateControl(dAtA []byte, v uint64) []byte {
 	return dAtA
 }
 func (m *Command) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	l = len(m.UID)
@@ -751,6 +1805,9 @@ func (m *Command) Size() (n int) {
 }
 
 func (m *Node) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	l = len(m.UID)
@@ -785,6 +1842,9 @@ func (m *Node) Size() (n int) {
 }
 
 func (m *Metrics) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	if m.Interval != 0 {
@@ -802,6 +1862,26 @@ func (m *Metrics) Size() (n int) {
 }
 
 func (m *Unsubscribe) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.User)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *Subscribe) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	l = len(m.Channel)
@@ -816,29 +1896,818 @@ func (m *Unsubscribe) Size() (n int) {
 }
 
 func (m *Disconnect) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	l = len(m.User)
 	if l > 0 {
 		n += 1 + l + sovControl(uint64(l))
 	}
+	if m.Reconnect {
+		n += 2
+	}
+	if m.Code != 0 {
+		n += 1 + sovControl(uint64(m.Code))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Whitelist) > 0 {
+		for _, s := range m.Whitelist {
+			l = len(s)
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
 	return n
 }
 
-func sovControl(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+func (m *Send) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Client)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = m.Data.Size()
+	n += 1 + l + sovControl(uint64(l))
+	return n
+}
+
+func (m *Mute) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.User)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.ExpireAt != 0 {
+		n += 1 + sovControl(uint64(m.ExpireAt))
+	}
+	return n
+}
+
+func (m *Refresh) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.User)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *SurveyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Op)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = m.Data.Size()
+	n += 1 + l + sovControl(uint64(l))
+	return n
+}
+
+func (m *SurveyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Code != 0 {
+		n += 1 + sovControl(uint64(m.Code))
+	}
+	l = m.Data.Size()
+	n += 1 + l + sovControl(uint64(l))
+	return n
+}
+
+func sovControl(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozControl(x uint64) (n int) {
+	return sovControl(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Command) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Command: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Command: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
+			}
+			m.Method = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Method |= MethodType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Node) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Node: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Node: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumClients", wireType)
+			}
+			m.NumClients = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumClients |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumUsers", wireType)
+			}
+			m.NumUsers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumUsers |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumChannels", wireType)
+			}
+			m.NumChannels = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumChannels |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uptime", wireType)
+			}
+			m.Uptime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Uptime |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metrics", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metrics == nil {
+				m.Metrics = &Metrics{}
+			}
+			if err := m.Metrics.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Metrics) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Metrics: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Metrics: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Interval = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Items == nil {
+				m.Items = make(map[string]float64)
+			}
+			var mapkey string
+			var mapvalue float64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowControl
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowControl
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthControl
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthControl
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapvaluetemp uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvaluetemp = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					mapvalue = math.Float64frombits(mapvaluetemp)
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipControl(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthControl
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Items[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Unsubscribe) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Unsubscribe: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Unsubscribe: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Channel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.User = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
-func sozControl(x uint64) (n int) {
-	return sovControl(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *Command) Unmarshal(dAtA []byte) error {
+func (m *Subscribe) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -853,7 +2722,7 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -861,15 +2730,15 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Command: wiretype end group for non-group")
+			return fmt.Errorf("proto: Subscribe: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Command: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Subscribe: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -881,7 +2750,7 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -891,35 +2760,19 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthControl
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UID = string(dAtA[iNdEx:postIndex])
+			m.Channel = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
-			}
-			m.Method = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Method |= (MethodType(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -929,21 +2782,23 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.User = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -954,6 +2809,9 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthControl
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -966,7 +2824,7 @@ func (m *Command) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Node) Unmarshal(dAtA []byte) error {
+func (m *Disconnect) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -981,7 +2839,7 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -989,15 +2847,15 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Node: wiretype end group for non-group")
+			return fmt.Errorf("proto: Disconnect: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Node: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Disconnect: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1009,7 +2867,7 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1019,16 +2877,19 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthControl
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UID = string(dAtA[iNdEx:postIndex])
+			m.User = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reconnect", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -1038,24 +2899,34 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthControl
+			m.Reconnect = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1067,7 +2938,7 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1077,16 +2948,19 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthControl
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Version = string(dAtA[iNdEx:postIndex])
+			m.Reason = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NumClients", wireType)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Whitelist", wireType)
 			}
-			m.NumClients = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -1096,16 +2970,82 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NumClients |= (uint32(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NumUsers", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
 			}
-			m.NumUsers = 0
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Whitelist = append(m.Whitelist, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Send) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Send: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Send: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Client", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -1115,16 +3055,29 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NumUsers |= (uint32(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NumChannels", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
 			}
-			m.NumChannels = 0
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Client = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -1134,16 +3087,83 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NumChannels |= (uint32(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uptime", wireType)
+			if byteLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Mute) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Mute: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Mute: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
 			}
-			m.Uptime = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -1153,16 +3173,29 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Uptime |= (uint32(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 8:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Channel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metrics", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -1172,25 +3205,43 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Metrics == nil {
-				m.Metrics = &Metrics{}
+			m.User = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireAt", wireType)
 			}
-			if err := m.Metrics.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.ExpireAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -1200,6 +3251,9 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthControl
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1212,7 +3266,7 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Metrics) Unmarshal(dAtA []byte) error {
+func (m *Refresh) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1227,7 +3281,7 @@ func (m *Metrics) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -1235,28 +3289,17 @@ func (m *Metrics) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Metrics: wiretype end group for non-group")
+			return fmt.Errorf("proto: Refresh: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Metrics: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Refresh: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
-			}
-			var v uint64
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-			m.Interval = float64(math.Float64frombits(v))
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -1266,91 +3309,23 @@ func (m *Metrics) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Items == nil {
-				m.Items = make(map[string]float64)
-			}
-			var mapkey string
-			var mapvalue float64
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowControl
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowControl
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthControl
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapvaluetemp uint64
-					if (iNdEx + 8) > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvaluetemp = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-					iNdEx += 8
-					mapvalue = math.Float64frombits(mapvaluetemp)
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipControl(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthControl
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.Items[mapkey] = mapvalue
+			m.User = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1361,6 +3336,9 @@ func (m *Metrics) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthControl
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1373,7 +3351,7 @@ func (m *Metrics) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Unsubscribe) Unmarshal(dAtA []byte) error {
+func (m *SurveyRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1388,7 +3366,7 @@ func (m *Unsubscribe) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -1396,15 +3374,15 @@ func (m *Unsubscribe) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Unsubscribe: wiretype end group for non-group")
+			return fmt.Errorf("proto: SurveyRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Unsubscribe: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SurveyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1416,7 +3394,7 @@ func (m *Unsubscribe) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1426,14 +3404,17 @@ func (m *Unsubscribe) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthControl
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Channel = string(dAtA[iNdEx:postIndex])
+			m.ID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1445,7 +3426,7 @@ func (m *Unsubscribe) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1455,10 +3436,46 @@ func (m *Unsubscribe) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthControl
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.User = string(dAtA[iNdEx:postIndex])
+			m.Op = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1469,6 +3486,9 @@ func (m *Unsubscribe) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthControl
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1481,7 +3501,7 @@ func (m *Unsubscribe) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Disconnect) Unmarshal(dAtA []byte) error {
+func (m *SurveyResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1496,7 +3516,7 @@ func (m *Disconnect) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -1504,15 +3524,15 @@ func (m *Disconnect) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Disconnect: wiretype end group for non-group")
+			return fmt.Errorf("proto: SurveyResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Disconnect: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SurveyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1524,7 +3544,7 @@ func (m *Disconnect) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1534,10 +3554,65 @@ func (m *Disconnect) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthControl
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.User = string(dAtA[iNdEx:postIndex])
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1548,6 +3623,9 @@ func (m *Disconnect) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthControl
 			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1614,10 +3692,13 @@ func skipControl(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			iNdEx += length
 			if length < 0 {
 				return 0, ErrInvalidLengthControl
 			}
+			iNdEx += length
+			if iNdEx < 0 {
+				return 0, ErrInvalidLengthControl
+			}
 			return iNdEx, nil
 		case 3:
 			for {
@@ -1646,6 +3727,9 @@ func skipControl(dAtA []byte) (n int, err error) {
 					return 0, err
 				}
 				iNdEx = start + next
+				if iNdEx < 0 {
+					return 0, ErrInvalidLengthControl
+				}
 			}
 			return iNdEx, nil
 		case 4:
@@ -1664,50 +3748,3 @@ var (
 	ErrInvalidLengthControl = fmt.Errorf("proto: negative length found during unmarshaling")
 	ErrIntOverflowControl   = fmt.Errorf("proto: integer overflow")
 )
-
-func init() { proto.RegisterFile("control.proto", fileDescriptorControl) }
-
-var fileDescriptorControl = []byte{
-	// 654 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x3f, 0x6f, 0xd3, 0x40,
-	0x1c, 0xcd, 0x25, 0x69, 0xfe, 0xfc, 0x92, 0x96, 0xe8, 0xd4, 0x4a, 0x26, 0xaa, 0x6c, 0x2b, 0x12,
-	0x92, 0x15, 0x89, 0x14, 0xb5, 0x0c, 0x15, 0xea, 0x82, 0x9d, 0x20, 0x65, 0x20, 0x95, 0xae, 0xcd,
-	0x0a, 0x72, 0x9c, 0x6b, 0x6a, 0x61, 0x9f, 0x23, 0xff, 0x29, 0xea, 0x17, 0x40, 0xa8, 0x13, 0x5f,
-	0xa0, 0x13, 0x0b, 0x23, 0x23, 0x1f, 0xa1, 0x6c, 0xcc, 0x0c, 0x16, 0x98, 0xcd, 0x9f, 0x80, 0x11,
-	0xdd, 0xd9, 0x89, 0x83, 0xe8, 0xc0, 0x72, 0xf7, 0xde, 0xfb, 0x3d, 0xdf, 0xdd, 0xef, 0xde, 0x19,
-	0xb6, 0x2d, 0x8f, 0x85, 0xbe, 0xe7, 0x0c, 0x96, 0xbe, 0x17, 0x7a, 0xb8, 0x9d, 0x53, 0xc1, 0xba,
-	0x8f, 0x17, 0x76, 0x78, 0x19, 0xcd, 0x06, 0x96, 0xe7, 0x1e, 0x2c, 0xbc, 0x85, 0x77, 0x20, 0xe4,
-	0x59, 0x74, 0x21, 0x98, 0x20, 0x02, 0x65, 0x1f, 0xf7, 0xbe, 0x22, 0xa8, 0x1b, 0x9e, 0xeb, 0x9a,
-	0x6c, 0x8e, 0x55, 0xa8, 0x44, 0xf6, 0x5c, 0x42, 0x2a, 0xd2, 0x9a, 0xfa, 0x4e, 0x12, 0x2b, 0x95,
-	0xe9, 0x78, 0x98, 0xc6, 0x0a, 0x57, 0x09, 0x1f, 0xf0, 0x09, 0xd4, 0x5c, 0x1a, 0x5e, 0x7a, 0x73,
-	0xa9, 0xac, 0x22, 0x6d, 0xe7, 0x50, 0x1a, 0x6c, 0xee, 0x3d, 0x78, 0x29, 0x6a, 0xe7, 0xd7, 0x4b,
-	0xaa, 0x43, 0x1a, 0x2b, 0xb9, 0x97, 0xe4, 0x33, 0x7e, 0x05, 0xb5, 0xa5, 0xe9, 0x9b, 0x6e, 0x20,
-	0x55, 0x54, 0xa4, 0xb5, 0xf5, 0x17, 0x77, 0xb1, 0x52, 0xfa, 0x1e, 0x2b, 0x4f, 0x37, 0x8e, 0x6c,
-	0x51, 0x16, 0xfa, 0xf6, 0x45, 0xb4, 0x30, 0x9d, 0x02, 0xd3, 0x03, 0x9b, 0x85, 0xd4, 0x67, 0xa6,
-	0x93, 0x75, 0x33, 0x20, 0xe6, 0x5b, 0xbe, 0x7e, 0xb6, 0x1a, 0xc9, 0xe7, 0x5e, 0x52, 0x86, 0xea,
-	0xc4, 0x9b, 0xd3, 0xff, 0x68, 0x64, 0x1f, 0xaa, 0xcc, 0x74, 0xa9, 0x68, 0xa3, 0xa9, 0x37, 0xd2,
-	0x58, 0x11, 0x9c, 0x88, 0x11, 0x3f, 0x82, 0xfa, 0x15, 0xf5, 0x03, 0xdb, 0x63, 0xe2, 0xa4, 0x4d,
-	0xbd, 0x95, 0xc6, 0xca, 0x4a, 0x22, 0x2b, 0x80, 0x9f, 0x40, 0x8b, 0x45, 0xee, 0x6b, 0xcb, 0xb1,
-	0x29, 0x0b, 0x03, 0xa9, 0xaa, 0x22, 0x6d, 0x5b, 0x7f, 0x90, 0xc6, 0xca, 0xa6, 0x4c, 0x80, 0x45,
-	0xae, 0x91, 0x61, 0xdc, 0x87, 0x26, 0x2f, 0x45, 0x01, 0xf5, 0x03, 0x69, 0x4b, 0xf8, 0xb7, 0xd3,
-	0x58, 0x29, 0x44, 0xd2, 0x60, 0x91, 0x3b, 0xe5, 0x08, 0x1f, 0x41, 0x5b, 0x2c, 0x73, 0x69, 0x32,
-	0x46, 0x9d, 0x40, 0xaa, 0x09, 0x7b, 0x27, 0x8d, 0x95, 0xbf, 0x74, 0xc2, 0x37, 0x33, 0x72, 0x82,
-	0x7b, 0x50, 0x8b, 0x96, 0xa1, 0xed, 0x52, 0xa9, 0x2e, 0xec, 0x22, 0x86, 0x4c, 0x21, 0xf9, 0x8c,
-	0x4f, 0xa0, 0xee, 0xd2, 0xd0, 0xb7, 0xad, 0x40, 0x6a, 0xa8, 0x48, 0x6b, 0x1d, 0xee, 0xfd, 0x93,
-	0x22, 0x2f, 0x66, 0x4d, 0xe7, 0x4e, 0xb2, 0x02, 0xbd, 0xcf, 0x08, 0xea, 0xb9, 0x03, 0x6b, 0xd0,
-	0x10, 0xc1, 0x5c, 0x99, 0x8e, 0xb8, 0x6c, 0xa4, 0xb7, 0xd3, 0x58, 0x59, 0x6b, 0x64, 0x8d, 0xf0,
-	0x73, 0xd8, 0xb2, 0x43, 0xea, 0x06, 0x52, 0x59, 0xad, 0x68, 0xad, 0x43, 0xf5, 0xde, 0x1d, 0x07,
-	0x63, 0x6e, 0x19, 0xb1, 0xd0, 0xbf, 0xd6, 0x9b, 0x69, 0xac, 0x64, 0x9f, 0x90, 0x6c, 0xea, 0x1e,
-	0x03, 0x14, 0x75, 0xdc, 0x81, 0xca, 0x1b, 0x7a, 0x9d, 0x45, 0x4c, 0x38, 0xc4, 0xbb, 0xb0, 0x75,
-	0x65, 0x3a, 0x51, 0x96, 0x29, 0x22, 0x19, 0x79, 0x56, 0x3e, 0x46, 0x3d, 0x02, 0xad, 0x29, 0x0b,
-	0xa2, 0x59, 0x60, 0xf9, 0xf6, 0x4c, 0xa4, 0x9b, 0x5f, 0x5e, 0xfe, 0x42, 0x44, 0xa3, 0xb9, 0x44,
-	0x56, 0x80, 0x3f, 0x11, 0x1e, 0xc9, 0xe6, 0x13, 0xe1, 0x9c, 0x88, 0xb1, 0xd7, 0x07, 0x18, 0xda,
-	0x81, 0xe5, 0x31, 0x46, 0xad, 0x70, 0xed, 0x45, 0xf7, 0x79, 0xfb, 0xef, 0x10, 0x40, 0xf1, 0x6b,
-	0x70, 0xf3, 0xe4, 0x74, 0x38, 0xea, 0x94, 0xba, 0xf8, 0xe6, 0x56, 0xdd, 0x29, 0x2a, 0xe2, 0xed,
-	0xf6, 0xa1, 0x35, 0x9d, 0x9c, 0x4d, 0xf5, 0x33, 0x83, 0x8c, 0xf5, 0x51, 0x07, 0x75, 0x1f, 0xde,
-	0xdc, 0xaa, 0x7b, 0x85, 0x69, 0xb3, 0x13, 0x0d, 0x60, 0x38, 0x3e, 0x33, 0x4e, 0x27, 0x93, 0x91,
-	0x71, 0xde, 0x29, 0x77, 0xa5, 0x9b, 0x5b, 0x75, 0xb7, 0xb0, 0x16, 0x07, 0xec, 0x56, 0xdf, 0x7f,
-	0x94, 0x4b, 0xfa, 0xfe, 0xef, 0x9f, 0x32, 0xfa, 0x94, 0xc8, 0xe8, 0x4b, 0x22, 0xa3, 0xbb, 0x44,
-	0x46, 0xdf, 0x12, 0x19, 0xfd, 0x48, 0x64, 0xf4, 0xe1, 0x97, 0x5c, 0x9a, 0xd5, 0x44, 0x18, 0x47,
-	0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x6f, 0x8c, 0xdf, 0x6e, 0x5f, 0x04, 0x00, 0x00,
-}
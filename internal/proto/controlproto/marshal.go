@@ -6,6 +6,12 @@ type Encoder interface {
 	EncodeNode(*Node) ([]byte, error)
 	EncodeUnsubscribe(*Unsubscribe) ([]byte, error)
 	EncodeDisconnect(*Disconnect) ([]byte, error)
+	EncodeSend(*Send) ([]byte, error)
+	EncodeMute(*Mute) ([]byte, error)
+	EncodeRefresh(*Refresh) ([]byte, error)
+	EncodeSubscribe(*Subscribe) ([]byte, error)
+	EncodeSurveyRequest(*SurveyRequest) ([]byte, error)
+	EncodeSurveyResponse(*SurveyResponse) ([]byte, error)
 }
 
 // ProtobufEncoder ...
@@ -36,3 +42,33 @@ func (e *ProtobufEncoder) EncodeUnsubscribe(cmd *Unsubscribe) ([]byte, error) {
 func (e *ProtobufEncoder) EncodeDisconnect(cmd *Disconnect) ([]byte, error) {
 	return cmd.Marshal()
 }
+
+// EncodeSend ...
+func (e *ProtobufEncoder) EncodeSend(cmd *Send) ([]byte, error) {
+	return cmd.Marshal()
+}
+
+// EncodeMute ...
+func (e *ProtobufEncoder) EncodeMute(cmd *Mute) ([]byte, error) {
+	return cmd.Marshal()
+}
+
+// EncodeRefresh ...
+func (e *ProtobufEncoder) EncodeRefresh(cmd *Refresh) ([]byte, error) {
+	return cmd.Marshal()
+}
+
+// EncodeSubscribe ...
+func (e *ProtobufEncoder) EncodeSubscribe(cmd *Subscribe) ([]byte, error) {
+	return cmd.Marshal()
+}
+
+// EncodeSurveyRequest ...
+func (e *ProtobufEncoder) EncodeSurveyRequest(cmd *SurveyRequest) ([]byte, error) {
+	return cmd.Marshal()
+}
+
+// EncodeSurveyResponse ...
+func (e *ProtobufEncoder) EncodeSurveyResponse(cmd *SurveyResponse) ([]byte, error) {
+	return cmd.Marshal()
+}
@@ -6,6 +6,12 @@ type Decoder interface {
 	DecodeNode([]byte) (*Node, error)
 	DecodeUnsubscribe([]byte) (*Unsubscribe, error)
 	DecodeDisconnect([]byte) (*Disconnect, error)
+	DecodeSend([]byte) (*Send, error)
+	DecodeMute([]byte) (*Mute, error)
+	DecodeRefresh([]byte) (*Refresh, error)
+	DecodeSubscribe([]byte) (*Subscribe, error)
+	DecodeSurveyRequest([]byte) (*SurveyRequest, error)
+	DecodeSurveyResponse([]byte) (*SurveyResponse, error)
 }
 
 // ProtobufDecoder ...
@@ -56,3 +62,63 @@ func (e *ProtobufDecoder) DecodeDisconnect(data []byte) (*Disconnect, error) {
 	}
 	return &cmd, nil
 }
+
+// DecodeSend ...
+func (e *ProtobufDecoder) DecodeSend(data []byte) (*Send, error) {
+	var cmd Send
+	err := cmd.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &cmd, nil
+}
+
+// DecodeMute ...
+func (e *ProtobufDecoder) DecodeMute(data []byte) (*Mute, error) {
+	var cmd Mute
+	err := cmd.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &cmd, nil
+}
+
+// DecodeRefresh ...
+func (e *ProtobufDecoder) DecodeRefresh(data []byte) (*Refresh, error) {
+	var cmd Refresh
+	err := cmd.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &cmd, nil
+}
+
+// DecodeSubscribe ...
+func (e *ProtobufDecoder) DecodeSubscribe(data []byte) (*Subscribe, error) {
+	var cmd Subscribe
+	err := cmd.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &cmd, nil
+}
+
+// DecodeSurveyRequest ...
+func (e *ProtobufDecoder) DecodeSurveyRequest(data []byte) (*SurveyRequest, error) {
+	var cmd SurveyRequest
+	err := cmd.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &cmd, nil
+}
+
+// DecodeSurveyResponse ...
+func (e *ProtobufDecoder) DecodeSurveyResponse(data []byte) (*SurveyResponse, error) {
+	var cmd SurveyResponse
+	err := cmd.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &cmd, nil
+}
@@ -214,6 +214,7 @@ type ParamsDecoder interface {
 	DecodePing([]byte) (*PingRequest, error)
 	DecodeRPC([]byte) (*RPCRequest, error)
 	DecodeSend([]byte) (*SendRequest, error)
+	DecodeMarkRead([]byte) (*MarkReadRequest, error)
 }
 
 // JSONParamsDecoder ...
@@ -352,6 +353,18 @@ func (d *JSONParamsDecoder) DecodeSend(data []byte) (*SendRequest, error) {
 	return &p, nil
 }
 
+// DecodeMarkRead ...
+func (d *JSONParamsDecoder) DecodeMarkRead(data []byte) (*MarkReadRequest, error) {
+	var p MarkReadRequest
+	if data != nil {
+		err := json.Unmarshal(data, &p)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &p, nil
+}
+
 // ProtobufParamsDecoder ...
 type ProtobufParamsDecoder struct{}
 
@@ -481,3 +494,13 @@ func (d *ProtobufParamsDecoder) DecodeSend(data []byte) (*SendRequest, error) {
 	}
 	return &p, nil
 }
+
+// DecodeMarkRead ...
+func (d *ProtobufParamsDecoder) DecodeMarkRead(data []byte) (*MarkReadRequest, error) {
+	var p MarkReadRequest
+	err := p.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
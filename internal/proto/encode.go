@@ -6,6 +6,42 @@ import (
 	"encoding/json"
 )
 
+// IsBinaryData returns true if data can't be embedded into JSON as is.
+func IsBinaryData(data Raw) bool {
+	return len(data) > 0 && !json.Valid(data)
+}
+
+// jsonPublication returns publication ready to be encoded into JSON: data of
+// publication marked as Binary moved into base64 encoded b64data field.
+// Binary flag set once when publication created so data not validated for
+// every client.
+func jsonPublication(pub *Publication) *Publication {
+	if !pub.Binary {
+		return pub
+	}
+	p := *pub
+	p.B64Data = pub.Data
+	p.Data = nil
+	return &p
+}
+
+// jsonPublications returns copy of publications with binary data moved into
+// b64data field, false returned if there are no publications with binary data.
+func jsonPublications(pubs []*Publication) ([]*Publication, bool) {
+	var result []*Publication
+	for i, pub := range pubs {
+		if !pub.Binary {
+			continue
+		}
+		if result == nil {
+			result = make([]*Publication, len(pubs))
+			copy(result, pubs)
+		}
+		result[i] = jsonPublication(pub)
+	}
+	return result, result != nil
+}
+
 // PushEncoder ...
 type PushEncoder interface {
 	Encode(*Push) ([]byte, error)
@@ -13,7 +49,10 @@ type PushEncoder interface {
 	EncodePublication(*Publication) ([]byte, error)
 	EncodeJoin(*Join) ([]byte, error)
 	EncodeLeave(*Leave) ([]byte, error)
+	EncodeMembership(*Membership) ([]byte, error)
 	EncodeUnsub(*Unsub) ([]byte, error)
+	EncodeSub(*Sub) ([]byte, error)
+	EncodeRefreshDemand(*RefreshDemand) ([]byte, error)
 }
 
 // JSONPushEncoder ...
@@ -32,11 +71,14 @@ func (e *JSONPushEncoder) Encode(message *Push) ([]byte, error) {
 
 // EncodePublication ...
 func (e *JSONPushEncoder) EncodePublication(message *Publication) ([]byte, error) {
-	return json.Marshal(message)
+	return json.Marshal(jsonPublication(message))
 }
 
 // EncodeMessage ...
 func (e *JSONPushEncoder) EncodeMessage(message *Message) ([]byte, error) {
+	if IsBinaryData(message.Data) {
+		message = &Message{B64Data: message.Data}
+	}
 	return json.Marshal(message)
 }
 
@@ -50,11 +92,26 @@ func (e *JSONPushEncoder) EncodeLeave(message *Leave) ([]byte, error) {
 	return json.Marshal(message)
 }
 
+// EncodeMembership ...
+func (e *JSONPushEncoder) EncodeMembership(message *Membership) ([]byte, error) {
+	return json.Marshal(message)
+}
+
 // EncodeUnsub ...
 func (e *JSONPushEncoder) EncodeUnsub(message *Unsub) ([]byte, error) {
 	return json.Marshal(message)
 }
 
+// EncodeSub ...
+func (e *JSONPushEncoder) EncodeSub(message *Sub) ([]byte, error) {
+	return json.Marshal(message)
+}
+
+// EncodeRefreshDemand ...
+func (e *JSONPushEncoder) EncodeRefreshDemand(message *RefreshDemand) ([]byte, error) {
+	return json.Marshal(message)
+}
+
 // ProtobufPushEncoder ...
 type ProtobufPushEncoder struct {
 }
@@ -89,11 +146,26 @@ func (e *ProtobufPushEncoder) EncodeLeave(message *Leave) ([]byte, error) {
 	return message.Marshal()
 }
 
+// EncodeMembership ...
+func (e *ProtobufPushEncoder) EncodeMembership(message *Membership) ([]byte, error) {
+	return message.Marshal()
+}
+
 // EncodeUnsub ...
 func (e *ProtobufPushEncoder) EncodeUnsub(message *Unsub) ([]byte, error) {
 	return message.Marshal()
 }
 
+// EncodeSub ...
+func (e *ProtobufPushEncoder) EncodeSub(message *Sub) ([]byte, error) {
+	return message.Marshal()
+}
+
+// EncodeRefreshDemand ...
+func (e *ProtobufPushEncoder) EncodeRefreshDemand(message *RefreshDemand) ([]byte, error) {
+	return message.Marshal()
+}
+
 // ReplyEncoder ...
 type ReplyEncoder interface {
 	Reset()
@@ -184,6 +256,7 @@ type ResultEncoder interface {
 	EncodeHistoryResult(*HistoryResult) ([]byte, error)
 	EncodePingResult(*PingResult) ([]byte, error)
 	EncodeRPCResult(*RPCResult) ([]byte, error)
+	EncodeMarkReadResult(*MarkReadResult) ([]byte, error)
 }
 
 // JSONResultEncoder ...
@@ -206,6 +279,11 @@ func (e *JSONResultEncoder) EncodeRefreshResult(res *RefreshResult) ([]byte, err
 
 // EncodeSubscribeResult ...
 func (e *JSONResultEncoder) EncodeSubscribeResult(res *SubscribeResult) ([]byte, error) {
+	if pubs, ok := jsonPublications(res.Publications); ok {
+		r := *res
+		r.Publications = pubs
+		res = &r
+	}
 	return json.Marshal(res)
 }
 
@@ -236,6 +314,11 @@ func (e *JSONResultEncoder) EncodePresenceStatsResult(res *PresenceStatsResult)
 
 // EncodeHistoryResult ...
 func (e *JSONResultEncoder) EncodeHistoryResult(res *HistoryResult) ([]byte, error) {
+	if pubs, ok := jsonPublications(res.Publications); ok {
+		r := *res
+		r.Publications = pubs
+		res = &r
+	}
 	return json.Marshal(res)
 }
 
@@ -249,6 +332,11 @@ func (e *JSONResultEncoder) EncodeRPCResult(res *RPCResult) ([]byte, error) {
 	return json.Marshal(res)
 }
 
+// EncodeMarkReadResult ...
+func (e *JSONResultEncoder) EncodeMarkReadResult(res *MarkReadResult) ([]byte, error) {
+	return json.Marshal(res)
+}
+
 // ProtobufResultEncoder ...
 type ProtobufResultEncoder struct{}
 
@@ -311,3 +399,8 @@ func (e *ProtobufResultEncoder) EncodePingResult(res *PingResult) ([]byte, error
 func (e *ProtobufResultEncoder) EncodeRPCResult(res *RPCResult) ([]byte, error) {
 	return res.Marshal()
 }
+
+// EncodeMarkReadResult ...
+func (e *ProtobufResultEncoder) EncodeMarkReadResult(res *MarkReadResult) ([]byte, error) {
+	return res.Marshal()
+}
@@ -0,0 +1,87 @@
+package proto
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONPushEncoderBinaryPublication(t *testing.T) {
+	binaryData := []byte{0x00, 0xff, 0x01}
+	pub := &Publication{UID: "1", Data: Raw(binaryData), Binary: true}
+	data, err := NewJSONPushEncoder().EncodePublication(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Publication
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded.B64Data, binaryData) {
+		t.Fatalf("unexpected b64data: %v", decoded.B64Data)
+	}
+	if !bytes.Equal(pub.Data, binaryData) || pub.B64Data != nil {
+		t.Fatal("publication must not be modified")
+	}
+
+	data, err = NewJSONPushEncoder().EncodePublication(&Publication{Data: Raw(`{"key":"value"}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"data":{"key":"value"}}` {
+		t.Fatalf("unexpected encoding: %s", data)
+	}
+}
+
+func TestJSONResultEncoderBinaryHistory(t *testing.T) {
+	binaryData := []byte{0x00, 0xff, 0x01}
+	pubs := []*Publication{
+		{UID: "1", Data: Raw(`{}`)},
+		{UID: "2", Data: Raw(binaryData), Binary: true},
+	}
+	data, err := NewJSONResultEncoder().EncodeHistoryResult(&HistoryResult{Publications: pubs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res HistoryResult
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatal(err)
+	}
+	if string(res.Publications[0].Data) != `{}` || res.Publications[0].B64Data != nil {
+		t.Fatal("JSON data must be left as is")
+	}
+	if !bytes.Equal(res.Publications[1].B64Data, binaryData) {
+		t.Fatalf("unexpected b64data: %v", res.Publications[1].B64Data)
+	}
+	if pubs[1].B64Data != nil {
+		t.Fatal("publications must not be modified")
+	}
+}
+
+func TestPublicationBinaryFlag(t *testing.T) {
+	if !IsBinaryData(Raw([]byte{0x00, 0xff, 0x01})) || IsBinaryData(Raw(`{}`)) || IsBinaryData(nil) {
+		t.Fatal("unexpected binary data detection")
+	}
+
+	// Flag must survive passing publication between nodes and through
+	// history so data not validated again.
+	encoded, err := (&Publication{Data: Raw([]byte{0x00}), Binary: true}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pub Publication
+	if err := pub.Unmarshal(encoded); err != nil {
+		t.Fatal(err)
+	}
+	if !pub.Binary {
+		t.Fatal("binary flag lost")
+	}
+
+	data, err := NewJSONPushEncoder().EncodePublication(&pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"data":null,"b64data":"AA=="}` {
+		t.Fatalf("unexpected encoding: %s", data)
+	}
+}
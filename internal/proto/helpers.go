@@ -35,6 +35,15 @@ func NewLeavePush(ch string, data Raw) *Push {
 	}
 }
 
+// NewMembershipPush returns initialized async membership message.
+func NewMembershipPush(ch string, data Raw) *Push {
+	return &Push{
+		Type:    PushTypeMembership,
+		Channel: ch,
+		Data:    data,
+	}
+}
+
 // NewUnsubPush returns initialized async unsubscribe message.
 func NewUnsubPush(ch string, data Raw) *Push {
 	return &Push{
@@ -44,6 +53,23 @@ func NewUnsubPush(ch string, data Raw) *Push {
 	}
 }
 
+// NewSubPush returns initialized async server-side subscribe message.
+func NewSubPush(ch string, data Raw) *Push {
+	return &Push{
+		Type:    PushTypeSub,
+		Channel: ch,
+		Data:    data,
+	}
+}
+
+// NewRefreshDemandPush returns initialized async refresh demand message.
+func NewRefreshDemandPush(data Raw) *Push {
+	return &Push{
+		Type: PushTypeRefreshDemand,
+		Data: data,
+	}
+}
+
 // ConnectResponse ...
 type ConnectResponse struct {
 	Error  *Error         `json:"error,omitempty"`
@@ -109,3 +135,9 @@ type PublishResponse struct {
 	Error  *Error         `json:"error,omitempty"`
 	Result *PublishResult `json:"result,omitempty"`
 }
+
+// MarkReadResponse ...
+type MarkReadResponse struct {
+	Error  *Error          `json:"error,omitempty"`
+	Result *MarkReadResult `json:"result,omitempty"`
+}
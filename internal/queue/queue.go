@@ -13,6 +13,18 @@ type Queue interface {
 	// In that case the []byte is dropped.
 	Add([]byte) bool
 
+	// AddWithKey adds []byte to the back of the queue marking it with key
+	// so it can be dropped later using DropOldest or Coalesce.
+	AddWithKey([]byte, string) bool
+
+	// DropOldest removes oldest items added with key until queue size in
+	// bytes is not larger than size. Returns number of removed items.
+	DropOldest(size int) int
+
+	// Coalesce removes all items added with key except the most recent one.
+	// Returns number of removed items.
+	Coalesce(key string) int
+
 	// Remove will remove a []byte from the queue.
 	// If false is returned, it either means 1) there were no items on the queue
 	// or 2) the queue is closed.
@@ -48,16 +60,32 @@ type Queue interface {
 	Size() int
 }
 
+type item struct {
+	data []byte
+	key  string
+	// dropped marks item removed by DropOldest or Coalesce but still
+	// occupying its slot until head reaches it.
+	dropped bool
+}
+
 type byteQueue struct {
 	mu      sync.RWMutex
 	cond    *sync.Cond
-	nodes   [][]byte
+	nodes   []item
 	head    int
 	tail    int
 	cnt     int
+	live    int
 	size    int
 	closed  bool
 	initCap int
+	// seq is a position of head item counted from queue start.
+	seq uint64
+	// keys contains positions of not dropped items with key, oldest first.
+	keys map[string][]uint64
+	// scanned is a number of items from head known to have no not dropped
+	// items with key so DropOldest does not look at them again.
+	scanned int
 }
 
 var initialCapacity = 2
@@ -66,7 +94,8 @@ var initialCapacity = 2
 func New() Queue {
 	sq := &byteQueue{
 		initCap: initialCapacity,
-		nodes:   make([][]byte, initialCapacity),
+		nodes:   make([]item, initialCapacity),
+		keys:    make(map[string][]uint64),
 	}
 	sq.cond = sync.NewCond(&sq.mu)
 	return sq
@@ -74,12 +103,15 @@ func New() Queue {
 
 // Write mutex must be held when calling
 func (q *byteQueue) resize(n int) {
-	nodes := make([][]byte, n)
-	if q.head < q.tail {
-		copy(nodes, q.nodes[q.head:q.tail])
-	} else {
-		copy(nodes, q.nodes[q.head:])
-		copy(nodes[len(q.nodes)-q.head:], q.nodes[:q.tail])
+	nodes := make([]item, n)
+	// Head and tail of empty queue may point to any slot.
+	if q.cnt > 0 {
+		if q.head < q.tail {
+			copy(nodes, q.nodes[q.head:q.tail])
+		} else {
+			copy(nodes, q.nodes[q.head:])
+			copy(nodes[len(q.nodes)-q.head:], q.nodes[:q.tail])
+		}
 	}
 
 	q.tail = q.cnt % n
@@ -91,6 +123,12 @@ func (q *byteQueue) resize(n int) {
 // will return false if the queue is closed.
 // In that case the []byte is dropped.
 func (q *byteQueue) Add(i []byte) bool {
+	return q.AddWithKey(i, "")
+}
+
+// AddWithKey adds a []byte marked with key to the back of the queue.
+// Items with empty key are never removed by DropOldest and Coalesce.
+func (q *byteQueue) AddWithKey(i []byte, key string) bool {
 	q.mu.Lock()
 	if q.closed {
 		q.mu.Unlock()
@@ -101,10 +139,14 @@ func (q *byteQueue) Add(i []byte) bool {
 		// In Go this resulted in a higher memory usage.
 		q.resize(q.cnt * 2)
 	}
-	q.nodes[q.tail] = i
+	if key != "" {
+		q.keys[key] = append(q.keys[key], q.seq+uint64(q.cnt))
+	}
+	q.nodes[q.tail] = item{data: i, key: key}
 	q.tail = (q.tail + 1) % len(q.nodes)
 	q.size += len(i)
 	q.cnt++
+	q.live++
 	q.cond.Signal()
 	q.mu.Unlock()
 	return true
@@ -116,10 +158,18 @@ func (q *byteQueue) Close() {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 	q.closed = true
+	q.reset()
+	q.cond.Broadcast()
+}
+
+// reset discards all items. Write mutex must be held when calling.
+func (q *byteQueue) reset() {
 	q.cnt = 0
+	q.live = 0
 	q.nodes = nil
 	q.size = 0
-	q.cond.Broadcast()
+	q.keys = nil
+	q.scanned = 0
 }
 
 // CloseRemaining will close the queue and return all entried in the queue.
@@ -130,17 +180,17 @@ func (q *byteQueue) CloseRemaining() [][]byte {
 	if q.closed {
 		return [][]byte{}
 	}
-	rem := make([][]byte, 0, q.cnt)
+	rem := make([][]byte, 0, q.live)
 	for q.cnt > 0 {
 		i := q.nodes[q.head]
 		q.head = (q.head + 1) % len(q.nodes)
 		q.cnt--
-		rem = append(rem, i)
+		if !i.dropped {
+			rem = append(rem, i.data)
+		}
 	}
 	q.closed = true
-	q.cnt = 0
-	q.nodes = nil
-	q.size = 0
+	q.reset()
 	q.cond.Broadcast()
 	return rem
 }
@@ -184,17 +234,108 @@ func (q *byteQueue) Remove() ([]byte, bool) {
 		q.mu.Unlock()
 		return nil, false
 	}
-	i := q.nodes[q.head]
+	it := q.nodes[q.head]
+	q.nodes[q.head] = item{}
+	q.advanceHead()
+	q.live--
+	q.size -= len(it.data)
+	if it.key != "" {
+		q.removeKeyPosition(it.key)
+	}
+	q.trimHead()
+
+	q.mu.Unlock()
+	return it.data, true
+}
+
+// DropOldest removes oldest items added with key until queue size in bytes
+// is not larger than size. Items are dropped in place so other items are
+// not moved.
+func (q *byteQueue) DropOldest(size int) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	excess := q.size - size
+	if excess <= 0 {
+		return 0
+	}
+	removed := 0
+	for ; q.scanned < q.cnt && excess > 0; q.scanned++ {
+		idx := (q.head + q.scanned) % len(q.nodes)
+		it := q.nodes[idx]
+		if it.dropped || it.key == "" {
+			continue
+		}
+		// All previous items with the same key already dropped so this
+		// one is the oldest for key.
+		q.removeKeyPosition(it.key)
+		excess -= len(it.data)
+		q.drop(idx)
+		removed++
+	}
+	q.trimHead()
+	return removed
+}
+
+// Coalesce removes all items added with key except the most recent one.
+func (q *byteQueue) Coalesce(key string) int {
+	if key == "" {
+		return 0
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	positions := q.keys[key]
+	if len(positions) <= 1 {
+		return 0
+	}
+	last := len(positions) - 1
+	for _, pos := range positions[:last] {
+		q.drop((q.head + int(pos-q.seq)) % len(q.nodes))
+	}
+	q.keys[key] = []uint64{positions[last]}
+	q.trimHead()
+	return last
+}
+
+// drop marks item in slot idx as dropped. Write mutex must be held when
+// calling.
+func (q *byteQueue) drop(idx int) {
+	q.size -= len(q.nodes[idx].data)
+	q.nodes[idx] = item{dropped: true}
+	q.live--
+}
+
+// removeKeyPosition forgets position of oldest item with key. Write mutex
+// must be held when calling.
+func (q *byteQueue) removeKeyPosition(key string) {
+	positions := q.keys[key]
+	if len(positions) <= 1 {
+		delete(q.keys, key)
+		return
+	}
+	q.keys[key] = positions[1:]
+}
+
+// Write mutex must be held when calling.
+func (q *byteQueue) advanceHead() {
 	q.head = (q.head + 1) % len(q.nodes)
 	q.cnt--
-	q.size -= len(i)
+	q.seq++
+	if q.scanned > 0 {
+		q.scanned--
+	}
+}
 
+// trimHead frees slots of dropped items at head so head item is never a
+// dropped one and shrinks capacity if possible. Write mutex must be held
+// when calling.
+func (q *byteQueue) trimHead() {
+	for q.cnt > 0 && q.nodes[q.head].dropped {
+		q.nodes[q.head] = item{}
+		q.advanceHead()
+	}
 	if n := len(q.nodes) / 2; n >= q.initCap && q.cnt <= n {
 		q.resize(n)
 	}
-
-	q.mu.Unlock()
-	return i, true
 }
 
 // Return the capacity (without allocations)
@@ -208,7 +349,7 @@ func (q *byteQueue) Cap() int {
 // Return the current length of the queue.
 func (q *byteQueue) Len() int {
 	q.mu.RLock()
-	l := q.cnt
+	l := q.live
 	q.mu.RUnlock()
 	return l
 }
@@ -1,6 +1,7 @@
 package queue
 
 import (
+	"math/rand"
 	"strconv"
 	"testing"
 
@@ -141,3 +142,136 @@ func BenchmarkQueueAddConsume(b *testing.B) {
 	b.StopTimer()
 	q.Close()
 }
+
+func TestByteQueueDropOldest(t *testing.T) {
+	q := New()
+	q.AddWithKey([]byte("1"), "a")
+	q.Add([]byte("2"))
+	q.AddWithKey([]byte("3"), "b")
+	q.AddWithKey([]byte("4"), "a")
+	assert.Equal(t, 0, q.DropOldest(4))
+	assert.Equal(t, 2, q.DropOldest(2))
+	assert.Equal(t, 2, q.Len())
+	assert.Equal(t, 2, q.Size())
+
+	// Items without key are never dropped.
+	assert.Equal(t, 1, q.DropOldest(0))
+	assert.Equal(t, 1, q.Len())
+	i, ok := q.Remove()
+	assert.True(t, ok)
+	assert.Equal(t, []byte("2"), i)
+}
+
+func TestByteQueueCoalesce(t *testing.T) {
+	q := New()
+	q.AddWithKey([]byte("1"), "a")
+	q.AddWithKey([]byte("2"), "b")
+	q.Add([]byte("3"))
+	q.AddWithKey([]byte("4"), "a")
+	q.AddWithKey([]byte("5"), "a")
+	assert.Equal(t, 0, q.Coalesce("b"))
+	assert.Equal(t, 0, q.Coalesce(""))
+	assert.Equal(t, 2, q.Coalesce("a"))
+	assert.Equal(t, 3, q.Len())
+	assert.Equal(t, 3, q.Size())
+	q.Add([]byte("6"))
+	assert.Equal(t, [][]byte{[]byte("2"), []byte("3"), []byte("5"), []byte("6")}, q.CloseRemaining())
+}
+
+func TestByteQueueDropInPlace(t *testing.T) {
+	q := New()
+	q.Add([]byte("1"))
+	q.AddWithKey([]byte("2"), "a")
+	q.AddWithKey([]byte("3"), "b")
+	q.AddWithKey([]byte("4"), "a")
+	assert.Equal(t, 1, q.DropOldest(3))
+	assert.Equal(t, 1, q.DropOldest(2))
+	assert.Equal(t, 0, q.Coalesce("a"))
+	assert.Equal(t, 0, q.Coalesce("b"))
+	// Remaining item of key a still coalesced correctly after drops.
+	q.AddWithKey([]byte("5"), "a")
+	assert.Equal(t, 1, q.Coalesce("a"))
+	assert.Equal(t, [][]byte{[]byte("1"), []byte("5")}, q.CloseRemaining())
+}
+
+func TestByteQueueModel(t *testing.T) {
+	type modelItem struct {
+		data string
+		key  string
+	}
+	rnd := rand.New(rand.NewSource(42))
+	keys := []string{"", "a", "b", "c"}
+	q := New()
+	var model []modelItem
+	size := func() int {
+		s := 0
+		for _, it := range model {
+			s += len(it.data)
+		}
+		return s
+	}
+	for i := 0; i < 10000; i++ {
+		switch op := rnd.Intn(10); {
+		case op < 5:
+			it := modelItem{data: strconv.Itoa(i), key: keys[rnd.Intn(len(keys))]}
+			q.AddWithKey([]byte(it.data), it.key)
+			model = append(model, it)
+		case op < 7:
+			data, ok := q.Remove()
+			assert.Equal(t, len(model) > 0, ok)
+			if len(model) > 0 {
+				assert.Equal(t, model[0].data, string(data))
+				model = model[1:]
+			}
+		case op < 9:
+			limit := rnd.Intn(size() + 1)
+			excess := size() - limit
+			var kept []modelItem
+			removed := 0
+			for _, it := range model {
+				if excess > 0 && it.key != "" {
+					excess -= len(it.data)
+					removed++
+					continue
+				}
+				kept = append(kept, it)
+			}
+			if size()-limit <= 0 {
+				removed = 0
+				kept = model
+			}
+			assert.Equal(t, removed, q.DropOldest(limit))
+			model = kept
+		default:
+			key := keys[1+rnd.Intn(len(keys)-1)]
+			last := -1
+			for j, it := range model {
+				if it.key == key {
+					last = j
+				}
+			}
+			var kept []modelItem
+			removed := 0
+			for j, it := range model {
+				if it.key == key && j != last {
+					removed++
+					continue
+				}
+				kept = append(kept, it)
+			}
+			assert.Equal(t, removed, q.Coalesce(key))
+			model = kept
+		}
+		assert.Equal(t, len(model), q.Len())
+		assert.Equal(t, size(), q.Size())
+	}
+	var expected [][]byte
+	for _, it := range model {
+		expected = append(expected, []byte(it.data))
+	}
+	remaining := q.CloseRemaining()
+	assert.Equal(t, len(expected), len(remaining))
+	for j := range expected {
+		assert.Equal(t, expected[j], remaining[j])
+	}
+}
@@ -0,0 +1,144 @@
+package centrifuge
+
+import (
+	"errors"
+)
+
+// historyIteratorPageSize is a number of publications HistoryIterator
+// loads from engine at once.
+const historyIteratorPageSize = 100
+
+// ErrHistoryChanged returned by HistoryIterator when channel history epoch
+// changed during iteration so publications can not be iterated in order
+// anymore.
+var ErrHistoryChanged = errors.New("history changed during iteration")
+
+// HistoryIterator iterates over channel history publications from oldest
+// to newest loading them from engine page by page. Iterator is not safe
+// for concurrent use.
+//
+//	it := node.HistoryIterator("channel", nil)
+//	for it.Next() {
+//		pub := it.Publication()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type HistoryIterator struct {
+	node     *Node
+	ch       string
+	pageSize int
+
+	since     *RecoveryPosition
+	epoch     string
+	last      uint64
+	page      []*Publication
+	pub       *Publication
+	exhausted bool
+	err       error
+}
+
+// HistoryIterator returns iterator over publications of channel history
+// after position since. Nil since means iteration from history beginning.
+// Iteration position can be saved with Position and passed as since to
+// continue iteration later.
+func (n *Node) HistoryIterator(ch string, since *RecoveryPosition) *HistoryIterator {
+	it := &HistoryIterator{
+		node:     n,
+		ch:       ch,
+		pageSize: historyIteratorPageSize,
+	}
+	if since != nil {
+		position := *since
+		it.since = &position
+		// Check already loaded from saved position publications against
+		// it so changed history and duplicates detected on first page too.
+		it.epoch = position.Epoch
+		it.last = packUint64(position.Seq, position.Gen)
+	}
+	return it
+}
+
+// Next moves iterator to next publication. It returns false when there are
+// no more publications or error happened, Err must be checked after
+// iteration finished.
+func (it *HistoryIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for len(it.page) == 0 {
+		if it.exhausted {
+			it.pub = nil
+			return false
+		}
+		if err := it.load(); err != nil {
+			it.err = err
+			it.pub = nil
+			return false
+		}
+	}
+	it.pub = it.page[0]
+	it.page[0] = nil
+	it.page = it.page[1:]
+	return true
+}
+
+// load loads next page of publications.
+func (it *HistoryIterator) load() error {
+	if it.node.historyManager == nil {
+		it.exhausted = true
+		return nil
+	}
+	actionCount.WithLabelValues("history_iterator").Inc()
+	pubs, position, err := it.node.historyManager.History(it.ch, HistoryFilter{
+		Since: it.since,
+		Limit: it.pageSize,
+	})
+	if err != nil {
+		return err
+	}
+	if it.epoch == "" {
+		it.epoch = position.Epoch
+	} else if it.epoch != position.Epoch {
+		return ErrHistoryChanged
+	}
+	if len(pubs) < it.pageSize {
+		it.exhausted = true
+	}
+	var page []*Publication
+	for _, pub := range pubs {
+		offset := packUint64(pub.Seq, pub.Gen)
+		if offset <= it.last {
+			// Engine returned history from beginning as previous
+			// position is not in history anymore.
+			continue
+		}
+		it.last = offset
+		it.since = &RecoveryPosition{Seq: pub.Seq, Gen: pub.Gen, Epoch: position.Epoch}
+		page = append(page, pub)
+	}
+	if len(page) == 0 {
+		it.exhausted = true
+	}
+	it.page = unexpiredPublications(page)
+	return nil
+}
+
+// Publication returns current publication.
+func (it *HistoryIterator) Publication() *Publication {
+	return it.pub
+}
+
+// Position returns position of current publication in channel history.
+func (it *HistoryIterator) Position() RecoveryPosition {
+	if it.pub == nil {
+		return RecoveryPosition{}
+	}
+	return RecoveryPosition{Seq: it.pub.Seq, Gen: it.pub.Gen, Epoch: it.epoch}
+}
+
+// Err returns error happened during iteration.
+func (it *HistoryIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,113 @@
+package centrifuge
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nodeWithHistory(t *testing.T, size int) *Node {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.HistorySize = size
+	config.HistoryLifetime = 60
+	assert.NoError(t, node.Reload(config))
+	return node
+}
+
+func TestHistoryIterator(t *testing.T) {
+	node := nodeWithHistory(t, 100)
+	for i := 1; i <= 25; i++ {
+		assert.NoError(t, node.Publish("test", []byte(strconv.Itoa(i))))
+	}
+
+	it := node.HistoryIterator("test", nil)
+	it.pageSize = 10
+	var data []string
+	for it.Next() {
+		data = append(data, string(it.Publication().Data))
+	}
+	assert.NoError(t, it.Err())
+	assert.Len(t, data, 25)
+	assert.Equal(t, "1", data[0])
+	assert.Equal(t, "25", data[24])
+	assert.Nil(t, it.Publication())
+}
+
+func TestHistoryIteratorSince(t *testing.T) {
+	node := nodeWithHistory(t, 100)
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, node.Publish("test", []byte(strconv.Itoa(i))))
+	}
+
+	it := node.HistoryIterator("test", nil)
+	it.pageSize = 2
+	assert.True(t, it.Next())
+	assert.True(t, it.Next())
+	position := it.Position()
+	assert.Equal(t, uint32(2), position.Seq)
+
+	// Continue iteration from saved position.
+	it = node.HistoryIterator("test", &position)
+	it.pageSize = 2
+	var data []string
+	for it.Next() {
+		data = append(data, string(it.Publication().Data))
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"3", "4", "5"}, data)
+}
+
+func TestHistoryIteratorEmpty(t *testing.T) {
+	node := nodeWithHistory(t, 100)
+	it := node.HistoryIterator("test", nil)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
+
+func TestHistoryIteratorTruncated(t *testing.T) {
+	node := nodeWithHistory(t, 3)
+	for i := 1; i <= 3; i++ {
+		assert.NoError(t, node.Publish("test", []byte(strconv.Itoa(i))))
+	}
+	it := node.HistoryIterator("test", nil)
+	it.pageSize = 1
+	assert.True(t, it.Next())
+	assert.Equal(t, "1", string(it.Publication().Data))
+	// Publications iterator has not reached yet pushed out of history.
+	for i := 4; i <= 8; i++ {
+		assert.NoError(t, node.Publish("test", []byte(strconv.Itoa(i))))
+	}
+	var data []string
+	for it.Next() {
+		data = append(data, string(it.Publication().Data))
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"6", "7", "8"}, data)
+}
+
+func TestHistoryIteratorSinceEpochChanged(t *testing.T) {
+	node := nodeWithHistory(t, 100)
+	for i := 1; i <= 3; i++ {
+		assert.NoError(t, node.Publish("test", []byte(strconv.Itoa(i))))
+	}
+	it := node.HistoryIterator("test", &RecoveryPosition{Seq: 1, Epoch: "previous"})
+	assert.False(t, it.Next())
+	assert.Equal(t, ErrHistoryChanged, it.Err())
+}
+
+func TestHistoryIteratorSinceSkipsIterated(t *testing.T) {
+	node := nodeWithHistory(t, 100)
+	for i := 1; i <= 3; i++ {
+		assert.NoError(t, node.Publish("test", []byte(strconv.Itoa(i))))
+	}
+	_, latest, err := node.historyManager.History("test", HistoryFilter{})
+	assert.NoError(t, err)
+
+	// Engine returns history from beginning when since position not found
+	// in history, publications up to since must not be iterated again.
+	it := node.HistoryIterator("test", &RecoveryPosition{Seq: 5, Epoch: latest.Epoch})
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
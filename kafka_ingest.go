@@ -0,0 +1,182 @@
+package centrifuge
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// KafkaChannelHeader is a record header which contains channel name to
+// publish record into. It's used by default channel function of KafkaIngest.
+const KafkaChannelHeader = "centrifuge-channel"
+
+// ErrKafkaNoChannel returned by default channel function of KafkaIngest when
+// record contains neither channel header nor key.
+var ErrKafkaNoChannel = errors.New("no channel found in Kafka record")
+
+// KafkaIngestConfig is a config for KafkaIngest.
+type KafkaIngestConfig struct {
+	// Brokers is a list of Kafka broker addresses.
+	Brokers []string
+	// Topics to consume.
+	Topics []string
+	// Group is a Kafka consumer group ID. Offsets committed by ingest are
+	// stored per consumer group so several nodes with the same group share
+	// topic partitions between each other.
+	Group string
+	// ChannelFunc maps record to channel. By default value of
+	// KafkaChannelHeader header used falling back to record key. Records for
+	// which ChannelFunc returns error are committed and skipped.
+	ChannelFunc func(msg *sarama.ConsumerMessage) (string, error)
+	// SaramaConfig allows to customize Kafka client. By default Kafka version
+	// 2.0.0 used (headers require at least 0.11.0) and new consumer groups
+	// start consuming from the oldest offset.
+	SaramaConfig *sarama.Config
+}
+
+// KafkaIngest consumes Kafka topics in consumer group and publishes records
+// into channels. Offset of record is marked for commit only after record
+// successfully published, publish is retried until it succeeds or partition
+// is revoked from this consumer so records of partition keep their order.
+type KafkaIngest struct {
+	node   *Node
+	config KafkaIngestConfig
+	group  sarama.ConsumerGroup
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewKafkaIngest creates KafkaIngest.
+func NewKafkaIngest(n *Node, config KafkaIngestConfig) (*KafkaIngest, error) {
+	if len(config.Brokers) == 0 {
+		return nil, errors.New("no Kafka brokers provided in configuration")
+	}
+	if len(config.Topics) == 0 {
+		return nil, errors.New("no Kafka topics provided in configuration")
+	}
+	if config.Group == "" {
+		return nil, errors.New("no Kafka consumer group provided in configuration")
+	}
+	if config.ChannelFunc == nil {
+		config.ChannelFunc = defaultKafkaChannel
+	}
+	saramaConfig := config.SaramaConfig
+	if saramaConfig == nil {
+		saramaConfig = sarama.NewConfig()
+		saramaConfig.Version = sarama.V2_0_0_0
+		saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+	}
+	saramaConfig.Consumer.Return.Errors = true
+	group, err := sarama.NewConsumerGroup(config.Brokers, config.Group, saramaConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &KafkaIngest{
+		node:   n,
+		config: config,
+		group:  group,
+	}, nil
+}
+
+func defaultKafkaChannel(msg *sarama.ConsumerMessage) (string, error) {
+	for _, h := range msg.Headers {
+		if h != nil && string(h.Key) == KafkaChannelHeader && len(h.Value) > 0 {
+			return string(h.Value), nil
+		}
+	}
+	if len(msg.Key) > 0 {
+		return string(msg.Key), nil
+	}
+	return "", ErrKafkaNoChannel
+}
+
+// Run starts consuming topics in background. Consumer group session is
+// restarted on rebalance.
+func (i *KafkaIngest) Run() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	i.cancel = cancel
+	i.wg.Add(2)
+	go func() {
+		defer i.wg.Done()
+		for err := range i.group.Errors() {
+			i.node.logger.log(newLogEntry(LogLevelError, "Kafka consumer error", map[string]interface{}{"error": err.Error()}))
+		}
+	}()
+	go func() {
+		defer i.wg.Done()
+		for ctx.Err() == nil {
+			err := i.group.Consume(ctx, i.config.Topics, &kafkaIngestHandler{i})
+			if err == sarama.ErrClosedConsumerGroup {
+				return
+			}
+			if err != nil {
+				i.node.logger.log(newLogEntry(LogLevelError, "error consuming Kafka topics", map[string]interface{}{"error": err.Error()}))
+				select {
+				case <-ctx.Done():
+				case <-time.After(300 * time.Millisecond):
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops consuming and commits marked offsets.
+func (i *KafkaIngest) Close() error {
+	if i.cancel != nil {
+		i.cancel()
+	}
+	err := i.group.Close()
+	i.wg.Wait()
+	return err
+}
+
+// kafkaIngestHandler implements sarama.ConsumerGroupHandler.
+type kafkaIngestHandler struct {
+	ingest *KafkaIngest
+}
+
+func (h *kafkaIngestHandler) Setup(sess sarama.ConsumerGroupSession) error {
+	h.ingest.node.logger.log(newLogEntry(LogLevelInfo, "Kafka consumer group session started", map[string]interface{}{"member": sess.MemberID(), "generation": sess.GenerationID()}))
+	return nil
+}
+
+func (h *kafkaIngestHandler) Cleanup(sess sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *kafkaIngestHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		if !h.ingest.process(sess.Context(), msg) {
+			// Partition revoked, next owner consumes record again.
+			return nil
+		}
+		sess.MarkMessage(msg, "")
+	}
+	return nil
+}
+
+// process publishes record retrying until publish succeeds. Returns false
+// if context done before record published.
+func (i *KafkaIngest) process(ctx context.Context, msg *sarama.ConsumerMessage) bool {
+	ch, err := i.config.ChannelFunc(msg)
+	if err != nil {
+		i.node.logger.log(newLogEntry(LogLevelInfo, "skip Kafka record", map[string]interface{}{"topic": msg.Topic, "partition": msg.Partition, "offset": msg.Offset, "error": err.Error()}))
+		return true
+	}
+	for attempt := 0; ; attempt++ {
+		err := i.node.Publish(ch, msg.Value)
+		if err == nil {
+			return true
+		}
+		i.node.logger.log(newLogEntry(LogLevelError, "error publishing Kafka record", map[string]interface{}{"topic": msg.Topic, "partition": msg.Partition, "offset": msg.Offset, "channel": ch, "error": err.Error()}))
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(ingestRetryDelay(attempt)):
+		}
+	}
+}
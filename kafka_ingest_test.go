@@ -0,0 +1,110 @@
+package centrifuge
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyPublishBroker fails first fails publishes.
+type flakyPublishBroker struct {
+	*MemoryEngine
+	mu    sync.Mutex
+	fails int
+	calls int
+}
+
+func (b *flakyPublishBroker) Publish(ch string, pub *Publication, opts *ChannelOptions) error {
+	b.mu.Lock()
+	b.calls++
+	if b.fails > 0 {
+		b.fails--
+		b.mu.Unlock()
+		return errors.New("broker unavailable")
+	}
+	b.mu.Unlock()
+	return b.MemoryEngine.Publish(ch, pub, opts)
+}
+
+type testKafkaSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []int64
+}
+
+func (s *testKafkaSession) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testKafkaSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+
+type testKafkaClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *testKafkaClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.messages
+}
+
+func TestKafkaIngestDefaultChannel(t *testing.T) {
+	ch, err := defaultKafkaChannel(&sarama.ConsumerMessage{
+		Key:     []byte("key"),
+		Headers: []*sarama.RecordHeader{{Key: []byte(KafkaChannelHeader), Value: []byte("header")}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "header", ch)
+	ch, err = defaultKafkaChannel(&sarama.ConsumerMessage{Key: []byte("key")})
+	assert.NoError(t, err)
+	assert.Equal(t, "key", ch)
+	_, err = defaultKafkaChannel(&sarama.ConsumerMessage{})
+	assert.Equal(t, ErrKafkaNoChannel, err)
+}
+
+func TestKafkaIngestConsumeClaim(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	e := testMemoryEngine()
+	node.SetBroker(&flakyPublishBroker{MemoryEngine: e, fails: 2})
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	assert.NoError(t, node.Reload(config))
+
+	ingest := &KafkaIngest{node: node, config: KafkaIngestConfig{ChannelFunc: defaultKafkaChannel}}
+	claim := &testKafkaClaim{messages: make(chan *sarama.ConsumerMessage, 3)}
+	claim.messages <- &sarama.ConsumerMessage{Offset: 1, Key: []byte("test"), Value: []byte(`1`)}
+	claim.messages <- &sarama.ConsumerMessage{Offset: 2, Value: []byte(`skipped`)}
+	claim.messages <- &sarama.ConsumerMessage{Offset: 3, Key: []byte("test"), Value: []byte(`3`)}
+	close(claim.messages)
+	sess := &testKafkaSession{ctx: context.Background()}
+
+	// Publish retried until success, skipped record committed too.
+	assert.NoError(t, (&kafkaIngestHandler{ingest}).ConsumeClaim(sess, claim))
+	assert.Equal(t, []int64{1, 2, 3}, sess.marked)
+	res, err := node.History("test")
+	assert.NoError(t, err)
+	assert.Len(t, res.Publications, 2)
+}
+
+func TestKafkaIngestConsumeClaimRevoked(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	node.SetBroker(&failingPublishBroker{testMemoryEngine()})
+
+	ingest := &KafkaIngest{node: node, config: KafkaIngestConfig{ChannelFunc: defaultKafkaChannel}}
+	claim := &testKafkaClaim{messages: make(chan *sarama.ConsumerMessage, 1)}
+	claim.messages <- &sarama.ConsumerMessage{Offset: 1, Key: []byte("test")}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	sess := &testKafkaSession{ctx: ctx}
+
+	// Record not published is not committed.
+	assert.NoError(t, (&kafkaIngestHandler{ingest}).ConsumeClaim(sess, claim))
+	assert.Len(t, sess.marked, 0)
+}
@@ -0,0 +1,271 @@
+package centrifuge
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/centrifugal/centrifuge/internal/uuid"
+)
+
+// LocalPublicationHandler is called for every publication local
+// subscription receives. Handler of one subscription is called for
+// publications one by one in order node received them.
+type LocalPublicationHandler func(ch string, pub Publication)
+
+// LocalSubscription is a subscription of server-side Go code to channel
+// traffic. It receives the same publications as client subscribed to
+// channel on this node would but without connection and its limits.
+// Publications are dropped if handler falls behind for more than
+// LocalSubscriptionQueueSize publications.
+type LocalSubscription struct {
+	node    *Node
+	id      string
+	pattern string
+	// channel is a broker channel exact subscription is bound to.
+	channel string
+	handler LocalPublicationHandler
+
+	// queueSize limits number of publications waiting for handler.
+	queueSize int
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  []localPublication
+	closed bool
+}
+
+type localPublication struct {
+	ch  string
+	pub *Publication
+}
+
+func (s *LocalSubscription) isPattern() bool {
+	return strings.HasSuffix(s.pattern, "*")
+}
+
+func (s *LocalSubscription) matches(ch string) bool {
+	return strings.HasPrefix(ch, strings.TrimSuffix(s.pattern, "*"))
+}
+
+func (s *LocalSubscription) add(ch string, pub *Publication) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	if s.queueSize > 0 && len(s.queue) >= s.queueSize {
+		// Handler is too slow, oldest publication gives way to new one.
+		s.queue[0] = localPublication{}
+		s.queue = s.queue[1:]
+		localDroppedCount.Inc()
+	}
+	s.queue = append(s.queue, localPublication{ch: ch, pub: pub})
+	s.cond.Signal()
+}
+
+// run calls handler for queued publications so slow handler does not block
+// delivery to other subscribers.
+func (s *LocalSubscription) run() {
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if s.closed {
+			s.mu.Unlock()
+			return
+		}
+		item := s.queue[0]
+		s.queue[0] = localPublication{}
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+		s.handler(item.ch, *item.pub)
+	}
+}
+
+func (s *LocalSubscription) close() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.closed = true
+	s.queue = nil
+	s.cond.Signal()
+	return true
+}
+
+// Unsubscribe stops subscription. Publications not passed to handler yet
+// are dropped.
+func (s *LocalSubscription) Unsubscribe() error {
+	if !s.close() {
+		return nil
+	}
+	return s.node.unsubscribeLocal(s)
+}
+
+// localSubscriptions keeps local subscriptions of node.
+type localSubscriptions struct {
+	mu       sync.RWMutex
+	exact    map[string]map[string]*LocalSubscription
+	patterns map[string]*LocalSubscription
+}
+
+func newLocalSubscriptions() *localSubscriptions {
+	return &localSubscriptions{
+		exact:    make(map[string]map[string]*LocalSubscription),
+		patterns: make(map[string]*LocalSubscription),
+	}
+}
+
+// add adds subscription and returns true if it's first exact subscription
+// on its channel.
+func (l *localSubscriptions) add(s *LocalSubscription) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if s.isPattern() {
+		l.patterns[s.id] = s
+		return false
+	}
+	subs, ok := l.exact[s.channel]
+	if !ok {
+		subs = make(map[string]*LocalSubscription)
+		l.exact[s.channel] = subs
+	}
+	subs[s.id] = s
+	return !ok
+}
+
+// remove removes subscription and returns true if it was last exact
+// subscription on its channel.
+func (l *localSubscriptions) remove(s *LocalSubscription) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if s.isPattern() {
+		delete(l.patterns, s.id)
+		return false
+	}
+	subs, ok := l.exact[s.channel]
+	if !ok {
+		return false
+	}
+	delete(subs, s.id)
+	if len(subs) == 0 {
+		delete(l.exact, s.channel)
+		return true
+	}
+	return false
+}
+
+// subscribed returns true if there are exact subscriptions on broker
+// channel.
+func (l *localSubscriptions) subscribed(ch string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, ok := l.exact[ch]
+	return ok
+}
+
+func (l *localSubscriptions) channels() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	channels := make([]string, 0, len(l.exact))
+	for ch := range l.exact {
+		channels = append(channels, ch)
+	}
+	return channels
+}
+
+// handlePublication passes publication received from broker channel ch of
+// channel name to matching subscriptions.
+func (l *localSubscriptions) handlePublication(ch string, name string, pub *Publication) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, s := range l.exact[ch] {
+		s.add(name, pub)
+	}
+	if ch != name {
+		// Pattern subscriptions do not receive publications of partitioned
+		// channels as they come from several broker channels.
+		return
+	}
+	for _, s := range l.patterns {
+		if s.matches(name) {
+			s.add(name, pub)
+		}
+	}
+}
+
+// SubscribeLocal subscribes handler to channel publications. Pattern is
+// either channel name or channel prefix ending with "*". Subscription on
+// channel name makes node subscribe to channel in broker just like client
+// subscription does so handler receives all channel publications in order.
+// Prefix subscription does not subscribe node to channels in broker: it
+// receives publications of matching channels node is subscribed to because
+// of clients or other local subscriptions.
+func (n *Node) SubscribeLocal(pattern string, handler LocalPublicationHandler) (*LocalSubscription, error) {
+	s := &LocalSubscription{
+		node:    n,
+		id:      uuid.Must(uuid.NewV4()).String(),
+		pattern: pattern,
+		channel: pattern,
+		handler: handler,
+
+		queueSize: n.Config().LocalSubscriptionQueueSize,
+	}
+	s.cond = sync.NewCond(&s.mu)
+
+	actionCount.WithLabelValues("subscribe_local").Inc()
+
+	if s.isPattern() {
+		n.localSubs.add(s)
+		go s.run()
+		return s, nil
+	}
+
+	chOpts, ok := n.ChannelOpts(pattern)
+	if !ok {
+		return nil, ErrNoChannelOptions
+	}
+	s.channel = n.partitions.assign(pattern, s.id, &chOpts)
+	mu := n.subLock(s.channel)
+	mu.Lock()
+	defer mu.Unlock()
+	first := n.localSubs.add(s)
+	if first && n.hub.NumSubscribers(s.channel) == 0 {
+		if err := n.broker.Subscribe(s.channel); err != nil {
+			n.localSubs.remove(s)
+			n.partitions.release(pattern, s.id)
+			return nil, err
+		}
+	}
+	go s.run()
+	return s, nil
+}
+
+func (n *Node) unsubscribeLocal(s *LocalSubscription) error {
+	if s.isPattern() {
+		n.localSubs.remove(s)
+		return nil
+	}
+	n.partitions.release(s.pattern, s.id)
+	mu := n.subLock(s.channel)
+	mu.Lock()
+	defer mu.Unlock()
+	last := n.localSubs.remove(s)
+	if last && n.hub.NumSubscribers(s.channel) == 0 {
+		return n.broker.Unsubscribe(s.channel)
+	}
+	return nil
+}
+
+// subscribedChannels returns broker channels node must be subscribed to.
+func (n *Node) subscribedChannels() []string {
+	channels := n.hub.Channels()
+	for _, ch := range n.localSubs.channels() {
+		if n.hub.NumSubscribers(ch) == 0 {
+			channels = append(channels, ch)
+		}
+	}
+	return channels
+}
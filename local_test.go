@@ -0,0 +1,152 @@
+package centrifuge
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type subscriptionsBroker struct {
+	*MemoryEngine
+	mu       sync.Mutex
+	channels map[string]bool
+}
+
+func (b *subscriptionsBroker) Subscribe(ch string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.channels[ch] = true
+	return nil
+}
+
+func (b *subscriptionsBroker) Unsubscribe(ch string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.channels, ch)
+	return nil
+}
+
+func (b *subscriptionsBroker) subscribed(ch string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.channels[ch]
+}
+
+func nodeWithSubscriptionsBroker() (*Node, *subscriptionsBroker) {
+	node := nodeWithMemoryEngine()
+	broker := &subscriptionsBroker{
+		MemoryEngine: node.broker.(*MemoryEngine),
+		channels:     make(map[string]bool),
+	}
+	node.SetBroker(broker)
+	return node, broker
+}
+
+func waitLocalPublication(t *testing.T, pubs chan string) string {
+	select {
+	case data := <-pubs:
+		return data
+	case <-time.After(time.Second):
+		t.Fatal("publication not received")
+	}
+	return ""
+}
+
+func TestNodeSubscribeLocal(t *testing.T) {
+	node, broker := nodeWithSubscriptionsBroker()
+	pubs := make(chan string, 10)
+	sub, err := node.SubscribeLocal("test", func(ch string, pub Publication) {
+		pubs <- ch + ":" + string(pub.Data)
+	})
+	assert.NoError(t, err)
+	assert.True(t, broker.subscribed("test"))
+
+	assert.NoError(t, node.Publish("test", []byte(`1`)))
+	assert.NoError(t, node.Publish("test", []byte(`2`)))
+	assert.NoError(t, node.Publish("other", []byte(`3`)))
+	assert.Equal(t, "test:1", waitLocalPublication(t, pubs))
+	assert.Equal(t, "test:2", waitLocalPublication(t, pubs))
+
+	assert.NoError(t, sub.Unsubscribe())
+	assert.False(t, broker.subscribed("test"))
+	assert.NoError(t, node.Publish("test", []byte(`4`)))
+	select {
+	case <-pubs:
+		t.Fatal("publication received after unsubscribe")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNodeSubscribeLocalQueueSize(t *testing.T) {
+	node, _ := nodeWithSubscriptionsBroker()
+	config := node.Config()
+	config.LocalSubscriptionQueueSize = 2
+	assert.NoError(t, node.Reload(config))
+
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	pubs := make(chan string, 10)
+	_, err := node.SubscribeLocal("test", func(ch string, pub Publication) {
+		if string(pub.Data) == "1" {
+			close(started)
+			<-unblock
+		}
+		pubs <- string(pub.Data)
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, node.Publish("test", []byte(`1`)))
+	<-started
+	for _, data := range []string{"2", "3", "4"} {
+		assert.NoError(t, node.Publish("test", []byte(data)))
+	}
+	close(unblock)
+	// Oldest queued publication dropped.
+	assert.Equal(t, "1", waitLocalPublication(t, pubs))
+	assert.Equal(t, "3", waitLocalPublication(t, pubs))
+	assert.Equal(t, "4", waitLocalPublication(t, pubs))
+}
+
+func TestNodeSubscribeLocalKeepsBrokerSubscription(t *testing.T) {
+	node, broker := nodeWithSubscriptionsBroker()
+	sub, err := node.SubscribeLocal("test", func(ch string, pub Publication) {})
+	assert.NoError(t, err)
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	subscribeClient(t, client, "test")
+
+	// Client unsubscribed but local subscription still needs channel.
+	assert.NoError(t, node.removeSubscription("test", client))
+	assert.True(t, broker.subscribed("test"))
+	assert.NoError(t, sub.Unsubscribe())
+	assert.False(t, broker.subscribed("test"))
+	assert.NoError(t, sub.Unsubscribe())
+}
+
+func TestNodeSubscribeLocalPattern(t *testing.T) {
+	node, broker := nodeWithSubscriptionsBroker()
+	pubs := make(chan string, 10)
+	sub, err := node.SubscribeLocal("chat*", func(ch string, pub Publication) {
+		pubs <- ch + ":" + string(pub.Data)
+	})
+	assert.NoError(t, err)
+	assert.Len(t, broker.channels, 0)
+
+	assert.NoError(t, node.Publish("test", []byte(`1`)))
+	assert.NoError(t, node.Publish("chat1", []byte(`2`)))
+	assert.Equal(t, "chat1:2", waitLocalPublication(t, pubs))
+	assert.NoError(t, sub.Unsubscribe())
+}
+
+func TestNodeSubscribeLocalNoChannelOptions(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	_, err := node.SubscribeLocal("unknown:test", func(ch string, pub Publication) {})
+	assert.Equal(t, ErrNoChannelOptions, err)
+}
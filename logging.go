@@ -1,5 +1,7 @@
 package centrifuge
 
+import "sync"
+
 // LogLevel describes the chosen log level.
 type LogLevel int
 
@@ -82,6 +84,18 @@ func newLogger(level LogLevel, handler LogHandler) *logger {
 type logger struct {
 	level   LogLevel
 	handler LogHandler
+
+	// errorHandlersMu protects errorHandlers.
+	errorHandlersMu sync.RWMutex
+	// errorHandlers called for every error entry regardless of log level.
+	errorHandlers []LogHandler
+}
+
+// addErrorHandler registers handler called for every error entry.
+func (l *logger) addErrorHandler(handler LogHandler) {
+	l.errorHandlersMu.Lock()
+	defer l.errorHandlersMu.Unlock()
+	l.errorHandlers = append(l.errorHandlers, handler)
 }
 
 // log calls log handler with provided LogEntry.
@@ -89,6 +103,14 @@ func (l *logger) log(entry LogEntry) {
 	if l == nil {
 		return
 	}
+	if entry.Level == LogLevelError {
+		l.errorHandlersMu.RLock()
+		errorHandlers := l.errorHandlers
+		l.errorHandlersMu.RUnlock()
+		for _, h := range errorHandlers {
+			h(entry)
+		}
+	}
 	if l.enabled(entry.Level) {
 		l.handler(entry)
 	}
@@ -96,7 +118,7 @@ func (l *logger) log(entry LogEntry) {
 
 // enabled says whether specified Level enabled or not.
 func (l *logger) enabled(level LogLevel) bool {
-	if l == nil {
+	if l == nil || l.handler == nil {
 		return false
 	}
 	return level >= l.level && l.level != LogLevelNone
@@ -0,0 +1,109 @@
+package centrifuge
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultJoinLeaveBatchInterval = time.Second
+	defaultJoinLeaveBatchSample   = 10
+)
+
+// membershipBatch accumulates join and leave events of channel until it's
+// sent as a single membership push.
+type membershipBatch struct {
+	membership *Membership
+	sample     int
+}
+
+func (b *membershipBatch) addJoin(info *ClientInfo) {
+	b.membership.Joined++
+	if len(b.membership.Joins) < b.sample {
+		b.membership.Joins = append(b.membership.Joins, info)
+	}
+}
+
+func (b *membershipBatch) addLeave(info *ClientInfo) {
+	b.membership.Left++
+	if len(b.membership.Leaves) < b.sample {
+		b.membership.Leaves = append(b.membership.Leaves, info)
+	}
+}
+
+type membershipBatches struct {
+	mu      sync.Mutex
+	batches map[string]*membershipBatch
+}
+
+func newMembershipBatches() *membershipBatches {
+	return &membershipBatches{
+		batches: make(map[string]*membershipBatch),
+	}
+}
+
+// add applies fn to channel batch. Returns true if batch has just been
+// created so caller must schedule sending it.
+func (b *membershipBatches) add(ch string, sample int, fn func(*membershipBatch)) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	batch, ok := b.batches[ch]
+	if !ok {
+		batch = &membershipBatch{
+			membership: &Membership{},
+			sample:     sample,
+		}
+		b.batches[ch] = batch
+	}
+	fn(batch)
+	return !ok
+}
+
+// take removes channel batch and returns accumulated membership summary.
+func (b *membershipBatches) take(ch string) (*Membership, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	batch, ok := b.batches[ch]
+	if !ok {
+		return nil, false
+	}
+	delete(b.batches, ch)
+	return batch.membership, true
+}
+
+// coalesceMembership checks whether join and leave messages of channel
+// must be coalesced taking current number of channel subscribers into
+// account.
+func (n *Node) coalesceMembership(chOpts *ChannelOptions, numSubscribers int) bool {
+	return chOpts.JoinLeaveBatchThreshold > 0 && numSubscribers >= chOpts.JoinLeaveBatchThreshold
+}
+
+// batchMembership adds join or leave event into channel batch.
+func (n *Node) batchMembership(ch string, chOpts *ChannelOptions, fn func(*membershipBatch)) {
+	sample := chOpts.JoinLeaveBatchSample
+	if sample == 0 {
+		sample = defaultJoinLeaveBatchSample
+	}
+	if !n.membership.add(ch, sample, fn) {
+		return
+	}
+	interval := chOpts.JoinLeaveBatchInterval
+	if interval == 0 {
+		interval = defaultJoinLeaveBatchInterval
+	}
+	time.AfterFunc(interval, func() {
+		n.flushMembership(ch)
+	})
+}
+
+// flushMembership sends coalesced membership summary to channel subscribers.
+func (n *Node) flushMembership(ch string) {
+	membership, ok := n.membership.take(ch)
+	if !ok {
+		return
+	}
+	messagesSentCount.WithLabelValues("membership").Inc()
+	if err := n.hub.broadcastMembership(ch, membership); err != nil {
+		n.logger.log(newLogEntry(LogLevelError, "error broadcasting membership", map[string]interface{}{"channel": ch, "error": err.Error()}))
+	}
+}
@@ -0,0 +1,94 @@
+package centrifuge
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMembershipBatches(t *testing.T) {
+	b := newMembershipBatches()
+	assert.True(t, b.add("test", 1, func(batch *membershipBatch) {
+		batch.addJoin(&ClientInfo{User: "1"})
+	}))
+	assert.False(t, b.add("test", 1, func(batch *membershipBatch) {
+		batch.addJoin(&ClientInfo{User: "2"})
+	}))
+	assert.False(t, b.add("test", 1, func(batch *membershipBatch) {
+		batch.addLeave(&ClientInfo{User: "3"})
+	}))
+
+	membership, ok := b.take("test")
+	assert.True(t, ok)
+	assert.Equal(t, uint32(2), membership.Joined)
+	assert.Equal(t, uint32(1), membership.Left)
+	assert.Len(t, membership.Joins, 1)
+	assert.Equal(t, "1", membership.Joins[0].User)
+	assert.Len(t, membership.Leaves, 1)
+
+	_, ok = b.take("test")
+	assert.False(t, ok)
+}
+
+func TestNodeJoinLeaveCoalesced(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	config := node.Config()
+	config.JoinLeave = true
+	config.JoinLeaveBatchThreshold = 2
+	config.JoinLeaveBatchInterval = 50 * time.Millisecond
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	subscribeClient(t, client, "test")
+	// Join of client itself published asynchronously.
+	select {
+	case data := <-transport.sink:
+		assert.True(t, strings.Contains(string(data), `"type":1`))
+	case <-time.After(time.Second):
+		t.Fatal("join not delivered")
+	}
+
+	// Below threshold join is sent as is.
+	assert.NoError(t, node.handleJoin("test", &proto.Join{Info: ClientInfo{User: "1"}}))
+	select {
+	case data := <-transport.sink:
+		assert.True(t, strings.Contains(string(data), `"type":1`))
+	case <-time.After(time.Second):
+		t.Fatal("join not delivered")
+	}
+
+	transport2 := newTestTransport()
+	client2, _ := newClient(newCtx, node, transport2)
+	connectClient(t, client2)
+	subscribeClient(t, client2, "test")
+
+	assert.NoError(t, node.handleJoin("test", &proto.Join{Info: ClientInfo{User: "2"}}))
+	assert.NoError(t, node.handleJoin("test", &proto.Join{Info: ClientInfo{User: "3"}}))
+	assert.NoError(t, node.handleLeave("test", &proto.Leave{Info: ClientInfo{User: "1"}}))
+
+	select {
+	case data := <-transport.sink:
+		s := string(data)
+		assert.True(t, strings.Contains(s, `"type":5`))
+		// Subscription of second client is counted too.
+		assert.True(t, strings.Contains(s, `"joined":3`))
+		assert.True(t, strings.Contains(s, `"left":1`))
+	case <-time.After(time.Second):
+		t.Fatal("membership not delivered")
+	}
+	select {
+	case <-transport.sink:
+		t.Fatal("unexpected push")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
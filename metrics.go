@@ -78,6 +78,13 @@ var (
 		Help:       "Client command duration summary.",
 	}, []string{"method"})
 
+	droppedPublicationsCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "client",
+		Name:      "num_dropped_publications",
+		Help:      "Number of low priority publications dropped due to client queue pressure.",
+	}, []string{"priority"})
+
 	recoverCount = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: metricsNamespace,
 		Subsystem: "client",
@@ -85,6 +92,13 @@ var (
 		Help:      "Count of recover operations.",
 	}, []string{"recovered"})
 
+	upcomingExpirationsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "node",
+		Name:      "upcoming_expirations",
+		Help:      "Number of connections expiring in upcoming minutes.",
+	}, []string{"minute"})
+
 	transportConnectCount = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: metricsNamespace,
 		Subsystem: "transport",
@@ -98,20 +112,102 @@ var (
 		Name:      "messages_sent",
 		Help:      "Number of messages sent over specific transport.",
 	}, []string{"transport"})
+
+	commandDurationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "client",
+		Name:      "command_duration_histogram_seconds",
+		Buckets:   []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		Help:      "Client command duration histogram.",
+	}, []string{"method", "transport"})
+
+	queueDroppedCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "client",
+		Name:      "num_queue_drops",
+		Help:      "Number of messages not queued to client: dropped under queue pressure or rejected by full queue.",
+	}, []string{"transport", "reason"})
+
+	localDroppedCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "node",
+		Name:      "num_local_subscription_drops",
+		Help:      "Number of publications dropped by local subscriptions with full queue.",
+	})
+
+	publicationsCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "node",
+		Name:      "num_publications",
+		Help:      "Number of publications published by node.",
+	}, []string{"namespace"})
+
+	broadcastFanoutCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "node",
+		Name:      "num_broadcast_deliveries",
+		Help:      "Number of deliveries of publications to subscribers of node.",
+	}, []string{"namespace"})
 )
 
-func init() {
-	prometheus.MustRegister(messagesSentCount)
-	prometheus.MustRegister(messagesReceivedCount)
-	prometheus.MustRegister(actionCount)
-	prometheus.MustRegister(numClientsGauge)
-	prometheus.MustRegister(numUsersGauge)
-	prometheus.MustRegister(numChannelsGauge)
-	prometheus.MustRegister(commandDurationSummary)
-	prometheus.MustRegister(replyErrorCount)
-	prometheus.MustRegister(serverDisconnectCount)
-	prometheus.MustRegister(recoverCount)
-	prometheus.MustRegister(transportConnectCount)
-	prometheus.MustRegister(transportMessagesSent)
-	prometheus.MustRegister(buildInfoGauge)
+// metricsNamespaceNone is a namespace label value used when channel
+// namespace labels are off or channel does not belong to namespace.
+const metricsNamespaceNone = "_"
+
+var collectors = []prometheus.Collector{
+	messagesSentCount,
+	messagesReceivedCount,
+	actionCount,
+	numClientsGauge,
+	numUsersGauge,
+	numChannelsGauge,
+	commandDurationSummary,
+	commandDurationHistogram,
+	replyErrorCount,
+	serverDisconnectCount,
+	recoverCount,
+	droppedPublicationsCount,
+	queueDroppedCount,
+	localDroppedCount,
+	publicationsCount,
+	broadcastFanoutCount,
+	transportConnectCount,
+	transportMessagesSent,
+	buildInfoGauge,
+	upcomingExpirationsGauge,
+}
+
+// registerMetrics registers library metrics in registerer. Metrics are
+// shared by all nodes of process so already registered ones are skipped.
+func registerMetrics(registerer prometheus.Registerer) error {
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			if _, ok := err.(prometheus.AlreadyRegisteredError); ok {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// metricsChannelNamespace returns namespace label value for channel.
+// Only namespaces from configuration used so number of label values is
+// bounded.
+func (n *Node) metricsChannelNamespace(ch string) string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if !n.config.MetricsChannelNamespace {
+		return metricsNamespaceNone
+	}
+	name := n.namespaceName(ch)
+	if name == "" {
+		return metricsNamespaceNone
+	}
+	for _, ns := range n.config.Namespaces {
+		if ns.Name == name {
+			return name
+		}
+	}
+	return metricsNamespaceNone
 }
@@ -0,0 +1,87 @@
+package centrifuge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeMetricsRegisterer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c := DefaultConfig
+	c.MetricsRegisterer = registry
+	_, err := New(c)
+	assert.NoError(t, err)
+	// Metrics shared by nodes so second node does not fail.
+	_, err = New(c)
+	assert.NoError(t, err)
+
+	assert.True(t, metricNames(t, registry)["centrifuge_node_num_clients"])
+}
+
+func metricNames(t *testing.T, g prometheus.Gatherer) map[string]bool {
+	families, err := g.Gather()
+	assert.NoError(t, err)
+	names := map[string]bool{}
+	for _, family := range families {
+		names[family.GetName()] = true
+	}
+	return names
+}
+
+func TestNodeMetricsDifferentRegisterers(t *testing.T) {
+	first := prometheus.NewRegistry()
+	c := DefaultConfig
+	c.MetricsRegisterer = first
+	_, err := New(c)
+	assert.NoError(t, err)
+
+	second := prometheus.NewRegistry()
+	c.MetricsRegisterer = second
+	_, err = New(c)
+	assert.NoError(t, err)
+
+	// Collectors shared by nodes so both registries expose the same metrics.
+	firstNames := metricNames(t, first)
+	assert.True(t, firstNames["centrifuge_node_num_clients"])
+	assert.Equal(t, firstNames, metricNames(t, second))
+
+	// Node created with registerer all collectors already registered in
+	// does not fail.
+	_, err = New(c)
+	assert.NoError(t, err)
+}
+
+func TestNodeMetricsChannelNamespace(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.Namespaces = []ChannelNamespace{{Name: "metrics"}}
+	assert.NoError(t, node.Reload(config))
+	assert.Equal(t, metricsNamespaceNone, node.metricsChannelNamespace("metrics:test"))
+
+	config.MetricsChannelNamespace = true
+	assert.NoError(t, node.Reload(config))
+	assert.Equal(t, "metrics", node.metricsChannelNamespace("metrics:test"))
+	assert.Equal(t, metricsNamespaceNone, node.metricsChannelNamespace("unknown:test"))
+	assert.Equal(t, metricsNamespaceNone, node.metricsChannelNamespace("test"))
+
+	before := testutil.ToFloat64(publicationsCount.WithLabelValues("metrics"))
+	assert.NoError(t, node.Publish("metrics:test", []byte(`{}`)))
+	assert.Equal(t, before+1, testutil.ToFloat64(publicationsCount.WithLabelValues("metrics")))
+}
+
+func TestClientMetricsQueueDrops(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientQueueMaxSize = 1
+	assert.NoError(t, node.Reload(config))
+
+	client, _ := newClient(SetCredentials(context.Background(), &Credentials{UserID: "42"}), node, newTestTransport())
+	before := testutil.ToFloat64(queueDroppedCount.WithLabelValues("test_transport", "overflow"))
+	assert.Error(t, client.transportSend(newPreparedReply(&proto.Reply{Result: []byte(`{}`)}, proto.EncodingJSON)))
+	assert.Equal(t, before+1, testutil.ToFloat64(queueDroppedCount.WithLabelValues("test_transport", "overflow")))
+}
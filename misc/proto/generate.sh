@@ -6,3 +6,7 @@ GOGO=1 gomplate -f $GOPATH/src/github.com/centrifugal/centrifuge/misc/proto/clie
 GOGO=1 gomplate -f $GOPATH/src/github.com/centrifugal/centrifuge/misc/proto/client.template.proto > $GOPATH/src/github.com/centrifugal/centrifuge/misc/proto/client.gogo.proto
 
 cd $GOPATH/src/github.com/centrifugal/centrifuge/internal/proto && protoc --proto_path=$GOPATH/src:$GOPATH/src/github.com/centrifugal/centrifuge/vendor:. --gogofaster_out=plugins=grpc:. client.proto
+
+cd $GOPATH/src/github.com/centrifugal/centrifuge/proxyproto && protoc --proto_path=$GOPATH/src:. --gogofaster_out=plugins=grpc:. proxy.proto
+cd $GOPATH/src/github.com/centrifugal/centrifuge/apiproto && protoc --proto_path=$GOPATH/src:. --gogofaster_out=plugins=grpc:. api.proto
+cd $GOPATH/src/github.com/centrifugal/centrifuge/internal/proto/controlproto && protoc --proto_path=$GOPATH/src:$GOPATH/src/github.com/centrifugal/centrifuge/vendor:. --gogofaster_out=. control.proto
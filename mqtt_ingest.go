@@ -0,0 +1,216 @@
+package centrifuge
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// MQTTIngestConfig is a config for MQTTIngest.
+type MQTTIngestConfig struct {
+	// Brokers is a list of MQTT broker URLs, like tcp://localhost:1883.
+	Brokers []string
+	// ClientID is MQTT client ID. It should be stable across restarts when
+	// using QoS 1 or 2 so broker will keep session and queue messages while
+	// ingest disconnected.
+	ClientID string
+	// Username to authenticate with.
+	Username string
+	// Password to authenticate with.
+	Password string
+	// Filters are topic filters to subscribe to with QoS level for each.
+	// Filters can contain + and # wildcards.
+	Filters map[string]byte
+	// CleanSession asks broker to discard session on disconnect. Should be
+	// false to not lose QoS 1 and 2 messages published while ingest is
+	// disconnected.
+	CleanSession bool
+	// ChannelFunc maps message to channel. By default message topic with
+	// slashes replaced by colons used, so message from topic
+	// sensors/room1/temperature delivered into channel
+	// sensors:room1:temperature. Messages for which ChannelFunc returns error
+	// are skipped.
+	ChannelFunc func(msg mqtt.Message) (string, error)
+	// MaxReconnectInterval is a max delay between reconnect attempts, delay
+	// starts from 100 milliseconds and doubles after every failed attempt.
+	// 10 seconds by default.
+	MaxReconnectInterval time.Duration
+}
+
+// MQTTIngest subscribes to MQTT topic filters and publishes messages into
+// channels. QoS 0 messages published once. QoS 1 and 2 messages retried
+// until published and acknowledged to broker only after that, if connection
+// lost before message published broker redelivers it to next session.
+// Connection is restored with exponential backoff.
+type MQTTIngest struct {
+	node    *Node
+	config  MQTTIngestConfig
+	client  mqtt.Client
+	lostCh  chan struct{}
+	closeCh chan struct{}
+	// disconnectCh closed after client disconnected on Close.
+	disconnectCh chan struct{}
+	once         sync.Once
+	wg           sync.WaitGroup
+}
+
+// NewMQTTIngest creates MQTTIngest.
+func NewMQTTIngest(n *Node, config MQTTIngestConfig) (*MQTTIngest, error) {
+	if len(config.Brokers) == 0 {
+		return nil, errors.New("no MQTT brokers provided in configuration")
+	}
+	if len(config.Filters) == 0 {
+		return nil, errors.New("no MQTT topic filters provided in configuration")
+	}
+	for filter, qos := range config.Filters {
+		if qos > 2 {
+			return nil, fmt.Errorf("invalid MQTT QoS %d for topic filter %s", qos, filter)
+		}
+	}
+	if config.ChannelFunc == nil {
+		config.ChannelFunc = defaultMQTTChannel
+	}
+	if config.MaxReconnectInterval == 0 {
+		config.MaxReconnectInterval = ingestMaxRetryDelay
+	}
+	i := &MQTTIngest{
+		node:         n,
+		config:       config,
+		lostCh:       make(chan struct{}, 1),
+		closeCh:      make(chan struct{}),
+		disconnectCh: make(chan struct{}),
+	}
+
+	opts := mqtt.NewClientOptions()
+	for _, broker := range config.Brokers {
+		opts.AddBroker(broker)
+	}
+	opts.SetClientID(config.ClientID)
+	opts.SetUsername(config.Username)
+	opts.SetPassword(config.Password)
+	opts.SetCleanSession(config.CleanSession)
+	// Reconnect handled by ingest itself as client does not retry initial
+	// connect and does not resubscribe.
+	opts.SetAutoReconnect(false)
+	opts.SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+		n.logger.log(newLogEntry(LogLevelError, "MQTT connection lost", map[string]interface{}{"error": err.Error()}))
+		i.connectionLost()
+	})
+	i.client = mqtt.NewClient(opts)
+	return i, nil
+}
+
+func defaultMQTTChannel(msg mqtt.Message) (string, error) {
+	return strings.Replace(msg.Topic(), "/", ":", -1), nil
+}
+
+// Run connects to MQTT broker in background.
+func (i *MQTTIngest) Run() error {
+	i.wg.Add(1)
+	go i.run()
+	return nil
+}
+
+// Close disconnects from MQTT broker.
+func (i *MQTTIngest) Close() error {
+	i.once.Do(func() {
+		close(i.closeCh)
+		i.wg.Wait()
+		// Disconnect before releasing handlers retrying publish so their
+		// messages not acknowledged.
+		i.client.Disconnect(250)
+		close(i.disconnectCh)
+	})
+	return nil
+}
+
+func (i *MQTTIngest) connectionLost() {
+	select {
+	case i.lostCh <- struct{}{}:
+	default:
+	}
+}
+
+func (i *MQTTIngest) reconnectDelay(attempt int) time.Duration {
+	delay := ingestRetryDelay(attempt)
+	if delay > i.config.MaxReconnectInterval {
+		delay = i.config.MaxReconnectInterval
+	}
+	return delay
+}
+
+func (i *MQTTIngest) run() {
+	defer i.wg.Done()
+	for {
+		for attempt := 0; ; attempt++ {
+			err := i.connect()
+			if err == nil {
+				break
+			}
+			i.node.logger.log(newLogEntry(LogLevelError, "error connecting to MQTT broker", map[string]interface{}{"error": err.Error()}))
+			select {
+			case <-i.closeCh:
+				return
+			case <-time.After(i.reconnectDelay(attempt)):
+			}
+		}
+		select {
+		case <-i.closeCh:
+			return
+		case <-i.lostCh:
+		}
+	}
+}
+
+// connect connects to broker and subscribes to topic filters. Subscribe on
+// every connect as broker does not keep subscriptions of clean sessions.
+func (i *MQTTIngest) connect() error {
+	token := i.client.Connect()
+	token.Wait()
+	if err := token.Error(); err != nil {
+		return err
+	}
+	token = i.client.SubscribeMultiple(i.config.Filters, i.handle)
+	token.Wait()
+	if err := token.Error(); err != nil {
+		i.client.Disconnect(0)
+		return err
+	}
+	i.node.logger.log(newLogEntry(LogLevelInfo, "subscribed to MQTT topics", map[string]interface{}{"filters": i.config.Filters}))
+	return nil
+}
+
+// handle called by client for every message, client acknowledges QoS 1 and 2
+// messages to broker after handle returned.
+func (i *MQTTIngest) handle(_ mqtt.Client, msg mqtt.Message) {
+	ch, err := i.config.ChannelFunc(msg)
+	if err != nil {
+		i.node.logger.log(newLogEntry(LogLevelInfo, "skip MQTT message", map[string]interface{}{"topic": msg.Topic(), "error": err.Error()}))
+		return
+	}
+	for attempt := 0; ; attempt++ {
+		err := i.node.Publish(ch, msg.Payload())
+		if err == nil {
+			return
+		}
+		i.node.logger.log(newLogEntry(LogLevelError, "error publishing MQTT message", map[string]interface{}{"topic": msg.Topic(), "qos": msg.Qos(), "channel": ch, "error": err.Error()}))
+		if msg.Qos() == 0 {
+			// At most once delivery, broker does not redeliver message.
+			return
+		}
+		select {
+		case <-i.disconnectCh:
+			return
+		case <-time.After(ingestRetryDelay(attempt)):
+		}
+		if !i.client.IsConnectionOpen() {
+			// Acknowledgement can't be sent anymore, broker redelivers
+			// message after reconnect.
+			return
+		}
+	}
+}
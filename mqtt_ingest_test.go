@@ -0,0 +1,187 @@
+package centrifuge
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/stretchr/testify/assert"
+)
+
+type testMQTTToken struct {
+	err error
+}
+
+func (t *testMQTTToken) Wait() bool                     { return true }
+func (t *testMQTTToken) WaitTimeout(time.Duration) bool { return true }
+func (t *testMQTTToken) Error() error                   { return t.err }
+
+type testMQTTClient struct {
+	mqtt.Client
+	mu           sync.Mutex
+	connectFails int
+	connects     int
+	subscribed   map[string]byte
+	connected    bool
+	connectedCh  chan struct{}
+}
+
+func (c *testMQTTClient) Connect() mqtt.Token {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.connects++
+	if c.connectFails > 0 {
+		c.connectFails--
+		return &testMQTTToken{err: errors.New("connection refused")}
+	}
+	c.connected = true
+	return &testMQTTToken{}
+}
+
+func (c *testMQTTClient) SubscribeMultiple(filters map[string]byte, callback mqtt.MessageHandler) mqtt.Token {
+	c.mu.Lock()
+	c.subscribed = filters
+	c.mu.Unlock()
+	c.connectedCh <- struct{}{}
+	return &testMQTTToken{}
+}
+
+func (c *testMQTTClient) IsConnectionOpen() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.connected
+}
+
+func (c *testMQTTClient) Disconnect(quiesce uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.connected = false
+}
+
+type testMQTTMessage struct {
+	mqtt.Message
+	topic   string
+	qos     byte
+	payload []byte
+}
+
+func (m *testMQTTMessage) Topic() string   { return m.topic }
+func (m *testMQTTMessage) Qos() byte       { return m.qos }
+func (m *testMQTTMessage) Payload() []byte { return m.payload }
+
+func newTestMQTTIngest(node *Node, client *testMQTTClient) *MQTTIngest {
+	return &MQTTIngest{
+		node: node,
+		config: MQTTIngestConfig{
+			Filters:              map[string]byte{"sensors/#": 1},
+			ChannelFunc:          defaultMQTTChannel,
+			MaxReconnectInterval: 200 * time.Millisecond,
+		},
+		client:       client,
+		lostCh:       make(chan struct{}, 1),
+		closeCh:      make(chan struct{}),
+		disconnectCh: make(chan struct{}),
+	}
+}
+
+func TestNewMQTTIngestInvalidQoS(t *testing.T) {
+	_, err := NewMQTTIngest(nodeWithMemoryEngine(), MQTTIngestConfig{
+		Brokers: []string{"tcp://localhost:1883"},
+		Filters: map[string]byte{"sensors/#": 3},
+	})
+	assert.Error(t, err)
+}
+
+func TestMQTTIngestDefaultChannel(t *testing.T) {
+	ch, err := defaultMQTTChannel(&testMQTTMessage{topic: "sensors/room1/temperature"})
+	assert.NoError(t, err)
+	assert.Equal(t, "sensors:room1:temperature", ch)
+}
+
+func TestMQTTIngestReconnect(t *testing.T) {
+	client := &testMQTTClient{connectFails: 2, connectedCh: make(chan struct{}, 2)}
+	ingest := newTestMQTTIngest(nodeWithMemoryEngine(), client)
+	assert.NoError(t, ingest.Run())
+	defer ingest.Close()
+
+	select {
+	case <-client.connectedCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for connect")
+	}
+	client.mu.Lock()
+	assert.Equal(t, 3, client.connects)
+	assert.Equal(t, map[string]byte{"sensors/#": 1}, client.subscribed)
+	client.mu.Unlock()
+
+	// Resubscribe after connection lost.
+	client.Disconnect(0)
+	ingest.connectionLost()
+	select {
+	case <-client.connectedCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for reconnect")
+	}
+}
+
+func TestMQTTIngestReconnectDelay(t *testing.T) {
+	ingest := newTestMQTTIngest(nodeWithMemoryEngine(), &testMQTTClient{})
+	assert.Equal(t, 100*time.Millisecond, ingest.reconnectDelay(0))
+	assert.Equal(t, 200*time.Millisecond, ingest.reconnectDelay(1))
+	assert.Equal(t, 200*time.Millisecond, ingest.reconnectDelay(10))
+}
+
+func TestMQTTIngestHandleRetry(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	broker := &flakyPublishBroker{MemoryEngine: testMemoryEngine(), fails: 2}
+	node.SetBroker(broker)
+	client := &testMQTTClient{connected: true}
+	ingest := newTestMQTTIngest(node, client)
+
+	// QoS 1 message retried until published.
+	ingest.handle(client, &testMQTTMessage{topic: "test", qos: 1, payload: []byte(`1`)})
+	broker.mu.Lock()
+	assert.Equal(t, 3, broker.calls)
+	broker.mu.Unlock()
+}
+
+func TestMQTTIngestHandleQoS0(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	broker := &flakyPublishBroker{MemoryEngine: testMemoryEngine(), fails: 1}
+	node.SetBroker(broker)
+	client := &testMQTTClient{connected: true}
+	ingest := newTestMQTTIngest(node, client)
+
+	// QoS 0 message not retried.
+	ingest.handle(client, &testMQTTMessage{topic: "test", qos: 0, payload: []byte(`1`)})
+	broker.mu.Lock()
+	assert.Equal(t, 1, broker.calls)
+	broker.mu.Unlock()
+}
+
+func TestMQTTIngestHandleConnectionLost(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	node.SetBroker(&failingPublishBroker{testMemoryEngine()})
+	client := &testMQTTClient{connected: true}
+	ingest := newTestMQTTIngest(node, client)
+
+	done := make(chan struct{})
+	go func() {
+		ingest.handle(client, &testMQTTMessage{topic: "test", qos: 1})
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("handle returned while publish failing")
+	case <-time.After(150 * time.Millisecond):
+	}
+	// Handle stops retrying when acknowledgement can't be delivered.
+	client.Disconnect(0)
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for handle")
+	}
+}
@@ -0,0 +1,112 @@
+package centrifuge
+
+import (
+	"sync"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto/controlproto"
+)
+
+type muteKey struct {
+	channel string
+	user    string
+}
+
+// mutedUsers keeps users muted in channels with time mute expires at.
+type mutedUsers struct {
+	mu    sync.RWMutex
+	mutes map[muteKey]time.Time
+}
+
+func newMutedUsers() *mutedUsers {
+	return &mutedUsers{
+		mutes: make(map[muteKey]time.Time),
+	}
+}
+
+func (m *mutedUsers) mute(ch string, user string, expireAt time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	// Mutes are rare so it's fine to clean up expired ones here.
+	for key, keyExpireAt := range m.mutes {
+		if !now.Before(keyExpireAt) {
+			delete(m.mutes, key)
+		}
+	}
+	m.mutes[muteKey{channel: ch, user: user}] = expireAt
+}
+
+func (m *mutedUsers) unmute(ch string, user string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.mutes, muteKey{channel: ch, user: user})
+}
+
+func (m *mutedUsers) muted(ch string, user string, now time.Time) bool {
+	m.mu.RLock()
+	expireAt, ok := m.mutes[muteKey{channel: ch, user: user}]
+	m.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	if now.Before(expireAt) {
+		return true
+	}
+	m.mu.Lock()
+	// Check again as mute could be prolonged meanwhile.
+	if expireAt, ok := m.mutes[muteKey{channel: ch, user: user}]; ok && !now.Before(expireAt) {
+		delete(m.mutes, muteKey{channel: ch, user: user})
+	}
+	m.mu.Unlock()
+	return false
+}
+
+// MuteUser prevents user from publishing into channel during duration.
+// Publish attempts of muted user are rejected with ErrorMuted on all nodes.
+// Node which starts after mute was made does not know about it so
+// application should repeat MuteUser for long mutes if nodes can be
+// restarted meanwhile.
+func (n *Node) MuteUser(ch string, user string, duration time.Duration) error {
+	expireAt := time.Now().Add(duration)
+	n.mutedUsers.mute(ch, user, expireAt)
+	return n.pubMute(ch, user, expireAt.UnixNano()/int64(time.Millisecond))
+}
+
+// UnmuteUser allows muted user to publish into channel again.
+func (n *Node) UnmuteUser(ch string, user string) error {
+	n.mutedUsers.unmute(ch, user)
+	return n.pubMute(ch, user, 0)
+}
+
+// UserMuted returns true if user is currently muted in channel.
+func (n *Node) UserMuted(ch string, user string) bool {
+	return n.mutedUsers.muted(ch, user, time.Now())
+}
+
+// pubMute publishes mute control message to all nodes. Zero expireAt
+// means unmute.
+func (n *Node) pubMute(ch string, user string, expireAt int64) error {
+	mute := &controlproto.Mute{
+		Channel:  ch,
+		User:     user,
+		ExpireAt: expireAt,
+	}
+	params, _ := n.controlEncoder.EncodeMute(mute)
+	cmd := &controlproto.Command{
+		UID:    n.uid,
+		Method: controlproto.MethodTypeMute,
+		Params: params,
+	}
+	return n.publishControl(cmd)
+}
+
+// muteCmd handles mute control command received from other node.
+func (n *Node) muteCmd(cmd *controlproto.Mute) error {
+	if cmd.ExpireAt == 0 {
+		n.mutedUsers.unmute(cmd.Channel, cmd.User)
+		return nil
+	}
+	n.mutedUsers.mute(cmd.Channel, cmd.User, time.Unix(0, cmd.ExpireAt*int64(time.Millisecond)))
+	return nil
+}
@@ -0,0 +1,71 @@
+package centrifuge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/centrifugal/centrifuge/internal/proto/controlproto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMutedUsers(t *testing.T) {
+	m := newMutedUsers()
+	now := time.Now()
+	m.mute("test", "42", now.Add(time.Minute))
+	assert.True(t, m.muted("test", "42", now))
+	assert.False(t, m.muted("test", "43", now))
+	assert.False(t, m.muted("other", "42", now))
+	assert.False(t, m.muted("test", "42", now.Add(2*time.Minute)))
+	assert.Len(t, m.mutes, 0)
+
+	m.mute("test", "42", now.Add(time.Minute))
+	m.unmute("test", "42")
+	assert.False(t, m.muted("test", "42", now))
+}
+
+func TestClientPublishMuted(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.Publish = true
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	assert.NoError(t, node.MuteUser("test", "42", time.Minute))
+	assert.True(t, node.UserMuted("test", "42"))
+
+	publishResp, disconnect := client.publishCmd(&proto.PublishRequest{
+		Channel: "test",
+		Data:    []byte(`{}`),
+	})
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorMuted, publishResp.Error)
+
+	assert.NoError(t, node.UnmuteUser("test", "42"))
+	publishResp, disconnect = client.publishCmd(&proto.PublishRequest{
+		Channel: "test",
+		Data:    []byte(`{}`),
+	})
+	assert.Nil(t, disconnect)
+	assert.Nil(t, publishResp.Error)
+}
+
+func TestNodeMuteControl(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	expireAt := time.Now().Add(time.Minute).UnixNano() / int64(time.Millisecond)
+	params, _ := node.controlEncoder.EncodeMute(&controlproto.Mute{Channel: "test", User: "42", ExpireAt: expireAt})
+	data, _ := node.controlEncoder.EncodeCommand(&controlproto.Command{UID: "other", Method: controlproto.MethodTypeMute, Params: params})
+	assert.NoError(t, node.handleControl(data))
+	assert.True(t, node.UserMuted("test", "42"))
+
+	params, _ = node.controlEncoder.EncodeMute(&controlproto.Mute{Channel: "test", User: "42"})
+	data, _ = node.controlEncoder.EncodeCommand(&controlproto.Command{UID: "other", Method: controlproto.MethodTypeMute, Params: params})
+	assert.NoError(t, node.handleControl(data))
+	assert.False(t, node.UserMuted("test", "42"))
+}
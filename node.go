@@ -36,12 +36,19 @@ type Node struct {
 	historyManager HistoryManager
 	// presenceManager is responsible for presence information management.
 	presenceManager PresenceManager
+	// presenceOccupancyManager set when presence manager reports channel
+	// occupancy changes.
+	presenceOccupancyManager PresenceOccupancyManager
 	// nodes contains registry of known nodes.
 	nodes *nodeRegistry
 	// shutdown is a flag which is only true when node is going to shut down.
 	shutdown bool
 	// shutdownCh is a channel which is closed when node shutdown initiated.
 	shutdownCh chan struct{}
+	// publishes counts publishes in progress to wait for them on shutdown.
+	publishes inflight
+	// interceptors wrap handling of client commands.
+	interceptors []CommandInterceptor
 	// eventHub to manage event handlers binded to node.
 	eventHub *nodeEventHub
 	// logger allows to log throughout library code and proxy log entries to
@@ -57,6 +64,85 @@ type Node struct {
 	metricsMu       sync.Mutex
 	metricsExporter *eagle.Eagle
 	metricsSnapshot *eagle.Metrics
+
+	// pushMu protects push notification providers and device token store.
+	pushMu        sync.RWMutex
+	pushProviders map[string]PushProvider
+	deviceTokens  DeviceTokenStore
+
+	// activity counts publications per channel for admin stats.
+	activity *channelActivity
+
+	// durableSubsMu protects durable subscriber store.
+	durableSubsMu sync.RWMutex
+	durableSubs   DurableSubscriberStore
+
+	// errorReporterMu protects errorReporter.
+	errorReporterMu sync.RWMutex
+	errorReporter   ErrorReporter
+
+	// analytics exports analytics events if enabled.
+	analytics *analyticsExporter
+
+	// discovery resolves peer nodes if set.
+	discovery Discovery
+
+	// oidc authenticates connection tokens with OpenID Connect if set.
+	oidc *OIDCAuthenticator
+
+	// health keeps node readiness state.
+	health *nodeHealth
+
+	// handlerLimiters bound concurrency of channel event handlers.
+	handlerLimiters *handlerLimiters
+
+	// idleChannels tracks activity of channels with IdleTTL option.
+	idleChannels *idleChannels
+
+	// ephemeral coalesces ephemeral publications.
+	ephemeral *pubCoalescer
+
+	// membership coalesces join and leave messages of big channels.
+	membership *membershipBatches
+
+	// bigChannels conflates publications of channels with BigChannel option.
+	bigChannels *pubCoalescer
+
+	// partitions keeps partitions of partitioned channels clients subscribed to.
+	partitions *partitionAssignments
+
+	// mutedUsers keeps users not allowed to publish into channels.
+	mutedUsers *mutedUsers
+	// localSubs keeps subscriptions of server-side code to channels.
+	localSubs *localSubscriptions
+	// expirations keeps expiration deadlines of node connections.
+	expirations *expirationWheel
+
+	// scheduleManager keeps scheduled publications if engine supports it.
+	scheduleManager ScheduleManager
+
+	// readPositionManager keeps read positions if engine supports it.
+	readPositionManager ReadPositionManager
+
+	// roleManager keeps channel roles of users if engine supports it.
+	roleManager RoleManager
+
+	// historyTxManager adds publications into several channel histories
+	// atomically if engine supports it.
+	historyTxManager HistoryTxManager
+
+	// disconnectScheduler keeps scheduled disconnects if engine supports it.
+	disconnectScheduler DisconnectScheduler
+	// rateCounter keeps counters of distributed rate limiters if engine
+	// supports it.
+	rateCounter RateCounter
+	// tokenUseCounter counts connection token uses if engine supports it.
+	tokenUseCounter TokenUseCounter
+	// idempotencyStore remembers idempotency keys of publications if engine
+	// supports it.
+	idempotencyStore IdempotencyStore
+	// surveys keeps surveys sent by node waiting for replies.
+	surveys *surveys
 }
 
 const (
@@ -73,23 +159,45 @@ func New(c Config) (*Node, error) {
 	}
 
 	n := &Node{
-		uid:            uid,
-		nodes:          newNodeRegistry(uid),
-		config:         c,
-		hub:            newHub(),
-		startedAt:      time.Now().Unix(),
-		shutdownCh:     make(chan struct{}),
-		logger:         nil,
-		controlEncoder: controlproto.NewProtobufEncoder(),
-		controlDecoder: controlproto.NewProtobufDecoder(),
-		eventHub:       &nodeEventHub{},
-		subLocks:       subLocks,
+		uid:             uid,
+		nodes:           newNodeRegistry(uid),
+		config:          c,
+		hub:             newHub(),
+		startedAt:       time.Now().Unix(),
+		shutdownCh:      make(chan struct{}),
+		logger:          newLogger(c.LogLevel, c.LogHandler),
+		controlEncoder:  controlproto.NewProtobufEncoder(),
+		controlDecoder:  controlproto.NewProtobufDecoder(),
+		eventHub:        &nodeEventHub{},
+		subLocks:        subLocks,
+		pushProviders:   make(map[string]PushProvider),
+		deviceTokens:    newMemoryDeviceTokenStore(),
+		durableSubs:     newMemoryDurableSubscriberStore(),
+		activity:        newChannelActivity(),
+		health:          newNodeHealth(),
+		handlerLimiters: newHandlerLimiters(),
+		idleChannels:    newIdleChannels(),
+		ephemeral:       newPubCoalescer(),
+		membership:      newMembershipBatches(),
+		bigChannels:     newPubCoalescer(),
+		partitions:      newPartitionAssignments(),
+		mutedUsers:      newMutedUsers(),
+		localSubs:       newLocalSubscriptions(),
+		surveys:         newSurveys(),
+		expirations:     newExpirationWheel(expirationResolution, expirationSlots, time.Now()),
 	}
 
-	if c.LogHandler != nil {
-		n.logger = newLogger(c.LogLevel, c.LogHandler)
+	registerer := c.MetricsRegisterer
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+	if err := registerMetrics(registerer); err != nil {
+		return nil, err
 	}
 
+	n.logger.addErrorHandler(n.reportError)
+	n.logBigChannelWarnings(c)
+
 	e, _ := NewMemoryEngine(n, MemoryEngineConfig{})
 	n.SetEngine(e)
 	return n, nil
@@ -114,11 +222,42 @@ func (n *Node) Config() Config {
 	return c
 }
 
-// SetEngine binds Engine to node.
+// SetEngine binds Engine to node. Engine is used as Broker, HistoryManager
+// and PresenceManager at once together with optional parts it implements.
 func (n *Node) SetEngine(e Engine) {
-	n.broker = e.(Broker)
-	n.historyManager = e.(HistoryManager)
-	n.presenceManager = e.(PresenceManager)
+	n.SetBroker(e)
+	n.SetHistoryManager(e)
+	n.SetPresenceManager(e)
+	if m, ok := e.(ScheduleManager); ok {
+		n.scheduleManager = m
+	} else {
+		n.scheduleManager = nil
+	}
+	if m, ok := e.(RoleManager); ok {
+		n.roleManager = m
+	} else {
+		n.roleManager = nil
+	}
+	if m, ok := e.(DisconnectScheduler); ok {
+		n.disconnectScheduler = m
+	} else {
+		n.disconnectScheduler = nil
+	}
+	if c, ok := e.(RateCounter); ok {
+		n.rateCounter = c
+	} else {
+		n.rateCounter = nil
+	}
+	if c, ok := e.(TokenUseCounter); ok {
+		n.tokenUseCounter = c
+	} else {
+		n.tokenUseCounter = nil
+	}
+	if s, ok := e.(IdempotencyStore); ok {
+		n.idempotencyStore = s
+	} else {
+		n.idempotencyStore = nil
+	}
 }
 
 // SetBroker allows to set Broker implementation to use.
@@ -126,14 +265,34 @@ func (n *Node) SetBroker(b Broker) {
 	n.broker = b
 }
 
-// SetHistoryManager allows to set HistoryManager to use.
+// SetHistoryManager allows to set HistoryManager to use. Optional parts
+// working with channel history streams – HistoryTxManager and
+// ReadPositionManager – are set from m too so they always use the same
+// storage as history. Nil HistoryManager turns history off: publications
+// are not saved and history calls return empty results.
 func (n *Node) SetHistoryManager(m HistoryManager) {
 	n.historyManager = m
+	if m, ok := m.(HistoryTxManager); ok {
+		n.historyTxManager = m
+	} else {
+		n.historyTxManager = nil
+	}
+	if m, ok := m.(ReadPositionManager); ok {
+		n.readPositionManager = m
+	} else {
+		n.readPositionManager = nil
+	}
 }
 
-// SetPresenceManager allows to set PresenceManager to use.
+// SetPresenceManager allows to set PresenceManager to use. Nil
+// PresenceManager turns presence off.
 func (n *Node) SetPresenceManager(m PresenceManager) {
 	n.presenceManager = m
+	if o, ok := m.(PresenceOccupancyManager); ok {
+		n.presenceOccupancyManager = o
+	} else {
+		n.presenceOccupancyManager = nil
+	}
 }
 
 // Hub returns node's Hub.
@@ -146,8 +305,12 @@ func (n *Node) Reload(c Config) error {
 	if err := c.Validate(); err != nil {
 		return err
 	}
+	n.logBigChannelWarnings(c)
 	n.mu.Lock()
 	defer n.mu.Unlock()
+	if err := n.checkPartitionsReload(c); err != nil {
+		return err
+	}
 	n.config = c
 	return nil
 }
@@ -172,6 +335,17 @@ func (n *Node) Run() error {
 	go n.sendNodePing()
 	go n.cleanNodeInfo()
 	go n.updateMetrics()
+	if n.discovery != nil {
+		go n.runDiscovery()
+	}
+	go n.runIdleChannels()
+	go n.runExpirations()
+	if n.scheduleManager != nil || n.disconnectScheduler != nil {
+		go n.runScheduled()
+	}
+	n.health.update(func() {
+		n.health.running = true
+	})
 	return nil
 }
 
@@ -191,7 +365,12 @@ func (n *Node) On() NodeEventHub {
 }
 
 // Shutdown sets shutdown flag to Node so handlers could stop accepting
-// new requests and disconnects clients with shutdown reason.
+// new requests, waits for publishes in progress and disconnects clients
+// with shutdown reason advising them to reconnect. Disconnects spread over
+// Config.ShutdownDrainInterval. Publishes are waited for up to half of
+// context deadline so clients are disconnected even if some publish hangs.
+// Shutdown returns context error if context done before all publishes
+// finished or all clients disconnected.
 func (n *Node) Shutdown(ctx context.Context) error {
 	n.mu.Lock()
 	if n.shutdown {
@@ -201,6 +380,9 @@ func (n *Node) Shutdown(ctx context.Context) error {
 	n.shutdown = true
 	close(n.shutdownCh)
 	n.mu.Unlock()
+	n.health.update(func() {
+		n.health.shutdown = true
+	})
 	if closer, ok := n.broker.(Closer); ok {
 		defer closer.Close(ctx)
 	}
@@ -210,7 +392,15 @@ func (n *Node) Shutdown(ctx context.Context) error {
 	if closer, ok := n.presenceManager.(Closer); ok {
 		defer closer.Close(ctx)
 	}
-	return n.hub.shutdown(ctx)
+	// Publishes are not waited for longer than part of deadline so clients
+	// are always disconnected before context done.
+	publishCtx, cancel := shutdownPublishContext(ctx)
+	defer cancel()
+	publishErr := n.publishes.wait(publishCtx)
+	if err := n.hub.shutdown(ctx, n.Config().ShutdownDrainInterval); err != nil {
+		return err
+	}
+	return publishErr
 }
 
 // NotifyShutdown returns a channel which will be closed on node shutdown.
@@ -227,6 +417,7 @@ func (n *Node) updateGauges() {
 		version = "_"
 	}
 	buildInfoGauge.WithLabelValues(version).Set(1)
+	n.updateExpirationGauges()
 }
 
 func (n *Node) updateMetrics() {
@@ -249,8 +440,12 @@ func (n *Node) initMetrics() error {
 		return nil
 	}
 	metricsSink := make(chan eagle.Metrics)
+	gatherer := prometheus.DefaultGatherer
+	if g, ok := n.config.MetricsRegisterer.(prometheus.Gatherer); ok {
+		gatherer = g
+	}
 	n.metricsExporter = eagle.New(eagle.Config{
-		Gatherer: prometheus.DefaultGatherer,
+		Gatherer: gatherer,
 		Interval: n.config.NodeInfoMetricsAggregateInterval,
 		Sink:     metricsSink,
 	})
@@ -308,7 +503,22 @@ func (n *Node) cleanNodeInfo() {
 // This is a snapshot of state mostly useful for understanding what's going on
 // with system.
 func (n *Node) Channels() ([]string, error) {
-	return n.broker.Channels()
+	channels, err := n.broker.Channels()
+	if err != nil {
+		return nil, err
+	}
+	// Report partitioned channels once under their own name.
+	seen := make(map[string]struct{}, len(channels))
+	result := channels[:0]
+	for _, ch := range channels {
+		name := logicalChannel(ch)
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		result = append(result, name)
+	}
+	return result, nil
 }
 
 // Info contains information about all known server nodes.
@@ -378,6 +588,7 @@ func (n *Node) handleControl(data []byte) error {
 		return nil
 	}
 
+	uid := cmd.UID
 	method := cmd.Method
 	params := cmd.Params
 
@@ -396,13 +607,60 @@ func (n *Node) handleControl(data []byte) error {
 			return err
 		}
 		return n.hub.unsubscribe(cmd.User, cmd.Channel)
+	case controlproto.MethodTypeSubscribe:
+		cmd, err := n.controlDecoder.DecodeSubscribe(params)
+		if err != nil {
+			n.logger.log(newLogEntry(LogLevelError, "error decoding subscribe control params", map[string]interface{}{"error": err.Error()}))
+			return err
+		}
+		return n.hub.subscribe(cmd.User, cmd.Channel)
 	case controlproto.MethodTypeDisconnect:
 		cmd, err := n.controlDecoder.DecodeDisconnect(params)
 		if err != nil {
 			n.logger.log(newLogEntry(LogLevelError, "error decoding disconnect control params", map[string]interface{}{"error": err.Error()}))
 			return err
 		}
-		return n.hub.disconnect(cmd.User, false)
+		return n.hub.disconnect(cmd.User, &Disconnect{
+			Code:      int(cmd.Code),
+			Reason:    cmd.Reason,
+			Reconnect: cmd.Reconnect,
+		}, cmd.Whitelist)
+	case controlproto.MethodTypeSend:
+		cmd, err := n.controlDecoder.DecodeSend(params)
+		if err != nil {
+			n.logger.log(newLogEntry(LogLevelError, "error decoding send control params", map[string]interface{}{"error": err.Error()}))
+			return err
+		}
+		return n.hub.send(cmd.Client, Raw(cmd.Data))
+	case controlproto.MethodTypeMute:
+		cmd, err := n.controlDecoder.DecodeMute(params)
+		if err != nil {
+			n.logger.log(newLogEntry(LogLevelError, "error decoding mute control params", map[string]interface{}{"error": err.Error()}))
+			return err
+		}
+		return n.muteCmd(cmd)
+	case controlproto.MethodTypeRefresh:
+		cmd, err := n.controlDecoder.DecodeRefresh(params)
+		if err != nil {
+			n.logger.log(newLogEntry(LogLevelError, "error decoding refresh control params", map[string]interface{}{"error": err.Error()}))
+			return err
+		}
+		return n.hub.demandRefresh(cmd.User)
+	case controlproto.MethodTypeSurveyRequest:
+		cmd, err := n.controlDecoder.DecodeSurveyRequest(params)
+		if err != nil {
+			n.logger.log(newLogEntry(LogLevelError, "error decoding survey request control params", map[string]interface{}{"error": err.Error()}))
+			return err
+		}
+		return n.surveyRequestCmd(uid, cmd)
+	case controlproto.MethodTypeSurveyResponse:
+		cmd, err := n.controlDecoder.DecodeSurveyResponse(params)
+		if err != nil {
+			n.logger.log(newLogEntry(LogLevelError, "error decoding survey response control params", map[string]interface{}{"error": err.Error()}))
+			return err
+		}
+		n.surveys.handleResult(cmd.ID, uid, SurveyResult{Code: cmd.Code, Data: Raw(cmd.Data)})
+		return nil
 	default:
 		n.logger.log(newLogEntry(LogLevelError, "unknown control message method", map[string]interface{}{"method": method}))
 		return fmt.Errorf("control method not found: %d", method)
@@ -414,15 +672,24 @@ func (n *Node) handleControl(data []byte) error {
 // to all clients on this node currently subscribed to channel.
 func (n *Node) handlePublication(ch string, pub *Publication) error {
 	messagesReceivedCount.WithLabelValues("publication").Inc()
+	// Channel can be a partition of partitioned channel here.
+	name := logicalChannel(ch)
+	n.activity.record(name)
+	n.localSubs.handlePublication(ch, name, pub)
 	numSubscribers := n.hub.NumSubscribers(ch)
 	hasCurrentSubscribers := numSubscribers > 0
 	if !hasCurrentSubscribers {
 		return nil
 	}
-	chOpts, ok := n.ChannelOpts(ch)
+	chOpts, ok := n.ChannelOpts(name)
 	if !ok {
 		return ErrNoChannelOptions
 	}
+	n.touchChannel(name, pub, &chOpts)
+	broadcastFanoutCount.WithLabelValues(n.metricsChannelNamespace(name)).Add(float64(numSubscribers))
+	if chOpts.BigChannel {
+		return n.handleBigChannelPublication(ch, pub, &chOpts)
+	}
 	return n.hub.broadcastPublication(ch, pub, &chOpts)
 }
 
@@ -430,10 +697,19 @@ func (n *Node) handlePublication(ch string, pub *Publication) error {
 // interested local clients subscribed to channel.
 func (n *Node) handleJoin(ch string, join *proto.Join) error {
 	messagesReceivedCount.WithLabelValues("join").Inc()
-	hasCurrentSubscribers := n.hub.NumSubscribers(ch) > 0
+	numSubscribers := n.hub.NumSubscribers(ch)
+	hasCurrentSubscribers := numSubscribers > 0
 	if !hasCurrentSubscribers {
 		return nil
 	}
+	chOpts, ok := n.ChannelOpts(ch)
+	if ok && n.coalesceMembership(&chOpts, numSubscribers) {
+		info := join.Info
+		n.batchMembership(ch, &chOpts, func(b *membershipBatch) {
+			b.addJoin(&info)
+		})
+		return nil
+	}
 	return n.hub.broadcastJoin(ch, join)
 }
 
@@ -441,15 +717,67 @@ func (n *Node) handleJoin(ch string, join *proto.Join) error {
 // interested local clients subscribed to channel.
 func (n *Node) handleLeave(ch string, leave *proto.Leave) error {
 	messagesReceivedCount.WithLabelValues("leave").Inc()
-	hasCurrentSubscribers := n.hub.NumSubscribers(ch) > 0
+	numSubscribers := n.hub.NumSubscribers(ch)
+	hasCurrentSubscribers := numSubscribers > 0
 	if !hasCurrentSubscribers {
 		return nil
 	}
+	chOpts, ok := n.ChannelOpts(ch)
+	if ok && n.coalesceMembership(&chOpts, numSubscribers) {
+		info := leave.Info
+		n.batchMembership(ch, &chOpts, func(b *membershipBatch) {
+			b.addLeave(&info)
+		})
+		return nil
+	}
 	return n.hub.broadcastLeave(ch, leave)
 }
 
+// newPublication creates publication to publish into channel according to
+// channel and publish options.
+func (n *Node) newPublication(ch string, data []byte, info *ClientInfo, chOpts *ChannelOptions, publishOpts *PublishOptions) (*Publication, error) {
+	if chOpts.CloudEvents && publishOpts.op != PublicationOpTombstone {
+		event := publishOpts.cloudEvent
+		if event == nil {
+			var err error
+			event, err = n.newPublicationCloudEvent(ch, data)
+			if err != nil {
+				return nil, err
+			}
+		}
+		encoded, err := event.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		data = encoded
+	}
+
+	pub := &Publication{
+		Data:   data,
+		Info:   info,
+		Op:     publishOpts.op,
+		RefSeq: publishOpts.refSeq,
+		RefGen: publishOpts.refGen,
+		Binary: proto.IsBinaryData(data),
+	}
+	if publishOpts.TTL > 0 {
+		pub.ExpireAt = time.Now().Add(publishOpts.TTL).UnixNano() / int64(time.Millisecond)
+	}
+	if n.analytics != nil {
+		pub.UID = uuid.Must(uuid.NewV4()).String()
+	}
+
+	return pub, nil
+}
+
 func (n *Node) publish(ch string, data []byte, info *ClientInfo, opts ...PublishOption) error {
-	chOpts, ok := n.ChannelOpts(ch)
+	n.publishes.add()
+	defer n.publishes.done()
+
+	chOpts, ok, err := n.channelOptions(ch)
+	if err != nil {
+		return err
+	}
 	if !ok {
 		return ErrNoChannelOptions
 	}
@@ -459,31 +787,88 @@ func (n *Node) publish(ch string, data []byte, info *ClientInfo, opts ...Publish
 		opt(publishOpts)
 	}
 
-	pub := &Publication{
-		Data: data,
-		Info: info,
+	if publishOpts.IdempotencyKey == "" {
+		return n.publishWithOptions(ch, data, info, chOpts, publishOpts)
+	}
+	status, err := n.saveIdempotencyKey(ch, publishOpts.IdempotencyKey)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case IdempotencyKeyPublished:
+		// Publication with the same key already published.
+		return nil
+	case IdempotencyKeyPending:
+		return ErrPublishInProgress
+	}
+	if err := n.publishWithOptions(ch, data, info, chOpts, publishOpts); err != nil {
+		n.removeIdempotencyKey(ch, publishOpts.IdempotencyKey)
+		return err
+	}
+	n.confirmIdempotencyKey(ch, publishOpts.IdempotencyKey)
+	return nil
+}
+
+func (n *Node) publishWithOptions(ch string, data []byte, info *ClientInfo, chOpts ChannelOptions, publishOpts *PublishOptions) error {
+	if publishOpts.op == PublicationOpNew && !publishOpts.PublishAt.IsZero() && publishOpts.PublishAt.After(time.Now()) {
+		return n.schedulePublication(ch, data, info, publishOpts)
+	}
+
+	pub, err := n.newPublication(ch, data, info, &chOpts, publishOpts)
+	if err != nil {
+		return err
 	}
 
 	messagesSentCount.WithLabelValues("publication").Inc()
+	publicationsCount.WithLabelValues(n.metricsChannelNamespace(ch)).Inc()
 
 	// If history enabled for channel we add Publication to history first and then
 	// publish to Broker.
 	if n.historyManager != nil && !publishOpts.SkipHistory && chOpts.HistorySize > 0 && chOpts.HistoryLifetime > 0 {
-		pub, err := n.historyManager.AddHistory(ch, pub, &chOpts)
+		historyPub, err := n.historyManager.AddHistory(ch, pub, &chOpts)
 		if err != nil {
 			return err
 		}
-		if pub != nil {
+		if historyPub != nil {
 			// Publication added to history, no need to handle Publish error here.
 			// In this case we rely on the fact that clients will eventually restore
 			// Publication from history.
-			n.broker.Publish(ch, pub, &chOpts)
+			n.brokerPublish(ch, historyPub, &chOpts)
+			pub = historyPub
 		}
+		n.handlePublished(ch, pub, &chOpts)
 		return nil
 	}
 	// If no history enabled - just publish to Broker. In this case we want to handle
 	// error as message will be lost forever otherwise.
-	return n.broker.Publish(ch, pub, &chOpts)
+	if err := n.brokerPublish(ch, pub, &chOpts); err != nil {
+		return err
+	}
+	n.handlePublished(ch, pub, &chOpts)
+	return nil
+}
+
+// brokerPublish publishes publication into all broker channels of channel.
+func (n *Node) brokerPublish(ch string, pub *Publication, chOpts *ChannelOptions) error {
+	for _, brokerCh := range brokerChannels(ch, chOpts) {
+		if err := n.broker.Publish(brokerCh, pub, chOpts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handlePublished is called after publication successfully published into
+// channel to track channel activity and notify about users who did not
+// receive it in realtime.
+func (n *Node) handlePublished(ch string, pub *Publication, chOpts *ChannelOptions) {
+	n.touchChannel(ch, pub, chOpts)
+	if chOpts.PushFallback {
+		n.pushFallback(ch, pub.Data, chOpts)
+	}
+	if chOpts.InactiveSubscribers {
+		n.reportInactiveSubscribers(ch, pub, chOpts)
+	}
 }
 
 // Publish sends data to all clients subscribed on channel. All running nodes
@@ -509,7 +894,12 @@ func (n *Node) publishJoin(ch string, join *proto.Join, opts *ChannelOptions) er
 		opts = &chOpts
 	}
 	messagesSentCount.WithLabelValues("join").Inc()
-	return n.broker.PublishJoin(ch, join, opts)
+	for _, brokerCh := range brokerChannels(ch, opts) {
+		if err := n.broker.PublishJoin(brokerCh, join, opts); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // publishLeave allows to publish join message into channel when someone subscribes on it
@@ -523,7 +913,12 @@ func (n *Node) publishLeave(ch string, leave *proto.Leave, opts *ChannelOptions)
 		opts = &chOpts
 	}
 	messagesSentCount.WithLabelValues("leave").Inc()
-	return n.broker.PublishLeave(ch, leave, opts)
+	for _, brokerCh := range brokerChannels(ch, opts) {
+		if err := n.broker.PublishLeave(brokerCh, leave, opts); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // publishControl publishes message into control channel so all running
@@ -600,11 +995,31 @@ func (n *Node) pubUnsubscribe(user string, ch string) error {
 	return n.publishControl(cmd)
 }
 
+// pubSubscribe publishes subscribe control message to all nodes – so all
+// nodes could subscribe user connections to channel.
+func (n *Node) pubSubscribe(user string, ch string) error {
+	subscribe := &controlproto.Subscribe{
+		User:    user,
+		Channel: ch,
+	}
+	params, _ := n.controlEncoder.EncodeSubscribe(subscribe)
+	cmd := &controlproto.Command{
+		UID:    n.uid,
+		Method: controlproto.MethodTypeSubscribe,
+		Params: params,
+	}
+	return n.publishControl(cmd)
+}
+
 // pubDisconnect publishes disconnect control message to all nodes – so all
 // nodes could disconnect user from Centrifugo.
-func (n *Node) pubDisconnect(user string, reconnect bool) error {
+func (n *Node) pubDisconnect(user string, d *Disconnect, whitelist []string) error {
 	disconnect := &controlproto.Disconnect{
-		User: user,
+		User:      user,
+		Reconnect: d.Reconnect,
+		Code:      uint32(d.Code),
+		Reason:    d.Reason,
+		Whitelist: whitelist,
 	}
 	params, _ := n.controlEncoder.EncodeDisconnect(disconnect)
 	cmd := &controlproto.Command{
@@ -615,11 +1030,30 @@ func (n *Node) pubDisconnect(user string, reconnect bool) error {
 	return n.publishControl(cmd)
 }
 
+// pubSend publishes message to client to other nodes.
+func (n *Node) pubSend(client string, data Raw) error {
+	send := &controlproto.Send{
+		Client: client,
+		Data:   proto.Raw(data),
+	}
+	params, _ := n.controlEncoder.EncodeSend(send)
+	cmd := &controlproto.Command{
+		UID:    n.uid,
+		Method: controlproto.MethodTypeSend,
+		Params: params,
+	}
+	return n.publishControl(cmd)
+}
+
 // addClient registers authenticated connection in clientConnectionHub
 // this allows to make operations with user connection on demand.
 func (n *Node) addClient(c *Client) error {
 	actionCount.WithLabelValues("add_client").Inc()
-	return n.hub.add(c)
+	config := n.Config()
+	return n.hub.add(c, hubLimits{
+		connections:     config.ClientConnectionLimit,
+		userConnections: config.userConnectionLimit(),
+	})
 }
 
 // removeClient removes client connection from connection registry.
@@ -632,17 +1066,23 @@ func (n *Node) removeClient(c *Client) error {
 // engine and clientSubscriptionHub.
 func (n *Node) addSubscription(ch string, c *Client) error {
 	actionCount.WithLabelValues("add_subscription").Inc()
-	mu := n.subLock(ch)
+	subCh := ch
+	if chOpts, ok := n.ChannelOpts(ch); ok {
+		subCh = n.partitions.assign(ch, c.ID(), &chOpts)
+	}
+	mu := n.subLock(subCh)
 	mu.Lock()
 	defer mu.Unlock()
-	first, err := n.hub.addSub(ch, c)
+	first, err := n.hub.addSub(subCh, c)
 	if err != nil {
+		n.partitions.release(ch, c.ID())
 		return err
 	}
-	if first {
-		err := n.broker.Subscribe(ch)
+	if first && !n.localSubs.subscribed(subCh) {
+		err := n.broker.Subscribe(subCh)
 		if err != nil {
-			n.hub.removeSub(ch, c)
+			n.hub.removeSub(subCh, c)
+			n.partitions.release(ch, c.ID())
 			return err
 		}
 	}
@@ -653,15 +1093,24 @@ func (n *Node) addSubscription(ch string, c *Client) error {
 // from both engine and clientSubscriptionHub.
 func (n *Node) removeSubscription(ch string, c *Client) error {
 	actionCount.WithLabelValues("remove_subscription").Inc()
-	mu := n.subLock(ch)
+	subCh := n.partitions.release(ch, c.ID())
+	mu := n.subLock(subCh)
 	mu.Lock()
 	defer mu.Unlock()
-	empty, err := n.hub.removeSub(ch, c)
+	empty, err := n.hub.removeSub(subCh, c)
 	if err != nil {
 		return err
 	}
 	if empty {
-		return n.broker.Unsubscribe(ch)
+		if chOpts, ok := n.ChannelOpts(ch); ok {
+			// Idle period starts when last subscriber left.
+			n.touchChannel(ch, nil, &chOpts)
+		}
+		if n.localSubs.subscribed(subCh) {
+			// Local subscriptions still need channel publications.
+			return nil
+		}
+		return n.broker.Unsubscribe(subCh)
 	}
 	return nil
 }
@@ -684,15 +1133,56 @@ func (n *Node) Unsubscribe(user string, ch string) error {
 	return n.pubUnsubscribe(user, ch)
 }
 
-// Disconnect allows to close all user connections to Centrifugo.
-func (n *Node) Disconnect(user string, reconnect bool) error {
+// Subscribe subscribes all user connections on all nodes to channel. See
+// Client.Subscribe for details on server-side subscriptions.
+func (n *Node) Subscribe(user string, ch string) error {
+	if _, ok := n.ChannelOpts(ch); !ok {
+		return ErrNoChannelOptions
+	}
+	// First subscribe on this node.
+	err := n.hub.subscribe(user, ch)
+	if err != nil {
+		return err
+	}
+	// Second send subscribe control message to other nodes.
+	return n.pubSubscribe(user, ch)
+}
+
+// Disconnect allows to close all user connections on all nodes. By default
+// DisconnectForceNoReconnect sent to connections, use WithDisconnect or
+// WithReconnect to change it and WithClientWhitelist to keep some of user
+// connections.
+func (n *Node) Disconnect(user string, opts ...DisconnectOption) error {
+	disconnectOpts := &DisconnectOptions{}
+	for _, opt := range opts {
+		opt(disconnectOpts)
+	}
+	disconnect := disconnectOpts.Disconnect
+	if disconnect == nil {
+		disconnect = DisconnectForceNoReconnect
+	}
+	return n.disconnectUser(user, disconnect, disconnectOpts.ClientWhitelist)
+}
+
+// disconnectUser disconnects user connections on all nodes except ones
+// with IDs in whitelist.
+func (n *Node) disconnectUser(user string, disconnect *Disconnect, whitelist []string) error {
 	// first disconnect user from this node
-	err := n.hub.disconnect(user, reconnect)
+	err := n.hub.disconnect(user, disconnect, whitelist)
 	if err != nil {
 		return err
 	}
 	// second send disconnect control message to other nodes
-	return n.pubDisconnect(user, reconnect)
+	return n.pubDisconnect(user, disconnect, whitelist)
+}
+
+// sendToClient sends async message to client connection with specified ID
+// connected to any node.
+func (n *Node) sendToClient(client string, data Raw) error {
+	if n.hub.connection(client) != nil {
+		return n.hub.send(client, data)
+	}
+	return n.pubSend(client, data)
 }
 
 // namespaceName returns namespace name from channel if exists.
@@ -706,10 +1196,34 @@ func (n *Node) namespaceName(ch string) string {
 }
 
 // ChannelOpts returns channel options for channel using current channel config.
+// Errors returned by Config.ChannelOptionsFunc are logged and reported as
+// channel options not found.
 func (n *Node) ChannelOpts(ch string) (ChannelOptions, bool) {
+	chOpts, ok, err := n.channelOptions(ch)
+	if err != nil {
+		n.logger.log(newLogEntry(LogLevelError, "error getting channel options", map[string]interface{}{"channel": ch, "error": err.Error()}))
+		return ChannelOptions{}, false
+	}
+	return chOpts, ok
+}
+
+// channelOptions returns channel options resolved by Config.ChannelOptionsFunc
+// if set or found in namespace configuration.
+func (n *Node) channelOptions(ch string) (ChannelOptions, bool, error) {
 	n.mu.RLock()
-	defer n.mu.RUnlock()
-	return n.config.channelOpts(n.namespaceName(ch))
+	optionsFunc := n.config.ChannelOptionsFunc
+	if optionsFunc == nil {
+		defer n.mu.RUnlock()
+		chOpts, ok := n.config.channelOpts(n.namespaceName(ch))
+		return chOpts, ok, nil
+	}
+	n.mu.RUnlock()
+	// Func called without lock held as it can use node methods.
+	chOpts, ok, err := optionsFunc(ch)
+	if err != nil || !ok {
+		return ChannelOptions{}, false, err
+	}
+	return bigChannelOptions(chOpts), true, nil
 }
 
 // addPresence proxies presence adding to engine.
@@ -721,18 +1235,43 @@ func (n *Node) addPresence(ch string, uid string, info *proto.ClientInfo) error
 	expire := n.config.ClientPresenceExpireInterval
 	n.mu.RUnlock()
 	actionCount.WithLabelValues("add_presence").Inc()
+	if n.eventHub.channelPresenceHandler != nil && n.presenceOccupancyManager != nil {
+		occupied, err := n.presenceOccupancyManager.AddPresenceOccupancy(ch, uid, info, expire)
+		if err == nil && occupied {
+			n.channelPresenceChanged(ch, uid, info.User, true)
+		}
+		return err
+	}
 	return n.presenceManager.AddPresence(ch, uid, info, expire)
 }
 
 // removePresence proxies presence removing to engine.
-func (n *Node) removePresence(ch string, uid string) error {
+func (n *Node) removePresence(ch string, uid string, user string) error {
 	if n.presenceManager == nil {
 		return nil
 	}
 	actionCount.WithLabelValues("remove_presence").Inc()
+	if n.eventHub.channelPresenceHandler != nil && n.presenceOccupancyManager != nil {
+		vacated, err := n.presenceOccupancyManager.RemovePresenceOccupancy(ch, uid)
+		if err == nil && vacated {
+			n.channelPresenceChanged(ch, uid, user, false)
+		}
+		return err
+	}
 	return n.presenceManager.RemovePresence(ch, uid)
 }
 
+// channelPresenceChanged calls ChannelPresenceHandler in separate goroutine.
+func (n *Node) channelPresenceChanged(ch string, client string, user string, occupied bool) {
+	handler := n.eventHub.channelPresenceHandler
+	go handler(context.Background(), ChannelPresenceEvent{
+		Channel:  ch,
+		Occupied: occupied,
+		Client:   client,
+		User:     user,
+	})
+}
+
 // Presence returns a map with information about active clients in channel.
 func (n *Node) Presence(ch string) (map[string]*ClientInfo, error) {
 	if n.presenceManager == nil {
@@ -755,18 +1294,29 @@ func (n *Node) PresenceStats(ch string) (PresenceStats, error) {
 	return n.presenceManager.PresenceStats(ch)
 }
 
-// History returns a slice of last messages published into project channel.
-func (n *Node) History(ch string) ([]*Publication, error) {
+// History returns publications of channel history together with current
+// channel stream position. Without options all publications kept in history
+// returned.
+func (n *Node) History(ch string, opts ...HistoryOption) (HistoryResult, error) {
+	if n.historyManager == nil {
+		return HistoryResult{}, nil
+	}
 	actionCount.WithLabelValues("history").Inc()
-	pubs, _, err := n.historyManager.History(ch, HistoryFilter{
-		Limit: -1,
-		Since: nil,
-	})
-	return pubs, err
+	pubs, position, err := n.historyManager.History(ch, historyFilter(opts))
+	if err != nil {
+		return HistoryResult{}, err
+	}
+	return HistoryResult{
+		Publications: unexpiredPublications(pubs),
+		Position:     position,
+	}, nil
 }
 
 // recoverHistory recovers publications since last UID seen by client.
 func (n *Node) recoverHistory(ch string, since RecoveryPosition) ([]*Publication, RecoveryPosition, error) {
+	if n.historyManager == nil {
+		return nil, RecoveryPosition{}, nil
+	}
 	actionCount.WithLabelValues("recover_history").Inc()
 	return n.historyManager.History(ch, HistoryFilter{
 		Limit: -1,
@@ -776,12 +1326,18 @@ func (n *Node) recoverHistory(ch string, since RecoveryPosition) ([]*Publication
 
 // RemoveHistory removes channel history.
 func (n *Node) RemoveHistory(ch string) error {
+	if n.historyManager == nil {
+		return nil
+	}
 	actionCount.WithLabelValues("remove_history").Inc()
 	return n.historyManager.RemoveHistory(ch)
 }
 
 // currentRecoveryState returns current recovery state for channel.
 func (n *Node) currentRecoveryState(ch string) (RecoveryPosition, error) {
+	if n.historyManager == nil {
+		return RecoveryPosition{}, nil
+	}
 	actionCount.WithLabelValues("history_recovery_state").Inc()
 	_, recoveryPosition, err := n.historyManager.History(ch, HistoryFilter{
 		Limit: 0,
@@ -837,6 +1393,8 @@ type nodeRegistry struct {
 	nodes map[string]controlproto.Node
 	// updates track time we last received ping from node. Used to clean up nodes map.
 	updates map[string]int64
+	// peers resolved by Discovery.
+	peers []Peer
 }
 
 func newNodeRegistry(currentUID string) *nodeRegistry {
@@ -859,6 +1417,20 @@ func (r *nodeRegistry) list() []controlproto.Node {
 	return nodes
 }
 
+func (r *nodeRegistry) listPeers() []Peer {
+	r.mu.RLock()
+	peers := make([]Peer, len(r.peers))
+	copy(peers, r.peers)
+	r.mu.RUnlock()
+	return peers
+}
+
+func (r *nodeRegistry) setPeers(peers []Peer) {
+	r.mu.Lock()
+	r.peers = peers
+	r.mu.Unlock()
+}
+
 func (r *nodeRegistry) get(uid string) controlproto.Node {
 	r.mu.RLock()
 	info := r.nodes[uid]
@@ -921,14 +1493,31 @@ type NodeEventHub interface {
 	ClientConnected(handler ConnectedHandler)
 	// ClientRefresh called when it's time to refresh expiring client connection.
 	ClientRefresh(handler RefreshHandler)
+	// InactiveSubscribers called after publication into channel with
+	// InactiveSubscribers option enabled if some of channel durable
+	// subscribers were offline and missed it.
+	InactiveSubscribers(handler InactiveSubscribersHandler)
+	// ChannelExpired called after channel with IdleTTL option set was
+	// purged because of inactivity.
+	ChannelExpired(handler ChannelExpiredHandler)
+	// Survey called when node receives survey sent with Node.Survey by
+	// this or another node.
+	Survey(handler SurveyHandler)
+	// ChannelPresence called when channel presence becomes occupied or
+	// vacated. Presence manager must implement PresenceOccupancyManager.
+	ChannelPresence(handler ChannelPresenceHandler)
 }
 
 // nodeEventHub can deal with events binded to Node.
 // All its methods are not goroutine-safe.
 type nodeEventHub struct {
-	connectingHandler ConnectingHandler
-	connectedHandler  ConnectedHandler
-	refreshHandler    RefreshHandler
+	connectingHandler          ConnectingHandler
+	connectedHandler           ConnectedHandler
+	refreshHandler             RefreshHandler
+	inactiveSubscribersHandler InactiveSubscribersHandler
+	channelExpiredHandler      ChannelExpiredHandler
+	surveyHandler              SurveyHandler
+	channelPresenceHandler     ChannelPresenceHandler
 }
 
 // ClientConnecting ...
@@ -946,6 +1535,26 @@ func (h *nodeEventHub) ClientRefresh(handler RefreshHandler) {
 	h.refreshHandler = handler
 }
 
+// InactiveSubscribers allows to set InactiveSubscribersHandler.
+func (h *nodeEventHub) InactiveSubscribers(handler InactiveSubscribersHandler) {
+	h.inactiveSubscribersHandler = handler
+}
+
+// ChannelExpired allows to set ChannelExpiredHandler.
+func (h *nodeEventHub) ChannelExpired(handler ChannelExpiredHandler) {
+	h.channelExpiredHandler = handler
+}
+
+// Survey allows to set SurveyHandler.
+func (h *nodeEventHub) Survey(handler SurveyHandler) {
+	h.surveyHandler = handler
+}
+
+// ChannelPresence allows to set ChannelPresenceHandler.
+func (h *nodeEventHub) ChannelPresence(handler ChannelPresenceHandler) {
+	h.channelPresenceHandler = handler
+}
+
 type brokerEventHandler struct {
 	node *Node
 }
@@ -3,6 +3,7 @@ package centrifuge
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -135,6 +136,62 @@ func TestSetConfig(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNodeSetHistoryManager(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	brokerEngine := node.broker.(*MemoryEngine)
+	historyEngine, _ := NewMemoryEngine(node, MemoryEngineConfig{})
+	node.SetHistoryManager(historyEngine)
+	assert.Equal(t, brokerEngine, node.broker)
+	assert.Equal(t, historyEngine, node.historyTxManager)
+	assert.Equal(t, historyEngine, node.readPositionManager)
+
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	assert.NoError(t, node.Reload(config))
+	assert.NoError(t, node.Publish("test", []byte(`{}`)))
+	pubs, _, err := historyEngine.History("test", HistoryFilter{Limit: -1})
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 1)
+	pubs, _, err = brokerEngine.History("test", HistoryFilter{Limit: -1})
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 0)
+}
+
+func TestNodeWithoutHistoryAndPresence(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	node.SetHistoryManager(nil)
+	node.SetPresenceManager(nil)
+	assert.Nil(t, node.historyTxManager)
+	assert.Nil(t, node.readPositionManager)
+
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	config.Presence = true
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	subscribeClient(t, client, "test")
+
+	assert.NoError(t, node.Publish("test", []byte(`{}`)))
+	history, err := node.History("test")
+	pubs := history.Publications
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 0)
+	presence, err := node.Presence("test")
+	assert.NoError(t, err)
+	assert.Len(t, presence, 0)
+	it := node.HistoryIterator("test", nil)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+	assert.NoError(t, node.RemoveHistory("test"))
+}
+
 func TestNodeRegistry(t *testing.T) {
 	registry := newNodeRegistry("node1")
 	nodeInfo1 := controlproto.Node{UID: "node1"}
@@ -192,3 +249,96 @@ func BenchmarkNodePublishWithNoopEngine(b *testing.B) {
 		node.Publish("bench", payload)
 	}
 }
+
+func TestNodeChannelOptionsFunc(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ChannelOptionsFunc = func(ch string) (ChannelOptions, bool, error) {
+		switch ch {
+		case "history":
+			return ChannelOptions{HistorySize: 10, HistoryLifetime: 60}, true, nil
+		case "error":
+			return ChannelOptions{}, false, errors.New("boom")
+		}
+		return ChannelOptions{}, false, nil
+	}
+	assert.NoError(t, node.Reload(config))
+
+	chOpts, ok := node.ChannelOpts("history")
+	assert.True(t, ok)
+	assert.Equal(t, 10, chOpts.HistorySize)
+	_, ok = node.ChannelOpts("unknown")
+	assert.False(t, ok)
+	_, ok = node.ChannelOpts("error")
+	assert.False(t, ok)
+
+	assert.NoError(t, node.Publish("history", []byte(`{}`)))
+	res, err := node.History("history")
+	assert.NoError(t, err)
+	assert.Len(t, res.Publications, 1)
+	assert.Equal(t, ErrNoChannelOptions, node.Publish("unknown", []byte(`{}`)))
+	assert.EqualError(t, node.Publish("error", []byte(`{}`)), "boom")
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	subscribeClient(t, client, "history")
+
+	replies := []*proto.Reply{}
+	rw := testReplyWriter(&replies)
+	disconnect := client.subscribeCmd(&proto.SubscribeRequest{Channel: "error"}, rw)
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorInternal, replies[0].Error)
+
+	resp, disconnect := client.publishCmd(&proto.PublishRequest{Channel: "error", Data: []byte(`{}`)})
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorInternal, resp.Error)
+}
+
+func TestNodeChannelPresenceHandler(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.Presence = true
+	assert.NoError(t, node.Reload(config))
+
+	events := make(chan ChannelPresenceEvent, 10)
+	node.On().ChannelPresence(func(ctx context.Context, e ChannelPresenceEvent) {
+		events <- e
+	})
+	waitEvent := func() ChannelPresenceEvent {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(time.Second):
+			t.Fatal("channel presence event not received")
+		}
+		return ChannelPresenceEvent{}
+	}
+
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client1, _ := newClient(newCtx, node, newTestTransport())
+	connectClient(t, client1)
+	client2, _ := newClient(newCtx, node, newTestTransport())
+	connectClient(t, client2)
+
+	subscribeClient(t, client1, "test")
+	e := waitEvent()
+	assert.Equal(t, "test", e.Channel)
+	assert.True(t, e.Occupied)
+	assert.Equal(t, client1.ID(), e.Client)
+	assert.Equal(t, "42", e.User)
+
+	subscribeClient(t, client2, "test")
+	assert.NoError(t, client1.Unsubscribe("test", false))
+	assert.NoError(t, client2.Unsubscribe("test", false))
+	e = waitEvent()
+	assert.False(t, e.Occupied)
+	assert.Equal(t, client2.ID(), e.Client)
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected event: %#v", e)
+	default:
+	}
+}
@@ -0,0 +1,376 @@
+package centrifuge
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	defaultOIDCUserClaim = "sub"
+	// oidcMinKeysRefreshInterval limits JWKS refreshes caused by tokens
+	// signed with unknown keys.
+	oidcMinKeysRefreshInterval = time.Minute
+)
+
+// ErrOIDCTokenExpired returned by OIDCAuthenticator when the only problem
+// with token is its expiration.
+var ErrOIDCTokenExpired = errors.New("token expired")
+
+// OIDCConfig is a config for OpenID Connect authentication.
+type OIDCConfig struct {
+	// Issuer is an OpenID provider issuer URL, for example
+	// https://accounts.google.com. Provider configuration loaded from
+	// Issuer + "/.well-known/openid-configuration".
+	Issuer string
+	// ClientID is an expected token audience.
+	ClientID string
+	// UserClaim is a claim used as user ID, "sub" by default.
+	UserClaim string
+	// InfoClaims are claims copied into connection info, for example
+	// name and email.
+	InfoClaims []string
+	// CapabilitiesClaim is a claim with list of user capabilities, for
+	// example roles or groups. Space separated string claims like scope
+	// also supported.
+	CapabilitiesClaim string
+	// UserInfo turns on loading claims from provider userinfo endpoint. In
+	// this case connection token must be an access token in JWT format.
+	UserInfo bool
+	// KeysRefreshInterval is how often provider signing keys reloaded, 1
+	// hour by default.
+	KeysRefreshInterval time.Duration
+	// HTTPClient allows to set custom HTTP client.
+	HTTPClient *http.Client
+}
+
+// OIDCClaims contains claims of authenticated connection.
+type OIDCClaims struct {
+	// Subject is a token subject.
+	Subject string
+	// Capabilities extracted from CapabilitiesClaim.
+	Capabilities []string
+	// Claims contain all token claims (and userinfo claims if enabled).
+	Claims map[string]interface{}
+}
+
+type oidcClaimsContextKeyType int
+
+var oidcClaimsContextKey oidcClaimsContextKeyType
+
+// OIDCClaimsFromContext returns claims of connection authenticated with
+// OpenID Connect. Client context passed to ClientConnected handler
+// contains them.
+func OIDCClaimsFromContext(ctx context.Context) (*OIDCClaims, bool) {
+	claims, ok := ctx.Value(oidcClaimsContextKey).(*OIDCClaims)
+	return claims, ok
+}
+
+type oidcProviderConfig struct {
+	Issuer           string `json:"issuer"`
+	JWKSURI          string `json:"jwks_uri"`
+	UserInfoEndpoint string `json:"userinfo_endpoint"`
+}
+
+type oidcJWK struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// OIDCAuthenticator validates OpenID Connect tokens and maps their claims
+// to connection Credentials.
+type OIDCAuthenticator struct {
+	config   OIDCConfig
+	client   *http.Client
+	provider oidcProviderConfig
+
+	mu            sync.RWMutex
+	keys          map[string]interface{}
+	keysUpdatedAt time.Time
+}
+
+// NewOIDCAuthenticator loads provider configuration and signing keys and
+// creates OIDCAuthenticator.
+func NewOIDCAuthenticator(c OIDCConfig) (*OIDCAuthenticator, error) {
+	if c.Issuer == "" {
+		return nil, errors.New("OIDC issuer required")
+	}
+	if c.ClientID == "" {
+		return nil, errors.New("OIDC client ID required")
+	}
+	if c.UserClaim == "" {
+		c.UserClaim = defaultOIDCUserClaim
+	}
+	if c.KeysRefreshInterval == 0 {
+		c.KeysRefreshInterval = time.Hour
+	}
+	client := c.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	a := &OIDCAuthenticator{
+		config: c,
+		client: client,
+	}
+	if err := a.getJSON(context.Background(), strings.TrimSuffix(c.Issuer, "/")+"/.well-known/openid-configuration", "", &a.provider); err != nil {
+		return nil, fmt.Errorf("error loading OIDC provider configuration: %v", err)
+	}
+	if a.provider.Issuer != c.Issuer {
+		return nil, fmt.Errorf("OIDC issuer mismatch: %s", a.provider.Issuer)
+	}
+	if c.UserInfo && a.provider.UserInfoEndpoint == "" {
+		return nil, errors.New("OIDC provider has no userinfo endpoint")
+	}
+	if err := a.refreshKeys(context.Background()); err != nil {
+		return nil, fmt.Errorf("error loading OIDC keys: %v", err)
+	}
+	return a, nil
+}
+
+// SetOIDCAuthenticator sets OIDCAuthenticator to authenticate connection
+// tokens with. It's used when credentials not set by Connecting handler or
+// middleware. Must be called before Node.Run.
+func (n *Node) SetOIDCAuthenticator(a *OIDCAuthenticator) {
+	n.oidc = a
+}
+
+func (a *OIDCAuthenticator) getJSON(ctx context.Context, url string, token string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status: %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// parseJWK converts JSON Web Key into RSA or ECDSA public key.
+func parseJWK(key oidcJWK) (interface{}, error) {
+	switch key.Kty {
+	case "RSA":
+		n, err := decodeBigInt(key.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(key.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch key.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", key.Crv)
+		}
+		x, err := decodeBigInt(key.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(key.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", key.Kty)
+	}
+}
+
+func (a *OIDCAuthenticator) refreshKeys(ctx context.Context) error {
+	var jwks struct {
+		Keys []oidcJWK `json:"keys"`
+	}
+	if err := a.getJSON(ctx, a.provider.JWKSURI, "", &jwks); err != nil {
+		return err
+	}
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		key, err := parseJWK(k)
+		if err != nil {
+			// Skip keys we can't use.
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	a.mu.Lock()
+	a.keys = keys
+	a.keysUpdatedAt = time.Now()
+	a.mu.Unlock()
+	return nil
+}
+
+// key returns signing key with kid reloading keys when they are outdated or
+// key is unknown.
+func (a *OIDCAuthenticator) key(ctx context.Context, kid string) (interface{}, error) {
+	a.mu.RLock()
+	key, ok := a.keys[kid]
+	updatedAt := a.keysUpdatedAt
+	a.mu.RUnlock()
+	sinceUpdate := time.Since(updatedAt)
+	if (ok && sinceUpdate < a.config.KeysRefreshInterval) || (!ok && sinceUpdate < oidcMinKeysRefreshInterval) {
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key: %s", kid)
+		}
+		return key, nil
+	}
+	if err := a.refreshKeys(ctx); err != nil {
+		if ok {
+			// Keep using known key if provider is not available.
+			return key, nil
+		}
+		return nil, err
+	}
+	a.mu.RLock()
+	key, ok = a.keys[kid]
+	a.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+	return key, nil
+}
+
+func hasAudience(claims jwt.MapClaims, aud string) bool {
+	switch v := claims["aud"].(type) {
+	case string:
+		return v == aud
+	case []interface{}:
+		for _, a := range v {
+			if s, ok := a.(string); ok && s == aud {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func claimStrings(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// Authenticate validates token and maps its claims to Credentials.
+func (a *OIDCAuthenticator) Authenticate(ctx context.Context, token string) (*Credentials, *OIDCClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		default:
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		kid, _ := t.Header["kid"].(string)
+		return a.key(ctx, kid)
+	})
+	if err != nil {
+		if validationErr, ok := err.(*jwt.ValidationError); ok && validationErr.Errors == jwt.ValidationErrorExpired {
+			return nil, nil, ErrOIDCTokenExpired
+		}
+		return nil, nil, err
+	}
+	if !claims.VerifyIssuer(a.config.Issuer, true) {
+		return nil, nil, errors.New("invalid token issuer")
+	}
+	if !hasAudience(claims, a.config.ClientID) {
+		return nil, nil, errors.New("invalid token audience")
+	}
+
+	if a.config.UserInfo {
+		userInfo := map[string]interface{}{}
+		if err := a.getJSON(ctx, a.provider.UserInfoEndpoint, token, &userInfo); err != nil {
+			return nil, nil, fmt.Errorf("error loading userinfo: %v", err)
+		}
+		if userInfo["sub"] != claims["sub"] {
+			return nil, nil, errors.New("userinfo subject mismatch")
+		}
+		for k, v := range userInfo {
+			if _, ok := claims[k]; !ok {
+				claims[k] = v
+			}
+		}
+	}
+
+	user, _ := claims[a.config.UserClaim].(string)
+	if user == "" {
+		return nil, nil, fmt.Errorf("no %s claim in token", a.config.UserClaim)
+	}
+	credentials := &Credentials{
+		UserID: user,
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		credentials.ExpireAt = int64(exp)
+	}
+	if len(a.config.InfoClaims) > 0 {
+		info := make(map[string]interface{}, len(a.config.InfoClaims))
+		for _, name := range a.config.InfoClaims {
+			if v, ok := claims[name]; ok {
+				info[name] = v
+			}
+		}
+		encoded, err := json.Marshal(info)
+		if err != nil {
+			return nil, nil, err
+		}
+		credentials.Info = encoded
+	}
+	subject, _ := claims["sub"].(string)
+	oidcClaims := &OIDCClaims{
+		Subject: subject,
+		Claims:  claims,
+	}
+	if a.config.CapabilitiesClaim != "" {
+		oidcClaims.Capabilities = claimStrings(claims[a.config.CapabilitiesClaim])
+	}
+	return credentials, oidcClaims, nil
+}
@@ -0,0 +1,213 @@
+package centrifuge
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type testOIDCProvider struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	userInfo map[string]interface{}
+}
+
+func newTestOIDCProvider(t *testing.T) *testOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	p := &testOIDCProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":            p.server.URL,
+			"jwks_uri":          p.server.URL + "/keys",
+			"userinfo_endpoint": p.server.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "test",
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(p.userInfo)
+	})
+	p.server = httptest.NewServer(mux)
+	return p
+}
+
+func (p *testOIDCProvider) token(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "test"
+	signed, err := token.SignedString(p.key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func (p *testOIDCProvider) claims(exp int64) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":   p.server.URL,
+		"aud":   "app",
+		"sub":   "42",
+		"exp":   exp,
+		"name":  "Alice",
+		"roles": []string{"admin", "editor"},
+	}
+}
+
+func TestOIDCAuthenticate(t *testing.T) {
+	p := newTestOIDCProvider(t)
+	defer p.server.Close()
+
+	a, err := NewOIDCAuthenticator(OIDCConfig{
+		Issuer:            p.server.URL,
+		ClientID:          "app",
+		InfoClaims:        []string{"name"},
+		CapabilitiesClaim: "roles",
+	})
+	assert.NoError(t, err)
+
+	exp := time.Now().Unix() + 3600
+	credentials, claims, err := a.Authenticate(context.Background(), p.token(t, p.claims(exp)))
+	assert.NoError(t, err)
+	assert.Equal(t, "42", credentials.UserID)
+	assert.Equal(t, exp, credentials.ExpireAt)
+	assert.JSONEq(t, `{"name":"Alice"}`, string(credentials.Info))
+	assert.Equal(t, "42", claims.Subject)
+	assert.Equal(t, []string{"admin", "editor"}, claims.Capabilities)
+
+	_, _, err = a.Authenticate(context.Background(), p.token(t, p.claims(time.Now().Unix()-10)))
+	assert.Equal(t, ErrOIDCTokenExpired, err)
+
+	wrongAud := p.claims(exp)
+	wrongAud["aud"] = []string{"other"}
+	_, _, err = a.Authenticate(context.Background(), p.token(t, wrongAud))
+	assert.Error(t, err)
+
+	wrongIss := p.claims(exp)
+	wrongIss["iss"] = "https://example.com"
+	_, _, err = a.Authenticate(context.Background(), p.token(t, wrongIss))
+	assert.Error(t, err)
+
+	hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, p.claims(exp)).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+	_, _, err = a.Authenticate(context.Background(), hmacToken)
+	assert.Error(t, err)
+}
+
+func TestOIDCAuthenticateUserInfo(t *testing.T) {
+	p := newTestOIDCProvider(t)
+	defer p.server.Close()
+	p.userInfo = map[string]interface{}{"sub": "42", "email": "alice@example.com", "scope": "read write"}
+
+	a, err := NewOIDCAuthenticator(OIDCConfig{
+		Issuer:            p.server.URL,
+		ClientID:          "app",
+		UserInfo:          true,
+		InfoClaims:        []string{"email"},
+		CapabilitiesClaim: "scope",
+	})
+	assert.NoError(t, err)
+
+	credentials, claims, err := a.Authenticate(context.Background(), p.token(t, p.claims(time.Now().Unix()+3600)))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"email":"alice@example.com"}`, string(credentials.Info))
+	assert.Equal(t, []string{"read", "write"}, claims.Capabilities)
+
+	p.userInfo["sub"] = "43"
+	_, _, err = a.Authenticate(context.Background(), p.token(t, p.claims(time.Now().Unix()+3600)))
+	assert.Error(t, err)
+}
+
+func TestNewOIDCAuthenticatorIssuerMismatch(t *testing.T) {
+	p := newTestOIDCProvider(t)
+	defer p.server.Close()
+	_, err := NewOIDCAuthenticator(OIDCConfig{Issuer: p.server.URL + "/", ClientID: "app"})
+	assert.Error(t, err)
+}
+
+func TestClientConnectOIDC(t *testing.T) {
+	p := newTestOIDCProvider(t)
+	defer p.server.Close()
+	a, err := NewOIDCAuthenticator(OIDCConfig{
+		Issuer:            p.server.URL,
+		ClientID:          "app",
+		CapabilitiesClaim: "roles",
+	})
+	assert.NoError(t, err)
+
+	node := nodeWithMemoryEngine()
+	node.SetOIDCAuthenticator(a)
+
+	transport := newTestTransport()
+	client, _ := newClient(context.Background(), node, transport)
+	resp, disconnect := client.connectCmd(&proto.ConnectRequest{
+		Token: p.token(t, p.claims(time.Now().Unix()+3600)),
+	})
+	assert.Nil(t, disconnect)
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, "42", client.UserID())
+	assert.True(t, resp.Result.Expires)
+	claims, ok := OIDCClaimsFromContext(client.ctx)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"admin", "editor"}, claims.Capabilities)
+
+	transport = newTestTransport()
+	client, _ = newClient(context.Background(), node, transport)
+	resp, disconnect = client.connectCmd(&proto.ConnectRequest{
+		Token: p.token(t, p.claims(time.Now().Unix()-10)),
+	})
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorTokenExpired, resp.Error)
+
+	_, disconnect = client.connectCmd(&proto.ConnectRequest{Token: "invalid"})
+	assert.Equal(t, DisconnectInvalidToken, disconnect)
+}
+
+func TestClientConnectOIDCTokenReplay(t *testing.T) {
+	p := newTestOIDCProvider(t)
+	defer p.server.Close()
+	a, err := NewOIDCAuthenticator(OIDCConfig{Issuer: p.server.URL, ClientID: "app"})
+	assert.NoError(t, err)
+
+	node := nodeWithMemoryEngine()
+	node.SetOIDCAuthenticator(a)
+	config := node.Config()
+	config.ClientTokenMaxUses = 1
+	assert.NoError(t, node.Reload(config))
+
+	claims := p.claims(time.Now().Unix() + 3600)
+	claims["jti"] = "token-id"
+	token := p.token(t, claims)
+
+	client, _ := newClient(context.Background(), node, newTestTransport())
+	resp, disconnect := client.connectCmd(&proto.ConnectRequest{Token: token})
+	assert.Nil(t, disconnect)
+	assert.Nil(t, resp.Error)
+
+	client, _ = newClient(context.Background(), node, newTestTransport())
+	_, disconnect = client.connectCmd(&proto.ConnectRequest{Token: token})
+	assert.Equal(t, DisconnectInvalidToken, disconnect)
+	assert.Equal(t, "", client.UserID())
+}
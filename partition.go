@@ -0,0 +1,130 @@
+package centrifuge
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// partitionSeparator separates channel name from partition index in names
+// of partition channels. Partition channels are only used internally by
+// hub and broker, clients always see original channel name.
+const partitionSeparator = "\x00"
+
+// partitionChannel returns name of channel partition with index.
+func partitionChannel(ch string, index int) string {
+	return ch + partitionSeparator + strconv.Itoa(index)
+}
+
+// logicalChannel returns channel name visible to clients for both
+// partition and ordinary channels.
+func logicalChannel(ch string) string {
+	if i := strings.Index(ch, partitionSeparator); i >= 0 {
+		return ch[:i]
+	}
+	return ch
+}
+
+// brokerChannels returns channels in broker channel messages must be
+// published to so all channel subscribers receive them.
+func brokerChannels(ch string, chOpts *ChannelOptions) []string {
+	if chOpts.Partitions <= 1 {
+		return []string{ch}
+	}
+	channels := make([]string, chOpts.Partitions)
+	for i := 0; i < chOpts.Partitions; i++ {
+		channels[i] = partitionChannel(ch, i)
+	}
+	return channels
+}
+
+// errPartitionsChanged returned by Node.Reload when number of partitions
+// changed for namespace node has subscribers in. Subscribers stay on old
+// partitions which are not published to after such change.
+var errPartitionsChanged = errors.New("channel partitions can't be changed while namespace has subscribers")
+
+// checkPartitionsReload checks that number of partitions not changed in
+// namespaces with subscribers. Must be called with node mutex held.
+func (n *Node) checkPartitionsReload(c Config) error {
+	for _, ch := range n.hub.Channels() {
+		ns := n.namespaceName(logicalChannel(ch))
+		oldOpts, _ := n.config.channelOpts(ns)
+		newOpts, ok := c.channelOpts(ns)
+		if !ok {
+			continue
+		}
+		if partitionsNum(oldOpts.Partitions) != partitionsNum(newOpts.Partitions) {
+			return errPartitionsChanged
+		}
+	}
+	return nil
+}
+
+// partitionsNum returns number of broker channels used for channel with
+// partitions option.
+func partitionsNum(partitions int) int {
+	if partitions <= 1 {
+		return 1
+	}
+	return partitions
+}
+
+// partitionAssignments remembers partitions clients subscribed to so
+// subscription is removed from the same partition even if number of
+// channel partitions changed on config reload.
+type partitionAssignments struct {
+	mu          sync.Mutex
+	assignments map[string]map[string]string
+}
+
+func newPartitionAssignments() *partitionAssignments {
+	return &partitionAssignments{
+		assignments: make(map[string]map[string]string),
+	}
+}
+
+// assign returns channel client must be subscribed to in hub and broker.
+// Clients are spread over partitions by hash of client ID.
+func (a *partitionAssignments) assign(ch string, clientID string, chOpts *ChannelOptions) string {
+	if chOpts.Partitions <= 1 {
+		return ch
+	}
+	subCh := partitionChannel(ch, consistentIndex(clientID, chOpts.Partitions))
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.assignments[clientID]; !ok {
+		a.assignments[clientID] = make(map[string]string)
+	}
+	a.assignments[clientID][ch] = subCh
+	return subCh
+}
+
+// release returns channel client was subscribed to and forgets it.
+func (a *partitionAssignments) release(ch string, clientID string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	subCh, ok := a.assignments[clientID][ch]
+	if !ok {
+		return ch
+	}
+	delete(a.assignments[clientID], ch)
+	if len(a.assignments[clientID]) == 0 {
+		delete(a.assignments, clientID)
+	}
+	return subCh
+}
+
+// numSubscribers returns number of channel subscribers on node summing
+// subscribers of all channel partitions.
+func (n *Node) numSubscribers(ch string) int {
+	chOpts, ok := n.ChannelOpts(ch)
+	if !ok || chOpts.Partitions <= 1 {
+		return n.hub.NumSubscribers(ch)
+	}
+	total := 0
+	for _, partition := range brokerChannels(ch, &chOpts) {
+		total += n.hub.NumSubscribers(partition)
+	}
+	return total
+}
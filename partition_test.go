@@ -0,0 +1,128 @@
+package centrifuge
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrokerChannels(t *testing.T) {
+	assert.Equal(t, []string{"test"}, brokerChannels("test", &ChannelOptions{}))
+	channels := brokerChannels("test", &ChannelOptions{Partitions: 3})
+	assert.Len(t, channels, 3)
+	for _, ch := range channels {
+		assert.Equal(t, "test", logicalChannel(ch))
+	}
+	assert.Equal(t, "test", logicalChannel("test"))
+}
+
+func TestPartitionedChannel(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	config := node.Config()
+	config.Partitions = 4
+	assert.NoError(t, node.Reload(config))
+
+	var clients []*Client
+	var transports []*testTransport
+	for i := 0; i < 8; i++ {
+		transport := newTestTransport()
+		transport.sink = make(chan []byte, 100)
+		newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+		client, _ := newClient(newCtx, node, transport)
+		connectClient(t, client)
+		subscribeClient(t, client, "test")
+		clients = append(clients, client)
+		transports = append(transports, transport)
+	}
+
+	assert.Equal(t, 0, node.hub.NumSubscribers("test"))
+	assert.Equal(t, 8, node.numSubscribers("test"))
+	assert.True(t, len(node.hub.Channels()) > 1)
+
+	channels, err := node.Channels()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"test"}, channels)
+
+	assert.NoError(t, node.Publish("test", []byte(`{}`)))
+	for _, transport := range transports {
+		select {
+		case data := <-transport.sink:
+			assert.True(t, strings.Contains(string(data), `"channel":"test"`))
+		case <-time.After(time.Second):
+			t.Fatal("publication not delivered")
+		}
+		select {
+		case <-transport.sink:
+			t.Fatal("publication delivered twice")
+		default:
+		}
+	}
+
+	// Partitions can't be changed while channel has subscribers.
+	config.Partitions = 2
+	assert.Equal(t, errPartitionsChanged, node.Reload(config))
+	config.Partitions = 4
+	config.HistorySize = 10
+	assert.NoError(t, node.Reload(config))
+	for _, client := range clients {
+		assert.NoError(t, client.unsubscribe("test"))
+	}
+	assert.Len(t, node.hub.Channels(), 0)
+	assert.Len(t, node.partitions.assignments, 0)
+}
+
+func TestClientSubscribePartitionChannel(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	transport := newTestTransport()
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	replies := []*proto.Reply{}
+	rw := testReplyWriter(&replies)
+	disconnect := client.subscribeCmd(&proto.SubscribeRequest{
+		Channel: partitionChannel("test", 0),
+	}, rw)
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorPermissionDenied, replies[0].Error)
+}
+
+func TestRedisEnginePublishOnHistoryAddPartitions(t *testing.T) {
+	n, _ := New(Config{})
+	e, err := NewRedisEngine(n, RedisEngineConfig{
+		PublishOnHistoryAdd: true,
+		Shards: []RedisShardConfig{
+			{Host: "127.0.0.1", Port: 6379},
+			{Host: "127.0.0.1", Port: 6380},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, e.publishOnHistoryAdd("test", &ChannelOptions{}))
+	// Partitions spread over shards can't be published by history script.
+	opts := &ChannelOptions{Partitions: 16}
+	sameShard := true
+	for _, ch := range brokerChannels("test", opts) {
+		if e.getShard(ch) != e.getShard("test") {
+			sameShard = false
+		}
+	}
+	assert.Equal(t, sameShard, e.publishOnHistoryAdd("test", opts))
+	assert.False(t, sameShard)
+
+	e.config.PublishOnHistoryAdd = false
+	assert.False(t, e.publishOnHistoryAdd("test", &ChannelOptions{}))
+
+	// Script publishes into all partitions of channel with single shard.
+	e, err = NewRedisEngine(n, RedisEngineConfig{
+		PublishOnHistoryAdd: true,
+		Shards:              []RedisShardConfig{{Host: "127.0.0.1", Port: 6379}},
+	})
+	assert.NoError(t, err)
+	assert.True(t, e.publishOnHistoryAdd("test", opts))
+}
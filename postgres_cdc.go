@@ -0,0 +1,376 @@
+package centrifuge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx"
+)
+
+// Logical decoding output plugins supported by PostgresCDCIngest.
+const (
+	// PostgresCDCPluginPgoutput is a built-in output plugin available since
+	// Postgres 10. It streams changes of tables included into publication.
+	PostgresCDCPluginPgoutput = "pgoutput"
+	// PostgresCDCPluginWal2JSON is a wal2json output plugin which must be
+	// installed into Postgres.
+	PostgresCDCPluginWal2JSON = "wal2json"
+)
+
+const (
+	defaultPostgresCDCSlot           = "centrifuge"
+	defaultPostgresCDCPublication    = "centrifuge"
+	defaultPostgresCDCStatusInterval = 10 * time.Second
+)
+
+// PostgresCDCChange is a single row change published into channels as JSON.
+type PostgresCDCChange struct {
+	// Kind is insert, update, delete or truncate.
+	Kind   string `json:"kind"`
+	Schema string `json:"schema"`
+	Table  string `json:"table"`
+	// Key contains primary key columns of row, empty for truncate.
+	Key map[string]interface{} `json:"key,omitempty"`
+	// Data contains new row values, empty for delete and truncate.
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+// PostgresCDCIngestConfig is a config for PostgresCDCIngest.
+type PostgresCDCIngestConfig struct {
+	// ConnString is a Postgres connection string, user must have REPLICATION
+	// attribute.
+	ConnString string
+	// Plugin is a logical decoding output plugin, PostgresCDCPluginPgoutput
+	// by default.
+	Plugin string
+	// Slot is a name of logical replication slot, "centrifuge" by default.
+	// Slot created with Plugin if not exists.
+	Slot string
+	// Publication is a name of publication to stream changes of when using
+	// pgoutput plugin, "centrifuge" by default. Publication must be created
+	// in advance, for example with CREATE PUBLICATION centrifuge FOR ALL
+	// TABLES.
+	Publication string
+	// Tables to stream changes from like "public.users", all tables of
+	// stream if empty.
+	Tables []string
+	// KeyColumns are primary key columns of tables used when replication
+	// stream does not contain information about key: for inserts decoded
+	// with wal2json and for tables without replica identity key with
+	// pgoutput. Keys are tables like "public.users", column "id" is used by
+	// default.
+	KeyColumns map[string][]string
+	// ChannelFunc returns channels to publish change into, returning no
+	// channels skips change. By default change published into table channel
+	// like "cdc:public.users" and row channel like "cdc:public.users.42" for
+	// tables with single key column.
+	ChannelFunc func(change *PostgresCDCChange) []string
+	// StatusInterval is how often confirmed position sent to Postgres, 10
+	// seconds by default.
+	StatusInterval time.Duration
+}
+
+// postgresCDCDecoder decodes WAL messages of output plugin into changes.
+// Non-zero position returned when message completes transaction and stream
+// can be confirmed up to it.
+type postgresCDCDecoder interface {
+	decode(msg *pgx.WalMessage) ([]*PostgresCDCChange, uint64, error)
+}
+
+// PostgresCDCIngest streams row changes from Postgres logical replication
+// slot and publishes them into channels. Position in slot confirmed only
+// after all changes of transaction published so changes are redelivered
+// after reconnect.
+type PostgresCDCIngest struct {
+	node       *Node
+	config     PostgresCDCIngestConfig
+	connConfig pgx.ConnConfig
+	tables     map[string]struct{}
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+// NewPostgresCDCIngest creates PostgresCDCIngest.
+func NewPostgresCDCIngest(n *Node, config PostgresCDCIngestConfig) (*PostgresCDCIngest, error) {
+	connConfig, err := pgx.ParseConnectionString(config.ConnString)
+	if err != nil {
+		return nil, err
+	}
+	if config.Plugin == "" {
+		config.Plugin = PostgresCDCPluginPgoutput
+	}
+	if config.Plugin != PostgresCDCPluginPgoutput && config.Plugin != PostgresCDCPluginWal2JSON {
+		return nil, fmt.Errorf("unsupported Postgres output plugin: %s", config.Plugin)
+	}
+	if config.Slot == "" {
+		config.Slot = defaultPostgresCDCSlot
+	}
+	if config.Publication == "" {
+		config.Publication = defaultPostgresCDCPublication
+	}
+	if config.ChannelFunc == nil {
+		config.ChannelFunc = defaultPostgresCDCChannels
+	}
+	if config.StatusInterval == 0 {
+		config.StatusInterval = defaultPostgresCDCStatusInterval
+	}
+	i := &PostgresCDCIngest{
+		node:       n,
+		config:     config,
+		connConfig: connConfig,
+	}
+	if len(config.Tables) > 0 {
+		i.tables = make(map[string]struct{}, len(config.Tables))
+		for _, table := range config.Tables {
+			i.tables[table] = struct{}{}
+		}
+	}
+	return i, nil
+}
+
+func defaultPostgresCDCChannels(change *PostgresCDCChange) []string {
+	table := "cdc:" + change.Schema + "." + change.Table
+	channels := []string{table}
+	if len(change.Key) == 1 {
+		for _, v := range change.Key {
+			channels = append(channels, fmt.Sprintf("%s.%v", table, v))
+		}
+	}
+	return channels
+}
+
+// keyColumns returns configured key columns of table.
+func (i *PostgresCDCIngest) keyColumns(schema, table string) []string {
+	if columns, ok := i.config.KeyColumns[schema+"."+table]; ok {
+		return columns
+	}
+	return []string{"id"}
+}
+
+func (i *PostgresCDCIngest) newDecoder() postgresCDCDecoder {
+	if i.config.Plugin == PostgresCDCPluginWal2JSON {
+		return &wal2jsonDecoder{keyColumns: i.keyColumns}
+	}
+	return newPgoutputDecoder(i.keyColumns)
+}
+
+func (i *PostgresCDCIngest) pluginArguments() []string {
+	if i.config.Plugin == PostgresCDCPluginWal2JSON {
+		if len(i.config.Tables) > 0 {
+			return []string{fmt.Sprintf("\"add-tables\" '%s'", strings.Join(i.config.Tables, ","))}
+		}
+		return nil
+	}
+	return []string{"proto_version '1'", fmt.Sprintf("publication_names '%s'", i.config.Publication)}
+}
+
+// Run starts streaming changes in background. Ingest reconnects with
+// backoff on errors.
+func (i *PostgresCDCIngest) Run() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	i.cancel = cancel
+	i.wg.Add(1)
+	go func() {
+		defer i.wg.Done()
+		for attempt := 0; ; attempt++ {
+			started, err := i.stream(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if started {
+				attempt = 0
+			}
+			i.node.logger.log(newLogEntry(LogLevelError, "Postgres replication error", map[string]interface{}{"error": err.Error()}))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(ingestRetryDelay(attempt)):
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops streaming changes.
+func (i *PostgresCDCIngest) Close() error {
+	if i.cancel != nil {
+		i.cancel()
+	}
+	i.wg.Wait()
+	return nil
+}
+
+// createSlot creates replication slot ignoring error if it already exists.
+func (i *PostgresCDCIngest) createSlot(conn *pgx.ReplicationConn) error {
+	err := conn.CreateReplicationSlot(i.config.Slot, i.config.Plugin)
+	if pgErr, ok := err.(pgx.PgError); ok && pgErr.Code == "42710" {
+		return nil
+	}
+	return err
+}
+
+// stream streams changes until error. Returns true if replication was
+// started so reconnect backoff can be reset.
+func (i *PostgresCDCIngest) stream(ctx context.Context) (bool, error) {
+	conn, err := pgx.ReplicationConnect(i.connConfig)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	if err := i.createSlot(conn); err != nil {
+		return false, err
+	}
+	if err := conn.StartReplication(i.config.Slot, 0, -1, i.pluginArguments()...); err != nil {
+		return false, err
+	}
+
+	decoder := i.newDecoder()
+	var confirmed uint64
+	sendStatus := func() error {
+		status, err := pgx.NewStandbyStatus(confirmed)
+		if err != nil {
+			return err
+		}
+		return conn.SendStandbyStatus(status)
+	}
+
+	for {
+		waitCtx, cancel := context.WithTimeout(ctx, i.config.StatusInterval)
+		msg, err := conn.WaitForReplicationMessage(waitCtx)
+		cancel()
+		if err == context.DeadlineExceeded {
+			if err := sendStatus(); err != nil {
+				return true, err
+			}
+			continue
+		}
+		if err != nil {
+			return true, err
+		}
+		if msg.WalMessage != nil {
+			pos, err := i.handleWal(decoder, msg.WalMessage)
+			if err != nil {
+				return true, err
+			}
+			if pos > 0 {
+				confirmed = pos
+			}
+		}
+		if msg.ServerHeartbeat != nil && msg.ServerHeartbeat.ReplyRequested == 1 {
+			if err := sendStatus(); err != nil {
+				return true, err
+			}
+		}
+	}
+}
+
+// handleWal publishes changes of WAL message and returns position to
+// confirm.
+func (i *PostgresCDCIngest) handleWal(decoder postgresCDCDecoder, msg *pgx.WalMessage) (uint64, error) {
+	changes, pos, err := decoder.decode(msg)
+	if err != nil {
+		if err == errPgoutputUnknownRelation {
+			// Can't decode rest of stream without relation, restart it.
+			return 0, err
+		}
+		// Can't do anything with malformed message, skip it.
+		i.node.logger.log(newLogEntry(LogLevelError, "error decoding Postgres WAL message", map[string]interface{}{"plugin": i.config.Plugin, "error": err.Error()}))
+		return 0, nil
+	}
+	for _, change := range changes {
+		if i.tables != nil {
+			if _, ok := i.tables[change.Schema+"."+change.Table]; !ok {
+				continue
+			}
+		}
+		channels := i.config.ChannelFunc(change)
+		if len(channels) == 0 {
+			continue
+		}
+		encoded, err := json.Marshal(change)
+		if err != nil {
+			return 0, err
+		}
+		for _, ch := range channels {
+			if err := i.node.Publish(ch, encoded); err != nil {
+				if err == ErrNoChannelOptions {
+					i.node.logger.log(newLogEntry(LogLevelInfo, "no channel options for Postgres change", map[string]interface{}{"channel": ch}))
+					continue
+				}
+				return 0, err
+			}
+		}
+	}
+	return pos, nil
+}
+
+type wal2jsonKeys struct {
+	KeyNames  []string      `json:"keynames"`
+	KeyValues []interface{} `json:"keyvalues"`
+}
+
+type wal2jsonChange struct {
+	Kind         string        `json:"kind"`
+	Schema       string        `json:"schema"`
+	Table        string        `json:"table"`
+	ColumnNames  []string      `json:"columnnames"`
+	ColumnValues []interface{} `json:"columnvalues"`
+	OldKeys      *wal2jsonKeys `json:"oldkeys"`
+}
+
+type wal2jsonTransaction struct {
+	Change []wal2jsonChange `json:"change"`
+}
+
+// wal2jsonDecoder decodes wal2json messages, every message contains whole
+// transaction.
+type wal2jsonDecoder struct {
+	keyColumns func(schema, table string) []string
+}
+
+func (d *wal2jsonDecoder) decode(msg *pgx.WalMessage) ([]*PostgresCDCChange, uint64, error) {
+	var tx wal2jsonTransaction
+	decoder := json.NewDecoder(bytes.NewReader(msg.WalData))
+	decoder.UseNumber()
+	if err := decoder.Decode(&tx); err != nil {
+		return nil, 0, err
+	}
+	changes := make([]*PostgresCDCChange, 0, len(tx.Change))
+	for _, c := range tx.Change {
+		change := &PostgresCDCChange{
+			Kind:   c.Kind,
+			Schema: c.Schema,
+			Table:  c.Table,
+			Key:    make(map[string]interface{}),
+		}
+		if len(c.ColumnNames) > 0 {
+			change.Data = make(map[string]interface{}, len(c.ColumnNames))
+			for j, name := range c.ColumnNames {
+				if j < len(c.ColumnValues) {
+					change.Data[name] = c.ColumnValues[j]
+				}
+			}
+		}
+		if c.OldKeys != nil {
+			for j, name := range c.OldKeys.KeyNames {
+				if j < len(c.OldKeys.KeyValues) {
+					change.Key[name] = c.OldKeys.KeyValues[j]
+				}
+			}
+		} else {
+			for _, name := range d.keyColumns(c.Schema, c.Table) {
+				if v, ok := change.Data[name]; ok {
+					change.Key[name] = v
+				}
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes, msg.WalStart, nil
+}
@@ -0,0 +1,325 @@
+package centrifuge
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx"
+)
+
+var (
+	errPgoutputUnknownRelation = errors.New("pgoutput message for unknown relation")
+	errPgoutputShortMessage    = errors.New("pgoutput message too short")
+)
+
+// Type OIDs of columns which text representation decoded into JSON values
+// other than string.
+const (
+	pgoutputOIDBool    = 16
+	pgoutputOIDInt8    = 20
+	pgoutputOIDInt2    = 21
+	pgoutputOIDInt4    = 23
+	pgoutputOIDOID     = 26
+	pgoutputOIDJSON    = 114
+	pgoutputOIDFloat4  = 700
+	pgoutputOIDFloat8  = 701
+	pgoutputOIDNumeric = 1700
+	pgoutputOIDJSONB   = 3802
+)
+
+type pgoutputColumn struct {
+	name string
+	key  bool
+	oid  uint32
+}
+
+type pgoutputRelation struct {
+	schema  string
+	table   string
+	columns []pgoutputColumn
+}
+
+// pgoutputDecoder decodes messages of pgoutput plugin logical replication
+// protocol version 1. Relation messages sent by Postgres before first change
+// of every relation in replication session, so decoder must be created for
+// every session.
+type pgoutputDecoder struct {
+	keyColumns func(schema, table string) []string
+	relations  map[uint32]*pgoutputRelation
+}
+
+func newPgoutputDecoder(keyColumns func(schema, table string) []string) *pgoutputDecoder {
+	return &pgoutputDecoder{
+		keyColumns: keyColumns,
+		relations:  make(map[uint32]*pgoutputRelation),
+	}
+}
+
+// pgoutputReader reads protocol message fields.
+type pgoutputReader struct {
+	data []byte
+	err  error
+}
+
+func (r *pgoutputReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.data) < n {
+		r.err = errPgoutputShortMessage
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *pgoutputReader) byte() byte {
+	b := r.next(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *pgoutputReader) uint16() uint16 {
+	b := r.next(2)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint16(b)
+}
+
+func (r *pgoutputReader) uint32() uint32 {
+	b := r.next(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+func (r *pgoutputReader) uint64() uint64 {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+// string reads null terminated string.
+func (r *pgoutputReader) string() string {
+	if r.err != nil {
+		return ""
+	}
+	for i, b := range r.data {
+		if b == 0 {
+			s := string(r.data[:i])
+			r.data = r.data[i+1:]
+			return s
+		}
+	}
+	r.err = errPgoutputShortMessage
+	return ""
+}
+
+// tuple reads TupleData. Values of unchanged TOAST columns are not sent by
+// Postgres so such columns are absent in result.
+func (r *pgoutputReader) tuple(rel *pgoutputRelation) (map[string]interface{}, error) {
+	n := int(r.uint16())
+	if r.err != nil {
+		return nil, r.err
+	}
+	if n > len(rel.columns) {
+		return nil, fmt.Errorf("pgoutput tuple has %d columns, relation %s.%s has %d", n, rel.schema, rel.table, len(rel.columns))
+	}
+	values := make(map[string]interface{}, n)
+	for j := 0; j < n; j++ {
+		column := rel.columns[j]
+		switch kind := r.byte(); kind {
+		case 'n':
+			values[column.name] = nil
+		case 'u':
+		case 't':
+			size := int(r.uint32())
+			value := r.next(size)
+			if r.err != nil {
+				return nil, r.err
+			}
+			values[column.name] = pgoutputValue(column.oid, value)
+		default:
+			if r.err != nil {
+				return nil, r.err
+			}
+			return nil, fmt.Errorf("unknown pgoutput tuple column kind: %q", kind)
+		}
+	}
+	return values, r.err
+}
+
+// pgoutputValue converts text representation of column value into JSON
+// value.
+func pgoutputValue(oid uint32, value []byte) interface{} {
+	switch oid {
+	case pgoutputOIDBool:
+		return string(value) == "t"
+	case pgoutputOIDInt2, pgoutputOIDInt4, pgoutputOIDInt8, pgoutputOIDOID, pgoutputOIDFloat4, pgoutputOIDFloat8, pgoutputOIDNumeric:
+		s := string(value)
+		if s == "NaN" || s == "Infinity" || s == "-Infinity" {
+			return s
+		}
+		return json.Number(s)
+	case pgoutputOIDJSON, pgoutputOIDJSONB:
+		return json.RawMessage(value)
+	default:
+		return string(value)
+	}
+}
+
+func (d *pgoutputDecoder) relation(id uint32) (*pgoutputRelation, error) {
+	rel, ok := d.relations[id]
+	if !ok {
+		return nil, errPgoutputUnknownRelation
+	}
+	return rel, nil
+}
+
+// key extracts key columns from tuple values. Columns marked as part of
+// replica identity key by Postgres used, configured key columns otherwise.
+func (d *pgoutputDecoder) key(rel *pgoutputRelation, values map[string]interface{}) map[string]interface{} {
+	key := make(map[string]interface{})
+	for _, column := range rel.columns {
+		if column.key {
+			if v, ok := values[column.name]; ok {
+				key[column.name] = v
+			}
+		}
+	}
+	if len(key) > 0 {
+		return key
+	}
+	for _, name := range d.keyColumns(rel.schema, rel.table) {
+		if v, ok := values[name]; ok {
+			key[name] = v
+		}
+	}
+	return key
+}
+
+func (d *pgoutputDecoder) decode(msg *pgx.WalMessage) ([]*PostgresCDCChange, uint64, error) {
+	if len(msg.WalData) == 0 {
+		return nil, 0, errPgoutputShortMessage
+	}
+	r := &pgoutputReader{data: msg.WalData[1:]}
+	switch msg.WalData[0] {
+	case 'B', 'O', 'Y':
+		// Begin, Origin and Type messages carry nothing to publish.
+		return nil, 0, nil
+	case 'C':
+		// Flags, commit LSN, end LSN of transaction, commit timestamp.
+		r.byte()
+		r.uint64()
+		end := r.uint64()
+		if r.err != nil {
+			return nil, 0, r.err
+		}
+		return nil, end, nil
+	case 'R':
+		id := r.uint32()
+		rel := &pgoutputRelation{
+			schema: r.string(),
+			table:  r.string(),
+		}
+		// Replica identity setting.
+		r.byte()
+		n := int(r.uint16())
+		for j := 0; j < n && r.err == nil; j++ {
+			flags := r.byte()
+			name := r.string()
+			oid := r.uint32()
+			// Type modifier.
+			r.uint32()
+			rel.columns = append(rel.columns, pgoutputColumn{name: name, key: flags&1 == 1, oid: oid})
+		}
+		if r.err != nil {
+			return nil, 0, r.err
+		}
+		d.relations[id] = rel
+		return nil, 0, nil
+	case 'I':
+		rel, err := d.relation(r.uint32())
+		if err != nil {
+			return nil, 0, err
+		}
+		if kind := r.byte(); kind != 'N' {
+			return nil, 0, fmt.Errorf("unexpected pgoutput insert tuple kind: %q", kind)
+		}
+		data, err := r.tuple(rel)
+		if err != nil {
+			return nil, 0, err
+		}
+		change := &PostgresCDCChange{Kind: "insert", Schema: rel.schema, Table: rel.table, Key: d.key(rel, data), Data: data}
+		return []*PostgresCDCChange{change}, 0, nil
+	case 'U':
+		rel, err := d.relation(r.uint32())
+		if err != nil {
+			return nil, 0, err
+		}
+		var old map[string]interface{}
+		kind := r.byte()
+		if kind == 'K' || kind == 'O' {
+			// Key changed or table has REPLICA IDENTITY FULL.
+			old, err = r.tuple(rel)
+			if err != nil {
+				return nil, 0, err
+			}
+			kind = r.byte()
+		}
+		if kind != 'N' {
+			return nil, 0, fmt.Errorf("unexpected pgoutput update tuple kind: %q", kind)
+		}
+		data, err := r.tuple(rel)
+		if err != nil {
+			return nil, 0, err
+		}
+		keyValues := data
+		if old != nil {
+			keyValues = old
+		}
+		change := &PostgresCDCChange{Kind: "update", Schema: rel.schema, Table: rel.table, Key: d.key(rel, keyValues), Data: data}
+		return []*PostgresCDCChange{change}, 0, nil
+	case 'D':
+		rel, err := d.relation(r.uint32())
+		if err != nil {
+			return nil, 0, err
+		}
+		if kind := r.byte(); kind != 'K' && kind != 'O' {
+			return nil, 0, fmt.Errorf("unexpected pgoutput delete tuple kind: %q", kind)
+		}
+		old, err := r.tuple(rel)
+		if err != nil {
+			return nil, 0, err
+		}
+		change := &PostgresCDCChange{Kind: "delete", Schema: rel.schema, Table: rel.table, Key: d.key(rel, old)}
+		return []*PostgresCDCChange{change}, 0, nil
+	case 'T':
+		n := int(r.uint32())
+		// Truncate options.
+		r.byte()
+		var changes []*PostgresCDCChange
+		for j := 0; j < n && r.err == nil; j++ {
+			rel, err := d.relation(r.uint32())
+			if err != nil {
+				return nil, 0, err
+			}
+			changes = append(changes, &PostgresCDCChange{Kind: "truncate", Schema: rel.schema, Table: rel.table})
+		}
+		if r.err != nil {
+			return nil, 0, r.err
+		}
+		return changes, 0, nil
+	default:
+		return nil, 0, fmt.Errorf("unknown pgoutput message type: %q", msg.WalData[0])
+	}
+}
@@ -0,0 +1,239 @@
+package centrifuge
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/jackc/pgx"
+	"github.com/stretchr/testify/assert"
+)
+
+// pgoutputMessage builds pgoutput protocol message.
+type pgoutputMessage []byte
+
+func (m pgoutputMessage) byte(b byte) pgoutputMessage {
+	return append(m, b)
+}
+
+func (m pgoutputMessage) uint16(v uint16) pgoutputMessage {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+	return append(m, b...)
+}
+
+func (m pgoutputMessage) uint32(v uint32) pgoutputMessage {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	return append(m, b...)
+}
+
+func (m pgoutputMessage) uint64(v uint64) pgoutputMessage {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return append(m, b...)
+}
+
+func (m pgoutputMessage) string(s string) pgoutputMessage {
+	return append(append(m, s...), 0)
+}
+
+// tuple appends TupleData, nil value means null, "\x00" means unchanged
+// TOAST value.
+func (m pgoutputMessage) tuple(values ...interface{}) pgoutputMessage {
+	m = m.uint16(uint16(len(values)))
+	for _, v := range values {
+		switch v := v.(type) {
+		case nil:
+			m = m.byte('n')
+		case string:
+			if v == "\x00" {
+				m = m.byte('u')
+				continue
+			}
+			m = m.byte('t').uint32(uint32(len(v)))
+			m = append(m, v...)
+		}
+	}
+	return m
+}
+
+func testPgoutputRelation() pgoutputMessage {
+	m := pgoutputMessage{'R'}.uint32(1).string("public").string("items").byte('d').uint16(5)
+	m = m.byte(1).string("id").uint32(pgoutputOIDInt4).uint32(0)
+	m = m.byte(0).string("text").uint32(25).uint32(0)
+	m = m.byte(0).string("done").uint32(pgoutputOIDBool).uint32(0)
+	m = m.byte(0).string("meta").uint32(pgoutputOIDJSONB).uint32(0)
+	m = m.byte(0).string("body").uint32(25).uint32(0)
+	return m
+}
+
+func decodePgoutput(t *testing.T, d *pgoutputDecoder, m pgoutputMessage) ([]*PostgresCDCChange, uint64) {
+	changes, pos, err := d.decode(&pgx.WalMessage{WalData: m})
+	assert.NoError(t, err)
+	return changes, pos
+}
+
+func TestPgoutputDecoder(t *testing.T) {
+	d := newPgoutputDecoder(func(schema, table string) []string { return []string{"id"} })
+
+	changes, pos := decodePgoutput(t, d, pgoutputMessage{'B'}.uint64(100).uint64(0).uint32(1))
+	assert.Len(t, changes, 0)
+	assert.Equal(t, uint64(0), pos)
+	decodePgoutput(t, d, testPgoutputRelation())
+
+	changes, _ = decodePgoutput(t, d, pgoutputMessage{'I'}.uint32(1).byte('N').tuple("1", "hello", "t", `{"a":1}`, nil))
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "insert", changes[0].Kind)
+	assert.Equal(t, "public", changes[0].Schema)
+	assert.Equal(t, "items", changes[0].Table)
+	assert.Equal(t, map[string]interface{}{"id": json.Number("1")}, changes[0].Key)
+	assert.Equal(t, map[string]interface{}{
+		"id":   json.Number("1"),
+		"text": "hello",
+		"done": true,
+		"meta": json.RawMessage(`{"a":1}`),
+		"body": nil,
+	}, changes[0].Data)
+	assert.Equal(t, []string{"cdc:public.items", "cdc:public.items.1"}, defaultPostgresCDCChannels(changes[0]))
+
+	// Unchanged TOAST column absent in data.
+	changes, _ = decodePgoutput(t, d, pgoutputMessage{'U'}.uint32(1).byte('N').tuple("1", "world", "f", nil, "\x00"))
+	assert.Equal(t, "update", changes[0].Kind)
+	assert.Equal(t, map[string]interface{}{"id": json.Number("1")}, changes[0].Key)
+	assert.Equal(t, "world", changes[0].Data["text"])
+	assert.Equal(t, false, changes[0].Data["done"])
+	_, ok := changes[0].Data["body"]
+	assert.False(t, ok)
+
+	// Key changed, old key used.
+	changes, _ = decodePgoutput(t, d, pgoutputMessage{'U'}.uint32(1).byte('K').tuple("1", nil, nil, nil, nil).byte('N').tuple("2", "world", "f", nil, nil))
+	assert.Equal(t, map[string]interface{}{"id": json.Number("1")}, changes[0].Key)
+	assert.Equal(t, json.Number("2"), changes[0].Data["id"])
+
+	changes, _ = decodePgoutput(t, d, pgoutputMessage{'D'}.uint32(1).byte('K').tuple("2", nil, nil, nil, nil))
+	assert.Equal(t, "delete", changes[0].Kind)
+	assert.Equal(t, map[string]interface{}{"id": json.Number("2")}, changes[0].Key)
+	assert.Nil(t, changes[0].Data)
+
+	changes, _ = decodePgoutput(t, d, pgoutputMessage{'T'}.uint32(1).byte(0).uint32(1))
+	assert.Equal(t, "truncate", changes[0].Kind)
+	assert.Equal(t, []string{"cdc:public.items"}, defaultPostgresCDCChannels(changes[0]))
+
+	// Position confirmed at end of transaction.
+	changes, pos = decodePgoutput(t, d, pgoutputMessage{'C'}.byte(0).uint64(100).uint64(120).uint64(0))
+	assert.Len(t, changes, 0)
+	assert.Equal(t, uint64(120), pos)
+}
+
+func TestPgoutputDecoderErrors(t *testing.T) {
+	d := newPgoutputDecoder(func(schema, table string) []string { return []string{"id"} })
+
+	_, _, err := d.decode(&pgx.WalMessage{WalData: pgoutputMessage{'I'}.uint32(1).byte('N').tuple("1")})
+	assert.Equal(t, errPgoutputUnknownRelation, err)
+
+	decodePgoutput(t, d, testPgoutputRelation())
+	_, _, err = d.decode(&pgx.WalMessage{WalData: pgoutputMessage{'I'}.uint32(1).byte('N').uint16(1).byte('t').uint32(10)})
+	assert.Equal(t, errPgoutputShortMessage, err)
+	_, _, err = d.decode(&pgx.WalMessage{WalData: pgoutputMessage{'X'}})
+	assert.Error(t, err)
+}
+
+func TestPgoutputDecoderKeyColumns(t *testing.T) {
+	d := newPgoutputDecoder(func(schema, table string) []string { return []string{"name"} })
+	// Table without replica identity key.
+	decodePgoutput(t, d, pgoutputMessage{'R'}.uint32(2).string("public").string("tags").byte('n').uint16(1).byte(0).string("name").uint32(25).uint32(0))
+	changes, _ := decodePgoutput(t, d, pgoutputMessage{'I'}.uint32(2).byte('N').tuple("go"))
+	assert.Equal(t, map[string]interface{}{"name": "go"}, changes[0].Key)
+}
+
+func TestWal2jsonDecoder(t *testing.T) {
+	i, err := NewPostgresCDCIngest(nodeWithMemoryEngine(), PostgresCDCIngestConfig{
+		ConnString: "postgres://localhost/test",
+		Plugin:     PostgresCDCPluginWal2JSON,
+		KeyColumns: map[string][]string{"public.tags": {"name"}},
+	})
+	assert.NoError(t, err)
+
+	data := []byte(`{"change":[
+		{"kind":"insert","schema":"public","table":"items","columnnames":["id","text"],"columntypes":["integer","text"],"columnvalues":[1000000,"hello"]},
+		{"kind":"insert","schema":"public","table":"tags","columnnames":["name"],"columntypes":["text"],"columnvalues":["go"]},
+		{"kind":"update","schema":"public","table":"items","columnnames":["id","text"],"columntypes":["integer","text"],"columnvalues":[1,"world"],"oldkeys":{"keynames":["id"],"keytypes":["integer"],"keyvalues":[1]}},
+		{"kind":"delete","schema":"public","table":"items","oldkeys":{"keynames":["id"],"keytypes":["integer"],"keyvalues":[1]}}
+	]}`)
+	changes, pos, err := i.newDecoder().decode(&pgx.WalMessage{WalStart: 10, WalData: data})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), pos)
+	assert.Len(t, changes, 4)
+
+	assert.Equal(t, "insert", changes[0].Kind)
+	assert.Equal(t, map[string]interface{}{"id": json.Number("1000000")}, changes[0].Key)
+	assert.Equal(t, "hello", changes[0].Data["text"])
+	assert.Equal(t, []string{"cdc:public.items", "cdc:public.items.1000000"}, defaultPostgresCDCChannels(changes[0]))
+
+	assert.Equal(t, map[string]interface{}{"name": "go"}, changes[1].Key)
+	assert.Equal(t, []string{"cdc:public.tags", "cdc:public.tags.go"}, defaultPostgresCDCChannels(changes[1]))
+
+	assert.Equal(t, "world", changes[2].Data["text"])
+	assert.Equal(t, map[string]interface{}{"id": json.Number("1")}, changes[2].Key)
+
+	assert.Equal(t, "delete", changes[3].Kind)
+	assert.Nil(t, changes[3].Data)
+
+	_, _, err = i.newDecoder().decode(&pgx.WalMessage{WalData: []byte(`{`)})
+	assert.Error(t, err)
+}
+
+func TestPostgresCDCIngestHandleWal(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	assert.NoError(t, node.Reload(config))
+
+	i, err := NewPostgresCDCIngest(node, PostgresCDCIngestConfig{
+		ConnString: "postgres://localhost/test",
+		Tables:     []string{"public.items"},
+		ChannelFunc: func(change *PostgresCDCChange) []string {
+			return []string{change.Table}
+		},
+	})
+	assert.NoError(t, err)
+	d := i.newDecoder()
+
+	_, err = i.handleWal(d, &pgx.WalMessage{WalData: testPgoutputRelation()})
+	assert.NoError(t, err)
+	_, err = i.handleWal(d, &pgx.WalMessage{WalData: pgoutputMessage{'R'}.uint32(2).string("public").string("other").byte('d').uint16(1).byte(1).string("id").uint32(pgoutputOIDInt4).uint32(0)})
+	assert.NoError(t, err)
+	_, err = i.handleWal(d, &pgx.WalMessage{WalData: pgoutputMessage{'I'}.uint32(1).byte('N').tuple("1", "hello", "t", nil, nil)})
+	assert.NoError(t, err)
+	// Table not in Tables skipped.
+	_, err = i.handleWal(d, &pgx.WalMessage{WalData: pgoutputMessage{'I'}.uint32(2).byte('N').tuple("1")})
+	assert.NoError(t, err)
+	// Malformed message skipped without confirming position.
+	pos, err := i.handleWal(d, &pgx.WalMessage{WalStart: 50, WalData: pgoutputMessage{'X'}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), pos)
+	// Message of unknown relation restarts stream.
+	_, err = i.handleWal(d, &pgx.WalMessage{WalData: pgoutputMessage{'I'}.uint32(3).byte('N').tuple("1")})
+	assert.Equal(t, errPgoutputUnknownRelation, err)
+
+	res, err := node.History("items")
+	assert.NoError(t, err)
+	assert.Len(t, res.Publications, 1)
+	var change PostgresCDCChange
+	assert.NoError(t, json.Unmarshal(res.Publications[0].Data, &change))
+	assert.Equal(t, "insert", change.Kind)
+	assert.Equal(t, "hello", change.Data["text"])
+	res, err = node.History("other")
+	assert.NoError(t, err)
+	assert.Len(t, res.Publications, 0)
+}
+
+func TestNewPostgresCDCIngestUnsupportedPlugin(t *testing.T) {
+	_, err := NewPostgresCDCIngest(nodeWithMemoryEngine(), PostgresCDCIngestConfig{
+		ConnString: "postgres://localhost/test",
+		Plugin:     "test_decoding",
+	})
+	assert.Error(t, err)
+}
@@ -19,17 +19,37 @@ type (
 	Join = proto.Join
 	// Leave sent to channel after someone unsubscribed.
 	Leave = proto.Leave
+	// Membership sent to channel instead of Join and Leave when they are
+	// coalesced.
+	Membership = proto.Membership
 	// ClientInfo is short information about client connection.
 	ClientInfo = proto.ClientInfo
 	// Encoding represents client connection transport encoding format.
 	Encoding = proto.Encoding
 	// Push wraps Publication, Join or Leave.
 	Push = proto.Push
+	// PublicationOp tells whether publication is new or changes previous one.
+	PublicationOp = proto.PublicationOp
+	// Redirect contains endpoints client advised to connect to instead of
+	// current node.
+	Redirect = proto.Redirect
+	// ReconnectAdvice contains reconnect delays in milliseconds server
+	// advises clients to use.
+	ReconnectAdvice = proto.ReconnectAdvice
 )
 
 // Push types.
 var (
-	PushTypePublication = proto.PushTypePublication
-	PushTypeJoin        = proto.PushTypeJoin
-	PushTypeLeave       = proto.PushTypeLeave
+	PushTypePublication   = proto.PushTypePublication
+	PushTypeJoin          = proto.PushTypeJoin
+	PushTypeLeave         = proto.PushTypeLeave
+	PushTypeMembership    = proto.PushTypeMembership
+	PushTypeRefreshDemand = proto.PushTypeRefreshDemand
+)
+
+// Publication operations.
+var (
+	PublicationOpNew       = proto.PublicationOpNew
+	PublicationOpEdit      = proto.PublicationOpEdit
+	PublicationOpTombstone = proto.PublicationOpTombstone
 )
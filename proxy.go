@@ -0,0 +1,160 @@
+package centrifuge
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+)
+
+// The following types describe payloads proxies send to application backend
+// and replies they expect back. Client payloads are sent as JSON in data
+// field for JSON protocol clients and as base64 encoded string in b64data
+// field for Protobuf protocol clients. The same applies to payloads in
+// replies.
+
+type proxyRequest struct {
+	Client    string   `json:"client"`
+	Transport string   `json:"transport"`
+	Encoding  Encoding `json:"encoding"`
+	User      string   `json:"user,omitempty"`
+}
+
+type proxyConnectRequest struct {
+	proxyRequest
+	Data    json.RawMessage `json:"data,omitempty"`
+	B64Data string          `json:"b64data,omitempty"`
+}
+
+type proxyRefreshRequest struct {
+	proxyRequest
+}
+
+type proxySubscribeRequest struct {
+	proxyRequest
+	Channel string `json:"channel"`
+}
+
+type proxyPublishRequest struct {
+	proxyRequest
+	Channel string          `json:"channel"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	B64Data string          `json:"b64data,omitempty"`
+}
+
+type proxyRPCRequest struct {
+	proxyRequest
+	Method  string          `json:"method,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	B64Data string          `json:"b64data,omitempty"`
+}
+
+type proxyConnectResult struct {
+	User     string          `json:"user"`
+	ExpireAt int64           `json:"expire_at"`
+	Info     json.RawMessage `json:"info,omitempty"`
+	B64Info  string          `json:"b64info,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	B64Data  string          `json:"b64data,omitempty"`
+}
+
+type proxyRefreshResult struct {
+	Expired  bool            `json:"expired"`
+	ExpireAt int64           `json:"expire_at"`
+	Info     json.RawMessage `json:"info,omitempty"`
+	B64Info  string          `json:"b64info,omitempty"`
+}
+
+type proxySubscribeResult struct {
+	ExpireAt int64           `json:"expire_at"`
+	Info     json.RawMessage `json:"info,omitempty"`
+	B64Info  string          `json:"b64info,omitempty"`
+}
+
+type proxyRPCResult struct {
+	Data    json.RawMessage `json:"data,omitempty"`
+	B64Data string          `json:"b64data,omitempty"`
+}
+
+// proxyDisconnect differs from Disconnect as code must be decoded from JSON.
+type proxyDisconnect struct {
+	Code      int    `json:"code"`
+	Reason    string `json:"reason"`
+	Reconnect bool   `json:"reconnect"`
+}
+
+type proxyReply struct {
+	Error      *Error           `json:"error,omitempty"`
+	Disconnect *proxyDisconnect `json:"disconnect,omitempty"`
+}
+
+func (r *proxyReply) disconnect() *Disconnect {
+	if r.Disconnect == nil {
+		return nil
+	}
+	return &Disconnect{
+		Code:      r.Disconnect.Code,
+		Reason:    r.Disconnect.Reason,
+		Reconnect: r.Disconnect.Reconnect,
+	}
+}
+
+type proxyConnectReply struct {
+	proxyReply
+	Result *proxyConnectResult `json:"result,omitempty"`
+}
+
+type proxyRefreshReply struct {
+	proxyReply
+	Result *proxyRefreshResult `json:"result,omitempty"`
+}
+
+type proxySubscribeReply struct {
+	proxyReply
+	Result *proxySubscribeResult `json:"result,omitempty"`
+}
+
+type proxyPublishReply struct {
+	proxyReply
+}
+
+type proxyRPCReply struct {
+	proxyReply
+	Result *proxyRPCResult `json:"result,omitempty"`
+}
+
+func newProxyRequest(c *Client) proxyRequest {
+	return proxyRequest{
+		Client:    c.ID(),
+		Transport: c.Transport().Name(),
+		Encoding:  c.Transport().Encoding(),
+		User:      c.UserID(),
+	}
+}
+
+// encodeProxyData returns payload to put into data or b64data field
+// depending on client protocol.
+func encodeProxyData(enc Encoding, data Raw) (json.RawMessage, string) {
+	if len(data) == 0 {
+		return nil, ""
+	}
+	if enc == proto.EncodingProtobuf {
+		return nil, base64.StdEncoding.EncodeToString(data)
+	}
+	return json.RawMessage(data), ""
+}
+
+// decodeProxyData extracts payload from data or b64data reply field.
+func decodeProxyData(data json.RawMessage, b64data string) (Raw, error) {
+	if b64data != "" {
+		decoded, err := base64.StdEncoding.DecodeString(b64data)
+		if err != nil {
+			return nil, err
+		}
+		return Raw(decoded), nil
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return Raw(data), nil
+}
@@ -0,0 +1,409 @@
+package centrifuge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/centrifugal/centrifuge/proxyproto"
+
+	"google.golang.org/grpc"
+)
+
+// GRPCProxyConfig is a configuration of GRPCProxy.
+type GRPCProxyConfig struct {
+	// Endpoint is an address of GRPC server implementing
+	// proxyproto.CentrifugeProxyServer service.
+	Endpoint string
+
+	// Connect turns on proxying connect events.
+	Connect bool
+	// Refresh turns on proxying connection refresh events.
+	Refresh bool
+	// Subscribe turns on proxying subscribe events.
+	Subscribe bool
+	// Publish turns on proxying publish events.
+	Publish bool
+	// RPC turns on proxying RPC events.
+	RPC bool
+
+	// Timeout is a deadline for a single GRPC call to backend.
+	Timeout time.Duration
+	// PoolSize is a number of GRPC connections to keep with backend. Calls
+	// are distributed over connections in round-robin order. By default 1.
+	PoolSize int
+	// DialOptions allow to set custom GRPC dial options. By default
+	// insecure connection used.
+	DialOptions []grpc.DialOption
+	// PassHeaders is a list of headers of initial client HTTP request to
+	// pass to backend in request headers field.
+	PassHeaders []string
+
+	// CircuitBreakerThreshold is a number of consecutive failed calls after
+	// which proxy stops calling backend for CircuitBreakerTimeout. 0 means
+	// no circuit breaking.
+	CircuitBreakerThreshold int
+	// CircuitBreakerTimeout is a time proxy rejects calls after circuit
+	// opened. After it passed proxy tries to call backend again.
+	CircuitBreakerTimeout time.Duration
+}
+
+const (
+	defaultGRPCProxyTimeout               = time.Second
+	defaultGRPCProxyCircuitBreakerTimeout = 5 * time.Second
+)
+
+var (
+	// ErrProxyCircuitOpen returned when proxy does not call backend as too
+	// many consecutive calls failed recently.
+	ErrProxyCircuitOpen = errors.New("proxy circuit open")
+)
+
+// circuitBreaker opens after threshold consecutive failures and rejects
+// calls until timeout passed.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	timeout   time.Duration
+	failures  int
+	openUntil time.Time
+}
+
+func (b *circuitBreaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.openUntil)
+}
+
+func (b *circuitBreaker) done(err error) {
+	if b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.timeout)
+	}
+}
+
+// GRPCProxy proxies client events to application backend over GRPC. Backend
+// must implement CentrifugeProxy service described in proxyproto/proxy.proto.
+type GRPCProxy struct {
+	node    *Node
+	config  GRPCProxyConfig
+	conns   []*grpc.ClientConn
+	clients []proxyproto.CentrifugeProxyClient
+	counter uint32
+	breaker *circuitBreaker
+}
+
+// NewGRPCProxy creates new GRPCProxy and dials backend.
+func NewGRPCProxy(n *Node, c GRPCProxyConfig) (*GRPCProxy, error) {
+	if c.Timeout == 0 {
+		c.Timeout = defaultGRPCProxyTimeout
+	}
+	if c.PoolSize == 0 {
+		c.PoolSize = 1
+	}
+	if c.CircuitBreakerTimeout == 0 {
+		c.CircuitBreakerTimeout = defaultGRPCProxyCircuitBreakerTimeout
+	}
+	dialOpts := c.DialOptions
+	if len(dialOpts) == 0 {
+		dialOpts = []grpc.DialOption{grpc.WithInsecure()}
+	}
+	p := &GRPCProxy{
+		node:   n,
+		config: c,
+		breaker: &circuitBreaker{
+			threshold: c.CircuitBreakerThreshold,
+			timeout:   c.CircuitBreakerTimeout,
+		},
+	}
+	for i := 0; i < c.PoolSize; i++ {
+		conn, err := grpc.Dial(c.Endpoint, dialOpts...)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.conns = append(p.conns, conn)
+		p.clients = append(p.clients, proxyproto.NewCentrifugeProxyClient(conn))
+	}
+	return p, nil
+}
+
+// Close closes all GRPC connections to backend.
+func (p *GRPCProxy) Close() error {
+	var err error
+	for _, conn := range p.conns {
+		if closeErr := conn.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (p *GRPCProxy) client() proxyproto.CentrifugeProxyClient {
+	i := atomic.AddUint32(&p.counter, 1)
+	return p.clients[int(i)%len(p.clients)]
+}
+
+// call runs fn with deadline taking circuit breaker state into account.
+func (p *GRPCProxy) call(ctx context.Context, fn func(ctx context.Context, client proxyproto.CentrifugeProxyClient) error) error {
+	if !p.breaker.allow() {
+		return ErrProxyCircuitOpen
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+	err := fn(ctx, p.client())
+	p.breaker.done(err)
+	return err
+}
+
+func (p *GRPCProxy) headers(req *http.Request) map[string]string {
+	if req == nil || len(p.config.PassHeaders) == 0 {
+		return nil
+	}
+	headers := make(map[string]string, len(p.config.PassHeaders))
+	for _, h := range p.config.PassHeaders {
+		if v := req.Header.Get(h); v != "" {
+			headers[h] = v
+		}
+	}
+	return headers
+}
+
+func (p *GRPCProxy) logError(msg string, err error, fields map[string]interface{}) {
+	fields["error"] = err.Error()
+	p.node.logger.log(newLogEntry(LogLevelError, msg, fields))
+}
+
+func grpcProxyError(e *proxyproto.Error) *Error {
+	if e == nil {
+		return nil
+	}
+	return &Error{Code: e.Code, Message: e.Message}
+}
+
+func grpcProxyDisconnect(d *proxyproto.Disconnect) *Disconnect {
+	if d == nil {
+		return nil
+	}
+	return &Disconnect{Code: int(d.Code), Reason: d.Reason, Reconnect: d.Reconnect}
+}
+
+// Bind sets proxy event handlers for all enabled events. It sets
+// ClientConnecting, ClientRefresh and ClientConnected Node handlers so if
+// application needs its own ClientConnected handler it should not call Bind
+// but use ConnectingHandler, RefreshHandler and BindClient instead.
+func (p *GRPCProxy) Bind() {
+	if p.config.Connect {
+		p.node.On().ClientConnecting(p.ConnectingHandler())
+	}
+	if p.config.Refresh {
+		p.node.On().ClientRefresh(p.RefreshHandler())
+	}
+	p.node.On().ClientConnected(func(ctx context.Context, c *Client) {
+		p.BindClient(c)
+	})
+}
+
+// BindClient sets proxy Subscribe, Publish and RPC handlers to client
+// for enabled events.
+func (p *GRPCProxy) BindClient(c *Client) {
+	if p.config.Subscribe {
+		c.On().Subscribe(p.SubscribeHandler(c))
+	}
+	if p.config.Publish {
+		c.On().Publish(p.PublishHandler(c))
+	}
+	if p.config.RPC {
+		c.On().RPC(p.RPCHandler(c))
+	}
+}
+
+// ConnectingHandler returns ConnectingHandler which proxies connect events.
+func (p *GRPCProxy) ConnectingHandler() ConnectingHandler {
+	return func(ctx context.Context, t Transport, e ConnectEvent) ConnectReply {
+		req := &proxyproto.ConnectRequest{
+			Client:    e.ClientID,
+			Transport: t.Name(),
+			Encoding:  string(t.Encoding()),
+			Data:      e.Data,
+			Headers:   p.headers(t.Info().Request),
+		}
+		var resp *proxyproto.ConnectResponse
+		err := p.call(ctx, func(ctx context.Context, client proxyproto.CentrifugeProxyClient) error {
+			var err error
+			resp, err = client.Connect(ctx, req)
+			return err
+		})
+		if err != nil {
+			p.logError("error proxying connect", err, map[string]interface{}{"client": e.ClientID})
+			return ConnectReply{Error: ErrorInternal}
+		}
+		if d := grpcProxyDisconnect(resp.Disconnect); d != nil {
+			return ConnectReply{Disconnect: d}
+		}
+		if resp.Error != nil {
+			return ConnectReply{Error: grpcProxyError(resp.Error)}
+		}
+		if resp.Result == nil {
+			return ConnectReply{}
+		}
+		return ConnectReply{
+			Credentials: &Credentials{
+				UserID:   resp.Result.User,
+				ExpireAt: resp.Result.ExpireAt,
+				Info:     resp.Result.Info,
+			},
+			Data: resp.Result.Data,
+		}
+	}
+}
+
+// RefreshHandler returns RefreshHandler which proxies refresh events.
+func (p *GRPCProxy) RefreshHandler() RefreshHandler {
+	return func(ctx context.Context, c *Client, e RefreshEvent) RefreshReply {
+		req := &proxyproto.RefreshRequest{
+			Client:    c.ID(),
+			Transport: c.Transport().Name(),
+			Encoding:  string(c.Transport().Encoding()),
+			User:      c.UserID(),
+			Headers:   p.headers(c.Transport().Info().Request),
+		}
+		var resp *proxyproto.RefreshResponse
+		err := p.call(ctx, func(ctx context.Context, client proxyproto.CentrifugeProxyClient) error {
+			var err error
+			resp, err = client.Refresh(ctx, req)
+			return err
+		})
+		if err != nil {
+			// Give client a chance to refresh later, connection will be closed
+			// after current expiration time passed.
+			p.logError("error proxying refresh", err, map[string]interface{}{"client": c.ID(), "user": c.UserID()})
+			return RefreshReply{}
+		}
+		if resp.Result == nil || resp.Result.Expired {
+			return RefreshReply{}
+		}
+		return RefreshReply{
+			ExpireAt: resp.Result.ExpireAt,
+			Info:     resp.Result.Info,
+		}
+	}
+}
+
+// SubscribeHandler returns SubscribeHandler which proxies subscribe events
+// of client.
+func (p *GRPCProxy) SubscribeHandler(c *Client) SubscribeHandler {
+	return func(e SubscribeEvent) SubscribeReply {
+		req := &proxyproto.SubscribeRequest{
+			Client:    c.ID(),
+			Transport: c.Transport().Name(),
+			Encoding:  string(c.Transport().Encoding()),
+			User:      c.UserID(),
+			Headers:   p.headers(c.Transport().Info().Request),
+			Channel:   e.Channel,
+		}
+		var resp *proxyproto.SubscribeResponse
+		err := p.call(c.ctx, func(ctx context.Context, client proxyproto.CentrifugeProxyClient) error {
+			var err error
+			resp, err = client.Subscribe(ctx, req)
+			return err
+		})
+		if err != nil {
+			p.logError("error proxying subscribe", err, map[string]interface{}{"client": c.ID(), "user": c.UserID(), "channel": e.Channel})
+			return SubscribeReply{Error: ErrorInternal}
+		}
+		if d := grpcProxyDisconnect(resp.Disconnect); d != nil {
+			return SubscribeReply{Disconnect: d}
+		}
+		if resp.Error != nil {
+			return SubscribeReply{Error: grpcProxyError(resp.Error)}
+		}
+		if resp.Result == nil {
+			return SubscribeReply{}
+		}
+		return SubscribeReply{
+			ExpireAt:    resp.Result.ExpireAt,
+			ChannelInfo: resp.Result.Info,
+		}
+	}
+}
+
+// PublishHandler returns PublishHandler which proxies publish events of
+// client.
+func (p *GRPCProxy) PublishHandler(c *Client) PublishHandler {
+	return func(e PublishEvent) PublishReply {
+		req := &proxyproto.PublishRequest{
+			Client:    c.ID(),
+			Transport: c.Transport().Name(),
+			Encoding:  string(c.Transport().Encoding()),
+			User:      c.UserID(),
+			Headers:   p.headers(c.Transport().Info().Request),
+			Channel:   e.Channel,
+			Data:      e.Data,
+		}
+		var resp *proxyproto.PublishResponse
+		err := p.call(c.ctx, func(ctx context.Context, client proxyproto.CentrifugeProxyClient) error {
+			var err error
+			resp, err = client.Publish(ctx, req)
+			return err
+		})
+		if err != nil {
+			p.logError("error proxying publish", err, map[string]interface{}{"client": c.ID(), "user": c.UserID(), "channel": e.Channel})
+			return PublishReply{Error: ErrorInternal}
+		}
+		if d := grpcProxyDisconnect(resp.Disconnect); d != nil {
+			return PublishReply{Disconnect: d}
+		}
+		return PublishReply{Error: grpcProxyError(resp.Error)}
+	}
+}
+
+// RPCHandler returns RPCHandler which proxies RPC events of client.
+func (p *GRPCProxy) RPCHandler(c *Client) RPCHandler {
+	return func(e RPCEvent) RPCReply {
+		req := &proxyproto.RPCRequest{
+			Client:    c.ID(),
+			Transport: c.Transport().Name(),
+			Encoding:  string(c.Transport().Encoding()),
+			User:      c.UserID(),
+			Headers:   p.headers(c.Transport().Info().Request),
+			Data:      e.Data,
+			Method:    e.Method,
+		}
+		var resp *proxyproto.RPCResponse
+		err := p.call(c.ctx, func(ctx context.Context, client proxyproto.CentrifugeProxyClient) error {
+			var err error
+			resp, err = client.RPC(ctx, req)
+			return err
+		})
+		if err != nil {
+			p.logError("error proxying RPC", err, map[string]interface{}{"client": c.ID(), "user": c.UserID()})
+			return RPCReply{Error: ErrorInternal}
+		}
+		if d := grpcProxyDisconnect(resp.Disconnect); d != nil {
+			return RPCReply{Disconnect: d}
+		}
+		if resp.Error != nil {
+			return RPCReply{Error: grpcProxyError(resp.Error)}
+		}
+		if resp.Result == nil {
+			return RPCReply{}
+		}
+		return RPCReply{Data: resp.Result.Data}
+	}
+}
@@ -0,0 +1,141 @@
+package centrifuge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/centrifugal/centrifuge/proxyproto"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type testGRPCProxyServer struct {
+	failRPC  bool
+	rpcCalls int32
+}
+
+func (s *testGRPCProxyServer) Connect(ctx context.Context, req *proxyproto.ConnectRequest) (*proxyproto.ConnectResponse, error) {
+	if req.Headers["Authorization"] != "Bearer test" {
+		return &proxyproto.ConnectResponse{Error: &proxyproto.Error{Code: ErrorUnauthorized.Code, Message: "unauthorized"}}, nil
+	}
+	return &proxyproto.ConnectResponse{Result: &proxyproto.ConnectResult{User: "42", Data: req.Data}}, nil
+}
+
+func (s *testGRPCProxyServer) Refresh(ctx context.Context, req *proxyproto.RefreshRequest) (*proxyproto.RefreshResponse, error) {
+	return &proxyproto.RefreshResponse{Result: &proxyproto.RefreshResult{Expired: true}}, nil
+}
+
+func (s *testGRPCProxyServer) Subscribe(ctx context.Context, req *proxyproto.SubscribeRequest) (*proxyproto.SubscribeResponse, error) {
+	if req.Channel == "forbidden" {
+		return &proxyproto.SubscribeResponse{Error: &proxyproto.Error{Code: ErrorPermissionDenied.Code, Message: "permission denied"}}, nil
+	}
+	return &proxyproto.SubscribeResponse{Result: &proxyproto.SubscribeResult{}}, nil
+}
+
+func (s *testGRPCProxyServer) Publish(ctx context.Context, req *proxyproto.PublishRequest) (*proxyproto.PublishResponse, error) {
+	return &proxyproto.PublishResponse{Disconnect: &proxyproto.Disconnect{Code: 4000, Reason: "publish not allowed"}}, nil
+}
+
+func (s *testGRPCProxyServer) RPC(ctx context.Context, req *proxyproto.RPCRequest) (*proxyproto.RPCResponse, error) {
+	atomic.AddInt32(&s.rpcCalls, 1)
+	if s.failRPC {
+		return nil, errors.New("boom")
+	}
+	return &proxyproto.RPCResponse{Result: &proxyproto.RPCResult{Data: req.Data}}, nil
+}
+
+func newTestGRPCProxyServer(t *testing.T, srv *testGRPCProxyServer) (string, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s := grpc.NewServer()
+	proxyproto.RegisterCentrifugeProxyServer(s, srv)
+	go s.Serve(lis)
+	return lis.Addr().String(), s.Stop
+}
+
+func TestGRPCProxy(t *testing.T) {
+	addr, stop := newTestGRPCProxyServer(t, &testGRPCProxyServer{})
+	defer stop()
+
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.Publish = true
+	node.Reload(config)
+
+	proxy, err := NewGRPCProxy(node, GRPCProxyConfig{
+		Endpoint:    addr,
+		Connect:     true,
+		Subscribe:   true,
+		Publish:     true,
+		RPC:         true,
+		PoolSize:    2,
+		PassHeaders: []string{"Authorization"},
+	})
+	assert.NoError(t, err)
+	defer proxy.Close()
+	proxy.Bind()
+
+	_, resp := newTestHTTPProxyClient(t, node, "Bearer wrong")
+	assert.Equal(t, ErrorUnauthorized.Code, resp.Error.Code)
+
+	client, resp := newTestHTTPProxyClient(t, node, "Bearer test")
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, "42", client.UserID())
+	assert.Equal(t, `"hello"`, string(resp.Result.Data))
+
+	var replies []*proto.Reply
+	rw := testReplyWriter(&replies)
+	disconnect := client.subscribeCmd(&proto.SubscribeRequest{Channel: "forbidden"}, rw)
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorPermissionDenied.Code, replies[0].Error.Code)
+
+	_, disconnect = client.publishCmd(&proto.PublishRequest{Channel: "test", Data: []byte(`{}`)})
+	assert.NotNil(t, disconnect)
+	assert.Equal(t, 4000, disconnect.Code)
+
+	replies = nil
+	params, _ := json.Marshal(&proto.RPCRequest{Data: []byte(`{"rpc":true}`)})
+	disconnect = client.handleRPC(params, rw)
+	assert.Nil(t, disconnect)
+	var rpcResult proto.RPCResult
+	json.Unmarshal(replies[0].Result, &rpcResult)
+	assert.Equal(t, `{"rpc":true}`, string(rpcResult.Data))
+}
+
+func TestGRPCProxyCircuitBreaker(t *testing.T) {
+	srv := &testGRPCProxyServer{failRPC: true}
+	addr, stop := newTestGRPCProxyServer(t, srv)
+	defer stop()
+
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientInsecure = true
+	node.Reload(config)
+
+	proxy, err := NewGRPCProxy(node, GRPCProxyConfig{
+		Endpoint:                addr,
+		RPC:                     true,
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerTimeout:   time.Minute,
+	})
+	assert.NoError(t, err)
+	defer proxy.Close()
+	proxy.Bind()
+
+	client, _ := newTestHTTPProxyClient(t, node, "")
+	params, _ := json.Marshal(&proto.RPCRequest{Data: []byte(`{}`)})
+	for i := 0; i < 4; i++ {
+		var replies []*proto.Reply
+		disconnect := client.handleRPC(params, testReplyWriter(&replies))
+		assert.Nil(t, disconnect)
+		assert.Equal(t, ErrorInternal.Code, replies[0].Error.Code)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&srv.rpcCalls))
+}
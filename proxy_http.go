@@ -0,0 +1,353 @@
+package centrifuge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// HTTPProxyConfig is a configuration of HTTPProxy.
+type HTTPProxyConfig struct {
+	// ConnectEndpoint is a URL to send connect events to. Empty value does
+	// not proxy connect events.
+	ConnectEndpoint string
+	// RefreshEndpoint is a URL to send connection refresh events to.
+	RefreshEndpoint string
+	// SubscribeEndpoint is a URL to send subscribe events to.
+	SubscribeEndpoint string
+	// PublishEndpoint is a URL to send publish events to.
+	PublishEndpoint string
+	// RPCEndpoint is a URL to send RPC events to.
+	RPCEndpoint string
+
+	// Timeout for a single HTTP request to backend, includes all retries.
+	Timeout time.Duration
+	// MaxRetries sets how many times to retry request on network error or
+	// 5xx response status. 0 means no retries.
+	MaxRetries int
+	// RetryInterval is a time to wait between retries.
+	RetryInterval time.Duration
+	// PassHeaders is a list of headers of initial client HTTP request to
+	// pass to backend. Only headers from this list will be proxied.
+	PassHeaders []string
+
+	// HTTPClient allows to set custom HTTP client, http.DefaultClient used
+	// if nil.
+	HTTPClient *http.Client
+}
+
+const (
+	defaultHTTPProxyTimeout       = time.Second
+	defaultHTTPProxyRetryInterval = 100 * time.Millisecond
+)
+
+// HTTPProxy proxies client events to application backend over HTTP. Every
+// event is sent as POST request with JSON body and JSON reply expected back.
+// Reply can contain result, error or disconnect objects which are mapped to
+// corresponding event handler reply fields.
+type HTTPProxy struct {
+	node   *Node
+	config HTTPProxyConfig
+	client *http.Client
+}
+
+// NewHTTPProxy creates new HTTPProxy.
+func NewHTTPProxy(n *Node, c HTTPProxyConfig) *HTTPProxy {
+	if c.Timeout == 0 {
+		c.Timeout = defaultHTTPProxyTimeout
+	}
+	if c.RetryInterval == 0 {
+		c.RetryInterval = defaultHTTPProxyRetryInterval
+	}
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPProxy{
+		node:   n,
+		config: c,
+		client: client,
+	}
+}
+
+type httpProxyStatusError struct {
+	code int
+}
+
+func (e *httpProxyStatusError) Error() string {
+	return fmt.Sprintf("unexpected HTTP proxy response status: %d", e.code)
+}
+
+// httpProxyRetryable returns true for errors which make sense to retry: network
+// errors and 5xx response statuses.
+func httpProxyRetryable(err error) bool {
+	if statusErr, ok := err.(*httpProxyStatusError); ok {
+		return statusErr.code >= http.StatusInternalServerError
+	}
+	return true
+}
+
+func (p *HTTPProxy) headers(req *http.Request) http.Header {
+	headers := http.Header{}
+	if req != nil {
+		for _, h := range p.config.PassHeaders {
+			if v := req.Header.Get(h); v != "" {
+				headers.Set(h, v)
+			}
+		}
+	}
+	headers.Set("Content-Type", "application/json")
+	return headers
+}
+
+// post sends request to endpoint with retries and decodes JSON reply.
+func (p *HTTPProxy) post(ctx context.Context, endpoint string, headers http.Header, req interface{}, reply interface{}) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+
+	var body []byte
+	for attempt := 0; ; attempt++ {
+		body, err = p.doPost(ctx, endpoint, headers, data)
+		if err == nil || !httpProxyRetryable(err) || attempt >= p.config.MaxRetries || ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-time.After(p.config.RetryInterval):
+		case <-ctx.Done():
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, reply)
+}
+
+func (p *HTTPProxy) doPost(ctx context.Context, endpoint string, headers http.Header, data []byte) ([]byte, error) {
+	r, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	r = r.WithContext(ctx)
+	for k, v := range headers {
+		r.Header[k] = v
+	}
+	resp, err := p.client.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &httpProxyStatusError{code: resp.StatusCode}
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (p *HTTPProxy) logError(msg string, err error, fields map[string]interface{}) {
+	fields["error"] = err.Error()
+	p.node.logger.log(newLogEntry(LogLevelError, msg, fields))
+}
+
+// Bind sets proxy event handlers for all configured endpoints. It sets
+// ClientConnecting, ClientRefresh and ClientConnected Node handlers so if
+// application needs its own ClientConnected handler it should not call Bind
+// but use ConnectingHandler, RefreshHandler and BindClient instead.
+func (p *HTTPProxy) Bind() {
+	if p.config.ConnectEndpoint != "" {
+		p.node.On().ClientConnecting(p.ConnectingHandler())
+	}
+	if p.config.RefreshEndpoint != "" {
+		p.node.On().ClientRefresh(p.RefreshHandler())
+	}
+	p.node.On().ClientConnected(func(ctx context.Context, c *Client) {
+		p.BindClient(c)
+	})
+}
+
+// BindClient sets proxy Subscribe, Publish and RPC handlers to client
+// for configured endpoints.
+func (p *HTTPProxy) BindClient(c *Client) {
+	if p.config.SubscribeEndpoint != "" {
+		c.On().Subscribe(p.SubscribeHandler(c))
+	}
+	if p.config.PublishEndpoint != "" {
+		c.On().Publish(p.PublishHandler(c))
+	}
+	if p.config.RPCEndpoint != "" {
+		c.On().RPC(p.RPCHandler(c))
+	}
+}
+
+// ConnectingHandler returns ConnectingHandler which proxies connect events.
+func (p *HTTPProxy) ConnectingHandler() ConnectingHandler {
+	return func(ctx context.Context, t Transport, e ConnectEvent) ConnectReply {
+		req := proxyConnectRequest{
+			proxyRequest: proxyRequest{
+				Client:    e.ClientID,
+				Transport: t.Name(),
+				Encoding:  t.Encoding(),
+			},
+		}
+		req.Data, req.B64Data = encodeProxyData(t.Encoding(), e.Data)
+
+		var reply proxyConnectReply
+		err := p.post(ctx, p.config.ConnectEndpoint, p.headers(t.Info().Request), req, &reply)
+		if err != nil {
+			p.logError("error proxying connect", err, map[string]interface{}{"client": e.ClientID})
+			return ConnectReply{Error: ErrorInternal}
+		}
+		if d := reply.disconnect(); d != nil {
+			return ConnectReply{Disconnect: d}
+		}
+		if reply.Error != nil {
+			return ConnectReply{Error: reply.Error}
+		}
+		if reply.Result == nil {
+			return ConnectReply{}
+		}
+		info, err := decodeProxyData(reply.Result.Info, reply.Result.B64Info)
+		if err != nil {
+			p.logError("error decoding connect proxy info", err, map[string]interface{}{"client": e.ClientID})
+			return ConnectReply{Error: ErrorInternal}
+		}
+		data, err := decodeProxyData(reply.Result.Data, reply.Result.B64Data)
+		if err != nil {
+			p.logError("error decoding connect proxy data", err, map[string]interface{}{"client": e.ClientID})
+			return ConnectReply{Error: ErrorInternal}
+		}
+		return ConnectReply{
+			Credentials: &Credentials{
+				UserID:   reply.Result.User,
+				ExpireAt: reply.Result.ExpireAt,
+				Info:     info,
+			},
+			Data: data,
+		}
+	}
+}
+
+// RefreshHandler returns RefreshHandler which proxies refresh events.
+func (p *HTTPProxy) RefreshHandler() RefreshHandler {
+	return func(ctx context.Context, c *Client, e RefreshEvent) RefreshReply {
+		req := proxyRefreshRequest{
+			proxyRequest: newProxyRequest(c),
+		}
+		var reply proxyRefreshReply
+		err := p.post(ctx, p.config.RefreshEndpoint, p.headers(c.Transport().Info().Request), req, &reply)
+		if err != nil {
+			// Give client a chance to refresh later, connection will be closed
+			// after current expiration time passed.
+			p.logError("error proxying refresh", err, map[string]interface{}{"client": c.ID(), "user": c.UserID()})
+			return RefreshReply{}
+		}
+		if reply.Result == nil || reply.Result.Expired {
+			return RefreshReply{}
+		}
+		info, err := decodeProxyData(reply.Result.Info, reply.Result.B64Info)
+		if err != nil {
+			p.logError("error decoding refresh proxy info", err, map[string]interface{}{"client": c.ID(), "user": c.UserID()})
+			return RefreshReply{}
+		}
+		return RefreshReply{
+			ExpireAt: reply.Result.ExpireAt,
+			Info:     info,
+		}
+	}
+}
+
+// SubscribeHandler returns SubscribeHandler which proxies subscribe events
+// of client.
+func (p *HTTPProxy) SubscribeHandler(c *Client) SubscribeHandler {
+	return func(e SubscribeEvent) SubscribeReply {
+		req := proxySubscribeRequest{
+			proxyRequest: newProxyRequest(c),
+			Channel:      e.Channel,
+		}
+		var reply proxySubscribeReply
+		err := p.post(c.ctx, p.config.SubscribeEndpoint, p.headers(c.Transport().Info().Request), req, &reply)
+		if err != nil {
+			p.logError("error proxying subscribe", err, map[string]interface{}{"client": c.ID(), "user": c.UserID(), "channel": e.Channel})
+			return SubscribeReply{Error: ErrorInternal}
+		}
+		if d := reply.disconnect(); d != nil {
+			return SubscribeReply{Disconnect: d}
+		}
+		if reply.Error != nil {
+			return SubscribeReply{Error: reply.Error}
+		}
+		if reply.Result == nil {
+			return SubscribeReply{}
+		}
+		info, err := decodeProxyData(reply.Result.Info, reply.Result.B64Info)
+		if err != nil {
+			p.logError("error decoding subscribe proxy info", err, map[string]interface{}{"client": c.ID(), "user": c.UserID(), "channel": e.Channel})
+			return SubscribeReply{Error: ErrorInternal}
+		}
+		return SubscribeReply{
+			ExpireAt:    reply.Result.ExpireAt,
+			ChannelInfo: info,
+		}
+	}
+}
+
+// PublishHandler returns PublishHandler which proxies publish events of
+// client.
+func (p *HTTPProxy) PublishHandler(c *Client) PublishHandler {
+	return func(e PublishEvent) PublishReply {
+		req := proxyPublishRequest{
+			proxyRequest: newProxyRequest(c),
+			Channel:      e.Channel,
+		}
+		req.Data, req.B64Data = encodeProxyData(c.Transport().Encoding(), e.Data)
+		var reply proxyPublishReply
+		err := p.post(c.ctx, p.config.PublishEndpoint, p.headers(c.Transport().Info().Request), req, &reply)
+		if err != nil {
+			p.logError("error proxying publish", err, map[string]interface{}{"client": c.ID(), "user": c.UserID(), "channel": e.Channel})
+			return PublishReply{Error: ErrorInternal}
+		}
+		if d := reply.disconnect(); d != nil {
+			return PublishReply{Disconnect: d}
+		}
+		return PublishReply{Error: reply.Error}
+	}
+}
+
+// RPCHandler returns RPCHandler which proxies RPC events of client.
+func (p *HTTPProxy) RPCHandler(c *Client) RPCHandler {
+	return func(e RPCEvent) RPCReply {
+		req := proxyRPCRequest{
+			proxyRequest: newProxyRequest(c),
+			Method:       e.Method,
+		}
+		req.Data, req.B64Data = encodeProxyData(c.Transport().Encoding(), e.Data)
+		var reply proxyRPCReply
+		err := p.post(c.ctx, p.config.RPCEndpoint, p.headers(c.Transport().Info().Request), req, &reply)
+		if err != nil {
+			p.logError("error proxying RPC", err, map[string]interface{}{"client": c.ID(), "user": c.UserID()})
+			return RPCReply{Error: ErrorInternal}
+		}
+		if d := reply.disconnect(); d != nil {
+			return RPCReply{Disconnect: d}
+		}
+		if reply.Error != nil {
+			return RPCReply{Error: reply.Error}
+		}
+		if reply.Result == nil {
+			return RPCReply{}
+		}
+		data, err := decodeProxyData(reply.Result.Data, reply.Result.B64Data)
+		if err != nil {
+			p.logError("error decoding RPC proxy data", err, map[string]interface{}{"client": c.ID(), "user": c.UserID()})
+			return RPCReply{Error: ErrorInternal}
+		}
+		return RPCReply{Data: data}
+	}
+}
@@ -0,0 +1,167 @@
+package centrifuge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHTTPProxyBackend(publishFailures int32) (*httptest.Server, *int32) {
+	var publishAttempts int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/connect", func(w http.ResponseWriter, r *http.Request) {
+		var req proxyConnectRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.Header.Get("Authorization") != "Bearer test" {
+			w.Write([]byte(`{"error":{"code":101,"message":"unauthorized"}}`))
+			return
+		}
+		w.Write([]byte(`{"result":{"user":"42","info":{"name":"test"},"data":{"echo":` + string(req.Data) + `}}}`))
+	})
+	mux.HandleFunc("/subscribe", func(w http.ResponseWriter, r *http.Request) {
+		var req proxySubscribeRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Channel == "forbidden" {
+			w.Write([]byte(`{"error":{"code":103,"message":"permission denied"}}`))
+			return
+		}
+		w.Write([]byte(`{"result":{}}`))
+	})
+	mux.HandleFunc("/publish", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&publishAttempts, 1) <= publishFailures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/rpc", func(w http.ResponseWriter, r *http.Request) {
+		var req proxyRPCRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.User != "42" {
+			w.Write([]byte(`{"disconnect":{"code":4000,"reason":"unknown user","reconnect":false}}`))
+			return
+		}
+		if req.Method != "" {
+			w.Write([]byte(`{"result":{"data":"` + req.Method + `"}}`))
+			return
+		}
+		w.Write([]byte(`{"result":{"data":` + string(req.Data) + `}}`))
+	})
+	return httptest.NewServer(mux), &publishAttempts
+}
+
+func newTestHTTPProxyClient(t *testing.T, node *Node, header string) (*Client, *proto.ConnectResponse) {
+	transport := newTestTransport()
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", header)
+	transport.req = req
+	client, _ := newClient(context.Background(), node, transport)
+	resp, disconnect := client.connectCmd(&proto.ConnectRequest{Data: []byte(`"hello"`)})
+	assert.Nil(t, disconnect)
+	if resp.Error == nil && node.eventHub.connectedHandler != nil {
+		node.eventHub.connectedHandler(client.ctx, client)
+	}
+	return client, resp
+}
+
+func TestHTTPProxyConnect(t *testing.T) {
+	server, _ := newTestHTTPProxyBackend(0)
+	defer server.Close()
+
+	node := nodeWithMemoryEngine()
+	proxy := NewHTTPProxy(node, HTTPProxyConfig{
+		ConnectEndpoint: server.URL + "/connect",
+		PassHeaders:     []string{"Authorization"},
+	})
+	proxy.Bind()
+
+	client, resp := newTestHTTPProxyClient(t, node, "Bearer test")
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, "42", client.UserID())
+	assert.Equal(t, `{"name":"test"}`, string(client.info))
+	assert.Equal(t, `{"echo":"hello"}`, string(resp.Result.Data))
+
+	_, resp = newTestHTTPProxyClient(t, node, "Bearer wrong")
+	assert.Equal(t, ErrorUnauthorized.Code, resp.Error.Code)
+}
+
+func TestHTTPProxyClientEvents(t *testing.T) {
+	server, publishAttempts := newTestHTTPProxyBackend(2)
+	defer server.Close()
+
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.Publish = true
+	node.Reload(config)
+
+	proxy := NewHTTPProxy(node, HTTPProxyConfig{
+		ConnectEndpoint:   server.URL + "/connect",
+		SubscribeEndpoint: server.URL + "/subscribe",
+		PublishEndpoint:   server.URL + "/publish",
+		RPCEndpoint:       server.URL + "/rpc",
+		PassHeaders:       []string{"Authorization"},
+		MaxRetries:        2,
+		RetryInterval:     time.Millisecond,
+	})
+	proxy.Bind()
+
+	client, _ := newTestHTTPProxyClient(t, node, "Bearer test")
+
+	var replies []*proto.Reply
+	rw := testReplyWriter(&replies)
+	disconnect := client.subscribeCmd(&proto.SubscribeRequest{Channel: "test"}, rw)
+	assert.Nil(t, disconnect)
+	assert.Nil(t, replies[0].Error)
+
+	replies = nil
+	disconnect = client.subscribeCmd(&proto.SubscribeRequest{Channel: "forbidden"}, rw)
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorPermissionDenied.Code, replies[0].Error.Code)
+
+	resp, disconnect := client.publishCmd(&proto.PublishRequest{Channel: "test", Data: []byte(`{}`)})
+	assert.Nil(t, disconnect)
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, int32(3), atomic.LoadInt32(publishAttempts))
+
+	replies = nil
+	params, _ := json.Marshal(&proto.RPCRequest{Data: []byte(`{"rpc":true}`)})
+	disconnect = client.handleRPC(params, rw)
+	assert.Nil(t, disconnect)
+	var rpcResult proto.RPCResult
+	json.Unmarshal(replies[0].Result, &rpcResult)
+	assert.Equal(t, `{"rpc":true}`, string(rpcResult.Data))
+
+	replies = nil
+	params, _ = json.Marshal(&proto.RPCRequest{Method: "test", Data: []byte(`{}`)})
+	disconnect = client.handleRPC(params, rw)
+	assert.Nil(t, disconnect)
+	json.Unmarshal(replies[0].Result, &rpcResult)
+	assert.Equal(t, `"test"`, string(rpcResult.Data))
+}
+
+func TestHTTPProxyNoRetryOnClientError(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	node := nodeWithMemoryEngine()
+	proxy := NewHTTPProxy(node, HTTPProxyConfig{
+		ConnectEndpoint: server.URL,
+		MaxRetries:      3,
+	})
+	proxy.Bind()
+
+	_, resp := newTestHTTPProxyClient(t, node, "")
+	assert.Equal(t, ErrorInternal.Code, resp.Error.Code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
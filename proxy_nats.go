@@ -0,0 +1,162 @@
+package centrifuge
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSRPCProxyConfig is a configuration of NATSRPCProxy.
+type NATSRPCProxyConfig struct {
+	// Servers is a comma separated list of NATS server URLs, nats.DefaultURL
+	// by default.
+	Servers string
+	// SubjectPrefix prepended to RPC method to get NATS subject, "rpc." by
+	// default. So RPC with method "user.get" sent to "rpc.user.get" subject.
+	SubjectPrefix string
+	// Timeout to wait for responder reply.
+	Timeout time.Duration
+	// Options allow to set custom NATS connection options. By default
+	// connection reconnects forever and does not buffer requests while
+	// disconnected so they fail fast.
+	Options []nats.Option
+}
+
+const (
+	defaultNATSRPCProxyTimeout       = time.Second
+	defaultNATSRPCProxySubjectPrefix = "rpc."
+)
+
+// natsRequester is implemented by *nats.Conn.
+type natsRequester interface {
+	RequestWithContext(ctx context.Context, subj string, data []byte) (*nats.Msg, error)
+	Close()
+}
+
+// NATSRPCProxy routes client RPC calls to NATS request/reply subjects derived
+// from RPC method so services subscribed on those subjects can answer
+// realtime RPCs. Request and reply payloads are the same JSON objects
+// HTTPProxy sends to and expects from RPC endpoint.
+type NATSRPCProxy struct {
+	node   *Node
+	config NATSRPCProxyConfig
+	conn   natsRequester
+}
+
+// NewNATSRPCProxy creates new NATSRPCProxy and connects to NATS.
+func NewNATSRPCProxy(n *Node, c NATSRPCProxyConfig) (*NATSRPCProxy, error) {
+	if c.Servers == "" {
+		c.Servers = nats.DefaultURL
+	}
+	if c.SubjectPrefix == "" {
+		c.SubjectPrefix = defaultNATSRPCProxySubjectPrefix
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultNATSRPCProxyTimeout
+	}
+	opts := c.Options
+	if len(opts) == 0 {
+		opts = []nats.Option{nats.ReconnectBufSize(-1), nats.MaxReconnects(-1)}
+	}
+	conn, err := nats.Connect(c.Servers, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &NATSRPCProxy{
+		node:   n,
+		config: c,
+		conn:   conn,
+	}, nil
+}
+
+// Close closes connection to NATS.
+func (p *NATSRPCProxy) Close() error {
+	p.conn.Close()
+	return nil
+}
+
+// Bind sets ClientConnected Node handler which sets RPC handler to every
+// client. If application needs its own ClientConnected handler it should
+// not call Bind but use BindClient instead.
+func (p *NATSRPCProxy) Bind() {
+	p.node.On().ClientConnected(func(ctx context.Context, c *Client) {
+		p.BindClient(c)
+	})
+}
+
+// BindClient sets proxy RPC handler to client.
+func (p *NATSRPCProxy) BindClient(c *Client) {
+	c.On().RPC(p.RPCHandler(c))
+}
+
+// validNATSSubjectMethod checks that method is a valid NATS subject part
+// without wildcards, so client can't send requests to arbitrary subjects.
+func validNATSSubjectMethod(method string) bool {
+	for _, token := range strings.Split(method, ".") {
+		if token == "" || token == "*" || token == ">" {
+			return false
+		}
+		if strings.ContainsAny(token, " \t\r\n") {
+			return false
+		}
+	}
+	return true
+}
+
+// RPCHandler returns RPCHandler which sends RPC events of client to NATS.
+func (p *NATSRPCProxy) RPCHandler(c *Client) RPCHandler {
+	return func(e RPCEvent) RPCReply {
+		if e.Method == "" {
+			return RPCReply{Error: ErrorMethodNotFound}
+		}
+		if !validNATSSubjectMethod(e.Method) {
+			return RPCReply{Error: ErrorBadRequest}
+		}
+		req := proxyRPCRequest{
+			proxyRequest: newProxyRequest(c),
+			Method:       e.Method,
+		}
+		req.Data, req.B64Data = encodeProxyData(c.Transport().Encoding(), e.Data)
+		data, err := json.Marshal(req)
+		if err != nil {
+			return RPCReply{Error: ErrorInternal}
+		}
+
+		ctx, cancel := context.WithTimeout(c.ctx, p.config.Timeout)
+		defer cancel()
+		msg, err := p.conn.RequestWithContext(ctx, p.config.SubjectPrefix+e.Method, data)
+		if err != nil {
+			if err == context.DeadlineExceeded || err == nats.ErrTimeout {
+				// No responder answered in time.
+				p.node.logger.log(newLogEntry(LogLevelInfo, "NATS RPC timeout", map[string]interface{}{"method": e.Method, "client": c.ID(), "user": c.UserID()}))
+				return RPCReply{Error: ErrorNotAvailable}
+			}
+			p.node.logger.log(newLogEntry(LogLevelError, "error sending NATS RPC request", map[string]interface{}{"method": e.Method, "client": c.ID(), "user": c.UserID(), "error": err.Error()}))
+			return RPCReply{Error: ErrorInternal}
+		}
+
+		var reply proxyRPCReply
+		if err := json.Unmarshal(msg.Data, &reply); err != nil {
+			p.node.logger.log(newLogEntry(LogLevelError, "error decoding NATS RPC reply", map[string]interface{}{"method": e.Method, "client": c.ID(), "user": c.UserID(), "error": err.Error()}))
+			return RPCReply{Error: ErrorInternal}
+		}
+		if d := reply.disconnect(); d != nil {
+			return RPCReply{Disconnect: d}
+		}
+		if reply.Error != nil {
+			return RPCReply{Error: reply.Error}
+		}
+		if reply.Result == nil {
+			return RPCReply{}
+		}
+		result, err := decodeProxyData(reply.Result.Data, reply.Result.B64Data)
+		if err != nil {
+			p.node.logger.log(newLogEntry(LogLevelError, "error decoding NATS RPC reply data", map[string]interface{}{"method": e.Method, "client": c.ID(), "user": c.UserID(), "error": err.Error()}))
+			return RPCReply{Error: ErrorInternal}
+		}
+		return RPCReply{Data: result}
+	}
+}
@@ -0,0 +1,110 @@
+package centrifuge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+// testNATSResponder emulates services subscribed on RPC subjects.
+type testNATSResponder struct {
+	subjects []string
+	requests []proxyRPCRequest
+	handler  func(subj string) ([]byte, error)
+}
+
+func (r *testNATSResponder) RequestWithContext(ctx context.Context, subj string, data []byte) (*nats.Msg, error) {
+	var req proxyRPCRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	r.subjects = append(r.subjects, subj)
+	r.requests = append(r.requests, req)
+	reply, err := r.handler(subj)
+	if err != nil {
+		return nil, err
+	}
+	return &nats.Msg{Subject: subj, Data: reply}, nil
+}
+
+func (r *testNATSResponder) Close() {}
+
+func newTestNATSRPCProxyClient(t *testing.T, responder *testNATSResponder) (*NATSRPCProxy, *Client) {
+	node := nodeWithMemoryEngine()
+	proxy := &NATSRPCProxy{
+		node: node,
+		config: NATSRPCProxyConfig{
+			SubjectPrefix: defaultNATSRPCProxySubjectPrefix,
+			Timeout:       time.Second,
+		},
+		conn: responder,
+	}
+	ctx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(ctx, node, newTestTransport())
+	connectClient(t, client)
+	return proxy, client
+}
+
+func TestNATSRPCProxy(t *testing.T) {
+	responder := &testNATSResponder{handler: func(subj string) ([]byte, error) {
+		switch subj {
+		case "rpc.time":
+			return []byte(`{"result":{"data":{"time":"now"}}}`), nil
+		case "rpc.forbidden":
+			return []byte(`{"error":{"code":103,"message":"permission denied"}}`), nil
+		default:
+			return []byte(`{"disconnect":{"code":4000,"reason":"bye","reconnect":false}}`), nil
+		}
+	}}
+	proxy, client := newTestNATSRPCProxyClient(t, responder)
+	handler := proxy.RPCHandler(client)
+
+	reply := handler(RPCEvent{Method: "time", Data: Raw(`{"tz":"UTC"}`)})
+	assert.Nil(t, reply.Error)
+	assert.Equal(t, `{"time":"now"}`, string(reply.Data))
+	assert.Equal(t, "rpc.time", responder.subjects[0])
+	assert.Equal(t, "42", responder.requests[0].User)
+	assert.Equal(t, client.ID(), responder.requests[0].Client)
+	assert.Equal(t, "time", responder.requests[0].Method)
+	assert.Equal(t, `{"tz":"UTC"}`, string(responder.requests[0].Data))
+
+	reply = handler(RPCEvent{Method: "forbidden"})
+	assert.Equal(t, ErrorPermissionDenied.Code, reply.Error.Code)
+
+	reply = handler(RPCEvent{Method: "user.kick"})
+	assert.NotNil(t, reply.Disconnect)
+	assert.Equal(t, 4000, reply.Disconnect.Code)
+}
+
+func TestNATSRPCProxyInvalidMethod(t *testing.T) {
+	responder := &testNATSResponder{handler: func(subj string) ([]byte, error) {
+		return []byte(`{}`), nil
+	}}
+	proxy, client := newTestNATSRPCProxyClient(t, responder)
+	handler := proxy.RPCHandler(client)
+
+	assert.Equal(t, ErrorMethodNotFound, handler(RPCEvent{}).Error)
+	for _, method := range []string{"user.", "user.*", ">", "user get"} {
+		assert.Equal(t, ErrorBadRequest, handler(RPCEvent{Method: method}).Error)
+	}
+	assert.Len(t, responder.subjects, 0)
+}
+
+func TestNATSRPCProxyRequestError(t *testing.T) {
+	responder := &testNATSResponder{handler: func(subj string) ([]byte, error) {
+		if subj == "rpc.slow" {
+			return nil, context.DeadlineExceeded
+		}
+		return nil, errors.New("boom")
+	}}
+	proxy, client := newTestNATSRPCProxyClient(t, responder)
+	handler := proxy.RPCHandler(client)
+
+	assert.Equal(t, ErrorNotAvailable, handler(RPCEvent{Method: "slow"}).Error)
+	assert.Equal(t, ErrorInternal, handler(RPCEvent{Method: "broken"}).Error)
+}
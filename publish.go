@@ -1,9 +1,31 @@
 package centrifuge
 
+import (
+	"time"
+)
+
 // PublishOptions define some fields to alter behaviour of Publish operation.
 type PublishOptions struct {
 	// SkipHistory allows to prevent saving specific Publication to channel history.
 	SkipHistory bool
+	// PublishAt allows to publish publication later. Zero value means
+	// publishing immediately.
+	PublishAt time.Time
+	// TTL sets publication lifetime. Expired publication is not delivered to
+	// clients and skipped in history and recovery. Zero value means
+	// publication never expires.
+	TTL time.Duration
+	// IdempotencyKey allows to skip duplicate publications into channel
+	// with the same key, see WithIdempotencyKey.
+	IdempotencyKey string
+	// cloudEvent is an original CloudEvent to publish as is into channels
+	// with CloudEvents option enabled.
+	cloudEvent *CloudEvent
+	// op is an operation of publication, edits and tombstones reference
+	// previous publication by refSeq and refGen.
+	op     PublicationOp
+	refSeq uint32
+	refGen uint32
 }
 
 // PublishOption is a type to represent various Publish options.
@@ -15,3 +37,44 @@ func SkipHistory() PublishOption {
 		opts.SkipHistory = true
 	}
 }
+
+// WithTTL allows to set publication TTL.
+func WithTTL(ttl time.Duration) PublishOption {
+	return func(opts *PublishOptions) {
+		opts.TTL = ttl
+	}
+}
+
+// publicationExpired checks whether publication has expiration time and it
+// has already passed.
+func publicationExpired(pub *Publication, nowMs int64) bool {
+	return pub.ExpireAt > 0 && pub.ExpireAt <= nowMs
+}
+
+// unexpiredPublications filters out expired publications.
+func unexpiredPublications(pubs []*Publication) []*Publication {
+	nowMs := time.Now().UnixNano() / int64(time.Millisecond)
+	filtered := pubs[:0]
+	for _, pub := range pubs {
+		if !publicationExpired(pub, nowMs) {
+			filtered = append(filtered, pub)
+		}
+	}
+	return filtered
+}
+
+// withCloudEvent passes original CloudEvent received over ingest endpoint.
+func withCloudEvent(event *CloudEvent) PublishOption {
+	return func(opts *PublishOptions) {
+		opts.cloudEvent = event
+	}
+}
+
+// withReference makes publication change previous publication with offset.
+func withReference(op PublicationOp, seq uint32, gen uint32) PublishOption {
+	return func(opts *PublishOptions) {
+		opts.op = op
+		opts.refSeq = seq
+		opts.refGen = gen
+	}
+}
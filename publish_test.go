@@ -1,7 +1,11 @@
 package centrifuge
 
 import (
+	"context"
 	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -12,3 +16,101 @@ func TestWithUID(t *testing.T) {
 	opt(opts)
 	assert.Equal(t, true, opts.SkipHistory)
 }
+
+func TestWithTTL(t *testing.T) {
+	opts := &PublishOptions{}
+	WithTTL(time.Second)(opts)
+	assert.Equal(t, time.Second, opts.TTL)
+}
+
+func TestPublishTTLHistory(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	assert.NoError(t, node.Reload(config))
+
+	assert.NoError(t, node.Publish("test", []byte(`{}`), WithTTL(time.Hour)))
+	chOpts, _ := node.ChannelOpts("test")
+	_, err := node.historyManager.AddHistory("test", &Publication{Data: []byte(`{}`), ExpireAt: 1}, &chOpts)
+	assert.NoError(t, err)
+
+	history, err := node.History("test")
+	pubs := history.Publications
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 1)
+	assert.True(t, pubs[0].ExpireAt > time.Now().UnixNano()/int64(time.Millisecond))
+}
+
+func TestPublishTTLRecovery(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	config.HistoryRecover = true
+	assert.NoError(t, node.Reload(config))
+
+	chOpts, _ := node.ChannelOpts("test")
+	_, recoveryPosition, _ := node.historyManager.History("test", HistoryFilter{})
+	assert.NoError(t, node.Publish("test", []byte(`{}`)))
+	_, err := node.historyManager.AddHistory("test", &Publication{Data: []byte(`{}`), ExpireAt: 1}, &chOpts)
+	assert.NoError(t, err)
+	assert.NoError(t, node.Publish("test", []byte(`{}`)))
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	replies := []*proto.Reply{}
+	rw := testReplyWriter(&replies)
+	disconnect := client.subscribeCmd(&proto.SubscribeRequest{
+		Channel: "test",
+		Recover: true,
+		Seq:     recoveryPosition.Seq,
+		Gen:     recoveryPosition.Gen,
+		Epoch:   recoveryPosition.Epoch,
+	}, rw)
+	assert.Nil(t, disconnect)
+	res := extractSubscribeResult(replies)
+	assert.True(t, res.Recovered)
+	assert.Len(t, res.Publications, 2)
+	assert.Equal(t, uint32(3), res.Seq)
+}
+
+func TestPublishTTLDelivery(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	config.HistoryRecover = true
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	subscribeClient(t, client, "test")
+
+	chOpts, _ := node.ChannelOpts("test")
+	reply := newPreparedReply(&proto.Reply{}, proto.EncodingJSON)
+	assert.NoError(t, client.writePublication("test", &Publication{Seq: 1, ExpireAt: 1}, reply, &chOpts))
+	assert.NoError(t, client.writePublication("test", &Publication{Seq: 2}, reply, &chOpts))
+
+	select {
+	case <-transport.sink:
+	case <-time.After(time.Second):
+		t.Fatal("publication not delivered")
+	}
+	select {
+	case <-transport.sink:
+		t.Fatal("expired publication delivered")
+	case <-time.After(100 * time.Millisecond):
+	}
+	client.mu.RLock()
+	assert.Equal(t, uint32(2), client.channels["test"].recoveryPosition.Seq)
+	client.mu.RUnlock()
+}
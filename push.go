@@ -0,0 +1,243 @@
+package centrifuge
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Push platforms supported by built-in push providers.
+const (
+	PushPlatformFCM  = "fcm"
+	PushPlatformAPNs = "apns"
+)
+
+// DeviceToken identifies user device registered to receive push notifications.
+type DeviceToken struct {
+	// Platform is a push platform of device, for example PushPlatformFCM.
+	Platform string `json:"platform"`
+	// Token is a device token (registration ID) issued by push platform.
+	Token string `json:"token"`
+}
+
+// PushNotification is sent to offline users when publication in channel
+// with PushFallback option enabled can't be delivered to them in realtime.
+type PushNotification struct {
+	// Channel publication was sent to.
+	Channel string
+	// User notification sent to.
+	User string
+	// Data is publication data.
+	Data Raw
+}
+
+// PushProvider sends push notifications over one push platform.
+type PushProvider interface {
+	// Push sends notification to device tokens. It returns tokens which were
+	// reported as invalid or unregistered by push platform – such tokens
+	// will be unregistered by Node.
+	Push(ctx context.Context, tokens []string, notification PushNotification) ([]string, error)
+}
+
+// DeviceTokenStore keeps device tokens of users. Node uses in-memory store
+// by default which is only suitable for single node setups – provide shared
+// store with Node.SetDeviceTokenStore when running several nodes.
+type DeviceTokenStore interface {
+	// AddDeviceToken registers device token for user.
+	AddDeviceToken(user string, token DeviceToken) error
+	// RemoveDeviceToken unregisters device token of user.
+	RemoveDeviceToken(user string, token DeviceToken) error
+	// DeviceTokens returns all device tokens registered for user.
+	DeviceTokens(user string) ([]DeviceToken, error)
+}
+
+// pushTimeout is a timeout for sending notifications to one user.
+const pushTimeout = 10 * time.Second
+
+type memoryDeviceTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]map[DeviceToken]struct{}
+}
+
+func newMemoryDeviceTokenStore() *memoryDeviceTokenStore {
+	return &memoryDeviceTokenStore{
+		tokens: make(map[string]map[DeviceToken]struct{}),
+	}
+}
+
+func (s *memoryDeviceTokenStore) AddDeviceToken(user string, token DeviceToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tokens[user]; !ok {
+		s.tokens[user] = make(map[DeviceToken]struct{})
+	}
+	s.tokens[user][token] = struct{}{}
+	return nil
+}
+
+func (s *memoryDeviceTokenStore) RemoveDeviceToken(user string, token DeviceToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens[user], token)
+	if len(s.tokens[user]) == 0 {
+		delete(s.tokens, user)
+	}
+	return nil
+}
+
+func (s *memoryDeviceTokenStore) DeviceTokens(user string) ([]DeviceToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tokens := make([]DeviceToken, 0, len(s.tokens[user]))
+	for token := range s.tokens[user] {
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+// SetPushProvider sets provider to send push notifications to devices of
+// platform.
+func (n *Node) SetPushProvider(platform string, p PushProvider) {
+	n.pushMu.Lock()
+	defer n.pushMu.Unlock()
+	n.pushProviders[platform] = p
+}
+
+// SetDeviceTokenStore sets store to keep device tokens in.
+func (n *Node) SetDeviceTokenStore(s DeviceTokenStore) {
+	n.pushMu.Lock()
+	defer n.pushMu.Unlock()
+	n.deviceTokens = s
+}
+
+// RegisterDeviceToken registers device of user to receive push notifications.
+func (n *Node) RegisterDeviceToken(user string, token DeviceToken) error {
+	n.pushMu.RLock()
+	store := n.deviceTokens
+	n.pushMu.RUnlock()
+	return store.AddDeviceToken(user, token)
+}
+
+// UnregisterDeviceToken removes device token of user.
+func (n *Node) UnregisterDeviceToken(user string, token DeviceToken) error {
+	n.pushMu.RLock()
+	store := n.deviceTokens
+	n.pushMu.RUnlock()
+	return store.RemoveDeviceToken(user, token)
+}
+
+// channelUsers returns users from user part of channel name. Empty slice
+// returned for channels which are not user limited.
+func (n *Node) channelUsers(ch string) []string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	userBoundary := n.config.ChannelUserBoundary
+	userSeparator := n.config.ChannelUserSeparator
+	if userBoundary == "" || !strings.Contains(ch, userBoundary) {
+		return nil
+	}
+	parts := strings.Split(ch, userBoundary)
+	userPart := parts[len(parts)-1]
+	if userSeparator == "" {
+		return []string{userPart}
+	}
+	var users []string
+	for _, user := range strings.Split(userPart, userSeparator) {
+		if user != "" {
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
+// offlineUsers returns users from list which are not online in channel. If
+// presence enabled for channel then user considered online if it has at least
+// one connection subscribed on channel on any node, otherwise only connections
+// to current node are taken into account.
+func (n *Node) offlineUsers(ch string, users []string, chOpts *ChannelOptions) ([]string, error) {
+	online := map[string]struct{}{}
+	if chOpts.Presence && n.presenceManager != nil {
+		presence, err := n.Presence(ch)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range presence {
+			online[info.User] = struct{}{}
+		}
+	} else {
+		for _, user := range users {
+			if len(n.hub.userConnections(user)) > 0 {
+				online[user] = struct{}{}
+			}
+		}
+	}
+	var offline []string
+	for _, user := range users {
+		if _, ok := online[user]; !ok {
+			offline = append(offline, user)
+		}
+	}
+	return offline, nil
+}
+
+// pushFallback sends publication as push notification to users of user
+// limited channel who are currently offline. Online check is done before
+// returning while notifications are sent in background.
+func (n *Node) pushFallback(ch string, data Raw, chOpts *ChannelOptions) {
+	users := n.channelUsers(ch)
+	if len(users) == 0 {
+		return
+	}
+	offline, err := n.offlineUsers(ch, users, chOpts)
+	if err != nil {
+		n.logger.log(newLogEntry(LogLevelError, "error checking online users for push fallback", map[string]interface{}{"channel": ch, "error": err.Error()}))
+		return
+	}
+	for _, user := range offline {
+		go n.pushUser(ch, user, data)
+	}
+}
+
+func (n *Node) pushUser(ch string, user string, data Raw) {
+	n.pushMu.RLock()
+	store := n.deviceTokens
+	providers := make(map[string]PushProvider, len(n.pushProviders))
+	for platform, p := range n.pushProviders {
+		providers[platform] = p
+	}
+	n.pushMu.RUnlock()
+
+	deviceTokens, err := store.DeviceTokens(user)
+	if err != nil {
+		n.logger.log(newLogEntry(LogLevelError, "error getting device tokens", map[string]interface{}{"user": user, "error": err.Error()}))
+		return
+	}
+	byPlatform := map[string][]string{}
+	for _, t := range deviceTokens {
+		byPlatform[t.Platform] = append(byPlatform[t.Platform], t.Token)
+	}
+
+	notification := PushNotification{
+		Channel: ch,
+		User:    user,
+		Data:    data,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pushTimeout)
+	defer cancel()
+
+	for platform, tokens := range byPlatform {
+		p, ok := providers[platform]
+		if !ok {
+			continue
+		}
+		invalid, err := p.Push(ctx, tokens, notification)
+		if err != nil {
+			n.logger.log(newLogEntry(LogLevelError, "error sending push notification", map[string]interface{}{"user": user, "platform": platform, "error": err.Error()}))
+		}
+		for _, token := range invalid {
+			store.RemoveDeviceToken(user, DeviceToken{Platform: platform, Token: token})
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package centrifuge
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	defaultAPNsEndpoint = "https://api.push.apple.com"
+	// apnsTokenLifetime is how long provider authentication token reused.
+	// Apple rejects tokens older than one hour.
+	apnsTokenLifetime = 50 * time.Minute
+)
+
+// APNsConfig is a config for APNs push provider which uses token-based
+// authentication.
+type APNsConfig struct {
+	// AuthKey is a PEM encoded .p8 authentication key.
+	AuthKey []byte
+	// KeyID is an ID of AuthKey.
+	KeyID string
+	// TeamID is Apple developer team ID.
+	TeamID string
+	// Topic is application bundle ID.
+	Topic string
+	// Endpoint is APNs server, https://api.push.apple.com by default. Use
+	// https://api.sandbox.push.apple.com for development builds.
+	Endpoint string
+	// Timeout for request to APNs, 10 seconds by default.
+	Timeout time.Duration
+	// HTTPClient allows to set custom HTTP client. Note that APNs requires
+	// HTTP/2 which default Go client negotiates automatically over TLS.
+	HTTPClient *http.Client
+}
+
+// APNsProvider sends push notifications over Apple Push Notification service.
+// Every notification is a background notification with channel and data keys,
+// data key contains publication data as string.
+type APNsProvider struct {
+	config APNsConfig
+	client *http.Client
+	key    *ecdsa.PrivateKey
+
+	mu          sync.Mutex
+	token       string
+	tokenIssued time.Time
+}
+
+// NewAPNsProvider creates APNsProvider.
+func NewAPNsProvider(c APNsConfig) (*APNsProvider, error) {
+	block, _ := pem.Decode(c.AuthKey)
+	if block == nil {
+		return nil, errors.New("APNs auth key must be PEM encoded")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("APNs auth key must be ECDSA private key")
+	}
+	if c.Endpoint == "" {
+		c.Endpoint = defaultAPNsEndpoint
+	}
+	client := c.HTTPClient
+	if client == nil {
+		timeout := c.Timeout
+		if timeout == 0 {
+			timeout = 10 * time.Second
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+	return &APNsProvider{
+		config: c,
+		client: client,
+		key:    key,
+	}, nil
+}
+
+func (p *APNsProvider) authToken() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != "" && time.Since(p.tokenIssued) < apnsTokenLifetime {
+		return p.token, nil
+	}
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": p.config.TeamID,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = p.config.KeyID
+	signed, err := token.SignedString(p.key)
+	if err != nil {
+		return "", err
+	}
+	p.token = signed
+	p.tokenIssued = now
+	return signed, nil
+}
+
+type apnsPayload struct {
+	APS     map[string]interface{} `json:"aps"`
+	Channel string                 `json:"channel"`
+	Data    string                 `json:"data"`
+}
+
+type apnsErrorResponse struct {
+	Reason string `json:"reason"`
+}
+
+// Push is a part of PushProvider interface.
+func (p *APNsProvider) Push(ctx context.Context, tokens []string, notification PushNotification) ([]string, error) {
+	body, err := json.Marshal(apnsPayload{
+		APS:     map[string]interface{}{"content-available": 1},
+		Channel: notification.Channel,
+		Data:    string(notification.Data),
+	})
+	if err != nil {
+		return nil, err
+	}
+	authToken, err := p.authToken()
+	if err != nil {
+		return nil, err
+	}
+	var invalid []string
+	var lastErr error
+	for _, token := range tokens {
+		ok, err := p.push(ctx, authToken, token, body)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if !ok {
+			invalid = append(invalid, token)
+		}
+	}
+	return invalid, lastErr
+}
+
+// push sends notification to one device, returns false if device token invalid.
+func (p *APNsProvider) push(ctx context.Context, authToken string, token string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, p.config.Endpoint+"/3/device/"+token, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "bearer "+authToken)
+	req.Header.Set("apns-topic", p.config.Topic)
+	req.Header.Set("apns-push-type", "background")
+	req.Header.Set("apns-priority", "5")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return true, nil
+	}
+	var errResp apnsErrorResponse
+	json.NewDecoder(resp.Body).Decode(&errResp)
+	if resp.StatusCode == http.StatusGone || errResp.Reason == "BadDeviceToken" || errResp.Reason == "Unregistered" {
+		return false, nil
+	}
+	return false, fmt.Errorf("unexpected APNs response status: %d, reason: %s", resp.StatusCode, errResp.Reason)
+}
@@ -0,0 +1,75 @@
+package centrifuge
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPNsProvider(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	authKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "com.example.app", r.Header.Get("apns-topic"))
+		authToken := strings.TrimPrefix(r.Header.Get("Authorization"), "bearer ")
+		token, err := jwt.Parse(authToken, func(token *jwt.Token) (interface{}, error) {
+			assert.Equal(t, "key", token.Header["kid"])
+			return &key.PublicKey, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "team", token.Claims.(jwt.MapClaims)["iss"])
+
+		var payload apnsPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		assert.Equal(t, "notifications#42", payload.Channel)
+		assert.Equal(t, `{"text":"hello"}`, payload.Data)
+
+		switch r.URL.Path {
+		case "/3/device/valid":
+			w.WriteHeader(http.StatusOK)
+		case "/3/device/unregistered":
+			w.WriteHeader(http.StatusGone)
+			w.Write([]byte(`{"reason":"Unregistered"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"reason":"BadDeviceToken"}`))
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewAPNsProvider(APNsConfig{
+		AuthKey:  authKey,
+		KeyID:    "key",
+		TeamID:   "team",
+		Topic:    "com.example.app",
+		Endpoint: server.URL,
+	})
+	assert.NoError(t, err)
+
+	invalid, err := provider.Push(context.Background(), []string{"valid", "unregistered", "bad"}, PushNotification{
+		Channel: "notifications#42",
+		Data:    Raw(`{"text":"hello"}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"unregistered", "bad"}, invalid)
+}
+
+func TestAPNsProviderInvalidKey(t *testing.T) {
+	_, err := NewAPNsProvider(APNsConfig{AuthKey: []byte("not a key")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,130 @@
+package centrifuge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultFCMEndpoint = "https://fcm.googleapis.com/fcm/send"
+	// fcmMaxRegistrationIDs is a max number of tokens in one FCM request.
+	fcmMaxRegistrationIDs = 1000
+)
+
+// FCMConfig is a config for FCM push provider.
+type FCMConfig struct {
+	// ServerKey is a Firebase Cloud Messaging server key.
+	ServerKey string
+	// Endpoint is FCM legacy HTTP API endpoint, https://fcm.googleapis.com/fcm/send
+	// by default.
+	Endpoint string
+	// Timeout for request to FCM, 10 seconds by default.
+	Timeout time.Duration
+	// HTTPClient allows to set custom HTTP client.
+	HTTPClient *http.Client
+}
+
+// FCMProvider sends push notifications over Firebase Cloud Messaging. Every
+// notification is a data message with channel and data keys, data key contains
+// publication data as string.
+type FCMProvider struct {
+	config FCMConfig
+	client *http.Client
+}
+
+// NewFCMProvider creates FCMProvider.
+func NewFCMProvider(c FCMConfig) *FCMProvider {
+	if c.Endpoint == "" {
+		c.Endpoint = defaultFCMEndpoint
+	}
+	client := c.HTTPClient
+	if client == nil {
+		timeout := c.Timeout
+		if timeout == 0 {
+			timeout = 10 * time.Second
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+	return &FCMProvider{
+		config: c,
+		client: client,
+	}
+}
+
+type fcmRequest struct {
+	RegistrationIDs []string          `json:"registration_ids"`
+	Data            map[string]string `json:"data"`
+}
+
+type fcmResponse struct {
+	Results []struct {
+		Error string `json:"error,omitempty"`
+	} `json:"results"`
+}
+
+// Push is a part of PushProvider interface.
+func (p *FCMProvider) Push(ctx context.Context, tokens []string, notification PushNotification) ([]string, error) {
+	var invalid []string
+	for len(tokens) > 0 {
+		batch := tokens
+		if len(batch) > fcmMaxRegistrationIDs {
+			batch = batch[:fcmMaxRegistrationIDs]
+		}
+		tokens = tokens[len(batch):]
+		batchInvalid, err := p.push(ctx, batch, notification)
+		invalid = append(invalid, batchInvalid...)
+		if err != nil {
+			return invalid, err
+		}
+	}
+	return invalid, nil
+}
+
+func (p *FCMProvider) push(ctx context.Context, tokens []string, notification PushNotification) ([]string, error) {
+	body, err := json.Marshal(fcmRequest{
+		RegistrationIDs: tokens,
+		Data: map[string]string{
+			"channel": notification.Channel,
+			"data":    string(notification.Data),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, p.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "key="+p.config.ServerKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected FCM response status: %d", resp.StatusCode)
+	}
+
+	var fcmResp fcmResponse
+	if err := json.NewDecoder(resp.Body).Decode(&fcmResp); err != nil {
+		return nil, err
+	}
+	var invalid []string
+	for i, result := range fcmResp.Results {
+		if i >= len(tokens) {
+			break
+		}
+		switch result.Error {
+		case "NotRegistered", "InvalidRegistration":
+			invalid = append(invalid, tokens[i])
+		}
+	}
+	return invalid, nil
+}
@@ -0,0 +1,46 @@
+package centrifuge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFCMProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key=secret", r.Header.Get("Authorization"))
+		var req fcmRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		assert.Equal(t, []string{"valid", "invalid"}, req.RegistrationIDs)
+		assert.Equal(t, "notifications#42", req.Data["channel"])
+		assert.Equal(t, `{"text":"hello"}`, req.Data["data"])
+		w.Write([]byte(`{"success":1,"failure":1,"results":[{"message_id":"1"},{"error":"NotRegistered"}]}`))
+	}))
+	defer server.Close()
+
+	provider := NewFCMProvider(FCMConfig{
+		ServerKey: "secret",
+		Endpoint:  server.URL,
+	})
+	invalid, err := provider.Push(context.Background(), []string{"valid", "invalid"}, PushNotification{
+		Channel: "notifications#42",
+		Data:    Raw(`{"text":"hello"}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"invalid"}, invalid)
+}
+
+func TestFCMProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	provider := NewFCMProvider(FCMConfig{Endpoint: server.URL})
+	_, err := provider.Push(context.Background(), []string{"token"}, PushNotification{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+package centrifuge
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPushProvider struct {
+	mu            sync.Mutex
+	notifications []PushNotification
+	tokens        [][]string
+	invalid       []string
+	done          chan struct{}
+}
+
+func newTestPushProvider() *testPushProvider {
+	return &testPushProvider{done: make(chan struct{}, 10)}
+}
+
+func (p *testPushProvider) Push(ctx context.Context, tokens []string, notification PushNotification) ([]string, error) {
+	p.mu.Lock()
+	p.notifications = append(p.notifications, notification)
+	p.tokens = append(p.tokens, tokens)
+	p.mu.Unlock()
+	p.done <- struct{}{}
+	return p.invalid, nil
+}
+
+func TestNodeChannelUsers(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	assert.Nil(t, node.channelUsers("test"))
+	assert.Equal(t, []string{"42"}, node.channelUsers("notifications#42"))
+	assert.Equal(t, []string{"1", "2"}, node.channelUsers("dialog#1,2"))
+}
+
+func TestNodeDeviceTokens(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	token := DeviceToken{Platform: PushPlatformFCM, Token: "token"}
+	assert.NoError(t, node.RegisterDeviceToken("42", token))
+	assert.NoError(t, node.RegisterDeviceToken("42", token))
+	tokens, _ := node.deviceTokens.DeviceTokens("42")
+	assert.Equal(t, []DeviceToken{token}, tokens)
+	assert.NoError(t, node.UnregisterDeviceToken("42", token))
+	tokens, _ = node.deviceTokens.DeviceTokens("42")
+	assert.Len(t, tokens, 0)
+}
+
+func TestNodePushFallback(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.PushFallback = true
+	node.Reload(config)
+
+	provider := newTestPushProvider()
+	provider.invalid = []string{"invalid"}
+	node.SetPushProvider(PushPlatformFCM, provider)
+	node.RegisterDeviceToken("42", DeviceToken{Platform: PushPlatformFCM, Token: "valid"})
+	node.RegisterDeviceToken("42", DeviceToken{Platform: PushPlatformFCM, Token: "invalid"})
+	node.RegisterDeviceToken("42", DeviceToken{Platform: PushPlatformAPNs, Token: "no provider"})
+
+	// Not user limited channel.
+	assert.NoError(t, node.Publish("test", []byte(`{}`)))
+
+	// User is online.
+	ctx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(ctx, node, newTestTransport())
+	client.connectCmd(&proto.ConnectRequest{})
+	assert.NoError(t, node.Publish("notifications#42", []byte(`{}`)))
+
+	client.Close(nil)
+	assert.NoError(t, node.Publish("notifications#42", []byte(`{"text":"hello"}`)))
+
+	select {
+	case <-provider.done:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for push notification")
+	}
+
+	provider.mu.Lock()
+	assert.Len(t, provider.notifications, 1)
+	assert.Equal(t, "notifications#42", provider.notifications[0].Channel)
+	assert.Equal(t, "42", provider.notifications[0].User)
+	assert.Equal(t, `{"text":"hello"}`, string(provider.notifications[0].Data))
+	assert.ElementsMatch(t, []string{"valid", "invalid"}, provider.tokens[0])
+	provider.mu.Unlock()
+
+	// Tokens reported as invalid are removed after push but asynchronously.
+	var tokens []DeviceToken
+	for i := 0; i < 50; i++ {
+		tokens, _ = node.deviceTokens.DeviceTokens("42")
+		if len(tokens) == 2 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.ElementsMatch(t, []DeviceToken{
+		{Platform: PushPlatformFCM, Token: "valid"},
+		{Platform: PushPlatformAPNs, Token: "no provider"},
+	}, tokens)
+}
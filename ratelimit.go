@@ -0,0 +1,251 @@
+package centrifuge
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// rateLimitCleanInterval is how often local limiters remove state of keys
+// which do not affect limits anymore.
+const rateLimitCleanInterval = time.Minute
+
+// ErrRateCounterNotSupported returned when distributed limiter used but
+// engine does not implement RateCounter.
+var ErrRateCounterNotSupported = errors.New("rate counters not supported")
+
+// RateLimiter limits rate of events identified by key. Key can be anything
+// application wants to limit – user ID, channel, user ID and channel pair
+// etc. Limiters are safe for concurrent use.
+type RateLimiter interface {
+	// Allow reports whether one more event with key allowed now. Event is
+	// counted when allowed.
+	Allow(key string) (bool, error)
+}
+
+// RateCounter is an optional part of Engine which keeps counters of rate
+// limiter windows shared between nodes.
+type RateCounter interface {
+	// IncrRate increments counter of key in window and returns counter
+	// values of this and previous window. Counter of window must live at
+	// least two intervals.
+	IncrRate(key string, window int64, interval time.Duration) (current int64, previous int64, err error)
+}
+
+// SetRateCounter allows to set RateCounter to use.
+func (n *Node) SetRateCounter(c RateCounter) {
+	n.rateCounter = c
+}
+
+// TokenBucketLimiter is a local token bucket limiter. Each key has bucket
+// of burst tokens refilled with rate tokens per interval, every allowed
+// event takes one token.
+type TokenBucketLimiter struct {
+	mu        sync.Mutex
+	unlimited bool
+	burst     float64
+	perToken  time.Duration
+	buckets   map[string]*tokenBucket
+	lastClean time.Time
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewTokenBucketLimiter creates TokenBucketLimiter which allows rate events
+// per interval on average and bursts up to burst events. Burst equal to
+// rate used if burst is not positive. Limiter allows all events if rate or
+// interval is not positive.
+func NewTokenBucketLimiter(rate int, interval time.Duration, burst int) *TokenBucketLimiter {
+	if rate <= 0 || interval <= 0 {
+		return &TokenBucketLimiter{unlimited: true}
+	}
+	if burst <= 0 {
+		burst = rate
+	}
+	perToken := interval / time.Duration(rate)
+	if perToken <= 0 {
+		// Rate is higher than one event per nanosecond.
+		perToken = 1
+	}
+	return &TokenBucketLimiter{
+		burst:     float64(burst),
+		perToken:  perToken,
+		buckets:   make(map[string]*tokenBucket),
+		lastClean: time.Now(),
+	}
+}
+
+// Allow - see RateLimiter interface description.
+func (l *TokenBucketLimiter) Allow(key string) (bool, error) {
+	return l.allowAt(key, time.Now()), nil
+}
+
+func (l *TokenBucketLimiter) allowAt(key string, now time.Time) bool {
+	if l.unlimited {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cleanUnsafe(now)
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, updated: now}
+		l.buckets[key] = bucket
+	}
+	if elapsed := now.Sub(bucket.updated); elapsed > 0 {
+		bucket.tokens += float64(elapsed) / float64(l.perToken)
+		if bucket.tokens > l.burst {
+			bucket.tokens = l.burst
+		}
+		bucket.updated = now
+	}
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// cleanUnsafe removes buckets which are full again as they do not differ
+// from new ones.
+func (l *TokenBucketLimiter) cleanUnsafe(now time.Time) {
+	if now.Sub(l.lastClean) < rateLimitCleanInterval {
+		return
+	}
+	l.lastClean = now
+	for key, bucket := range l.buckets {
+		refill := time.Duration((l.burst - bucket.tokens) * float64(l.perToken))
+		if now.Sub(bucket.updated) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// slidingWindowEstimate approximates number of events during last interval
+// weighting events of previous window by part of it still inside interval.
+func slidingWindowEstimate(current int64, previous int64, now time.Time, interval time.Duration) float64 {
+	elapsed := float64(now.UnixNano()%int64(interval)) / float64(interval)
+	return float64(previous)*(1-elapsed) + float64(current)
+}
+
+func slidingWindow(now time.Time, interval time.Duration) int64 {
+	return now.UnixNano() / int64(interval)
+}
+
+// SlidingWindowLimiter is a local sliding window limiter. It allows up to
+// limit events with key during any interval. Window is approximated using
+// counters of current and previous fixed windows so memory used does not
+// depend on limit.
+type SlidingWindowLimiter struct {
+	mu        sync.Mutex
+	limit     int
+	interval  time.Duration
+	counters  map[string]*windowCounter
+	lastClean time.Time
+}
+
+type windowCounter struct {
+	window   int64
+	current  int64
+	previous int64
+}
+
+// NewSlidingWindowLimiter creates SlidingWindowLimiter which allows limit
+// events per interval.
+func NewSlidingWindowLimiter(limit int, interval time.Duration) *SlidingWindowLimiter {
+	return &SlidingWindowLimiter{
+		limit:     limit,
+		interval:  interval,
+		counters:  make(map[string]*windowCounter),
+		lastClean: time.Now(),
+	}
+}
+
+// Allow - see RateLimiter interface description.
+func (l *SlidingWindowLimiter) Allow(key string) (bool, error) {
+	return l.allowAt(key, time.Now()), nil
+}
+
+func (l *SlidingWindowLimiter) allowAt(key string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	window := slidingWindow(now, l.interval)
+	l.cleanUnsafe(now, window)
+	counter, ok := l.counters[key]
+	if !ok {
+		counter = &windowCounter{window: window}
+		l.counters[key] = counter
+	}
+	if counter.window != window {
+		if counter.window == window-1 {
+			counter.previous = counter.current
+		} else {
+			counter.previous = 0
+		}
+		counter.current = 0
+		counter.window = window
+	}
+	if slidingWindowEstimate(counter.current+1, counter.previous, now, l.interval) > float64(l.limit) {
+		return false
+	}
+	counter.current++
+	return true
+}
+
+// cleanUnsafe removes counters of windows which are not used in estimate
+// anymore.
+func (l *SlidingWindowLimiter) cleanUnsafe(now time.Time, window int64) {
+	if now.Sub(l.lastClean) < rateLimitCleanInterval {
+		return
+	}
+	l.lastClean = now
+	for key, counter := range l.counters {
+		if counter.window < window-1 {
+			delete(l.counters, key)
+		}
+	}
+}
+
+// DistributedLimiter is a sliding window limiter which keeps counters in
+// engine so limit is shared by all nodes. Each allow attempt costs one
+// round trip to engine and is counted even if not allowed which makes
+// clients hammering limiter stay limited.
+type DistributedLimiter struct {
+	node     *Node
+	name     string
+	limit    int
+	interval time.Duration
+}
+
+// NewDistributedLimiter creates DistributedLimiter which allows limit
+// events per interval among all nodes. Name must be unique for limiter in
+// application as it's used to separate counters of different limiters in
+// engine. Engine must implement RateCounter.
+func (n *Node) NewDistributedLimiter(name string, limit int, interval time.Duration) *DistributedLimiter {
+	return &DistributedLimiter{
+		node:     n,
+		name:     name,
+		limit:    limit,
+		interval: interval,
+	}
+}
+
+// Allow - see RateLimiter interface description.
+func (l *DistributedLimiter) Allow(key string) (bool, error) {
+	return l.allowAt(key, time.Now())
+}
+
+func (l *DistributedLimiter) allowAt(key string, now time.Time) (bool, error) {
+	counter := l.node.rateCounter
+	if counter == nil {
+		return false, ErrRateCounterNotSupported
+	}
+	current, previous, err := counter.IncrRate(l.name+":"+key, slidingWindow(now, l.interval), l.interval)
+	if err != nil {
+		return false, err
+	}
+	return slidingWindowEstimate(current, previous, now, l.interval) <= float64(l.limit), nil
+}
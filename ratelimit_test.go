@@ -0,0 +1,78 @@
+package centrifuge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucketLimiter(t *testing.T) {
+	l := NewTokenBucketLimiter(1, time.Second, 2)
+	now := time.Now()
+	assert.True(t, l.allowAt("42", now))
+	assert.True(t, l.allowAt("42", now))
+	assert.False(t, l.allowAt("42", now))
+	// Other keys have their own buckets.
+	assert.True(t, l.allowAt("43", now))
+	// One token refilled after second.
+	assert.True(t, l.allowAt("42", now.Add(time.Second)))
+	assert.False(t, l.allowAt("42", now.Add(time.Second)))
+	// Full buckets removed.
+	l.allowAt("43", now.Add(time.Hour))
+	assert.Len(t, l.buckets, 1)
+}
+
+func TestTokenBucketLimiterUnlimited(t *testing.T) {
+	now := time.Now()
+	for _, rate := range []int{0, -1} {
+		l := NewTokenBucketLimiter(rate, time.Second, 1)
+		for i := 0; i < 10; i++ {
+			assert.True(t, l.allowAt("42", now))
+		}
+	}
+	l := NewTokenBucketLimiter(1, 0, 1)
+	assert.True(t, l.allowAt("42", now))
+	assert.True(t, l.allowAt("42", now))
+}
+
+func TestSlidingWindowLimiter(t *testing.T) {
+	interval := time.Minute
+	l := NewSlidingWindowLimiter(2, interval)
+	start := time.Unix(0, slidingWindow(time.Now(), interval)*int64(interval))
+	assert.True(t, l.allowAt("42", start))
+	assert.True(t, l.allowAt("42", start.Add(time.Second)))
+	assert.False(t, l.allowAt("42", start.Add(2*time.Second)))
+	assert.True(t, l.allowAt("43", start))
+	// Half of previous window still counted.
+	assert.False(t, l.allowAt("42", start.Add(interval+interval/4)))
+	assert.True(t, l.allowAt("42", start.Add(interval+interval/2)))
+	// Counters of old windows removed.
+	l.allowAt("43", start.Add(time.Hour))
+	assert.Len(t, l.counters, 1)
+}
+
+func TestDistributedLimiter(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	interval := time.Minute
+	l := node.NewDistributedLimiter("publish", 2, interval)
+	start := time.Unix(0, slidingWindow(time.Now(), interval)*int64(interval))
+	ok, err := l.allowAt("42", start)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, _ = l.allowAt("42", start)
+	assert.True(t, ok)
+	ok, _ = l.allowAt("42", start)
+	assert.False(t, ok)
+
+	// Limiters with different names do not share counters.
+	ok, _ = node.NewDistributedLimiter("subscribe", 2, interval).allowAt("42", start)
+	assert.True(t, ok)
+}
+
+func TestDistributedLimiterNotSupported(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	node.SetRateCounter(nil)
+	_, err := node.NewDistributedLimiter("publish", 2, time.Minute).Allow("42")
+	assert.Equal(t, ErrRateCounterNotSupported, err)
+}
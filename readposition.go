@@ -0,0 +1,74 @@
+package centrifuge
+
+import (
+	"errors"
+)
+
+// ErrReadPositionNotSupported returned when read position requested but
+// engine does not implement ReadPositionManager.
+var ErrReadPositionNotSupported = errors.New("read positions not supported")
+
+// ReadPositionManager keeps positions in channel streams up to which users
+// read publications. It's an optional part of Engine, keeping read positions
+// together with history allows to calculate unread counts consistently with
+// channel stream.
+type ReadPositionManager interface {
+	// SetReadPosition saves read position of user in channel. Position must
+	// not move backwards within the same epoch.
+	SetReadPosition(ch string, user string, pos RecoveryPosition) error
+	// ReadPosition returns read position of user in channel and false if
+	// user has not read anything in channel yet.
+	ReadPosition(ch string, user string) (RecoveryPosition, bool, error)
+}
+
+// SetReadPositionManager allows to set ReadPositionManager to use.
+func (n *Node) SetReadPositionManager(m ReadPositionManager) {
+	n.readPositionManager = m
+}
+
+// SetReadPosition marks publications in channel up to position as read by
+// user.
+func (n *Node) SetReadPosition(user string, ch string, pos RecoveryPosition) error {
+	if n.readPositionManager == nil {
+		return ErrReadPositionNotSupported
+	}
+	actionCount.WithLabelValues("set_read_position").Inc()
+	return n.readPositionManager.SetReadPosition(ch, user, pos)
+}
+
+// ReadPosition returns position in channel up to which user read
+// publications and false if user has not read anything in channel yet.
+func (n *Node) ReadPosition(user string, ch string) (RecoveryPosition, bool, error) {
+	if n.readPositionManager == nil {
+		return RecoveryPosition{}, false, ErrReadPositionNotSupported
+	}
+	actionCount.WithLabelValues("read_position").Inc()
+	return n.readPositionManager.ReadPosition(ch, user)
+}
+
+// UnreadCount returns number of publications in channel not read by user
+// yet. If user has not read anything in current channel epoch then number
+// of publications kept in channel history returned.
+func (n *Node) UnreadCount(user string, ch string) (int, error) {
+	pos, ok, err := n.ReadPosition(user, ch)
+	if err != nil {
+		return 0, err
+	}
+	current, err := n.currentRecoveryState(ch)
+	if err != nil {
+		return 0, err
+	}
+	if ok && pos.Epoch == current.Epoch {
+		read := packUint64(pos.Seq, pos.Gen)
+		top := packUint64(current.Seq, current.Gen)
+		if read >= top {
+			return 0, nil
+		}
+		return int(top - read), nil
+	}
+	history, err := n.History(ch)
+	if err != nil {
+		return 0, err
+	}
+	return len(history.Publications), nil
+}
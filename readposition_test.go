@@ -0,0 +1,112 @@
+package centrifuge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryEngineReadPosition(t *testing.T) {
+	e := testMemoryEngine()
+
+	_, ok, err := e.ReadPosition("test", "42")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, e.SetReadPosition("test", "42", RecoveryPosition{Seq: 2, Gen: 1, Epoch: "xyz"}))
+	pos, ok, err := e.ReadPosition("test", "42")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, RecoveryPosition{Seq: 2, Gen: 1, Epoch: "xyz"}, pos)
+
+	// Position does not move backwards within epoch.
+	assert.NoError(t, e.SetReadPosition("test", "42", RecoveryPosition{Seq: 5, Gen: 0, Epoch: "xyz"}))
+	pos, _, _ = e.ReadPosition("test", "42")
+	assert.Equal(t, RecoveryPosition{Seq: 2, Gen: 1, Epoch: "xyz"}, pos)
+
+	assert.NoError(t, e.SetReadPosition("test", "42", RecoveryPosition{Seq: 1, Gen: 0, Epoch: "new"}))
+	pos, _, _ = e.ReadPosition("test", "42")
+	assert.Equal(t, RecoveryPosition{Seq: 1, Gen: 0, Epoch: "new"}, pos)
+}
+
+func TestNodeUnreadCount(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	config.HistoryRecover = true
+	node.Reload(config)
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, node.Publish("test", []byte(`{}`)))
+	}
+
+	count, err := node.UnreadCount("42", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, count)
+
+	state, err := node.currentRecoveryState("test")
+	assert.NoError(t, err)
+	assert.NoError(t, node.SetReadPosition("42", "test", RecoveryPosition{Seq: 3, Gen: 0, Epoch: state.Epoch}))
+
+	count, err = node.UnreadCount("42", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	assert.NoError(t, node.SetReadPosition("42", "test", state))
+	count, err = node.UnreadCount("42", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// Position from another epoch is not taken into account.
+	assert.NoError(t, node.SetReadPosition("43", "test", RecoveryPosition{Seq: 5, Gen: 0, Epoch: "old"}))
+	count, err = node.UnreadCount("43", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, count)
+}
+
+func TestNodeReadPositionNotSupported(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	node.SetReadPositionManager(nil)
+	_, err := node.UnreadCount("42", "test")
+	assert.Equal(t, ErrReadPositionNotSupported, err)
+	assert.Equal(t, ErrReadPositionNotSupported, node.SetReadPosition("42", "test", RecoveryPosition{}))
+}
+
+func TestClientMarkRead(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+
+	connectClient(t, client)
+
+	resp, disconnect := client.markReadCmd(&proto.MarkReadRequest{Channel: "test", Seq: 1, Epoch: "xyz"})
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorPermissionDenied, resp.Error)
+
+	subscribeClient(t, client, "test")
+
+	resp, disconnect = client.markReadCmd(&proto.MarkReadRequest{Channel: "test", Seq: 1, Epoch: "xyz"})
+	assert.Nil(t, disconnect)
+	assert.Nil(t, resp.Error)
+	assert.NotNil(t, resp.Result)
+
+	pos, ok, err := node.ReadPosition("42", "test")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, RecoveryPosition{Seq: 1, Epoch: "xyz"}, pos)
+
+	_, disconnect = client.markReadCmd(&proto.MarkReadRequest{})
+	assert.Equal(t, DisconnectBadRequest, disconnect)
+
+	node.SetReadPositionManager(nil)
+	resp, disconnect = client.markReadCmd(&proto.MarkReadRequest{Channel: "test", Seq: 2, Epoch: "xyz"})
+	assert.Nil(t, disconnect)
+	assert.Equal(t, ErrorNotAvailable, resp.Error)
+}
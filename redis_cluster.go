@@ -0,0 +1,217 @@
+package centrifuge
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// redisClusterSlots is a number of hash slots in Redis Cluster.
+const redisClusterSlots = 16384
+
+// crc16 calculates CRC16-CCITT (XMODEM) checksum used by Redis Cluster for
+// hash slots.
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// redisClusterSlot returns hash slot of key. Only part of key inside first
+// non-empty {...} hash tag is hashed if key has it.
+func redisClusterSlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key) % redisClusterSlots)
+}
+
+// redisClusterRedirect extracts target node address from MOVED and ASK
+// errors Redis Cluster node returns for keys it does not serve.
+func redisClusterRedirect(err error) (addr string, ask bool, ok bool) {
+	e, isRedisErr := err.(redis.Error)
+	if !isRedisErr {
+		return "", false, false
+	}
+	parts := strings.Fields(string(e))
+	if len(parts) != 3 || (parts[0] != "MOVED" && parts[0] != "ASK") {
+		return "", false, false
+	}
+	return parts[2], parts[0] == "ASK", true
+}
+
+// parseRedisClusterSlots builds slot to master address mapping from
+// CLUSTER SLOTS reply.
+func parseRedisClusterSlots(reply interface{}) ([]string, error) {
+	ranges, err := redis.Values(reply, nil)
+	if err != nil {
+		return nil, err
+	}
+	slots := make([]string, redisClusterSlots)
+	for _, r := range ranges {
+		values, err := redis.Values(r, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) < 3 {
+			return nil, errors.New("malformed cluster slots range")
+		}
+		start, err := redis.Int(values[0], nil)
+		if err != nil {
+			return nil, err
+		}
+		end, err := redis.Int(values[1], nil)
+		if err != nil {
+			return nil, err
+		}
+		master, err := redis.Values(values[2], nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(master) < 2 {
+			return nil, errors.New("malformed cluster slots node")
+		}
+		host, err := redis.String(master[0], nil)
+		if err != nil {
+			return nil, err
+		}
+		port, err := redis.Int(master[1], nil)
+		if err != nil {
+			return nil, err
+		}
+		addr := net.JoinHostPort(host, strconv.Itoa(port))
+		for slot := start; slot <= end && slot < redisClusterSlots; slot++ {
+			if slot >= 0 {
+				slots[slot] = addr
+			}
+		}
+	}
+	return slots, nil
+}
+
+// redisCluster keeps Redis Cluster topology of shard: which master serves
+// each hash slot and connection pools to masters.
+type redisCluster struct {
+	node  *Node
+	conf  RedisShardConfig
+	mu    sync.RWMutex
+	slots []string
+	pools map[string]*redis.Pool
+}
+
+func newRedisCluster(n *Node, conf RedisShardConfig) (*redisCluster, error) {
+	for _, addr := range conf.ClusterAddrs {
+		if _, _, err := splitRedisAddr(addr); err != nil {
+			return nil, err
+		}
+	}
+	return &redisCluster{
+		node:  n,
+		conf:  conf,
+		pools: make(map[string]*redis.Pool),
+	}, nil
+}
+
+func splitRedisAddr(addr string) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, err
+	}
+	return host, port, nil
+}
+
+// pool returns connection pool to cluster node with address addr.
+func (c *redisCluster) pool(addr string) *redis.Pool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if p, ok := c.pools[addr]; ok {
+		return p
+	}
+	nodeConf := c.conf
+	nodeConf.ClusterAddrs = nil
+	nodeConf.Host, nodeConf.Port, _ = splitRedisAddr(addr)
+	p := newPool(c.node, nodeConf)
+	c.pools[addr] = p
+	return p
+}
+
+// refresh loads slots mapping from first cluster node answering.
+func (c *redisCluster) refresh() error {
+	c.mu.RLock()
+	addrs := append([]string{}, c.conf.ClusterAddrs...)
+	for addr := range c.pools {
+		addrs = append(addrs, addr)
+	}
+	c.mu.RUnlock()
+
+	var lastErr error
+	for _, addr := range addrs {
+		conn := c.pool(addr).Get()
+		reply, err := conn.Do("CLUSTER", "SLOTS")
+		conn.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		slots, err := parseRedisClusterSlots(reply)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		c.mu.Lock()
+		c.slots = slots
+		c.mu.Unlock()
+		return nil
+	}
+	return lastErr
+}
+
+// addr returns address of master serving key.
+func (c *redisCluster) addr(key string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.slots != nil {
+		if addr := c.slots[redisClusterSlot(key)]; addr != "" {
+			return addr
+		}
+	}
+	return c.conf.ClusterAddrs[0]
+}
+
+// masters returns addresses of all known masters.
+func (c *redisCluster) masters() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	seen := map[string]struct{}{}
+	var addrs []string
+	for _, addr := range c.slots {
+		if _, ok := seen[addr]; ok || addr == "" {
+			continue
+		}
+		seen[addr] = struct{}{}
+		addrs = append(addrs, addr)
+	}
+	if len(addrs) == 0 {
+		return c.conf.ClusterAddrs
+	}
+	return addrs
+}
@@ -0,0 +1,94 @@
+package centrifuge
+
+import (
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCRC16(t *testing.T) {
+	assert.Equal(t, uint16(0x31C3), crc16("123456789"))
+}
+
+func TestRedisClusterSlot(t *testing.T) {
+	assert.Equal(t, 12182, redisClusterSlot("foo"))
+	assert.Equal(t, redisClusterSlot("bar"), redisClusterSlot("foo.{bar}.baz"))
+	assert.Equal(t, redisClusterSlot("{bar}.one"), redisClusterSlot("{bar}.two"))
+	// Empty hash tag is not used.
+	assert.Equal(t, int(crc16("foo{}")%redisClusterSlots), redisClusterSlot("foo{}"))
+}
+
+func TestRedisClusterRedirect(t *testing.T) {
+	addr, ask, ok := redisClusterRedirect(redis.Error("MOVED 3999 127.0.0.1:6381"))
+	assert.True(t, ok)
+	assert.False(t, ask)
+	assert.Equal(t, "127.0.0.1:6381", addr)
+
+	addr, ask, ok = redisClusterRedirect(redis.Error("ASK 3999 127.0.0.1:6382"))
+	assert.True(t, ok)
+	assert.True(t, ask)
+	assert.Equal(t, "127.0.0.1:6382", addr)
+
+	_, _, ok = redisClusterRedirect(redis.Error("ERR unknown command"))
+	assert.False(t, ok)
+	_, _, ok = redisClusterRedirect(nil)
+	assert.False(t, ok)
+}
+
+func TestParseRedisClusterSlots(t *testing.T) {
+	reply := []interface{}{
+		[]interface{}{int64(0), int64(8191), []interface{}{[]byte("127.0.0.1"), int64(7000), []byte("id1")}},
+		[]interface{}{int64(8192), int64(16383), []interface{}{[]byte("127.0.0.1"), int64(7001), []byte("id2")}, []interface{}{[]byte("127.0.0.1"), int64(7002)}},
+	}
+	slots, err := parseRedisClusterSlots(reply)
+	assert.NoError(t, err)
+	assert.Len(t, slots, redisClusterSlots)
+	assert.Equal(t, "127.0.0.1:7000", slots[0])
+	assert.Equal(t, "127.0.0.1:7000", slots[8191])
+	assert.Equal(t, "127.0.0.1:7001", slots[8192])
+	assert.Equal(t, "127.0.0.1:7001", slots[16383])
+
+	_, err = parseRedisClusterSlots([]interface{}{[]interface{}{int64(0)}})
+	assert.Error(t, err)
+}
+
+func TestRedisClusterAddr(t *testing.T) {
+	c, err := newRedisCluster(nil, RedisShardConfig{ClusterAddrs: []string{"127.0.0.1:7000"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:7000", c.addr("foo"))
+	assert.Equal(t, []string{"127.0.0.1:7000"}, c.masters())
+
+	slots := make([]string, redisClusterSlots)
+	for i := range slots {
+		slots[i] = "127.0.0.1:7001"
+	}
+	slots[redisClusterSlot("foo")] = "127.0.0.1:7002"
+	c.slots = slots
+	assert.Equal(t, "127.0.0.1:7002", c.addr("foo"))
+	assert.Equal(t, "127.0.0.1:7001", c.addr("bar"))
+	assert.Len(t, c.masters(), 2)
+
+	_, err = newRedisCluster(nil, RedisShardConfig{ClusterAddrs: []string{"127.0.0.1"}})
+	assert.Error(t, err)
+}
+
+func TestRedisClusterKeys(t *testing.T) {
+	s := &shard{config: RedisShardConfig{Prefix: "centrifuge"}}
+	assert.Equal(t, channelID("centrifuge.history.list.test"), s.getHistoryKey("test"))
+
+	s.cluster = &redisCluster{}
+	assert.Equal(t, channelID("centrifuge.history.list.{test}"), s.getHistoryKey("test"))
+	slot := redisClusterSlot(string(s.getHistoryKey("test")))
+	assert.Equal(t, slot, redisClusterSlot(string(s.gethistorySeqKey("test"))))
+	assert.Equal(t, slot, redisClusterSlot(string(s.gethistoryEpochKey("test"))))
+	assert.Equal(t, slot, redisClusterSlot(string(s.getPresenceHashKey("test"))))
+	assert.Equal(t, slot, redisClusterSlot(string(s.getPresenceSetKey("test"))))
+	assert.Equal(t, redisClusterSlot(string(s.getScheduledSetKey())), redisClusterSlot(string(s.getScheduledHashKey())))
+}
+
+func TestDataRequestKey(t *testing.T) {
+	assert.Equal(t, "key", dataRequestKey(&dataRequest{op: dataOpHistory, args: []interface{}{channelID("key")}}))
+	assert.Equal(t, "key", dataRequestKey(&dataRequest{op: dataOpAddHistoryTx, args: []interface{}{2, channelID("key")}}))
+	assert.Equal(t, "", dataRequestKey(&dataRequest{op: dataOpChannels, args: []interface{}{"CHANNELS"}}))
+}
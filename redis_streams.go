@@ -0,0 +1,360 @@
+package centrifuge
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	defaultRedisStreamsBatchSize     = 64
+	defaultRedisStreamsClaimMinIdle  = 30 * time.Second
+	defaultRedisStreamsClaimInterval = 5 * time.Second
+	defaultRedisStreamsDataField     = "data"
+	defaultRedisStreamsChannelField  = "channel"
+)
+
+// RedisStreamEntry is an entry read from Redis stream.
+type RedisStreamEntry struct {
+	Stream string
+	ID     string
+	Fields map[string]string
+}
+
+// ErrRedisStreamNoChannel returned by default channel function of
+// RedisStreamsIngest when entry has no channel field.
+var ErrRedisStreamNoChannel = errors.New("no channel field in stream entry")
+
+// RedisStreamsIngestConfig is a config for RedisStreamsIngest.
+type RedisStreamsIngestConfig struct {
+	// RedisShardConfig describes Redis server to connect to. Prefix is not
+	// used as stream names used as is. ReadTimeout must be greater than Block.
+	RedisShardConfig
+	// Streams is a list of stream keys to consume.
+	Streams []string
+	// Group is a name of Redis consumer group. Group created automatically
+	// (together with stream if it does not exist yet) and starts delivering
+	// entries added after group creation.
+	Group string
+	// Consumer is a name of this ingest worker inside consumer group. It should
+	// be stable across restarts so worker can continue delivering entries it
+	// read but did not manage to publish before restart. By default hostname used.
+	Consumer string
+	// ChannelFunc maps stream entry to channel. By default value of entry
+	// channel field used. Entries for which ChannelFunc returns error are
+	// acknowledged and skipped.
+	ChannelFunc func(entry RedisStreamEntry) (string, error)
+	// DataField is an entry field which contains publication data. By default data.
+	DataField string
+	// BatchSize is a max number of entries to read from Redis in one request.
+	BatchSize int
+	// Block is how long to block waiting for new entries. By default half of
+	// ReadTimeout.
+	Block time.Duration
+	// ClaimMinIdle is how long entry must stay pending, i.e. delivered to some
+	// consumer of group but not acknowledged, before this worker claims it.
+	// This allows to deliver entries read by workers which crashed.
+	ClaimMinIdle time.Duration
+	// ClaimInterval is how often to check for pending entries to claim.
+	ClaimInterval time.Duration
+}
+
+// RedisStreamsIngest reads entries from Redis streams using consumer group
+// and publishes them into channels. Entry acknowledged with XACK only after
+// successful publish so position in stream checkpointed in Redis and entries
+// which were not published will be claimed and delivered again.
+type RedisStreamsIngest struct {
+	node    *Node
+	config  RedisStreamsIngestConfig
+	pool    *redis.Pool
+	closeCh chan struct{}
+	once    sync.Once
+	wg      sync.WaitGroup
+}
+
+// NewRedisStreamsIngest creates RedisStreamsIngest. Redis 5.0+ is required.
+func NewRedisStreamsIngest(n *Node, config RedisStreamsIngestConfig) (*RedisStreamsIngest, error) {
+	if len(config.Streams) == 0 {
+		return nil, errors.New("no Redis streams provided in configuration")
+	}
+	if config.Group == "" {
+		return nil, errors.New("no Redis consumer group provided in configuration")
+	}
+	if config.Consumer == "" {
+		hostname, err := os.Hostname()
+		if err != nil || hostname == "" {
+			hostname = n.uid
+		}
+		config.Consumer = hostname
+	}
+	if config.ChannelFunc == nil {
+		config.ChannelFunc = defaultRedisStreamChannel
+	}
+	if config.DataField == "" {
+		config.DataField = defaultRedisStreamsDataField
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = defaultRedisStreamsBatchSize
+	}
+	if config.Block == 0 {
+		readTimeout := defaultReadTimeout
+		if config.ReadTimeout != 0 {
+			readTimeout = config.ReadTimeout
+		}
+		config.Block = readTimeout / 2
+	}
+	if config.ClaimMinIdle == 0 {
+		config.ClaimMinIdle = defaultRedisStreamsClaimMinIdle
+	}
+	if config.ClaimInterval == 0 {
+		config.ClaimInterval = defaultRedisStreamsClaimInterval
+	}
+	return &RedisStreamsIngest{
+		node:    n,
+		config:  config,
+		pool:    newPool(n, config.RedisShardConfig),
+		closeCh: make(chan struct{}),
+	}, nil
+}
+
+func defaultRedisStreamChannel(entry RedisStreamEntry) (string, error) {
+	ch, ok := entry.Fields[defaultRedisStreamsChannelField]
+	if !ok || ch == "" {
+		return "", ErrRedisStreamNoChannel
+	}
+	return ch, nil
+}
+
+// Run creates consumer groups and starts consuming streams in background.
+func (i *RedisStreamsIngest) Run() error {
+	conn := i.pool.Get()
+	defer conn.Close()
+	for _, stream := range i.config.Streams {
+		_, err := conn.Do("XGROUP", "CREATE", stream, i.config.Group, "$", "MKSTREAM")
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return fmt.Errorf("error creating consumer group for stream %s: %v", stream, err)
+		}
+	}
+	i.wg.Add(2)
+	go i.runForever(i.runRead)
+	go i.runForever(i.runClaim)
+	return nil
+}
+
+// Close stops consuming streams.
+func (i *RedisStreamsIngest) Close() error {
+	i.once.Do(func() {
+		close(i.closeCh)
+	})
+	i.wg.Wait()
+	return i.pool.Close()
+}
+
+func (i *RedisStreamsIngest) closed() bool {
+	select {
+	case <-i.closeCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (i *RedisStreamsIngest) runForever(fn func() error) {
+	defer i.wg.Done()
+	for !i.closed() {
+		if err := fn(); err != nil {
+			i.node.logger.log(newLogEntry(LogLevelError, "error consuming Redis streams", map[string]interface{}{"error": err.Error()}))
+		}
+		select {
+		case <-i.closeCh:
+		case <-time.After(300 * time.Millisecond):
+		}
+	}
+}
+
+// runRead first re-delivers entries which were read by this consumer before
+// but not acknowledged and then reads new entries.
+func (i *RedisStreamsIngest) runRead() error {
+	conn := i.pool.Get()
+	defer conn.Close()
+
+	ids := make(map[string]string, len(i.config.Streams))
+	for _, stream := range i.config.Streams {
+		ids[stream] = "0"
+	}
+
+	for !i.closed() {
+		args := []interface{}{"GROUP", i.config.Group, i.config.Consumer, "COUNT", i.config.BatchSize, "BLOCK", int64(i.config.Block / time.Millisecond), "STREAMS"}
+		for _, stream := range i.config.Streams {
+			args = append(args, stream)
+		}
+		for _, stream := range i.config.Streams {
+			args = append(args, ids[stream])
+		}
+		reply, err := redis.DoWithTimeout(conn, i.config.Block+time.Second, "XREADGROUP", args...)
+		if err == redis.ErrNil {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		streams, err := parseRedisStreams(reply)
+		if err != nil {
+			return err
+		}
+		for stream, entries := range streams {
+			if ids[stream] != ">" && len(entries) == 0 {
+				// All pending entries of this consumer processed.
+				ids[stream] = ">"
+				continue
+			}
+			if err := i.process(conn, stream, entries); err != nil {
+				return err
+			}
+			if ids[stream] != ">" {
+				ids[stream] = entries[len(entries)-1].ID
+			}
+		}
+	}
+	return nil
+}
+
+// runClaim periodically claims entries which stay pending for too long.
+func (i *RedisStreamsIngest) runClaim() error {
+	conn := i.pool.Get()
+	defer conn.Close()
+
+	for {
+		select {
+		case <-i.closeCh:
+			return nil
+		case <-time.After(i.config.ClaimInterval):
+		}
+		for _, stream := range i.config.Streams {
+			if err := i.claim(conn, stream); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (i *RedisStreamsIngest) claim(conn redis.Conn, stream string) error {
+	pending, err := redis.Values(conn.Do("XPENDING", stream, i.config.Group, "-", "+", i.config.BatchSize))
+	if err != nil {
+		return err
+	}
+	minIdle := int64(i.config.ClaimMinIdle / time.Millisecond)
+	args := []interface{}{stream, i.config.Group, i.config.Consumer, minIdle}
+	for _, p := range pending {
+		info, err := redis.Values(p, nil)
+		if err != nil || len(info) < 3 {
+			return errors.New("unexpected XPENDING reply")
+		}
+		id, _ := redis.String(info[0], nil)
+		idle, _ := redis.Int64(info[2], nil)
+		if idle >= minIdle {
+			args = append(args, id)
+		}
+	}
+	if len(args) == 4 {
+		return nil
+	}
+	reply, err := conn.Do("XCLAIM", args...)
+	if err != nil {
+		return err
+	}
+	entries, err := parseRedisStreamEntries(stream, reply)
+	if err != nil {
+		return err
+	}
+	return i.process(conn, stream, entries)
+}
+
+// process publishes entries and acknowledges the ones which were
+// successfully published or skipped.
+func (i *RedisStreamsIngest) process(conn redis.Conn, stream string, entries []RedisStreamEntry) error {
+	args := []interface{}{stream, i.config.Group}
+	for _, entry := range entries {
+		if entry.Fields == nil {
+			// Entry was deleted from stream while pending.
+			args = append(args, entry.ID)
+			continue
+		}
+		ch, err := i.config.ChannelFunc(entry)
+		if err != nil {
+			i.node.logger.log(newLogEntry(LogLevelInfo, "skip Redis stream entry", map[string]interface{}{"stream": stream, "id": entry.ID, "error": err.Error()}))
+			args = append(args, entry.ID)
+			continue
+		}
+		if err := i.node.Publish(ch, []byte(entry.Fields[i.config.DataField])); err != nil {
+			i.node.logger.log(newLogEntry(LogLevelError, "error publishing Redis stream entry", map[string]interface{}{"stream": stream, "id": entry.ID, "channel": ch, "error": err.Error()}))
+			continue
+		}
+		args = append(args, entry.ID)
+	}
+	if len(args) == 2 {
+		return nil
+	}
+	_, err := conn.Do("XACK", args...)
+	return err
+}
+
+// parseRedisStreams parses XREADGROUP reply.
+func parseRedisStreams(reply interface{}) (map[string][]RedisStreamEntry, error) {
+	values, err := redis.Values(reply, nil)
+	if err != nil {
+		return nil, err
+	}
+	streams := make(map[string][]RedisStreamEntry, len(values))
+	for _, v := range values {
+		streamReply, err := redis.Values(v, nil)
+		if err != nil || len(streamReply) != 2 {
+			return nil, errors.New("unexpected XREADGROUP reply")
+		}
+		stream, err := redis.String(streamReply[0], nil)
+		if err != nil {
+			return nil, err
+		}
+		entries, err := parseRedisStreamEntries(stream, streamReply[1])
+		if err != nil {
+			return nil, err
+		}
+		streams[stream] = entries
+	}
+	return streams, nil
+}
+
+// parseRedisStreamEntries parses list of stream entries as returned by
+// XREADGROUP and XCLAIM. Fields of entries deleted from stream are nil.
+func parseRedisStreamEntries(stream string, reply interface{}) ([]RedisStreamEntry, error) {
+	values, err := redis.Values(reply, nil)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]RedisStreamEntry, 0, len(values))
+	for _, v := range values {
+		entryReply, err := redis.Values(v, nil)
+		if err != nil || len(entryReply) != 2 {
+			return nil, errors.New("unexpected stream entry reply")
+		}
+		id, err := redis.String(entryReply[0], nil)
+		if err != nil {
+			return nil, err
+		}
+		entry := RedisStreamEntry{Stream: stream, ID: id}
+		if entryReply[1] != nil {
+			fields, err := redis.StringMap(entryReply[1], nil)
+			if err != nil {
+				return nil, err
+			}
+			entry.Fields = fields
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,121 @@
+// +build integration
+
+package centrifuge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRedisStreamsIngest(t *testing.T, n *Node, consumer string) *RedisStreamsIngest {
+	ingest, err := NewRedisStreamsIngest(n, RedisStreamsIngestConfig{
+		RedisShardConfig: RedisShardConfig{
+			Host: testRedisHost,
+			Port: testRedisPort,
+			DB:   testRedisDB,
+		},
+		Streams:       []string{"test_stream"},
+		Group:         "test_group",
+		Consumer:      consumer,
+		Block:         100 * time.Millisecond,
+		ClaimMinIdle:  100 * time.Millisecond,
+		ClaimInterval: 50 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	return ingest
+}
+
+func waitHistory(t *testing.T, n *Node, ch string, num int) []*Publication {
+	var pubs []*Publication
+	for j := 0; j < 50; j++ {
+		history, err := n.History(ch)
+		pubs = history.Publications
+		assert.NoError(t, err)
+		if len(pubs) >= num {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return pubs
+}
+
+func TestRedisStreamsIngest(t *testing.T) {
+	c := dial()
+	defer c.close()
+
+	n := nodeWithMemoryEngine()
+	config := n.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	n.Reload(config)
+
+	ingest := newTestRedisStreamsIngest(t, n, "test_consumer")
+	assert.NoError(t, ingest.Run())
+	defer ingest.Close()
+
+	_, err := c.Do("XADD", "test_stream", "*", "channel", "test", "data", `{"input":"1"}`)
+	assert.NoError(t, err)
+	_, err = c.Do("XADD", "test_stream", "*", "data", `{"input":"no channel"}`)
+	assert.NoError(t, err)
+	_, err = c.Do("XADD", "test_stream", "*", "channel", "test", "data", `{"input":"2"}`)
+	assert.NoError(t, err)
+
+	pubs := waitHistory(t, n, "test", 2)
+	assert.Len(t, pubs, 2)
+	assert.Equal(t, `{"input":"1"}`, string(pubs[0].Data))
+	assert.Equal(t, `{"input":"2"}`, string(pubs[1].Data))
+
+	// All entries including skipped one must be acknowledged.
+	time.Sleep(50 * time.Millisecond)
+	pending, err := c.Do("XPENDING", "test_stream", "test_group")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), pending.([]interface{})[0])
+}
+
+func TestRedisStreamsIngestClaim(t *testing.T) {
+	c := dial()
+	defer c.close()
+
+	_, err := c.Do("XGROUP", "CREATE", "test_stream", "test_group", "$", "MKSTREAM")
+	assert.NoError(t, err)
+	_, err = c.Do("XADD", "test_stream", "*", "channel", "test", "data", `{"input":"1"}`)
+	assert.NoError(t, err)
+	// Read entry by consumer which never acknowledges it.
+	_, err = c.Do("XREADGROUP", "GROUP", "test_group", "dead_consumer", "STREAMS", "test_stream", ">")
+	assert.NoError(t, err)
+
+	n := nodeWithMemoryEngine()
+	config := n.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	n.Reload(config)
+
+	ingest := newTestRedisStreamsIngest(t, n, "test_consumer")
+	assert.NoError(t, ingest.Run())
+	defer ingest.Close()
+
+	pubs := waitHistory(t, n, "test", 1)
+	assert.Len(t, pubs, 1)
+	assert.Equal(t, `{"input":"1"}`, string(pubs[0].Data))
+}
+
+func TestParseRedisStreamEntries(t *testing.T) {
+	reply := []interface{}{
+		[]interface{}{[]byte("1-0"), []interface{}{[]byte("channel"), []byte("test"), []byte("data"), []byte("{}")}},
+		[]interface{}{[]byte("2-0"), nil},
+	}
+	entries, err := parseRedisStreamEntries("stream", reply)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "1-0", entries[0].ID)
+	assert.Equal(t, "test", entries[0].Fields["channel"])
+	assert.Nil(t, entries[1].Fields)
+
+	ch, err := defaultRedisStreamChannel(entries[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "test", ch)
+	_, err = defaultRedisStreamChannel(entries[1])
+	assert.Equal(t, ErrRedisStreamNoChannel, err)
+}
@@ -0,0 +1,58 @@
+package centrifuge
+
+import (
+	"errors"
+	"time"
+)
+
+// tokenUseDefaultLifetime is how long uses of token without expiration
+// are counted.
+const tokenUseDefaultLifetime = 24 * time.Hour
+
+// ErrTokenUsesNotSupported returned when token uses must be limited but
+// engine does not implement TokenUseCounter.
+var ErrTokenUsesNotSupported = errors.New("token use counting not supported")
+
+// TokenUseCounter is an optional part of Engine which counts uses of
+// connection tokens by their jti claim so token can only be used limited
+// number of times. This blocks replay of tokens leaked from query strings
+// or logs.
+type TokenUseCounter interface {
+	// IncrTokenUse increments number of uses of token with id and returns
+	// number of uses including this one. Counter must live at least until
+	// expireAt.
+	IncrTokenUse(id string, expireAt time.Time) (int64, error)
+}
+
+// SetTokenUseCounter allows to set TokenUseCounter to use.
+func (n *Node) SetTokenUseCounter(c TokenUseCounter) {
+	n.tokenUseCounter = c
+}
+
+// checkTokenUses counts use of connection token with jti id expiring at
+// exp Unix seconds and returns Disconnect if token was used too many
+// times.
+func (c *Client) checkTokenUses(id string, exp int64) *Disconnect {
+	maxUses := c.node.Config().ClientTokenMaxUses
+	if maxUses <= 0 || id == "" {
+		return nil
+	}
+	if c.node.tokenUseCounter == nil {
+		c.node.logger.log(newLogEntry(LogLevelError, "error counting token uses", map[string]interface{}{"client": c.uid, "error": ErrTokenUsesNotSupported.Error()}))
+		return DisconnectServerError
+	}
+	expireAt := time.Now().Add(tokenUseDefaultLifetime)
+	if exp > 0 {
+		expireAt = time.Unix(exp, 0)
+	}
+	uses, err := c.node.tokenUseCounter.IncrTokenUse(id, expireAt)
+	if err != nil {
+		c.node.logger.log(newLogEntry(LogLevelError, "error counting token uses", map[string]interface{}{"client": c.uid, "error": err.Error()}))
+		return DisconnectServerError
+	}
+	if uses > int64(maxUses) {
+		c.node.logger.log(newLogEntry(LogLevelInfo, "connection token used too many times", map[string]interface{}{"client": c.uid, "jti": id, "uses": uses}))
+		return DisconnectInvalidToken
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package centrifuge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func getConnTokenWithID(user string, id string, exp int64) string {
+	claims := jwt.MapClaims{"sub": user, "jti": id, "exp": exp}
+	t, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestMemoryEngineIncrTokenUse(t *testing.T) {
+	e := testMemoryEngine()
+	uses, err := e.IncrTokenUse("token", time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), uses)
+	uses, _ = e.IncrTokenUse("token", time.Now().Add(time.Minute))
+	assert.Equal(t, int64(2), uses)
+	uses, _ = e.IncrTokenUse("other", time.Now().Add(time.Minute))
+	assert.Equal(t, int64(1), uses)
+	// Expired counters start again.
+	uses, _ = e.IncrTokenUse("expired", time.Now().Add(-time.Second))
+	assert.Equal(t, int64(1), uses)
+	uses, _ = e.IncrTokenUse("expired", time.Now().Add(time.Minute))
+	assert.Equal(t, int64(1), uses)
+}
+
+func TestClientConnectTokenReplay(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.Secret = "secret"
+	config.ClientTokenMaxUses = 1
+	assert.NoError(t, node.Reload(config))
+
+	token := getConnTokenWithID("42", "token-id", time.Now().Unix()+60)
+
+	client, _ := newClient(context.Background(), node, newTestTransport())
+	resp, disconnect := client.connectCmd(&proto.ConnectRequest{Token: token})
+	assert.Nil(t, disconnect)
+	assert.Nil(t, resp.Error)
+
+	client, _ = newClient(context.Background(), node, newTestTransport())
+	_, disconnect = client.connectCmd(&proto.ConnectRequest{Token: token})
+	assert.Equal(t, DisconnectInvalidToken, disconnect)
+
+	// Tokens without jti claim are not limited.
+	for i := 0; i < 2; i++ {
+		client, _ = newClient(context.Background(), node, newTestTransport())
+		_, disconnect = client.connectCmd(&proto.ConnectRequest{Token: getConnTokenWithID("42", "", time.Now().Unix()+60)})
+		assert.Nil(t, disconnect)
+	}
+}
+
+func TestClientConnectTokenUsesNotSupported(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	node.SetTokenUseCounter(nil)
+	config := node.Config()
+	config.Secret = "secret"
+	config.ClientTokenMaxUses = 1
+	assert.NoError(t, node.Reload(config))
+
+	client, _ := newClient(context.Background(), node, newTestTransport())
+	_, disconnect := client.connectCmd(&proto.ConnectRequest{Token: getConnTokenWithID("42", "token-id", time.Now().Unix()+60)})
+	assert.Equal(t, DisconnectServerError, disconnect)
+}
@@ -0,0 +1,88 @@
+package centrifuge
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrorReport contains information about server error including context of
+// connection and channel (if known) where error happened.
+type ErrorReport struct {
+	// Message describes error, it's the same as error log entry message.
+	Message string
+	// Error is an underlying error, can be nil.
+	Error error
+	// Panic is a value recovered from panic, nil if report is not about panic.
+	Panic interface{}
+	// Stack is a stack trace of goroutine at the moment of panic.
+	Stack []byte
+	// Channel where error happened.
+	Channel string
+	// Client is an ID of client connection where error happened.
+	Client string
+	// User is an ID of user where error happened.
+	User string
+	// Fields contain all additional information about error.
+	Fields map[string]interface{}
+}
+
+// ErrorReporter allows to send server errors to external error tracker.
+// ReportError is called synchronously from library internals so it must not
+// block – send reports asynchronously.
+type ErrorReporter interface {
+	ReportError(report ErrorReport)
+}
+
+// SetErrorReporter sets ErrorReporter to report panics recovered from event
+// handlers, engine failures and other internal errors.
+func (n *Node) SetErrorReporter(r ErrorReporter) {
+	n.errorReporterMu.Lock()
+	defer n.errorReporterMu.Unlock()
+	n.errorReporter = r
+}
+
+// reportError sends error log entry to ErrorReporter.
+func (n *Node) reportError(entry LogEntry) {
+	n.errorReporterMu.RLock()
+	reporter := n.errorReporter
+	n.errorReporterMu.RUnlock()
+	if reporter == nil {
+		return
+	}
+	report := ErrorReport{
+		Message: entry.Message,
+		Fields:  entry.Fields,
+	}
+	if v, ok := entry.Fields["error"].(string); ok {
+		report.Error = errors.New(v)
+	}
+	if v, ok := entry.Fields["panic"]; ok {
+		report.Panic = v
+	}
+	if v, ok := entry.Fields["stack"].(string); ok {
+		report.Stack = []byte(v)
+	}
+	if v, ok := entry.Fields["channel"].(string); ok {
+		report.Channel = v
+	}
+	if v, ok := entry.Fields["client"].(string); ok {
+		report.Client = v
+	}
+	if v, ok := entry.Fields["user"].(string); ok {
+		report.User = v
+	}
+	reporter.ReportError(report)
+}
+
+// logPanic logs value recovered from panic together with stack trace. Log
+// entry then gets into ErrorReporter as all other errors.
+func (n *Node) logPanic(message string, recovered interface{}, fields map[string]interface{}) {
+	if fields == nil {
+		fields = make(map[string]interface{})
+	}
+	fields["panic"] = recovered
+	fields["error"] = fmt.Sprintf("%v", recovered)
+	fields["stack"] = string(debug.Stack())
+	n.logger.log(newLogEntry(LogLevelError, message, fields))
+}
@@ -0,0 +1,240 @@
+package centrifuge
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/uuid"
+)
+
+const defaultSentryQueueSize = 256
+
+// SentryConfig is a config for Sentry error reporter.
+type SentryConfig struct {
+	// DSN is a Sentry project DSN like https://<key>@sentry.io/<project>.
+	DSN string
+	// Environment of application, for example production.
+	Environment string
+	// Release is an application version.
+	Release string
+	// ServerName is a name of server, hostname by default.
+	ServerName string
+	// QueueSize is a max number of reports waiting to be sent, 256 by
+	// default. Reports are dropped when queue is full.
+	QueueSize int
+	// Timeout for request to Sentry, 10 seconds by default.
+	Timeout time.Duration
+	// HTTPClient allows to set custom HTTP client.
+	HTTPClient *http.Client
+}
+
+// SentryReporter is an ErrorReporter which sends reports to Sentry. Reports
+// are sent asynchronously from separate goroutine.
+type SentryReporter struct {
+	config    SentryConfig
+	client    *http.Client
+	storeURL  string
+	publicKey string
+	secretKey string
+
+	queue     chan *sentryEvent
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	doneCh    chan struct{}
+}
+
+// NewSentryReporter creates SentryReporter and starts sending reports.
+func NewSentryReporter(c SentryConfig) (*SentryReporter, error) {
+	storeURL, publicKey, secretKey, err := parseSentryDSN(c.DSN)
+	if err != nil {
+		return nil, err
+	}
+	if c.ServerName == "" {
+		c.ServerName, _ = os.Hostname()
+	}
+	if c.QueueSize == 0 {
+		c.QueueSize = defaultSentryQueueSize
+	}
+	client := c.HTTPClient
+	if client == nil {
+		timeout := c.Timeout
+		if timeout == 0 {
+			timeout = 10 * time.Second
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+	r := &SentryReporter{
+		config:    c,
+		client:    client,
+		storeURL:  storeURL,
+		publicKey: publicKey,
+		secretKey: secretKey,
+		queue:     make(chan *sentryEvent, c.QueueSize),
+		closeCh:   make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+	go r.run()
+	return r, nil
+}
+
+// parseSentryDSN extracts store endpoint and keys from DSN.
+func parseSentryDSN(dsn string) (string, string, string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid Sentry DSN: %v", err)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return "", "", "", errors.New("invalid Sentry DSN: no public key")
+	}
+	publicKey := u.User.Username()
+	secretKey, _ := u.User.Password()
+
+	path := strings.TrimSuffix(u.Path, "/")
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 || idx == len(path)-1 {
+		return "", "", "", errors.New("invalid Sentry DSN: no project ID")
+	}
+	projectID := path[idx+1:]
+	storeURL := fmt.Sprintf("%s://%s%s/api/%s/store/", u.Scheme, u.Host, path[:idx], projectID)
+	return storeURL, publicKey, secretKey, nil
+}
+
+type sentryException struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sentryEvent struct {
+	EventID     string                 `json:"event_id"`
+	Timestamp   string                 `json:"timestamp"`
+	Level       string                 `json:"level"`
+	Logger      string                 `json:"logger"`
+	Platform    string                 `json:"platform"`
+	Message     string                 `json:"message"`
+	ServerName  string                 `json:"server_name,omitempty"`
+	Environment string                 `json:"environment,omitempty"`
+	Release     string                 `json:"release,omitempty"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+	Exception   []sentryException      `json:"exception,omitempty"`
+}
+
+func (r *SentryReporter) newEvent(report ErrorReport) *sentryEvent {
+	event := &sentryEvent{
+		EventID:     strings.Replace(uuid.Must(uuid.NewV4()).String(), "-", "", -1),
+		Timestamp:   time.Now().UTC().Format("2006-01-02T15:04:05"),
+		Level:       "error",
+		Logger:      "centrifuge",
+		Platform:    "go",
+		Message:     report.Message,
+		ServerName:  r.config.ServerName,
+		Environment: r.config.Environment,
+		Release:     r.config.Release,
+		Tags:        make(map[string]string),
+		Extra:       make(map[string]interface{}, len(report.Fields)),
+	}
+	if report.Channel != "" {
+		event.Tags["channel"] = report.Channel
+	}
+	if report.Client != "" {
+		event.Tags["client"] = report.Client
+	}
+	if report.User != "" {
+		event.Tags["user"] = report.User
+	}
+	for k, v := range report.Fields {
+		switch k {
+		case "panic", "stack":
+		default:
+			event.Extra[k] = fmt.Sprintf("%v", v)
+		}
+	}
+	if len(report.Stack) > 0 {
+		event.Extra["stack"] = string(report.Stack)
+	}
+	if report.Panic != nil {
+		event.Level = "fatal"
+		event.Exception = []sentryException{{Type: "panic", Value: fmt.Sprintf("%v", report.Panic)}}
+	} else if report.Error != nil {
+		event.Exception = []sentryException{{Type: "error", Value: report.Error.Error()}}
+	}
+	return event
+}
+
+// ReportError is a part of ErrorReporter interface.
+func (r *SentryReporter) ReportError(report ErrorReport) {
+	select {
+	case <-r.closeCh:
+	case r.queue <- r.newEvent(report):
+	default:
+		// Queue is full, drop report.
+	}
+}
+
+// Close sends all queued reports and stops reporter.
+func (r *SentryReporter) Close() error {
+	r.closeOnce.Do(func() {
+		close(r.closeCh)
+	})
+	<-r.doneCh
+	return nil
+}
+
+// run sends queued reports. Send errors are ignored as reporting them into
+// node log would produce new reports.
+func (r *SentryReporter) run() {
+	defer close(r.doneCh)
+	for {
+		select {
+		case event := <-r.queue:
+			r.send(event)
+		case <-r.closeCh:
+			for {
+				select {
+				case event := <-r.queue:
+					r.send(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *SentryReporter) authHeader() string {
+	auth := fmt.Sprintf("Sentry sentry_version=7, sentry_client=centrifuge, sentry_timestamp=%d, sentry_key=%s", time.Now().Unix(), r.publicKey)
+	if r.secretKey != "" {
+		auth += ", sentry_secret=" + r.secretKey
+	}
+	return auth
+}
+
+func (r *SentryReporter) send(event *sentryEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, r.storeURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", r.authHeader())
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected Sentry response status: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package centrifuge
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+type testErrorReporter struct {
+	mu      sync.Mutex
+	reports []ErrorReport
+}
+
+func (r *testErrorReporter) ReportError(report ErrorReport) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, report)
+}
+
+func TestNodeReportError(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	reporter := &testErrorReporter{}
+	node.SetErrorReporter(reporter)
+
+	node.Log(NewLogEntry(LogLevelInfo, "not an error"))
+	node.Log(NewLogEntry(LogLevelError, "error publishing", map[string]interface{}{"channel": "test", "client": "1", "user": "42", "error": "boom"}))
+
+	assert.Len(t, reporter.reports, 1)
+	report := reporter.reports[0]
+	assert.Equal(t, "error publishing", report.Message)
+	assert.EqualError(t, report.Error, "boom")
+	assert.Equal(t, "test", report.Channel)
+	assert.Equal(t, "1", report.Client)
+	assert.Equal(t, "42", report.User)
+	assert.Nil(t, report.Panic)
+}
+
+func TestClientHandlerPanicReported(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	reporter := &testErrorReporter{}
+	node.SetErrorReporter(reporter)
+
+	ctx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(ctx, node, newTestTransport())
+	client.connectCmd(&proto.ConnectRequest{})
+	client.On().RPC(func(e RPCEvent) RPCReply {
+		panic("oops")
+	})
+
+	var replies []*proto.Reply
+	rw := testReplyWriter(&replies)
+	disconnect := client.handle(&proto.Command{ID: 1, Method: proto.MethodTypeRPC, Params: []byte(`{"data":{}}`)}, rw.write, rw.flush)
+	assert.Equal(t, DisconnectServerError, disconnect)
+
+	assert.Len(t, reporter.reports, 1)
+	report := reporter.reports[0]
+	assert.Equal(t, "oops", report.Panic)
+	assert.Equal(t, client.ID(), report.Client)
+	assert.Equal(t, "42", report.User)
+	assert.Contains(t, string(report.Stack), "TestClientHandlerPanicReported")
+}
@@ -0,0 +1,126 @@
+package centrifuge
+
+import (
+	"errors"
+)
+
+// ChannelRole is a role of user in channel. Roles are ordered: owner has
+// all rights of moderator and moderator has all rights of member.
+type ChannelRole string
+
+// Known channel roles.
+const (
+	ChannelRoleOwner     ChannelRole = "owner"
+	ChannelRoleModerator ChannelRole = "moderator"
+	ChannelRoleMember    ChannelRole = "member"
+)
+
+func (r ChannelRole) level() int {
+	switch r {
+	case ChannelRoleOwner:
+		return 3
+	case ChannelRoleModerator:
+		return 2
+	case ChannelRoleMember:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Valid returns true if role is one of known channel roles.
+func (r ChannelRole) Valid() bool {
+	return r.level() > 0
+}
+
+// AtLeast returns true if role grants all rights of role other.
+func (r ChannelRole) AtLeast(other ChannelRole) bool {
+	return r.Valid() && r.level() >= other.level()
+}
+
+var (
+	// ErrRolesNotSupported returned when channel roles requested but
+	// engine does not implement RoleManager.
+	ErrRolesNotSupported = errors.New("channel roles not supported")
+	// ErrInvalidRole returned when unknown role granted to user.
+	ErrInvalidRole = errors.New("invalid channel role")
+)
+
+// RoleManager keeps roles of users in channels. It's an optional part of
+// Engine, keeping roles in engine makes them visible to all nodes.
+type RoleManager interface {
+	// SetRole sets role of user in channel replacing previous one.
+	SetRole(ch string, user string, role ChannelRole) error
+	// RemoveRole removes role of user in channel.
+	RemoveRole(ch string, user string) error
+	// Role returns role of user in channel and false if user has no role.
+	Role(ch string, user string) (ChannelRole, bool, error)
+	// Roles returns roles of all users in channel.
+	Roles(ch string) (map[string]ChannelRole, error)
+}
+
+// SetRoleManager allows to set RoleManager to use.
+func (n *Node) SetRoleManager(m RoleManager) {
+	n.roleManager = m
+}
+
+// GrantRole gives user role in channel.
+func (n *Node) GrantRole(ch string, user string, role ChannelRole) error {
+	if n.roleManager == nil {
+		return ErrRolesNotSupported
+	}
+	if !role.Valid() {
+		return ErrInvalidRole
+	}
+	actionCount.WithLabelValues("grant_role").Inc()
+	return n.roleManager.SetRole(ch, user, role)
+}
+
+// RevokeRole takes role in channel away from user.
+func (n *Node) RevokeRole(ch string, user string) error {
+	if n.roleManager == nil {
+		return ErrRolesNotSupported
+	}
+	actionCount.WithLabelValues("revoke_role").Inc()
+	return n.roleManager.RemoveRole(ch, user)
+}
+
+// UserRole returns role of user in channel and false if user has no role.
+func (n *Node) UserRole(ch string, user string) (ChannelRole, bool, error) {
+	if n.roleManager == nil {
+		return "", false, ErrRolesNotSupported
+	}
+	actionCount.WithLabelValues("user_role").Inc()
+	return n.roleManager.Role(ch, user)
+}
+
+// ChannelRoles returns roles of all users in channel.
+func (n *Node) ChannelRoles(ch string) (map[string]ChannelRole, error) {
+	if n.roleManager == nil {
+		return nil, ErrRolesNotSupported
+	}
+	actionCount.WithLabelValues("channel_roles").Inc()
+	return n.roleManager.Roles(ch)
+}
+
+// HasRole returns true if user has at least role in channel. It can be
+// used in permission callbacks, for example to only allow moderators to
+// mute channel users or remove publications.
+func (n *Node) HasRole(ch string, user string, role ChannelRole) (bool, error) {
+	userRole, ok, err := n.UserRole(ch, user)
+	if err != nil || !ok {
+		return false, err
+	}
+	return userRole.AtLeast(role), nil
+}
+
+// IsChannelOwner returns true if user is owner of channel.
+func (n *Node) IsChannelOwner(ch string, user string) (bool, error) {
+	return n.HasRole(ch, user, ChannelRoleOwner)
+}
+
+// IsChannelModerator returns true if user can moderate channel, i.e. user
+// is channel moderator or owner.
+func (n *Node) IsChannelModerator(ch string, user string) (bool, error) {
+	return n.HasRole(ch, user, ChannelRoleModerator)
+}
@@ -0,0 +1,66 @@
+package centrifuge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelRoleAtLeast(t *testing.T) {
+	assert.True(t, ChannelRoleOwner.AtLeast(ChannelRoleModerator))
+	assert.True(t, ChannelRoleModerator.AtLeast(ChannelRoleModerator))
+	assert.True(t, ChannelRoleModerator.AtLeast(ChannelRoleMember))
+	assert.False(t, ChannelRoleMember.AtLeast(ChannelRoleModerator))
+	assert.False(t, ChannelRole("unknown").AtLeast(ChannelRoleMember))
+	assert.False(t, ChannelRole("unknown").Valid())
+}
+
+func TestMemoryEngineRoles(t *testing.T) {
+	e := testMemoryEngine()
+
+	_, ok, err := e.Role("test", "42")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, e.SetRole("test", "42", ChannelRoleModerator))
+	assert.NoError(t, e.SetRole("test", "43", ChannelRoleMember))
+	role, ok, err := e.Role("test", "42")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, ChannelRoleModerator, role)
+
+	roles, err := e.Roles("test")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]ChannelRole{"42": ChannelRoleModerator, "43": ChannelRoleMember}, roles)
+
+	assert.NoError(t, e.RemoveRole("test", "42"))
+	_, ok, _ = e.Role("test", "42")
+	assert.False(t, ok)
+}
+
+func TestNodeRoles(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	assert.Equal(t, ErrInvalidRole, node.GrantRole("test", "42", ChannelRole("admin")))
+	assert.NoError(t, node.GrantRole("test", "42", ChannelRoleOwner))
+	assert.NoError(t, node.GrantRole("test", "43", ChannelRoleMember))
+
+	isModerator, err := node.IsChannelModerator("test", "42")
+	assert.NoError(t, err)
+	assert.True(t, isModerator)
+	isModerator, err = node.IsChannelModerator("test", "43")
+	assert.NoError(t, err)
+	assert.False(t, isModerator)
+	isOwner, err := node.IsChannelOwner("test", "44")
+	assert.NoError(t, err)
+	assert.False(t, isOwner)
+
+	assert.NoError(t, node.RevokeRole("test", "42"))
+	isOwner, err = node.IsChannelOwner("test", "42")
+	assert.NoError(t, err)
+	assert.False(t, isOwner)
+
+	node.SetRoleManager(nil)
+	_, err = node.HasRole("test", "43", ChannelRoleMember)
+	assert.Equal(t, ErrRolesNotSupported, err)
+}
@@ -0,0 +1,152 @@
+package centrifuge
+
+import (
+	"errors"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/uuid"
+)
+
+const (
+	// scheduledCheckInterval is how often node looks for due scheduled
+	// publications and disconnects.
+	scheduledCheckInterval = 200 * time.Millisecond
+	// scheduledBatchLimit is a max number of due publications or
+	// disconnects taken at once.
+	scheduledBatchLimit = 512
+)
+
+// ErrScheduleNotSupported returned when publication scheduled but engine
+// does not implement ScheduleManager.
+var ErrScheduleNotSupported = errors.New("scheduled publications not supported")
+
+// ScheduledPublication is a publication waiting to be published into
+// channel at scheduled time.
+type ScheduledPublication struct {
+	// ID is a unique identifier of scheduled publication.
+	ID string `json:"id"`
+	// Channel to publish into.
+	Channel string `json:"channel"`
+	// Data of publication. Encoded as base64 string in JSON so binary
+	// payloads can be scheduled too.
+	Data []byte `json:"data"`
+	// Info is an optional publisher info.
+	Info *ClientInfo `json:"info,omitempty"`
+	// Time is a Unix time in milliseconds when publication must be published.
+	Time int64 `json:"time"`
+	// SkipHistory is SkipHistory option publication scheduled with.
+	SkipHistory bool `json:"skip_history,omitempty"`
+	// TTL is a publication lifetime in milliseconds counted from publishing.
+	TTL int64 `json:"ttl,omitempty"`
+}
+
+// ScheduleManager keeps scheduled publications. It's an optional part of
+// Engine, shared implementation allows to schedule publications in any node
+// and publish them from one of running nodes exactly once.
+type ScheduleManager interface {
+	// AddScheduled saves publication to publish later.
+	AddScheduled(pub *ScheduledPublication) error
+	// PopScheduled removes and returns up to limit publications scheduled
+	// not later than now. Each scheduled publication must be returned only
+	// once among all nodes.
+	PopScheduled(now time.Time, limit int) ([]*ScheduledPublication, error)
+}
+
+// SetScheduleManager allows to set ScheduleManager to use. Must be called
+// before Node.Run.
+func (n *Node) SetScheduleManager(m ScheduleManager) {
+	n.scheduleManager = m
+}
+
+// WithDelay allows to publish publication into channel after delay.
+func WithDelay(d time.Duration) PublishOption {
+	return func(opts *PublishOptions) {
+		opts.PublishAt = time.Now().Add(d)
+	}
+}
+
+// PublishAt publishes data into channel at time t. Publication is kept in
+// engine until then and published by one of running nodes. Publication
+// scheduled for past time is published immediately.
+func (n *Node) PublishAt(ch string, data []byte, t time.Time, opts ...PublishOption) error {
+	opts = append(opts, func(opts *PublishOptions) {
+		opts.PublishAt = t
+	})
+	return n.publish(ch, data, nil, opts...)
+}
+
+// schedulePublication saves publication to publish it later.
+func (n *Node) schedulePublication(ch string, data []byte, info *ClientInfo, publishOpts *PublishOptions) error {
+	if n.scheduleManager == nil {
+		return ErrScheduleNotSupported
+	}
+	actionCount.WithLabelValues("add_scheduled").Inc()
+	return n.scheduleManager.AddScheduled(&ScheduledPublication{
+		ID:          uuid.Must(uuid.NewV4()).String(),
+		Channel:     ch,
+		Data:        data,
+		Info:        info,
+		Time:        publishOpts.PublishAt.UnixNano() / int64(time.Millisecond),
+		SkipHistory: publishOpts.SkipHistory,
+		TTL:         int64(publishOpts.TTL / time.Millisecond),
+	})
+}
+
+func (n *Node) runScheduled() {
+	ticker := time.NewTicker(scheduledCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.shutdownCh:
+			return
+		case <-ticker.C:
+			now := time.Now()
+			if n.scheduleManager != nil {
+				n.publishScheduled(now)
+			}
+			if n.disconnectScheduler != nil {
+				n.disconnectScheduled(now)
+			}
+		}
+	}
+}
+
+// publishScheduled publishes all due scheduled publications. Publications
+// failed to publish are rescheduled and retried on next check.
+func (n *Node) publishScheduled(now time.Time) {
+	for {
+		failed := false
+		pubs, err := n.scheduleManager.PopScheduled(now, scheduledBatchLimit)
+		if err != nil {
+			n.logger.log(newLogEntry(LogLevelError, "error getting scheduled publications", map[string]interface{}{"error": err.Error()}))
+			return
+		}
+		for _, pub := range pubs {
+			var opts []PublishOption
+			if pub.SkipHistory {
+				opts = append(opts, SkipHistory())
+			}
+			if pub.TTL > 0 {
+				opts = append(opts, WithTTL(time.Duration(pub.TTL)*time.Millisecond))
+			}
+			err := n.publish(pub.Channel, pub.Data, pub.Info, opts...)
+			if err == nil || err == ErrNoChannelOptions {
+				if err != nil {
+					n.logger.log(newLogEntry(LogLevelInfo, "scheduled publication channel not found", map[string]interface{}{"channel": pub.Channel}))
+				}
+				continue
+			}
+			n.logger.log(newLogEntry(LogLevelError, "error publishing scheduled publication", map[string]interface{}{"channel": pub.Channel, "error": err.Error()}))
+			failed = true
+			// Return publication back to retry on next check.
+			if err := n.scheduleManager.AddScheduled(pub); err != nil {
+				n.logger.log(newLogEntry(LogLevelError, "error rescheduling publication", map[string]interface{}{"channel": pub.Channel, "error": err.Error()}))
+			}
+		}
+		if failed || len(pubs) < scheduledBatchLimit {
+			// Rescheduled publications are due already so popping again
+			// would return them back at once.
+			return
+		}
+	}
+}
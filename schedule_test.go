@@ -0,0 +1,125 @@
+package centrifuge
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryEngineScheduled(t *testing.T) {
+	e := testMemoryEngine()
+	now := time.Now()
+	nowMs := now.UnixNano() / int64(time.Millisecond)
+	assert.NoError(t, e.AddScheduled(&ScheduledPublication{ID: "2", Channel: "test", Time: nowMs + 2000}))
+	assert.NoError(t, e.AddScheduled(&ScheduledPublication{ID: "1", Channel: "test", Time: nowMs + 1000}))
+	assert.NoError(t, e.AddScheduled(&ScheduledPublication{ID: "3", Channel: "test", Time: nowMs + 3000}))
+
+	pubs, err := e.PopScheduled(now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 0)
+
+	pubs, err = e.PopScheduled(now.Add(2*time.Second), 10)
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 2)
+	assert.Equal(t, "1", pubs[0].ID)
+	assert.Equal(t, "2", pubs[1].ID)
+
+	// Already popped publications not returned again.
+	pubs, err = e.PopScheduled(now.Add(2*time.Second), 10)
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 0)
+
+	assert.NoError(t, e.AddScheduled(&ScheduledPublication{ID: "4", Channel: "test", Time: nowMs}))
+	pubs, err = e.PopScheduled(now.Add(5*time.Second), 1)
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 1)
+	assert.Equal(t, "4", pubs[0].ID)
+}
+
+func TestNodePublishAt(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.HistorySize = 10
+	config.HistoryLifetime = 60
+	assert.NoError(t, node.Reload(config))
+
+	assert.NoError(t, node.PublishAt("test", []byte(`{"at":1}`), time.Now().Add(time.Minute)))
+	assert.NoError(t, node.Publish("test", []byte(`{"delay":1}`), WithDelay(time.Minute)))
+	// Past time means publishing immediately.
+	assert.NoError(t, node.PublishAt("test", []byte(`{"now":1}`), time.Now().Add(-time.Second)))
+	assert.Equal(t, ErrNoChannelOptions, node.PublishAt("unknown:test", []byte(`{}`), time.Now().Add(time.Minute)))
+
+	history, err := node.History("test")
+	pubs := history.Publications
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 1)
+
+	node.publishScheduled(time.Now())
+	history, err = node.History("test")
+	pubs = history.Publications
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 1)
+
+	node.publishScheduled(time.Now().Add(2 * time.Minute))
+	history, err = node.History("test")
+	pubs = history.Publications
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 3)
+}
+
+func TestScheduledPublicationBinaryData(t *testing.T) {
+	pub := &ScheduledPublication{ID: "1", Channel: "test", Data: []byte{0x00, 0xff, 0x01}}
+	data, err := json.Marshal(pub)
+	assert.NoError(t, err)
+	var decoded ScheduledPublication
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, pub.Data, decoded.Data)
+}
+
+func TestNodePublishAtNotSupported(t *testing.T) {
+	node, _ := New(DefaultConfig)
+	node.SetScheduleManager(nil)
+	assert.Equal(t, ErrScheduleNotSupported, node.PublishAt("test", []byte(`{}`), time.Now().Add(time.Minute)))
+}
+
+type failingPublishBroker struct {
+	*MemoryEngine
+}
+
+func (b *failingPublishBroker) Publish(ch string, pub *Publication, opts *ChannelOptions) error {
+	return errors.New("broker unavailable")
+}
+
+type countingScheduleManager struct {
+	ScheduleManager
+	pops int
+}
+
+func (m *countingScheduleManager) PopScheduled(now time.Time, limit int) ([]*ScheduledPublication, error) {
+	m.pops++
+	return m.ScheduleManager.PopScheduled(now, limit)
+}
+
+func TestNodePublishScheduledFailure(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	e := testMemoryEngine()
+	node.SetBroker(&failingPublishBroker{e})
+	manager := &countingScheduleManager{ScheduleManager: e}
+	node.SetScheduleManager(manager)
+
+	nowMs := time.Now().UnixNano() / int64(time.Millisecond)
+	for i := 0; i < scheduledBatchLimit; i++ {
+		assert.NoError(t, manager.AddScheduled(&ScheduledPublication{ID: strconv.Itoa(i), Channel: "test", Data: []byte(`{}`), Time: nowMs}))
+	}
+
+	node.publishScheduled(time.Now())
+	// Failed batch is not popped again until next check.
+	assert.Equal(t, 1, manager.pops)
+	pubs, err := e.PopScheduled(time.Now(), 2*scheduledBatchLimit)
+	assert.NoError(t, err)
+	assert.Len(t, pubs, scheduledBatchLimit)
+}
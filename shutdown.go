@@ -0,0 +1,92 @@
+package centrifuge
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// inflight counts operations in progress so shutdown can wait for them.
+type inflight struct {
+	mu     sync.Mutex
+	num    int
+	zeroCh chan struct{}
+}
+
+func (f *inflight) add() {
+	f.mu.Lock()
+	f.num++
+	f.mu.Unlock()
+}
+
+func (f *inflight) done() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.num--
+	if f.num == 0 && f.zeroCh != nil {
+		close(f.zeroCh)
+		f.zeroCh = nil
+	}
+}
+
+// wait blocks until there are no operations in progress or context done.
+func (f *inflight) wait(ctx context.Context) error {
+	f.mu.Lock()
+	if f.num == 0 {
+		f.mu.Unlock()
+		return nil
+	}
+	if f.zeroCh == nil {
+		f.zeroCh = make(chan struct{})
+	}
+	ch := f.zeroCh
+	f.mu.Unlock()
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+const (
+	// shutdownPublishShare is a part of shutdown deadline node waits for
+	// publishes in progress, the rest is reserved to disconnect clients.
+	shutdownPublishShare = 0.5
+	// drainDeadlineShare is a part of time left until deadline disconnects
+	// of clients are spread over, the rest is kept to write and close.
+	drainDeadlineShare = 0.8
+)
+
+// shutdownPublishContext returns context to wait for publishes with which
+// leaves part of ctx deadline to disconnect clients.
+func shutdownPublishContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	left := time.Until(deadline)
+	return context.WithTimeout(ctx, time.Duration(float64(left)*shutdownPublishShare))
+}
+
+// drainDelays returns sorted random delays to disconnect num clients with
+// spread over interval. Interval is shortened to fit context deadline
+// keeping margin to write and close connections.
+func drainDelays(ctx context.Context, num int, interval time.Duration) []time.Duration {
+	delays := make([]time.Duration, num)
+	if deadline, ok := ctx.Deadline(); ok {
+		if left := time.Duration(float64(time.Until(deadline)) * drainDeadlineShare); left < interval {
+			interval = left
+		}
+	}
+	if interval <= 0 {
+		return delays
+	}
+	for i := range delays {
+		delays[i] = time.Duration(rand.Int63n(int64(interval)))
+	}
+	sort.Slice(delays, func(i, j int) bool { return delays[i] < delays[j] })
+	return delays
+}
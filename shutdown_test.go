@@ -0,0 +1,134 @@
+package centrifuge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInflight(t *testing.T) {
+	var f inflight
+	assert.NoError(t, f.wait(context.Background()))
+
+	f.add()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, f.wait(ctx))
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		f.done()
+	}()
+	assert.NoError(t, f.wait(context.Background()))
+}
+
+func TestDrainDelays(t *testing.T) {
+	delays := drainDelays(context.Background(), 100, 0)
+	for _, d := range delays {
+		assert.Equal(t, time.Duration(0), d)
+	}
+
+	delays = drainDelays(context.Background(), 100, time.Second)
+	assert.Len(t, delays, 100)
+	for i, d := range delays {
+		assert.True(t, d >= 0 && d < time.Second)
+		if i > 0 {
+			assert.True(t, d >= delays[i-1])
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	for _, d := range drainDelays(ctx, 100, time.Hour) {
+		// Margin kept to close connections before deadline.
+		assert.True(t, d < 80*time.Millisecond)
+	}
+}
+
+func TestNodeShutdownDrain(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ShutdownDrainInterval = 100 * time.Millisecond
+	assert.NoError(t, node.Reload(config))
+
+	transports := make([]*testTransport, 10)
+	for i := range transports {
+		transports[i] = newTestTransport()
+		client, _ := newClient(SetCredentials(context.Background(), &Credentials{UserID: "42"}), node, transports[i])
+		connectClient(t, client)
+	}
+
+	started := time.Now()
+	assert.NoError(t, node.Shutdown(context.Background()))
+	assert.True(t, time.Since(started) < time.Second)
+	assert.Equal(t, 0, node.hub.NumClients())
+	for _, transport := range transports {
+		assert.True(t, transport.closed)
+		assert.Equal(t, DisconnectShutdown.Reason, transport.disconnect.Reason)
+		assert.True(t, transport.disconnect.Reconnect)
+	}
+
+	// New connections not accepted after shutdown.
+	client, _ := newClient(SetCredentials(context.Background(), &Credentials{UserID: "42"}), node, newTestTransport())
+	_, disconnect := client.connectCmd(&proto.ConnectRequest{})
+	assert.Equal(t, DisconnectShutdown, disconnect)
+}
+
+func TestNodeShutdownDrainDeadline(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ShutdownDrainInterval = time.Hour
+	assert.NoError(t, node.Reload(config))
+
+	for i := 0; i < 10; i++ {
+		client, _ := newClient(SetCredentials(context.Background(), &Credentials{UserID: "42"}), node, newTestTransport())
+		connectClient(t, client)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- node.Shutdown(ctx)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown did not respect context deadline")
+	}
+}
+
+func TestNodeShutdownWaitsPublishes(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	node.publishes.add()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, node.Shutdown(ctx))
+}
+
+func TestNodeShutdownDisconnectsWithBlockedPublish(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	transports := make([]*testTransport, 3)
+	for i := range transports {
+		transports[i] = newTestTransport()
+		client, _ := newClient(SetCredentials(context.Background(), &Credentials{UserID: "42"}), node, transports[i])
+		connectClient(t, client)
+	}
+	// Publish never finishes.
+	node.publishes.add()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, node.Shutdown(ctx))
+	assert.Equal(t, 0, node.hub.NumClients())
+	for _, transport := range transports {
+		transport.mu.Lock()
+		assert.True(t, transport.closed)
+		assert.Equal(t, DisconnectShutdown.Reason, transport.disconnect.Reason)
+		transport.mu.Unlock()
+	}
+}
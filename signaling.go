@@ -0,0 +1,124 @@
+package centrifuge
+
+import (
+	"encoding/json"
+)
+
+// Signal types relayed by Signaling.
+const (
+	// SignalTypeOffer is a WebRTC session description offer.
+	SignalTypeOffer = "offer"
+	// SignalTypeAnswer is a WebRTC session description answer.
+	SignalTypeAnswer = "answer"
+	// SignalTypeCandidate is a WebRTC ICE candidate.
+	SignalTypeCandidate = "candidate"
+)
+
+// Signal is a WebRTC signalling message relayed between peers of room.
+type Signal struct {
+	// Room is a channel peers subscribed to.
+	Room string `json:"room"`
+	// Type is one of offer, answer or candidate.
+	Type string `json:"type"`
+	// To is a client ID of peer signal addressed to.
+	To string `json:"to,omitempty"`
+	// From is a client ID of peer who sent signal, set by server.
+	From string `json:"from,omitempty"`
+	// User is a user ID of peer who sent signal, set by server.
+	User string `json:"user,omitempty"`
+	// Payload is a session description or ICE candidate.
+	Payload Raw `json:"payload,omitempty"`
+}
+
+// signalMessage is an async message delivered to peer.
+type signalMessage struct {
+	Signal *Signal `json:"signal"`
+}
+
+// Signaling is a signalling layer for WebRTC applications on top of channels.
+// Room is a channel with presence enabled, room peers are client connections
+// in room presence. Client joins room by subscribing on channel and sends
+// signals to room peers which receive them as async messages like
+// {"signal": {"room": "rooms:1", "type": "offer", "from": "<client ID>", ...}}.
+type Signaling struct {
+	node *Node
+}
+
+// NewSignaling creates Signaling.
+func NewSignaling(n *Node) *Signaling {
+	return &Signaling{
+		node: n,
+	}
+}
+
+// Peers returns room peers keyed by client ID.
+func (s *Signaling) Peers(room string) (map[string]*ClientInfo, error) {
+	return s.node.Presence(room)
+}
+
+func validSignalType(t string) bool {
+	switch t {
+	case SignalTypeOffer, SignalTypeAnswer, SignalTypeCandidate:
+		return true
+	default:
+		return false
+	}
+}
+
+// Relay sends signal from client to room peer. Client must be subscribed on
+// room and target peer must be in room presence.
+func (s *Signaling) Relay(c *Client, signal *Signal) *Error {
+	if signal.Room == "" || signal.To == "" || signal.To == c.ID() || !validSignalType(signal.Type) {
+		return ErrorBadRequest
+	}
+	chOpts, ok := s.node.ChannelOpts(signal.Room)
+	if !ok {
+		return ErrorNamespaceNotFound
+	}
+	if !chOpts.Presence {
+		return ErrorNotAvailable
+	}
+	if _, ok := c.Channels()[signal.Room]; !ok {
+		return ErrorPermissionDenied
+	}
+	peers, err := s.Peers(signal.Room)
+	if err != nil {
+		s.node.logger.log(newLogEntry(LogLevelError, "error getting room peers", map[string]interface{}{"channel": signal.Room, "user": c.UserID(), "client": c.ID(), "error": err.Error()}))
+		return ErrorInternal
+	}
+	if _, ok := peers[signal.To]; !ok {
+		return ErrorPermissionDenied
+	}
+
+	signal.From = c.ID()
+	signal.User = c.UserID()
+	data, err := json.Marshal(signalMessage{Signal: signal})
+	if err != nil {
+		return ErrorInternal
+	}
+	if err := s.node.sendToClient(signal.To, data); err != nil {
+		s.node.logger.log(newLogEntry(LogLevelError, "error relaying signal", map[string]interface{}{"channel": signal.Room, "user": c.UserID(), "client": c.ID(), "error": err.Error()}))
+		return ErrorInternal
+	}
+	return nil
+}
+
+// RPCHandler returns RPCHandler for client connection which relays signals
+// sent as RPC data like {"room": "rooms:1", "type": "offer", "to": "<client ID>",
+// "payload": {...}}:
+//
+//	node.On().ClientConnected(func(ctx context.Context, client *centrifuge.Client) {
+//		client.On().RPC(signaling.RPCHandler(client))
+//	})
+func (s *Signaling) RPCHandler(c *Client) RPCHandler {
+	return func(e RPCEvent) RPCReply {
+		var signal Signal
+		if err := json.Unmarshal(e.Data, &signal); err != nil {
+			return RPCReply{Error: ErrorBadRequest}
+		}
+		if err := s.Relay(c, &signal); err != nil {
+			return RPCReply{Error: err}
+		}
+		return RPCReply{}
+	}
+}
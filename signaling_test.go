@@ -0,0 +1,95 @@
+package centrifuge
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/centrifugal/centrifuge/internal/proto/controlproto"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSignalingClient(t *testing.T, node *Node, user string, transport *testTransport) *Client {
+	ctx := SetCredentials(context.Background(), &Credentials{UserID: user})
+	client, _ := newClient(ctx, node, transport)
+	connectClient(t, client)
+	subscribeClient(t, client, "room")
+	return client
+}
+
+func waitSignal(t *testing.T, sink chan []byte) string {
+	for {
+		select {
+		case data := <-sink:
+			if strings.Contains(string(data), "signal") {
+				return string(data)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting signal")
+		}
+	}
+}
+
+func TestSignalingRelay(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.Presence = true
+	node.Reload(config)
+
+	signaling := NewSignaling(node)
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	peer := newTestSignalingClient(t, node, "42", transport)
+	client := newTestSignalingClient(t, node, "43", newTestTransport())
+
+	peers, err := signaling.Peers("room")
+	assert.NoError(t, err)
+	assert.Len(t, peers, 2)
+
+	handler := signaling.RPCHandler(client)
+	reply := handler(RPCEvent{Data: Raw(`{"room":"room","type":"offer","to":"` + peer.ID() + `","payload":{"sdp":"test"}}`)})
+	assert.Nil(t, reply.Error)
+
+	data := waitSignal(t, transport.sink)
+	assert.Contains(t, data, `"from":"`+client.ID()+`"`)
+	assert.Contains(t, data, `"user":"43"`)
+	assert.Contains(t, data, `"sdp":"test"`)
+}
+
+func TestSignalingRelayErrors(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.Presence = true
+	node.Reload(config)
+
+	signaling := NewSignaling(node)
+	peer := newTestSignalingClient(t, node, "42", newTestTransport())
+	client := newTestSignalingClient(t, node, "43", newTestTransport())
+
+	assert.Equal(t, ErrorBadRequest, signaling.Relay(client, &Signal{Room: "room", Type: "unknown", To: peer.ID()}))
+	assert.Equal(t, ErrorBadRequest, signaling.Relay(client, &Signal{Room: "room", Type: SignalTypeOffer, To: client.ID()}))
+	assert.Equal(t, ErrorPermissionDenied, signaling.Relay(client, &Signal{Room: "other", Type: SignalTypeOffer, To: peer.ID()}))
+	assert.Equal(t, ErrorPermissionDenied, signaling.Relay(client, &Signal{Room: "room", Type: SignalTypeOffer, To: "unknown"}))
+	assert.Equal(t, ErrorNamespaceNotFound, signaling.Relay(client, &Signal{Room: "ns:room", Type: SignalTypeOffer, To: peer.ID()}))
+
+	config.Presence = false
+	node.Reload(config)
+	assert.Equal(t, ErrorNotAvailable, signaling.Relay(client, &Signal{Room: "room", Type: SignalTypeOffer, To: peer.ID()}))
+}
+
+func TestNodeHandleControlSend(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	client, _ := newClient(ctx, node, transport)
+	connectClient(t, client)
+
+	params, _ := node.controlEncoder.EncodeSend(&controlproto.Send{Client: client.ID(), Data: proto.Raw(`{"signal":{}}`)})
+	data, _ := node.controlEncoder.EncodeCommand(&controlproto.Command{UID: "other", Method: controlproto.MethodTypeSend, Params: params})
+	assert.NoError(t, node.handleControl(data))
+	waitSignal(t, transport.sink)
+}
@@ -0,0 +1,188 @@
+package centrifuge
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto/controlproto"
+	"github.com/centrifugal/centrifuge/internal/uuid"
+)
+
+// defaultSurveyTimeout used when context passed to Survey has no deadline.
+const defaultSurveyTimeout = 10 * time.Second
+
+// ErrSurveyHandlerNotRegistered returned when Survey called but survey
+// handler not set on node.
+var ErrSurveyHandlerNotRegistered = errors.New("survey handler not registered")
+
+// SurveyResult is a reply of one node to survey.
+type SurveyResult struct {
+	Code uint32
+	Data Raw
+}
+
+// survey collects results of one survey sent by this node.
+type survey struct {
+	mu       sync.Mutex
+	expected int
+	results  map[string]SurveyResult
+	done     chan struct{}
+}
+
+func (s *survey) add(nodeID string, result SurveyResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.results) >= s.expected {
+		return
+	}
+	s.results[nodeID] = result
+	if len(s.results) == s.expected {
+		close(s.done)
+	}
+}
+
+func (s *survey) copyResults() map[string]SurveyResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := make(map[string]SurveyResult, len(s.results))
+	for nodeID, result := range s.results {
+		results[nodeID] = result
+	}
+	return results
+}
+
+// surveys keeps surveys of node waiting for replies.
+type surveys struct {
+	mu      sync.Mutex
+	pending map[string]*survey
+}
+
+func newSurveys() *surveys {
+	return &surveys{
+		pending: make(map[string]*survey),
+	}
+}
+
+func (s *surveys) add(id string, expected int) *survey {
+	sv := &survey{
+		expected: expected,
+		results:  make(map[string]SurveyResult, expected),
+		done:     make(chan struct{}),
+	}
+	s.mu.Lock()
+	s.pending[id] = sv
+	s.mu.Unlock()
+	return sv
+}
+
+func (s *surveys) remove(id string) {
+	s.mu.Lock()
+	delete(s.pending, id)
+	s.mu.Unlock()
+}
+
+// handleResult passes result of node to survey with id if this node still
+// waits for it.
+func (s *surveys) handleResult(id string, nodeID string, result SurveyResult) {
+	s.mu.Lock()
+	sv, ok := s.pending[id]
+	s.mu.Unlock()
+	if ok {
+		sv.add(nodeID, result)
+	}
+}
+
+// Survey sends question to all running nodes including this one and
+// collects their replies keyed by node ID. Nodes answer using SurveyHandler
+// set with On().Survey, nodes without handler do not answer. Survey waits
+// for replies from all nodes known to this node until context done – if
+// context has no deadline default 10 seconds timeout used. When not all
+// nodes answered in time replies collected so far returned with context
+// error.
+func (n *Node) Survey(ctx context.Context, op string, data []byte) (map[string]SurveyResult, error) {
+	handler := n.eventHub.surveyHandler
+	if handler == nil {
+		return nil, ErrSurveyHandlerNotRegistered
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultSurveyTimeout)
+		defer cancel()
+	}
+
+	actionCount.WithLabelValues("survey").Inc()
+
+	numNodes := len(n.nodes.list())
+	if numNodes == 0 {
+		// Node info of this node not added yet.
+		numNodes = 1
+	}
+
+	id := uuid.Must(uuid.NewV4()).String()
+	sv := n.surveys.add(id, numNodes)
+	defer n.surveys.remove(id)
+
+	if numNodes > 1 {
+		params, err := n.controlEncoder.EncodeSurveyRequest(&controlproto.SurveyRequest{
+			ID:   id,
+			Op:   op,
+			Data: data,
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = n.publishControl(&controlproto.Command{
+			UID:    n.uid,
+			Method: controlproto.MethodTypeSurveyRequest,
+			Params: params,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	go func() {
+		reply := handler(SurveyEvent{Op: op, Data: data})
+		sv.add(n.uid, SurveyResult{Code: reply.Code, Data: reply.Data})
+	}()
+
+	select {
+	case <-sv.done:
+		return sv.copyResults(), nil
+	case <-ctx.Done():
+		return sv.copyResults(), ctx.Err()
+	}
+}
+
+// surveyRequestCmd answers survey request sent by another node.
+func (n *Node) surveyRequestCmd(nodeID string, cmd *controlproto.SurveyRequest) error {
+	handler := n.eventHub.surveyHandler
+	if handler == nil {
+		return nil
+	}
+	// Handler can be slow so control messages are not blocked by it.
+	go func() {
+		reply := handler(SurveyEvent{Op: cmd.Op, Data: Raw(cmd.Data)})
+		params, err := n.controlEncoder.EncodeSurveyResponse(&controlproto.SurveyResponse{
+			ID:   cmd.ID,
+			Code: reply.Code,
+			Data: reply.Data,
+		})
+		if err != nil {
+			n.logger.log(newLogEntry(LogLevelError, "error encoding survey response", map[string]interface{}{"error": err.Error()}))
+			return
+		}
+		err = n.publishControl(&controlproto.Command{
+			UID:    n.uid,
+			Method: controlproto.MethodTypeSurveyResponse,
+			Params: params,
+		})
+		if err != nil {
+			n.logger.log(newLogEntry(LogLevelError, "error publishing survey response", map[string]interface{}{"node": nodeID, "error": err.Error()}))
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,86 @@
+package centrifuge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// controlBroker delivers control messages to all nodes of test cluster.
+type controlBroker struct {
+	*MemoryEngine
+	nodes []*Node
+}
+
+func (b *controlBroker) PublishControl(data []byte) error {
+	for _, n := range b.nodes {
+		go n.handleControl(data)
+	}
+	return nil
+}
+
+func clusterWithControlBroker(t *testing.T, size int) []*Node {
+	broker := &controlBroker{}
+	nodes := make([]*Node, size)
+	for i := range nodes {
+		n := nodeWithMemoryEngine()
+		if i == 0 {
+			broker.MemoryEngine = n.broker.(*MemoryEngine)
+		}
+		n.SetBroker(broker)
+		nodes[i] = n
+	}
+	broker.nodes = nodes
+	for _, n := range nodes {
+		assert.NoError(t, n.pubNode())
+	}
+	deadline := time.Now().Add(time.Second)
+	for _, n := range nodes {
+		for len(n.nodes.list()) < size && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	return nodes
+}
+
+func TestNodeSurveyNoHandler(t *testing.T) {
+	n := nodeWithMemoryEngine()
+	_, err := n.Survey(context.Background(), "test", nil)
+	assert.Equal(t, ErrSurveyHandlerNotRegistered, err)
+}
+
+func TestNodeSurvey(t *testing.T) {
+	nodes := clusterWithControlBroker(t, 3)
+	for _, n := range nodes {
+		n := n
+		n.On().Survey(func(e SurveyEvent) SurveyReply {
+			assert.Equal(t, "whoami", e.Op)
+			assert.Equal(t, Raw(`{}`), e.Data)
+			return SurveyReply{Code: 1, Data: Raw(`"` + n.uid + `"`)}
+		})
+	}
+
+	results, err := nodes[0].Survey(context.Background(), "whoami", []byte(`{}`))
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	for _, n := range nodes {
+		assert.Equal(t, SurveyResult{Code: 1, Data: Raw(`"` + n.uid + `"`)}, results[n.uid])
+	}
+	assert.Len(t, nodes[0].surveys.pending, 0)
+}
+
+func TestNodeSurveyTimeout(t *testing.T) {
+	nodes := clusterWithControlBroker(t, 2)
+	nodes[0].On().Survey(func(e SurveyEvent) SurveyReply {
+		return SurveyReply{Data: Raw(`1`)}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	results, err := nodes[0].Survey(ctx, "test", nil)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, Raw(`1`), results[nodes[0].uid].Data)
+}
@@ -0,0 +1,112 @@
+package centrifuge
+
+import (
+	"errors"
+)
+
+// ErrTxNotSupported returned when transactional publish requested but
+// engine does not implement HistoryTxManager.
+var ErrTxNotSupported = errors.New("transactional publish not supported")
+
+// ChannelData is data to publish into channel.
+type ChannelData struct {
+	// Channel to publish into.
+	Channel string
+	// Data of publication.
+	Data []byte
+}
+
+// HistoryTxEntry is a publication to add into channel history as part of
+// transaction.
+type HistoryTxEntry struct {
+	Channel     string
+	Publication *Publication
+	Options     *ChannelOptions
+}
+
+// HistoryTxManager is an optional part of Engine which allows to add
+// publications into histories of several channels atomically.
+type HistoryTxManager interface {
+	// AddHistoryTx adds publications into channel histories so either all
+	// of them saved or none. Returned publications correspond to entries
+	// and follow the same rules as publications returned from AddHistory:
+	// nil publication means it has already been published to Broker.
+	AddHistoryTx(entries []HistoryTxEntry) ([]*Publication, error)
+}
+
+// SetHistoryTxManager allows to set HistoryTxManager to use.
+func (n *Node) SetHistoryTxManager(m HistoryTxManager) {
+	n.historyTxManager = m
+}
+
+// PublishTx publishes data into several channels as one logical event:
+// either all publications are added into histories of their channels
+// and sent to subscribers or none of them. Like with Publish broker errors
+// after publications were saved into history are not returned as clients
+// eventually recover publications from history. Channels without history
+// are published to Broker after histories saved, first error publishing
+// into them is returned.
+func (n *Node) PublishTx(data []ChannelData) error {
+	channelOpts := make([]ChannelOptions, len(data))
+	for i, d := range data {
+		chOpts, ok := n.ChannelOpts(d.Channel)
+		if !ok {
+			return ErrNoChannelOptions
+		}
+		channelOpts[i] = chOpts
+	}
+
+	publishOpts := &PublishOptions{}
+	pubs := make([]*Publication, len(data))
+	var entries []HistoryTxEntry
+	var entryIndexes []int
+	for i, d := range data {
+		pub, err := n.newPublication(d.Channel, d.Data, nil, &channelOpts[i], publishOpts)
+		if err != nil {
+			return err
+		}
+		pubs[i] = pub
+		if historyEnabled(channelOpts[i]) {
+			entries = append(entries, HistoryTxEntry{
+				Channel:     d.Channel,
+				Publication: pub,
+				Options:     &channelOpts[i],
+			})
+			entryIndexes = append(entryIndexes, i)
+		}
+	}
+
+	actionCount.WithLabelValues("publish_tx").Inc()
+
+	published := make([]bool, len(data))
+	if len(entries) > 0 {
+		if n.historyTxManager == nil {
+			return ErrTxNotSupported
+		}
+		historyPubs, err := n.historyTxManager.AddHistoryTx(entries)
+		if err != nil {
+			return err
+		}
+		for j, historyPub := range historyPubs {
+			i := entryIndexes[j]
+			if historyPub == nil {
+				published[i] = true
+				continue
+			}
+			pubs[i] = historyPub
+		}
+	}
+
+	var publishErr error
+	for i, d := range data {
+		messagesSentCount.WithLabelValues("publication").Inc()
+		if !published[i] {
+			err := n.brokerPublish(d.Channel, pubs[i], &channelOpts[i])
+			if err != nil && publishErr == nil && !historyEnabled(channelOpts[i]) {
+				publishErr = err
+			}
+		}
+		n.handlePublished(d.Channel, pubs[i], &channelOpts[i])
+	}
+	return publishErr
+}
@@ -0,0 +1,90 @@
+package centrifuge
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryEngineAddHistoryTx(t *testing.T) {
+	e := testMemoryEngine()
+	opts := &ChannelOptions{HistorySize: 10, HistoryLifetime: 60}
+	_, err := e.AddHistory("a", &Publication{Data: []byte(`{}`)}, opts)
+	assert.NoError(t, err)
+
+	pubs, err := e.AddHistoryTx([]HistoryTxEntry{
+		{Channel: "a", Publication: &Publication{Data: []byte(`{}`)}, Options: opts},
+		{Channel: "b", Publication: &Publication{Data: []byte(`{}`)}, Options: opts},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 2)
+	assert.Equal(t, uint32(2), pubs[0].Seq)
+	assert.Equal(t, uint32(1), pubs[1].Seq)
+
+	history, _, err := e.History("b", HistoryFilter{Limit: -1})
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+
+func TestNodePublishTx(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	config := node.Config()
+	config.Namespaces = []ChannelNamespace{{
+		Name: "history",
+		ChannelOptions: ChannelOptions{
+			HistorySize:     10,
+			HistoryLifetime: 60,
+		},
+	}}
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	subscribeClient(t, client, "history:a")
+	subscribeClient(t, client, "b")
+
+	assert.NoError(t, node.PublishTx([]ChannelData{
+		{Channel: "history:a", Data: []byte(`{"event": 1}`)},
+		{Channel: "b", Data: []byte(`{"event": 1}`)},
+	}))
+
+	// Writer can merge both publications into one frame.
+	var received string
+	for !strings.Contains(received, `"history:a"`) || !strings.Contains(received, `"b"`) {
+		select {
+		case data := <-transport.sink:
+			received += string(data)
+		case <-time.After(time.Second):
+			t.Fatal("publication not delivered")
+		}
+	}
+
+	history, err := node.History("history:a")
+	pubs := history.Publications
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 1)
+	assert.Equal(t, uint32(1), pubs[0].Seq)
+
+	// Nothing published if one of channels can't be published into.
+	assert.Equal(t, ErrNoChannelOptions, node.PublishTx([]ChannelData{
+		{Channel: "history:a", Data: []byte(`{}`)},
+		{Channel: "unknown:b", Data: []byte(`{}`)},
+	}))
+	history, err = node.History("history:a")
+	pubs = history.Publications
+	assert.NoError(t, err)
+	assert.Len(t, pubs, 1)
+
+	node.SetHistoryTxManager(nil)
+	assert.Equal(t, ErrTxNotSupported, node.PublishTx([]ChannelData{
+		{Channel: "history:a", Data: []byte(`{}`)},
+	}))
+}
@@ -0,0 +1,222 @@
+package centrifuge
+
+import (
+	"errors"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto"
+	"github.com/centrifugal/centrifuge/internal/proto/controlproto"
+	"github.com/centrifugal/centrifuge/internal/uuid"
+)
+
+// ErrDisconnectScheduleNotSupported returned when disconnect scheduled but
+// engine does not implement DisconnectScheduler.
+var ErrDisconnectScheduleNotSupported = errors.New("scheduled disconnects not supported")
+
+// DisconnectOptions define some fields to alter behaviour of user disconnect.
+type DisconnectOptions struct {
+	// Disconnect is sent to user connections. DisconnectForceNoReconnect
+	// used if not set.
+	Disconnect *Disconnect
+	// ClientWhitelist contains IDs of user connections which must not be
+	// disconnected.
+	ClientWhitelist []string
+}
+
+// DisconnectOption is a type to represent various disconnect options.
+type DisconnectOption func(*DisconnectOptions)
+
+// WithDisconnect allows to set custom Disconnect sent to user connections.
+func WithDisconnect(disconnect *Disconnect) DisconnectOption {
+	return func(opts *DisconnectOptions) {
+		opts.Disconnect = disconnect
+	}
+}
+
+// WithReconnect allows to set whether user connections are advised to
+// reconnect: DisconnectForceReconnect sent to them if true,
+// DisconnectForceNoReconnect otherwise.
+func WithReconnect(reconnect bool) DisconnectOption {
+	return func(opts *DisconnectOptions) {
+		if reconnect {
+			opts.Disconnect = DisconnectForceReconnect
+		} else {
+			opts.Disconnect = DisconnectForceNoReconnect
+		}
+	}
+}
+
+// WithClientWhitelist allows to keep connections with given IDs when
+// disconnecting user, for example current connection of user which
+// signed out on other devices.
+func WithClientWhitelist(whitelist []string) DisconnectOption {
+	return func(opts *DisconnectOptions) {
+		opts.ClientWhitelist = whitelist
+	}
+}
+
+// ScheduledDisconnect is a disconnect of user connections waiting to be
+// executed at scheduled time.
+type ScheduledDisconnect struct {
+	// ID is a unique identifier of scheduled disconnect.
+	ID string `json:"id"`
+	// User to disconnect.
+	User string `json:"user"`
+	// Time is a Unix time in milliseconds when user must be disconnected.
+	Time int64 `json:"time"`
+	// Code is a disconnect code sent to user connections.
+	Code int `json:"code"`
+	// Reason is a disconnect reason sent to user connections.
+	Reason string `json:"reason"`
+	// Reconnect is a reconnect advice sent to user connections.
+	Reconnect bool `json:"reconnect,omitempty"`
+}
+
+// DisconnectScheduler keeps scheduled disconnects. It's an optional part of
+// Engine, shared implementation allows to schedule disconnect on any node
+// and execute it from one of running nodes so connections are closed on
+// whichever node they are.
+type DisconnectScheduler interface {
+	// AddScheduledDisconnect saves disconnect to execute later.
+	AddScheduledDisconnect(d *ScheduledDisconnect) error
+	// PopScheduledDisconnects removes and returns up to limit disconnects
+	// scheduled not later than now. Each scheduled disconnect must be
+	// returned only once among all nodes.
+	PopScheduledDisconnects(now time.Time, limit int) ([]*ScheduledDisconnect, error)
+}
+
+// SetDisconnectScheduler allows to set DisconnectScheduler to use. Must be
+// called before Node.Run.
+func (n *Node) SetDisconnectScheduler(s DisconnectScheduler) {
+	n.disconnectScheduler = s
+}
+
+// ScheduleDisconnect disconnects all user connections at time t on all
+// nodes. This is useful for session length policies. Disconnect scheduled
+// for past time is executed immediately.
+func (n *Node) ScheduleDisconnect(user string, t time.Time, opts ...DisconnectOption) error {
+	if n.disconnectScheduler == nil {
+		return ErrDisconnectScheduleNotSupported
+	}
+	disconnectOpts := &DisconnectOptions{}
+	for _, opt := range opts {
+		opt(disconnectOpts)
+	}
+	disconnect := disconnectOpts.Disconnect
+	if disconnect == nil {
+		disconnect = DisconnectForceNoReconnect
+	}
+	actionCount.WithLabelValues("schedule_disconnect").Inc()
+	return n.disconnectScheduler.AddScheduledDisconnect(&ScheduledDisconnect{
+		ID:        uuid.Must(uuid.NewV4()).String(),
+		User:      user,
+		Time:      t.UnixNano() / int64(time.Millisecond),
+		Code:      disconnect.Code,
+		Reason:    disconnect.Reason,
+		Reconnect: disconnect.Reconnect,
+	})
+}
+
+// disconnectScheduled disconnects users with due scheduled disconnects.
+// Disconnects failed to execute are rescheduled and retried on next check.
+func (n *Node) disconnectScheduled(now time.Time) {
+	for {
+		failed := false
+		disconnects, err := n.disconnectScheduler.PopScheduledDisconnects(now, scheduledBatchLimit)
+		if err != nil {
+			n.logger.log(newLogEntry(LogLevelError, "error getting scheduled disconnects", map[string]interface{}{"error": err.Error()}))
+			return
+		}
+		for _, d := range disconnects {
+			disconnect := &Disconnect{
+				Code:      d.Code,
+				Reason:    d.Reason,
+				Reconnect: d.Reconnect,
+			}
+			if err := n.disconnectUser(d.User, disconnect, nil); err != nil {
+				n.logger.log(newLogEntry(LogLevelError, "error executing scheduled disconnect", map[string]interface{}{"user": d.User, "error": err.Error()}))
+				failed = true
+				// Return disconnect back to retry on next check.
+				if err := n.disconnectScheduler.AddScheduledDisconnect(d); err != nil {
+					n.logger.log(newLogEntry(LogLevelError, "error rescheduling disconnect", map[string]interface{}{"user": d.User, "error": err.Error()}))
+				}
+			}
+		}
+		if failed || len(disconnects) < scheduledBatchLimit {
+			// Rescheduled disconnects are due already so popping again
+			// would return them back at once.
+			return
+		}
+	}
+}
+
+// DemandRefresh makes all user connections on all nodes refresh their
+// credentials. If refresh handler set it's called immediately for each
+// connection refreshed on server side. Other connections (including ones
+// connected with ConnectReply.ClientSideRefresh) receive refresh demand push
+// and must send refresh command with new token during
+// ClientExpiredCloseDelay or they will be disconnected with
+// DisconnectExpired.
+func (n *Node) DemandRefresh(user string) error {
+	actionCount.WithLabelValues("demand_refresh").Inc()
+	if err := n.hub.demandRefresh(user); err != nil {
+		return err
+	}
+	return n.pubRefresh(user)
+}
+
+// pubRefresh publishes refresh demand control message to all nodes.
+func (n *Node) pubRefresh(user string) error {
+	refresh := &controlproto.Refresh{
+		User: user,
+	}
+	params, _ := n.controlEncoder.EncodeRefresh(refresh)
+	cmd := &controlproto.Command{
+		UID:    n.uid,
+		Method: controlproto.MethodTypeRefresh,
+		Params: params,
+	}
+	return n.publishControl(cmd)
+}
+
+// demandRefresh makes connection refresh its credentials.
+func (c *Client) demandRefresh() error {
+	c.mu.RLock()
+	closed := c.closed
+	authenticated := c.authenticated
+	c.mu.RUnlock()
+	if closed || !authenticated {
+		return nil
+	}
+
+	c.mu.RLock()
+	serverSideRefresh := c.node.eventHub.refreshHandler != nil && !c.clientSideRefresh
+	c.mu.RUnlock()
+
+	if serverSideRefresh {
+		c.expire()
+		return nil
+	}
+
+	config := c.node.Config()
+	closeDelay := config.ClientExpiredCloseDelay
+
+	c.mu.Lock()
+	c.exp = time.Now().Unix()
+	c.mu.Unlock()
+	c.node.expirations.add(c, time.Now().Add(closeDelay))
+
+	pushEncoder := proto.GetPushEncoder(c.transport.Encoding())
+	data, err := pushEncoder.EncodeRefreshDemand(&proto.RefreshDemand{TTL: uint32(closeDelay / time.Second)})
+	if err != nil {
+		return err
+	}
+	result, err := pushEncoder.Encode(proto.NewRefreshDemandPush(data))
+	if err != nil {
+		return err
+	}
+	reply := newPreparedReply(&proto.Reply{
+		Result: result,
+	}, c.transport.Encoding())
+	return c.transportSend(reply)
+}
@@ -0,0 +1,285 @@
+package centrifuge
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/centrifugal/centrifuge/internal/proto/controlproto"
+	"github.com/stretchr/testify/assert"
+)
+
+func waitTransportClosed(t *testing.T, transport *testTransport) *Disconnect {
+	for i := 0; i < 300; i++ {
+		transport.mu.Lock()
+		closed := transport.closed
+		disconnect := transport.disconnect
+		transport.mu.Unlock()
+		if closed {
+			return disconnect
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("transport not closed")
+	return nil
+}
+
+func TestMemoryEngineScheduledDisconnects(t *testing.T) {
+	e := testMemoryEngine()
+	now := time.Now()
+	nowMs := now.UnixNano() / int64(time.Millisecond)
+	assert.NoError(t, e.AddScheduledDisconnect(&ScheduledDisconnect{ID: "2", User: "42", Time: nowMs + 2000}))
+	assert.NoError(t, e.AddScheduledDisconnect(&ScheduledDisconnect{ID: "1", User: "42", Time: nowMs + 1000}))
+
+	disconnects, err := e.PopScheduledDisconnects(now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, disconnects, 0)
+
+	disconnects, err = e.PopScheduledDisconnects(now.Add(2*time.Second), 10)
+	assert.NoError(t, err)
+	assert.Len(t, disconnects, 2)
+	assert.Equal(t, "1", disconnects[0].ID)
+	assert.Equal(t, "2", disconnects[1].ID)
+
+	disconnects, err = e.PopScheduledDisconnects(now.Add(2*time.Second), 10)
+	assert.NoError(t, err)
+	assert.Len(t, disconnects, 0)
+}
+
+func TestNodeScheduleDisconnect(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	sessionEnd := &Disconnect{Code: 4000, Reason: "session ended", Reconnect: false}
+	assert.NoError(t, node.ScheduleDisconnect("42", time.Now().Add(time.Hour), WithDisconnect(sessionEnd)))
+	node.disconnectScheduled(time.Now())
+	transport.mu.Lock()
+	assert.False(t, transport.closed)
+	transport.mu.Unlock()
+
+	node.disconnectScheduled(time.Now().Add(2 * time.Hour))
+	disconnect := waitTransportClosed(t, transport)
+	assert.Equal(t, 4000, disconnect.Code)
+	assert.Equal(t, "session ended", disconnect.Reason)
+
+	node.SetDisconnectScheduler(nil)
+	assert.Equal(t, ErrDisconnectScheduleNotSupported, node.ScheduleDisconnect("42", time.Now()))
+}
+
+type failingControlBroker struct {
+	*MemoryEngine
+}
+
+func (b *failingControlBroker) PublishControl(data []byte) error {
+	return errors.New("broker unavailable")
+}
+
+type countingDisconnectScheduler struct {
+	DisconnectScheduler
+	pops int
+}
+
+func (s *countingDisconnectScheduler) PopScheduledDisconnects(now time.Time, limit int) ([]*ScheduledDisconnect, error) {
+	s.pops++
+	return s.DisconnectScheduler.PopScheduledDisconnects(now, limit)
+}
+
+func TestNodeDisconnectScheduledFailure(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	e := testMemoryEngine()
+	node.SetBroker(&failingControlBroker{e})
+	scheduler := &countingDisconnectScheduler{DisconnectScheduler: e}
+	node.SetDisconnectScheduler(scheduler)
+
+	nowMs := time.Now().UnixNano() / int64(time.Millisecond)
+	for i := 0; i < scheduledBatchLimit; i++ {
+		assert.NoError(t, scheduler.AddScheduledDisconnect(&ScheduledDisconnect{ID: strconv.Itoa(i), User: "42", Time: nowMs}))
+	}
+
+	node.disconnectScheduled(time.Now())
+	// Failed batch is not popped again until next check.
+	assert.Equal(t, 1, scheduler.pops)
+	disconnects, err := e.PopScheduledDisconnects(time.Now(), 2*scheduledBatchLimit)
+	assert.NoError(t, err)
+	assert.Len(t, disconnects, scheduledBatchLimit)
+}
+
+func TestNodeDisconnectControl(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	params, _ := node.controlEncoder.EncodeDisconnect(&controlproto.Disconnect{User: "42", Code: 4000, Reason: "session ended"})
+	data, _ := node.controlEncoder.EncodeCommand(&controlproto.Command{UID: "other", Method: controlproto.MethodTypeDisconnect, Params: params})
+	assert.NoError(t, node.handleControl(data))
+	disconnect := waitTransportClosed(t, transport)
+	assert.Equal(t, 4000, disconnect.Code)
+	assert.Equal(t, "session ended", disconnect.Reason)
+}
+
+func TestNodeDisconnectWithOptions(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	transport := newTestTransport()
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	keptTransport := newTestTransport()
+	kept, _ := newClient(newCtx, node, keptTransport)
+	connectClient(t, kept)
+
+	banned := &Disconnect{Code: 4001, Reason: "banned", Reconnect: false}
+	assert.NoError(t, node.Disconnect("42", WithDisconnect(banned), WithClientWhitelist([]string{kept.ID()})))
+	disconnect := waitTransportClosed(t, transport)
+	assert.Equal(t, 4001, disconnect.Code)
+	assert.Equal(t, "banned", disconnect.Reason)
+	time.Sleep(10 * time.Millisecond)
+	keptTransport.mu.Lock()
+	assert.False(t, keptTransport.closed)
+	keptTransport.mu.Unlock()
+
+	assert.NoError(t, node.Disconnect("42", WithReconnect(true)))
+	disconnect = waitTransportClosed(t, keptTransport)
+	assert.Equal(t, DisconnectForceReconnect.Reason, disconnect.Reason)
+	assert.True(t, disconnect.Reconnect)
+}
+
+func TestNodeDisconnectControlWhitelist(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	newCtx := SetCredentials(context.Background(), &Credentials{UserID: "42"})
+	transport := newTestTransport()
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+	keptTransport := newTestTransport()
+	kept, _ := newClient(newCtx, node, keptTransport)
+	connectClient(t, kept)
+
+	params, _ := node.controlEncoder.EncodeDisconnect(&controlproto.Disconnect{User: "42", Code: 4001, Reason: "banned", Whitelist: []string{kept.ID()}})
+	data, _ := node.controlEncoder.EncodeCommand(&controlproto.Command{UID: "other", Method: controlproto.MethodTypeDisconnect, Params: params})
+	assert.NoError(t, node.handleControl(data))
+	disconnect := waitTransportClosed(t, transport)
+	assert.Equal(t, 4001, disconnect.Code)
+	time.Sleep(10 * time.Millisecond)
+	keptTransport.mu.Lock()
+	assert.False(t, keptTransport.closed)
+	keptTransport.mu.Unlock()
+}
+
+func TestNodeUnsubscribeControl(t *testing.T) {
+	node := nodeWithMemoryEngine()
+
+	client, _ := newClient(SetCredentials(context.Background(), &Credentials{UserID: "42"}), node, newTestTransport())
+	connectClient(t, client)
+	subscribeClient(t, client, "test")
+	assert.Equal(t, 1, node.hub.NumSubscribers("test"))
+
+	params, _ := node.controlEncoder.EncodeUnsubscribe(&controlproto.Unsubscribe{User: "42", Channel: "test"})
+	data, _ := node.controlEncoder.EncodeCommand(&controlproto.Command{UID: "other", Method: controlproto.MethodTypeUnsubscribe, Params: params})
+	assert.NoError(t, node.handleControl(data))
+	assert.Equal(t, 0, node.hub.NumSubscribers("test"))
+	assert.Equal(t, 0, len(client.Channels()))
+}
+
+func TestNodeDemandRefresh(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientExpiredCloseDelay = 100 * time.Millisecond
+	assert.NoError(t, node.Reload(config))
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42"})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	assert.NoError(t, node.DemandRefresh("42"))
+	select {
+	case data := <-transport.sink:
+		assert.True(t, strings.Contains(string(data), `"type":6`))
+	case <-time.After(time.Second):
+		t.Fatal("refresh demand not delivered")
+	}
+
+	// Connection not refreshed in time.
+	disconnect := waitTransportClosed(t, transport)
+	assert.Equal(t, DisconnectExpired, disconnect)
+}
+
+func TestNodeDemandRefreshHandler(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	refreshed := make(chan struct{}, 1)
+	node.On().ClientRefresh(func(ctx context.Context, c *Client, e RefreshEvent) RefreshReply {
+		refreshed <- struct{}{}
+		return RefreshReply{ExpireAt: time.Now().Unix() + 60}
+	})
+
+	transport := newTestTransport()
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42", ExpireAt: time.Now().Unix() + 60})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	params, _ := node.controlEncoder.EncodeRefresh(&controlproto.Refresh{User: "42"})
+	data, _ := node.controlEncoder.EncodeCommand(&controlproto.Command{UID: "other", Method: controlproto.MethodTypeRefresh, Params: params})
+	assert.NoError(t, node.handleControl(data))
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("refresh handler not called")
+	}
+	transport.mu.Lock()
+	assert.False(t, transport.closed)
+	transport.mu.Unlock()
+}
+
+func TestNodeDemandRefreshClientSideRefresh(t *testing.T) {
+	node := nodeWithMemoryEngine()
+	config := node.Config()
+	config.ClientExpiredCloseDelay = 100 * time.Millisecond
+	assert.NoError(t, node.Reload(config))
+	node.On().ClientConnecting(func(ctx context.Context, t Transport, e ConnectEvent) ConnectReply {
+		return ConnectReply{ClientSideRefresh: true}
+	})
+	refreshed := make(chan struct{}, 1)
+	node.On().ClientRefresh(func(ctx context.Context, c *Client, e RefreshEvent) RefreshReply {
+		refreshed <- struct{}{}
+		return RefreshReply{ExpireAt: time.Now().Unix() + 60}
+	})
+
+	transport := newTestTransport()
+	transport.sink = make(chan []byte, 100)
+	ctx := context.Background()
+	newCtx := SetCredentials(ctx, &Credentials{UserID: "42", ExpireAt: time.Now().Unix() + 60})
+	client, _ := newClient(newCtx, node, transport)
+	connectClient(t, client)
+
+	// Client refreshing itself gets refresh demand instead of server-side
+	// refresh handler call.
+	assert.NoError(t, node.DemandRefresh("42"))
+	waitSinkContains(t, transport, `"type":6`)
+	select {
+	case <-refreshed:
+		t.Fatal("refresh handler called for client-side refresh connection")
+	default:
+	}
+	client.mu.RLock()
+	assert.True(t, client.exp <= time.Now().Unix())
+	client.mu.RUnlock()
+
+	disconnect := waitTransportClosed(t, transport)
+	assert.Equal(t, DisconnectExpired, disconnect)
+}
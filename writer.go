@@ -2,14 +2,51 @@ package centrifuge
 
 import (
 	"sync"
+	"time"
 
 	"github.com/centrifugal/centrifuge/internal/queue"
 )
 
+// QueuePolicy defines what happens when client's message queue exceeds
+// Config.ClientQueueMaxSize.
+type QueuePolicy int
+
+const (
+	// QueuePolicyDisconnect closes slow client connection with DisconnectSlow.
+	QueuePolicyDisconnect QueuePolicy = iota
+	// QueuePolicyDropOldest drops oldest queued publications until queue
+	// fits limit. Client disconnected if dropping publications is not enough.
+	QueuePolicyDropOldest
+	// QueuePolicyCoalesce keeps only latest queued publication of channel
+	// new publication belongs to. Client disconnected if queue still exceeds
+	// limit after that.
+	QueuePolicyCoalesce
+)
+
+func (p QueuePolicy) String() string {
+	switch p {
+	case QueuePolicyDropOldest:
+		return "drop_oldest"
+	case QueuePolicyCoalesce:
+		return "coalesce"
+	default:
+		return "disconnect"
+	}
+}
+
 type writerConfig struct {
-	WriteFn            func(...[]byte) error
-	MaxQueueSize       int
+	WriteFn      func(...[]byte) error
+	MaxQueueSize int
+	// QueuePolicy applied when queue size exceeds MaxQueueSize.
+	QueuePolicy QueuePolicy
+	// DropFn called with number of messages dropped by QueuePolicy.
+	DropFn func(int)
+	// MaxMessagesInFrame limits number of queued messages written at once,
+	// 0 means defaultMaxMessagesInFrame, negative value means no limit.
 	MaxMessagesInFrame int
+	// WriteDelay is a time writer waits after first message arrived to
+	// collect more messages into one write.
+	WriteDelay time.Duration
 }
 
 // writer helps to manage per-connection message queue.
@@ -50,9 +87,17 @@ func (w *writer) runWriteRoutine() {
 			continue
 		}
 
+		if w.config.WriteDelay > 0 {
+			time.Sleep(w.config.WriteDelay)
+		}
+
 		var writeErr error
 
 		messageCount := w.messages.Len()
+		maxMessagesInFrame := maxMessagesInFrame
+		if maxMessagesInFrame < 0 {
+			maxMessagesInFrame = messageCount + 1
+		}
 		if maxMessagesInFrame > 1 && messageCount > 0 {
 			// There are several more messages left in queue, try to send them in single frame,
 			// but no more than maxMessagesInFrame.
@@ -101,16 +146,55 @@ func (w *writer) runWriteRoutine() {
 }
 
 func (w *writer) enqueue(data []byte) *Disconnect {
-	ok := w.messages.Add(data)
+	return w.enqueueChannel("", data, 0)
+}
+
+// enqueueChannel queues publication of channel ch which can be dropped by
+// QueuePolicy when queue overflows. Publications with positive priority
+// are allowed to exceed MaxQueueSize up to MaxQueueSize*(1+priority) so they
+// get through when normal priority messages already overflow queue.
+func (w *writer) enqueueChannel(ch string, data []byte, priority int) *Disconnect {
+	ok := w.messages.AddWithKey(data, ch)
 	if !ok {
 		return DisconnectNormal
 	}
-	if w.config.MaxQueueSize > 0 && w.messages.Size() > w.config.MaxQueueSize {
+	maxSize := w.config.MaxQueueSize
+	if maxSize > 0 && priority > 0 {
+		maxSize *= 1 + priority
+	}
+	if maxSize <= 0 || w.messages.Size() <= maxSize {
+		return nil
+	}
+	var dropped int
+	switch w.config.QueuePolicy {
+	case QueuePolicyDropOldest:
+		dropped = w.messages.DropOldest(maxSize)
+	case QueuePolicyCoalesce:
+		dropped = w.messages.Coalesce(ch)
+	}
+	if dropped > 0 && w.config.DropFn != nil {
+		w.config.DropFn(dropped)
+	}
+	if w.messages.Size() > maxSize {
 		return DisconnectSlow
 	}
 	return nil
 }
 
+// size returns size in bytes of messages waiting to be written.
+func (w *writer) size() int {
+	return w.messages.Size()
+}
+
+// overloaded checks whether queue is too large to accept message with
+// provided negative priority.
+func (w *writer) overloaded(priority int) bool {
+	if priority >= 0 || w.config.MaxQueueSize <= 0 {
+		return false
+	}
+	return w.messages.Size() > w.config.MaxQueueSize/(1-priority)
+}
+
 func (w *writer) close() error {
 	w.mu.Lock()
 	if w.closed {
@@ -5,6 +5,7 @@ import (
 	"os"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -133,3 +134,118 @@ func TestWriterDisconnect(t *testing.T) {
 	disconnect := w.enqueue([]byte("test"))
 	assert.NotNil(t, disconnect)
 }
+
+// newBlockedWriter creates writer which write routine took first message
+// from queue and blocks writing it until returned release func called, so
+// next messages stay in queue.
+func newBlockedWriter(t *testing.T, config writerConfig) (*writer, func()) {
+	unblock := make(chan struct{})
+	sink := make(chan struct{}, 1)
+	config.WriteFn = func(...[]byte) error {
+		select {
+		case sink <- struct{}{}:
+		default:
+		}
+		<-unblock
+		return nil
+	}
+	w := newWriter(config)
+	assert.Nil(t, w.enqueue([]byte("1234")))
+	select {
+	case <-sink:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for write")
+	}
+	return w, func() {
+		close(unblock)
+		w.close()
+	}
+}
+
+func TestWriterOverloaded(t *testing.T) {
+	w, release := newBlockedWriter(t, writerConfig{MaxQueueSize: 12})
+	defer release()
+	assert.False(t, w.overloaded(-1))
+
+	assert.Nil(t, w.enqueue([]byte("1234")))
+	assert.False(t, w.overloaded(-1))
+	assert.True(t, w.overloaded(-3))
+
+	assert.Nil(t, w.enqueue([]byte("1234")))
+	assert.True(t, w.overloaded(-1))
+	assert.False(t, w.overloaded(0))
+	assert.False(t, w.overloaded(1))
+}
+
+func TestWriterWriteDelay(t *testing.T) {
+	writes := make(chan int, 10)
+	w := newWriter(writerConfig{
+		MaxMessagesInFrame: -1,
+		WriteDelay:         50 * time.Millisecond,
+		WriteFn: func(bufs ...[]byte) error {
+			writes <- len(bufs)
+			return nil
+		},
+	})
+	defer w.close()
+	for i := 0; i < 6; i++ {
+		assert.Nil(t, w.enqueue([]byte("test")))
+	}
+	select {
+	case num := <-writes:
+		assert.Equal(t, 6, num)
+	case <-time.After(time.Second):
+		t.Fatal("messages not written")
+	}
+}
+
+func TestWriterQueuePolicy(t *testing.T) {
+	testCases := []struct {
+		policy     QueuePolicy
+		dropped    int
+		disconnect *Disconnect
+	}{
+		{QueuePolicyDisconnect, 0, DisconnectSlow},
+		{QueuePolicyDropOldest, 1, nil},
+		{QueuePolicyCoalesce, 1, nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.policy.String(), func(t *testing.T) {
+			var dropped int
+			w, release := newBlockedWriter(t, writerConfig{
+				MaxQueueSize: 8,
+				QueuePolicy:  tc.policy,
+				DropFn: func(num int) {
+					dropped += num
+				},
+			})
+			defer release()
+
+			assert.Nil(t, w.enqueueChannel("a", []byte("1234"), 0))
+			assert.Nil(t, w.enqueueChannel("b", []byte("12"), 0))
+			assert.Equal(t, tc.disconnect, w.enqueueChannel("a", []byte("1234"), 0))
+			assert.Equal(t, tc.dropped, dropped)
+		})
+	}
+}
+
+func TestWriterQueuePolicyNotEnough(t *testing.T) {
+	w, release := newBlockedWriter(t, writerConfig{
+		MaxQueueSize: 4,
+		QueuePolicy:  QueuePolicyDropOldest,
+	})
+	defer release()
+	assert.Nil(t, w.enqueueChannel("a", []byte("12"), 0))
+	// Messages without channel can't be dropped.
+	assert.Equal(t, DisconnectSlow, w.enqueue([]byte("123456")))
+}
+
+func TestWriterPositivePriority(t *testing.T) {
+	w, release := newBlockedWriter(t, writerConfig{MaxQueueSize: 4})
+	defer release()
+
+	assert.Nil(t, w.enqueueChannel("a", []byte("1234"), 0))
+	// Priority publications queued over limit normal ones disconnect at.
+	assert.Nil(t, w.enqueueChannel("b", []byte("1234"), 1))
+	assert.Equal(t, DisconnectSlow, w.enqueueChannel("b", []byte("1234"), 1))
+}